@@ -42,6 +42,7 @@ class TutorAdmin(admin.ModelAdmin):
             response = requests.post(
                 f"{settings.SEARCH_SERVICE_URL}/admin/sync",
                 json=data,
+                headers={"Authorization": f"Bearer {settings.SEARCH_ADMIN_API_KEY}"},
                 timeout=30,
             )
             if response.ok:
@@ -39,6 +39,7 @@ class Command(BaseCommand):
                 response = requests.post(
                     f"{settings.SEARCH_SERVICE_URL}/admin/sync",
                     json=data,
+                    headers={"Authorization": f"Bearer {settings.SEARCH_ADMIN_API_KEY}"},
                     timeout=60,
                 )
 
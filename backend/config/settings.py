@@ -256,6 +256,8 @@ CELERY_BROKER_CONNECTION_RETRY_ON_STARTUP = True
 
 # Search Service Configuration
 SEARCH_SERVICE_URL = env("SEARCH_SERVICE_URL", default="http://search-service:8080")
+# Bearer token for the search service's /admin endpoints (its ADMIN_API_KEY)
+SEARCH_ADMIN_API_KEY = env("SEARCH_ADMIN_API_KEY", default="")
 
 
 # Kafka Configuration
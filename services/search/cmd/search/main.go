@@ -2,128 +2,583 @@ package main
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
-	"strings"
+	"slices"
 	"syscall"
-	"time"
+	// Tutor and student time zones must resolve in images without tzdata.
+	_ "time/tzdata"
 
+	kafkago "github.com/segmentio/kafka-go"
+	grpclib "google.golang.org/grpc"
+
+	"search/internal/activity"
 	"search/internal/api"
+	"search/internal/audit"
+	"search/internal/auth"
+	"search/internal/bootstrap"
+	"search/internal/config"
+	"search/internal/django"
+	"search/internal/drift"
+	"search/internal/feedback"
+	searchgrpc "search/internal/grpc"
 	"search/internal/handler"
 	"search/internal/kafka"
+	"search/internal/lease"
+	"search/internal/limiter"
 	"search/internal/opensearch"
+	"search/internal/outbox"
+	"search/internal/port"
+	"search/internal/reindex"
+	"search/internal/relevance"
+	"search/internal/schedule"
+	"search/internal/shadow"
+	"search/internal/store"
+	"search/internal/tenant"
+	"search/internal/watermark"
 )
 
+// activityBufferSize is how many events a slow /admin/events client may fall
+// behind before the oldest are dropped.
+const activityBufferSize = 256
+
 func main() {
+	// Until the configuration is loaded, logs go out as JSON at info level.
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
 	}))
+
+	cfg, err := config.Load()
+	if err != nil {
+		logger.Error("Failed to load configuration", "error", err)
+		os.Exit(1)
+	}
+
+	logger = slog.New(cfg.Log.NewHandler(os.Stdout, cfg.Log.SlogLevel()))
 	slog.SetDefault(logger)
+	kafkaLogger := componentLogger(cfg.Log, config.ComponentKafka)
+	osLogger := componentLogger(cfg.Log, config.ComponentOpenSearch)
+	apiLogger := componentLogger(cfg.Log, config.ComponentAPI)
+
+	logger.Info("Starting search service", "config", cfg)
 
-	opensearchURL := getEnv("OPENSEARCH_URL", "http://localhost:9200")
-	port := getEnv("PORT", "8080")
-	corsOrigins := getEnv("CORS_ALLOWED_ORIGINS", "*")
-	kafkaBrokers := getEnv("KAFKA_BROKERS", "localhost:9092")
-	kafkaTopic := getEnv("KAFKA_TOPIC", "tutor-events")
-	kafkaGroupID := getEnv("KAFKA_GROUP_ID", "search-service")
-
-	logger.Info("Starting search service",
-		"opensearch_url", opensearchURL,
-		"port", port,
-		"cors_origins", corsOrigins,
-		"kafka_brokers", kafkaBrokers,
-		"kafka_topic", kafkaTopic,
-	)
-
-	osClient, err := opensearch.NewClient(opensearchURL, logger)
+	// Bind before waiting on any dependency so a port conflict fails at once.
+	lis, err := bootstrap.Listen(cfg.Server.Port)
 	if err != nil {
-		logger.Error("Failed to create OpenSearch client", "error", err)
+		logger.Error("Failed to bind HTTP port", "port", cfg.Server.Port, "error", err)
 		os.Exit(1)
 	}
+	logger.Info("HTTP listener bound", "addr", lis.Addr().String())
+
+	// Left nil when GRPC_PORT is unset.
+	var grpcLis net.Listener
+	if cfg.Server.GRPCPort != 0 {
+		grpcLis, err = bootstrap.Listen(cfg.Server.GRPCPort)
+		if err != nil {
+			logger.Error("Failed to bind gRPC port", "port", cfg.Server.GRPCPort, "error", err)
+			os.Exit(1)
+		}
+		logger.Info("gRPC listener bound", "addr", grpcLis.Addr().String())
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	if err := waitForOpenSearch(ctx, osClient, logger); err != nil {
-		logger.Error("OpenSearch connection failed", "error", err)
+	// Validated by config.Load.
+	exp, _ := cfg.Experiment.Load()
+	clientOpts := []opensearch.ClientOption{
+		opensearch.WithIndexSettings(cfg.OpenSearch.IndexSettings()),
+		opensearch.WithIndexName(cfg.OpenSearch.Index),
+		opensearch.WithRetry(cfg.OpenSearch.MaxRetries, cfg.OpenSearch.RetryBackoff),
+		opensearch.WithRefreshPolicy(opensearch.RefreshPolicy(cfg.OpenSearch.Refresh)),
+		opensearch.WithPing(opensearch.PingMode(cfg.OpenSearch.PingMode), cfg.OpenSearch.PingTimeout),
+		opensearch.WithPopularityWeights(cfg.Indexing.Popularity),
+		opensearch.WithTransportSettings(opensearch.TransportSettings{
+			MaxIdleConns:          cfg.OpenSearch.MaxIdleConns,
+			MaxIdleConnsPerHost:   cfg.OpenSearch.MaxIdleConnsPerHost,
+			IdleConnTimeout:       cfg.OpenSearch.IdleConnTimeout,
+			TLSHandshakeTimeout:   cfg.OpenSearch.TLSHandshakeTimeout,
+			ResponseHeaderTimeout: cfg.OpenSearch.ResponseHeaderTimeout,
+		}),
+	}
+	if cfg.OpenSearch.Username != "" {
+		clientOpts = append(clientOpts, opensearch.WithBasicAuth(cfg.OpenSearch.Username, cfg.OpenSearch.Password))
+	}
+	// Shared by every write, so the Kafka consumer, syncs and reindexes
+	// back off together when OpenSearch answers 429.
+	var indexRate *limiter.Adaptive
+	if cfg.OpenSearch.IndexRate > 0 {
+		indexRate = limiter.NewAdaptive(cfg.OpenSearch.IndexRate, osLogger)
+		clientOpts = append(clientOpts, opensearch.WithIndexingRate(indexRate))
+	}
+	if cfg.OpenSearch.IndexExperiments {
+		clientOpts = append(clientOpts, opensearch.WithIndexExperiments())
+	}
+	if cfg.OpenSearch.IndexAliases {
+		clientOpts = append(clientOpts, opensearch.WithIndexAliases())
+	}
+	var variants opensearch.RelevanceRegistry
+	if exp != nil {
+		logger.Info("Relevance experiment enabled", "experiment", exp.Name())
+		variants = exp.Relevance()
+	}
+	// Searches read the registry per request, so RELEVANCE_CONFIG_FILE can
+	// change the default weights without a restart.
+	relevanceSource, err := relevance.New(cfg.Relevance.File, variants, cfg.Search.PinnedBadge, logger)
+	if err != nil {
+		logger.Error("Failed to load relevance config", "error", err)
 		os.Exit(1)
 	}
+	clientOpts = append(clientOpts, opensearch.WithRelevanceSource(relevanceSource))
+	if cfg.Relevance.File != "" {
+		go relevanceSource.Watch(ctx, cfg.Relevance.PollInterval)
+		logger.Info("Watching relevance config", "file", cfg.Relevance.File, "poll_interval", cfg.Relevance.PollInterval)
+	}
+
+	var osClient port.SearchClient
+	// Holds the job lease; the memory backend only coordinates within
+	// this process.
+	var locks port.LockStore
+	// Left nil on the memory backend, which opens no connections.
+	var connections api.ConnectionReporter
+	if cfg.Search.Backend == config.BackendMemory {
+		logger.Warn("Using in-memory search backend; the index is empty at startup and lost on exit")
+		client := opensearch.NewMemoryClient(opensearch.WithMemoryPopularityWeights(cfg.Indexing.Popularity))
+		osClient = client
+		locks = client
+	} else {
+		client, err := opensearch.NewClient(cfg.OpenSearch.URL, osLogger, clientOpts...)
+		if err != nil {
+			logger.Error("Failed to create OpenSearch client", "error", err)
+			os.Exit(1)
+		}
+		osClient = client
+		locks = client
+		connections = client
+	}
+
+	// Wraps the backend below the limiter, so a shadow search does not
+	// take a request's concurrency slot; config.Load rejects it on the
+	// memory backend.
+	var shadowClient *shadow.Client
+	if cfg.Shadow.File != "" {
+		candidateSource, err := relevance.New(cfg.Shadow.File, nil, cfg.Search.PinnedBadge, logger)
+		if err != nil {
+			logger.Error("Failed to load shadow relevance config", "error", err)
+			os.Exit(1)
+		}
+		candidate, err := opensearch.NewClient(cfg.OpenSearch.URL, osLogger,
+			append(slices.Clone(clientOpts), opensearch.WithRelevanceSource(candidateSource))...)
+		if err != nil {
+			logger.Error("Failed to create shadow OpenSearch client", "error", err)
+			os.Exit(1)
+		}
+		shadowClient = shadow.New(osClient, candidate, cfg.Shadow.SamplePercent, logger)
+		osClient = shadowClient
+		logger.Info("Shadow reads enabled", "relevance_file", cfg.Shadow.File, "sample_percent", cfg.Shadow.SamplePercent)
+	}
+
+	// Validated by config.Load.
+	tenants, _ := cfg.Tenant.Registry()
+	if tenants == nil {
+		tenants = tenant.Single(cfg.OpenSearch.Index)
+	}
+
+	// Parts that need the index are started as bootstrap OnReady hooks.
+	bootOpts := []bootstrap.Option{bootstrap.WithMode(cfg.Server.StartupMode)}
+
+	hub := activity.NewHub(activityBufferSize)
+
+	// Validated by config.Load.
+	subjects, _ := cfg.Indexing.SubjectCatalog()
+	translations, _ := cfg.Indexing.Translations()
+	scrubber, _ := cfg.Indexing.Scrubber()
+
+	var auditOpts []audit.Option
+	if cfg.Admin.AuditLogFile != "" {
+		f, err := os.OpenFile(cfg.Admin.AuditLogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			logger.Error("Failed to open audit log file", "path", cfg.Admin.AuditLogFile, "error", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		auditOpts = append(auditOpts, audit.WithWriter(f))
+	}
+	auditLog := audit.New(logger, cfg.Admin.AuditLogSize, auditOpts...)
+
+	// Left nil without DJANGO_API_URL; reindexing and backfills need it.
+	var djangoClient *django.Client
+	if cfg.Django.APIURL != "" {
+		djangoClient = django.NewClient(cfg.Django.APIURL)
+	}
+
+	handlerOpts := []handler.Option{
+		handler.WithActivityHub(hub),
+		handler.WithTenants(tenants),
+		handler.WithMaxListItems(cfg.Indexing.MaxListItems),
+		handler.WithAvatarPolicy(cfg.Indexing.AvatarPolicy()),
+		handler.WithRatingMode(cfg.Indexing.RatingMode),
+		handler.WithSubjectCatalog(subjects),
+		handler.WithScrubber(scrubber),
+	}
+	if djangoClient != nil && cfg.Features.TutorBackfill {
+		handlerOpts = append(handlerOpts, handler.WithBackfill(djangoClient))
+	}
+	if cfg.Kafka.SkipUnchanged {
+		handlerOpts = append(handlerOpts, handler.WithSkipUnchanged(cfg.Kafka.UnchangedCacheSize))
+	}
+	if cfg.Kafka.StrictSequence {
+		handlerOpts = append(handlerOpts, handler.WithStrictSequence(cfg.Kafka.SequenceBufferSize, cfg.Kafka.SequenceTimeout))
+	}
+
+	// Left nil when the consumer is disabled: nothing advances it.
+	var indexWatermark api.WatermarkSource
+	var wmTracker *watermark.Tracker
+	if cfg.Features.KafkaConsumer {
+		var wmOpts []watermark.Option
+		if cfg.Kafka.WatermarkFile != "" {
+			wmOpts = append(wmOpts, watermark.WithStateFile(cfg.Kafka.WatermarkFile))
+		}
+		wm, err := watermark.New(wmOpts...)
+		if err != nil {
+			logger.Error("Failed to load event watermark", "path", cfg.Kafka.WatermarkFile, "error", err)
+			os.Exit(1)
+		}
+		indexWatermark, wmTracker = wm, wm
+		handlerOpts = append(handlerOpts, handler.WithWatermark(wm))
+		go wm.Run(ctx, watermark.DefaultSaveInterval, logger)
+	}
+
+	// Kafka events wait for a slot in their own pool, apart from HTTP traffic.
+	indexingClient := limiter.New(osClient, cfg.Indexing.MaxConcurrent)
+	eventHandler := handler.New(indexingClient, kafkaLogger, handlerOpts...)
+	go eventHandler.RunSequence(ctx)
+
+	// Left nil when the consumer is disabled so the router sees no pauser
+	// and /health does not check Kafka.
+	var pauser api.ConsumerPauser
+	var consumerOffsets api.ConsumerOffsets
+	var consumerStatus api.ConsumerStatusReporter
+	var kafkaChecker api.KafkaChecker
+	var eventTracker api.EventTracker
+	var snapshotTracker api.SnapshotTracker
+	var eventStats api.EventStatsReporter
+
+	if cfg.Features.KafkaConsumer {
+		var extraTopics []string
+		if cfg.Kafka.BookingTopic != "" {
+			extraTopics = append(extraTopics, cfg.Kafka.BookingTopic)
+		}
+		consumer := kafka.NewConsumer(kafka.Config{
+			Brokers:     cfg.Kafka.Brokers,
+			Topic:       cfg.Kafka.Topic,
+			ExtraTopics: extraTopics,
+			GroupID:     cfg.Kafka.GroupID,
+			StartOffset: kafkaStartOffset(cfg.Kafka.StartOffset),
+		}, eventHandler, kafkaLogger,
+			kafka.WithErrorHook(func(event *kafka.Event, err error) {
+				e := activity.Event{
+					Type:   activity.TypeConsumerError,
+					Source: activity.SourceKafka,
+					Error:  err.Error(),
+				}
+				if event != nil {
+					e.EventID = event.EventID
+				}
+				hub.Publish(e)
+			}),
+			kafka.WithMaxMessageBytes(cfg.Kafka.MaxMessageBytes),
+			kafka.WithHandleTimeout(cfg.Kafka.HandleTimeout),
+			kafka.WithIdleHeartbeat(cfg.Kafka.IdleHeartbeat),
+			kafka.WithBatching(cfg.Kafka.BatchSize, cfg.Kafka.BatchWait),
+		)
+
+		pauser = consumer
+		consumerOffsets = consumer
+		consumerStatus = consumer
+		eventTracker = eventHandler
+		snapshotTracker = eventHandler
+		eventStats = eventHandler
+		kafkaChecker = kafka.NewHealthChecker(cfg.Kafka.Brokers, cfg.Kafka.HealthGracePeriod, consumer.LastMessageAt)
+
+		bootOpts = append(bootOpts, bootstrap.WithOnReady(func(ctx context.Context) {
+			go func() {
+				if err := consumer.Start(ctx); err != nil {
+					kafkaLogger.Error("Kafka consumer error", "error", err)
+				}
+			}()
+		}))
+	} else {
+		logger.Info("Kafka consumer disabled")
+	}
+
+	// Left nil without DJANGO_API_URL so /admin/reindex stays informational.
+	var reindexJob api.ReindexJob
+	// Left nil unless scheduled reindexing runs under REINDEX_LEASE_TTL.
+	var leaseReporter api.LeaseReporter
+	if djangoClient != nil {
+		job := reindex.NewJob(djangoClient, osClient, logger,
+			reindex.WithSubjectCatalog(subjects),
+			reindex.WithScrubber(scrubber),
+		)
+		reindexJob = job
+
+		if cfg.Reindex.Schedule != "" {
+			// Validated by config.Load.
+			sched, _ := schedule.Parse(cfg.Reindex.Schedule)
+			var schedOpts []reindex.SchedulerOption
+			if cfg.Reindex.LeaseTTL > 0 {
+				holder := leaseHolder(cfg.Reindex.LeaseHolder)
+				jobLease := lease.New(locks, lease.JobsLock, holder, cfg.Reindex.LeaseTTL, logger)
+				leaseReporter = jobLease
+				schedOpts = append(schedOpts, reindex.WithLeader(jobLease))
+				bootOpts = append(bootOpts, bootstrap.WithOnReady(func(ctx context.Context) {
+					go jobLease.Run(ctx)
+				}))
+				logger.Info("Job lease enabled", "holder", holder, "ttl", cfg.Reindex.LeaseTTL)
+			}
+			scheduler := reindex.NewScheduler(sched, job, logger, schedOpts...)
+			bootOpts = append(bootOpts, bootstrap.WithOnReady(func(ctx context.Context) {
+				go scheduler.Run(ctx)
+			}))
+			logger.Info("Scheduled reindex enabled", "schedule", cfg.Reindex.Schedule)
+		}
+	}
+
+	// Assigned only when pacing is on, so a nil *limiter.Adaptive never
+	// becomes a non-nil interface.
+	var indexingRate api.RateReporter
+	if indexRate != nil {
+		indexingRate = indexRate
+	}
 
-	if err := osClient.EnsureIndex(ctx); err != nil {
-		logger.Error("Failed to ensure index", "error", err)
+	// Left nil unless DRIFT_CHECK_INTERVAL is set, which needs DJANGO_API_URL.
+	var driftReporter api.DriftReporter
+	if djangoClient != nil && cfg.Drift.Interval > 0 {
+		checker := drift.New(djangoClient, osClient, cfg.Drift.Threshold, logger)
+		driftReporter = checker
+		bootOpts = append(bootOpts, bootstrap.WithOnReady(func(ctx context.Context) {
+			go checker.Run(ctx, cfg.Drift.Interval)
+		}))
+		logger.Info("Index drift check enabled", "interval", cfg.Drift.Interval, "threshold", cfg.Drift.Threshold)
+	}
+
+	journal, err := outbox.New(outbox.WithFile(cfg.Journal.File), outbox.WithMaxEntries(cfg.Journal.MaxEntries))
+	if err != nil {
+		logger.Error("Failed to load write journal", "error", err)
 		os.Exit(1)
 	}
+	if pending := len(journal.Entries()); pending > 0 {
+		logger.Info("Pending tutor writes loaded", "pending", pending, "file", cfg.Journal.File)
+	}
+	// Replays go straight to OpenSearch, outside the HTTP concurrency limit.
+	bootOpts = append(bootOpts, bootstrap.WithOnReady(func(ctx context.Context) {
+		go journal.Run(ctx, osClient, logger)
+	}))
 
-	eventHandler := handler.New(osClient, logger)
+	boot := bootstrap.New(osClient, func(ctx context.Context) error {
+		return opensearch.EnsureIndices(ctx, osClient, tenants.All())
+	}, logger, bootOpts...)
+	if err := boot.Start(ctx); err != nil {
+		logger.Error("Startup failed", "error", err)
+		os.Exit(1)
+	}
 
-	consumer := kafka.NewConsumer(kafka.Config{
-		Brokers: strings.Split(kafkaBrokers, ","),
-		Topic:   kafkaTopic,
-		GroupID: kafkaGroupID,
-	}, eventHandler, logger)
+	var verifier *auth.Verifier
+	if cfg.Auth.JWTSecret != "" {
+		verifier = auth.NewVerifier(cfg.Auth.JWTSecret)
+	}
 
-	go func() {
-		if err := consumer.Start(ctx); err != nil {
-			logger.Error("Kafka consumer error", "error", err)
+	// Feedback is logged unless a topic is configured; Kafka writes are
+	// flushed after the HTTP server has drained.
+	var feedbackSink feedback.Sink
+	var feedbackKafka *feedback.KafkaSink
+	if cfg.Feedback.Enabled {
+		feedbackSink = feedback.NewLogSink(logger)
+		if cfg.Feedback.KafkaTopic != "" {
+			feedbackKafka = feedback.NewKafkaSink(cfg.Kafka.Brokers, cfg.Feedback.KafkaTopic, logger)
+			feedbackSink = feedbackKafka
 		}
-	}()
+	}
+
+	searchClient := limiter.New(osClient, cfg.Search.MaxConcurrent, limiter.WithWait(cfg.Search.AcquireTimeout))
+	// Closed when the HTTP server starts shutting down, to end streams.
+	draining := make(chan struct{})
+	router := api.NewRouter(searchClient, apiLogger, api.RouterConfig{
+		AllowedOrigins: cfg.CORS.AllowedOrigins,
+		Timeouts: api.Timeouts{
+			Search:   cfg.Server.SearchTimeout,
+			Mutation: cfg.Server.MutationTimeout,
+			Admin:    cfg.Server.AdminTimeout,
+			Quick:    cfg.Server.QuickSearchTimeout,
+		},
+		Activity:        hub,
+		AdminAPIKey:     cfg.Admin.APIKey,
+		RawQuery:        cfg.Admin.RawQuery,
+		Consumer:        pauser,
+		ConsumerOffsets: consumerOffsets,
+		ConsumerStatus:  consumerStatus,
+		Auth:            verifier,
+		Store:           store.NewMemory(),
+		Kafka:           kafkaChecker,
+		Reindex:         reindexJob,
+		Events:          eventTracker,
+		Snapshots:       snapshotTracker,
+		EventStats:      eventStats,
+		Tenants:         tenants,
+		Experiment:      exp,
+
+		Readiness:    boot,
+		Audit:        auditLog,
+		Watermark:    indexWatermark,
+		MaxStaleness: cfg.Kafka.MaxStaleness,
+		Drift:        driftReporter,
+		Lease:        leaseReporter,
+		IndexingRate: indexingRate,
+		Connections:  connections,
+		Journal:      journal,
+		Draining:     draining,
+		Relevance:    relevanceSource,
+		Effective:    cfg,
+
+		Feedback:         feedbackSink,
+		FeedbackRate:     limiter.NewKeyed(cfg.Feedback.RateLimit, cfg.Feedback.RateBurst),
+		MaxFeedbackBatch: cfg.Feedback.MaxBatch,
+		MaxResponseBytes: cfg.Search.MaxResponseBytes,
 
-	router := api.NewRouter(osClient, logger, corsOrigins)
+		MaxTutorID:   cfg.Indexing.MaxTutorID,
+		MaxListItems: cfg.Indexing.MaxListItems,
+		Avatars:      cfg.Indexing.AvatarPolicy(),
+		RatingMode:   cfg.Indexing.RatingMode,
+		Subjects:     subjects,
+		Translations: translations,
+		Scrubber:     scrubber,
+		QueryLimits: api.QueryLimits{
+			Subjects:   cfg.Search.MaxSubjects,
+			Locations:  cfg.Search.MaxLocations,
+			ExcludeIDs: cfg.Search.MaxExcludeIDs,
+			FacetSize:  cfg.Search.MaxFacetSize,
+		},
+	})
 
 	server := &http.Server{
-		Addr:         ":" + port,
 		Handler:      router,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+		ReadTimeout:  cfg.Server.ReadTimeout,
+		WriteTimeout: cfg.Server.WriteTimeout,
+		IdleTimeout:  cfg.Server.IdleTimeout,
 	}
+	server.RegisterOnShutdown(func() { close(draining) })
+
+	// Left nil when GRPC_PORT is unset.
+	var grpcServer *grpclib.Server
+	if grpcLis != nil {
+		grpcServer = searchgrpc.NewGRPCServer(searchgrpc.New(osClient, logger,
+			searchgrpc.WithActivityHub(hub),
+			searchgrpc.WithSubjectCatalog(subjects),
+			searchgrpc.WithScrubber(scrubber),
+		), logger)
+
+		go func() {
+			logger.Info("gRPC server starting", "addr", grpcLis.Addr().String())
+			if err := grpcServer.Serve(grpcLis); err != nil {
+				logger.Error("gRPC server error", "error", err)
+			}
+		}()
+	} else {
+		logger.Info("gRPC server disabled")
+	}
+
+	// Closed once both servers have drained, so main does not exit while
+	// in-flight requests are still being served.
+	shutdownDone := make(chan struct{})
 
 	go func() {
+		defer close(shutdownDone)
 		sigCh := make(chan os.Signal, 1)
 		signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 		<-sigCh
 		logger.Info("Shutdown signal received")
 		cancel()
 
-		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownGrace)
 		defer shutdownCancel()
-		if err := server.Shutdown(shutdownCtx); err != nil {
+
+		grpcStopped := make(chan struct{})
+		go func() {
+			defer close(grpcStopped)
+			if grpcServer == nil {
+				return
+			}
+			// GracefulStop waits for in-flight RPCs without a deadline, so
+			// fall back to a hard stop when the shutdown budget runs out.
+			done := make(chan struct{})
+			go func() {
+				grpcServer.GracefulStop()
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-shutdownCtx.Done():
+				grpcServer.Stop()
+			}
+		}()
+
+		if err := bootstrap.Shutdown(shutdownCtx, server); err != nil {
 			logger.Error("Server shutdown error", "error", err)
 		}
+		<-grpcStopped
 	}()
 
-	logger.Info("Server starting", "port", port)
-	if err := server.ListenAndServe(); err != http.ErrServerClosed {
+	logger.Info("Server starting", "addr", lis.Addr().String())
+	if err := bootstrap.Serve(server, lis); err != nil {
 		logger.Error("Server error", "error", err)
 		os.Exit(1)
 	}
+	<-shutdownDone
 
+	if shadowClient != nil {
+		shadowClient.Wait()
+	}
+	if feedbackKafka != nil {
+		if err := feedbackKafka.Close(); err != nil {
+			logger.Error("Failed to flush search feedback", "error", err)
+		}
+	}
+	if err := wmTracker.Save(); err != nil {
+		logger.Error("Failed to save event watermark", "error", err)
+	}
 	logger.Info("Server stopped")
 }
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// leaseHolder returns configured, or the host name and process ID so that
+// replicas sharing a host still differ.
+func leaseHolder(configured string) string {
+	if configured != "" {
+		return configured
 	}
-	return defaultValue
+	host, err := os.Hostname()
+	if err != nil {
+		host = "search"
+	}
+	return fmt.Sprintf("%s-%d", host, os.Getpid())
 }
 
-func waitForOpenSearch(ctx context.Context, client opensearch.SearchClient, logger *slog.Logger) error {
-	maxRetries := 30
-	for i := 0; i < maxRetries; i++ {
-		if err := client.Ping(ctx); err == nil {
-			logger.Info("OpenSearch connection established")
-			return nil
-		}
-		logger.Info("Waiting for OpenSearch...", "attempt", i+1)
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		case <-time.After(2 * time.Second):
-		}
+func kafkaStartOffset(name string) int64 {
+	if name == config.StartOffsetLatest {
+		return kafkago.LastOffset
 	}
-	return errors.New("failed to connect to OpenSearch after max retries")
+	return kafkago.FirstOffset
+}
+
+// componentLogger returns a logger for component, tagged with it, whose
+// level starts at the service's but is its own, so one subsystem's
+// verbosity can be raised without the others'.
+func componentLogger(cfg config.LogConfig, component string) *slog.Logger {
+	level := new(slog.LevelVar)
+	level.Set(cfg.SlogLevel())
+	return slog.New(cfg.NewHandler(os.Stdout, level)).With("component", component)
 }
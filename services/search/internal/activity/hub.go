@@ -0,0 +1,145 @@
+// Package activity is an in-process pub/sub hub for indexing activity, used
+// to feed the admin live event stream.
+package activity
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Event types published to the hub.
+const (
+	TypeUpsert        = "upsert"
+	TypeDelete        = "delete"
+	TypeSync          = "sync"
+	TypeConsumerError = "consumer_error"
+)
+
+// Event sources.
+const (
+	SourceHTTP  = "http"
+	SourceKafka = "kafka"
+	SourceGRPC  = "grpc"
+)
+
+// Event describes one thing the service did to the index.
+type Event struct {
+	Type    string    `json:"type"`
+	Source  string    `json:"source"`
+	TutorID int64     `json:"tutor_id,omitempty"`
+	EventID string    `json:"event_id,omitempty"`
+	Synced  int       `json:"synced,omitempty"`
+	Total   int       `json:"total,omitempty"`
+	Error   string    `json:"error,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// Hub fans events out to subscribers. Publish never blocks: each subscriber
+// has a bounded buffer and, when it is full, the oldest buffered event is
+// dropped to make room. A nil *Hub is valid and discards everything.
+type Hub struct {
+	mu         sync.Mutex
+	subs       map[*Subscription]struct{}
+	bufferSize int
+}
+
+// NewHub creates a hub whose subscribers buffer up to bufferSize events.
+func NewHub(bufferSize int) *Hub {
+	if bufferSize < 1 {
+		bufferSize = 1
+	}
+	return &Hub{
+		subs:       make(map[*Subscription]struct{}),
+		bufferSize: bufferSize,
+	}
+}
+
+// Publish delivers e to every current subscriber.
+func (h *Hub) Publish(e Event) {
+	if h == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subs {
+		sub.offer(e)
+	}
+}
+
+// Subscribe registers a new subscriber. Callers must Close it when done.
+func (h *Hub) Subscribe() *Subscription {
+	sub := &Subscription{
+		hub: h,
+		ch:  make(chan Event, h.bufferSize),
+	}
+
+	h.mu.Lock()
+	h.subs[sub] = struct{}{}
+	h.mu.Unlock()
+
+	return sub
+}
+
+// Subscribers returns the number of active subscriptions.
+func (h *Hub) Subscribers() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs)
+}
+
+// Subscription is one subscriber's view of the hub.
+type Subscription struct {
+	hub     *Hub
+	ch      chan Event
+	dropped atomic.Int64
+	once    sync.Once
+}
+
+// Events returns the channel of delivered events. It is closed by Close.
+func (s *Subscription) Events() <-chan Event {
+	return s.ch
+}
+
+// Dropped reports how many events were discarded because the subscriber
+// fell behind.
+func (s *Subscription) Dropped() int64 {
+	return s.dropped.Load()
+}
+
+// Close unregisters the subscription and closes its channel.
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.hub.mu.Lock()
+		delete(s.hub.subs, s)
+		close(s.ch)
+		s.hub.mu.Unlock()
+	})
+}
+
+// offer enqueues e, evicting the oldest buffered event when full. It is only
+// called with the hub lock held, so there is a single producer per channel.
+func (s *Subscription) offer(e Event) {
+	select {
+	case s.ch <- e:
+		return
+	default:
+	}
+
+	select {
+	case <-s.ch:
+		s.dropped.Add(1)
+	default:
+	}
+
+	select {
+	case s.ch <- e:
+	default:
+		s.dropped.Add(1)
+	}
+}
@@ -0,0 +1,101 @@
+package activity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func receive(t *testing.T, sub *Subscription) Event {
+	t.Helper()
+	select {
+	case e := <-sub.Events():
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+		return Event{}
+	}
+}
+
+func TestHub_SubscribeAndPublish(t *testing.T) {
+	hub := NewHub(4)
+	sub := hub.Subscribe()
+	defer sub.Close()
+
+	hub.Publish(Event{Type: TypeUpsert, Source: SourceHTTP, TutorID: 7})
+
+	e := receive(t, sub)
+	assert.Equal(t, TypeUpsert, e.Type)
+	assert.Equal(t, SourceHTTP, e.Source)
+	assert.Equal(t, int64(7), e.TutorID)
+	assert.False(t, e.Time.IsZero(), "publish should stamp the event time")
+}
+
+func TestHub_FanOut(t *testing.T) {
+	hub := NewHub(4)
+	subs := []*Subscription{hub.Subscribe(), hub.Subscribe(), hub.Subscribe()}
+	require.Equal(t, 3, hub.Subscribers())
+
+	hub.Publish(Event{Type: TypeDelete, TutorID: 1})
+	hub.Publish(Event{Type: TypeSync, Synced: 2, Total: 3})
+
+	for _, sub := range subs {
+		assert.Equal(t, TypeDelete, receive(t, sub).Type)
+		assert.Equal(t, TypeSync, receive(t, sub).Type)
+		sub.Close()
+	}
+	assert.Equal(t, 0, hub.Subscribers())
+}
+
+func TestHub_SlowSubscriberDropsOldest(t *testing.T) {
+	hub := NewHub(3)
+	slow := hub.Subscribe()
+	defer slow.Close()
+	fast := hub.Subscribe()
+	defer fast.Close()
+
+	done := make(chan struct{})
+	go func() {
+		for i := int64(1); i <= 10; i++ {
+			hub.Publish(Event{Type: TypeUpsert, TutorID: i})
+			// The fast subscriber drains as events arrive.
+			assert.Equal(t, i, (<-fast.Events()).TutorID)
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("publish blocked on a slow subscriber")
+	}
+
+	assert.Equal(t, int64(7), slow.Dropped())
+	assert.Equal(t, int64(0), fast.Dropped())
+
+	// The slow subscriber keeps only the newest events.
+	assert.Equal(t, int64(8), receive(t, slow).TutorID)
+	assert.Equal(t, int64(9), receive(t, slow).TutorID)
+	assert.Equal(t, int64(10), receive(t, slow).TutorID)
+}
+
+func TestHub_CloseIsIdempotent(t *testing.T) {
+	hub := NewHub(1)
+	sub := hub.Subscribe()
+
+	sub.Close()
+	sub.Close()
+
+	_, open := <-sub.Events()
+	assert.False(t, open)
+
+	// Publishing after close must not panic on the closed channel.
+	hub.Publish(Event{Type: TypeUpsert})
+}
+
+func TestHub_NilHubDiscards(t *testing.T) {
+	var hub *Hub
+	hub.Publish(Event{Type: TypeUpsert})
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"search/internal/domain"
+	"search/internal/port"
+)
+
+// defaultAlternativesPriceRatio keeps alternatives strictly cheaper than the
+// reference tutor.
+const defaultAlternativesPriceRatio = 1.0
+
+type alternativesResponse struct {
+	TutorID int64 `json:"tutor_id"`
+	// BelowPrice is the exclusive hourly rate cap the results were held to.
+	BelowPrice float64        `json:"below_price"`
+	Results    []domain.Tutor `json:"results"`
+	Total      int            `json:"total"`
+}
+
+// TutorAlternatives lists tutors sharing at least one subject with the
+// reference tutor whose hourly rate is below max_price_ratio (0 to 1,
+// default 1) times its own, best rated first.
+func (h *Handlers) TutorAlternatives(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, ok := h.tutorID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	ratio := defaultAlternativesPriceRatio
+	if raw := r.URL.Query().Get("max_price_ratio"); raw != "" {
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil || v <= 0 || v > 1 {
+			respondError(w, http.StatusBadRequest, "max_price_ratio must be greater than 0 and at most 1")
+			return
+		}
+		ratio = v
+	}
+
+	ref, err := h.os.GetTutor(ctx, id)
+	if errors.Is(err, port.ErrNotFound) {
+		respondError(w, http.StatusNotFound, "Tutor not found")
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to get tutor", "id", id, "error", err)
+		respondBackendError(w, err, "Failed to get tutor")
+		return
+	}
+
+	resp := alternativesResponse{
+		TutorID:    id,
+		BelowPrice: ref.HourlyRate * ratio,
+		Results:    []domain.Tutor{},
+	}
+	// Without subjects the search would match every tutor.
+	if len(ref.Subjects) == 0 {
+		respondJSON(w, http.StatusOK, resp)
+		return
+	}
+
+	query := port.SearchQuery{
+		Subjects:   ref.Subjects,
+		BelowPrice: &resp.BelowPrice,
+		ExcludeIDs: []int64{id},
+		Sort:       port.SortRating,
+	}
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		if v, err := strconv.Atoi(limit); err == nil {
+			query.Limit = v
+		}
+	}
+
+	result, err := h.os.SearchTutors(ctx, query)
+	if err != nil {
+		h.logger.Error("Failed to search tutor alternatives", "id", id, "error", err)
+		respondBackendError(w, err, "Failed to search tutor alternatives")
+		return
+	}
+	stripIndexMeta(r, result.Results)
+	h.localizeSubjects(result.Results, h.language(w, r))
+	resp.Results = result.Results
+	resp.Total = result.Total
+
+	respondJSON(w, http.StatusOK, resp)
+}
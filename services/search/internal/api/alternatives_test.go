@@ -0,0 +1,146 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"search/internal/domain"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func TestTutorAlternatives_Query(t *testing.T) {
+	reference := &domain.Tutor{ID: 7, FullName: "Ann", Subjects: []string{"math", "physics"}, HourlyRate: 40}
+
+	tests := []struct {
+		name         string
+		query        string
+		wantBelow    float64
+		wantLimit    int
+		wantSearched bool
+		wantStatus   int
+	}{
+		{"default ratio", "", 40, 0, true, http.StatusOK},
+		{"custom ratio", "?max_price_ratio=0.75", 30, 0, true, http.StatusOK},
+		{"limit passed through", "?limit=5", 40, 5, true, http.StatusOK},
+		{"ratio zero", "?max_price_ratio=0", 0, 0, false, http.StatusBadRequest},
+		{"ratio above one", "?max_price_ratio=1.5", 0, 0, false, http.StatusBadRequest},
+		{"ratio not a number", "?max_price_ratio=half", 0, 0, false, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{
+				tutor:        reference,
+				searchResult: &port.SearchResponse{Results: []domain.Tutor{{ID: 3}}, Total: 1},
+			}
+			handlers := NewHandlers(mock, testutil.NewLogger(t))
+			req := httptest.NewRequest("GET", "/tutors/7/alternatives"+tt.query, nil)
+			req.SetPathValue("id", "7")
+			rec := httptest.NewRecorder()
+
+			handlers.TutorAlternatives(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			q := mock.searchedQuery
+			if !tt.wantSearched {
+				if q.Subjects != nil {
+					t.Errorf("expected no search, got %+v", q)
+				}
+				return
+			}
+			if !slices.Equal(q.Subjects, reference.Subjects) {
+				t.Errorf("expected subjects %v, got %v", reference.Subjects, q.Subjects)
+			}
+			if q.BelowPrice == nil || *q.BelowPrice != tt.wantBelow {
+				t.Errorf("expected below price %v, got %v", tt.wantBelow, q.BelowPrice)
+			}
+			if q.MaxPrice != nil || q.MinPrice != nil {
+				t.Errorf("expected no inclusive price bounds, got %v and %v", q.MinPrice, q.MaxPrice)
+			}
+			if !slices.Equal(q.ExcludeIDs, []int64{7}) {
+				t.Errorf("expected the reference excluded, got %v", q.ExcludeIDs)
+			}
+			if q.Sort != port.SortRating {
+				t.Errorf("expected rating sort, got %q", q.Sort)
+			}
+			if q.Limit != tt.wantLimit {
+				t.Errorf("expected limit %d, got %d", tt.wantLimit, q.Limit)
+			}
+
+			var resp alternativesResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.TutorID != 7 || resp.BelowPrice != tt.wantBelow || resp.Total != 1 || len(resp.Results) != 1 {
+				t.Errorf("unexpected response %+v", resp)
+			}
+		})
+	}
+}
+
+func TestTutorAlternatives_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		id         string
+		getErr     error
+		searchErr  error
+		wantStatus int
+	}{
+		{"invalid ID", "abc", nil, nil, http.StatusBadRequest},
+		{"reference not indexed", "8", nil, nil, http.StatusNotFound},
+		{"get fails", "7", errors.New("boom"), nil, http.StatusInternalServerError},
+		{"search fails", "7", nil, errors.New("boom"), http.StatusInternalServerError},
+		{"search overloaded", "7", nil, port.ErrOverloaded, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{
+				tutor:     &domain.Tutor{ID: 7, Subjects: []string{"math"}, HourlyRate: 40},
+				getErr:    tt.getErr,
+				searchErr: tt.searchErr,
+			}
+			handlers := NewHandlers(mock, testutil.NewLogger(t))
+			req := httptest.NewRequest("GET", "/tutors/"+tt.id+"/alternatives", nil)
+			req.SetPathValue("id", tt.id)
+			rec := httptest.NewRecorder()
+
+			handlers.TutorAlternatives(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestTutorAlternatives_NoSubjects(t *testing.T) {
+	mock := &mockSearchClient{tutor: &domain.Tutor{ID: 7, HourlyRate: 40}}
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+	req := httptest.NewRequest("GET", "/tutors/7/alternatives", nil)
+	req.SetPathValue("id", "7")
+	rec := httptest.NewRecorder()
+
+	handlers.TutorAlternatives(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if mock.searchedQuery.BelowPrice != nil {
+		t.Errorf("expected no search for a tutor without subjects, got %+v", mock.searchedQuery)
+	}
+	var resp alternativesResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Results == nil || len(resp.Results) != 0 || resp.Total != 0 {
+		t.Errorf("expected empty results, got %+v", resp)
+	}
+}
@@ -0,0 +1,94 @@
+package api
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"search/internal/audit"
+	"search/internal/auth"
+	"search/internal/tenant"
+)
+
+// AuditMiddleware records an audit entry for every request it wraps, with
+// the caller identified by adminKey or a user token verified by v. Handlers
+// add the tutors or document counts they touched through the audit
+// package. Only mutating routes should be wrapped.
+func AuditMiddleware(log *audit.Log, adminKey string, v *auth.Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, details := audit.NewContext(r.Context())
+			ww := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			e := audit.Entry{
+				Method:     r.Method,
+				Route:      chi.RouteContext(r.Context()).RoutePattern(),
+				Path:       r.URL.Path,
+				Actor:      auditActor(r, adminKey, v),
+				RemoteAddr: r.RemoteAddr,
+				Status:     ww.statusCode,
+				Outcome:    audit.OutcomeFor(ww.statusCode),
+			}
+			if t, ok := tenant.FromContext(ctx); ok {
+				e.Tenant = t.Name
+			}
+			if details.Apply(&e) {
+				log.Record(e)
+			}
+		})
+	}
+}
+
+// auditActor names the caller without recording credentials: the admin key
+// by a short hash, users by their token's subject.
+func auditActor(r *http.Request, adminKey string, v *auth.Verifier) string {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == "" {
+		return "anonymous"
+	}
+	if adminKey != "" && subtle.ConstantTimeCompare([]byte(token), []byte(adminKey)) == 1 {
+		return "admin_key:" + apiKeyID(adminKey)
+	}
+	if v != nil {
+		if userID, err := v.UserID(token); err == nil {
+			return "user:" + userID
+		}
+	}
+	return "anonymous"
+}
+
+// apiKeyID identifies an API key in logs by the first 8 hex digits of its
+// SHA-256.
+func apiKeyID(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:4])
+}
+
+// AuditLog returns the retained audit entries recorded at or after since
+// (RFC 3339), oldest first; without since, all of them.
+func (h *Handlers) AuditLog(w http.ResponseWriter, r *http.Request) {
+	if h.audit == nil {
+		respondError(w, http.StatusNotFound, "Audit log is not enabled")
+		return
+	}
+
+	var since time.Time
+	if raw := r.URL.Query().Get("since"); raw != "" {
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "since must be RFC 3339")
+			return
+		}
+		since = t
+	}
+
+	respondJSON(w, http.StatusOK, map[string]any{
+		"entries": h.audit.Since(since),
+	})
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"search/internal/audit"
+	"search/internal/auth"
+	"search/internal/opensearch"
+	"search/internal/testutil"
+)
+
+const testAdminKey = "admin-secret"
+
+func newAuditedRouter(t *testing.T) (http.Handler, *audit.Log) {
+	t.Helper()
+	logger := testutil.NewLogger(t)
+	log := audit.New(logger, 100)
+	cfg := testRouterConfig()
+	cfg.AdminAPIKey = testAdminKey
+	cfg.Auth = auth.NewVerifier("django-secret")
+	cfg.Audit = log
+	return NewRouter(opensearch.NewMemoryClient(), logger, cfg), log
+}
+
+func serve(router http.Handler, method, path, body, authHeader string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if authHeader != "" {
+		req.Header.Set("Authorization", authHeader)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAudit_MutatingRoutesAreRecorded(t *testing.T) {
+	admin := "Bearer " + testAdminKey
+
+	tests := []struct {
+		method      string
+		path        string
+		body        string
+		wantRoute   string
+		wantIDs     []int64
+		wantCount   int
+		wantOutcome string
+	}{
+		{"PUT", "/tutors/1", `{"full_name": "Ada", "rating": 4.5, "reviews_count": 3}`, "/tutors/{id}", []int64{1}, 0, audit.OutcomeSuccess},
+		{"PUT", "/tutors/2", `{"rating": 9}`, "/tutors/{id}", []int64{2}, 0, audit.OutcomeRejected},
+		{"DELETE", "/tutors/1", "", "/tutors/{id}", []int64{1}, 0, audit.OutcomeSuccess},
+		{"POST", "/admin/sync", `[{"id": 3, "full_name": "Alan"}, {"id": 4, "full_name": "Grace"}]`, "/admin/sync", nil, 2, audit.OutcomeSuccess},
+		{"POST", "/admin/reindex", "", "/admin/reindex", nil, 0, audit.OutcomeSuccess},
+		{"POST", "/admin/reconcile?fix=delete_extra&confirm=true", `{"ids": [3]}`, "/admin/reconcile", nil, 1, audit.OutcomeSuccess},
+		{"POST", "/admin/tutors/delete", `{"ids": [3, 99]}`, "/admin/tutors/delete", nil, 1, audit.OutcomeSuccess},
+		{"PUT", "/admin/index/settings", `{"number_of_replicas": 1}`, "/admin/index/settings", nil, 0, audit.OutcomeFailed},
+		{"POST", "/admin/consumer/seek", `{"to": "latest"}`, "/admin/consumer/seek", nil, 0, audit.OutcomeRejected},
+		{"POST", "/admin/index/recreate", `{"confirm": "tutors"}`, "/admin/index/recreate", nil, 0, audit.OutcomeSuccess},
+	}
+
+	router, log := newAuditedRouter(t)
+	for _, tt := range tests {
+		serve(router, tt.method, tt.path, tt.body, admin)
+	}
+
+	entries := log.Since(time.Time{})
+	if len(entries) != len(tests) {
+		t.Fatalf("expected %d entries, got %d: %+v", len(tests), len(entries), entries)
+	}
+	for i, tt := range tests {
+		e := entries[i]
+		if e.Method != tt.method || e.Route != tt.wantRoute {
+			t.Errorf("%s %s: expected route %s, got %s %s", tt.method, tt.path, tt.wantRoute, e.Method, e.Route)
+		}
+		if !slices.Equal(e.TutorIDs, tt.wantIDs) || e.Count != tt.wantCount {
+			t.Errorf("%s %s: expected ids %v and count %d, got %v and %d", tt.method, tt.path, tt.wantIDs, tt.wantCount, e.TutorIDs, e.Count)
+		}
+		if e.Outcome != tt.wantOutcome {
+			t.Errorf("%s %s: expected outcome %s, got %s (status %d)", tt.method, tt.path, tt.wantOutcome, e.Outcome, e.Status)
+		}
+		if !strings.HasPrefix(e.Actor, "admin_key:") || strings.Contains(e.Actor, testAdminKey) {
+			t.Errorf("%s %s: expected the admin key ID as actor, got %q", tt.method, tt.path, e.Actor)
+		}
+	}
+}
+
+func TestAudit_ReadsAreNotRecorded(t *testing.T) {
+	router, log := newAuditedRouter(t)
+	admin := "Bearer " + testAdminKey
+
+	for _, tc := range []struct{ method, path, body string }{
+		{"GET", "/health", ""},
+		{"GET", "/tutors/1", ""},
+		{"GET", "/tutors/search?q=ada", ""},
+		{"GET", "/tutors/top?subjects=math", ""},
+		{"POST", "/admin/reconcile", `{"ids": [1]}`},
+		{"GET", "/admin/reindex/last", ""},
+		{"GET", "/admin/consumer/offsets", ""},
+		{"GET", "/admin/audit", ""},
+	} {
+		serve(router, tc.method, tc.path, tc.body, admin)
+	}
+
+	if entries := log.Since(time.Time{}); len(entries) != 0 {
+		t.Errorf("expected no entries for reads, got %+v", entries)
+	}
+}
+
+func TestAudit_Actor(t *testing.T) {
+	userToken, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": 42,
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("django-secret"))
+
+	tests := []struct {
+		name       string
+		authHeader string
+		want       string
+	}{
+		{"admin key", "Bearer " + testAdminKey, "admin_key:" + apiKeyID(testAdminKey)},
+		{"user token", "Bearer " + userToken, "user:42"},
+		{"unknown token", "Bearer guess", "anonymous"},
+		{"no token", "", "anonymous"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, log := newAuditedRouter(t)
+			serve(router, "DELETE", "/tutors/5?idempotent=true", "", tt.authHeader)
+
+			entries := log.Since(time.Time{})
+			if len(entries) != 1 {
+				t.Fatalf("expected one entry, got %d", len(entries))
+			}
+			if entries[0].Actor != tt.want {
+				t.Errorf("expected actor %q, got %q", tt.want, entries[0].Actor)
+			}
+		})
+	}
+}
+
+func TestAuditLog(t *testing.T) {
+	router, _ := newAuditedRouter(t)
+	admin := "Bearer " + testAdminKey
+
+	serve(router, "DELETE", "/tutors/1?idempotent=true", "", admin)
+	cutoff := time.Now().UTC()
+	time.Sleep(time.Millisecond)
+	serve(router, "DELETE", "/tutors/2?idempotent=true", "", admin)
+
+	tests := []struct {
+		name       string
+		query      string
+		authHeader string
+		wantStatus int
+		wantIDs    []int64
+	}{
+		{"all", "", admin, http.StatusOK, []int64{1, 2}},
+		{"since", "?since=" + cutoff.Format(time.RFC3339Nano), admin, http.StatusOK, []int64{2}},
+		{"bad since", "?since=yesterday", admin, http.StatusBadRequest, nil},
+		{"no key", "", "", http.StatusUnauthorized, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(router, "GET", "/admin/audit"+tt.query, "", tt.authHeader)
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var resp struct {
+				Entries []audit.Entry `json:"entries"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			var ids []int64
+			for _, e := range resp.Entries {
+				ids = append(ids, e.TutorIDs...)
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("expected entries for tutors %v, got %v", tt.wantIDs, ids)
+			}
+		})
+	}
+}
+
+func TestAuditLog_Disabled(t *testing.T) {
+	handlers := NewHandlers(&mockSearchClient{}, testutil.NewLogger(t))
+	rec := httptest.NewRecorder()
+	handlers.AuditLog(rec, httptest.NewRequest("GET", "/admin/audit", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
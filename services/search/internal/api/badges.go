@@ -0,0 +1,80 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"search/internal/activity"
+	"search/internal/audit"
+	"search/internal/domain"
+	"search/internal/port"
+)
+
+type badgesRequest struct {
+	Add    []string `json:"add"`
+	Remove []string `json:"remove"`
+}
+
+// UpdateTutorBadges adds and removes badges on an indexed tutor straight
+// away, without waiting for Django. Only the badges change; a badge listed
+// in both add and remove is removed.
+func (h *Handlers) UpdateTutorBadges(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, ok := h.tutorID(w, r, "id")
+	if !ok {
+		return
+	}
+	audit.AddTutorIDs(ctx, id)
+
+	var req badgesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if len(req.Add) == 0 && len(req.Remove) == 0 {
+		respondError(w, http.StatusBadRequest, "add or remove is required")
+		return
+	}
+	for _, list := range [][]string{req.Add, req.Remove} {
+		for i, b := range list {
+			list[i] = strings.ToLower(strings.TrimSpace(b))
+			if err := domain.ValidateBadge(list[i]); err != nil {
+				respondError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+	}
+
+	err := h.os.UpdateBadges(ctx, id, req.Add, req.Remove)
+	switch {
+	case errors.Is(err, port.ErrNotFound):
+		respondError(w, http.StatusNotFound, "Tutor not found")
+		return
+	case err != nil:
+		h.logger.Error("Failed to update tutor badges", "id", id, "error", err)
+		respondBackendError(w, err, "Failed to update badges")
+		return
+	}
+	h.logger.Info("Tutor badges updated", "id", id, "add", req.Add, "remove", req.Remove)
+	h.activity.Publish(activity.Event{Type: activity.TypeUpsert, Source: activity.SourceHTTP, TutorID: id})
+
+	resp := map[string]any{"status": "updated", "tutor_id": id}
+	// The update is applied either way; the badges are only echoed when
+	// the tutor can be read back.
+	if tutor, err := h.os.GetTutor(ctx, id); err == nil {
+		resp["badges"] = nonNilBadges(tutor.Badges)
+	} else {
+		h.logger.Warn("Failed to read back tutor badges", "id", id, "error", err)
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// nonNilBadges returns badges, or an empty list so it encodes as [].
+func nonNilBadges(badges []string) []string {
+	if badges == nil {
+		return []string{}
+	}
+	return badges
+}
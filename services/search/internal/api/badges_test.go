@@ -0,0 +1,100 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"search/internal/domain"
+	"search/internal/opensearch"
+	"search/internal/testutil"
+)
+
+func TestUpdateTutorBadges(t *testing.T) {
+	client := opensearch.NewMemoryClient()
+	tutor := domain.Tutor{ID: 7, FullName: "Ada Lovelace", Headline: "Math", Badges: []string{"new"}}
+	if err := client.UpsertTutor(context.Background(), &tutor); err != nil {
+		t.Fatalf("failed to index tutor: %v", err)
+	}
+	cfg := testRouterConfig()
+	cfg.AdminAPIKey = testAdminKey
+	router := NewRouter(client, testutil.NewLogger(t), cfg)
+
+	post := func(path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", path, bytes.NewReader([]byte(body)))
+		req.Header.Set("Authorization", "Bearer "+testAdminKey)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := post("/admin/tutors/7/badges", `{"add": [" Featured "], "remove": ["new"]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		TutorID int64    `json:"tutor_id"`
+		Badges  []string `json:"badges"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.TutorID != 7 || !slices.Equal(resp.Badges, []string{"featured"}) {
+		t.Errorf("expected tutor 7 with badges [featured], got %+v", resp)
+	}
+
+	got, err := client.GetTutor(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.FullName != "Ada Lovelace" || got.Headline != "Math" {
+		t.Errorf("expected the other fields to be kept, got %+v", got)
+	}
+
+	// A profile update that does not send badges keeps them.
+	req := httptest.NewRequest("PUT", "/tutors/7", bytes.NewReader([]byte(`{"full_name": "Ada King"}`)))
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	if got, _ := client.GetTutor(context.Background(), 7); !slices.Equal(got.Badges, []string{"featured"}) {
+		t.Errorf("expected badges to survive a profile update, got %q", got.Badges)
+	}
+
+	searchRec := httptest.NewRecorder()
+	router.ServeHTTP(searchRec, httptest.NewRequest("GET", "/tutors/search?badge=featured", nil))
+	if !bytes.Contains(searchRec.Body.Bytes(), []byte(`"total":1`)) {
+		t.Errorf("expected the badge filter to find the tutor, got %s", searchRec.Body.String())
+	}
+
+	tests := []struct {
+		name, path, body string
+		want             int
+	}{
+		{"unknown tutor", "/admin/tutors/8/badges", `{"add": ["featured"]}`, http.StatusNotFound},
+		{"invalid id", "/admin/tutors/x/badges", `{"add": ["featured"]}`, http.StatusBadRequest},
+		{"nothing to do", "/admin/tutors/7/badges", `{}`, http.StatusBadRequest},
+		{"invalid badge", "/admin/tutors/7/badges", `{"add": ["top pick!"]}`, http.StatusBadRequest},
+		{"malformed body", "/admin/tutors/7/badges", `{"add": `, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := post(tt.path, tt.body); rec.Code != tt.want {
+				t.Errorf("expected %d, got %d: %s", tt.want, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestUpdateTutorBadges_RequiresAdminKey(t *testing.T) {
+	cfg := testRouterConfig()
+	cfg.AdminAPIKey = testAdminKey
+	router := NewRouter(opensearch.NewMemoryClient(), testutil.NewLogger(t), cfg)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("POST", "/admin/tutors/7/badges", bytes.NewReader([]byte(`{"add": ["featured"]}`))))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"search/internal/domain"
+	"search/internal/port"
+)
+
+// Optional payload a search response sheds, in truncationOrder, to stay
+// within the response budget. Responses carry no highlights, so the full
+// bio goes first.
+const (
+	truncatedBio        = "bio"
+	truncatedAlternates = "alternates"
+	truncatedBioSnippet = "bio_snippet"
+)
+
+// truncationOrder is the order fitResponse drops optional payload in.
+var truncationOrder = []string{truncatedBio, truncatedAlternates, truncatedBioSnippet}
+
+// fitResponse returns result's response body, as responseBody does, after
+// dropping optional payload in truncationOrder while the body encodes to
+// more than h.maxResponseBytes. What it dropped is listed in the result's
+// TruncatedFields. A body still over budget once nothing optional is left
+// is sent as it is rather than failed.
+func (h *Handlers) fitResponse(r *http.Request, result *port.SearchResponse, lang string) any {
+	body := h.responseBody(r, result, lang)
+	if h.maxResponseBytes <= 0 {
+		return body
+	}
+	size := encodedSize(body)
+	for _, field := range truncationOrder {
+		if size <= h.maxResponseBytes {
+			return body
+		}
+		if !dropField(result, field) {
+			continue
+		}
+		result.TruncatedFields = append(result.TruncatedFields, field)
+		body = h.responseBody(r, result, lang)
+		size = encodedSize(body)
+	}
+	if size > h.maxResponseBytes {
+		h.logger.Warn("Search response over budget after truncation",
+			"bytes", size,
+			"max_bytes", h.maxResponseBytes,
+			"results", len(result.Results),
+		)
+	}
+	return body
+}
+
+// dropField clears field from result and reports whether it held any.
+func dropField(result *port.SearchResponse, field string) bool {
+	switch field {
+	case truncatedAlternates:
+		dropped := len(result.Alternates) > 0
+		result.Alternates = nil
+		return dropped
+	case truncatedBio:
+		return clearText(result, func(t *domain.Tutor) *string { return &t.Bio })
+	case truncatedBioSnippet:
+		return clearText(result, func(t *domain.Tutor) *string { return &t.BioSnippet })
+	}
+	return false
+}
+
+// clearText empties the field of every result and alternate that text
+// points at and reports whether any was set.
+func clearText(result *port.SearchResponse, text func(*domain.Tutor) *string) bool {
+	cleared := clearTutors(result.Results, text)
+	for _, alternates := range result.Alternates {
+		cleared = clearTutors(alternates, text) || cleared
+	}
+	return cleared
+}
+
+// clearTutors empties the field text points at in each of tutors and
+// reports whether any was set.
+func clearTutors(tutors []domain.Tutor, text func(*domain.Tutor) *string) bool {
+	cleared := false
+	for i := range tutors {
+		if s := text(&tutors[i]); *s != "" {
+			*s = ""
+			cleared = true
+		}
+	}
+	return cleared
+}
+
+// fitSections trims a grouped response the way fitResponse trims a flat
+// one, listing what it dropped in resp.TruncatedFields. Sections carry no
+// alternates, so only the bio fields can go.
+func (h *Handlers) fitSections(resp *sectionsResponse) {
+	if h.maxResponseBytes <= 0 {
+		return
+	}
+	size := encodedSize(resp)
+	for _, field := range truncationOrder {
+		if size <= h.maxResponseBytes {
+			return
+		}
+		if !dropSectionField(resp.Sections, field) {
+			continue
+		}
+		resp.TruncatedFields = append(resp.TruncatedFields, field)
+		size = encodedSize(resp)
+	}
+	if size > h.maxResponseBytes {
+		h.logger.Warn("Search response over budget after truncation",
+			"bytes", size,
+			"max_bytes", h.maxResponseBytes,
+			"sections", len(resp.Sections),
+		)
+	}
+}
+
+// dropSectionField clears field from every section and reports whether any
+// held it.
+func dropSectionField(sections []section, field string) bool {
+	var text func(*domain.Tutor) *string
+	switch field {
+	case truncatedBio:
+		text = func(t *domain.Tutor) *string { return &t.Bio }
+	case truncatedBioSnippet:
+		text = func(t *domain.Tutor) *string { return &t.BioSnippet }
+	default:
+		return false
+	}
+	dropped := false
+	for i := range sections {
+		dropped = clearTutors(sections[i].Tutors, text) || dropped
+	}
+	return dropped
+}
+
+// byteCounter is an io.Writer counting what is written to it.
+type byteCounter int
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	*c += byteCounter(len(p))
+	return len(p), nil
+}
+
+// encodedSize returns how many bytes respondJSON writes for body.
+func encodedSize(body any) int {
+	var n byteCounter
+	if err := json.NewEncoder(&n).Encode(body); err != nil {
+		return 0
+	}
+	return int(n)
+}
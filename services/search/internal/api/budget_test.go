@@ -0,0 +1,226 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"search/internal/domain"
+	"search/internal/opensearch"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+// budgetResult returns a diversified search result carrying every field
+// fitResponse may drop.
+func budgetResult() *port.SearchResponse {
+	tutor := func(id int64) domain.Tutor {
+		return domain.Tutor{
+			ID:         id,
+			FullName:   "Ada Lovelace",
+			Bio:        strings.Repeat("Analytical engines and more. ", 40),
+			BioSnippet: "Analytical engines and more.",
+		}
+	}
+	return &port.SearchResponse{
+		Results:    []domain.Tutor{tutor(1), tutor(2)},
+		Total:      4,
+		Alternates: map[int64][]domain.Tutor{1: {tutor(3), tutor(4)}},
+	}
+}
+
+func TestFitResponse_Thresholds(t *testing.T) {
+	req := httptest.NewRequest("GET", "/tutors/search", nil)
+	sizer := NewHandlers(&mockSearchClient{}, testutil.NewLogger(t))
+
+	// stages[k] is the response after the first k drops.
+	stages := []*port.SearchResponse{budgetResult()}
+	for k := range truncationOrder {
+		next := budgetResult()
+		for _, field := range truncationOrder[:k+1] {
+			dropField(next, field)
+		}
+		next.TruncatedFields = slices.Clone(truncationOrder[:k+1])
+		stages = append(stages, next)
+	}
+	sizes := make([]int, len(stages))
+	for k, stage := range stages {
+		sizes[k] = encodedSize(sizer.responseBody(req, stage, ""))
+		if k > 0 && sizes[k] >= sizes[k-1] {
+			t.Fatalf("expected dropping %s to shrink the response, got %d then %d bytes", truncationOrder[k-1], sizes[k-1], sizes[k])
+		}
+	}
+
+	for k := range stages {
+		for _, budget := range []int{sizes[k], sizes[k] - 1} {
+			want := k
+			if budget < sizes[k] {
+				want = min(k+1, len(stages)-1)
+			}
+			h := NewHandlers(&mockSearchClient{}, testutil.NewLogger(t), WithMaxResponseBytes(budget))
+			result := budgetResult()
+			body := h.fitResponse(req, result, "")
+
+			if !slices.Equal(result.TruncatedFields, stages[want].TruncatedFields) {
+				t.Errorf("budget %d: expected truncated fields %v, got %v", budget, stages[want].TruncatedFields, result.TruncatedFields)
+			}
+			if got := encodedSize(body); got != sizes[want] {
+				t.Errorf("budget %d: expected a %d byte response, got %d", budget, sizes[want], got)
+			}
+		}
+	}
+}
+
+func TestFitResponse_SkipsAbsentFields(t *testing.T) {
+	req := httptest.NewRequest("GET", "/tutors/search", nil)
+	h := NewHandlers(&mockSearchClient{}, testutil.NewLogger(t), WithMaxResponseBytes(1))
+	result := budgetResult()
+	result.Alternates = nil
+
+	h.fitResponse(req, result, "")
+
+	if want := []string{truncatedBio, truncatedBioSnippet}; !slices.Equal(result.TruncatedFields, want) {
+		t.Errorf("expected only fields the response held to be listed, got %v", result.TruncatedFields)
+	}
+}
+
+func TestSearchTutors_ResponseBudget(t *testing.T) {
+	tests := []struct {
+		name        string
+		budget      int
+		wantBio     bool
+		wantSnippet bool
+		truncated   []string
+	}{
+		{"disabled", 0, true, true, nil},
+		{"roomy", 1 << 20, true, true, nil},
+		{"unreachable", 1, false, false, []string{truncatedBio, truncatedAlternates, truncatedBioSnippet}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{searchResult: budgetResult()}
+			cfg := testRouterConfig()
+			cfg.MaxResponseBytes = tt.budget
+			router := NewRouter(mock, testutil.NewLogger(t), cfg)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest("GET", "/tutors/search?fields=bio&diversify_by=location", nil))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+			}
+			var resp struct {
+				Results []struct {
+					Bio        string `json:"bio"`
+					BioSnippet string `json:"bio_snippet"`
+				} `json:"results"`
+				TruncatedFields []string `json:"truncated_fields"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got := resp.Results[0].Bio != ""; got != tt.wantBio {
+				t.Errorf("expected bio sent %t, got %t", tt.wantBio, got)
+			}
+			if got := resp.Results[0].BioSnippet != ""; got != tt.wantSnippet {
+				t.Errorf("expected bio_snippet sent %t, got %t", tt.wantSnippet, got)
+			}
+			if !slices.Equal(resp.TruncatedFields, tt.truncated) {
+				t.Errorf("expected truncated fields %v, got %v", tt.truncated, resp.TruncatedFields)
+			}
+		})
+	}
+}
+
+// budgetSections returns a grouped response whose tutors carry both bio
+// fields.
+func budgetSections() *sectionsResponse {
+	result := budgetResult()
+	return &sectionsResponse{
+		Sections: []section{
+			{Subject: "math", Total: 1, Tutors: result.Results[:1]},
+			{Subject: "physics", Total: 1, Tutors: result.Results[1:]},
+		},
+		Total:          2,
+		AppliedFilters: &port.SearchQuery{},
+	}
+}
+
+func TestFitSections_Thresholds(t *testing.T) {
+	// Sections carry no alternates, so only the bio fields can go.
+	stages := [][]string{nil, {truncatedBio}, {truncatedBio, truncatedBioSnippet}}
+	sizes := make([]int, len(stages))
+	for k, fields := range stages {
+		resp := budgetSections()
+		for _, field := range fields {
+			dropSectionField(resp.Sections, field)
+		}
+		resp.TruncatedFields = fields
+		sizes[k] = encodedSize(resp)
+		if k > 0 && sizes[k] >= sizes[k-1] {
+			t.Fatalf("expected dropping %s to shrink the response, got %d then %d bytes", fields[k-1], sizes[k-1], sizes[k])
+		}
+	}
+
+	for k := range stages {
+		for _, budget := range []int{sizes[k], sizes[k] - 1} {
+			want := k
+			if budget < sizes[k] {
+				want = min(k+1, len(stages)-1)
+			}
+			h := NewHandlers(&mockSearchClient{}, testutil.NewLogger(t), WithMaxResponseBytes(budget))
+			resp := budgetSections()
+			h.fitSections(resp)
+
+			if !slices.Equal(resp.TruncatedFields, stages[want]) {
+				t.Errorf("budget %d: expected truncated fields %v, got %v", budget, stages[want], resp.TruncatedFields)
+			}
+			if got := encodedSize(resp); got != sizes[want] {
+				t.Errorf("budget %d: expected a %d byte response, got %d", budget, sizes[want], got)
+			}
+		}
+	}
+}
+
+func TestSearchTutors_GroupBySubjectResponseBudget(t *testing.T) {
+	client := opensearch.NewMemoryClient()
+	tutor := domain.Tutor{ID: 1, FullName: "Ada Lovelace", Subjects: []string{"math"}, Bio: strings.Repeat("Analytical engines and more. ", 40)}
+	if err := client.UpsertTutor(context.Background(), &tutor); err != nil {
+		t.Fatalf("failed to seed tutor: %v", err)
+	}
+	cfg := testRouterConfig()
+	cfg.MaxResponseBytes = 1
+	router := NewRouter(client, testutil.NewLogger(t), cfg)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/tutors/search?group_by=subject&subjects=math&fields=bio", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Sections []struct {
+			Tutors []struct {
+				Bio        string `json:"bio"`
+				BioSnippet string `json:"bio_snippet"`
+			} `json:"tutors"`
+		} `json:"sections"`
+		TruncatedFields []string `json:"truncated_fields"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Sections) != 1 || len(resp.Sections[0].Tutors) != 1 {
+		t.Fatalf("expected one tutor in one section, got %+v", resp.Sections)
+	}
+	if got := resp.Sections[0].Tutors[0]; got.Bio != "" || got.BioSnippet != "" {
+		t.Errorf("expected both bio fields dropped, got %+v", got)
+	}
+	if want := []string{truncatedBio, truncatedBioSnippet}; !slices.Equal(resp.TruncatedFields, want) {
+		t.Errorf("expected truncated fields %v, got %v", want, resp.TruncatedFields)
+	}
+}
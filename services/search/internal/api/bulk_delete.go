@@ -0,0 +1,77 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"search/internal/activity"
+	"search/internal/audit"
+	"search/internal/port"
+)
+
+// maxBulkDeleteIDs caps how many tutors one bulk delete request may name.
+const maxBulkDeleteIDs = 10000
+
+type bulkDeleteRequest struct {
+	IDs []int64 `json:"ids"`
+}
+
+type bulkDeleteResponse struct {
+	Results []port.BulkDeleteResult `json:"results"`
+	Counts  map[string]int          `json:"counts"`
+}
+
+// BulkDeleteTutors deletes the tutors listed in {"ids": [...]} and reports
+// each ID as deleted, not_found or error. Per-ID failures do not fail the
+// request.
+func (h *Handlers) BulkDeleteTutors(w http.ResponseWriter, r *http.Request) {
+	var req bulkDeleteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if len(req.IDs) == 0 {
+		respondError(w, http.StatusBadRequest, "ids must not be empty")
+		return
+	}
+	if len(req.IDs) > maxBulkDeleteIDs {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("At most %d ids can be deleted per request", maxBulkDeleteIDs))
+		return
+	}
+	for _, id := range req.IDs {
+		if id <= 0 {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("Invalid tutor ID %d", id))
+			return
+		}
+	}
+
+	results, err := h.os.BulkDeleteTutors(r.Context(), req.IDs)
+	if err != nil {
+		h.logger.Error("Failed to bulk delete tutors", "ids", len(req.IDs), "error", err)
+		respondBackendError(w, err, "Failed to delete tutors")
+		return
+	}
+
+	counts := map[string]int{
+		port.BulkDeleted:  0,
+		port.BulkNotFound: 0,
+		port.BulkError:    0,
+	}
+	for _, result := range results {
+		counts[result.Status]++
+		if result.Status == port.BulkDeleted {
+			h.activity.Publish(activity.Event{Type: activity.TypeDelete, Source: activity.SourceHTTP, TutorID: result.ID})
+		}
+	}
+
+	audit.SetCount(r.Context(), counts[port.BulkDeleted])
+	h.logger.Info("Bulk deleted tutors",
+		"requested", len(req.IDs),
+		"deleted", counts[port.BulkDeleted],
+		"not_found", counts[port.BulkNotFound],
+		"errors", counts[port.BulkError],
+	)
+
+	respondJSON(w, http.StatusOK, bulkDeleteResponse{Results: results, Counts: counts})
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func TestBulkDeleteTutors_PartialFailure(t *testing.T) {
+	mock := &mockSearchClient{
+		bulkResults: map[int64]port.BulkDeleteResult{
+			2: {ID: 2, Status: port.BulkNotFound},
+			3: {ID: 3, Status: port.BulkError, Error: "es_rejected_execution_exception: queue full"},
+		},
+	}
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+	req := httptest.NewRequest("POST", "/admin/tutors/delete", strings.NewReader(`{"ids": [1, 2, 3, 4]}`))
+	rec := httptest.NewRecorder()
+	handlers.BulkDeleteTutors(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if !slices.Equal(mock.bulkDeletedIDs, []int64{1, 2, 3, 4}) {
+		t.Errorf("expected all IDs to be sent, got %v", mock.bulkDeletedIDs)
+	}
+
+	var response bulkDeleteResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+
+	wantStatuses := []string{port.BulkDeleted, port.BulkNotFound, port.BulkError, port.BulkDeleted}
+	if len(response.Results) != len(wantStatuses) {
+		t.Fatalf("expected %d results, got %v", len(wantStatuses), response.Results)
+	}
+	for i, want := range wantStatuses {
+		if response.Results[i].Status != want {
+			t.Errorf("result %d: expected %s, got %s", i, want, response.Results[i].Status)
+		}
+	}
+	if response.Results[2].Error == "" {
+		t.Error("expected error message for failed ID")
+	}
+	wantCounts := map[string]int{"deleted": 2, "not_found": 1, "error": 1}
+	for status, want := range wantCounts {
+		if response.Counts[status] != want {
+			t.Errorf("expected %s count %d, got %d", status, want, response.Counts[status])
+		}
+	}
+}
+
+func TestBulkDeleteTutors_InvalidRequest(t *testing.T) {
+	tooMany := make([]int64, maxBulkDeleteIDs+1)
+	for i := range tooMany {
+		tooMany[i] = int64(i + 1)
+	}
+	tooManyBody, _ := json.Marshal(map[string]any{"ids": tooMany})
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"invalid body", `ids=1,2`},
+		{"missing ids", `{}`},
+		{"empty ids", `{"ids": []}`},
+		{"zero id", `{"ids": [1, 0]}`},
+		{"negative id", `{"ids": [-3]}`},
+		{"too many ids", string(tooManyBody)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{}
+			handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+			rec := httptest.NewRecorder()
+			handlers.BulkDeleteTutors(rec, httptest.NewRequest("POST", "/admin/tutors/delete", strings.NewReader(tt.body)))
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+			}
+			if mock.bulkDeletedIDs != nil {
+				t.Errorf("expected nothing to be deleted, got %v", mock.bulkDeletedIDs)
+			}
+		})
+	}
+}
+
+func TestBulkDeleteTutors_MaxIDs(t *testing.T) {
+	ids := make([]int64, maxBulkDeleteIDs)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+	body, _ := json.Marshal(map[string]any{"ids": ids})
+	mock := &mockSearchClient{}
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+	rec := httptest.NewRecorder()
+	handlers.BulkDeleteTutors(rec, httptest.NewRequest("POST", "/admin/tutors/delete", bytes.NewReader(body)))
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if len(mock.bulkDeletedIDs) != maxBulkDeleteIDs {
+		t.Errorf("expected %d IDs to be deleted, got %d", maxBulkDeleteIDs, len(mock.bulkDeletedIDs))
+	}
+}
+
+func TestBulkDeleteTutors_Error(t *testing.T) {
+	mock := &mockSearchClient{bulkErr: errors.New("context canceled")}
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+	rec := httptest.NewRecorder()
+	handlers.BulkDeleteTutors(rec, httptest.NewRequest("POST", "/admin/tutors/delete", strings.NewReader(`{"ids": [1]}`)))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+}
+
+func TestRouter_BulkDeleteRequiresAdminKey(t *testing.T) {
+	logger := testutil.NewLogger(t)
+
+	for _, tt := range []struct {
+		authHeader string
+		wantStatus int
+	}{
+		{"", http.StatusUnauthorized},
+		{"Bearer wrong", http.StatusUnauthorized},
+		{"Bearer secret", http.StatusOK},
+	} {
+		t.Run(fmt.Sprintf("auth %q", tt.authHeader), func(t *testing.T) {
+			mock := &mockSearchClient{}
+			cfg := testRouterConfig()
+			cfg.AdminAPIKey = "secret"
+			router := NewRouter(mock, logger, cfg)
+
+			req := httptest.NewRequest("POST", "/admin/tutors/delete", strings.NewReader(`{"ids": [5]}`))
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if (mock.bulkDeletedIDs != nil) != (tt.wantStatus == http.StatusOK) {
+				t.Errorf("unexpected deleted IDs %v", mock.bulkDeletedIDs)
+			}
+		})
+	}
+}
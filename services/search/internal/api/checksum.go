@@ -0,0 +1,38 @@
+package api
+
+import (
+	"net/http"
+
+	"search/internal/port"
+)
+
+// indexChecksum is the body of GET /admin/checksum.
+type indexChecksum struct {
+	Index     string `json:"index"`
+	Algorithm string `json:"algorithm"`
+	port.Checksum
+}
+
+// IndexChecksum digests the ID and updated_at of every tutor in the index,
+// for comparing it with Django or another environment without copying the
+// documents. prefix_buckets=N also digests the tutors in N buckets by ID
+// modulo N, to narrow down which ones differ.
+func (h *Handlers) IndexChecksum(w http.ResponseWriter, r *http.Request) {
+	buckets, ok := intParam(w, r.URL.Query().Get("prefix_buckets"), "prefix_buckets", 0, 0, port.MaxChecksumBuckets)
+	if !ok {
+		return
+	}
+
+	ctx := r.Context()
+	sum := port.NewChecksummer(buckets)
+	if err := h.os.ScanTutorVersions(ctx, sum.Add); err != nil {
+		h.logger.Error("Failed to checksum index", "error", err)
+		respondBackendError(w, err, "Failed to checksum index")
+		return
+	}
+	respondJSON(w, http.StatusOK, indexChecksum{
+		Index:     port.IndexFor(ctx),
+		Algorithm: port.ChecksumAlgorithm,
+		Checksum:  sum.Sum(),
+	})
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"search/internal/domain"
+	"search/internal/opensearch"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+// Digests of checksumFixture, computed independently as
+// sha256("1:1772366400000000\n2:1772440200123456\n5:0\n17:1768435200000000\n")
+// and the same per bucket of ID modulo 4.
+const (
+	fixtureDigest = "6408ddf53b06fdf2d462f89046476ff579d3658786bb97d2bf4cd978d496bd76"
+	emptyDigest   = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+func checksumFixture(t *testing.T) *opensearch.MemoryClient {
+	t.Helper()
+	client := opensearch.NewMemoryClient()
+	for _, tutor := range []domain.Tutor{
+		{ID: 17, UpdatedAt: time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, UpdatedAt: time.Date(2026, 3, 2, 10, 30, 0, 123456000, time.FixedZone("EET", 2*3600))},
+		{ID: 5},
+		{ID: 1, UpdatedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)},
+	} {
+		if err := client.UpsertTutor(context.Background(), &tutor); err != nil {
+			t.Fatalf("failed to seed tutor %d: %v", tutor.ID, err)
+		}
+	}
+	return client
+}
+
+func getChecksum(t *testing.T, get func(string) *httptest.ResponseRecorder, path string) indexChecksum {
+	t.Helper()
+	rec := get(path)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var body indexChecksum
+	if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return body
+}
+
+func TestIndexChecksum(t *testing.T) {
+	get := newQualityRouter(t, checksumFixture(t))
+
+	got := getChecksum(t, get, "/admin/checksum")
+	if got.Index != "tutors" || got.Algorithm != port.ChecksumAlgorithm {
+		t.Errorf("unexpected index %q or algorithm %q", got.Index, got.Algorithm)
+	}
+	if got.Count != 4 || got.Digest.Digest != fixtureDigest {
+		t.Errorf("expected 4 tutors digesting to %s, got %d and %s", fixtureDigest, got.Count, got.Digest.Digest)
+	}
+	if got.Buckets != nil {
+		t.Errorf("expected no buckets by default, got %+v", got.Buckets)
+	}
+}
+
+func TestIndexChecksum_PrefixBuckets(t *testing.T) {
+	client := checksumFixture(t)
+	get := newQualityRouter(t, client)
+
+	want := []port.BucketDigest{
+		{Bucket: 0, Digest: port.Digest{Count: 0, Digest: emptyDigest}},
+		{Bucket: 1, Digest: port.Digest{Count: 3, Digest: "36acd708897e5a50a6b399cc4dfcdd9fde42c5ff266383eb94cf726eb8bae610"}},
+		{Bucket: 2, Digest: port.Digest{Count: 1, Digest: "2f9f45caa289882b96717dc90a7b2f6eeb8c165b9bd9faed820b808f2d04f6c5"}},
+		{Bucket: 3, Digest: port.Digest{Count: 0, Digest: emptyDigest}},
+	}
+	got := getChecksum(t, get, "/admin/checksum?prefix_buckets=4")
+	if got.Digest.Digest != fixtureDigest || len(got.Buckets) != len(want) {
+		t.Fatalf("unexpected checksum %+v", got)
+	}
+	for i := range want {
+		if got.Buckets[i] != want[i] {
+			t.Errorf("bucket %d: expected %+v, got %+v", i, want[i], got.Buckets[i])
+		}
+	}
+
+	// A newer write changes the digest and only its own bucket.
+	client.UpsertTutor(context.Background(), &domain.Tutor{ID: 2, UpdatedAt: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)})
+	changed := getChecksum(t, get, "/admin/checksum?prefix_buckets=4")
+	if changed.Digest.Digest == fixtureDigest {
+		t.Error("expected the digest to change")
+	}
+	for i, b := range changed.Buckets {
+		if (b == want[i]) == (i == 2) {
+			t.Errorf("bucket %d: expected only bucket 2 to change, got %+v", i, b)
+		}
+	}
+}
+
+func TestIndexChecksum_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		client port.SearchClient
+		path   string
+		status int
+	}{
+		{"buckets not a number", &mockSearchClient{}, "/admin/checksum?prefix_buckets=many", http.StatusBadRequest},
+		{"too many buckets", &mockSearchClient{}, "/admin/checksum?prefix_buckets=1025", http.StatusBadRequest},
+		{"backend error", &mockSearchClient{indexedErr: errors.New("cluster unavailable")}, "/admin/checksum", http.StatusInternalServerError},
+		{"out of order", &mockSearchClient{indexedIDs: []int64{2, 1}}, "/admin/checksum", http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := newQualityRouter(t, tt.client)(tt.path); rec.Code != tt.status {
+				t.Errorf("expected %d, got %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestIndexChecksum_RequiresAdminKey(t *testing.T) {
+	cfg := testRouterConfig()
+	cfg.AdminAPIKey = testAdminKey
+	router := NewRouter(checksumFixture(t), testutil.NewLogger(t), cfg)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/admin/checksum", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected %d, got %d", http.StatusUnauthorized, rec.Code)
+	}
+}
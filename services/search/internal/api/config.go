@@ -0,0 +1,76 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"search/internal/relevance"
+)
+
+// RelevanceReloader is implemented by *relevance.Source.
+type RelevanceReloader interface {
+	Reload() error
+	Status() relevance.Status
+}
+
+type configResponse struct {
+	// Service is the effective service config, secrets redacted.
+	Service   map[string]any    `json:"service,omitempty"`
+	Relevance *relevance.Status `json:"relevance,omitempty"`
+}
+
+// EffectiveConfig reports the service config and relevance settings in
+// effect. Secrets are redacted the way the startup log redacts them.
+func (h *Handlers) EffectiveConfig(w http.ResponseWriter, r *http.Request) {
+	var resp configResponse
+	if h.settings != nil {
+		resp.Service, _ = logValueJSON(h.settings.LogValue()).(map[string]any)
+	}
+	if h.relevance != nil {
+		st := h.relevance.Status()
+		resp.Relevance = &st
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// ReloadConfig rereads the relevance config file without waiting for the
+// watcher to notice a change. A rejected file leaves the previous settings
+// in effect.
+func (h *Handlers) ReloadConfig(w http.ResponseWriter, r *http.Request) {
+	if h.relevance == nil {
+		respondError(w, http.StatusNotFound, "Relevance config file is not configured")
+		return
+	}
+	err := h.relevance.Reload()
+	if errors.Is(err, relevance.ErrNoFile) {
+		respondError(w, http.StatusNotFound, "Relevance config file is not configured")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusUnprocessableEntity, "Relevance config rejected, previous config kept: "+err.Error())
+		return
+	}
+	respondJSON(w, http.StatusOK, h.relevance.Status())
+}
+
+// logValueJSON turns a resolved slog value into something encoding/json
+// renders readably: groups become objects and durations strings.
+func logValueJSON(v slog.Value) any {
+	v = v.Resolve()
+	switch v.Kind() {
+	case slog.KindGroup:
+		m := make(map[string]any, len(v.Group()))
+		for _, a := range v.Group() {
+			m[a.Key] = logValueJSON(a.Value)
+		}
+		return m
+	case slog.KindDuration:
+		return v.Duration().String()
+	case slog.KindTime:
+		return v.Time().Format(time.RFC3339)
+	default:
+		return v.Any()
+	}
+}
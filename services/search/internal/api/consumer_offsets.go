@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"search/internal/kafka"
+)
+
+// ConsumerOffsets is implemented by *kafka.Consumer.
+type ConsumerOffsets interface {
+	GroupID() string
+	Offsets(ctx context.Context) ([]kafka.PartitionOffset, error)
+	Seek(ctx context.Context, target kafka.SeekTarget) ([]kafka.PartitionOffset, error)
+}
+
+// ConsumerStatusReporter is implemented by *kafka.Consumer.
+type ConsumerStatusReporter interface {
+	Status() kafka.Status
+}
+
+type consumerOffsetsResponse struct {
+	GroupID    string                  `json:"group_id"`
+	Partitions []kafka.PartitionOffset `json:"partitions"`
+}
+
+type consumerSeekRequest struct {
+	To        string `json:"to"`
+	Timestamp string `json:"timestamp"`
+	Confirm   string `json:"confirm"`
+}
+
+type consumerSeekResponse struct {
+	Status     string                  `json:"status"`
+	GroupID    string                  `json:"group_id"`
+	To         string                  `json:"to"`
+	Partitions []kafka.PartitionOffset `json:"partitions"`
+}
+
+// ConsumerGroupOffsets reports the consumer group's committed offset,
+// high-water mark and lag on every partition.
+func (h *Handlers) ConsumerGroupOffsets(w http.ResponseWriter, r *http.Request) {
+	if h.consumerOffsets == nil {
+		respondError(w, http.StatusNotFound, "Kafka consumer is not enabled")
+		return
+	}
+
+	offsets, err := h.consumerOffsets.Offsets(r.Context())
+	if errors.Is(err, kafka.ErrSeekUnsupported) {
+		respondError(w, http.StatusNotFound, "Consumer group offsets are not available")
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to load consumer offsets", "error", err)
+		respondError(w, http.StatusInternalServerError, "Failed to load consumer offsets")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, consumerOffsetsResponse{
+		GroupID:    h.consumerOffsets.GroupID(),
+		Partitions: offsets,
+	})
+}
+
+// SeekConsumer moves the consumer group to the earliest or latest offset, or
+// to a timestamp, on every partition, restarting consumption from there.
+func (h *Handlers) SeekConsumer(w http.ResponseWriter, r *http.Request) {
+	if h.consumerOffsets == nil {
+		respondError(w, http.StatusNotFound, "Kafka consumer is not enabled")
+		return
+	}
+
+	var req consumerSeekRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	// Like index recreation, the target must be named: the group ID guards
+	// against rewinding the wrong deployment's consumer.
+	group := h.consumerOffsets.GroupID()
+	if req.Confirm != group {
+		respondError(w, http.StatusBadRequest, `Seeking skips or replays events for the whole group; send "confirm": "`+group+`"`)
+		return
+	}
+
+	target := kafka.SeekTarget{To: req.To}
+	if req.Timestamp != "" {
+		ts, err := time.Parse(time.RFC3339, req.Timestamp)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "timestamp must be RFC 3339")
+			return
+		}
+		target.Timestamp = ts
+	}
+	if err := target.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	offsets, err := h.consumerOffsets.Seek(r.Context(), target)
+	if errors.Is(err, kafka.ErrSeekUnsupported) {
+		respondError(w, http.StatusNotFound, "Consumer group offsets are not available")
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to seek consumer", "to", target.To, "error", err)
+		respondError(w, http.StatusInternalServerError, "Failed to seek consumer")
+		return
+	}
+
+	h.logger.Warn("Consumer group seeked",
+		"group_id", group,
+		"to", target.To,
+		"timestamp", req.Timestamp,
+	)
+
+	respondJSON(w, http.StatusOK, consumerSeekResponse{
+		Status:     "seeked",
+		GroupID:    group,
+		To:         target.To,
+		Partitions: offsets,
+	})
+}
+
+// ConsumerStatus reports when the consumer last read a message, where, and
+// its idle heartbeats, to tell a quiet topic from a wedged consumer.
+func (h *Handlers) ConsumerStatus(w http.ResponseWriter, r *http.Request) {
+	if h.consumerStatus == nil {
+		respondError(w, http.StatusNotFound, "Kafka consumer is not enabled")
+		return
+	}
+	respondJSON(w, http.StatusOK, h.consumerStatus.Status())
+}
@@ -0,0 +1,212 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"search/internal/kafka"
+	"search/internal/testutil"
+)
+
+type fakeConsumerOffsets struct {
+	offsets []kafka.PartitionOffset
+	err     error
+	seeks   []kafka.SeekTarget
+}
+
+func (f *fakeConsumerOffsets) GroupID() string { return "search-service" }
+
+func (f *fakeConsumerOffsets) Offsets(context.Context) ([]kafka.PartitionOffset, error) {
+	return f.offsets, f.err
+}
+
+func (f *fakeConsumerOffsets) Seek(_ context.Context, target kafka.SeekTarget) ([]kafka.PartitionOffset, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.seeks = append(f.seeks, target)
+	return f.offsets, nil
+}
+
+func TestConsumerGroupOffsets(t *testing.T) {
+	logger := testutil.NewLogger(t)
+	partitions := []kafka.PartitionOffset{{Topic: "tutor-events", Partition: 0, Committed: 40, HighWater: 42, Lag: 2}}
+
+	tests := []struct {
+		name       string
+		consumer   *fakeConsumerOffsets
+		wantStatus int
+	}{
+		{"offsets", &fakeConsumerOffsets{offsets: partitions}, http.StatusOK},
+		{"consumer disabled", nil, http.StatusNotFound},
+		{"offsets unavailable", &fakeConsumerOffsets{err: kafka.ErrSeekUnsupported}, http.StatusNotFound},
+		{"broker error", &fakeConsumerOffsets{err: errors.New("no coordinator")}, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var opts []Option
+			if tt.consumer != nil {
+				opts = append(opts, WithConsumerOffsets(tt.consumer))
+			}
+			handlers := NewHandlers(&mockSearchClient{}, logger, opts...)
+			rec := httptest.NewRecorder()
+
+			handlers.ConsumerGroupOffsets(rec, httptest.NewRequest("GET", "/admin/consumer/offsets", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var resp consumerOffsetsResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.GroupID != "search-service" || len(resp.Partitions) != 1 || resp.Partitions[0].Lag != 2 {
+				t.Errorf("unexpected response %+v", resp)
+			}
+		})
+	}
+}
+
+func TestSeekConsumer(t *testing.T) {
+	logger := testutil.NewLogger(t)
+	at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantTarget *kafka.SeekTarget
+	}{
+		{"earliest", `{"to": "earliest", "confirm": "search-service"}`, http.StatusOK, &kafka.SeekTarget{To: kafka.SeekEarliest}},
+		{"latest", `{"to": "latest", "confirm": "search-service"}`, http.StatusOK, &kafka.SeekTarget{To: kafka.SeekLatest}},
+		{"timestamp", `{"to": "timestamp", "timestamp": "2026-05-01T12:00:00Z", "confirm": "search-service"}`, http.StatusOK, &kafka.SeekTarget{To: kafka.SeekTimestamp, Timestamp: at}},
+		{"missing confirm", `{"to": "latest"}`, http.StatusBadRequest, nil},
+		{"wrong confirm", `{"to": "latest", "confirm": "true"}`, http.StatusBadRequest, nil},
+		{"unknown target", `{"to": "yesterday", "confirm": "search-service"}`, http.StatusBadRequest, nil},
+		{"timestamp missing", `{"to": "timestamp", "confirm": "search-service"}`, http.StatusBadRequest, nil},
+		{"timestamp malformed", `{"to": "timestamp", "timestamp": "May 1", "confirm": "search-service"}`, http.StatusBadRequest, nil},
+		{"invalid body", `to=latest`, http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consumer := &fakeConsumerOffsets{}
+			handlers := NewHandlers(&mockSearchClient{}, logger, WithConsumerOffsets(consumer))
+			rec := httptest.NewRecorder()
+
+			handlers.SeekConsumer(rec, httptest.NewRequest("POST", "/admin/consumer/seek", strings.NewReader(tt.body)))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tt.wantTarget == nil {
+				if len(consumer.seeks) != 0 {
+					t.Errorf("expected no seek, got %+v", consumer.seeks)
+				}
+				return
+			}
+			if len(consumer.seeks) != 1 || consumer.seeks[0] != *tt.wantTarget {
+				t.Errorf("expected seek to %+v, got %+v", *tt.wantTarget, consumer.seeks)
+			}
+		})
+	}
+}
+
+func TestSeekConsumer_Errors(t *testing.T) {
+	logger := testutil.NewLogger(t)
+	body := `{"to": "latest", "confirm": "search-service"}`
+
+	handlers := NewHandlers(&mockSearchClient{}, logger)
+	rec := httptest.NewRecorder()
+	handlers.SeekConsumer(rec, httptest.NewRequest("POST", "/admin/consumer/seek", strings.NewReader(body)))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d without a consumer, got %d", http.StatusNotFound, rec.Code)
+	}
+
+	handlers = NewHandlers(&mockSearchClient{}, logger, WithConsumerOffsets(&fakeConsumerOffsets{err: errors.New("rebalance in progress")}))
+	rec = httptest.NewRecorder()
+	handlers.SeekConsumer(rec, httptest.NewRequest("POST", "/admin/consumer/seek", strings.NewReader(body)))
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d on a failed seek, got %d", http.StatusInternalServerError, rec.Code)
+	}
+}
+
+func TestRouter_ConsumerEndpointsRequireAdminKey(t *testing.T) {
+	logger := testutil.NewLogger(t)
+	consumer := &fakeConsumerOffsets{}
+	cfg := testRouterConfig()
+	cfg.AdminAPIKey = "secret"
+	cfg.ConsumerOffsets = consumer
+	router := NewRouter(&mockSearchClient{}, logger, cfg)
+
+	for _, tc := range []struct{ method, path, body string }{
+		{"GET", "/admin/consumer/offsets", ""},
+		{"POST", "/admin/consumer/seek", `{"to": "earliest", "confirm": "search-service"}`},
+	} {
+		req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s %s: expected status %d without a key, got %d", tc.method, tc.path, http.StatusUnauthorized, rec.Code)
+		}
+
+		req = httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		req.Header.Set("Authorization", "Bearer secret")
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Errorf("%s %s: expected status %d with the key, got %d", tc.method, tc.path, http.StatusOK, rec.Code)
+		}
+	}
+	if len(consumer.seeks) != 1 {
+		t.Errorf("expected exactly one authorized seek, got %d", len(consumer.seeks))
+	}
+}
+
+type fakeConsumerStatus kafka.Status
+
+func (f fakeConsumerStatus) Status() kafka.Status { return kafka.Status(f) }
+
+func TestConsumerStatus(t *testing.T) {
+	logger := testutil.NewLogger(t)
+	lastMessage := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	status := fakeConsumerStatus{
+		GroupID:       "search-service",
+		LastMessageAt: &lastMessage,
+		LastPosition:  &kafka.Position{Topic: "tutor-events", Partition: 1, Offset: 41, Lag: 0},
+		IdleSeconds:   180,
+		Heartbeats:    3,
+	}
+
+	handlers := NewHandlers(&mockSearchClient{}, logger, WithConsumerStatus(status))
+	rec := httptest.NewRecorder()
+	handlers.ConsumerStatus(rec, httptest.NewRequest("GET", "/admin/consumer/status", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	var resp kafka.Status
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.LastMessageAt == nil || !resp.LastMessageAt.Equal(lastMessage) || resp.Heartbeats != 3 || resp.LastPosition.Offset != 41 {
+		t.Errorf("unexpected response %+v", resp)
+	}
+
+	disabled := NewHandlers(&mockSearchClient{}, logger)
+	rec = httptest.NewRecorder()
+	disabled.ConsumerStatus(rec, httptest.NewRequest("GET", "/admin/consumer/status", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d without a consumer, got %d", http.StatusNotFound, rec.Code)
+	}
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"crypto/rand"
+	"embed"
+	"encoding/hex"
+	"html/template"
+	"net/http"
+)
+
+//go:embed dashboard.html
+var dashboardFS embed.FS
+
+var dashboardTemplate = template.Must(template.ParseFS(dashboardFS, "dashboard.html"))
+
+// dashboardPanel is one JSON endpoint the dashboard fetches and shows.
+type dashboardPanel struct {
+	Title string
+	Path  string
+}
+
+// dashboardPanels are fetched by the page itself, from the same origin, so
+// the page needs no data of its own and each panel fails on its own.
+var dashboardPanels = []dashboardPanel{
+	{"Readiness", "/health/ready"},
+	{"Index", "/admin/index/stats"},
+	{"Kafka consumer", "/admin/consumer/status"},
+	{"Kafka events", "/admin/events/stats"},
+}
+
+// Dashboard serves a status page that polls dashboardPanels. It loads no
+// external assets; its one inline script is allowed by a per-request CSP
+// nonce.
+func (h *Handlers) Dashboard(w http.ResponseWriter, r *http.Request) {
+	var b [16]byte
+	rand.Read(b[:])
+	nonce := hex.EncodeToString(b[:])
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy",
+		"default-src 'none'; connect-src 'self'; style-src 'nonce-"+nonce+"'; script-src 'nonce-"+nonce+"'")
+	w.Header().Set("Cache-Control", "no-store")
+	err := dashboardTemplate.Execute(w, map[string]any{
+		"Nonce":  nonce,
+		"Panels": dashboardPanels,
+	})
+	if err != nil {
+		h.logger.Error("Failed to render dashboard", "error", err)
+	}
+}
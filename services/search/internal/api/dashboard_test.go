@@ -0,0 +1,131 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func dashboardRouter(t *testing.T) http.Handler {
+	cfg := testRouterConfig()
+	cfg.AdminAPIKey = testAdminKey
+	return NewRouter(&mockSearchClient{indexedIDs: []int64{1, 2, 3}}, testutil.NewLogger(t), cfg)
+}
+
+func adminGet(router http.Handler, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", path, nil)
+	req.Header.Set("Authorization", "Bearer "+testAdminKey)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestDashboard_RequiresAdminKey(t *testing.T) {
+	rec := httptest.NewRecorder()
+	dashboardRouter(t).ServeHTTP(rec, httptest.NewRequest("GET", "/admin/dashboard", nil))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, rec.Code)
+	}
+}
+
+func TestDashboard_Renders(t *testing.T) {
+	router := dashboardRouter(t)
+	rec := adminGet(router, "/admin/dashboard")
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("expected an HTML page, got %q", ct)
+	}
+	csp := rec.Header().Get("Content-Security-Policy")
+	nonce := regexp.MustCompile(`script-src 'nonce-([^']+)'`).FindStringSubmatch(csp)
+	if nonce == nil || !strings.Contains(csp, "connect-src 'self'") {
+		t.Fatalf("expected a same-origin CSP with a script nonce, got %q", csp)
+	}
+	body := rec.Body.String()
+	if !strings.Contains(body, `<script nonce="`+nonce[1]+`">`) {
+		t.Error("expected the inline script to carry the CSP nonce")
+	}
+
+	// Nothing is loaded from another origin.
+	if m := regexp.MustCompile(`(?i)(https?:)?//[a-z0-9]|\b(src|href)=`).FindString(body); m != "" {
+		t.Errorf("expected only same-origin requests, found %q", m)
+	}
+
+	endpoints := regexp.MustCompile(`data-endpoint="([^"]*)"`).FindAllStringSubmatch(body, -1)
+	if len(endpoints) != len(dashboardPanels) {
+		t.Fatalf("expected %d panels, got %d", len(dashboardPanels), len(endpoints))
+	}
+	for _, m := range endpoints {
+		path := m[1]
+		if !strings.HasPrefix(path, "/") || strings.HasPrefix(path, "//") {
+			t.Errorf("expected a same-origin path, got %q", path)
+			continue
+		}
+		// Every panel is a routed JSON endpoint, even when its feature is
+		// disabled and it answers 404.
+		rec := adminGet(router, path)
+		if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("expected %s to answer JSON, got %d %q", path, rec.Code, ct)
+		}
+	}
+}
+
+func TestIndexStats(t *testing.T) {
+	rec := adminGet(dashboardRouter(t), "/admin/index/stats")
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if got := strings.TrimSpace(rec.Body.String()); got != `{"index":"tutors","tutors":3}` {
+		t.Errorf("unexpected body %s", got)
+	}
+}
+
+func TestIndexStats_BackendError(t *testing.T) {
+	mock := &mockSearchClient{indexedErr: errors.New("cluster unavailable")}
+	rec := httptest.NewRecorder()
+	NewHandlers(mock, testutil.NewLogger(t)).IndexStats(rec, httptest.NewRequest("GET", "/admin/index/stats", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+}
+
+type fixedRate float64
+
+func (r fixedRate) Rate() float64 { return float64(r) }
+
+func TestIndexStats_IndexingRate(t *testing.T) {
+	mock := &mockSearchClient{indexedIDs: []int64{1}}
+	rec := httptest.NewRecorder()
+	NewHandlers(mock, testutil.NewLogger(t), WithIndexingRate(fixedRate(12.5))).
+		IndexStats(rec, httptest.NewRequest("GET", "/admin/index/stats", nil))
+
+	if got := strings.TrimSpace(rec.Body.String()); got != `{"index":"tutors","tutors":1,"indexing_rate":12.5}` {
+		t.Errorf("unexpected body %s", got)
+	}
+}
+
+type fixedConnections port.ConnectionStats
+
+func (c fixedConnections) ConnectionStats() port.ConnectionStats { return port.ConnectionStats(c) }
+
+func TestIndexStats_Connections(t *testing.T) {
+	mock := &mockSearchClient{indexedIDs: []int64{1}}
+	rec := httptest.NewRecorder()
+	NewHandlers(mock, testutil.NewLogger(t), WithConnectionStats(fixedConnections{New: 3, Reused: 120})).
+		IndexStats(rec, httptest.NewRequest("GET", "/admin/index/stats", nil))
+
+	if got := strings.TrimSpace(rec.Body.String()); got != `{"index":"tutors","tutors":1,"connections":{"new":3,"reused":120}}` {
+		t.Errorf("unexpected body %s", got)
+	}
+}
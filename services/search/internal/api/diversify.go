@@ -0,0 +1,68 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"search/internal/domain"
+	"search/internal/port"
+)
+
+// checkDiversify returns a 400 body listing the valid fields when
+// diversify_by names one results cannot be collapsed on.
+func checkDiversify(q url.Values) *QueryError {
+	field := q.Get("diversify_by")
+	if field == "" || slices.Contains(port.DiversifyFields, strings.ToLower(field)) {
+		return nil
+	}
+	return &QueryError{
+		Error: fmt.Sprintf("cannot diversify by %q", field),
+		Param: "diversify_by",
+		Valid: port.DiversifyFields,
+	}
+}
+
+// diversifiedResponse is a SearchResponse with each result's collapsed
+// alternates nested under it, and its facets if it has any.
+type diversifiedResponse struct {
+	*port.SearchResponse
+	Results []diversifiedHit `json:"results"`
+	Facets  *facetsBody      `json:"facets,omitempty"`
+}
+
+type diversifiedHit struct {
+	domain.Tutor
+	Alternates []domain.Tutor `json:"alternates,omitempty"`
+}
+
+// responseBody returns result as it is sent: nesting its alternates when
+// the search was diversified, after stripping and localizing them like
+// the results, and adding its facets in lang when it was run with them.
+func (h *Handlers) responseBody(r *http.Request, result *port.SearchResponse, lang string) any {
+	var facets *facetsBody
+	if result.Facets != nil {
+		f := h.facetsBody(result.Facets, lang)
+		facets = &f
+	}
+	if result.Alternates == nil {
+		if facets == nil {
+			return result
+		}
+		return facetedResponse{SearchResponse: result, Facets: facets}
+	}
+	resp := diversifiedResponse{
+		SearchResponse: result,
+		Results:        make([]diversifiedHit, len(result.Results)),
+		Facets:         facets,
+	}
+	for i, t := range result.Results {
+		alternates := result.Alternates[t.ID]
+		stripIndexMeta(r, alternates)
+		h.localizeSubjects(alternates, lang)
+		resp.Results[i] = diversifiedHit{Tutor: t, Alternates: alternates}
+	}
+	return resp
+}
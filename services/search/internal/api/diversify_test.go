@@ -0,0 +1,91 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"search/internal/domain"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func TestSearchTutors_DiversifyBy(t *testing.T) {
+	mock := &mockSearchClient{searchResult: &port.SearchResponse{
+		Results: []domain.Tutor{{ID: 3, Location: "London"}, {ID: 1, Location: "Paris"}},
+		Total:   4,
+		Alternates: map[int64][]domain.Tutor{
+			3: {{ID: 2, Location: "London"}, {ID: 5, Location: "London"}},
+		},
+	}}
+	router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/tutors/search?diversify_by=Location", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if mock.searchedQuery.DiversifyBy != port.DiversifyLocation {
+		t.Errorf("expected diversify_by location, got %q", mock.searchedQuery.DiversifyBy)
+	}
+
+	var resp struct {
+		Results []struct {
+			ID         int64          `json:"id"`
+			Alternates []domain.Tutor `json:"alternates"`
+		} `json:"results"`
+		Total          int              `json:"total"`
+		AppliedFilters port.SearchQuery `json:"applied_filters"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Results) != 2 || resp.Total != 4 || resp.AppliedFilters.DiversifyBy != port.DiversifyLocation {
+		t.Fatalf("unexpected response %s", rec.Body.String())
+	}
+	var alternates []int64
+	for _, a := range resp.Results[0].Alternates {
+		alternates = append(alternates, a.ID)
+	}
+	if !slices.Equal(alternates, []int64{2, 5}) || resp.Results[1].Alternates != nil {
+		t.Errorf("expected tutors 2 and 5 under the first result only, got %s", rec.Body.String())
+	}
+}
+
+func TestSearchTutors_DiversifyByWithoutAlternates(t *testing.T) {
+	mock := &mockSearchClient{searchResult: &port.SearchResponse{Results: []domain.Tutor{{ID: 1}}, Total: 1}}
+	router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/tutors/search", nil))
+
+	var resp map[string][]map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	if _, ok := resp["results"][0]["alternates"]; ok {
+		t.Errorf("expected no alternates on an undiversified search, got %s", rec.Body.String())
+	}
+}
+
+func TestSearchTutors_InvalidDiversifyBy(t *testing.T) {
+	for _, field := range []string{"subjects", "rating"} {
+		t.Run(field, func(t *testing.T) {
+			mock := &mockSearchClient{searchResult: &port.SearchResponse{}}
+			router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest("GET", "/tutors/search?diversify_by="+field, nil))
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+			}
+			var resp QueryError
+			json.Unmarshal(rec.Body.Bytes(), &resp)
+			if resp.Param != "diversify_by" || !slices.Equal(resp.Valid, port.DiversifyFields) {
+				t.Errorf("unexpected error response %+v", resp)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"net/http"
+
+	"search/internal/drift"
+)
+
+// DriftReporter is implemented by *drift.Checker.
+type DriftReporter interface {
+	Latest() *drift.Result
+}
+
+// IndexDrift reports the latest comparison of the index document count
+// with the total Django reports.
+func (h *Handlers) IndexDrift(w http.ResponseWriter, r *http.Request) {
+	if h.drift == nil {
+		respondError(w, http.StatusNotFound, "Drift check is not enabled")
+		return
+	}
+	latest := h.drift.Latest()
+	if latest == nil {
+		respondError(w, http.StatusServiceUnavailable, "Drift check has not run yet")
+		return
+	}
+	respondJSON(w, http.StatusOK, latest)
+}
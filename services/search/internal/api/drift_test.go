@@ -0,0 +1,60 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"search/internal/drift"
+	"search/internal/testutil"
+)
+
+// fakeDrift reports a fixed result; nil means no check has run.
+type fakeDrift struct{ result *drift.Result }
+
+func (f fakeDrift) Latest() *drift.Result { return f.result }
+
+func TestIndexDrift(t *testing.T) {
+	cfg := testRouterConfig()
+	cfg.Drift = fakeDrift{&drift.Result{DjangoCount: 200, IndexCount: 180, Difference: -20, Drift: 0.1, Threshold: 0.01, Exceeded: true}}
+	cfg.AdminAPIKey = testAdminKey
+	router := NewRouter(&mockSearchClient{}, testutil.NewLogger(t), cfg)
+
+	rec := serve(router, "GET", "/admin/drift", "", "Bearer "+testAdminKey)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var resp drift.Result
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.DjangoCount != 200 || resp.IndexCount != 180 || resp.Difference != -20 || !resp.Exceeded {
+		t.Errorf("expected the latest result, got %+v", resp)
+	}
+}
+
+func TestIndexDrift_NotReady(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []Option
+		wantStatus int
+	}{
+		{"not enabled", nil, http.StatusNotFound},
+		{"no check yet", []Option{WithDriftReporter(fakeDrift{})}, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := NewHandlers(&mockSearchClient{}, testutil.NewLogger(t), tt.opts...)
+
+			rec := httptest.NewRecorder()
+			handlers.IndexDrift(rec, httptest.NewRequest("GET", "/admin/drift", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// maxPooledBuffer caps the capacity of buffers returned to bufferPool, so
+// one huge response does not pin its memory for the life of the process.
+const maxPooledBuffer = 256 << 10
+
+// bufferPool holds the buffers JSON responses are encoded into and
+// TimeoutMiddleware collects handler output in.
+var bufferPool = sync.Pool{
+	New: func() any { return new(bytes.Buffer) },
+}
+
+func getBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBuffer {
+		return
+	}
+	bufferPool.Put(buf)
+}
+
+// respondJSON encodes data into a pooled buffer and writes it in one call
+// with status. The body is byte for byte what json.Encoder writes, trailing
+// newline included. Content-Length is set unless a middleware has set a
+// Content-Encoding, which would change the length on the wire. A value
+// that fails to encode gets a 500 instead of a truncated body.
+func respondJSON(w http.ResponseWriter, status int, data any) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+
+	h := w.Header()
+	if err := json.NewEncoder(buf).Encode(data); err != nil {
+		buf.Reset()
+		buf.WriteString(`{"error":"Failed to encode response"}` + "\n")
+		status = http.StatusInternalServerError
+	}
+	h.Set("Content-Type", "application/json")
+	if h.Get("Content-Encoding") == "" {
+		h.Set("Content-Length", strconv.Itoa(buf.Len()))
+	}
+	w.WriteHeader(status)
+	_, _ = w.Write(buf.Bytes())
+}
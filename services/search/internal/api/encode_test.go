@@ -0,0 +1,155 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"search/internal/domain"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+// respondJSONUnpooled is respondJSON as it was before pooling, kept as the
+// reference for output and the baseline for the benchmarks.
+func respondJSONUnpooled(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(data)
+}
+
+func benchmarkSearchResponse() *port.SearchResponse {
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	resp := &port.SearchResponse{Total: 240, TookMs: 12, ShardsTotal: 1}
+	for i := range 20 {
+		resp.Results = append(resp.Results, domain.Tutor{
+			ID:           int64(i + 1),
+			Slug:         "tutor-" + strconv.Itoa(i+1),
+			FullName:     "Ada Lovelace",
+			Headline:     "Math & physics <for> \"everyone\"",
+			BioSnippet:   "Ten years of teaching calculus, algebra and mechanics to school and university students.",
+			Subjects:     []string{"math", "physics"},
+			HourlyRate:   40,
+			Rating:       4.8,
+			ReviewsCount: 120,
+			IsVerified:   true,
+			Location:     "Berlin",
+			Formats:      []string{"online", "in_person"},
+			Levels:       []string{"school", "university"},
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		})
+	}
+	return resp
+}
+
+func TestRespondJSON_MatchesEncoder(t *testing.T) {
+	values := []any{
+		map[string]string{"error": "Tutor not found"},
+		map[string]any{"status": "ok", "html": "<b>&</b>", "unicode": "Привет  "},
+		[]int{1, 2, 3},
+		nil,
+		"plain",
+		benchmarkSearchResponse(),
+	}
+	for i, v := range values {
+		want := httptest.NewRecorder()
+		respondJSONUnpooled(want, http.StatusOK, v)
+		got := httptest.NewRecorder()
+		respondJSON(got, http.StatusOK, v)
+
+		if !bytes.Equal(got.Body.Bytes(), want.Body.Bytes()) {
+			t.Errorf("value %d: body differs\ngot:  %s\nwant: %s", i, got.Body.Bytes(), want.Body.Bytes())
+		}
+		if cl := got.Header().Get("Content-Length"); cl != strconv.Itoa(want.Body.Len()) {
+			t.Errorf("value %d: expected Content-Length %d, got %q", i, want.Body.Len(), cl)
+		}
+		if ct := got.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("value %d: expected Content-Type application/json, got %q", i, ct)
+		}
+	}
+}
+
+func TestRespondJSON_ReusedBuffersDoNotLeak(t *testing.T) {
+	big := httptest.NewRecorder()
+	respondJSON(big, http.StatusOK, benchmarkSearchResponse())
+
+	small := httptest.NewRecorder()
+	respondJSON(small, http.StatusNotFound, map[string]string{"error": "Not found"})
+
+	if small.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", small.Code)
+	}
+	if got := small.Body.String(); got != `{"error":"Not found"}`+"\n" {
+		t.Errorf("expected only the small body, got %q", got)
+	}
+}
+
+func TestRespondJSON_ContentEncodingSkipsLength(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Content-Encoding", "gzip")
+	respondJSON(rec, http.StatusOK, map[string]string{"status": "ok"})
+
+	if cl := rec.Header().Get("Content-Length"); cl != "" {
+		t.Errorf("expected no Content-Length with a Content-Encoding, got %q", cl)
+	}
+}
+
+func TestRespondJSON_EncodeError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	respondJSON(rec, http.StatusOK, map[string]float64{"rating": math.NaN()})
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status 500, got %d", rec.Code)
+	}
+	var body map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body["error"] == "" {
+		t.Errorf("expected a JSON error body, got %q", rec.Body.String())
+	}
+	if cl := rec.Header().Get("Content-Length"); cl != strconv.Itoa(rec.Body.Len()) {
+		t.Errorf("expected Content-Length %d, got %q", rec.Body.Len(), cl)
+	}
+}
+
+// discardWriter is a ResponseWriter that keeps only headers, so the
+// benchmarks measure encoding rather than the recorder.
+type discardWriter struct{ header http.Header }
+
+func (d *discardWriter) Header() http.Header         { return d.header }
+func (d *discardWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (d *discardWriter) WriteHeader(int)             {}
+
+func BenchmarkRespondJSON(b *testing.B) {
+	resp := benchmarkSearchResponse()
+	for _, bm := range []struct {
+		name    string
+		respond func(http.ResponseWriter, int, any)
+	}{
+		{"unpooled", respondJSONUnpooled},
+		{"pooled", respondJSON},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			w := &discardWriter{header: make(http.Header)}
+			b.ReportAllocs()
+			for range b.N {
+				bm.respond(w, http.StatusOK, resp)
+			}
+		})
+	}
+}
+
+func BenchmarkSearchTutors(b *testing.B) {
+	mock := &mockSearchClient{searchResult: benchmarkSearchResponse()}
+	router := NewRouter(mock, testutil.DiscardLogger(), testRouterConfig())
+	req := httptest.NewRequest("GET", "/tutors/search?q=math&subjects=math", nil)
+
+	b.ReportAllocs()
+	for range b.N {
+		router.ServeHTTP(&discardWriter{header: make(http.Header)}, req)
+	}
+}
@@ -0,0 +1,22 @@
+package api
+
+import (
+	"net/http"
+
+	"search/internal/handler"
+)
+
+// EventStatsReporter is implemented by *handler.EventHandler.
+type EventStatsReporter interface {
+	Stats() handler.EventStats
+}
+
+// EventStats reports how many Kafka events of each type were handled since
+// startup, by outcome, with the last error per type.
+func (h *Handlers) EventStats(w http.ResponseWriter, r *http.Request) {
+	if h.eventStats == nil {
+		respondError(w, http.StatusNotFound, "Event statistics require the Kafka consumer")
+		return
+	}
+	respondJSON(w, http.StatusOK, h.eventStats.Stats())
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"search/internal/handler"
+	"search/internal/testutil"
+)
+
+type fakeEventStats handler.EventStats
+
+func (f fakeEventStats) Stats() handler.EventStats {
+	return handler.EventStats(f)
+}
+
+func TestEventStats_JSONShape(t *testing.T) {
+	since := time.Date(2026, 3, 10, 8, 0, 0, 0, time.UTC)
+	failedAt := since.Add(time.Hour)
+	stats := fakeEventStats{
+		Since: since,
+		EventTypes: map[string]handler.EventTypeStats{
+			"TutorUpdated": {
+				Succeeded:   3,
+				Failed:      1,
+				FailureRate: 0.25,
+				LastError:   &handler.LastFailure{Error: "opensearch unavailable", At: failedAt},
+			},
+			"TutorCreated": {Succeeded: 2},
+		},
+		Backfills: handler.BackfillStats{Indexed: 4, Gone: 1},
+		Unchanged: handler.UnchangedStats{Skipped: 2, Lookups: 3},
+		Sequence:  handler.SequenceStats{Reordered: 2, Stashed: 1, Superseded: 1},
+	}
+	handlers := NewHandlers(&mockSearchClient{}, testutil.NewLogger(t), WithEventStats(stats))
+
+	rec := httptest.NewRecorder()
+	handlers.EventStats(rec, httptest.NewRequest("GET", "/admin/events/stats", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	want := map[string]any{
+		"since": "2026-03-10T08:00:00Z",
+		"event_types": map[string]any{
+			"TutorUpdated": map[string]any{
+				"succeeded":    3.0,
+				"failed":       1.0,
+				"quarantined":  0.0,
+				"ignored":      0.0,
+				"failure_rate": 0.25,
+				"last_error":   map[string]any{"error": "opensearch unavailable", "at": "2026-03-10T09:00:00Z"},
+			},
+			"TutorCreated": map[string]any{
+				"succeeded":    2.0,
+				"failed":       0.0,
+				"quarantined":  0.0,
+				"ignored":      0.0,
+				"failure_rate": 0.0,
+			},
+		},
+		"backfills": map[string]any{"indexed": 4.0, "gone": 1.0, "failed": 0.0},
+		"unchanged": map[string]any{"skipped": 2.0, "lookups": 3.0},
+		"sequence": map[string]any{
+			"reordered":  2.0,
+			"stashed":    1.0,
+			"retried":    0.0,
+			"superseded": 1.0,
+			"pending":    0.0,
+		},
+	}
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(want)
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("expected %s, got %s", wantJSON, gotJSON)
+	}
+}
+
+func TestEventStats_ConsumerDisabled(t *testing.T) {
+	handlers := NewHandlers(&mockSearchClient{}, testutil.NewLogger(t))
+
+	rec := httptest.NewRecorder()
+	handlers.EventStats(rec, httptest.NewRequest("GET", "/admin/events/stats", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
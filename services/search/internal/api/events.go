@@ -0,0 +1,72 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// sseKeepAlive is how often an idle stream sends a comment line so proxies
+// don't close the connection.
+const sseKeepAlive = 15 * time.Second
+
+// StreamActivity streams indexing activity as server-sent events until the
+// client disconnects. Each event's name is its activity type and its data is
+// the JSON-encoded activity.Event. When the server shuts down the stream
+// ends with a shutdown event, after which clients reconnect elsewhere.
+func (h *Handlers) StreamActivity(w http.ResponseWriter, r *http.Request) {
+	if h.activity == nil {
+		respondError(w, http.StatusNotFound, "Activity stream is not enabled")
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	// The stream outlives the server-wide write timeout.
+	_ = rc.SetWriteDeadline(time.Time{})
+
+	sub := h.activity.Subscribe()
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		h.logger.Error("Activity stream requires a flushable writer", "error", err)
+		return
+	}
+
+	ticker := time.NewTicker(sseKeepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-h.draining:
+			fmt.Fprint(w, "event: shutdown\ndata: {}\n\n")
+			_ = rc.Flush()
+			return
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		case e, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(e)
+			if err != nil {
+				h.logger.Error("Failed to encode activity event", "error", err)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"search/internal/activity"
+	"search/internal/bootstrap"
+	"search/internal/testutil"
+)
+
+// readSSEEvent reads lines from an SSE stream until one complete event.
+func readSSEEvent(t *testing.T, reader *bufio.Reader) (string, activity.Event) {
+	t.Helper()
+
+	var name string
+	var event activity.Event
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read stream: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case strings.HasPrefix(line, "event: "):
+			name = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event); err != nil {
+				t.Fatalf("invalid event data %q: %v", line, err)
+			}
+		case line == "" && name != "":
+			return name, event
+		}
+	}
+}
+
+func openStream(t *testing.T, ctx context.Context, url string) *bufio.Reader {
+	t.Helper()
+
+	req, _ := http.NewRequestWithContext(ctx, "GET", url+"/admin/events", nil)
+	req.Header.Set("Authorization", "Bearer "+testAdminKey)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("expected text/event-stream, got %s", ct)
+	}
+	return bufio.NewReader(resp.Body)
+}
+
+func waitForSubscribers(t *testing.T, hub *activity.Hub, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for hub.Subscribers() < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected %d subscribers, got %d", n, hub.Subscribers())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestStreamActivity_FansOutHTTPWrites(t *testing.T) {
+	logger := testutil.NewLogger(t)
+	hub := activity.NewHub(16)
+	cfg := testRouterConfig()
+	cfg.Activity = hub
+	cfg.AdminAPIKey = testAdminKey
+	server := httptest.NewServer(NewRouter(&mockSearchClient{}, logger, cfg))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first := openStream(t, ctx, server.URL)
+	second := openStream(t, ctx, server.URL)
+	waitForSubscribers(t, hub, 2)
+
+	req, _ := http.NewRequest("PUT", server.URL+"/tutors/42", bytes.NewReader([]byte(`{"full_name":"Test"}`)))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("upsert failed: %v", err)
+	}
+	resp.Body.Close()
+
+	req, _ = http.NewRequest("POST", server.URL+"/admin/sync", strings.NewReader(`[{"id":1},{"id":2}]`))
+	req.Header.Set("Authorization", "Bearer "+testAdminKey)
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	resp.Body.Close()
+
+	for _, stream := range []*bufio.Reader{first, second} {
+		name, event := readSSEEvent(t, stream)
+		if name != activity.TypeUpsert || event.TutorID != 42 || event.Source != activity.SourceHTTP {
+			t.Errorf("unexpected first event %s %+v", name, event)
+		}
+
+		name, event = readSSEEvent(t, stream)
+		if name != activity.TypeSync || event.Synced != 2 || event.Total != 2 {
+			t.Errorf("unexpected second event %s %+v", name, event)
+		}
+	}
+}
+
+func TestStreamActivity_UnsubscribesOnDisconnect(t *testing.T) {
+	logger := testutil.NewLogger(t)
+	hub := activity.NewHub(16)
+	cfg := testRouterConfig()
+	cfg.Activity = hub
+	cfg.AdminAPIKey = testAdminKey
+	server := httptest.NewServer(NewRouter(&mockSearchClient{}, logger, cfg))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	openStream(t, ctx, server.URL)
+	waitForSubscribers(t, hub, 1)
+
+	cancel()
+
+	deadline := time.Now().Add(time.Second)
+	for hub.Subscribers() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("subscription not released after client disconnect")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestStreamActivity_EndsOnShutdown(t *testing.T) {
+	logger := testutil.NewLogger(t)
+	hub := activity.NewHub(16)
+	draining := make(chan struct{})
+	cfg := testRouterConfig()
+	cfg.Activity = hub
+	cfg.AdminAPIKey = testAdminKey
+	cfg.Draining = draining
+
+	lis, err := bootstrap.Listen(0)
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	srv := &http.Server{Handler: NewRouter(&mockSearchClient{}, logger, cfg)}
+	srv.RegisterOnShutdown(func() { close(draining) })
+	go bootstrap.Serve(srv, lis)
+
+	reader := openStream(t, context.Background(), "http://"+lis.Addr().String())
+	waitForSubscribers(t, hub, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- bootstrap.Shutdown(ctx, srv) }()
+
+	if name, _ := readSSEEvent(t, reader); name != "shutdown" {
+		t.Errorf("expected a final shutdown event, got %q", name)
+	}
+	if _, err := reader.ReadString('\n'); err != io.EOF {
+		t.Errorf("expected the stream to end, got %v", err)
+	}
+	if err := <-shutdown; err != nil {
+		t.Errorf("expected the server to drain before the grace ran out, got %v", err)
+	}
+}
+
+func TestStreamActivity_Disabled(t *testing.T) {
+	logger := testutil.NewLogger(t)
+	handlers := NewHandlers(&mockSearchClient{}, logger)
+
+	req := httptest.NewRequest("GET", "/admin/events", nil)
+	rec := httptest.NewRecorder()
+
+	handlers.StreamActivity(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+
+	"search/internal/port"
+)
+
+// ClientIDHeader carries a stable browser or app identifier, hashed to keep
+// each client in the same experiment variant across searches.
+const ClientIDHeader = "X-Client-ID"
+
+// QueryHashHeader carries SearchQuery.Hash on JSON search responses, so a
+// support ticket can name the exact search without quoting its URL.
+const QueryHashHeader = "X-Query-Hash"
+
+// variant picks the experiment variant serving r. An exp parameter naming a
+// known variant, or port.IndexVariant for the index experiment, wins;
+// otherwise the client ID is hashed. Searches without either, or with no
+// experiment running, get the default relevance.
+func (h *Handlers) variant(r *http.Request) string {
+	exp := r.URL.Query().Get("exp")
+	if exp == port.IndexVariant {
+		return exp
+	}
+	if h.experiment == nil {
+		return ""
+	}
+	if h.experiment.Has(exp) {
+		return exp
+	}
+	if id := r.Header.Get(ClientIDHeader); id != "" {
+		return h.experiment.Assign(id)
+	}
+	return ""
+}
+
+// logSearchAnalytics records which variant served a search and how many
+// tutors it found, for comparing variants offline. The query hash groups
+// searches that differ only in parameter order or case.
+func (h *Handlers) logSearchAnalytics(query port.SearchQuery, result *port.SearchResponse) {
+	if query.Variant == "" {
+		return
+	}
+	experiment := port.IndexVariant
+	if query.Variant != port.IndexVariant {
+		experiment = h.experiment.Name()
+	}
+	h.logger.Info("Search served by experiment variant",
+		"experiment", experiment,
+		"variant", query.Variant,
+		"query_hash", query.Hash(),
+		"results", len(result.Results),
+		"total", result.Total,
+	)
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"search/internal/port"
+)
+
+// maxExperimentMapping bounds the body of POST /admin/experiment/index.
+const maxExperimentMapping = 16 << 10
+
+// CreateExperimentIndex starts an index experiment on the tenant's index
+// with the analysis changes in the body, a port.ExperimentMapping. Fields
+// outside the whitelist are rejected rather than ignored, so a mapping
+// change the experiment cannot make is not mistaken for one it made.
+func (h *Handlers) CreateExperimentIndex(w http.ResponseWriter, r *http.Request) {
+	var mapping port.ExperimentMapping
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxExperimentMapping))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&mapping); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	if err := mapping.Validate(); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	index := port.IndexFor(r.Context())
+	exp, err := h.os.CreateExperimentIndex(r.Context(), mapping)
+	switch {
+	case errors.Is(err, port.ErrUnsupported):
+		respondError(w, http.StatusNotImplemented, "Index experiments are not supported by this search backend")
+		return
+	case errors.Is(err, port.ErrExperimentRunning):
+		respondError(w, http.StatusConflict, "An index experiment is already running; delete it first")
+		return
+	case err != nil:
+		h.logger.Error("Failed to create experiment index", "index", index, "error", err)
+		respondBackendError(w, err, "Failed to create experiment index")
+		return
+	}
+
+	h.logger.Warn("Index experiment created",
+		"index", index,
+		"experiment_index", exp.Index,
+		"analyzers", mapping.Analyzers,
+		"stemmers", mapping.Stemmers,
+	)
+
+	respondJSON(w, http.StatusCreated, map[string]any{
+		"status":       "created",
+		"alias":        exp.Alias,
+		"index":        exp.Index,
+		"reindex_task": exp.ReindexTask,
+		"variant":      port.IndexVariant,
+	})
+}
+
+// DeleteExperimentIndex ends the tenant's index experiment: writes stop
+// reaching its index and the index is deleted. Searches with the index
+// variant fall back to the live index.
+func (h *Handlers) DeleteExperimentIndex(w http.ResponseWriter, r *http.Request) {
+	index := port.IndexFor(r.Context())
+	err := h.os.DeleteExperimentIndex(r.Context())
+	switch {
+	case errors.Is(err, port.ErrUnsupported):
+		respondError(w, http.StatusNotImplemented, "Index experiments are not supported by this search backend")
+		return
+	case errors.Is(err, port.ErrNoExperiment):
+		respondError(w, http.StatusNotFound, "No index experiment is running")
+		return
+	case err != nil:
+		h.logger.Error("Failed to delete experiment index", "index", index, "error", err)
+		respondBackendError(w, err, "Failed to delete experiment index")
+		return
+	}
+
+	h.logger.Warn("Index experiment deleted", "index", index)
+
+	respondJSON(w, http.StatusOK, map[string]any{
+		"status": "deleted",
+		"alias":  index + port.ExperimentIndexSuffix,
+	})
+}
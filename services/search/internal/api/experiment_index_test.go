@@ -0,0 +1,134 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func TestCreateExperimentIndex(t *testing.T) {
+	mock := &mockSearchClient{}
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+	body := `{
+		"analyzers": {"english_analyzer": {"tokenizer": "standard", "filter": ["lowercase", "asciifolding", "english_stemmer"]}},
+		"stemmers": {"english_stemmer": "light_english"}
+	}`
+	req := httptest.NewRequest("POST", "/admin/experiment/index", strings.NewReader(body))
+	rec := httptest.NewRecorder()
+	handlers.CreateExperimentIndex(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	if mock.experimentMapping == nil || mock.experimentMapping.Stemmers["english_stemmer"] != "light_english" ||
+		len(mock.experimentMapping.Analyzers["english_analyzer"].Filter) != 3 {
+		t.Errorf("expected the mapping to reach the backend, got %+v", mock.experimentMapping)
+	}
+	var resp map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp["alias"] != "tutors-exp" || resp["index"] != "tutors-exp-1" || resp["reindex_task"] != "node:1" || resp["variant"] != "index" {
+		t.Errorf("unexpected response %v", resp)
+	}
+}
+
+func TestCreateExperimentIndex_InvalidRequests(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"invalid body", `analyzers=standard`},
+		{"setting outside the whitelist", `{"number_of_shards": 4}`},
+		{"mapping outside the whitelist", `{"mappings": {"properties": {"bio": {"type": "keyword"}}}}`},
+		{"unknown analyzer", `{"analyzers": {"lowercase_normalizer": {"tokenizer": "standard"}}}`},
+		{"unknown filter", `{"analyzers": {"english_analyzer": {"tokenizer": "standard", "filter": ["synonym"]}}}`},
+		{"unknown stemmer language", `{"stemmers": {"russian_stemmer": "english"}}`},
+		{"body too large", `{"analyzers": {"english_analyzer": {"tokenizer": "` + strings.Repeat("a", maxExperimentMapping) + `"}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{}
+			handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+			req := httptest.NewRequest("POST", "/admin/experiment/index", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			handlers.CreateExperimentIndex(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+			}
+			if mock.experimentMapping != nil {
+				t.Errorf("expected no experiment to be created, got %+v", mock.experimentMapping)
+			}
+		})
+	}
+}
+
+func TestExperimentIndex_BackendErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		err        error
+		wantStatus int
+	}{
+		{"create unsupported", "POST", port.ErrUnsupported, http.StatusNotImplemented},
+		{"create while running", "POST", port.ErrExperimentRunning, http.StatusConflict},
+		{"create failure", "POST", errors.New("cluster unavailable"), http.StatusInternalServerError},
+		{"delete unsupported", "DELETE", port.ErrUnsupported, http.StatusNotImplemented},
+		{"delete without experiment", "DELETE", port.ErrNoExperiment, http.StatusNotFound},
+		{"delete failure", "DELETE", errors.New("cluster unavailable"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{experimentErr: tt.err}
+			handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+			req := httptest.NewRequest(tt.method, "/admin/experiment/index", strings.NewReader(`{}`))
+			rec := httptest.NewRecorder()
+			if tt.method == "POST" {
+				handlers.CreateExperimentIndex(rec, req)
+			} else {
+				handlers.DeleteExperimentIndex(rec, req)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestRouter_ExperimentIndex(t *testing.T) {
+	mock := &mockSearchClient{}
+	cfg := testRouterConfig()
+	cfg.AdminAPIKey = testAdminKey
+	router := NewRouter(mock, testutil.NewLogger(t), cfg)
+
+	req := httptest.NewRequest("POST", "/admin/experiment/index", strings.NewReader(`{}`))
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d without the admin key, got %d", http.StatusUnauthorized, rec.Code)
+	}
+
+	for _, method := range []string{"POST", "DELETE"} {
+		req := httptest.NewRequest(method, "/admin/experiment/index", strings.NewReader(`{}`))
+		req.Header.Set("Authorization", "Bearer "+testAdminKey)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code >= 300 {
+			t.Errorf("%s: expected success, got %d: %s", method, rec.Code, rec.Body.String())
+		}
+	}
+	if mock.experimentMapping == nil || !mock.experimentDeleted {
+		t.Errorf("expected the experiment to be created and deleted, got mapping %+v deleted %v", mock.experimentMapping, mock.experimentDeleted)
+	}
+}
@@ -0,0 +1,102 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"search/internal/experiment"
+	"search/internal/opensearch"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func newTestExperiment(t *testing.T) *experiment.Experiment {
+	t.Helper()
+	e, err := experiment.Parse([]byte(`{"name": "headline-boost", "variants": [
+		{"name": "control", "weight": 1},
+		{"name": "boosted", "weight": 1, "relevance": {"headline_boost": 4}}
+	]}`))
+	if err != nil {
+		t.Fatalf("invalid experiment: %v", err)
+	}
+	return e
+}
+
+func TestSearchTutors_ExperimentVariant(t *testing.T) {
+	e := newTestExperiment(t)
+	assigned := e.Assign("client-1")
+
+	tests := []struct {
+		name        string
+		url         string
+		clientID    string
+		experiment  *experiment.Experiment
+		wantVariant string
+	}{
+		{name: "exp parameter", url: "/tutors/search?exp=boosted", clientID: "client-1", experiment: e, wantVariant: "boosted"},
+		{name: "hashed client ID", url: "/tutors/search", clientID: "client-1", experiment: e, wantVariant: assigned},
+		{name: "unknown exp falls back to client ID", url: "/tutors/search?exp=retired", clientID: "client-1", experiment: e, wantVariant: assigned},
+		{name: "no client ID", url: "/tutors/search", experiment: e},
+		{name: "no experiment", url: "/tutors/search?exp=boosted", clientID: "client-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := NewHandlers(opensearch.NewMemoryClient(), testutil.NewLogger(t), WithExperiment(tt.experiment))
+
+			req := httptest.NewRequest("GET", tt.url, nil)
+			if tt.clientID != "" {
+				req.Header.Set(ClientIDHeader, tt.clientID)
+			}
+			rec := httptest.NewRecorder()
+			handlers.SearchTutors(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+			}
+			var response map[string]any
+			json.Unmarshal(rec.Body.Bytes(), &response)
+			variant, ok := response["variant"]
+			if tt.wantVariant == "" {
+				if ok {
+					t.Errorf("expected no variant, got %v", variant)
+				}
+				return
+			}
+			if variant != tt.wantVariant {
+				t.Errorf("expected variant %q, got %v", tt.wantVariant, variant)
+			}
+		})
+	}
+}
+
+func TestSearchTutors_IndexVariant(t *testing.T) {
+	tests := []struct {
+		name       string
+		experiment *experiment.Experiment
+	}{
+		{name: "alongside a relevance experiment", experiment: newTestExperiment(t)},
+		{name: "without a relevance experiment"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{searchResult: &port.SearchResponse{Variant: port.IndexVariant}}
+			handlers := NewHandlers(mock, testutil.NewLogger(t), WithExperiment(tt.experiment))
+
+			req := httptest.NewRequest("GET", "/tutors/search?q=algebra&exp=index", nil)
+			req.Header.Set(ClientIDHeader, "client-1")
+			rec := httptest.NewRecorder()
+			handlers.SearchTutors(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+			}
+			if mock.searchedQuery.Variant != port.IndexVariant {
+				t.Errorf("expected the search to ask for variant %q, got %q", port.IndexVariant, mock.searchedQuery.Variant)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"search/internal/domain"
+)
+
+const (
+	// maxExportLimit caps how many tutors one CSV export may return.
+	maxExportLimit = 10000
+	// exportFlushEvery is how many rows are written between flushes.
+	exportFlushEvery = 500
+	// exportStatusTrailer reports whether an export that began streaming
+	// finished: "complete", or "truncated" when it was cut short by an
+	// error or server shutdown.
+	exportStatusTrailer = "X-Export-Status"
+)
+
+// errDraining stops an export when the server shuts down.
+var errDraining = errors.New("server is shutting down")
+
+// csvHeader is the column order of CSV exports.
+var csvHeader = []string{
+	"id", "slug", "full_name", "headline", "subjects",
+	"hourly_rate", "rating", "reviews_count", "is_verified", "location",
+}
+
+// wantsCSV reports whether a /tutors/search request asks for CSV, either with
+// format=csv or an Accept header naming text/csv.
+func wantsCSV(r *http.Request) bool {
+	if r.URL.Query().Get("format") == "csv" {
+		return true
+	}
+	return strings.Contains(r.Header.Get("Accept"), "text/csv")
+}
+
+// ExportTutorsCSV streams every tutor matching the /tutors/search filters as
+// CSV, up to limit rows (default and maximum 10000). offset is ignored.
+// format=csv selects the output and is not applied as a lesson-format filter;
+// use Accept: text/csv to combine export with one.
+func (h *Handlers) ExportTutorsCSV(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	params, ok := h.validateQuery(w, r.URL.RawQuery)
+	if !ok {
+		return
+	}
+	query := parseSearchValues(params)
+	query.Subjects = h.subjects.Keys(query.Subjects)
+	if query.Format == "csv" {
+		query.Format = ""
+	}
+	query = h.excludeHidden(ctx, query)
+
+	limit := maxExportLimit
+	if query.Limit > 0 && query.Limit < maxExportLimit {
+		limit = query.Limit
+	}
+
+	rc := http.NewResponseController(w)
+	cw := csv.NewWriter(w)
+	rows := 0
+	started := false
+	start := func() {
+		started = true
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="tutors.csv"`)
+		w.Header().Set("Trailer", exportStatusTrailer)
+		w.WriteHeader(http.StatusOK)
+		_ = cw.Write(csvHeader)
+	}
+
+	err := h.os.ScanTutors(ctx, query, limit, func(tutor domain.Tutor) error {
+		select {
+		case <-h.draining:
+			return errDraining
+		default:
+		}
+		if !started {
+			start()
+		}
+		if err := cw.Write(csvRecord(tutor)); err != nil {
+			return err
+		}
+		rows++
+		if rows%exportFlushEvery == 0 {
+			cw.Flush()
+			if err := cw.Error(); err != nil {
+				return err
+			}
+			_ = rc.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		h.logger.Error("Failed to export tutors", "rows", rows, "error", err)
+		if !started && errors.Is(err, errDraining) {
+			respondError(w, http.StatusServiceUnavailable, "Server is shutting down")
+			return
+		}
+		if !started {
+			respondBackendError(w, err, "Failed to export tutors")
+			return
+		}
+		// Headers are already sent; only the trailer can tell the body is
+		// truncated.
+		cw.Flush()
+		w.Header().Set(exportStatusTrailer, "truncated")
+		return
+	}
+
+	if !started {
+		start()
+	}
+	cw.Flush()
+	w.Header().Set(exportStatusTrailer, "complete")
+}
+
+// csvRecord renders a tutor as a row in csvHeader order.
+func csvRecord(t domain.Tutor) []string {
+	return []string{
+		strconv.FormatInt(t.ID, 10),
+		t.Slug,
+		t.FullName,
+		t.Headline,
+		strings.Join(t.Subjects, ";"),
+		strconv.FormatFloat(t.HourlyRate, 'f', -1, 64),
+		strconv.FormatFloat(t.Rating, 'f', -1, 64),
+		strconv.Itoa(t.ReviewsCount),
+		strconv.FormatBool(t.IsVerified),
+		t.Location,
+	}
+}
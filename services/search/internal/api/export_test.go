@@ -0,0 +1,230 @@
+package api
+
+import (
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"search/internal/domain"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func exportTutors() []domain.Tutor {
+	return []domain.Tutor{
+		{
+			ID:           1,
+			Slug:         "ada-lovelace",
+			FullName:     "Ada Lovelace",
+			Headline:     `Math, logic and "analytical engines"`,
+			Bio:          "Line one,\nline two",
+			Subjects:     []string{"math", "computer science"},
+			HourlyRate:   42.5,
+			Rating:       4.9,
+			ReviewsCount: 12,
+			IsVerified:   true,
+			Location:     "London, UK",
+		},
+		{ID: 2, Slug: "marie-curie", FullName: "Marie Curie", Headline: "Physics\nand chemistry", Subjects: []string{"physics"}, HourlyRate: 60},
+	}
+}
+
+func TestExportTutorsCSV(t *testing.T) {
+	mock := &mockSearchClient{searchResult: &port.SearchResponse{Results: exportTutors()}}
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+	rec := httptest.NewRecorder()
+	handlers.ExportTutorsCSV(rec, httptest.NewRequest("GET", "/tutors/search?format=csv", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/csv; charset=utf-8" {
+		t.Errorf("expected CSV content type, got %q", ct)
+	}
+	if cd := rec.Header().Get("Content-Disposition"); !strings.HasPrefix(cd, "attachment") {
+		t.Errorf("expected attachment disposition, got %q", cd)
+	}
+	if status := rec.Result().Trailer.Get(exportStatusTrailer); status != "complete" {
+		t.Errorf("expected a complete export status, got %q", status)
+	}
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("response is not valid CSV: %v\n%s", err, rec.Body.String())
+	}
+	want := [][]string{
+		csvHeader,
+		{"1", "ada-lovelace", "Ada Lovelace", `Math, logic and "analytical engines"`, "math;computer science", "42.5", "4.9", "12", "true", "London, UK"},
+		{"2", "marie-curie", "Marie Curie", "Physics\nand chemistry", "physics", "60", "0", "0", "false", ""},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("expected %d records, got %d: %v", len(want), len(records), records)
+	}
+	for i := range want {
+		if !slices.Equal(records[i], want[i]) {
+			t.Errorf("record %d: expected %q, got %q", i, want[i], records[i])
+		}
+	}
+}
+
+func TestExportTutorsCSV_QuotesSpecialCharacters(t *testing.T) {
+	mock := &mockSearchClient{searchResult: &port.SearchResponse{Results: exportTutors()[:1]}}
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+	rec := httptest.NewRecorder()
+	handlers.ExportTutorsCSV(rec, httptest.NewRequest("GET", "/tutors/search?format=csv", nil))
+
+	row := strings.SplitN(rec.Body.String(), "\n", 2)[1]
+	for _, field := range []string{`"Math, logic and ""analytical engines"""`, `"London, UK"`} {
+		if !strings.Contains(row, field) {
+			t.Errorf("expected quoted field %s in %q", field, row)
+		}
+	}
+}
+
+func TestExportTutorsCSV_Filters(t *testing.T) {
+	mock := &mockSearchClient{searchResult: &port.SearchResponse{}}
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+	req := httptest.NewRequest("GET", "/tutors/search?q=math&subjects=physics&format=online&min_rating=4&exclude_ids=3", nil)
+	req.Header.Set("Accept", "text/csv")
+	rec := httptest.NewRecorder()
+	handlers.ExportTutorsCSV(rec, req)
+
+	query := mock.searchedQuery
+	if query.Text != "math" || query.Format != "online" || !slices.Equal(query.Subjects, []string{"physics"}) {
+		t.Errorf("expected filters to be passed through, got %+v", query)
+	}
+	if query.MinRating == nil || *query.MinRating != 4 || !slices.Equal(query.ExcludeIDs, []int64{3}) {
+		t.Errorf("expected rating and exclusions to be passed through, got %+v", query)
+	}
+	if mock.scanLimit != maxExportLimit {
+		t.Errorf("expected default limit %d, got %d", maxExportLimit, mock.scanLimit)
+	}
+	if body := rec.Body.String(); body != strings.Join(csvHeader, ",")+"\n" {
+		t.Errorf("expected only the header row, got %q", body)
+	}
+}
+
+func TestExportTutorsCSV_Limit(t *testing.T) {
+	tests := []struct {
+		param string
+		want  int
+	}{
+		{"limit=250", 250},
+		{"limit=50000", maxExportLimit},
+		{"limit=0", maxExportLimit},
+		{"limit=-5", maxExportLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.param, func(t *testing.T) {
+			mock := &mockSearchClient{searchResult: &port.SearchResponse{}}
+			handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+			handlers.ExportTutorsCSV(httptest.NewRecorder(), httptest.NewRequest("GET", "/tutors/search?format=csv&"+tt.param, nil))
+
+			if mock.scanLimit != tt.want {
+				t.Errorf("expected limit %d, got %d", tt.want, mock.scanLimit)
+			}
+			if mock.searchedQuery.Format != "" {
+				t.Errorf("expected format=csv not to be used as a filter, got %q", mock.searchedQuery.Format)
+			}
+		})
+	}
+}
+
+func TestExportTutorsCSV_Error(t *testing.T) {
+	mock := &mockSearchClient{searchErr: errors.New("search error")}
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+	rec := httptest.NewRecorder()
+	handlers.ExportTutorsCSV(rec, httptest.NewRequest("GET", "/tutors/search?format=csv", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expected JSON error, got content type %q", ct)
+	}
+}
+
+func TestRouter_SearchContentNegotiation(t *testing.T) {
+	logger := testutil.NewLogger(t)
+	mock := &mockSearchClient{searchResult: &port.SearchResponse{Results: exportTutors(), Total: 2}}
+	router := NewRouter(mock, logger, testRouterConfig())
+
+	tests := []struct {
+		name     string
+		path     string
+		accept   string
+		wantType string
+	}{
+		{"json by default", "/tutors/search?q=math", "", "application/json"},
+		{"format filter stays json", "/tutors/search?format=online", "", "application/json"},
+		{"format=csv", "/tutors/search?format=csv", "", "text/csv; charset=utf-8"},
+		{"accept header", "/tutors/search", "text/csv", "text/csv; charset=utf-8"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != tt.wantType {
+				t.Errorf("expected content type %q, got %q", tt.wantType, ct)
+			}
+		})
+	}
+}
+
+func TestExportTutorsCSV_Draining(t *testing.T) {
+	draining := make(chan struct{})
+	close(draining)
+	mock := &mockSearchClient{searchResult: &port.SearchResponse{Results: exportTutors()}}
+	handlers := NewHandlers(mock, testutil.NewLogger(t), WithDraining(draining))
+
+	rec := httptest.NewRecorder()
+	handlers.ExportTutorsCSV(rec, httptest.NewRequest("GET", "/tutors/search?format=csv", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d, got %d", http.StatusServiceUnavailable, rec.Code)
+	}
+}
+
+func TestExportTutorsCSV_TruncatedByShutdown(t *testing.T) {
+	draining := make(chan struct{})
+	tutors := exportTutors()
+	mock := &mockSearchClient{searchResult: &port.SearchResponse{Results: tutors}}
+	handlers := NewHandlers(mock, testutil.NewLogger(t), WithDraining(draining))
+	// The shutdown begins once the first row is written.
+	var once sync.Once
+	mock.scanned = func() { once.Do(func() { close(draining) }) }
+
+	rec := httptest.NewRecorder()
+	handlers.ExportTutorsCSV(rec, httptest.NewRequest("GET", "/tutors/search?format=csv", nil))
+
+	records, err := csv.NewReader(rec.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("response is not valid CSV: %v", err)
+	}
+	if len(records) != 2 {
+		t.Errorf("expected the header and one row, got %v", records)
+	}
+	if got := rec.Result().Trailer.Get(exportStatusTrailer); got != "truncated" {
+		t.Errorf("expected a truncated export status, got %q", got)
+	}
+}
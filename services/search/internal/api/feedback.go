@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"search/internal/feedback"
+	"search/internal/tenant"
+)
+
+const (
+	// DefaultMaxFeedbackBatch is the default cap on events per feedback
+	// request.
+	DefaultMaxFeedbackBatch = 100
+	// maxFeedbackBody caps the feedback request body in bytes, well above
+	// what a full batch takes.
+	maxFeedbackBody = 64 << 10
+	// maxClientIDLength caps X-Client-ID on feedback, which is passed on
+	// to the sink.
+	maxClientIDLength = 128
+	// feedbackRetryAfter is the Retry-After, in seconds, sent to a client
+	// over its feedback rate.
+	feedbackRetryAfter = "1"
+)
+
+// RateLimiter is implemented by *limiter.Keyed.
+type RateLimiter interface {
+	Allow(key string) bool
+}
+
+// Feedback accepts a JSON array of search result events, validates them
+// and hands them to the feedback sink. It never touches the search backend
+// and returns as soon as the sink has taken the batch.
+func (h *Handlers) Feedback(w http.ResponseWriter, r *http.Request) {
+	if h.feedback == nil {
+		respondError(w, http.StatusNotFound, "Feedback is not enabled")
+		return
+	}
+
+	clientID := r.Header.Get(ClientIDHeader)
+	if len(clientID) > maxClientIDLength {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("%s must be at most %d characters", ClientIDHeader, maxClientIDLength))
+		return
+	}
+	if h.feedbackRate != nil && !h.feedbackRate.Allow(feedbackClientKey(r, clientID)) {
+		w.Header().Set("Retry-After", feedbackRetryAfter)
+		respondError(w, http.StatusTooManyRequests, "Too many feedback requests")
+		return
+	}
+
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxFeedbackBody))
+	dec.DisallowUnknownFields()
+	var events []feedback.Event
+	if err := dec.Decode(&events); err != nil {
+		respondError(w, http.StatusBadRequest, "Request body must be a JSON array of feedback events")
+		return
+	}
+	if len(events) == 0 {
+		respondError(w, http.StatusBadRequest, "At least one feedback event is required")
+		return
+	}
+	if len(events) > h.maxFeedbackBatch {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("At most %d feedback events per request, got %d", h.maxFeedbackBatch, len(events)))
+		return
+	}
+	for i, e := range events {
+		if err := e.Validate(h.maxTutorID); err != nil {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("events[%d]: %s", i, err))
+			return
+		}
+	}
+
+	batch := feedback.Batch{ClientID: clientID, ReceivedAt: time.Now().UTC(), Events: events}
+	if t, ok := tenant.FromContext(r.Context()); ok {
+		batch.Tenant = t.Name
+	}
+	h.feedback.Record(batch)
+
+	respondJSON(w, http.StatusAccepted, map[string]int{"accepted": len(events)})
+}
+
+// feedbackClientKey identifies the client for rate limiting: its client
+// ID, or its IP address when it sends none.
+func feedbackClientKey(r *http.Request, clientID string) string {
+	if clientID != "" {
+		return "id:" + clientID
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
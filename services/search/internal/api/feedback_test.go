@@ -0,0 +1,188 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"search/internal/feedback"
+	"search/internal/limiter"
+	"search/internal/testutil"
+)
+
+// recordingSink keeps every batch it is handed.
+type recordingSink struct {
+	mu      sync.Mutex
+	batches []feedback.Batch
+}
+
+func (s *recordingSink) Record(b feedback.Batch) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches = append(s.batches, b)
+}
+
+func (s *recordingSink) recorded() []feedback.Batch {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.batches
+}
+
+func feedbackRouter(t testing.TB, sink feedback.Sink, rate RateLimiter) http.Handler {
+	cfg := testRouterConfig()
+	cfg.Feedback = sink
+	cfg.FeedbackRate = rate
+	cfg.MaxFeedbackBatch = 3
+	return NewRouter(&mockSearchClient{}, testutil.NewLogger(t), cfg)
+}
+
+func postFeedback(router http.Handler, body, clientID string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/feedback", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if clientID != "" {
+		req.Header.Set(ClientIDHeader, clientID)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+const feedbackBatch = `[
+	{"query_hash": "0123456789abcdef", "tutor_id": 1, "action": "impression"},
+	{"query_hash": "0123456789abcdef", "tutor_id": 2, "action": "impression"},
+	{"query_hash": "0123456789abcdef", "tutor_id": 2, "action": "click"}
+]`
+
+func TestFeedback_AcceptsBatch(t *testing.T) {
+	sink := &recordingSink{}
+	cfg := testRouterConfig()
+	cfg.Feedback = sink
+	// Without a search client any backend call would panic.
+	router := NewRouter(nil, testutil.NewLogger(t), cfg)
+
+	before := time.Now().UTC()
+	rec := postFeedback(router, feedbackBatch, "client-1")
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusAccepted, rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Accepted int `json:"accepted"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || resp.Accepted != 3 {
+		t.Errorf("expected 3 accepted, got %s", rec.Body.String())
+	}
+
+	batches := sink.recorded()
+	if len(batches) != 1 {
+		t.Fatalf("expected one batch, got %d", len(batches))
+	}
+	b := batches[0]
+	if b.ClientID != "client-1" || b.Tenant != "default" || b.ReceivedAt.Before(before) {
+		t.Errorf("unexpected batch context %+v", b)
+	}
+	want := feedback.Event{QueryHash: "0123456789abcdef", TutorID: 2, Action: feedback.ActionClick}
+	if len(b.Events) != 3 || b.Events[2] != want {
+		t.Errorf("expected the events in order, got %+v", b.Events)
+	}
+}
+
+func TestFeedback_Validation(t *testing.T) {
+	click := `{"query_hash": "0123456789abcdef", "tutor_id": 1, "action": "click"}`
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"not an array", `{"query_hash": "0123456789abcdef", "tutor_id": 1, "action": "click"}`, "JSON array"},
+		{"malformed", `[{"tutor_id": }]`, "JSON array"},
+		{"unknown field", `[{"query_hash": "0123456789abcdef", "tutor_id": 1, "action": "click", "position": 3}]`, "JSON array"},
+		{"empty", `[]`, "At least one"},
+		{"too many", "[" + strings.Repeat(click+",", 3) + click + "]", "At most 3"},
+		{"unknown action", `[{"query_hash": "0123456789abcdef", "tutor_id": 1, "action": "click"}, {"query_hash": "0123456789abcdef", "tutor_id": 1, "action": "hover"}]`, "events[1]: action must be"},
+		{"bad hash", `[{"query_hash": "not-a-hash", "tutor_id": 1, "action": "click"}]`, "events[0]: query_hash"},
+		{"bad tutor", `[{"query_hash": "0123456789abcdef", "tutor_id": -4, "action": "click"}]`, "events[0]: tutor_id"},
+		{"oversized body", `[{"query_hash": "` + strings.Repeat("a", maxFeedbackBody) + `"}]`, "JSON array"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &recordingSink{}
+			rec := postFeedback(feedbackRouter(t, sink, nil), tt.body, "")
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected status %d, got %d: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %s", tt.wantErr, rec.Body.String())
+			}
+			if len(sink.recorded()) != 0 {
+				t.Error("expected an invalid batch to reach no sink")
+			}
+		})
+	}
+}
+
+func TestFeedback_ClientIDTooLong(t *testing.T) {
+	rec := postFeedback(feedbackRouter(t, &recordingSink{}, nil), feedbackBatch, strings.Repeat("c", maxClientIDLength+1))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestFeedback_RateLimitedPerClient(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	sink := &recordingSink{}
+	router := feedbackRouter(t, sink, limiter.NewKeyed(1, 2, limiter.WithKeyedClock(func() time.Time { return now })))
+
+	for i := range 2 {
+		if rec := postFeedback(router, feedbackBatch, "client-1"); rec.Code != http.StatusAccepted {
+			t.Fatalf("request %d: expected status %d, got %d", i, http.StatusAccepted, rec.Code)
+		}
+	}
+	rec := postFeedback(router, feedbackBatch, "client-1")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected status %d, got %d", http.StatusTooManyRequests, rec.Code)
+	}
+	if got := rec.Header().Get("Retry-After"); got != feedbackRetryAfter {
+		t.Errorf("expected Retry-After %s, got %q", feedbackRetryAfter, got)
+	}
+
+	if rec := postFeedback(router, feedbackBatch, "client-2"); rec.Code != http.StatusAccepted {
+		t.Errorf("expected another client to be unaffected, got %d", rec.Code)
+	}
+	// Clients without an ID are limited by address.
+	for i := range 3 {
+		rec := postFeedback(router, feedbackBatch, "")
+		want := http.StatusAccepted
+		if i == 2 {
+			want = http.StatusTooManyRequests
+		}
+		if rec.Code != want {
+			t.Errorf("anonymous request %d: expected status %d, got %d", i, want, rec.Code)
+		}
+	}
+	if got := len(sink.recorded()); got != 5 {
+		t.Errorf("expected 5 batches recorded, got %d", got)
+	}
+}
+
+func TestFeedback_Disabled(t *testing.T) {
+	rec := postFeedback(feedbackRouter(t, nil, nil), feedbackBatch, "")
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
+
+func BenchmarkFeedback(b *testing.B) {
+	router := feedbackRouter(b, feedback.NewLogSink(testutil.DiscardLogger()), nil)
+	for i := range b.N {
+		postFeedback(router, feedbackBatch, fmt.Sprintf("client-%d", i))
+	}
+}
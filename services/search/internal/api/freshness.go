@@ -0,0 +1,113 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"search/internal/domain"
+	"search/internal/handler"
+	"search/internal/port"
+	"search/internal/tenant"
+)
+
+// EventTracker is implemented by *handler.EventHandler.
+type EventTracker interface {
+	LastEvent(tenantName string, tutorID int64) (handler.LastEvent, bool)
+}
+
+type freshnessResponse struct {
+	TutorID       int64      `json:"tutor_id"`
+	Indexed       bool       `json:"indexed"`
+	IndexedAt     *time.Time `json:"indexed_at"`
+	UpdatedAt     *time.Time `json:"updated_at"`
+	LastEventType string     `json:"last_event_type,omitempty"`
+	LastEventAt   *time.Time `json:"last_event_at"`
+	// Stale is true when the newest event for the tutor has not reached the
+	// index: it is newer than indexed_at, or it is a delete and the document
+	// is still there.
+	Stale bool `json:"stale"`
+}
+
+// GetTutor returns one indexed tutor, including when it was indexed.
+func (h *Handlers) GetTutor(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.tutorID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	tutor, err := h.os.GetTutor(r.Context(), id)
+	if errors.Is(err, port.ErrNotFound) {
+		respondError(w, http.StatusNotFound, "Tutor not found")
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to get tutor", "id", id, "error", err)
+		respondBackendError(w, err, "Failed to get tutor")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, tutor)
+}
+
+// TutorFreshness compares a tutor's indexed_at with the newest Kafka event
+// seen for it, to tell whether the search copy is behind Django.
+func (h *Handlers) TutorFreshness(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.tutorID(w, r, "id")
+	if !ok {
+		return
+	}
+
+	tutor, err := h.os.GetTutor(r.Context(), id)
+	if err != nil && !errors.Is(err, port.ErrNotFound) {
+		h.logger.Error("Failed to get tutor", "id", id, "error", err)
+		respondBackendError(w, err, "Failed to get tutor")
+		return
+	}
+
+	var last handler.LastEvent
+	seen := false
+	if h.events != nil {
+		t, _ := tenant.FromContext(r.Context())
+		last, seen = h.events.LastEvent(t.Name, id)
+	}
+	if tutor == nil && !seen {
+		respondError(w, http.StatusNotFound, "Tutor is not indexed and no events were seen for it")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, freshness(id, tutor, last, seen))
+}
+
+func freshness(id int64, tutor *domain.Tutor, last handler.LastEvent, seen bool) freshnessResponse {
+	resp := freshnessResponse{TutorID: id, Indexed: tutor != nil}
+	if tutor != nil {
+		resp.IndexedAt = tutor.IndexedAt
+		if !tutor.UpdatedAt.IsZero() {
+			resp.UpdatedAt = &tutor.UpdatedAt
+		}
+	}
+	if !seen {
+		return resp
+	}
+
+	resp.LastEventType = last.Type
+	resp.LastEventAt = &last.At
+	if last.Type == "TutorDeleted" {
+		resp.Stale = tutor != nil
+	} else {
+		resp.Stale = tutor == nil || tutor.IndexedAt == nil || last.At.After(*tutor.IndexedAt)
+	}
+	return resp
+}
+
+// stripIndexMeta drops service-internal fields from search results unless
+// the caller asked for them with include_meta=true.
+func stripIndexMeta(r *http.Request, tutors []domain.Tutor) {
+	if r.URL.Query().Get("include_meta") == "true" {
+		return
+	}
+	for i := range tutors {
+		tutors[i].IndexedAt = nil
+	}
+}
@@ -0,0 +1,209 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"search/internal/domain"
+	"search/internal/handler"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+type fakeEventTracker map[int64]handler.LastEvent
+
+func (f fakeEventTracker) LastEvent(tenantName string, tutorID int64) (handler.LastEvent, bool) {
+	e, ok := f[tutorID]
+	return e, ok
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestGetTutor(t *testing.T) {
+	indexedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	mock := &mockSearchClient{tutor: &domain.Tutor{ID: 7, FullName: "Ann", IndexedAt: &indexedAt}}
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+	tests := []struct {
+		name       string
+		id         string
+		getErr     error
+		wantStatus int
+	}{
+		{"found", "7", nil, http.StatusOK},
+		{"not found", "8", nil, http.StatusNotFound},
+		{"invalid ID", "abc", nil, http.StatusBadRequest},
+		{"backend error", "7", errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.getErr = tt.getErr
+			req := httptest.NewRequest("GET", "/tutors/"+tt.id, nil)
+			req.SetPathValue("id", tt.id)
+			rec := httptest.NewRecorder()
+
+			handlers.GetTutor(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var got domain.Tutor
+			json.Unmarshal(rec.Body.Bytes(), &got)
+			if got.IndexedAt == nil || !got.IndexedAt.Equal(indexedAt) {
+				t.Errorf("expected indexed_at %v, got %v", indexedAt, got.IndexedAt)
+			}
+		})
+	}
+}
+
+func TestSearchTutors_IncludeMeta(t *testing.T) {
+	tests := []struct {
+		url         string
+		wantIndexed bool
+	}{
+		{"/tutors/search", false},
+		{"/tutors/search?include_meta=false", false},
+		{"/tutors/search?include_meta=true", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			mock := &mockSearchClient{searchResult: &port.SearchResponse{
+				Results: []domain.Tutor{{ID: 1, IndexedAt: timePtr(time.Now().UTC())}},
+				Total:   1,
+			}}
+			handlers := NewHandlers(mock, testutil.NewLogger(t))
+			rec := httptest.NewRecorder()
+
+			handlers.SearchTutors(rec, httptest.NewRequest("GET", tt.url, nil))
+
+			var response struct {
+				Results []map[string]any `json:"results"`
+			}
+			json.Unmarshal(rec.Body.Bytes(), &response)
+			if len(response.Results) != 1 {
+				t.Fatalf("expected 1 result, got %d", len(response.Results))
+			}
+			if _, ok := response.Results[0]["indexed_at"]; ok != tt.wantIndexed {
+				t.Errorf("expected indexed_at present=%v, got %v", tt.wantIndexed, response.Results[0])
+			}
+		})
+	}
+}
+
+func TestTutorFreshness(t *testing.T) {
+	indexedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	indexed := &domain.Tutor{ID: 7, IndexedAt: &indexedAt}
+
+	tests := []struct {
+		name       string
+		tutor      *domain.Tutor
+		events     fakeEventTracker
+		wantStatus int
+		wantStale  bool
+	}{
+		{
+			name:       "indexed after last event",
+			tutor:      indexed,
+			events:     fakeEventTracker{7: {Type: "TutorUpdated", At: indexedAt.Add(-time.Second)}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "event newer than index",
+			tutor:      indexed,
+			events:     fakeEventTracker{7: {Type: "TutorUpdated", At: indexedAt.Add(time.Minute)}},
+			wantStatus: http.StatusOK,
+			wantStale:  true,
+		},
+		{
+			name:       "indexed before indexed_at existed",
+			tutor:      &domain.Tutor{ID: 7},
+			events:     fakeEventTracker{7: {Type: "TutorCreated", At: indexedAt}},
+			wantStatus: http.StatusOK,
+			wantStale:  true,
+		},
+		{
+			name:       "created but never indexed",
+			events:     fakeEventTracker{7: {Type: "TutorCreated", At: indexedAt}},
+			wantStatus: http.StatusOK,
+			wantStale:  true,
+		},
+		{
+			name:       "deleted and removed",
+			events:     fakeEventTracker{7: {Type: "TutorDeleted", At: indexedAt}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "deleted but still indexed",
+			tutor:      indexed,
+			events:     fakeEventTracker{7: {Type: "TutorDeleted", At: indexedAt.Add(-time.Hour)}},
+			wantStatus: http.StatusOK,
+			wantStale:  true,
+		},
+		{
+			name:       "no events seen",
+			tutor:      indexed,
+			events:     fakeEventTracker{},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "unknown tutor",
+			events:     fakeEventTracker{},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{tutor: tt.tutor}
+			handlers := NewHandlers(mock, testutil.NewLogger(t), WithEventTracker(tt.events))
+			req := httptest.NewRequest("GET", "/admin/tutors/7/freshness", nil)
+			req.SetPathValue("id", "7")
+			rec := httptest.NewRecorder()
+
+			handlers.TutorFreshness(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var response freshnessResponse
+			json.Unmarshal(rec.Body.Bytes(), &response)
+			if response.Stale != tt.wantStale {
+				t.Errorf("expected stale=%v, got %+v", tt.wantStale, response)
+			}
+			if response.Indexed != (tt.tutor != nil) {
+				t.Errorf("expected indexed=%v, got %v", tt.tutor != nil, response.Indexed)
+			}
+		})
+	}
+}
+
+func TestTutorFreshness_WithoutEventTracker(t *testing.T) {
+	indexedAt := time.Now().UTC()
+	mock := &mockSearchClient{tutor: &domain.Tutor{ID: 7, IndexedAt: &indexedAt}}
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+	req := httptest.NewRequest("GET", "/admin/tutors/7/freshness", nil)
+	req.SetPathValue("id", "7")
+	rec := httptest.NewRecorder()
+
+	handlers.TutorFreshness(rec, req)
+
+	var response freshnessResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	if rec.Code != http.StatusOK || response.Stale || response.LastEventAt != nil {
+		t.Errorf("expected fresh response without event data, got %d %+v", rec.Code, response)
+	}
+}
@@ -1,27 +1,379 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
+	"search/internal/activity"
+	"search/internal/audit"
 	"search/internal/domain"
-	"search/internal/opensearch"
+	"search/internal/experiment"
+	"search/internal/feedback"
+	"search/internal/kafka"
+	"search/internal/outbox"
+	"search/internal/port"
+	"search/internal/reindex"
+	"search/internal/store"
 )
 
 type Handlers struct {
-	os     opensearch.SearchClient
-	logger *slog.Logger
+	os       port.SearchClient
+	logger   *slog.Logger
+	activity *activity.Hub
+	consumer ConsumerPauser
+	// consumerOffsets backs the /admin/consumer endpoints.
+	consumerOffsets ConsumerOffsets
+	consumerStatus  ConsumerStatusReporter
+	store           store.Store
+	kafka           KafkaChecker
+	reindex         ReindexJob
+	events          EventTracker
+	snapshots       SnapshotTracker
+	eventStats      EventStatsReporter
+	experiment      *experiment.Experiment
+	// maxTutorID is the largest tutor ID path parameters accept.
+	maxTutorID int64
+	// maxListItems caps subjects and formats of indexed tutors.
+	maxListItems int
+	avatars      domain.AvatarPolicy
+	ratingMode   string
+	subjects     *domain.SubjectCatalog
+	translations *domain.Translations
+	scrubber     domain.Scrubber
+	queries      QueryValidator
+	readiness    ReadinessChecker
+	audit        *audit.Log
+	watermark    WatermarkSource
+	drift        DriftReporter
+	lease        LeaseReporter
+	indexRate    RateReporter
+	connections  ConnectionReporter
+	journal      *outbox.Journal
+	relevance    RelevanceReloader
+	feedback     feedback.Sink
+	// feedbackRate limits POST /feedback per client; nil leaves it
+	// unlimited.
+	feedbackRate     RateLimiter
+	maxFeedbackBatch int
+	// maxResponseBytes is the JSON search response budget; zero disables
+	// it.
+	maxResponseBytes int
+	// settings is the service config GET /admin/config reports, with
+	// secrets already redacted by its LogValue.
+	settings slog.LogValuer
+	// draining is closed when the server starts shutting down; nil never
+	// is.
+	draining <-chan struct{}
+	// maxStaleness fails readiness while the index lags further behind;
+	// zero disables the check.
+	maxStaleness time.Duration
 }
 
-func NewHandlers(os opensearch.SearchClient, logger *slog.Logger) *Handlers {
-	return &Handlers{
-		os:     os,
-		logger: logger,
+// KafkaChecker is implemented by kafka.HealthChecker.
+type KafkaChecker interface {
+	Check(ctx context.Context) kafka.Health
+}
+
+// ReindexJob is implemented by *reindex.Job.
+type ReindexJob interface {
+	Start(ctx context.Context, trigger string) error
+	Status() (last *reindex.Run, running bool)
+}
+
+// Option configures optional Handlers dependencies.
+type Option func(*Handlers)
+
+// WithActivityHub publishes indexing activity to hub and enables the
+// /admin/events stream.
+func WithActivityHub(hub *activity.Hub) Option {
+	return func(h *Handlers) {
+		h.activity = hub
+	}
+}
+
+// WithConsumerPauser lets index maintenance endpoints pause event consumption
+// while the index is unavailable.
+func WithConsumerPauser(p ConsumerPauser) Option {
+	return func(h *Handlers) {
+		h.consumer = p
+	}
+}
+
+// WithConsumerOffsets enables the /admin/consumer offset endpoints.
+func WithConsumerOffsets(c ConsumerOffsets) Option {
+	return func(h *Handlers) {
+		h.consumerOffsets = c
+	}
+}
+
+// WithConsumerStatus enables /admin/consumer/status.
+func WithConsumerStatus(c ConsumerStatusReporter) Option {
+	return func(h *Handlers) {
+		h.consumerStatus = c
+	}
+}
+
+// WithStore enables the per-user /me endpoints backed by s.
+func WithStore(s store.Store) Option {
+	return func(h *Handlers) {
+		h.store = s
+	}
+}
+
+// WithKafkaChecker adds Kafka broker connectivity to /health.
+func WithKafkaChecker(c KafkaChecker) Option {
+	return func(h *Handlers) {
+		h.kafka = c
+	}
+}
+
+// WithReindexJob makes /admin/reindex run a full resync from Django.
+func WithReindexJob(j ReindexJob) Option {
+	return func(h *Handlers) {
+		h.reindex = j
+	}
+}
+
+// WithEventTracker lets /admin/tutors/{id}/freshness compare the index with
+// the newest Kafka event per tutor.
+func WithEventTracker(t EventTracker) Option {
+	return func(h *Handlers) {
+		h.events = t
+	}
+}
+
+// WithSnapshotTracker backs /admin/snapshot-ingest/status.
+func WithSnapshotTracker(t SnapshotTracker) Option {
+	return func(h *Handlers) {
+		h.snapshots = t
+	}
+}
+
+// WithEventStats backs /admin/events/stats.
+func WithEventStats(r EventStatsReporter) Option {
+	return func(h *Handlers) {
+		h.eventStats = r
+	}
+}
+
+// WithExperiment assigns searches to e's variants.
+func WithExperiment(e *experiment.Experiment) Option {
+	return func(h *Handlers) {
+		h.experiment = e
+	}
+}
+
+// WithMaxTutorID makes tutor ID path parameters above n invalid instead of
+// those above domain.DefaultMaxTutorID. Zero keeps the default.
+func WithMaxTutorID(n int64) Option {
+	return func(h *Handlers) {
+		if n > 0 {
+			h.maxTutorID = n
+		}
+	}
+}
+
+// WithMaxListItems caps the subjects and formats of each indexed tutor at
+// n entries instead of domain.DefaultMaxListItems. Zero keeps the default.
+func WithMaxListItems(n int) Option {
+	return func(h *Handlers) {
+		if n > 0 {
+			h.maxListItems = n
+		}
+	}
+}
+
+// WithAvatarPolicy normalizes avatar URLs with p instead of only stripping
+// the default tracking parameters.
+func WithAvatarPolicy(p domain.AvatarPolicy) Option {
+	return func(h *Handlers) {
+		h.avatars = p
+	}
+}
+
+// WithRatingMode sets how a tutor with a rating but no reviews is handled:
+// domain.RatingModeStrict rejects it, the default zeroes the rating.
+func WithRatingMode(mode string) Option {
+	return func(h *Handlers) {
+		h.ratingMode = mode
+	}
+}
+
+// WithSubjectCatalog maps indexed and searched subjects to c's keys and
+// labels instead of the embedded defaults. Nil keeps the defaults.
+func WithSubjectCatalog(c *domain.SubjectCatalog) Option {
+	return func(h *Handlers) {
+		if c != nil {
+			h.subjects = c
+		}
+	}
+}
+
+// WithTranslations labels subjects in localized responses with t instead
+// of the embedded translations. Nil keeps the defaults.
+func WithTranslations(t *domain.Translations) Option {
+	return func(h *Handlers) {
+		if t != nil {
+			h.translations = t
+		}
+	}
+}
+
+// WithScrubber runs the bio and headline of every indexed tutor through s.
+// Nil indexes them as sent.
+func WithScrubber(s domain.Scrubber) Option {
+	return func(h *Handlers) {
+		h.scrubber = s
+	}
+}
+
+// WithQueryLimits caps the filter values a search request may list. The
+// hard caps of NewQueryValidator apply with or without it.
+func WithQueryLimits(l QueryLimits) Option {
+	return func(h *Handlers) {
+		h.queries.Limits = l
+	}
+}
+
+// WithReadiness makes /health/ready report r's startup state.
+func WithReadiness(r ReadinessChecker) Option {
+	return func(h *Handlers) {
+		h.readiness = r
+	}
+}
+
+// WithWatermark backs GET /admin/freshness with w.
+func WithWatermark(w WatermarkSource) Option {
+	return func(h *Handlers) {
+		h.watermark = w
+	}
+}
+
+// WithDriftReporter backs GET /admin/drift with d.
+func WithDriftReporter(d DriftReporter) Option {
+	return func(h *Handlers) {
+		h.drift = d
+	}
+}
+
+// WithLeaseReporter backs GET /admin/lock with l.
+func WithLeaseReporter(l LeaseReporter) Option {
+	return func(h *Handlers) {
+		h.lease = l
+	}
+}
+
+// WithWriteJournal queues failed tutor upserts and deletes in j for
+// replay and backs GET /admin/pending.
+func WithWriteJournal(j *outbox.Journal) Option {
+	return func(h *Handlers) {
+		h.journal = j
+	}
+}
+
+// WithDraining ends streaming responses, the activity stream and CSV
+// exports, once draining is closed, so they do not hold the server past
+// its shutdown grace.
+func WithDraining(draining <-chan struct{}) Option {
+	return func(h *Handlers) {
+		h.draining = draining
+	}
+}
+
+// WithRelevanceReloader backs POST /admin/config/reload with r and adds
+// its settings to GET /admin/config.
+func WithRelevanceReloader(r RelevanceReloader) Option {
+	return func(h *Handlers) {
+		h.relevance = r
+	}
+}
+
+// WithEffectiveConfig reports cfg from GET /admin/config. Its LogValue
+// must redact secrets, as it does for the startup log.
+func WithEffectiveConfig(cfg slog.LogValuer) Option {
+	return func(h *Handlers) {
+		h.settings = cfg
+	}
+}
+
+// WithFeedbackSink enables POST /feedback, handing accepted events to s.
+func WithFeedbackSink(s feedback.Sink) Option {
+	return func(h *Handlers) {
+		h.feedback = s
+	}
+}
+
+// WithFeedbackRateLimit turns away POST /feedback requests from clients
+// that l refuses.
+func WithFeedbackRateLimit(l RateLimiter) Option {
+	return func(h *Handlers) {
+		h.feedbackRate = l
+	}
+}
+
+// WithMaxFeedbackBatch caps the events per POST /feedback request at n
+// instead of DefaultMaxFeedbackBatch. Zero keeps the default.
+func WithMaxFeedbackBatch(n int) Option {
+	return func(h *Handlers) {
+		if n > 0 {
+			h.maxFeedbackBatch = n
+		}
+	}
+}
+
+// WithMaxResponseBytes trims JSON search responses encoding to more than n
+// bytes by dropping optional fields; see fitResponse. Zero disables it.
+func WithMaxResponseBytes(n int) Option {
+	return func(h *Handlers) {
+		h.maxResponseBytes = n
+	}
+}
+
+// WithMaxStaleness makes /health/ready fail while the index is more than d
+// behind Django with messages still waiting. Zero disables the check.
+func WithMaxStaleness(d time.Duration) Option {
+	return func(h *Handlers) {
+		h.maxStaleness = d
+	}
+}
+
+// WithAuditLog backs GET /admin/audit with log.
+func WithAuditLog(log *audit.Log) Option {
+	return func(h *Handlers) {
+		h.audit = log
+	}
+}
+
+func NewHandlers(os port.SearchClient, logger *slog.Logger, opts ...Option) *Handlers {
+	h := &Handlers{
+		os:           os,
+		logger:       logger,
+		maxTutorID:   domain.DefaultMaxTutorID,
+		maxListItems: domain.DefaultMaxListItems,
+		avatars:      domain.AvatarPolicy{StripParams: domain.DefaultAvatarStripParams},
+		subjects:     domain.DefaultSubjectCatalog(),
+		translations: domain.DefaultTranslations(),
+		queries:      NewQueryValidator(QueryLimits{}),
+
+		maxFeedbackBatch: DefaultMaxFeedbackBatch,
+	}
+	for _, opt := range opts {
+		opt(h)
 	}
+	return h
 }
 
+// Health reports OpenSearch and, when a KafkaChecker is configured, Kafka
+// connectivity. A Kafka outage only fails the check once it outlasts the
+// checker's grace period.
 func (h *Handlers) Health(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -36,21 +388,43 @@ func (h *Handlers) Health(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]string{
-		"status":     "ok",
-		"opensearch": "connected",
-	})
+	if h.kafka == nil {
+		respondJSON(w, http.StatusOK, map[string]string{
+			"status":     "ok",
+			"opensearch": "connected",
+		})
+		return
+	}
+
+	kh := h.kafka.Check(ctx)
+	resp := map[string]any{
+		"status":                           "ok",
+		"opensearch":                       "connected",
+		"kafka":                            "connected",
+		"kafka_seconds_since_last_message": nil,
+	}
+	if !kh.LastMessageAt.IsZero() {
+		resp["kafka_seconds_since_last_message"] = int64(time.Since(kh.LastMessageAt).Seconds())
+	}
+	status := http.StatusOK
+	if !kh.Connected {
+		resp["kafka"] = "disconnected"
+		h.logger.Warn("Kafka brokers unreachable", "within_grace_period", kh.Healthy)
+	}
+	if !kh.Healthy {
+		resp["status"] = "unhealthy"
+		status = http.StatusServiceUnavailable
+	}
+	respondJSON(w, status, resp)
 }
 
 func (h *Handlers) UpsertTutor(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	idStr := r.PathValue("id")
-
-	id, err := strconv.ParseInt(idStr, 10, 64)
-	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid tutor ID")
+	id, ok := h.tutorID(w, r, "id")
+	if !ok {
 		return
 	}
+	audit.AddTutorIDs(ctx, id)
 
 	var tutor domain.Tutor
 	if err := json.NewDecoder(r.Body).Decode(&tutor); err != nil {
@@ -58,13 +432,35 @@ func (h *Handlers) UpsertTutor(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The path is authoritative; a conflicting body ID almost always means
+	// the caller built the URL and payload from different records.
+	if tutor.ID != 0 && tutor.ID != id {
+		respondError(w, http.StatusConflict, fmt.Sprintf("Body ID %d does not match path ID %d", tutor.ID, id))
+		return
+	}
 	tutor.ID = id
 
+	if err := h.sanitize(&tutor); err != nil {
+		respondValidationError(w, err)
+		return
+	}
+	if err := tutor.Validate(); err != nil {
+		respondValidationError(w, err)
+		return
+	}
+	tutor.MarkIndexed(time.Now())
+
 	if err := h.os.UpsertTutor(ctx, &tutor); err != nil {
+		if h.queueWrite(ctx, w, outbox.OpUpsert, id, &tutor, err) {
+			return
+		}
 		h.logger.Error("Failed to upsert tutor", "id", id, "error", err)
-		respondError(w, http.StatusInternalServerError, "Failed to index tutor")
+		respondBackendError(w, err, "Failed to index tutor")
 		return
 	}
+	h.forgetPending(ctx, id)
+
+	h.activity.Publish(activity.Event{Type: activity.TypeUpsert, Source: activity.SourceHTTP, TutorID: id})
 
 	respondJSON(w, http.StatusOK, map[string]any{
 		"status":   "indexed",
@@ -74,19 +470,32 @@ func (h *Handlers) UpsertTutor(w http.ResponseWriter, r *http.Request) {
 
 func (h *Handlers) DeleteTutor(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	idStr := r.PathValue("id")
-
-	id, err := strconv.ParseInt(idStr, 10, 64)
-	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid tutor ID")
+	id, ok := h.tutorID(w, r, "id")
+	if !ok {
 		return
 	}
+	audit.AddTutorIDs(ctx, id)
+
+	idempotent := r.URL.Query().Get("idempotent") == "true"
 
-	if err := h.os.DeleteTutor(ctx, id); err != nil {
+	err := h.os.DeleteTutor(ctx, id)
+	switch {
+	case errors.Is(err, port.ErrNotFound) && !idempotent:
+		// A queued upsert would bring the tutor back.
+		h.forgetPending(ctx, id)
+		respondError(w, http.StatusNotFound, "Tutor not found")
+		return
+	case err != nil && !errors.Is(err, port.ErrNotFound):
+		if h.queueWrite(ctx, w, outbox.OpDelete, id, nil, err) {
+			return
+		}
 		h.logger.Error("Failed to delete tutor", "id", id, "error", err)
-		respondError(w, http.StatusInternalServerError, "Failed to delete tutor")
+		respondBackendError(w, err, "Failed to delete tutor")
 		return
 	}
+	h.forgetPending(ctx, id)
+
+	h.activity.Publish(activity.Event{Type: activity.TypeDelete, Source: activity.SourceHTTP, TutorID: id})
 
 	respondJSON(w, http.StatusOK, map[string]any{
 		"status":   "deleted",
@@ -95,19 +504,112 @@ func (h *Handlers) DeleteTutor(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *Handlers) SearchTutors(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-	query := parseSearchQuery(r)
+	start := time.Now()
+	ctx, timing := port.NewTimingContext(r.Context())
+	params, ok := h.validateQuery(w, r.URL.RawQuery)
+	if !ok {
+		return
+	}
+	query := parseSearchValues(params)
+	query.Subjects = h.subjects.Keys(query.Subjects)
+	query.Variant = h.variant(r)
+
+	if params.Has("group_by") {
+		if qerr := checkGroupBy(params, query.Subjects); qerr != nil {
+			respondJSON(w, http.StatusBadRequest, qerr)
+			return
+		}
+		h.searchSections(ctx, w, r, query, timing, start)
+		return
+	}
 
-	result, err := h.os.SearchTutors(ctx, query)
+	facetSize := 0
+	if params.Get("include_facets") == "true" {
+		// Validate has checked facet_size.
+		facetSize, _ = h.queries.Limits.facetSize(params)
+	}
+	result, err := h.search(ctx, query, facetSize)
 	if err != nil {
 		h.logger.Error("Failed to search tutors", "error", err)
-		respondError(w, http.StatusInternalServerError, "Failed to search tutors")
+		respondBackendError(w, err, "Failed to search tutors")
+		return
+	}
+	if rejectPartial(w, r, result) {
 		return
 	}
+	lang := h.language(w, r)
+	stripIndexMeta(r, result.Results)
+	h.localizeSubjects(result.Results, lang)
+	h.logSearchAnalytics(query, result)
+
+	applied := query.Normalized()
+	result.AppliedFilters = &applied
+	w.Header().Set(QueryHashHeader, query.Hash())
+	setPaginationHeaders(w, r, query, result.Total)
+	setServerTiming(w, timing, start)
+	respondJSON(w, http.StatusOK, h.fitResponse(r, result, lang))
+}
 
-	respondJSON(w, http.StatusOK, result)
+// rejectPartial answers 503 and returns true when some shards failed and
+// the caller asked for complete results with allow_partial=false. By
+// default partial results are served with "partial": true.
+func rejectPartial(w http.ResponseWriter, r *http.Request, result *port.SearchResponse) bool {
+	if !result.Partial || r.URL.Query().Get("allow_partial") != "false" {
+		return false
+	}
+	respondError(w, http.StatusServiceUnavailable, fmt.Sprintf(
+		"Search results are incomplete: %d of %d shards failed", result.ShardsFailed, result.ShardsTotal))
+	return true
 }
 
+// maxSyncErrors caps the per-tutor errors a sync reports.
+const maxSyncErrors = 50
+
+// Sync error categories.
+const (
+	// syncErrValidation marks a tutor the sync refused to index.
+	syncErrValidation = "validation"
+	// syncErrIndex marks a tutor the search backend failed to write.
+	syncErrIndex = "index"
+	// syncErrOverloaded marks the tutor at which the search backend pushed
+	// back; the sync stops there and the rest are not attempted.
+	syncErrOverloaded = "overloaded"
+)
+
+type syncError struct {
+	// Index is the tutor's position in the request body.
+	Index    int    `json:"index"`
+	ID       int64  `json:"id"`
+	Category string `json:"category"`
+	Error    string `json:"error"`
+}
+
+type syncResponse struct {
+	Synced int `json:"synced"`
+	Total  int `json:"total"`
+	Failed int `json:"failed"`
+	// NotAttempted counts the tutors after one the backend pushed back on.
+	NotAttempted int `json:"not_attempted,omitempty"`
+	// Errors lists the first maxSyncErrors failures in request order.
+	Errors    []syncError `json:"errors"`
+	Truncated bool        `json:"truncated"`
+}
+
+// addError records a failed tutor, keeping the first maxSyncErrors.
+func (s *syncResponse) addError(index int, id int64, category string, err error) {
+	s.Failed++
+	if len(s.Errors) == maxSyncErrors {
+		s.Truncated = true
+		return
+	}
+	s.Errors = append(s.Errors, syncError{Index: index, ID: id, Category: category, Error: err.Error()})
+}
+
+// SyncTutors indexes a JSON array of tutors, skipping invalid ones, and
+// answers 207 listing the failures when any tutor was not synced. When the
+// backend is overloaded the sync stops at that tutor and sets Retry-After,
+// so the caller can resend the rest. With dry_run=true it only reports
+// what would change; see syncDryRun.
 func (h *Handlers) SyncTutors(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -116,42 +618,178 @@ func (h *Handlers) SyncTutors(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
+	if r.URL.Query().Get("dry_run") == "true" {
+		h.syncDryRun(w, r, tutors)
+		return
+	}
 
-	synced := 0
-	for _, tutor := range tutors {
-		if err := h.os.UpsertTutor(ctx, &tutor); err != nil {
+	resp := syncResponse{Total: len(tutors), Errors: []syncError{}}
+	now := time.Now()
+	for i, tutor := range tutors {
+		err := h.sanitize(&tutor)
+		if err == nil {
+			err = tutor.Validate()
+		}
+		if err != nil {
+			h.logger.Warn("Skipped invalid tutor in sync", "id", tutor.ID, "error", err)
+			resp.addError(i, tutor.ID, syncErrValidation, err)
+			continue
+		}
+		tutor.MarkIndexed(now)
+		err = h.os.UpsertTutor(ctx, &tutor)
+		if errors.Is(err, port.ErrOverloaded) {
+			h.logger.Warn("Search backend overloaded, stopping sync", "id", tutor.ID, "synced", resp.Synced, "remaining", len(tutors)-i)
+			resp.addError(i, tutor.ID, syncErrOverloaded, err)
+			resp.NotAttempted = len(tutors) - i - 1
+			w.Header().Set("Retry-After", overloadRetryAfter)
+			break
+		}
+		if err != nil {
 			h.logger.Error("Failed to sync tutor", "id", tutor.ID, "error", err)
+			resp.addError(i, tutor.ID, syncErrIndex, err)
 			continue
 		}
-		synced++
+		resp.Synced++
 	}
+	audit.SetCount(ctx, resp.Synced)
 
-	respondJSON(w, http.StatusOK, map[string]int{
-		"synced": synced,
-		"total":  len(tutors),
+	h.activity.Publish(activity.Event{
+		Type:   activity.TypeSync,
+		Source: activity.SourceHTTP,
+		Synced: resp.Synced,
+		Total:  len(tutors),
 	})
+
+	status := http.StatusOK
+	if resp.Synced < resp.Total {
+		status = http.StatusMultiStatus
+	}
+	respondJSON(w, status, resp)
 }
 
+// sanitize normalizes the tutor's rating, cleans its lists and avatar URL,
+// scrubs its text and maps subjects to canonical keys, warning when a
+// rating was zeroed, a list had to be cut to the cap or the avatar was
+// dropped. It returns a
+// *domain.ValidationError for a rating without reviews in strict mode.
+func (h *Handlers) sanitize(tutor *domain.Tutor) error {
+	rating := tutor.Rating
+	zeroed, err := tutor.NormalizeRating(h.ratingMode)
+	if err != nil {
+		return err
+	}
+	if zeroed {
+		h.logger.Warn("Zeroed rating of tutor without reviews",
+			"tutor_id", tutor.ID,
+			"rating", rating,
+		)
+	}
+	raw := tutor.AvatarURL
+	if err := tutor.NormalizeAvatar(h.avatars); err != nil {
+		h.logger.Warn("Dropped invalid avatar URL",
+			"tutor_id", tutor.ID,
+			"avatar_url", raw,
+			"error", err,
+		)
+	}
+	if truncated := tutor.Sanitize(h.maxListItems); len(truncated) > 0 {
+		h.logger.Warn("Truncated oversized tutor lists",
+			"tutor_id", tutor.ID,
+			"fields", truncated,
+			"max_items", h.maxListItems,
+		)
+	}
+	if zeroed := tutor.NormalizeTimes(time.Now()); len(zeroed) > 0 {
+		h.logger.Warn("Zeroed implausible tutor timestamps", "tutor_id", tutor.ID, "fields", zeroed)
+	}
+	if scrubbed := tutor.Scrub(h.scrubber); len(scrubbed) > 0 {
+		h.logger.Info("Scrubbed tutor text", "tutor_id", tutor.ID, "fields", scrubbed)
+	}
+	tutor.CanonicalizeSubjects(h.subjects)
+	tutor.NormalizeRates()
+	return nil
+}
+
+// Reindex starts a full resync from Django in the background. Without a
+// configured job it only points callers at /admin/sync.
 func (h *Handlers) Reindex(w http.ResponseWriter, r *http.Request) {
+	if h.reindex == nil {
+		respondJSON(w, http.StatusAccepted, map[string]string{
+			"status":  "accepted",
+			"message": "Use /admin/sync with tutor data from Django",
+		})
+		return
+	}
+
+	// The run outlives this request.
+	err := h.reindex.Start(context.WithoutCancel(r.Context()), reindex.TriggerManual)
+	if errors.Is(err, reindex.ErrRunning) {
+		respondError(w, http.StatusConflict, "Reindex already running")
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to start reindex", "error", err)
+		respondError(w, http.StatusInternalServerError, "Failed to start reindex")
+		return
+	}
+
 	respondJSON(w, http.StatusAccepted, map[string]string{
-		"status":  "accepted",
-		"message": "Use /admin/sync with tutor data from Django",
+		"status":  "started",
+		"message": "Poll /admin/reindex/last for the result",
 	})
 }
 
-func parseSearchQuery(r *http.Request) opensearch.SearchQuery {
-	q := r.URL.Query()
+// ReindexStatus reports the last finished reindex run and whether one is in
+// progress.
+func (h *Handlers) ReindexStatus(w http.ResponseWriter, r *http.Request) {
+	if h.reindex == nil {
+		respondError(w, http.StatusNotFound, "Reindex from Django is not configured")
+		return
+	}
+
+	last, running := h.reindex.Status()
+	respondJSON(w, http.StatusOK, map[string]any{
+		"running":  running,
+		"last_run": last,
+	})
+}
+
+func parseSearchQuery(r *http.Request) port.SearchQuery {
+	return parseSearchValues(r.URL.Query())
+}
 
-	query := opensearch.SearchQuery{
-		Text:     q.Get("q"),
-		Format:   q.Get("format"),
-		Location: q.Get("location"),
+// parseSearchValues builds a SearchQuery from /tutors/search parameters.
+// Unparseable numbers are ignored.
+func parseSearchValues(q url.Values) port.SearchQuery {
+	query := port.SearchQuery{
+		Text:          q.Get("q"),
+		Format:        q.Get("format"),
+		Location:      q.Get("location"),
+		Certification: q.Get("certification"),
+		Badge:         q.Get("badge"),
 	}
 
 	if subjects := q["subjects"]; len(subjects) > 0 {
 		query.Subjects = subjects
 	}
 
+	for _, level := range q["level"] {
+		query.Levels = append(query.Levels, strings.ToLower(level))
+	}
+
+	query.Trial, _ = strconv.ParseBool(q.Get("trial"))
+
+	query.Sort = sortOrders[strings.ToLower(q.Get("sort"))]
+	query.DiversifyBy = strings.ToLower(q.Get("diversify_by"))
+
+	for _, raw := range q["fields"] {
+		for _, field := range strings.Split(raw, ",") {
+			if strings.TrimSpace(field) == "bio" {
+				query.IncludeBio = true
+			}
+		}
+	}
+
 	if minPrice := q.Get("min_price"); minPrice != "" {
 		if v, err := strconv.ParseFloat(minPrice, 64); err == nil {
 			query.MinPrice = &v
@@ -170,6 +808,22 @@ func parseSearchQuery(r *http.Request) opensearch.SearchQuery {
 		}
 	}
 
+	if days := q.Get("available_within_days"); days != "" {
+		if v, err := strconv.Atoi(days); err == nil && v > 0 {
+			query.AvailableWithinDays = v
+		}
+	}
+
+	query.AvailableBetween, _ = availableBetween(q, time.Now())
+
+	for _, raw := range q["exclude_ids"] {
+		for _, part := range strings.Split(raw, ",") {
+			if v, err := strconv.ParseInt(strings.TrimSpace(part), 10, 64); err == nil {
+				query.ExcludeIDs = append(query.ExcludeIDs, v)
+			}
+		}
+	}
+
 	if limit := q.Get("limit"); limit != "" {
 		if v, err := strconv.Atoi(limit); err == nil {
 			query.Limit = v
@@ -185,12 +839,39 @@ func parseSearchQuery(r *http.Request) opensearch.SearchQuery {
 	return query
 }
 
-func respondJSON(w http.ResponseWriter, status int, data any) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	_ = json.NewEncoder(w).Encode(data)
-}
-
 func respondError(w http.ResponseWriter, status int, message string) {
 	respondJSON(w, status, map[string]string{"error": message})
 }
+
+// overloadRetryAfter is the Retry-After, in seconds, sent when the search
+// backend concurrency limit turns a request away.
+const overloadRetryAfter = "1"
+
+// respondBackendError writes the response for a failed search backend call:
+// 503 with Retry-After when the concurrency limit was reached, 422 when the
+// backend rejected the tutor document, otherwise a 500 with message.
+func respondBackendError(w http.ResponseWriter, err error, message string) {
+	if errors.Is(err, port.ErrOverloaded) {
+		w.Header().Set("Retry-After", overloadRetryAfter)
+		respondError(w, http.StatusServiceUnavailable, "Search backend is busy, retry shortly")
+		return
+	}
+	if errors.Is(err, port.ErrDocumentRejected) {
+		respondError(w, http.StatusUnprocessableEntity, "Tutor was rejected by the search backend")
+		return
+	}
+	respondError(w, http.StatusInternalServerError, message)
+}
+
+// respondValidationError writes a 400 listing each field violation.
+func respondValidationError(w http.ResponseWriter, err error) {
+	var verr *domain.ValidationError
+	if !errors.As(err, &verr) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	respondJSON(w, http.StatusBadRequest, map[string]any{
+		"error":      "Invalid tutor",
+		"violations": verr.Violations,
+	})
+}
@@ -5,24 +5,100 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"log/slog"
+	"fmt"
+	"maps"
 	"net/http"
 	"net/http/httptest"
-	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"search/internal/domain"
+	"search/internal/kafka"
 	"search/internal/opensearch"
+	"search/internal/port"
+	"search/internal/testutil"
 )
 
 type mockSearchClient struct {
 	pingErr       error
 	upsertErr     error
 	deleteErr     error
-	searchResult  *opensearch.SearchResponse
+	searchResult  *port.SearchResponse
 	searchErr     error
 	upsertedTutor *domain.Tutor
 	deletedID     int64
+	deletedIDs    []int64
+	indexedIDs    []int64
+	indexedErr    error
+	recreateErr   error
+	recreated     bool
+	searchedQuery port.SearchQuery
+	scanLimit     int
+	// scanned, if set, is called after each tutor ScanTutors hands out.
+	scanned func()
+	// upsertErrs fails the upserts of specific tutors.
+	upsertErrs map[int64]error
+	// bulkResults overrides the per-ID outcomes of BulkDeleteTutors, which
+	// otherwise reports every ID as deleted.
+	bulkResults    map[int64]port.BulkDeleteResult
+	bulkErr        error
+	bulkDeletedIDs []int64
+	rawBody        []byte
+	rawErr         error
+	// replicas records the last UpdateIndexSettings call.
+	replicas    int
+	settingsErr error
+	// experimentMapping records the last CreateExperimentIndex call;
+	// experimentErr fails it and DeleteExperimentIndex.
+	experimentMapping *port.ExperimentMapping
+	experimentDeleted bool
+	experimentErr     error
+	// aliases is what IndexAliases returns and PointIndexAliases moves;
+	// aliasErr fails both.
+	aliases  port.IndexAliases
+	aliasErr error
+	// tutor is returned by GetTutor when its ID matches.
+	tutor  *domain.Tutor
+	getErr error
+	// indexed holds the tutors GetTutors finds.
+	indexed map[int64]domain.Tutor
+	// topSubjects and topPerSubject record the last TopTutorsBySubject call.
+	topSubjects   []string
+	topPerSubject int
+	topErr        error
+	// facetCounts is returned by FacetCounts and with the search result by
+	// SearchTutorsWithFacets, which sets searchedWithFacets.
+	facetCounts        port.FacetCounts
+	searchedWithFacets bool
+	// facetSize is the size facets were last counted with; concurrent
+	// requests count them.
+	facetSize  atomic.Int64
+	countsErr  error
+	qualityErr error
+	// quickPrefix and quickSize record the last QuickSearch call, which
+	// returns quickResult.
+	quickPrefix string
+	quickSize   int
+	quickResult port.QuickSearchResult
+	quickErr    error
+	// popularityResult is returned by RecomputePopularity.
+	popularityResult port.PopularityResult
+	popularityErr    error
+	// priceSubject and priceByFormat record the last PriceStats call,
+	// which returns priceStats.
+	priceSubject  string
+	priceByFormat bool
+	priceStats    port.PriceStats
+	priceErr      error
+	// relatedSubject and relatedSize record the last RelatedSubjects call,
+	// which returns related.
+	relatedSubject string
+	relatedSize    int
+	related        port.RelatedSubjects
+	relatedErr     error
 }
 
 func (m *mockSearchClient) Ping(ctx context.Context) error {
@@ -37,28 +113,316 @@ func (m *mockSearchClient) UpsertTutor(ctx context.Context, tutor *domain.Tutor)
 	if m.upsertErr != nil {
 		return m.upsertErr
 	}
+	if err := m.upsertErrs[tutor.ID]; err != nil {
+		return err
+	}
 	m.upsertedTutor = tutor
 	return nil
 }
 
+func (m *mockSearchClient) BulkUpsertTutors(ctx context.Context, tutors []*domain.Tutor) (*port.BulkResult, error) {
+	result := &port.BulkResult{Items: make([]port.BulkItem, len(tutors))}
+	for i, tutor := range tutors {
+		result.Items[i] = port.BulkItem{ID: tutor.ID, Err: m.UpsertTutor(ctx, tutor)}
+	}
+	return result, nil
+}
+
+func (m *mockSearchClient) UpsertSnapshotTutor(ctx context.Context, tutor *domain.Tutor, snapshotID string) (bool, error) {
+	return true, nil
+}
+
+func (m *mockSearchClient) BookSlot(ctx context.Context, tutorID int64, slot time.Time) error {
+	return nil
+}
+
+func (m *mockSearchClient) FreeSlot(ctx context.Context, tutorID int64, slot time.Time) error {
+	return nil
+}
+
+func (m *mockSearchClient) CheckQuality(ctx context.Context, rules []string, limit, offset int) ([]port.QualityRuleResult, error) {
+	if m.qualityErr != nil {
+		return nil, m.qualityErr
+	}
+	return []port.QualityRuleResult{}, nil
+}
+
+func (m *mockSearchClient) UpdateBadges(ctx context.Context, tutorID int64, add, remove []string) error {
+	return nil
+}
+
+func (m *mockSearchClient) SetVerified(ctx context.Context, tutorID int64, verified bool, at time.Time) error {
+	return nil
+}
+
+func (m *mockSearchClient) SetOffersTrial(ctx context.Context, tutorID int64, offers bool) error {
+	return nil
+}
+
 func (m *mockSearchClient) DeleteTutor(ctx context.Context, id int64) error {
 	if m.deleteErr != nil {
 		return m.deleteErr
 	}
 	m.deletedID = id
+	m.deletedIDs = append(m.deletedIDs, id)
 	return nil
 }
 
-func (m *mockSearchClient) SearchTutors(ctx context.Context, query opensearch.SearchQuery) (*opensearch.SearchResponse, error) {
+func (m *mockSearchClient) BulkDeleteTutors(ctx context.Context, ids []int64) ([]port.BulkDeleteResult, error) {
+	if m.bulkErr != nil {
+		return nil, m.bulkErr
+	}
+	m.bulkDeletedIDs = ids
+	results := make([]port.BulkDeleteResult, len(ids))
+	for i, id := range ids {
+		result, ok := m.bulkResults[id]
+		if !ok {
+			result = port.BulkDeleteResult{ID: id, Status: port.BulkDeleted}
+		}
+		results[i] = result
+	}
+	return results, nil
+}
+
+func (m *mockSearchClient) GetTutor(ctx context.Context, id int64) (*domain.Tutor, error) {
+	if m.getErr != nil {
+		return nil, m.getErr
+	}
+	if m.tutor == nil || m.tutor.ID != id {
+		return nil, port.ErrNotFound
+	}
+	return m.tutor, nil
+}
+
+func (m *mockSearchClient) GetTutorBySlug(ctx context.Context, slug string) (*domain.Tutor, error) {
+	if m.getErr != nil {
+		return nil, m.getErr
+	}
+	if m.tutor == nil || (m.tutor.Slug != slug && !slices.Contains(m.tutor.PreviousSlugs, slug)) {
+		return nil, port.ErrNotFound
+	}
+	return m.tutor, nil
+}
+
+func (m *mockSearchClient) ChangeSlug(ctx context.Context, tutorID int64, oldSlug, newSlug string) error {
+	return nil
+}
+
+func (m *mockSearchClient) GetTutors(ctx context.Context, ids []int64) (map[int64]domain.Tutor, error) {
+	if m.getErr != nil {
+		return nil, m.getErr
+	}
+	found := make(map[int64]domain.Tutor)
+	for _, id := range ids {
+		if t, ok := m.indexed[id]; ok {
+			found[id] = t
+		}
+	}
+	return found, nil
+}
+
+func (m *mockSearchClient) SearchTutors(ctx context.Context, query port.SearchQuery) (*port.SearchResponse, error) {
+	m.searchedQuery = query
 	if m.searchErr != nil {
 		return nil, m.searchErr
 	}
 	return m.searchResult, nil
 }
 
+func (m *mockSearchClient) SearchTutorsWithFacets(ctx context.Context, query port.SearchQuery, facetSize int) (*port.SearchResponse, error) {
+	m.searchedWithFacets = true
+	m.facetSize.Store(int64(facetSize))
+	result, err := m.SearchTutors(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if m.countsErr != nil {
+		return nil, m.countsErr
+	}
+	withFacets := *result
+	withFacets.Facets = &m.facetCounts
+	return &withFacets, nil
+}
+
+func (m *mockSearchClient) SearchTutorsMulti(ctx context.Context, queries []port.SearchQuery) ([]*port.SearchResponse, error) {
+	results := make([]*port.SearchResponse, len(queries))
+	for i, query := range queries {
+		result, err := m.SearchTutors(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = result
+	}
+	return results, nil
+}
+
+func (m *mockSearchClient) ScanTutors(ctx context.Context, query port.SearchQuery, limit int, fn func(domain.Tutor) error) error {
+	m.searchedQuery = query
+	m.scanLimit = limit
+	if m.searchErr != nil {
+		return m.searchErr
+	}
+	if m.searchResult == nil {
+		return nil
+	}
+	for _, t := range m.searchResult.Results[:min(limit, len(m.searchResult.Results))] {
+		if err := fn(t); err != nil {
+			return err
+		}
+		if m.scanned != nil {
+			m.scanned()
+		}
+	}
+	return nil
+}
+
+func (m *mockSearchClient) RawSearch(ctx context.Context, body []byte) (json.RawMessage, error) {
+	m.rawBody = body
+	if m.rawErr != nil {
+		return nil, m.rawErr
+	}
+	return json.RawMessage(`{"hits":{"total":{"value":0},"hits":[]}}`), nil
+}
+
+func (m *mockSearchClient) IndexedTutorIDs(ctx context.Context) ([]int64, error) {
+	if m.indexedErr != nil {
+		return nil, m.indexedErr
+	}
+	return m.indexedIDs, nil
+}
+
+func (m *mockSearchClient) ScanTutorVersions(ctx context.Context, fn func(port.TutorVersion) error) error {
+	if m.indexedErr != nil {
+		return m.indexedErr
+	}
+	for _, id := range m.indexedIDs {
+		if err := fn(port.TutorVersion{ID: id}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mockSearchClient) CountTutors(ctx context.Context) (int64, error) {
+	if m.indexedErr != nil {
+		return 0, m.indexedErr
+	}
+	return int64(len(m.indexedIDs)), nil
+}
+
+func (m *mockSearchClient) RecreateIndex(ctx context.Context) (*port.RecreateResult, error) {
+	if m.recreateErr != nil {
+		return nil, m.recreateErr
+	}
+	m.recreated = true
+	return &port.RecreateResult{OldCount: int64(len(m.indexedIDs))}, nil
+}
+
+func (m *mockSearchClient) RecomputePopularity(ctx context.Context) (*port.PopularityResult, error) {
+	if m.popularityErr != nil {
+		return nil, m.popularityErr
+	}
+	result := m.popularityResult
+	return &result, nil
+}
+
+func (m *mockSearchClient) TopTutorsBySubject(ctx context.Context, subjects []string, perSubject int) (map[string][]domain.Tutor, error) {
+	m.topSubjects = subjects
+	m.topPerSubject = perSubject
+	if m.topErr != nil {
+		return nil, m.topErr
+	}
+	result := make(map[string][]domain.Tutor, len(subjects))
+	for _, s := range subjects {
+		result[s] = []domain.Tutor{}
+	}
+	return result, nil
+}
+
+func (m *mockSearchClient) RelatedSubjects(ctx context.Context, subject string, size int) (*port.RelatedSubjects, error) {
+	m.relatedSubject = subject
+	m.relatedSize = size
+	if m.relatedErr != nil {
+		return nil, m.relatedErr
+	}
+	return &m.related, nil
+}
+
+func (m *mockSearchClient) PriceStats(ctx context.Context, subject string, byFormat bool) (*port.PriceStats, error) {
+	m.priceSubject = subject
+	m.priceByFormat = byFormat
+	if m.priceErr != nil {
+		return nil, m.priceErr
+	}
+	return &m.priceStats, nil
+}
+
+func (m *mockSearchClient) FacetCounts(ctx context.Context, size int) (*port.FacetCounts, error) {
+	m.facetSize.Store(int64(size))
+	if m.countsErr != nil {
+		return nil, m.countsErr
+	}
+	return &m.facetCounts, nil
+}
+
+func (m *mockSearchClient) QuickSearch(ctx context.Context, prefix string, size int) (*port.QuickSearchResult, error) {
+	m.quickPrefix, m.quickSize = prefix, size
+	if m.quickErr != nil {
+		return nil, m.quickErr
+	}
+	return &m.quickResult, nil
+}
+
+func (m *mockSearchClient) UpdateIndexSettings(ctx context.Context, replicas int) error {
+	if m.settingsErr != nil {
+		return m.settingsErr
+	}
+	m.replicas = replicas
+	return nil
+}
+
+func (m *mockSearchClient) CreateExperimentIndex(ctx context.Context, mapping port.ExperimentMapping) (*port.ExperimentIndex, error) {
+	if m.experimentErr != nil {
+		return nil, m.experimentErr
+	}
+	m.experimentMapping = &mapping
+	alias := port.IndexFor(ctx) + port.ExperimentIndexSuffix
+	return &port.ExperimentIndex{Alias: alias, Index: alias + "-1", ReindexTask: "node:1"}, nil
+}
+
+func (m *mockSearchClient) DeleteExperimentIndex(ctx context.Context) error {
+	if m.experimentErr != nil {
+		return m.experimentErr
+	}
+	m.experimentDeleted = true
+	return nil
+}
+
+func (m *mockSearchClient) IndexAliases(ctx context.Context) (*port.IndexAliases, error) {
+	if m.aliasErr != nil {
+		return nil, m.aliasErr
+	}
+	aliases := m.aliases
+	return &aliases, nil
+}
+
+func (m *mockSearchClient) PointIndexAliases(ctx context.Context, read, write string) (*port.IndexAliases, error) {
+	if m.aliasErr != nil {
+		return nil, m.aliasErr
+	}
+	if read != "" {
+		m.aliases.Read.Index = read
+	}
+	if write != "" {
+		m.aliases.Write.Index = write
+	}
+	aliases := m.aliases
+	return &aliases, nil
+}
+
 func TestHealth_Healthy(t *testing.T) {
 	mock := &mockSearchClient{}
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	logger := testutil.NewLogger(t)
 	handlers := NewHandlers(mock, logger)
 
 	req := httptest.NewRequest("GET", "/health", nil)
@@ -80,7 +444,7 @@ func TestHealth_Healthy(t *testing.T) {
 
 func TestHealth_Unhealthy(t *testing.T) {
 	mock := &mockSearchClient{pingErr: errors.New("connection error")}
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	logger := testutil.NewLogger(t)
 	handlers := NewHandlers(mock, logger)
 
 	req := httptest.NewRequest("GET", "/health", nil)
@@ -93,15 +457,93 @@ func TestHealth_Unhealthy(t *testing.T) {
 	}
 }
 
+// stubKafkaChecker returns a fixed health result.
+type stubKafkaChecker struct {
+	health kafka.Health
+}
+
+func (s stubKafkaChecker) Check(context.Context) kafka.Health {
+	return s.health
+}
+
+func TestHealth_Kafka(t *testing.T) {
+	tests := []struct {
+		name        string
+		health      kafka.Health
+		wantStatus  int
+		wantKafka   string
+		wantOverall string
+	}{
+		{"connected", kafka.Health{Connected: true, Healthy: true}, http.StatusOK, "connected", "ok"},
+		{"disconnected within grace period", kafka.Health{Healthy: true}, http.StatusOK, "disconnected", "ok"},
+		{"disconnected past grace period", kafka.Health{}, http.StatusServiceUnavailable, "disconnected", "unhealthy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := testutil.NewLogger(t)
+			handlers := NewHandlers(&mockSearchClient{}, logger, WithKafkaChecker(stubKafkaChecker{tt.health}))
+
+			rec := httptest.NewRecorder()
+			handlers.Health(rec, httptest.NewRequest("GET", "/health", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			var response map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if response["kafka"] != tt.wantKafka {
+				t.Errorf("expected kafka %q, got %v", tt.wantKafka, response["kafka"])
+			}
+			if response["status"] != tt.wantOverall {
+				t.Errorf("expected status %q, got %v", tt.wantOverall, response["status"])
+			}
+			if v, ok := response["kafka_seconds_since_last_message"]; !ok || v != nil {
+				t.Errorf("expected null seconds since last message before any message, got %v", v)
+			}
+		})
+	}
+}
+
+func TestHealth_KafkaSecondsSinceLastMessage(t *testing.T) {
+	logger := testutil.NewLogger(t)
+	checker := stubKafkaChecker{kafka.Health{Connected: true, Healthy: true, LastMessageAt: time.Now().Add(-90 * time.Second)}}
+	handlers := NewHandlers(&mockSearchClient{}, logger, WithKafkaChecker(checker))
+
+	rec := httptest.NewRecorder()
+	handlers.Health(rec, httptest.NewRequest("GET", "/health", nil))
+
+	var response map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	if got, _ := response["kafka_seconds_since_last_message"].(float64); got < 90 || got > 91 {
+		t.Errorf("expected about 90 seconds since last message, got %v", response["kafka_seconds_since_last_message"])
+	}
+}
+
+func TestHealth_WithoutKafkaCheckerOmitsKafka(t *testing.T) {
+	logger := testutil.NewLogger(t)
+	handlers := NewHandlers(&mockSearchClient{}, logger)
+
+	rec := httptest.NewRecorder()
+	handlers.Health(rec, httptest.NewRequest("GET", "/health", nil))
+
+	if strings.Contains(rec.Body.String(), "kafka") {
+		t.Errorf("expected no kafka fields without a checker, got %s", rec.Body.String())
+	}
+}
+
 func TestUpsertTutor_Success(t *testing.T) {
 	mock := &mockSearchClient{}
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	logger := testutil.NewLogger(t)
 	handlers := NewHandlers(mock, logger)
 
 	tutor := domain.Tutor{
-		FullName: "Test Tutor",
-		Headline: "Test Headline",
-		Rating:   4.5,
+		FullName:     "Test Tutor",
+		Headline:     "Test Headline",
+		Rating:       4.5,
+		ReviewsCount: 10,
 	}
 
 	body, _ := json.Marshal(tutor)
@@ -109,6 +551,7 @@ func TestUpsertTutor_Success(t *testing.T) {
 	req.SetPathValue("id", "123")
 	rec := httptest.NewRecorder()
 
+	before := time.Now().Truncate(domain.TimePrecision)
 	handlers.UpsertTutor(rec, req)
 
 	if rec.Code != http.StatusOK {
@@ -117,11 +560,275 @@ func TestUpsertTutor_Success(t *testing.T) {
 	if mock.upsertedTutor.ID != 123 {
 		t.Errorf("expected ID 123, got %d", mock.upsertedTutor.ID)
 	}
+	if at := mock.upsertedTutor.IndexedAt; at == nil || at.Before(before) || at.Location() != time.UTC {
+		t.Errorf("expected indexed_at stamped in UTC after %v, got %v", before, at)
+	}
+}
+
+func TestUpsertTutor_BodyIDMismatch(t *testing.T) {
+	logger := testutil.NewLogger(t)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{"ids match", `{"id": 123, "full_name": "Test Tutor"}`, http.StatusOK},
+		{"body omits id", `{"full_name": "Test Tutor"}`, http.StatusOK},
+		{"ids differ", `{"id": 456, "full_name": "Test Tutor"}`, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{}
+			handlers := NewHandlers(mock, logger)
+
+			req := httptest.NewRequest("PUT", "/tutors/123", bytes.NewReader([]byte(tt.body)))
+			req.SetPathValue("id", "123")
+			rec := httptest.NewRecorder()
+
+			handlers.UpsertTutor(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if tt.wantStatus == http.StatusOK && mock.upsertedTutor.ID != 123 {
+				t.Errorf("expected ID 123, got %d", mock.upsertedTutor.ID)
+			}
+			if tt.wantStatus == http.StatusConflict && mock.upsertedTutor != nil {
+				t.Error("expected mismatched tutor not to be indexed")
+			}
+		})
+	}
+}
+
+func TestUpsertTutor_ValidationErrors(t *testing.T) {
+	mock := &mockSearchClient{}
+	logger := testutil.NewLogger(t)
+	handlers := NewHandlers(mock, logger)
+
+	body := []byte(`{"full_name": "Test Tutor", "rating": 12, "hourly_rate": -1, "slug": "Bad Slug"}`)
+	req := httptest.NewRequest("PUT", "/tutors/123", bytes.NewReader(body))
+	req.SetPathValue("id", "123")
+	rec := httptest.NewRecorder()
+
+	handlers.UpsertTutor(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+	if mock.upsertedTutor != nil {
+		t.Error("expected invalid tutor not to be indexed")
+	}
+
+	var response struct {
+		Error      string              `json:"error"`
+		Violations []domain.FieldError `json:"violations"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	fields := map[string]string{}
+	for _, v := range response.Violations {
+		fields[v.Field] = v.Code
+	}
+	want := map[string]string{
+		"rating":      domain.CodeOutOfRange,
+		"hourly_rate": domain.CodeOutOfRange,
+		"slug":        domain.CodeInvalidFormat,
+	}
+	for field, code := range want {
+		if fields[field] != code {
+			t.Errorf("expected %s violation %q, got %q", field, code, fields[field])
+		}
+	}
+}
+
+func TestUpsertTutor_SanitizesLists(t *testing.T) {
+	mock := &mockSearchClient{}
+	handlers := NewHandlers(mock, testutil.NewLogger(t), WithMaxListItems(2))
+
+	body := []byte(`{"full_name": "Test Tutor", "subjects": [" Math", "math", "", "physics", "chemistry"], "formats": ["online", "Online "]}`)
+	req := httptest.NewRequest("PUT", "/tutors/123", bytes.NewReader(body))
+	req.SetPathValue("id", "123")
+	rec := httptest.NewRecorder()
+
+	handlers.UpsertTutor(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if want := []string{"math", "physics"}; !slices.Equal(mock.upsertedTutor.Subjects, want) {
+		t.Errorf("expected subjects %q, got %q", want, mock.upsertedTutor.Subjects)
+	}
+	if want := []string{"Mathematics", "Physics"}; !slices.Equal(mock.upsertedTutor.SubjectsDisplay, want) {
+		t.Errorf("expected subject labels %q, got %q", want, mock.upsertedTutor.SubjectsDisplay)
+	}
+	if want := []string{"online"}; !slices.Equal(mock.upsertedTutor.Formats, want) {
+		t.Errorf("expected formats %q, got %q", want, mock.upsertedTutor.Formats)
+	}
+}
+
+func TestUpsertTutor_ScrubsText(t *testing.T) {
+	scrubber, err := domain.NewPatternScrubber("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mock := &mockSearchClient{}
+	handlers := NewHandlers(mock, testutil.NewLogger(t), WithScrubber(scrubber))
+
+	body, _ := json.Marshal(domain.Tutor{FullName: "Test Tutor", Headline: "Physics tutor", Bio: "WhatsApp 0044 7700 900123"})
+	req := httptest.NewRequest("PUT", "/tutors/123", bytes.NewReader(body))
+	req.SetPathValue("id", "123")
+	rec := httptest.NewRecorder()
+
+	handlers.UpsertTutor(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if want := "WhatsApp [removed]"; mock.upsertedTutor.Bio != want {
+		t.Errorf("expected bio %q, got %q", want, mock.upsertedTutor.Bio)
+	}
+	if want := "Physics tutor"; mock.upsertedTutor.Headline != want {
+		t.Errorf("expected headline %q, got %q", want, mock.upsertedTutor.Headline)
+	}
+}
+
+func TestUpsertTutor_NormalizesAvatar(t *testing.T) {
+	tests := []struct {
+		avatar string
+		want   string
+	}{
+		{"//img.example.com/a.jpg", "https://img.example.com/a.jpg"},
+		{"/media/a.jpg?fbclid=1", "https://cdn.example.com/media/a.jpg"},
+		{"data:image/png;base64,AAAA", ""},
+	}
+
+	for _, tt := range tests {
+		mock := &mockSearchClient{}
+		handlers := NewHandlers(mock, testutil.NewLogger(t), WithAvatarPolicy(domain.AvatarPolicy{
+			CDNBase:     "https://cdn.example.com",
+			StripParams: domain.DefaultAvatarStripParams,
+		}))
+
+		body, _ := json.Marshal(domain.Tutor{FullName: "Test Tutor", AvatarURL: tt.avatar})
+		req := httptest.NewRequest("PUT", "/tutors/123", bytes.NewReader(body))
+		req.SetPathValue("id", "123")
+		rec := httptest.NewRecorder()
+
+		handlers.UpsertTutor(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected status %d, got %d", tt.avatar, http.StatusOK, rec.Code)
+		}
+		if mock.upsertedTutor.AvatarURL != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.avatar, tt.want, mock.upsertedTutor.AvatarURL)
+		}
+	}
+}
+
+func TestSyncTutors_SanitizesLists(t *testing.T) {
+	client := opensearch.NewMemoryClient()
+	handlers := NewHandlers(client, testutil.NewLogger(t))
+
+	body := []byte(`[{"id": 1, "full_name": "A", "subjects": ["math", "MATH", " math "]}, {"id": 2, "full_name": "B", "subjects": [" ", "art"]}]`)
+	rec := httptest.NewRecorder()
+	handlers.SyncTutors(rec, httptest.NewRequest("POST", "/admin/sync", bytes.NewReader(body)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	for id, want := range map[int64][]string{1: {"math"}, 2: {"art"}} {
+		tutor, err := client.GetTutor(context.Background(), id)
+		if err != nil {
+			t.Fatalf("tutor %d not synced: %v", id, err)
+		}
+		if !slices.Equal(tutor.Subjects, want) {
+			t.Errorf("tutor %d: expected subjects %q, got %q", id, want, tutor.Subjects)
+		}
+	}
+}
+
+func TestUpsertTutor_RatingConsistency(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       string
+		body       string
+		wantStatus int
+		wantRating float64
+	}{
+		{"rounds to two decimals", domain.RatingModeStrict, `{"rating": 4.876, "reviews_count": 3}`, http.StatusOK, 4.88},
+		{"lenient zeroes rating without reviews", domain.RatingModeLenient, `{"rating": 4.8, "reviews_count": 0}`, http.StatusOK, 0},
+		{"default is lenient", "", `{"rating": 4.8}`, http.StatusOK, 0},
+		{"strict rejects rating without reviews", domain.RatingModeStrict, `{"rating": 4.8, "reviews_count": 0}`, http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{}
+			handlers := NewHandlers(mock, testutil.NewLogger(t), WithRatingMode(tt.mode))
+
+			req := httptest.NewRequest("PUT", "/tutors/123", strings.NewReader(tt.body))
+			req.SetPathValue("id", "123")
+			rec := httptest.NewRecorder()
+			handlers.UpsertTutor(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				if mock.upsertedTutor != nil {
+					t.Error("expected inconsistent tutor not to be indexed")
+				}
+				if !strings.Contains(rec.Body.String(), domain.CodeInconsistent) {
+					t.Errorf("expected an inconsistent rating violation, got %s", rec.Body.String())
+				}
+				return
+			}
+			if mock.upsertedTutor.Rating != tt.wantRating {
+				t.Errorf("expected rating %g, got %g", tt.wantRating, mock.upsertedTutor.Rating)
+			}
+		})
+	}
+}
+
+func TestSyncTutors_RatingConsistency(t *testing.T) {
+	body := `[{"id": 1, "full_name": "A", "rating": 4.8}, {"id": 2, "full_name": "B", "rating": 4.5, "reviews_count": 7}]`
+
+	for _, tt := range []struct {
+		mode       string
+		wantSynced int
+	}{
+		{domain.RatingModeLenient, 2},
+		{domain.RatingModeStrict, 1},
+	} {
+		t.Run(tt.mode, func(t *testing.T) {
+			client := opensearch.NewMemoryClient()
+			handlers := NewHandlers(client, testutil.NewLogger(t), WithRatingMode(tt.mode))
+
+			rec := httptest.NewRecorder()
+			handlers.SyncTutors(rec, httptest.NewRequest("POST", "/admin/sync", strings.NewReader(body)))
+
+			var resp map[string]int
+			json.Unmarshal(rec.Body.Bytes(), &resp)
+			if resp["synced"] != tt.wantSynced || resp["total"] != 2 {
+				t.Errorf("expected %d of 2 synced, got %v", tt.wantSynced, resp)
+			}
+			tutor, err := client.GetTutor(context.Background(), 1)
+			switch {
+			case tt.mode == domain.RatingModeStrict && err == nil:
+				t.Error("expected inconsistent tutor to be skipped")
+			case tt.mode == domain.RatingModeLenient && (err != nil || tutor.Rating != 0):
+				t.Errorf("expected tutor 1 synced with rating 0, got %v, %v", tutor, err)
+			}
+		})
+	}
 }
 
 func TestUpsertTutor_InvalidID(t *testing.T) {
 	mock := &mockSearchClient{}
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	logger := testutil.NewLogger(t)
 	handlers := NewHandlers(mock, logger)
 
 	req := httptest.NewRequest("PUT", "/tutors/invalid", nil)
@@ -137,7 +844,7 @@ func TestUpsertTutor_InvalidID(t *testing.T) {
 
 func TestUpsertTutor_InvalidBody(t *testing.T) {
 	mock := &mockSearchClient{}
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	logger := testutil.NewLogger(t)
 	handlers := NewHandlers(mock, logger)
 
 	req := httptest.NewRequest("PUT", "/tutors/123", bytes.NewReader([]byte("invalid json")))
@@ -153,7 +860,7 @@ func TestUpsertTutor_InvalidBody(t *testing.T) {
 
 func TestDeleteTutor_Success(t *testing.T) {
 	mock := &mockSearchClient{}
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	logger := testutil.NewLogger(t)
 	handlers := NewHandlers(mock, logger)
 
 	req := httptest.NewRequest("DELETE", "/tutors/456", nil)
@@ -170,9 +877,54 @@ func TestDeleteTutor_Success(t *testing.T) {
 	}
 }
 
+func TestDeleteTutor_NotFound(t *testing.T) {
+	logger := testutil.NewLogger(t)
+
+	tests := []struct {
+		name       string
+		target     string
+		wantStatus int
+	}{
+		{"strict", "/tutors/456", http.StatusNotFound},
+		{"idempotent", "/tutors/456?idempotent=true", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := NewHandlers(&mockSearchClient{deleteErr: port.ErrNotFound}, logger)
+
+			req := httptest.NewRequest("DELETE", tt.target, nil)
+			req.SetPathValue("id", "456")
+			rec := httptest.NewRecorder()
+
+			handlers.DeleteTutor(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestDeleteTutor_Error(t *testing.T) {
+	mock := &mockSearchClient{deleteErr: errors.New("connection refused")}
+	logger := testutil.NewLogger(t)
+	handlers := NewHandlers(mock, logger)
+
+	req := httptest.NewRequest("DELETE", "/tutors/456?idempotent=true", nil)
+	req.SetPathValue("id", "456")
+	rec := httptest.NewRecorder()
+
+	handlers.DeleteTutor(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+}
+
 func TestDeleteTutor_InvalidID(t *testing.T) {
 	mock := &mockSearchClient{}
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	logger := testutil.NewLogger(t)
 	handlers := NewHandlers(mock, logger)
 
 	req := httptest.NewRequest("DELETE", "/tutors/invalid", nil)
@@ -188,7 +940,7 @@ func TestDeleteTutor_InvalidID(t *testing.T) {
 
 func TestSearchTutors_Success(t *testing.T) {
 	mock := &mockSearchClient{
-		searchResult: &opensearch.SearchResponse{
+		searchResult: &port.SearchResponse{
 			Results: []domain.Tutor{
 				{ID: 1, FullName: "Tutor 1"},
 				{ID: 2, FullName: "Tutor 2"},
@@ -196,7 +948,7 @@ func TestSearchTutors_Success(t *testing.T) {
 			Total: 2,
 		},
 	}
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	logger := testutil.NewLogger(t)
 	handlers := NewHandlers(mock, logger)
 
 	req := httptest.NewRequest("GET", "/tutors/search?q=test", nil)
@@ -208,7 +960,7 @@ func TestSearchTutors_Success(t *testing.T) {
 		t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
 	}
 
-	var response opensearch.SearchResponse
+	var response port.SearchResponse
 	json.Unmarshal(rec.Body.Bytes(), &response)
 
 	if len(response.Results) != 2 {
@@ -221,7 +973,7 @@ func TestSearchTutors_Success(t *testing.T) {
 
 func TestSearchTutors_Error(t *testing.T) {
 	mock := &mockSearchClient{searchErr: errors.New("search error")}
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	logger := testutil.NewLogger(t)
 	handlers := NewHandlers(mock, logger)
 
 	req := httptest.NewRequest("GET", "/tutors/search", nil)
@@ -234,9 +986,71 @@ func TestSearchTutors_Error(t *testing.T) {
 	}
 }
 
+func TestSearchTutors_PartialResults(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+	}{
+		{"served by default", "/tutors/search", http.StatusOK},
+		{"served when allowed", "/tutors/search?allow_partial=true", http.StatusOK},
+		{"rejected in strict mode", "/tutors/search?allow_partial=false", http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{
+				searchResult: &port.SearchResponse{
+					Results:      []domain.Tutor{{ID: 1}},
+					Total:        1,
+					ShardsTotal:  3,
+					ShardsFailed: 1,
+					Partial:      true,
+				},
+			}
+			handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+			rec := httptest.NewRecorder()
+			handlers.SearchTutors(rec, httptest.NewRequest("GET", tt.url, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				if !strings.Contains(rec.Body.String(), "1 of 3 shards failed") {
+					t.Errorf("expected the failed shard count, got %s", rec.Body.String())
+				}
+				return
+			}
+			var resp map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp["partial"] != true || resp["shards_failed"] != float64(1) {
+				t.Errorf("expected partial with 1 failed shard, got %v", resp)
+			}
+		})
+	}
+}
+
+func TestSearchTutors_StrictModeServesCompleteResults(t *testing.T) {
+	mock := &mockSearchClient{searchResult: &port.SearchResponse{Results: []domain.Tutor{{ID: 1}}, Total: 1, ShardsTotal: 3}}
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+	rec := httptest.NewRecorder()
+	handlers.SearchTutors(rec, httptest.NewRequest("GET", "/tutors/search?allow_partial=false", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), "partial") {
+		t.Errorf("expected no partial flag, got %s", rec.Body.String())
+	}
+}
+
 func TestSyncTutors_Success(t *testing.T) {
 	mock := &mockSearchClient{}
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	logger := testutil.NewLogger(t)
 	handlers := NewHandlers(mock, logger)
 
 	tutors := []domain.Tutor{
@@ -260,11 +1074,135 @@ func TestSyncTutors_Success(t *testing.T) {
 	if response["synced"] != 2 {
 		t.Errorf("expected synced 2, got %d", response["synced"])
 	}
+	if !strings.Contains(rec.Body.String(), `"errors":[]`) {
+		t.Errorf("expected an empty errors list, got %s", rec.Body.String())
+	}
+	if mock.upsertedTutor.IndexedAt == nil {
+		t.Error("expected synced tutors to be stamped with indexed_at")
+	}
+}
+
+func TestSyncTutors_PartialFailure(t *testing.T) {
+	mock := &mockSearchClient{upsertErrs: map[int64]error{
+		3: errors.New("cluster unavailable"),
+		4: fmt.Errorf("%w: mapper_parsing_exception", port.ErrDocumentRejected),
+	}}
+	handlers := NewHandlers(mock, testutil.NewLogger(t), WithRatingMode(domain.RatingModeStrict))
+
+	body := `[{"id": 1, "full_name": "A"}, {"id": 2, "full_name": "B", "rating": 4.8}, {"id": 3, "full_name": "C"}, {"id": 4, "full_name": "D"}, {"id": 5, "full_name": "E"}]`
+	rec := httptest.NewRecorder()
+	handlers.SyncTutors(rec, httptest.NewRequest("POST", "/admin/sync", strings.NewReader(body)))
+
+	if rec.Code != http.StatusMultiStatus {
+		t.Fatalf("expected status %d, got %d", http.StatusMultiStatus, rec.Code)
+	}
+	var resp syncResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if resp.Synced != 2 || resp.Total != 5 || resp.Failed != 3 || resp.Truncated {
+		t.Errorf("unexpected counts %+v", resp)
+	}
+	want := []struct {
+		index    int
+		id       int64
+		category string
+	}{
+		{1, 2, syncErrValidation},
+		{2, 3, syncErrIndex},
+		{3, 4, syncErrIndex},
+	}
+	if len(resp.Errors) != len(want) {
+		t.Fatalf("expected %d errors, got %+v", len(want), resp.Errors)
+	}
+	for i, w := range want {
+		got := resp.Errors[i]
+		if got.Index != w.index || got.ID != w.id || got.Category != w.category || got.Error == "" {
+			t.Errorf("error %d: expected index %d, id %d, category %s, got %+v", i, w.index, w.id, w.category, got)
+		}
+	}
+}
+
+func TestSyncTutors_RejectsInvalidTutor(t *testing.T) {
+	client := opensearch.NewMemoryClient()
+	handlers := NewHandlers(client, testutil.NewLogger(t))
+
+	body := `[{"id": 1, "full_name": "A"}, {"id": 2, "full_name": "B", "rating": 7, "reviews_count": 3}, {"id": 3, "full_name": "C"}]`
+	rec := httptest.NewRecorder()
+	handlers.SyncTutors(rec, httptest.NewRequest("POST", "/admin/sync", strings.NewReader(body)))
+
+	if rec.Code != http.StatusMultiStatus {
+		t.Fatalf("expected status %d, got %d", http.StatusMultiStatus, rec.Code)
+	}
+	var resp syncResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if resp.Synced != 2 || resp.Failed != 1 {
+		t.Errorf("expected 2 synced and 1 failed, got %+v", resp)
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].Index != 1 || resp.Errors[0].ID != 2 || resp.Errors[0].Category != syncErrValidation {
+		t.Errorf("expected tutor 2 reported as a validation error, got %+v", resp.Errors)
+	}
+
+	indexed, err := client.GetTutors(context.Background(), []int64{1, 2, 3})
+	if err != nil {
+		t.Fatalf("failed to read index: %v", err)
+	}
+	if _, ok := indexed[2]; ok || len(indexed) != 2 {
+		t.Errorf("expected tutors 1 and 3 indexed without 2, got %v", slices.Sorted(maps.Keys(indexed)))
+	}
+}
+
+func TestSyncTutors_CapsErrors(t *testing.T) {
+	handlers := NewHandlers(&mockSearchClient{}, testutil.NewLogger(t), WithRatingMode(domain.RatingModeStrict))
+
+	tutors := make([]domain.Tutor, maxSyncErrors+10)
+	for i := range tutors {
+		tutors[i] = domain.Tutor{ID: int64(i + 1), FullName: "T", Rating: 4}
+	}
+	body, _ := json.Marshal(tutors)
+	rec := httptest.NewRecorder()
+	handlers.SyncTutors(rec, httptest.NewRequest("POST", "/admin/sync", bytes.NewReader(body)))
+
+	var resp syncResponse
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	if rec.Code != http.StatusMultiStatus || resp.Failed != len(tutors) || len(resp.Errors) != maxSyncErrors || !resp.Truncated {
+		t.Errorf("expected %d failures with %d reported and truncated, got %d: %d, %d, %t",
+			len(tutors), maxSyncErrors, rec.Code, resp.Failed, len(resp.Errors), resp.Truncated)
+	}
+}
+
+func TestSyncTutors_StopsWhenOverloaded(t *testing.T) {
+	mock := &mockSearchClient{upsertErrs: map[int64]error{2: port.ErrOverloaded}}
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+	body := `[{"id": 1, "full_name": "A"}, {"id": 2, "full_name": "B"}, {"id": 3, "full_name": "C"}, {"id": 4, "full_name": "D"}]`
+	rec := httptest.NewRecorder()
+	handlers.SyncTutors(rec, httptest.NewRequest("POST", "/admin/sync", strings.NewReader(body)))
+
+	if rec.Code != http.StatusMultiStatus {
+		t.Fatalf("expected status %d, got %d", http.StatusMultiStatus, rec.Code)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("expected a Retry-After header")
+	}
+	var resp syncResponse
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp.Synced != 1 || resp.Failed != 1 || resp.NotAttempted != 2 {
+		t.Errorf("expected 1 synced, 1 failed and 2 not attempted, got %+v", resp)
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].Index != 1 || resp.Errors[0].Category != syncErrOverloaded {
+		t.Errorf("expected the overloaded tutor reported, got %+v", resp.Errors)
+	}
+	if mock.upsertedTutor.ID != 1 {
+		t.Errorf("expected no write after the backend pushed back, last wrote %d", mock.upsertedTutor.ID)
+	}
 }
 
 func TestSyncTutors_InvalidBody(t *testing.T) {
 	mock := &mockSearchClient{}
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	logger := testutil.NewLogger(t)
 	handlers := NewHandlers(mock, logger)
 
 	req := httptest.NewRequest("POST", "/admin/sync", bytes.NewReader([]byte("invalid")))
@@ -279,7 +1217,7 @@ func TestSyncTutors_InvalidBody(t *testing.T) {
 
 func TestReindex(t *testing.T) {
 	mock := &mockSearchClient{}
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	logger := testutil.NewLogger(t)
 	handlers := NewHandlers(mock, logger)
 
 	req := httptest.NewRequest("POST", "/admin/reindex", nil)
@@ -296,13 +1234,13 @@ func TestParseSearchQuery(t *testing.T) {
 	tests := []struct {
 		name     string
 		url      string
-		checkFn  func(q opensearch.SearchQuery) bool
+		checkFn  func(q port.SearchQuery) bool
 		checkMsg string
 	}{
 		{
 			name: "text only",
 			url:  "/search?q=математика",
-			checkFn: func(q opensearch.SearchQuery) bool {
+			checkFn: func(q port.SearchQuery) bool {
 				return q.Text == "математика"
 			},
 			checkMsg: "text should be 'математика'",
@@ -310,7 +1248,7 @@ func TestParseSearchQuery(t *testing.T) {
 		{
 			name: "subjects",
 			url:  "/search?subjects=math&subjects=physics",
-			checkFn: func(q opensearch.SearchQuery) bool {
+			checkFn: func(q port.SearchQuery) bool {
 				return len(q.Subjects) == 2 && q.Subjects[0] == "math"
 			},
 			checkMsg: "should have 2 subjects",
@@ -318,7 +1256,7 @@ func TestParseSearchQuery(t *testing.T) {
 		{
 			name: "price range",
 			url:  "/search?min_price=500&max_price=2000",
-			checkFn: func(q opensearch.SearchQuery) bool {
+			checkFn: func(q port.SearchQuery) bool {
 				return q.MinPrice != nil && *q.MinPrice == 500 &&
 					q.MaxPrice != nil && *q.MaxPrice == 2000
 			},
@@ -327,19 +1265,91 @@ func TestParseSearchQuery(t *testing.T) {
 		{
 			name: "format",
 			url:  "/search?format=online",
-			checkFn: func(q opensearch.SearchQuery) bool {
+			checkFn: func(q port.SearchQuery) bool {
 				return q.Format == "online"
 			},
 			checkMsg: "format should be 'online'",
 		},
+		{
+			name: "certification",
+			url:  "/search?certification=CELTA",
+			checkFn: func(q port.SearchQuery) bool {
+				return q.Certification == "CELTA"
+			},
+			checkMsg: "certification should be 'CELTA'",
+		},
+		{
+			name: "trial",
+			url:  "/search?trial=true",
+			checkFn: func(q port.SearchQuery) bool {
+				return q.Trial
+			},
+			checkMsg: "trial=true should keep tutors offering a trial",
+		},
+		{
+			name: "trial not a boolean",
+			url:  "/search?trial=yes",
+			checkFn: func(q port.SearchQuery) bool {
+				return !q.Trial
+			},
+			checkMsg: "an unparseable trial should be ignored",
+		},
+		{
+			name: "levels",
+			url:  "/search?level=school&level=Adult",
+			checkFn: func(q port.SearchQuery) bool {
+				return slices.Equal(q.Levels, []string{"school", "adult"})
+			},
+			checkMsg: "levels should be [school adult], lowercased",
+		},
+		{
+			name: "full bio requested",
+			url:  "/search?fields=name,bio",
+			checkFn: func(q port.SearchQuery) bool {
+				return q.IncludeBio
+			},
+			checkMsg: "fields=bio should include the bio",
+		},
+		{
+			name: "snippet only by default",
+			url:  "/search?q=math",
+			checkFn: func(q port.SearchQuery) bool {
+				return !q.IncludeBio
+			},
+			checkMsg: "the bio should be left out by default",
+		},
 		{
 			name: "pagination",
 			url:  "/search?limit=50&offset=100",
-			checkFn: func(q opensearch.SearchQuery) bool {
+			checkFn: func(q port.SearchQuery) bool {
 				return q.Limit == 50 && q.Offset == 100
 			},
 			checkMsg: "pagination should be limit=50, offset=100",
 		},
+		{
+			name: "exclude ids",
+			url:  "/search?exclude_ids=3,1&exclude_ids=7&exclude_ids=abc",
+			checkFn: func(q port.SearchQuery) bool {
+				return len(q.ExcludeIDs) == 3 && q.ExcludeIDs[0] == 3 && q.ExcludeIDs[2] == 7
+			},
+			checkMsg: "should parse comma-separated and repeated exclude_ids, skipping invalid ones",
+		},
+		{
+			name: "available within days",
+			url:  "/search?available_within_days=7",
+			checkFn: func(q port.SearchQuery) bool {
+				return q.AvailableWithinDays == 7
+			},
+			checkMsg: "available_within_days should be 7",
+		},
+		{
+			name: "non-positive available within days",
+			url:  "/search?available_within_days=-1",
+			checkFn: func(q port.SearchQuery) bool {
+				return q.AvailableWithinDays == 0
+			},
+			checkMsg: "negative available_within_days should be ignored",
+		},
 	}
 
 	for _, tt := range tests {
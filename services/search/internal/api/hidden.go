@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"search/internal/auth"
+	"search/internal/port"
+	"search/internal/store"
+)
+
+// HideTutor excludes a tutor from the authenticated user's search results.
+func (h *Handlers) HideTutor(w http.ResponseWriter, r *http.Request) {
+	userID, _ := auth.UserIDFrom(r.Context())
+
+	tutorID, ok := h.tutorID(w, r, "tutor_id")
+	if !ok {
+		return
+	}
+
+	err := h.store.HideTutor(r.Context(), userID, tutorID)
+	if errors.Is(err, store.ErrLimitReached) {
+		respondError(w, http.StatusConflict, fmt.Sprintf("At most %d tutors can be hidden", store.MaxHiddenTutors))
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to hide tutor", "user_id", userID, "tutor_id", tutorID, "error", err)
+		respondError(w, http.StatusInternalServerError, "Failed to hide tutor")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]any{
+		"status":   "hidden",
+		"tutor_id": tutorID,
+	})
+}
+
+// UnhideTutor restores a hidden tutor to the authenticated user's results.
+func (h *Handlers) UnhideTutor(w http.ResponseWriter, r *http.Request) {
+	userID, _ := auth.UserIDFrom(r.Context())
+
+	tutorID, ok := h.tutorID(w, r, "tutor_id")
+	if !ok {
+		return
+	}
+
+	err := h.store.UnhideTutor(r.Context(), userID, tutorID)
+	if errors.Is(err, store.ErrNotFound) {
+		respondError(w, http.StatusNotFound, "Tutor is not hidden")
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to unhide tutor", "user_id", userID, "tutor_id", tutorID, "error", err)
+		respondError(w, http.StatusInternalServerError, "Failed to unhide tutor")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]any{
+		"status":   "unhidden",
+		"tutor_id": tutorID,
+	})
+}
+
+// ListHiddenTutors returns the IDs the authenticated user has hidden.
+func (h *Handlers) ListHiddenTutors(w http.ResponseWriter, r *http.Request) {
+	userID, _ := auth.UserIDFrom(r.Context())
+
+	ids, err := h.store.HiddenTutorIDs(r.Context(), userID)
+	if err != nil {
+		h.logger.Error("Failed to list hidden tutors", "user_id", userID, "error", err)
+		respondError(w, http.StatusInternalServerError, "Failed to list hidden tutors")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]any{"tutor_ids": ids})
+}
+
+// search runs query, first excluding the tutors the authenticated user has
+// hidden, and with a positive facetSize counts that many subjects' facets
+// in the same round-trip. Anonymous requests are searched unchanged.
+func (h *Handlers) search(ctx context.Context, query port.SearchQuery, facetSize int) (*port.SearchResponse, error) {
+	query = h.excludeHidden(ctx, query)
+	if facetSize > 0 {
+		return h.os.SearchTutorsWithFacets(ctx, query, facetSize)
+	}
+	return h.os.SearchTutors(ctx, query)
+}
+
+// excludeHidden adds the authenticated user's hidden tutors to the query's
+// exclusions.
+func (h *Handlers) excludeHidden(ctx context.Context, query port.SearchQuery) port.SearchQuery {
+	if userID, ok := auth.UserIDFrom(ctx); ok && h.store != nil {
+		hidden, err := h.store.HiddenTutorIDs(ctx, userID)
+		if err != nil {
+			// Hiding is a preference; searching without it beats failing.
+			h.logger.Warn("Failed to load hidden tutors", "user_id", userID, "error", err)
+		} else {
+			query.ExcludeIDs = mergeIDs(query.ExcludeIDs, hidden)
+		}
+	}
+	return query
+}
+
+// mergeIDs returns the sorted union of a and b without duplicates.
+func mergeIDs(a, b []int64) []int64 {
+	if len(b) == 0 {
+		return a
+	}
+	merged := slices.Concat(a, b)
+	slices.Sort(merged)
+	return slices.Compact(merged)
+}
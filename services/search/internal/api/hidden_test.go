@@ -0,0 +1,141 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"search/internal/port"
+	"search/internal/store"
+	"search/internal/testutil"
+)
+
+func hideRequest(method, tutorID, userID string) *http.Request {
+	req := asUser(httptest.NewRequest(method, "/me/hidden/"+tutorID, nil), userID)
+	req.SetPathValue("tutor_id", tutorID)
+	return req
+}
+
+func TestHideTutor(t *testing.T) {
+	h := newSavedSearchHandlers(t, &mockSearchClient{})
+
+	for _, id := range []string{"7", "3", "7"} {
+		rec := httptest.NewRecorder()
+		h.HideTutor(rec, hideRequest("POST", id, "1"))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status %d hiding %s, got %d", http.StatusOK, id, rec.Code)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	h.ListHiddenTutors(rec, asUser(httptest.NewRequest("GET", "/me/hidden", nil), "1"))
+
+	var response struct {
+		TutorIDs []int64 `json:"tutor_ids"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	if !slices.Equal(response.TutorIDs, []int64{3, 7}) {
+		t.Errorf("expected hidden tutors [3 7], got %v", response.TutorIDs)
+	}
+}
+
+func TestHideTutor_InvalidID(t *testing.T) {
+	h := newSavedSearchHandlers(t, &mockSearchClient{})
+
+	for _, id := range []string{"abc", "0", "-4"} {
+		rec := httptest.NewRecorder()
+		h.HideTutor(rec, hideRequest("POST", id, "1"))
+		if rec.Code != http.StatusBadRequest {
+			t.Errorf("expected status %d for %q, got %d", http.StatusBadRequest, id, rec.Code)
+		}
+	}
+}
+
+func TestHideTutor_Limit(t *testing.T) {
+	s := store.NewMemory()
+	for id := range int64(store.MaxHiddenTutors) {
+		s.HideTutor(context.Background(), "1", id+1)
+	}
+	h := NewHandlers(&mockSearchClient{}, testutil.NewLogger(t), WithStore(s))
+
+	rec := httptest.NewRecorder()
+	h.HideTutor(rec, hideRequest("POST", "100000", "1"))
+
+	if rec.Code != http.StatusConflict {
+		t.Errorf("expected status %d, got %d", http.StatusConflict, rec.Code)
+	}
+}
+
+func TestUnhideTutor(t *testing.T) {
+	h := newSavedSearchHandlers(t, &mockSearchClient{})
+	h.HideTutor(httptest.NewRecorder(), hideRequest("POST", "7", "1"))
+
+	rec := httptest.NewRecorder()
+	h.UnhideTutor(rec, hideRequest("DELETE", "7", "1"))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	h.UnhideTutor(rec, hideRequest("DELETE", "7", "1"))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d for a tutor that is not hidden, got %d", http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestSearchTutors_MergesHiddenTutors(t *testing.T) {
+	mock := &mockSearchClient{searchResult: &port.SearchResponse{}}
+	h := newSavedSearchHandlers(t, mock)
+	h.HideTutor(httptest.NewRecorder(), hideRequest("POST", "9", "1"))
+	h.HideTutor(httptest.NewRecorder(), hideRequest("POST", "4", "1"))
+
+	tests := []struct {
+		name   string
+		userID string
+		url    string
+		want   []int64
+	}{
+		{"authenticated", "1", "/tutors/search", []int64{4, 9}},
+		{"authenticated with explicit excludes", "1", "/tutors/search?exclude_ids=2,9", []int64{2, 4, 9}},
+		{"other user", "2", "/tutors/search", nil},
+		{"anonymous", "", "/tutors/search", nil},
+		{"anonymous with explicit excludes", "", "/tutors/search?exclude_ids=9", []int64{9}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.url, nil)
+			if tt.userID != "" {
+				req = asUser(req, tt.userID)
+			}
+			rec := httptest.NewRecorder()
+
+			h.SearchTutors(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+			}
+			if !slices.Equal(mock.searchedQuery.ExcludeIDs, tt.want) {
+				t.Errorf("expected exclude IDs %v, got %v", tt.want, mock.searchedQuery.ExcludeIDs)
+			}
+		})
+	}
+}
+
+func TestRunSavedSearch_ExcludesHiddenTutors(t *testing.T) {
+	mock := &mockSearchClient{searchResult: &port.SearchResponse{}}
+	h := newSavedSearchHandlers(t, mock)
+	view := createSavedSearch(t, h, "1", `{"name": "math", "params": "subjects=math"}`)
+	h.HideTutor(httptest.NewRecorder(), hideRequest("POST", "5", "1"))
+
+	req := asUser(httptest.NewRequest("GET", "/me/searches/"+view.ID+"/run", nil), "1")
+	req.SetPathValue("id", view.ID)
+	h.RunSavedSearch(httptest.NewRecorder(), req)
+
+	if !slices.Equal(mock.searchedQuery.ExcludeIDs, []int64{5}) {
+		t.Errorf("expected hidden tutor 5 to be excluded, got %v", mock.searchedQuery.ExcludeIDs)
+	}
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"search/internal/domain"
+)
+
+// availableBetween converts the available_between window, on the clock of
+// student_tz (UTC when absent), to ranges of UTC minutes using the zone's
+// offset at now. It returns nil when available_between is absent, and a 400
+// body naming the offending param when it is not a HH:MM-HH:MM window or
+// student_tz is not an IANA time zone.
+func availableBetween(q url.Values, now time.Time) ([]domain.MinuteRange, *QueryError) {
+	window := q.Get("available_between")
+	if window == "" {
+		return nil, nil
+	}
+	start, end, err := domain.ParseWindow(window)
+	if err != nil {
+		return nil, &QueryError{Error: "available_between " + err.Error(), Param: "available_between"}
+	}
+	loc := time.UTC
+	if tz := q.Get("student_tz"); tz != "" {
+		if loc, err = domain.LoadTimezone(tz); err != nil {
+			return nil, &QueryError{Error: "student_tz " + err.Error(), Param: "student_tz"}
+		}
+	}
+	return domain.WindowUTC(start, end, loc, now), nil
+}
+
+// formatWindowUTC is the inverse of availableBetween without student_tz:
+// the available_between window, on the UTC clock, that gives ranges back.
+// A window past UTC midnight is the two ranges ShiftToUTC splits it into.
+// It returns "" for no ranges or ones no single window produces.
+func formatWindowUTC(ranges []domain.MinuteRange) string {
+	clock := func(m int) string {
+		return fmt.Sprintf("%02d:%02d", m/60, m%60)
+	}
+	switch {
+	case len(ranges) == 1:
+		return clock(ranges[0].Start) + "-" + clock(ranges[0].End)
+	case len(ranges) == 2 && ranges[0].Start == 0 && ranges[1].End == domain.MinutesPerDay:
+		return clock(ranges[1].Start) + "-" + clock(ranges[0].End)
+	}
+	return ""
+}
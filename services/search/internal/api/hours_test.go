@@ -0,0 +1,106 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"search/internal/domain"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func TestSearchTutors_AvailableBetween(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+		wantRanges []domain.MinuteRange
+		wantParam  string
+	}{
+		{"absent", "/tutors/search", http.StatusOK, nil, ""},
+		{"utc by default", "/tutors/search?available_between=18:00-21:00", http.StatusOK, []domain.MinuteRange{{Start: 1080, End: 1260}}, ""},
+		{"student zone", "/tutors/search?available_between=18:00-21:00&student_tz=Asia/Tokyo", http.StatusOK, []domain.MinuteRange{{Start: 540, End: 720}}, ""},
+		{"wraps utc midnight", "/tutors/search?available_between=07:00-10:00&student_tz=Asia/Tokyo", http.StatusOK, []domain.MinuteRange{{Start: 0, End: 60}, {Start: 1320, End: 1440}}, ""},
+		{"zone without window is ignored", "/tutors/search?student_tz=Asia/Tokyo", http.StatusOK, nil, ""},
+		{"malformed window", "/tutors/search?available_between=evening", http.StatusBadRequest, nil, "available_between"},
+		{"empty window", "/tutors/search?available_between=18:00-18:00", http.StatusBadRequest, nil, "available_between"},
+		{"unknown zone", "/tutors/search?available_between=18:00-21:00&student_tz=Berlin", http.StatusBadRequest, nil, "student_tz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{searchResult: &port.SearchResponse{}}
+			router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest("GET", tt.url, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK {
+				if !reflect.DeepEqual(mock.searchedQuery.AvailableBetween, tt.wantRanges) {
+					t.Errorf("expected ranges %v, got %v", tt.wantRanges, mock.searchedQuery.AvailableBetween)
+				}
+				return
+			}
+
+			var resp QueryError
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Param != tt.wantParam || resp.Error == "" {
+				t.Errorf("unexpected error response %+v", resp)
+			}
+		})
+	}
+}
+
+func TestUpsertTutor_InvalidTimezone(t *testing.T) {
+	mock := &mockSearchClient{}
+	router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+	body := `{"id": 1, "full_name": "Ada", "timezone": "Europe/Atlantis", "working_hours": [{"start": "09:00", "end": "17:00"}]}`
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("PUT", "/tutors/1", strings.NewReader(body)))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusBadRequest, rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `"field":"timezone"`) {
+		t.Errorf("expected a timezone violation, got %s", rec.Body.String())
+	}
+	if mock.upsertedTutor != nil {
+		t.Error("expected tutor not to be indexed")
+	}
+}
+
+func TestFormatWindowUTC(t *testing.T) {
+	tests := []struct {
+		window string
+		want   string
+	}{
+		{"09:00-17:30", "09:00-17:30"},
+		{"18:00-24:00", "18:00-24:00"},
+		{"00:00-24:00", "00:00-24:00"},
+		{"22:00-01:00", "22:00-01:00"},
+	}
+	for _, tt := range tests {
+		ranges, qerr := availableBetween(url.Values{"available_between": {tt.window}}, time.Now())
+		if qerr != nil {
+			t.Fatalf("%s: unexpected error %+v", tt.window, qerr)
+		}
+		if got := formatWindowUTC(ranges); got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.window, tt.want, got)
+		}
+	}
+	if got := formatWindowUTC([]domain.MinuteRange{{Start: 60, End: 120}, {Start: 600, End: 660}}); got != "" {
+		t.Errorf("expected ranges no window produces to encode to nothing, got %q", got)
+	}
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"search/internal/port"
+)
+
+type indexAliasesRequest struct {
+	Read  string `json:"read"`
+	Write string `json:"write"`
+}
+
+// IndexAliases reports the indices the tenant's read and write aliases
+// point at.
+func (h *Handlers) IndexAliases(w http.ResponseWriter, r *http.Request) {
+	aliases, err := h.os.IndexAliases(r.Context())
+	switch {
+	case errors.Is(err, port.ErrUnsupported):
+		respondError(w, http.StatusNotImplemented, "Index aliases are not supported by this search backend")
+		return
+	case err != nil:
+		h.logger.Error("Failed to get index aliases", "index", port.IndexFor(r.Context()), "error", err)
+		respondBackendError(w, err, "Failed to get index aliases")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, aliases)
+}
+
+// PointIndexAliases moves the tenant's read alias, write alias or both to
+// the indices in the body. During a long reindex the write alias is
+// pointed at the new index first and the read alias follows once it is
+// filled.
+func (h *Handlers) PointIndexAliases(w http.ResponseWriter, r *http.Request) {
+	var req indexAliasesRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Read == "" && req.Write == "" {
+		respondError(w, http.StatusBadRequest, "read or write is required")
+		return
+	}
+	for _, target := range []struct{ field, index string }{{"read", req.Read}, {"write", req.Write}} {
+		if target.index == "" {
+			continue
+		}
+		if err := port.ValidateAliasTarget(target.index); err != nil {
+			respondError(w, http.StatusBadRequest, target.field+": "+err.Error())
+			return
+		}
+	}
+
+	index := port.IndexFor(r.Context())
+	aliases, err := h.os.PointIndexAliases(r.Context(), req.Read, req.Write)
+	switch {
+	case errors.Is(err, port.ErrUnsupported):
+		respondError(w, http.StatusNotImplemented, "Index aliases are not supported by this search backend")
+		return
+	case errors.Is(err, port.ErrIndexNotFound):
+		respondError(w, http.StatusNotFound, "Index not found")
+		return
+	case err != nil:
+		h.logger.Error("Failed to point index aliases", "index", index, "error", err)
+		respondBackendError(w, err, "Failed to point index aliases")
+		return
+	}
+
+	h.logger.Warn("Index aliases pointed",
+		"index", index,
+		"read", aliases.Read.Index,
+		"write", aliases.Write.Index,
+	)
+
+	respondJSON(w, http.StatusOK, aliases)
+}
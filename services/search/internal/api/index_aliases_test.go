@@ -0,0 +1,149 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func aliasMock() *mockSearchClient {
+	return &mockSearchClient{aliases: port.IndexAliases{
+		Read:  port.AliasTarget{Alias: "tutors-read", Index: "tutors"},
+		Write: port.AliasTarget{Alias: "tutors-write", Index: "tutors"},
+	}}
+}
+
+func TestIndexAliases(t *testing.T) {
+	handlers := NewHandlers(aliasMock(), testutil.NewLogger(t))
+
+	req := httptest.NewRequest("GET", "/admin/index/aliases", nil)
+	rec := httptest.NewRecorder()
+	handlers.IndexAliases(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	want := `{"read":{"alias":"tutors-read","index":"tutors"},"write":{"alias":"tutors-write","index":"tutors"}}` + "\n"
+	if rec.Body.String() != want {
+		t.Errorf("expected %s, got %s", want, rec.Body.String())
+	}
+}
+
+func TestPointIndexAliases(t *testing.T) {
+	mock := aliasMock()
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+	req := httptest.NewRequest("PUT", "/admin/index/aliases", strings.NewReader(`{"write": "tutors-v2"}`))
+	rec := httptest.NewRecorder()
+	handlers.PointIndexAliases(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if mock.aliases.Read.Index != "tutors" || mock.aliases.Write.Index != "tutors-v2" {
+		t.Errorf("expected only the write alias moved, got %+v", mock.aliases)
+	}
+	var resp port.IndexAliases
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp != mock.aliases {
+		t.Errorf("expected the new targets %+v, got %+v", mock.aliases, resp)
+	}
+}
+
+func TestPointIndexAliases_InvalidRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"invalid body", `read=tutors-v2`, "Invalid request body"},
+		{"unknown field", `{"search": "tutors-v2"}`, "Invalid request body"},
+		{"neither alias", `{}`, "read or write is required"},
+		{"wildcard", `{"read": "tutors-*"}`, "read: "},
+		{"several indices", `{"write": "tutors,tutors-v2"}`, "write: "},
+		{"an alias", `{"read": "tutors-write"}`, "read: "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := aliasMock()
+			handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+			req := httptest.NewRequest("PUT", "/admin/index/aliases", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			handlers.PointIndexAliases(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %s", tt.wantErr, rec.Body.String())
+			}
+			if mock.aliases != aliasMock().aliases {
+				t.Errorf("expected the aliases not to move, got %+v", mock.aliases)
+			}
+		})
+	}
+}
+
+func TestIndexAliases_BackendErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		handle     func(*Handlers, http.ResponseWriter, *http.Request)
+		err        error
+		wantStatus int
+	}{
+		{"get unsupported", (*Handlers).IndexAliases, port.ErrUnsupported, http.StatusNotImplemented},
+		{"get failure", (*Handlers).IndexAliases, errors.New("cluster unavailable"), http.StatusInternalServerError},
+		{"point unsupported", (*Handlers).PointIndexAliases, port.ErrUnsupported, http.StatusNotImplemented},
+		{"point at a missing index", (*Handlers).PointIndexAliases, port.ErrIndexNotFound, http.StatusNotFound},
+		{"point failure", (*Handlers).PointIndexAliases, errors.New("cluster unavailable"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := NewHandlers(&mockSearchClient{aliasErr: tt.err}, testutil.NewLogger(t))
+
+			req := httptest.NewRequest("PUT", "/admin/index/aliases", strings.NewReader(`{"read": "tutors-v2"}`))
+			rec := httptest.NewRecorder()
+			tt.handle(handlers, rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestRouter_IndexAliasesRequireAdminKey(t *testing.T) {
+	mock := aliasMock()
+	cfg := testRouterConfig()
+	cfg.AdminAPIKey = testAdminKey
+	router := NewRouter(mock, testutil.NewLogger(t), cfg)
+
+	for _, method := range []string{"GET", "PUT"} {
+		req := httptest.NewRequest(method, "/admin/index/aliases", strings.NewReader(`{"read": "tutors-v2"}`))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s: expected status %d without the admin key, got %d", method, http.StatusUnauthorized, rec.Code)
+		}
+
+		req = httptest.NewRequest(method, "/admin/index/aliases", strings.NewReader(`{"read": "tutors-v2"}`))
+		req.Header.Set("Authorization", "Bearer "+testAdminKey)
+		rec = httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Errorf("%s: expected status %d, got %d: %s", method, http.StatusOK, rec.Code, rec.Body.String())
+		}
+	}
+	if mock.aliases.Read.Index != "tutors-v2" {
+		t.Errorf("expected the read alias moved, got %+v", mock.aliases)
+	}
+}
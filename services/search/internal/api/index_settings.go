@@ -0,0 +1,52 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"search/internal/port"
+)
+
+type indexSettingsRequest struct {
+	Replicas *int `json:"number_of_replicas"`
+}
+
+// UpdateIndexSettings changes the replica count of the tenant's live index.
+// The shard count is fixed when the index is created and cannot be changed
+// here.
+func (h *Handlers) UpdateIndexSettings(w http.ResponseWriter, r *http.Request) {
+	var req indexSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Replicas == nil {
+		respondError(w, http.StatusBadRequest, "number_of_replicas is required")
+		return
+	}
+	if err := port.ValidateReplicas(*req.Replicas); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	index := port.IndexFor(r.Context())
+	err := h.os.UpdateIndexSettings(r.Context(), *req.Replicas)
+	switch {
+	case errors.Is(err, port.ErrUnsupported):
+		respondError(w, http.StatusNotImplemented, "Index settings are not supported by this search backend")
+		return
+	case err != nil:
+		h.logger.Error("Failed to update index settings", "index", index, "error", err)
+		respondBackendError(w, err, "Failed to update index settings")
+		return
+	}
+
+	h.logger.Warn("Index settings updated", "index", index, "number_of_replicas", *req.Replicas)
+
+	respondJSON(w, http.StatusOK, map[string]any{
+		"status":             "updated",
+		"index":              index,
+		"number_of_replicas": *req.Replicas,
+	})
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func TestUpdateIndexSettings(t *testing.T) {
+	mock := &mockSearchClient{}
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+	req := httptest.NewRequest("PUT", "/admin/index/settings", strings.NewReader(`{"number_of_replicas": 2}`))
+	rec := httptest.NewRecorder()
+	handlers.UpdateIndexSettings(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if mock.replicas != 2 {
+		t.Errorf("expected 2 replicas to be set, got %d", mock.replicas)
+	}
+	var resp map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	if resp["index"] != "tutors" || resp["number_of_replicas"] != float64(2) {
+		t.Errorf("unexpected response %v", resp)
+	}
+}
+
+func TestUpdateIndexSettings_InvalidRequests(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"invalid body", `replicas=2`},
+		{"missing replicas", `{}`},
+		{"negative replicas", `{"number_of_replicas": -1}`},
+		{"too many replicas", fmt.Sprintf(`{"number_of_replicas": %d}`, port.MaxReplicas+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{replicas: -1}
+			handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+			req := httptest.NewRequest("PUT", "/admin/index/settings", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			handlers.UpdateIndexSettings(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+			}
+			if mock.replicas != -1 {
+				t.Errorf("expected settings not to be updated, got %d replicas", mock.replicas)
+			}
+		})
+	}
+}
+
+func TestUpdateIndexSettings_BackendErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"unsupported", port.ErrUnsupported, http.StatusNotImplemented},
+		{"failure", errors.New("cluster unavailable"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{settingsErr: tt.err}
+			handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+			req := httptest.NewRequest("PUT", "/admin/index/settings", strings.NewReader(`{"number_of_replicas": 1}`))
+			rec := httptest.NewRecorder()
+			handlers.UpdateIndexSettings(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestRouter_IndexSettingsRequiresAdminKey(t *testing.T) {
+	logger := testutil.NewLogger(t)
+
+	for _, tt := range []struct {
+		authHeader string
+		wantStatus int
+	}{
+		{"", http.StatusUnauthorized},
+		{"Bearer wrong", http.StatusUnauthorized},
+		{"Bearer secret", http.StatusOK},
+	} {
+		t.Run(fmt.Sprintf("auth %q", tt.authHeader), func(t *testing.T) {
+			mock := &mockSearchClient{replicas: -1}
+			cfg := testRouterConfig()
+			cfg.AdminAPIKey = "secret"
+			router := NewRouter(mock, logger, cfg)
+
+			req := httptest.NewRequest("PUT", "/admin/index/settings", strings.NewReader(`{"number_of_replicas": 1}`))
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if (mock.replicas == 1) != (tt.wantStatus == http.StatusOK) {
+				t.Errorf("unexpected replicas %d", mock.replicas)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+
+	"search/internal/port"
+)
+
+// RateReporter is implemented by *limiter.Adaptive.
+type RateReporter interface {
+	Rate() float64
+}
+
+// WithIndexingRate reports the pace of tutor writes in IndexStats.
+func WithIndexingRate(r RateReporter) Option {
+	return func(h *Handlers) {
+		h.indexRate = r
+	}
+}
+
+// ConnectionReporter is implemented by *opensearch.Client.
+type ConnectionReporter interface {
+	ConnectionStats() port.ConnectionStats
+}
+
+// WithConnectionStats reports how requests to the search backend got
+// their connections in IndexStats.
+func WithConnectionStats(c ConnectionReporter) Option {
+	return func(h *Handlers) {
+		h.connections = c
+	}
+}
+
+// indexStats is the body of GET /admin/index/stats.
+type indexStats struct {
+	Index  string `json:"index"`
+	Tutors int64  `json:"tutors"`
+	// IndexingRate is the tutor writes per second currently allowed,
+	// lowered while OpenSearch pushes back with 429s.
+	IndexingRate *float64 `json:"indexing_rate,omitempty"`
+	// Connections counts the connections requests to the search backend
+	// were sent on since startup, new and reused from the pool.
+	Connections *port.ConnectionStats `json:"connections,omitempty"`
+}
+
+// IndexStats reports the index searches read and how many tutors it holds.
+func (h *Handlers) IndexStats(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	count, err := h.os.CountTutors(ctx)
+	if err != nil {
+		h.logger.Error("Failed to count indexed tutors", "error", err)
+		respondBackendError(w, err, "Failed to count indexed tutors")
+		return
+	}
+	stats := indexStats{Index: port.IndexFor(ctx), Tutors: count}
+	if h.indexRate != nil {
+		rate := h.indexRate.Rate()
+		stats.IndexingRate = &rate
+	}
+	if h.connections != nil {
+		conns := h.connections.ConnectionStats()
+		stats.Connections = &conns
+	}
+	respondJSON(w, http.StatusOK, stats)
+}
@@ -0,0 +1,28 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"search/internal/lease"
+)
+
+// LeaseReporter is implemented by *lease.Lease.
+type LeaseReporter interface {
+	Status(ctx context.Context) (lease.Status, error)
+}
+
+// JobLease reports which replica holds the lease on scheduled jobs.
+func (h *Handlers) JobLease(w http.ResponseWriter, r *http.Request) {
+	if h.lease == nil {
+		respondError(w, http.StatusNotFound, "Job lease is not enabled")
+		return
+	}
+	status, err := h.lease.Status(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to read job lease", "error", err)
+		respondError(w, http.StatusInternalServerError, "Failed to read job lease")
+		return
+	}
+	respondJSON(w, http.StatusOK, status)
+}
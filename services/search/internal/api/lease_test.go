@@ -0,0 +1,75 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"search/internal/lease"
+	"search/internal/opensearch"
+	"search/internal/testutil"
+)
+
+// failingLease fails every status read.
+type failingLease struct{}
+
+func (failingLease) Status(ctx context.Context) (lease.Status, error) {
+	return lease.Status{}, errors.New("cluster unavailable")
+}
+
+func TestJobLease(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	l := lease.New(opensearch.NewMemoryClient(), lease.JobsLock, "pod-a", 30*time.Second,
+		testutil.NewLogger(t), lease.WithClock(func() time.Time { return now }))
+	if _, err := l.Acquire(context.Background()); err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	cfg := testRouterConfig()
+	cfg.Lease = l
+	cfg.AdminAPIKey = testAdminKey
+	router := NewRouter(&mockSearchClient{}, testutil.NewLogger(t), cfg)
+
+	rec := serve(router, "GET", "/admin/lock", "", "Bearer "+testAdminKey)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var resp lease.Status
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Name != lease.JobsLock || resp.Holder != "pod-a" || !resp.HeldSelf || resp.Expired {
+		t.Errorf("expected pod-a to hold the lease, got %+v", resp)
+	}
+	if resp.ExpiresAt == nil || !resp.ExpiresAt.Equal(now.Add(30*time.Second)) {
+		t.Errorf("expected expiry 30s from now, got %v", resp.ExpiresAt)
+	}
+}
+
+func TestJobLease_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []Option
+		wantStatus int
+	}{
+		{"not enabled", nil, http.StatusNotFound},
+		{"store failure", []Option{WithLeaseReporter(failingLease{})}, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := NewHandlers(&mockSearchClient{}, testutil.NewLogger(t), tt.opts...)
+
+			rec := httptest.NewRecorder()
+			handlers.JobLease(rec, httptest.NewRequest("GET", "/admin/lock", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"search/internal/domain"
+)
+
+// checkLevels returns a 400 body listing the valid levels when a level
+// parameter in q is not one of them. Levels are matched case-insensitively.
+func checkLevels(q url.Values) *QueryError {
+	for _, level := range q["level"] {
+		if !domain.IsLevel(strings.ToLower(level)) {
+			return &QueryError{
+				Error: fmt.Sprintf("unknown level %q", level),
+				Param: "level",
+				Valid: domain.Levels,
+			}
+		}
+	}
+	return nil
+}
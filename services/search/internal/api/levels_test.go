@@ -0,0 +1,61 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"search/internal/domain"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func TestSearchTutors_Levels(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+		wantLevels []string
+	}{
+		{"no level", "/tutors/search", http.StatusOK, nil},
+		{"known levels", "/tutors/search?level=school&level=University", http.StatusOK, []string{"school", "university"}},
+		{"unknown level", "/tutors/search?level=school&level=phd", http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{searchResult: &port.SearchResponse{}}
+			router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest("GET", tt.url, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK {
+				if !slices.Equal(mock.searchedQuery.Levels, tt.wantLevels) {
+					t.Errorf("expected levels %q, got %q", tt.wantLevels, mock.searchedQuery.Levels)
+				}
+				return
+			}
+
+			var resp struct {
+				Error string   `json:"error"`
+				Param string   `json:"param"`
+				Valid []string `json:"valid"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Param != "level" || resp.Error != `unknown level "phd"` {
+				t.Errorf("unexpected error response %+v", resp)
+			}
+			if !slices.Equal(resp.Valid, domain.Levels) {
+				t.Errorf("expected valid levels %q, got %q", domain.Levels, resp.Valid)
+			}
+		})
+	}
+}
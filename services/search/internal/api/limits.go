@@ -0,0 +1,97 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"search/internal/port"
+)
+
+// QueryLimits caps how many values one search request may list for the
+// filters that expand into large terms queries. A zero field is uncapped.
+type QueryLimits struct {
+	Subjects   int
+	Locations  int
+	ExcludeIDs int
+	// FacetSize caps facet_size and is its default; zero means
+	// port.MaxFacetSize.
+	FacetSize int
+}
+
+// QueryLimitError reports the first filter that exceeds its cap.
+type QueryLimitError struct {
+	Param string
+	Limit int
+	Got   int
+}
+
+func (e *QueryLimitError) Error() string {
+	return fmt.Sprintf("too many %s values: got %d, at most %d allowed", e.Param, e.Got, e.Limit)
+}
+
+// check counts the filter values in q. exclude_ids entries are counted
+// after splitting on commas; location is counted per repeated parameter,
+// though only the first is applied.
+func (l QueryLimits) check(q url.Values) *QueryLimitError {
+	excludeIDs := 0
+	for _, raw := range q["exclude_ids"] {
+		for _, part := range strings.Split(raw, ",") {
+			if strings.TrimSpace(part) != "" {
+				excludeIDs++
+			}
+		}
+	}
+
+	for _, c := range []struct {
+		param string
+		limit int
+		got   int
+	}{
+		{"subjects", l.Subjects, len(q["subjects"])},
+		{"location", l.Locations, len(q["location"])},
+		{"exclude_ids", l.ExcludeIDs, excludeIDs},
+	} {
+		if c.limit > 0 && c.got > c.limit {
+			return &QueryLimitError{Param: c.param, Limit: c.limit, Got: c.got}
+		}
+	}
+	return nil
+}
+
+// facetSize returns the facet_size in q, how many subjects a facet count
+// lists, or the cap when q has none. A value that is not a whole number
+// between 1 and the cap gets a 400 body.
+func (l QueryLimits) facetSize(q url.Values) (int, *QueryError) {
+	limit := l.FacetSize
+	if limit <= 0 {
+		limit = port.MaxFacetSize
+	}
+	raw := q.Get("facet_size")
+	if raw == "" {
+		return limit, nil
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || n < 1 || n > limit {
+		return 0, &QueryError{
+			Error: fmt.Sprintf("facet_size must be between 1 and %d, got %q", limit, raw),
+			Param: "facet_size",
+			Limit: float64(limit),
+		}
+	}
+	return n, nil
+}
+
+// caps reports whether l sets a cap of its own for param.
+func (l QueryLimits) caps(param string) bool {
+	switch param {
+	case "subjects":
+		return l.Subjects > 0
+	case "location":
+		return l.Locations > 0
+	case "exclude_ids":
+		return l.ExcludeIDs > 0
+	}
+	return false
+}
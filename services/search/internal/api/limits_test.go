@@ -0,0 +1,223 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"search/internal/port"
+	"search/internal/store"
+	"search/internal/testutil"
+)
+
+var testQueryLimits = QueryLimits{Subjects: 3, Locations: 1, ExcludeIDs: 4}
+
+func TestQueryLimits_Check(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantParam string
+		wantGot   int
+	}{
+		{"within limits", "subjects=a&subjects=b&subjects=c&location=x&exclude_ids=1,2,3,4", "", 0},
+		{"too many subjects", "subjects=a&subjects=b&subjects=c&subjects=d", "subjects", 4},
+		{"too many locations", "location=x&location=y", "location", 2},
+		{"exclude ids across params", "exclude_ids=1,2,3&exclude_ids=4,5", "exclude_ids", 5},
+		{"blank exclude ids not counted", "exclude_ids=1,,2,%20,3", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, _ := url.ParseQuery(tt.query)
+			err := testQueryLimits.check(q)
+			if tt.wantParam == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected %s to exceed its limit", tt.wantParam)
+			}
+			if err.Param != tt.wantParam || err.Got != tt.wantGot {
+				t.Errorf("expected %s with %d values, got %s with %d", tt.wantParam, tt.wantGot, err.Param, err.Got)
+			}
+		})
+	}
+}
+
+func TestQueryLimits_ZeroIsUncapped(t *testing.T) {
+	q := url.Values{"subjects": strings.Split(strings.Repeat("x,", 100), ",")}
+	if err := (QueryLimits{}).check(q); err != nil {
+		t.Errorf("expected no cap, got %v", err)
+	}
+}
+
+func TestSearchTutors_QueryLimitExceeded(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantParam string
+		wantLimit int
+	}{
+		{"subjects", "subjects=a&subjects=b&subjects=c&subjects=d", "subjects", 3},
+		{"locations", "location=x&location=y", "location", 1},
+		{"exclude ids", "exclude_ids=1,2,3,4,5", "exclude_ids", 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{searchResult: &port.SearchResponse{}}
+			handlers := NewHandlers(mock, testutil.NewLogger(t), WithQueryLimits(testQueryLimits))
+
+			rec := httptest.NewRecorder()
+			handlers.SearchTutors(rec, httptest.NewRequest("GET", "/tutors/search?"+tt.query, nil))
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+			}
+			var body struct {
+				Error string `json:"error"`
+				Param string `json:"param"`
+				Limit int    `json:"limit"`
+			}
+			json.Unmarshal(rec.Body.Bytes(), &body)
+			if body.Param != tt.wantParam || body.Limit != tt.wantLimit {
+				t.Errorf("expected %s limited to %d, got %+v", tt.wantParam, tt.wantLimit, body)
+			}
+		})
+	}
+}
+
+func TestSearchTutors_WithinQueryLimits(t *testing.T) {
+	mock := &mockSearchClient{searchResult: &port.SearchResponse{}}
+	handlers := NewHandlers(mock, testutil.NewLogger(t), WithQueryLimits(testQueryLimits))
+
+	rec := httptest.NewRecorder()
+	handlers.SearchTutors(rec, httptest.NewRequest("GET", "/tutors/search?subjects=a&subjects=b&subjects=c", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+}
+
+func TestExportTutorsCSV_QueryLimitExceeded(t *testing.T) {
+	mock := &mockSearchClient{searchResult: &port.SearchResponse{}}
+	handlers := NewHandlers(mock, testutil.NewLogger(t), WithQueryLimits(testQueryLimits))
+
+	rec := httptest.NewRecorder()
+	handlers.ExportTutorsCSV(rec, httptest.NewRequest("GET", "/tutors/search?format=csv&exclude_ids=1,2,3,4,5", nil))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestCreateSavedSearch_QueryLimitExceeded(t *testing.T) {
+	h := NewHandlers(&mockSearchClient{}, testutil.NewLogger(t),
+		WithStore(store.NewMemory()), WithQueryLimits(testQueryLimits))
+
+	body := `{"name": "everything", "params": "subjects=a&subjects=b&subjects=c&subjects=d"}`
+	req := asUser(httptest.NewRequest("POST", "/me/searches", bytes.NewReader([]byte(body))), "1")
+	rec := httptest.NewRecorder()
+	h.CreateSavedSearch(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestQueryLimits_FacetSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		limits  QueryLimits
+		query   string
+		want    int
+		wantErr bool
+	}{
+		{"defaults to the cap", QueryLimits{FacetSize: 50}, "", 50, false},
+		{"unset cap defaults to the hard cap", QueryLimits{}, "", port.MaxFacetSize, false},
+		{"within the cap", QueryLimits{FacetSize: 50}, "facet_size=10", 10, false},
+		{"at the cap", QueryLimits{FacetSize: 50}, "facet_size=50", 50, false},
+		{"over the cap", QueryLimits{FacetSize: 50}, "facet_size=51", 0, true},
+		{"over the hard cap", QueryLimits{}, "facet_size=1001", 0, true},
+		{"zero", QueryLimits{FacetSize: 50}, "facet_size=0", 0, true},
+		{"negative", QueryLimits{FacetSize: 50}, "facet_size=-3", 0, true},
+		{"not a number", QueryLimits{FacetSize: 50}, "facet_size=many", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, _ := url.ParseQuery(tt.query)
+			got, err := tt.limits.facetSize(q)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected facet_size to be rejected, got %d", got)
+				}
+				limit := tt.limits.FacetSize
+				if limit == 0 {
+					limit = port.MaxFacetSize
+				}
+				if err.Param != "facet_size" || err.Limit != float64(limit) {
+					t.Errorf("expected facet_size limited to %d, got %+v", limit, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %+v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected facet size %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFacetSize_ReachesTheBackend(t *testing.T) {
+	limits := QueryLimits{FacetSize: 50}
+	tests := []struct {
+		name     string
+		url      string
+		wantCode int
+		wantSize int
+	}{
+		{"subjects default", "/subjects", http.StatusOK, 50},
+		{"subjects", "/subjects?facet_size=5", http.StatusOK, 5},
+		{"subjects over the cap", "/subjects?facet_size=51", http.StatusBadRequest, 0},
+		{"search default", "/tutors/search?include_facets=true", http.StatusOK, 50},
+		{"search", "/tutors/search?include_facets=true&facet_size=7", http.StatusOK, 7},
+		{"search over the cap", "/tutors/search?include_facets=true&facet_size=51", http.StatusBadRequest, 0},
+		{"search rejects a bad size without facets", "/tutors/search?facet_size=0", http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{searchResult: &port.SearchResponse{}}
+			cfg := testRouterConfig()
+			cfg.QueryLimits = limits
+			router := NewRouter(mock, testutil.NewLogger(t), cfg)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest("GET", tt.url, nil))
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantCode, rec.Code, rec.Body.String())
+			}
+			if tt.wantCode == http.StatusBadRequest {
+				var body QueryError
+				json.Unmarshal(rec.Body.Bytes(), &body)
+				if body.Param != "facet_size" || body.Limit != 50 {
+					t.Errorf("expected facet_size limited to 50, got %+v", body)
+				}
+				return
+			}
+			if got := mock.facetSize.Load(); got != int64(tt.wantSize) {
+				t.Errorf("expected facets counted with size %d, got %d", tt.wantSize, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+
+	"search/internal/domain"
+)
+
+// language returns the language to label subjects in when the request
+// asks for localize=true, negotiated from Accept-Language among the
+// translated languages, and "" otherwise. A localized response names its
+// language in Content-Language.
+func (h *Handlers) language(w http.ResponseWriter, r *http.Request) string {
+	if r.URL.Query().Get("localize") != "true" {
+		return ""
+	}
+	lang := domain.NegotiateLanguage(r.Header.Get("Accept-Language"), h.translations.Languages())
+	w.Header().Set("Content-Language", lang)
+	w.Header().Add("Vary", "Accept-Language")
+	return lang
+}
+
+// subjectLabel returns the label of a subject key in lang, falling back to
+// English and then to the catalog label. An empty lang uses the catalog
+// label.
+func (h *Handlers) subjectLabel(key, lang string) string {
+	if lang != "" {
+		if label, ok := h.translations.Label(key, lang); ok {
+			return label
+		}
+	}
+	return h.subjects.Label(key)
+}
+
+// localizeSubjects replaces the SubjectsDisplay of tutors with labels in
+// lang. A subject without a translation keeps its indexed label. An empty
+// lang leaves tutors as indexed.
+func (h *Handlers) localizeSubjects(tutors []domain.Tutor, lang string) {
+	if lang == "" {
+		return
+	}
+	for i := range tutors {
+		t := &tutors[i]
+		indexed := len(t.SubjectsDisplay) == len(t.Subjects)
+		labels := make([]string, len(t.Subjects))
+		for j, key := range t.Subjects {
+			if label, ok := h.translations.Label(key, lang); ok {
+				labels[j] = label
+			} else if indexed {
+				labels[j] = t.SubjectsDisplay[j]
+			} else {
+				labels[j] = h.subjects.Label(key)
+			}
+		}
+		t.SubjectsDisplay = labels
+	}
+}
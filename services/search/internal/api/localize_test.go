@@ -0,0 +1,115 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"search/internal/domain"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func localizedSearchClient() *mockSearchClient {
+	return &mockSearchClient{
+		searchResult: &port.SearchResponse{Results: []domain.Tutor{{
+			ID:              1,
+			Subjects:        []string{"math", "calculus"},
+			SubjectsDisplay: []string{"Mathematics", "Calculus"},
+		}}, Total: 1},
+		facetCounts: port.FacetCounts{Subjects: map[string]int{"math": 3, "calculus": 1}},
+	}
+}
+
+func TestSearchTutors_Localize(t *testing.T) {
+	tests := []struct {
+		name           string
+		url            string
+		acceptLanguage string
+		wantLabels     []string
+		wantLanguage   string
+	}{
+		{"russian", "/tutors/search?localize=true", "ru-RU,ru;q=0.9,en;q=0.8", []string{"Математика", "Calculus"}, "ru"},
+		{"english preferred", "/tutors/search?localize=true", "en-GB,ru;q=0.5", []string{"Mathematics", "Calculus"}, "en"},
+		{"unsupported falls back to English", "/tutors/search?localize=true", "de-DE", []string{"Mathematics", "Calculus"}, "en"},
+		{"not asked", "/tutors/search", "ru", []string{"Mathematics", "Calculus"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := NewHandlers(localizedSearchClient(), testutil.NewLogger(t))
+
+			req := httptest.NewRequest("GET", tt.url, nil)
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+			rec := httptest.NewRecorder()
+			handlers.SearchTutors(rec, req)
+
+			var resp port.SearchResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if got := resp.Results[0].SubjectsDisplay; !slices.Equal(got, tt.wantLabels) {
+				t.Errorf("expected labels %v, got %v", tt.wantLabels, got)
+			}
+			if got := rec.Header().Get("Content-Language"); got != tt.wantLanguage {
+				t.Errorf("expected Content-Language %q, got %q", tt.wantLanguage, got)
+			}
+		})
+	}
+}
+
+func TestSubjects_Localize(t *testing.T) {
+	handlers := NewHandlers(localizedSearchClient(), testutil.NewLogger(t))
+
+	req := httptest.NewRequest("GET", "/subjects?localize=true", nil)
+	req.Header.Set("Accept-Language", "ru")
+	rec := httptest.NewRecorder()
+	handlers.Subjects(rec, req)
+
+	var resp struct {
+		Subjects []subjectFacet `json:"subjects"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	want := []subjectFacet{
+		{Key: "math", Label: "Математика", Count: 3},
+		// Keys without a translation keep the catalog label, here the key.
+		{Key: "calculus", Label: "calculus", Count: 1},
+	}
+	if !slices.Equal(resp.Subjects, want) {
+		t.Errorf("expected %+v, got %+v", want, resp.Subjects)
+	}
+	if vary := rec.Header().Values("Vary"); !slices.Contains(vary, "Accept-Language") {
+		t.Errorf("expected Vary: Accept-Language, got %v", vary)
+	}
+}
+
+func TestLocalize_CustomTranslations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "translations.json")
+	if err := os.WriteFile(path, []byte(`{"translations": {"calculus": {"en": "Calculus", "ru": "Матанализ"}}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	translations, err := domain.LoadTranslations(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handlers := NewHandlers(localizedSearchClient(), testutil.NewLogger(t),
+		WithTranslations(translations))
+
+	req := httptest.NewRequest("GET", "/tutors/search?localize=true", nil)
+	req.Header.Set("Accept-Language", "ru")
+	rec := httptest.NewRecorder()
+	handlers.SearchTutors(rec, req)
+
+	var resp port.SearchResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got := resp.Results[0].SubjectsDisplay; !slices.Equal(got, []string{"Математика", "Матанализ"}) {
+		t.Errorf("expected both subjects in Russian, got %v", got)
+	}
+}
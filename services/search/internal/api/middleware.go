@@ -1,10 +1,20 @@
 package api
 
 import (
+	"bytes"
+	"context"
+	"crypto/subtle"
 	"log/slog"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"search/internal/auth"
+	"search/internal/tenant"
 )
 
 func LoggingMiddleware(logger *slog.Logger) func(http.Handler) http.Handler {
@@ -42,6 +52,83 @@ func RecoveryMiddleware(logger *slog.Logger) func(http.Handler) http.Handler {
 	}
 }
 
+// AdminAuthMiddleware requires "Authorization: Bearer <apiKey>". With no key
+// configured the wrapped routes are refused outright rather than left open.
+func AdminAuthMiddleware(apiKey string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if apiKey == "" {
+				respondError(w, http.StatusForbidden, "Admin API key is not configured")
+				return
+			}
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(apiKey)) != 1 {
+				respondError(w, http.StatusUnauthorized, "Unauthorized")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// AuthMiddleware attaches the user from a valid "Authorization: Bearer <jwt>"
+// header to the request context. Requests without a token pass through
+// anonymously; a token that fails verification is rejected with 401. A nil
+// verifier disables authentication entirely.
+func AuthMiddleware(v *auth.Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if v == nil || !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			userID, err := v.UserID(token)
+			if err != nil {
+				respondError(w, http.StatusUnauthorized, "Invalid token")
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(auth.WithUserID(r.Context(), userID)))
+		})
+	}
+}
+
+// RequireUser rejects requests that AuthMiddleware did not authenticate.
+func RequireUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := auth.UserIDFrom(r.Context()); !ok {
+			respondError(w, http.StatusUnauthorized, "Authentication required")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// TenantMiddleware routes each request to the tenant named by the X-Tenant
+// header or the tenant query parameter, falling back to the default tenant.
+// Unknown tenants, or a header and parameter that disagree, get a 400.
+func TenantMiddleware(tenants *tenant.Registry) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			name := r.Header.Get("X-Tenant")
+			if param := r.URL.Query().Get("tenant"); param != "" {
+				if name != "" && name != param {
+					respondError(w, http.StatusBadRequest, "X-Tenant header and tenant parameter disagree")
+					return
+				}
+				name = param
+			}
+
+			t, ok := tenants.Lookup(name)
+			if !ok {
+				respondError(w, http.StatusBadRequest, "Unknown tenant: "+name)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(tenant.NewContext(r.Context(), t)))
+		})
+	}
+}
+
 func CORSMiddleware(allowedOrigins string) func(http.Handler) http.Handler {
 	origins := strings.Split(allowedOrigins, ",")
 	originSet := make(map[string]bool)
@@ -55,13 +142,15 @@ func CORSMiddleware(allowedOrigins string) func(http.Handler) http.Handler {
 
 			if allowedOrigins == "*" || originSet[origin] {
 				w.Header().Set("Access-Control-Allow-Origin", origin)
+				w.Header().Set("Timing-Allow-Origin", origin)
 			} else if allowedOrigins == "*" {
 				w.Header().Set("Access-Control-Allow-Origin", "*")
 			}
 
 			w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Tenant, X-Client-ID")
 			w.Header().Set("Access-Control-Allow-Credentials", "true")
+			w.Header().Set("Access-Control-Expose-Headers", "X-Total-Count, X-Limit, X-Offset, Link, X-Query-Hash")
 
 			if r.Method == "OPTIONS" {
 				w.WriteHeader(http.StatusOK)
@@ -73,6 +162,179 @@ func CORSMiddleware(allowedOrigins string) func(http.Handler) http.Handler {
 	}
 }
 
+// HeadMiddleware serves HEAD requests with the GET handler registered for the
+// same path, so status and headers match GET while the body is dropped.
+func HeadMiddleware(next http.Handler) http.Handler {
+	getHead := middleware.GetHead(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+		getHead.ServeHTTP(&headResponseWriter{ResponseWriter: w}, r)
+	})
+}
+
+// routeMethods are the methods probed when answering OPTIONS.
+var routeMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+}
+
+// OptionsMiddleware sets the Allow header on OPTIONS requests to the methods
+// registered for the requested path, and answers 404 for unknown paths. The
+// response itself is completed by CORSMiddleware, which must come next.
+func OptionsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rctx := chi.RouteContext(r.Context())
+		if r.Method != http.MethodOptions || rctx == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		allowed := allowedMethods(rctx.Routes, r.URL.Path)
+		if len(allowed) == 0 {
+			respondError(w, http.StatusNotFound, "Not found")
+			return
+		}
+
+		w.Header().Set("Allow", strings.Join(append(allowed, http.MethodOptions), ", "))
+		next.ServeHTTP(w, r)
+	})
+}
+
+func allowedMethods(routes chi.Routes, path string) []string {
+	var allowed []string
+	for _, method := range routeMethods {
+		probe := method
+		if method == http.MethodHead {
+			// HEAD is served by the GET handler via HeadMiddleware.
+			probe = http.MethodGet
+		}
+		if routes.Match(chi.NewRouteContext(), probe, path) {
+			allowed = append(allowed, method)
+		}
+	}
+	return allowed
+}
+
+// headResponseWriter discards the body written by a GET handler.
+type headResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (hw *headResponseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+func (hw *headResponseWriter) Unwrap() http.ResponseWriter {
+	return hw.ResponseWriter
+}
+
+// TimeoutMiddleware gives each request a context deadline of d. If the
+// handler is still running when the deadline passes, the client gets a 504
+// JSON error and anything the handler writes afterwards is discarded.
+func TimeoutMiddleware(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+
+			tw := &timeoutWriter{header: make(http.Header), buf: getBuffer()}
+			done := make(chan struct{})
+			panicCh := make(chan any, 1)
+
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panicCh <- p
+					}
+				}()
+				next.ServeHTTP(tw, r.WithContext(ctx))
+				close(done)
+			}()
+
+			select {
+			case p := <-panicCh:
+				// Re-panic on the request goroutine so RecoveryMiddleware sees it.
+				panic(p)
+			case <-done:
+				tw.mu.Lock()
+				defer tw.mu.Unlock()
+				for k, v := range tw.header {
+					w.Header()[k] = v
+				}
+				if tw.code == 0 {
+					tw.code = http.StatusOK
+				}
+				w.WriteHeader(tw.code)
+				_, _ = w.Write(tw.buf.Bytes())
+				// The handler has returned, so nothing writes to the
+				// buffer any more. After a timeout it may still, so that
+				// buffer is left to the garbage collector.
+				putBuffer(tw.buf)
+			case <-ctx.Done():
+				tw.mu.Lock()
+				defer tw.mu.Unlock()
+				tw.timedOut = true
+				respondError(w, http.StatusGatewayTimeout, "Request timed out")
+			}
+		})
+	}
+}
+
+// DeadlineMiddleware gives each request a context deadline of d without
+// buffering the response, for handlers that stream. The handler is
+// responsible for stopping when the context ends.
+func DeadlineMiddleware(d time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), d)
+			defer cancel()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// timeoutWriter buffers a handler's response so TimeoutMiddleware can either
+// forward it or replace it with a timeout error.
+type timeoutWriter struct {
+	mu       sync.Mutex
+	header   http.Header
+	buf      *bytes.Buffer
+	code     int
+	timedOut bool
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if tw.code == 0 {
+		tw.code = http.StatusOK
+	}
+	return tw.buf.Write(b)
+}
+
+func (tw *timeoutWriter) WriteHeader(code int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut || tw.code != 0 {
+		return
+	}
+	tw.code = code
+}
+
 type responseWriter struct {
 	http.ResponseWriter
 	statusCode int
@@ -82,3 +344,9 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.statusCode = code
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+// Unwrap exposes the underlying writer to http.ResponseController so
+// streaming handlers can flush through the logging wrapper.
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -1,15 +1,15 @@
 package api
 
 import (
-	"log/slog"
 	"net/http"
 	"net/http/httptest"
-	"os"
 	"testing"
+
+	"search/internal/testutil"
 )
 
 func TestLoggingMiddleware(t *testing.T) {
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	logger := testutil.NewLogger(t)
 
 	handler := LoggingMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -30,7 +30,7 @@ func TestLoggingMiddleware(t *testing.T) {
 }
 
 func TestRecoveryMiddleware_NoPanic(t *testing.T) {
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	logger := testutil.NewLogger(t)
 
 	handler := RecoveryMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -48,7 +48,7 @@ func TestRecoveryMiddleware_NoPanic(t *testing.T) {
 }
 
 func TestRecoveryMiddleware_WithPanic(t *testing.T) {
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	logger := testutil.NewLogger(t)
 
 	handler := RecoveryMiddleware(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		panic("test panic")
@@ -94,6 +94,9 @@ func TestCORSMiddleware_SpecificOrigins(t *testing.T) {
 	if rec.Header().Get("Access-Control-Allow-Origin") != "http://localhost:3000" {
 		t.Error("should allow configured origin")
 	}
+	if rec.Header().Get("Timing-Allow-Origin") != "http://localhost:3000" {
+		t.Error("should expose Server-Timing to configured origin")
+	}
 }
 
 func TestCORSMiddleware_OptionsRequest(t *testing.T) {
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"search/internal/port"
+)
+
+// setPaginationHeaders mirrors the page of a search in headers for clients
+// that page without reading the body: X-Total-Count, X-Limit and X-Offset
+// with the limit and offset actually applied, and a Link header with
+// rel="prev" and rel="next" unless on the first or last page. The links
+// keep every query parameter of r, including repeated ones.
+func setPaginationHeaders(w http.ResponseWriter, r *http.Request, query port.SearchQuery, total int) {
+	limit, offset := query.Page()
+	h := w.Header()
+	h.Set("X-Total-Count", strconv.Itoa(total))
+	h.Set("X-Limit", strconv.Itoa(limit))
+	h.Set("X-Offset", strconv.Itoa(offset))
+
+	var links []string
+	if offset > 0 {
+		links = append(links, pageLink(r, limit, max(offset-limit, 0), "prev"))
+	}
+	if offset+limit < total {
+		links = append(links, pageLink(r, limit, offset+limit, "next"))
+	}
+	if len(links) > 0 {
+		h.Set("Link", strings.Join(links, ", "))
+	}
+}
+
+// pageLink formats one Link header entry for r's URL with limit and offset
+// replaced. The target is relative to the host the request reached.
+func pageLink(r *http.Request, limit, offset int, rel string) string {
+	q := r.URL.Query()
+	q.Set("limit", strconv.Itoa(limit))
+	q.Set("offset", strconv.Itoa(offset))
+	u := url.URL{Path: r.URL.Path, RawQuery: q.Encode()}
+	return "<" + u.String() + `>; rel="` + rel + `"`
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func TestSearchTutors_PaginationHeaders(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		total      int
+		wantLimit  string
+		wantOffset string
+		wantLink   string
+	}{
+		{
+			name:       "first page",
+			url:        "/tutors/search?subjects=math&subjects=physics&limit=10",
+			total:      25,
+			wantLimit:  "10",
+			wantOffset: "0",
+			wantLink:   `</tutors/search?limit=10&offset=10&subjects=math&subjects=physics>; rel="next"`,
+		},
+		{
+			name:       "middle page",
+			url:        "/tutors/search?q=ada&subjects=math&subjects=physics&limit=10&offset=10",
+			total:      25,
+			wantLimit:  "10",
+			wantOffset: "10",
+			wantLink: `</tutors/search?limit=10&offset=0&q=ada&subjects=math&subjects=physics>; rel="prev", ` +
+				`</tutors/search?limit=10&offset=20&q=ada&subjects=math&subjects=physics>; rel="next"`,
+		},
+		{
+			name:       "last page",
+			url:        "/tutors/search?subjects=math&limit=10&offset=20",
+			total:      25,
+			wantLimit:  "10",
+			wantOffset: "20",
+			wantLink:   `</tutors/search?limit=10&offset=10&subjects=math>; rel="prev"`,
+		},
+		{
+			name:       "page ending exactly at the total",
+			url:        "/tutors/search?limit=10&offset=10",
+			total:      20,
+			wantLimit:  "10",
+			wantOffset: "10",
+			wantLink:   `</tutors/search?limit=10&offset=0>; rel="prev"`,
+		},
+		{
+			name:       "offset not a multiple of the limit",
+			url:        "/tutors/search?limit=10&offset=5",
+			total:      25,
+			wantLimit:  "10",
+			wantOffset: "5",
+			wantLink: `</tutors/search?limit=10&offset=0>; rel="prev", ` +
+				`</tutors/search?limit=10&offset=15>; rel="next"`,
+		},
+		{
+			name:       "defaults and clamping",
+			url:        "/tutors/search?limit=500&offset=-3",
+			total:      150,
+			wantLimit:  "100",
+			wantOffset: "0",
+			wantLink:   `</tutors/search?limit=100&offset=100>; rel="next"`,
+		},
+		{
+			name:       "single page",
+			url:        "/tutors/search",
+			total:      3,
+			wantLimit:  "20",
+			wantOffset: "0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{searchResult: &port.SearchResponse{Total: tt.total}}
+			router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest("GET", tt.url, nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+			}
+
+			h := rec.Header()
+			if got := h.Get("X-Total-Count"); got != strconv.Itoa(tt.total) {
+				t.Errorf("expected X-Total-Count %d, got %q", tt.total, got)
+			}
+			if got := h.Get("X-Limit"); got != tt.wantLimit {
+				t.Errorf("expected X-Limit %s, got %q", tt.wantLimit, got)
+			}
+			if got := h.Get("X-Offset"); got != tt.wantOffset {
+				t.Errorf("expected X-Offset %s, got %q", tt.wantOffset, got)
+			}
+			if got := h.Get("Link"); got != tt.wantLink {
+				t.Errorf("expected Link %q, got %q", tt.wantLink, got)
+			}
+		})
+	}
+}
+
+func TestSearchTutors_QueryHashHeader(t *testing.T) {
+	mock := &mockSearchClient{searchResult: &port.SearchResponse{}}
+	router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+	hash := func(url string) string {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest("GET", url, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d: %s", url, rec.Code, rec.Body.String())
+		}
+		return rec.Header().Get(QueryHashHeader)
+	}
+
+	a := hash("/tutors/search?q=Algebra&subjects=math&subjects=physics")
+	b := hash("/tutors/search?subjects=physics&subjects=math&q=algebra&limit=20")
+	if a == "" || a != b {
+		t.Errorf("expected equal non-empty hashes for equivalent searches, got %q and %q", a, b)
+	}
+	if c := hash("/tutors/search?q=algebra&subjects=math"); c == a {
+		t.Errorf("expected a different hash for a different search, got %q for both", c)
+	}
+}
+
+func TestSearchTutors_AppliedFilters(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want port.SearchQuery
+	}{
+		{
+			name: "defaults filled in",
+			url:  "/tutors/search?q=algebra",
+			want: port.SearchQuery{Text: "algebra", Limit: port.DefaultLimit},
+		},
+		{
+			name: "out-of-range limit clamped",
+			url:  "/tutors/search?limit=500&offset=-3",
+			want: port.SearchQuery{Limit: port.MaxLimit},
+		},
+		{
+			name: "duplicate subjects and levels collapsed",
+			url:  "/tutors/search?subjects=physics&subjects=Maths&subjects=math&level=School&level=school&limit=10&offset=20",
+			want: port.SearchQuery{Subjects: []string{"math", "physics"}, Levels: []string{"school"}, Limit: 10, Offset: 20},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{searchResult: &port.SearchResponse{}}
+			router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest("GET", tt.url, nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+			}
+
+			var resp struct {
+				AppliedFilters *port.SearchQuery `json:"applied_filters"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.AppliedFilters == nil || !reflect.DeepEqual(*resp.AppliedFilters, tt.want) {
+				t.Errorf("applied_filters = %+v, want %+v", resp.AppliedFilters, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"search/internal/domain"
+	"search/internal/outbox"
+	"search/internal/port"
+)
+
+// queueWrite records a tutor write that failed with err for replay and
+// answers 202, returning true. Without a journal, for overload rejections
+// the caller should retry itself, for documents the backend rejected, which
+// would fail again, and when the journal refuses the write, it returns
+// false so the caller reports the error.
+func (h *Handlers) queueWrite(ctx context.Context, w http.ResponseWriter, op string, id int64, tutor *domain.Tutor, err error) bool {
+	if h.journal == nil || errors.Is(err, port.ErrOverloaded) || errors.Is(err, port.ErrDocumentRejected) {
+		return false
+	}
+	if recErr := h.journal.Record(ctx, op, id, tutor); recErr != nil {
+		h.logger.Error("Failed to queue tutor write for retry", "id", id, "op", op, "error", recErr)
+		return false
+	}
+	h.logger.Warn("Queued failed tutor write for retry", "id", id, "op", op, "error", err)
+	respondJSON(w, http.StatusAccepted, map[string]any{
+		"status":   "pending",
+		"tutor_id": id,
+	})
+	return true
+}
+
+// forgetPending drops a queued write of id once a newer one succeeded.
+func (h *Handlers) forgetPending(ctx context.Context, id int64) {
+	if err := h.journal.Forget(ctx, id); err != nil {
+		h.logger.Warn("Failed to drop superseded pending write", "id", id, "error", err)
+	}
+}
+
+// PendingWrites lists the failed HTTP writes waiting to be replayed,
+// oldest first.
+func (h *Handlers) PendingWrites(w http.ResponseWriter, r *http.Request) {
+	if h.journal == nil {
+		respondError(w, http.StatusNotFound, "Write journal is not enabled")
+		return
+	}
+	entries := h.journal.Entries()
+	if entries == nil {
+		entries = []outbox.Entry{}
+	}
+	respondJSON(w, http.StatusOK, map[string]any{
+		"count":       len(entries),
+		"max_entries": h.journal.MaxEntries(),
+		"entries":     entries,
+	})
+}
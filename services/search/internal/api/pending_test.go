@@ -0,0 +1,145 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"search/internal/outbox"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func newJournal(t *testing.T, opts ...outbox.Option) *outbox.Journal {
+	t.Helper()
+	journal, err := outbox.New(opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return journal
+}
+
+func putTutor(h *Handlers, id string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("PUT", "/tutors/"+id, bytes.NewReader([]byte(`{"full_name": "Ada Lovelace"}`)))
+	req.SetPathValue("id", id)
+	rec := httptest.NewRecorder()
+	h.UpsertTutor(rec, req)
+	return rec
+}
+
+func TestUpsertTutor_QueuesFailedWrite(t *testing.T) {
+	mock := &mockSearchClient{upsertErr: errors.New("connection refused")}
+	journal := newJournal(t)
+	handlers := NewHandlers(mock, testutil.NewLogger(t), WithWriteJournal(journal))
+
+	rec := putTutor(handlers, "7")
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusAccepted, rec.Code, rec.Body.String())
+	}
+	entries := journal.Entries()
+	if len(entries) != 1 || entries[0].Op != outbox.OpUpsert || entries[0].Tutor.FullName != "Ada Lovelace" {
+		t.Fatalf("expected the upsert of tutor 7 queued, got %+v", entries)
+	}
+
+	// A later write that succeeds supersedes the queued one.
+	mock.upsertErr = nil
+	if rec := putTutor(handlers, "7"); rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if entries := journal.Entries(); len(entries) != 0 {
+		t.Errorf("expected the queued write dropped, got %+v", entries)
+	}
+}
+
+func TestDeleteTutor_QueuesFailedWrite(t *testing.T) {
+	mock := &mockSearchClient{deleteErr: errors.New("connection refused")}
+	journal := newJournal(t)
+	handlers := NewHandlers(mock, testutil.NewLogger(t), WithWriteJournal(journal))
+
+	req := httptest.NewRequest("DELETE", "/tutors/456", nil)
+	req.SetPathValue("id", "456")
+	rec := httptest.NewRecorder()
+	handlers.DeleteTutor(rec, req)
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("expected status %d, got %d", http.StatusAccepted, rec.Code)
+	}
+	if entries := journal.Entries(); len(entries) != 1 || entries[0].Op != outbox.OpDelete || entries[0].TutorID != 456 {
+		t.Errorf("expected the delete of tutor 456 queued, got %+v", entries)
+	}
+}
+
+func TestUpsertTutor_NotQueued(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		journal    *outbox.Journal
+		wantStatus int
+	}{
+		{"overloaded", port.ErrOverloaded, newJournal(t), http.StatusServiceUnavailable},
+		{"rejected document", fmt.Errorf("failed to index tutor: %w", port.ErrDocumentRejected), newJournal(t), http.StatusUnprocessableEntity},
+		{"journal full", errors.New("connection refused"), newJournal(t, outbox.WithMaxEntries(0)), http.StatusInternalServerError},
+		{"no journal", errors.New("connection refused"), nil, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{upsertErr: tt.err}
+			handlers := NewHandlers(mock, testutil.NewLogger(t), WithWriteJournal(tt.journal))
+
+			if rec := putTutor(handlers, "7"); rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if entries := tt.journal.Entries(); len(entries) != 0 {
+				t.Errorf("expected nothing queued, got %+v", entries)
+			}
+		})
+	}
+}
+
+func TestPendingWrites(t *testing.T) {
+	mock := &mockSearchClient{upsertErr: errors.New("connection refused")}
+	cfg := testRouterConfig()
+	cfg.Journal = newJournal(t, outbox.WithMaxEntries(50))
+	cfg.AdminAPIKey = testAdminKey
+	router := NewRouter(mock, testutil.NewLogger(t), cfg)
+
+	put := httptest.NewRequest("PUT", "/tutors/7", bytes.NewReader([]byte(`{"full_name": "Ada Lovelace"}`)))
+	router.ServeHTTP(httptest.NewRecorder(), put)
+
+	rec := serve(router, "GET", "/admin/pending", "", "Bearer "+testAdminKey)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Count      int            `json:"count"`
+		MaxEntries int            `json:"max_entries"`
+		Entries    []outbox.Entry `json:"entries"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Count != 1 || resp.MaxEntries != 50 || len(resp.Entries) != 1 || resp.Entries[0].TutorID != 7 {
+		t.Errorf("expected tutor 7 pending, got %+v", resp)
+	}
+	if resp.Entries[0].Tenant == nil || resp.Entries[0].Tenant.Index != port.IndexName {
+		t.Errorf("expected the write routed to the default index, got %+v", resp.Entries[0].Tenant)
+	}
+}
+
+func TestPendingWrites_NotEnabled(t *testing.T) {
+	handlers := NewHandlers(&mockSearchClient{}, testutil.NewLogger(t))
+
+	rec := httptest.NewRecorder()
+	handlers.PendingWrites(rec, httptest.NewRequest("GET", "/admin/pending", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
@@ -0,0 +1,29 @@
+package api
+
+import (
+	"net/http"
+
+	"search/internal/audit"
+	"search/internal/port"
+)
+
+// RecomputePopularity rescores every tutor in the tenant's index with the
+// configured popularity weights, for use after the weights change or as
+// recency decays. Tutors that could not be rescored are counted in the
+// response rather than failing the request.
+func (h *Handlers) RecomputePopularity(w http.ResponseWriter, r *http.Request) {
+	result, err := h.os.RecomputePopularity(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to recompute popularity", "error", err)
+		respondBackendError(w, err, "Failed to recompute popularity")
+		return
+	}
+
+	audit.SetCount(r.Context(), result.Updated)
+	h.logger.Info("Popularity recomputed",
+		"index", port.IndexFor(r.Context()),
+		"updated", result.Updated,
+		"failed", result.Failed,
+	)
+	respondJSON(w, http.StatusOK, result)
+}
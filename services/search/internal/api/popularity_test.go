@@ -0,0 +1,60 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func TestRecomputePopularity(t *testing.T) {
+	mock := &mockSearchClient{popularityResult: port.PopularityResult{Updated: 7, Failed: 1, Errors: []string{"tutor 3: mapper_parsing_exception: bad"}}}
+	cfg := testRouterConfig()
+	cfg.AdminAPIKey = "secret"
+	router := NewRouter(mock, testutil.NewLogger(t), cfg)
+
+	req := httptest.NewRequest("POST", "/admin/recompute-popularity", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var resp port.PopularityResult
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Updated != 7 || resp.Failed != 1 || len(resp.Errors) != 1 {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
+
+func TestRecomputePopularity_RequiresAdminKey(t *testing.T) {
+	cfg := testRouterConfig()
+	cfg.AdminAPIKey = "secret"
+	router := NewRouter(&mockSearchClient{}, testutil.NewLogger(t), cfg)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("POST", "/admin/recompute-popularity", nil))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, rec.Code)
+	}
+}
+
+func TestRecomputePopularity_BackendError(t *testing.T) {
+	mock := &mockSearchClient{popularityErr: errors.New("cluster unavailable")}
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+	rec := httptest.NewRecorder()
+	handlers.RecomputePopularity(rec, httptest.NewRequest("POST", "/admin/recompute-popularity", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+}
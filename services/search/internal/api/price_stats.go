@@ -0,0 +1,87 @@
+package api
+
+import (
+	"math"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"search/internal/port"
+)
+
+// priceStatsBody is one set of hourly rate stats, in cents precision.
+type priceStatsBody struct {
+	Count        int64   `json:"count"`
+	Min          float64 `json:"min"`
+	Max          float64 `json:"max"`
+	Avg          float64 `json:"avg"`
+	StdDeviation float64 `json:"std_deviation"`
+	// Percentiles is keyed "25", "50", "75" and "90".
+	Percentiles map[string]float64 `json:"percentiles"`
+}
+
+type priceStatsResponse struct {
+	Subject string `json:"subject"`
+	Label   string `json:"label"`
+	priceStatsBody
+	ByFormat map[string]priceStatsBody `json:"by_format,omitempty"`
+}
+
+func newPriceStatsBody(s *port.PriceStats) priceStatsBody {
+	return priceStatsBody{
+		Count:        s.Count,
+		Min:          roundCents(s.Min),
+		Max:          roundCents(s.Max),
+		Avg:          roundCents(s.Avg),
+		StdDeviation: roundCents(s.StdDeviation),
+		Percentiles: map[string]float64{
+			"25": roundCents(s.P25),
+			"50": roundCents(s.P50),
+			"75": roundCents(s.P75),
+			"90": roundCents(s.P90),
+		},
+	}
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// SubjectPriceStats reports the spread of hourly rates among the tutors
+// teaching a subject, for showing tutors how their price compares. The
+// subject goes through the catalog like a search filter. by_format=true
+// adds the same stats per lesson format. 404 when nobody with a rate
+// teaches it.
+func (h *Handlers) SubjectPriceStats(w http.ResponseWriter, r *http.Request) {
+	raw := r.PathValue("subject")
+	if strings.TrimSpace(raw) == "" || utf8.RuneCountInString(raw) > DefaultMaxTextLength {
+		respondError(w, http.StatusBadRequest, "Invalid subject")
+		return
+	}
+	subject := h.subjects.Key(raw)
+	byFormat := r.URL.Query().Get("by_format") == "true"
+
+	stats, err := h.os.PriceStats(r.Context(), subject, byFormat)
+	if err != nil {
+		h.logger.Error("Failed to aggregate price stats", "subject", subject, "error", err)
+		respondBackendError(w, err, "Failed to load price stats")
+		return
+	}
+	if stats.Count == 0 {
+		respondError(w, http.StatusNotFound, "No tutors with a rate teach this subject")
+		return
+	}
+
+	resp := priceStatsResponse{
+		Subject:        subject,
+		Label:          h.subjectLabel(subject, h.language(w, r)),
+		priceStatsBody: newPriceStatsBody(stats),
+	}
+	if byFormat {
+		resp.ByFormat = make(map[string]priceStatsBody, len(stats.ByFormat))
+		for format, s := range stats.ByFormat {
+			resp.ByFormat[format] = newPriceStatsBody(s)
+		}
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func TestSubjectPriceStats(t *testing.T) {
+	mock := &mockSearchClient{priceStats: port.PriceStats{
+		Count: 4, Min: 20, Max: 80, Avg: 45, StdDeviation: 22.912878,
+		P25: 27.5, P50: 40, P75: 57.5, P90: 71.000004,
+		ByFormat: map[string]*port.PriceStats{"online": {Count: 3, Min: 20, Max: 60, Avg: 40, P50: 40}},
+	}}
+	router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+	req := httptest.NewRequest("GET", "/subjects/Maths/price-stats?by_format=true", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if mock.priceSubject != "math" || !mock.priceByFormat {
+		t.Errorf("expected the canonical subject with formats, got %q, %t", mock.priceSubject, mock.priceByFormat)
+	}
+	var resp priceStatsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if resp.Subject != "math" || resp.Label != "Mathematics" || resp.Count != 4 || resp.Avg != 45 {
+		t.Errorf("unexpected response %s", rec.Body.String())
+	}
+	if resp.StdDeviation != 22.91 || resp.Percentiles["90"] != 71 || resp.Percentiles["25"] != 27.5 {
+		t.Errorf("expected values rounded to cents, got %s", rec.Body.String())
+	}
+	if online, ok := resp.ByFormat["online"]; !ok || online.Count != 3 || online.Percentiles["50"] != 40 {
+		t.Errorf("unexpected format stats %s", rec.Body.String())
+	}
+}
+
+func TestSubjectPriceStats_WithoutFormats(t *testing.T) {
+	mock := &mockSearchClient{priceStats: port.PriceStats{Count: 1, Min: 30, Max: 30, Avg: 30}}
+	router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+	req := httptest.NewRequest("GET", "/subjects/physics/price-stats", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if mock.priceByFormat || strings.Contains(rec.Body.String(), "by_format") {
+		t.Errorf("expected no format stats, got %s", rec.Body.String())
+	}
+}
+
+func TestSubjectPriceStats_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		stats      port.PriceStats
+		priceErr   error
+		wantStatus int
+	}{
+		{"no tutors", "/subjects/astrology/price-stats", port.PriceStats{}, nil, http.StatusNotFound},
+		{"subject too long", "/subjects/" + strings.Repeat("a", DefaultMaxTextLength+1) + "/price-stats", port.PriceStats{Count: 1}, nil, http.StatusBadRequest},
+		{"blank subject", "/subjects/%20/price-stats", port.PriceStats{Count: 1}, nil, http.StatusBadRequest},
+		{"backend overloaded", "/subjects/math/price-stats", port.PriceStats{}, port.ErrOverloaded, http.StatusServiceUnavailable},
+		{"backend failure", "/subjects/math/price-stats", port.PriceStats{}, errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{priceStats: tt.stats, priceErr: tt.priceErr}
+			router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest("GET", tt.path, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// staleCheckTimeout bounds the broker round trip a readiness probe may make
+// to confirm the index is stale; probes time out quickly.
+const staleCheckTimeout = time.Second
+
+// CodeServiceStarting is the error code of the 503 sent to every request
+// but the liveness probe until startup has finished.
+const CodeServiceStarting = "service_starting"
+
+// startingRetryAfter is the Retry-After, in seconds, sent while startup is
+// still waiting for its dependencies.
+const startingRetryAfter = "5"
+
+// ReadinessChecker is implemented by *bootstrap.Bootstrap.
+type ReadinessChecker interface {
+	Ready() bool
+}
+
+// ReadinessGateMiddleware answers every request except /health/live with a
+// 503, a Retry-After and code CodeServiceStarting until checker reports
+// ready. Readiness never reverts, so the first ready answer is latched and
+// later requests go straight through. A nil checker disables the gate.
+func ReadinessGateMiddleware(checker ReadinessChecker) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if checker == nil {
+			return next
+		}
+		var open atomic.Bool
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if open.Load() || r.URL.Path == "/health/live" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if checker.Ready() {
+				open.Store(true)
+				next.ServeHTTP(w, r)
+				return
+			}
+			w.Header().Set("Retry-After", startingRetryAfter)
+			respondJSON(w, http.StatusServiceUnavailable, map[string]string{
+				"error": "Service is starting",
+				"code":  CodeServiceStarting,
+			})
+		})
+	}
+}
+
+// Live reports that the process is up and serving HTTP. It checks no
+// dependencies, so a slow backend never gets the pod restarted.
+func (h *Handlers) Live(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, map[string]string{"status": "alive"})
+}
+
+// Ready reports whether startup has finished: the search backend was
+// reached, its indices exist and the Kafka consumer, if enabled, is
+// running. Without a ReadinessChecker the service is always ready. With a
+// watermark it also reports lag_seconds, and with a max staleness it fails
+// while the index is stale.
+func (h *Handlers) Ready(w http.ResponseWriter, r *http.Request) {
+	if h.readiness != nil && !h.readiness.Ready() {
+		respondJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "starting"})
+		return
+	}
+	if h.watermark == nil {
+		respondJSON(w, http.StatusOK, map[string]string{"status": "ready"})
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), staleCheckTimeout)
+	defer cancel()
+	f := h.indexFreshness(ctx, false)
+	resp := map[string]any{"status": "ready", "lag_seconds": f.LagSeconds}
+	if f.Stale {
+		resp["status"] = "stale"
+		resp["messages_behind"] = f.MessagesBehind
+		respondJSON(w, http.StatusServiceUnavailable, resp)
+		return
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
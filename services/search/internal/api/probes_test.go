@@ -0,0 +1,157 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"search/internal/testutil"
+)
+
+type fakeReadiness bool
+
+func (f fakeReadiness) Ready() bool { return bool(f) }
+
+func TestProbes(t *testing.T) {
+	tests := []struct {
+		name       string
+		readiness  ReadinessChecker
+		path       string
+		wantStatus int
+	}{
+		{"live while starting", fakeReadiness(false), "/health/live", http.StatusOK},
+		{"not ready while starting", fakeReadiness(false), "/health/ready", http.StatusServiceUnavailable},
+		{"ready once started", fakeReadiness(true), "/health/ready", http.StatusOK},
+		{"ready without a checker", nil, "/health/ready", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The backend is down: probes must not depend on it.
+			mock := &mockSearchClient{pingErr: errors.New("connection refused")}
+			cfg := testRouterConfig()
+			cfg.Readiness = tt.readiness
+			router := NewRouter(mock, testutil.NewLogger(t), cfg)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest("GET", tt.path, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
+
+// flipReadiness is a ReadinessChecker whose answer can change mid-test.
+type flipReadiness struct{ ready atomic.Bool }
+
+func (f *flipReadiness) Ready() bool { return f.ready.Load() }
+
+func TestReadinessGate(t *testing.T) {
+	readiness := &flipReadiness{}
+	cfg := testRouterConfig()
+	cfg.Readiness = readiness
+	router := NewRouter(&mockSearchClient{}, testutil.NewLogger(t), cfg)
+
+	for _, path := range []string{"/tutors/search?q=math", "/subjects", "/health", "/health/ready"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		if rec.Code != http.StatusServiceUnavailable {
+			t.Errorf("%s while starting: expected status 503, got %d", path, rec.Code)
+		}
+		if got := rec.Header().Get("Retry-After"); got != startingRetryAfter {
+			t.Errorf("%s while starting: expected Retry-After %q, got %q", path, startingRetryAfter, got)
+		}
+		var body map[string]string
+		if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["code"] != CodeServiceStarting {
+			t.Errorf("%s while starting: expected code %q, got %q", path, CodeServiceStarting, body["code"])
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/health/live", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("/health/live while starting: expected status 200, got %d", rec.Code)
+	}
+
+	readiness.ready.Store(true)
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/subjects", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("/subjects once ready: expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Retry-After"); got != "" {
+		t.Errorf("/subjects once ready: expected no Retry-After, got %q", got)
+	}
+}
+
+func TestReadinessGate_WithoutChecker(t *testing.T) {
+	router := NewRouter(&mockSearchClient{}, testutil.NewLogger(t), testRouterConfig())
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/subjects", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestReadinessGate_ConcurrentFlip(t *testing.T) {
+	readiness := &flipReadiness{}
+	cfg := testRouterConfig()
+	cfg.Readiness = readiness
+	router := NewRouter(&mockSearchClient{}, testutil.NewLogger(t), cfg)
+
+	const workers, requests = 8, 50
+	var wg sync.WaitGroup
+	var sawOK atomic.Bool
+	errs := make(chan string, workers*requests)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < requests; j++ {
+				if i == 0 && j == requests/2 {
+					readiness.ready.Store(true)
+				}
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, httptest.NewRequest("GET", "/subjects", nil))
+				switch rec.Code {
+				case http.StatusOK:
+					sawOK.Store(true)
+				case http.StatusServiceUnavailable:
+					// Only possible before the flip; once a request got
+					// through, the gate stays open.
+				default:
+					errs <- rec.Body.String()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for body := range errs {
+		t.Errorf("unexpected response during the flip: %s", body)
+	}
+	if !sawOK.Load() {
+		t.Error("expected requests to get through once ready")
+	}
+
+	// After the flip every request passes, even if the checker were to
+	// report not ready again: the gate is latched open.
+	readiness.ready.Store(false)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/subjects", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("after the flip: expected status 200, got %d", rec.Code)
+	}
+}
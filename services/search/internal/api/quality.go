@@ -0,0 +1,90 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"search/internal/port"
+)
+
+// Paging bounds of GET /admin/quality.
+const (
+	// qualitySampleSize is how many IDs each rule lists in the overview.
+	qualitySampleSize = 10
+	// defaultQualityLimit and maxQualityLimit bound a single rule's page.
+	defaultQualityLimit = 100
+	maxQualityLimit     = 1000
+	// maxQualityWindow is the deepest offset plus limit OpenSearch pages
+	// to by default (index.max_result_window).
+	maxQualityWindow = 10000
+)
+
+// DataQuality lists tutors with suspicious data. Without a rule parameter
+// it reports every rule in port.QualityRules with its count and up to
+// qualitySampleSize IDs; with rule it pages through that rule's tutors with
+// limit and offset.
+func (h *Handlers) DataQuality(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	rule := q.Get("rule")
+	if rule == "" {
+		results, err := h.os.CheckQuality(r.Context(), port.QualityRules, qualitySampleSize, 0)
+		if err != nil {
+			h.logger.Error("Failed to check data quality", "error", err)
+			respondBackendError(w, err, "Failed to check data quality")
+			return
+		}
+		respondJSON(w, http.StatusOK, map[string]any{"rules": results})
+		return
+	}
+
+	if !slices.Contains(port.QualityRules, rule) {
+		respondJSON(w, http.StatusBadRequest, map[string]any{
+			"error": fmt.Sprintf("unknown rule %q", rule),
+			"param": "rule",
+			"valid": port.QualityRules,
+		})
+		return
+	}
+	limit, ok := intParam(w, q.Get("limit"), "limit", defaultQualityLimit, 1, maxQualityLimit)
+	if !ok {
+		return
+	}
+	offset, ok := intParam(w, q.Get("offset"), "offset", 0, 0, maxQualityWindow-limit)
+	if !ok {
+		return
+	}
+
+	results, err := h.os.CheckQuality(r.Context(), []string{rule}, limit, offset)
+	if err != nil {
+		h.logger.Error("Failed to check data quality", "rule", rule, "error", err)
+		respondBackendError(w, err, "Failed to check data quality")
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]any{
+		"rule":   rule,
+		"count":  results[0].Count,
+		"ids":    results[0].IDs,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
+
+// intParam parses the query parameter name from raw, returning def when it
+// is empty. A value that is not an integer from lo to hi gets a 400 naming
+// the parameter, and false.
+func intParam(w http.ResponseWriter, raw, name string, def, lo, hi int) (int, bool) {
+	if raw == "" {
+		return def, true
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil || v < lo || v > hi {
+		respondJSON(w, http.StatusBadRequest, map[string]any{
+			"error": fmt.Sprintf("%s must be an integer from %d to %d", name, lo, hi),
+			"param": name,
+		})
+		return 0, false
+	}
+	return v, true
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"search/internal/domain"
+	"search/internal/opensearch"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func newQualityRouter(t *testing.T, client port.SearchClient) func(path string) *httptest.ResponseRecorder {
+	t.Helper()
+	cfg := testRouterConfig()
+	cfg.AdminAPIKey = testAdminKey
+	router := NewRouter(client, testutil.NewLogger(t), cfg)
+	return func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("Authorization", "Bearer "+testAdminKey)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+}
+
+func TestDataQuality(t *testing.T) {
+	client := opensearch.NewMemoryClient()
+	for id := int64(1); id <= 15; id++ {
+		tutor := domain.Tutor{ID: id, Headline: "Math", Subjects: []string{"math"}, HourlyRate: 30}
+		if id%2 == 0 {
+			tutor.Subjects = nil
+		}
+		client.UpsertTutor(context.Background(), &tutor)
+	}
+	get := newQualityRouter(t, client)
+
+	rec := get("/admin/quality")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var overview struct {
+		Rules []port.QualityRuleResult `json:"rules"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&overview); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(overview.Rules) != len(port.QualityRules) {
+		t.Fatalf("expected every rule, got %+v", overview.Rules)
+	}
+	for i, r := range overview.Rules {
+		if r.Rule != port.QualityRules[i] {
+			t.Errorf("expected rule %s at %d, got %s", port.QualityRules[i], i, r.Rule)
+		}
+		if r.Rule == port.QualityMissingSubjects {
+			if r.Count != 7 || !slices.Equal(r.IDs, []int64{2, 4, 6, 8, 10, 12, 14}) {
+				t.Errorf("unexpected missing_subjects result %+v", r)
+			}
+		} else if r.Count != 0 || r.IDs == nil {
+			t.Errorf("expected %s to find nothing with an empty ID list, got %+v", r.Rule, r)
+		}
+	}
+
+	rec = get("/admin/quality?rule=missing_subjects&limit=3&offset=2")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	var page struct {
+		Rule   string  `json:"rule"`
+		Count  int64   `json:"count"`
+		IDs    []int64 `json:"ids"`
+		Limit  int     `json:"limit"`
+		Offset int     `json:"offset"`
+	}
+	if err := json.NewDecoder(rec.Body).Decode(&page); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if page.Rule != "missing_subjects" || page.Count != 7 || !slices.Equal(page.IDs, []int64{6, 8, 10}) ||
+		page.Limit != 3 || page.Offset != 2 {
+		t.Errorf("unexpected page %+v", page)
+	}
+}
+
+func TestDataQuality_SampleSize(t *testing.T) {
+	client := opensearch.NewMemoryClient()
+	for id := int64(1); id <= 25; id++ {
+		client.UpsertTutor(context.Background(), &domain.Tutor{ID: id, Headline: "Math"})
+	}
+	get := newQualityRouter(t, client)
+
+	var overview struct {
+		Rules []port.QualityRuleResult `json:"rules"`
+	}
+	json.NewDecoder(get("/admin/quality").Body).Decode(&overview)
+	for _, r := range overview.Rules {
+		if r.Rule == port.QualityMissingSubjects && (r.Count != 25 || len(r.IDs) != qualitySampleSize) {
+			t.Errorf("expected 25 hits with %d sample IDs, got %d with %d", qualitySampleSize, r.Count, len(r.IDs))
+		}
+	}
+
+	var page struct {
+		IDs []int64 `json:"ids"`
+	}
+	json.NewDecoder(get("/admin/quality?rule=missing_subjects").Body).Decode(&page)
+	if len(page.IDs) != 25 {
+		t.Errorf("expected the default limit to list all 25, got %d", len(page.IDs))
+	}
+}
+
+func TestDataQuality_BadRequests(t *testing.T) {
+	get := newQualityRouter(t, &mockSearchClient{})
+
+	tests := []struct {
+		path      string
+		wantParam string
+	}{
+		{"/admin/quality?rule=bogus", "rule"},
+		{"/admin/quality?rule=missing_subjects&limit=0", "limit"},
+		{"/admin/quality?rule=missing_subjects&limit=1001", "limit"},
+		{"/admin/quality?rule=missing_subjects&limit=x", "limit"},
+		{"/admin/quality?rule=missing_subjects&offset=-1", "offset"},
+		{"/admin/quality?rule=missing_subjects&limit=1000&offset=9001", "offset"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			rec := get(tt.path)
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+			}
+			var body map[string]any
+			json.NewDecoder(rec.Body).Decode(&body)
+			if body["param"] != tt.wantParam {
+				t.Errorf("expected param %s, got %v", tt.wantParam, body)
+			}
+		})
+	}
+}
+
+func TestDataQuality_BackendError(t *testing.T) {
+	get := newQualityRouter(t, &mockSearchClient{qualityErr: errors.New("connection refused")})
+
+	if rec := get("/admin/quality"); rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected 500, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestDataQuality_RequiresAdminKey(t *testing.T) {
+	cfg := testRouterConfig()
+	cfg.AdminAPIKey = testAdminKey
+	router := NewRouter(&mockSearchClient{}, testutil.NewLogger(t), cfg)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/admin/quality", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", rec.Code)
+	}
+}
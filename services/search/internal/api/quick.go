@@ -0,0 +1,45 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// Bounds on GET /search/quick, which runs on every keystroke.
+const (
+	quickSearchSize     = 5
+	maxQuickQueryLength = 100
+)
+
+// QuickSearch serves search-as-you-type: the first tutors matching q and
+// the subjects whose key starts with it, from a single backend request.
+// Subjects are labelled and ordered like GET /subjects.
+func (h *Handlers) QuickSearch(w http.ResponseWriter, r *http.Request) {
+	prefix := strings.TrimSpace(r.URL.Query().Get("q"))
+	if prefix == "" {
+		respondError(w, http.StatusBadRequest, "q is required")
+		return
+	}
+	if utf8.RuneCountInString(prefix) > maxQuickQueryLength {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("q must be at most %d characters", maxQuickQueryLength))
+		return
+	}
+
+	result, err := h.os.QuickSearch(r.Context(), prefix, quickSearchSize)
+	if err != nil {
+		h.logger.Error("Failed to run quick search", "q", prefix, "error", err)
+		respondBackendError(w, err, "Failed to search")
+		return
+	}
+	stripIndexMeta(r, result.Tutors)
+	lang := h.language(w, r)
+	h.localizeSubjects(result.Tutors, lang)
+
+	subjects := h.subjectFacets(result.Subjects, lang)
+	respondJSON(w, http.StatusOK, map[string]any{
+		"tutors":   result.Tutors,
+		"subjects": subjects[:min(quickSearchSize, len(subjects))],
+	})
+}
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"search/internal/domain"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func TestQuickSearch(t *testing.T) {
+	indexedAt := time.Now()
+	mock := &mockSearchClient{quickResult: port.QuickSearchResult{
+		Tutors:   []domain.Tutor{{ID: 1, FullName: "Marie Curie", IndexedAt: &indexedAt}},
+		Subjects: map[string]int{"marketing": 1, "math": 4, "machine-learning": 1},
+	}}
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+	rec := httptest.NewRecorder()
+	handlers.QuickSearch(rec, httptest.NewRequest("GET", "/search/quick?q=+ma+", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if mock.quickPrefix != "ma" || mock.quickSize != quickSearchSize {
+		t.Errorf("expected prefix ma and size %d, got %q and %d", quickSearchSize, mock.quickPrefix, mock.quickSize)
+	}
+	var resp struct {
+		Tutors   []domain.Tutor `json:"tutors"`
+		Subjects []subjectFacet `json:"subjects"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Tutors) != 1 || resp.Tutors[0].ID != 1 || resp.Tutors[0].IndexedAt != nil {
+		t.Errorf("expected the tutor without index metadata, got %+v", resp.Tutors)
+	}
+	want := []subjectFacet{
+		{Key: "math", Label: "Mathematics", Count: 4},
+		{Key: "machine-learning", Label: "machine-learning", Count: 1},
+		{Key: "marketing", Label: "marketing", Count: 1},
+	}
+	if !slices.Equal(resp.Subjects, want) {
+		t.Errorf("expected %+v, got %+v", want, resp.Subjects)
+	}
+}
+
+func TestQuickSearch_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{"missing q", "/search/quick"},
+		{"blank q", "/search/quick?q=+++"},
+		{"q too long", "/search/quick?q=" + strings.Repeat("a", maxQuickQueryLength+1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{}
+			handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+			rec := httptest.NewRecorder()
+			handlers.QuickSearch(rec, httptest.NewRequest("GET", tt.url, nil))
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+			}
+			if mock.quickPrefix != "" {
+				t.Errorf("expected no backend call, got prefix %q", mock.quickPrefix)
+			}
+		})
+	}
+}
+
+func TestQuickSearch_BackendError(t *testing.T) {
+	mock := &mockSearchClient{quickErr: errors.New("cluster unavailable")}
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+	rec := httptest.NewRecorder()
+	handlers.QuickSearch(rec, httptest.NewRequest("GET", "/search/quick?q=ma", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"search/internal/port"
+)
+
+const (
+	// maxRawQuerySize caps "size" in a raw query.
+	maxRawQuerySize = 100
+	// maxRawQueryBody caps the raw query request body in bytes.
+	maxRawQueryBody = 64 << 10
+)
+
+// rawQueryKeys are the top-level keys a raw query may use.
+var rawQueryKeys = map[string]bool{
+	"query":        true,
+	"aggs":         true,
+	"aggregations": true,
+	"size":         true,
+	"from":         true,
+	"sort":         true,
+}
+
+// RawQuery runs a caller-supplied OpenSearch search body against the tutors
+// index and returns OpenSearch's response unmodified. Only read-only
+// searches pass validateRawQuery.
+func (h *Handlers) RawQuery(w http.ResponseWriter, r *http.Request) {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRawQueryBody))
+	dec.UseNumber()
+
+	var body map[string]any
+	if err := dec.Decode(&body); err != nil || body == nil {
+		respondError(w, http.StatusBadRequest, "Request body must be a JSON object")
+		return
+	}
+	if err := validateRawQuery(body); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Send the validated value rather than the raw bytes so duplicate keys
+	// cannot smuggle anything past validation.
+	query, err := json.Marshal(body)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid query")
+		return
+	}
+
+	result, err := h.os.RawSearch(r.Context(), query)
+	switch {
+	case errors.Is(err, port.ErrInvalidQuery):
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, port.ErrUnsupported):
+		respondError(w, http.StatusNotImplemented, "Raw queries are not supported by this search backend")
+		return
+	case err != nil:
+		h.logger.Error("Failed to run raw query", "error", err)
+		respondBackendError(w, err, "Failed to run query")
+		return
+	}
+
+	h.logger.Info("Raw query executed", "query", string(query))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(bytes.TrimSpace(result))
+}
+
+// validateRawQuery allows only query, aggs, size, from and sort at the top
+// level, caps size, and rejects scripts anywhere in the body.
+func validateRawQuery(body map[string]any) error {
+	for key := range body {
+		if !rawQueryKeys[key] {
+			return fmt.Errorf("%q is not allowed; use only query, aggs, size, from and sort", key)
+		}
+	}
+	if err := validateCount(body, "size", maxRawQuerySize); err != nil {
+		return err
+	}
+	if err := validateCount(body, "from", -1); err != nil {
+		return err
+	}
+	return rejectScripts(body, "")
+}
+
+// validateCount checks that key, if present, is a non-negative integer no
+// greater than limit (when limit >= 0).
+func validateCount(body map[string]any, key string, limit int64) error {
+	raw, ok := body[key]
+	if !ok {
+		return nil
+	}
+	num, ok := raw.(json.Number)
+	if !ok {
+		return fmt.Errorf("%s must be an integer", key)
+	}
+	v, err := num.Int64()
+	if err != nil || v < 0 {
+		return fmt.Errorf("%s must be a non-negative integer", key)
+	}
+	if limit >= 0 && v > limit {
+		return fmt.Errorf("%s must be at most %d", key, limit)
+	}
+	return nil
+}
+
+// rejectScripts walks v and fails on any key that names a script, which
+// covers script queries, script_score, script_fields, scripted_metric and
+// bucket_script alike.
+func rejectScripts(v any, path string) error {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, child := range v {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			if strings.Contains(strings.ToLower(key), "script") {
+				return fmt.Errorf("scripts are not allowed (%s)", childPath)
+			}
+			if err := rejectScripts(child, childPath); err != nil {
+				return err
+			}
+		}
+	case []any:
+		for i, child := range v {
+			if err := rejectScripts(child, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
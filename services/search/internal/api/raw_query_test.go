@@ -0,0 +1,158 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func TestRawQuery_Allowed(t *testing.T) {
+	mock := &mockSearchClient{}
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+	body := `{
+		"size": 0,
+		"query": {"bool": {"filter": [{"term": {"is_verified": true}}]}},
+		"aggs": {"by_subject": {"terms": {"field": "subjects", "size": 50}, "aggs": {"avg_rate": {"avg": {"field": "hourly_rate"}}}}},
+		"sort": [{"rating": "desc"}]
+	}`
+	rec := httptest.NewRecorder()
+	handlers.RawQuery(rec, httptest.NewRequest("POST", "/admin/query", strings.NewReader(body)))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if rec.Body.String() != `{"hits":{"total":{"value":0},"hits":[]}}` {
+		t.Errorf("expected the raw OpenSearch response, got %s", rec.Body.String())
+	}
+
+	var sent map[string]any
+	if err := json.Unmarshal(mock.rawBody, &sent); err != nil {
+		t.Fatalf("sent body is not JSON: %v", err)
+	}
+	for _, key := range []string{"size", "query", "aggs", "sort"} {
+		if _, ok := sent[key]; !ok {
+			t.Errorf("expected %s to be forwarded, got %s", key, mock.rawBody)
+		}
+	}
+}
+
+func TestRawQuery_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"not json", `size=10`, "JSON object"},
+		{"array", `[{"query": {}}]`, "JSON object"},
+		{"null", `null`, "JSON object"},
+		{"script query", `{"query": {"script": {"script": "doc['rating'].value > 4"}}}`, "scripts are not allowed (query.script)"},
+		{"script_score", `{"query": {"function_score": {"functions": [{"script_score": {"script": {"source": "1"}}}]}}}`, "scripts are not allowed (query.function_score.functions[0].script_score)"},
+		{"script in terms agg", `{"aggs": {"x": {"terms": {"script": {"source": "1"}}}}}`, "scripts are not allowed (aggs.x.terms.script)"},
+		{"scripted_metric", `{"aggs": {"x": {"scripted_metric": {"map_script": "state.x = 1"}}}}`, "scripts are not allowed"},
+		{"bucket_script", `{"aggs": {"x": {"bucket_script": {"buckets_path": {}}}}}`, "scripts are not allowed"},
+		{"script sort", `{"sort": [{"_script": {"type": "number"}}]}`, "scripts are not allowed"},
+		{"script_fields", `{"script_fields": {"x": {"script": "1"}}}`, `"script_fields" is not allowed`},
+		{"runtime_mappings", `{"runtime_mappings": {}}`, `"runtime_mappings" is not allowed`},
+		{"source filtering", `{"_source": ["bio"]}`, `"_source" is not allowed`},
+		{"update document", `{"doc": {"rating": 5}}`, `"doc" is not allowed`},
+		{"upsert", `{"upsert": {"id": 1}}`, `"upsert" is not allowed`},
+		{"delete by query", `{"query": {"match_all": {}}, "conflicts": "proceed"}`, `"conflicts" is not allowed`},
+		{"size too large", fmt.Sprintf(`{"size": %d}`, maxRawQuerySize+1), "size must be at most 100"},
+		{"negative size", `{"size": -1}`, "size must be a non-negative integer"},
+		{"fractional size", `{"size": 1.5}`, "size must be a non-negative integer"},
+		{"string size", `{"size": "10"}`, "size must be an integer"},
+		{"negative from", `{"from": -5}`, "from must be a non-negative integer"},
+		{"body too large", `{"query": {"terms": {"slug": ["` + strings.Repeat("a", maxRawQueryBody) + `"]}}}`, "JSON object"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{}
+			handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+			rec := httptest.NewRecorder()
+			handlers.RawQuery(rec, httptest.NewRequest("POST", "/admin/query", strings.NewReader(tt.body)))
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+			}
+			var response map[string]string
+			json.Unmarshal(rec.Body.Bytes(), &response)
+			if !strings.Contains(response["error"], tt.wantErr) {
+				t.Errorf("expected error containing %q, got %s", tt.wantErr, rec.Body.String())
+			}
+			if mock.rawBody != nil {
+				t.Errorf("expected nothing to reach OpenSearch, got %s", mock.rawBody)
+			}
+		})
+	}
+}
+
+func TestRawQuery_BackendErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"invalid query", fmt.Errorf("%w: unknown field [nope]", port.ErrInvalidQuery), http.StatusBadRequest},
+		{"unsupported backend", port.ErrUnsupported, http.StatusNotImplemented},
+		{"cluster down", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handlers := NewHandlers(&mockSearchClient{rawErr: tt.err}, testutil.NewLogger(t))
+
+			rec := httptest.NewRecorder()
+			handlers.RawQuery(rec, httptest.NewRequest("POST", "/admin/query", strings.NewReader(`{"query": {"nope": {}}}`)))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestRouter_RawQueryGating(t *testing.T) {
+	logger := testutil.NewLogger(t)
+
+	tests := []struct {
+		name       string
+		enabled    bool
+		authHeader string
+		wantStatus int
+	}{
+		{"disabled", false, "Bearer secret", http.StatusNotFound},
+		{"missing key", true, "", http.StatusUnauthorized},
+		{"wrong key", true, "Bearer wrong", http.StatusUnauthorized},
+		{"enabled with key", true, "Bearer secret", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testRouterConfig()
+			cfg.AdminAPIKey = "secret"
+			cfg.RawQuery = tt.enabled
+			router := NewRouter(&mockSearchClient{}, logger, cfg)
+
+			req := httptest.NewRequest("POST", "/admin/query", strings.NewReader(`{"size": 0}`))
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"search/internal/audit"
+	"search/internal/port"
+)
+
+const fixDeleteExtra = "delete_extra"
+
+type reconcileRequest struct {
+	IDs []int64 `json:"ids"`
+}
+
+type reconcileResponse struct {
+	MissingInIndex []int64        `json:"missing_in_index"`
+	ExtraInIndex   []int64        `json:"extra_in_index"`
+	Counts         map[string]int `json:"counts"`
+}
+
+// Reconcile diffs the live tutor IDs sent by Django against the IDs present
+// in the index. The body is either {"ids": [...]} or, with Content-Type
+// application/x-ndjson, one ID per line. With fix=delete_extra and
+// confirm=true, documents that exist only in the index are deleted; an empty
+// ID list is refused rather than emptying the index.
+func (h *Handlers) Reconcile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	q := r.URL.Query()
+
+	fix := q.Get("fix")
+	if fix == "" {
+		// A plain diff changes nothing and is not audited.
+		audit.Skip(ctx)
+	}
+	if fix != "" && fix != fixDeleteExtra {
+		respondError(w, http.StatusBadRequest, "Unsupported fix, expected fix=delete_extra")
+		return
+	}
+	if fix == fixDeleteExtra && q.Get("confirm") != "true" {
+		respondError(w, http.StatusBadRequest, "fix=delete_extra requires confirm=true")
+		return
+	}
+
+	live, err := decodeIDList(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	// Against an empty list every indexed document counts as extra.
+	if fix == fixDeleteExtra && len(live) == 0 {
+		respondError(w, http.StatusBadRequest, "fix=delete_extra requires at least one live ID")
+		return
+	}
+
+	indexed, err := h.os.IndexedTutorIDs(ctx)
+	if err != nil {
+		h.logger.Error("Failed to list indexed tutor IDs", "error", err)
+		respondBackendError(w, err, "Failed to read index")
+		return
+	}
+
+	missing, extra := diffIDs(live, indexed)
+
+	resp := reconcileResponse{
+		MissingInIndex: missing,
+		ExtraInIndex:   extra,
+		Counts: map[string]int{
+			"live":             len(live),
+			"indexed":          len(indexed),
+			"missing_in_index": len(missing),
+			"extra_in_index":   len(extra),
+		},
+	}
+
+	if fix == fixDeleteExtra {
+		deleted := 0
+		for _, id := range extra {
+			// A document that vanished since the scan is already reconciled.
+			if err := h.os.DeleteTutor(ctx, id); err != nil && !errors.Is(err, port.ErrNotFound) {
+				h.logger.Error("Failed to delete extra tutor", "id", id, "error", err)
+				continue
+			}
+			deleted++
+		}
+		resp.Counts["deleted"] = deleted
+		audit.SetCount(ctx, deleted)
+		h.logger.Info("Deleted extra tutors from index", "deleted", deleted, "extra", len(extra))
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// decodeIDList reads tutor IDs from a JSON object or an NDJSON stream.
+func decodeIDList(r *http.Request) ([]int64, error) {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "application/x-ndjson" {
+		var req reconcileRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, err
+		}
+		return req.IDs, nil
+	}
+
+	var ids []int64
+	scanner := bufio.NewScanner(r.Body)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid ID %q", line, text)
+		}
+		ids = append(ids, id)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// diffIDs returns the sorted IDs present only in live and only in indexed.
+func diffIDs(live, indexed []int64) (missing, extra []int64) {
+	liveSet := make(map[int64]struct{}, len(live))
+	for _, id := range live {
+		liveSet[id] = struct{}{}
+	}
+	indexedSet := make(map[int64]struct{}, len(indexed))
+	for _, id := range indexed {
+		indexedSet[id] = struct{}{}
+	}
+
+	missing = []int64{}
+	for id := range liveSet {
+		if _, ok := indexedSet[id]; !ok {
+			missing = append(missing, id)
+		}
+	}
+	extra = []int64{}
+	for id := range indexedSet {
+		if _, ok := liveSet[id]; !ok {
+			extra = append(extra, id)
+		}
+	}
+
+	slices.Sort(missing)
+	slices.Sort(extra)
+	return missing, extra
+}
@@ -0,0 +1,212 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"search/internal/testutil"
+)
+
+func TestDiffIDs(t *testing.T) {
+	tests := []struct {
+		name        string
+		live        []int64
+		indexed     []int64
+		wantMissing []int64
+		wantExtra   []int64
+	}{
+		{"identical", []int64{1, 2, 3}, []int64{3, 2, 1}, []int64{}, []int64{}},
+		{"overlapping", []int64{1, 2, 3, 4}, []int64{3, 4, 5, 6}, []int64{1, 2}, []int64{5, 6}},
+		{"disjoint", []int64{1, 2}, []int64{7, 8}, []int64{1, 2}, []int64{7, 8}},
+		{"empty index", []int64{2, 1}, nil, []int64{1, 2}, []int64{}},
+		{"no live tutors", nil, []int64{9}, []int64{}, []int64{9}},
+		{"duplicates collapsed", []int64{1, 1, 2}, []int64{2, 3, 3}, []int64{1}, []int64{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			missing, extra := diffIDs(tt.live, tt.indexed)
+
+			if !slices.Equal(missing, tt.wantMissing) {
+				t.Errorf("expected missing %v, got %v", tt.wantMissing, missing)
+			}
+			if !slices.Equal(extra, tt.wantExtra) {
+				t.Errorf("expected extra %v, got %v", tt.wantExtra, extra)
+			}
+		})
+	}
+}
+
+func TestReconcile_Report(t *testing.T) {
+	mock := &mockSearchClient{indexedIDs: []int64{2, 3, 4}}
+	logger := testutil.NewLogger(t)
+	handlers := NewHandlers(mock, logger)
+
+	req := httptest.NewRequest("POST", "/admin/reconcile", strings.NewReader(`{"ids": [1, 2, 3]}`))
+	rec := httptest.NewRecorder()
+
+	handlers.Reconcile(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+
+	var response reconcileResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+
+	if !slices.Equal(response.MissingInIndex, []int64{1}) {
+		t.Errorf("expected missing [1], got %v", response.MissingInIndex)
+	}
+	if !slices.Equal(response.ExtraInIndex, []int64{4}) {
+		t.Errorf("expected extra [4], got %v", response.ExtraInIndex)
+	}
+	if response.Counts["live"] != 3 || response.Counts["indexed"] != 3 {
+		t.Errorf("unexpected counts %v", response.Counts)
+	}
+	if _, ok := response.Counts["deleted"]; ok {
+		t.Error("report-only run should not include a deleted count")
+	}
+	if len(mock.deletedIDs) != 0 {
+		t.Errorf("report-only run must not delete, deleted %v", mock.deletedIDs)
+	}
+}
+
+func TestReconcile_NDJSON(t *testing.T) {
+	mock := &mockSearchClient{indexedIDs: []int64{1, 2}}
+	logger := testutil.NewLogger(t)
+	handlers := NewHandlers(mock, logger)
+
+	req := httptest.NewRequest("POST", "/admin/reconcile", strings.NewReader("1\n2\n\n5\n"))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	rec := httptest.NewRecorder()
+
+	handlers.Reconcile(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+
+	var response reconcileResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+
+	if !slices.Equal(response.MissingInIndex, []int64{5}) {
+		t.Errorf("expected missing [5], got %v", response.MissingInIndex)
+	}
+	if len(response.ExtraInIndex) != 0 {
+		t.Errorf("expected no extras, got %v", response.ExtraInIndex)
+	}
+}
+
+func TestReconcile_DeleteExtra(t *testing.T) {
+	mock := &mockSearchClient{indexedIDs: []int64{1, 7, 8}}
+	logger := testutil.NewLogger(t)
+	handlers := NewHandlers(mock, logger)
+
+	req := httptest.NewRequest("POST", "/admin/reconcile?fix=delete_extra&confirm=true", bytes.NewReader([]byte(`{"ids": [1]}`)))
+	rec := httptest.NewRecorder()
+
+	handlers.Reconcile(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if !slices.Equal(mock.deletedIDs, []int64{7, 8}) {
+		t.Errorf("expected extras [7 8] deleted, got %v", mock.deletedIDs)
+	}
+
+	var response reconcileResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+
+	if response.Counts["deleted"] != 2 {
+		t.Errorf("expected deleted count 2, got %d", response.Counts["deleted"])
+	}
+}
+
+func TestReconcile_Validation(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		body string
+	}{
+		{"fix without confirm", "/admin/reconcile?fix=delete_extra", `{"ids": [1]}`},
+		{"fix with wrong confirm", "/admin/reconcile?fix=delete_extra&confirm=yes", `{"ids": [1]}`},
+		{"unknown fix", "/admin/reconcile?fix=add_missing&confirm=true", `{"ids": [1]}`},
+		{"invalid body", "/admin/reconcile", `not json`},
+		{"fix with no ids", "/admin/reconcile?fix=delete_extra&confirm=true", `{"ids": []}`},
+		{"fix without ids", "/admin/reconcile?fix=delete_extra&confirm=true", `{}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{indexedIDs: []int64{1, 2}}
+			logger := testutil.NewLogger(t)
+			handlers := NewHandlers(mock, logger)
+
+			req := httptest.NewRequest("POST", tt.url, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			handlers.Reconcile(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+			}
+			if len(mock.deletedIDs) != 0 {
+				t.Errorf("expected no deletes, got %v", mock.deletedIDs)
+			}
+		})
+	}
+}
+
+func TestReconcile_InvalidNDJSONLine(t *testing.T) {
+	mock := &mockSearchClient{}
+	logger := testutil.NewLogger(t)
+	handlers := NewHandlers(mock, logger)
+
+	req := httptest.NewRequest("POST", "/admin/reconcile", strings.NewReader("1\nabc\n"))
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	rec := httptest.NewRecorder()
+
+	handlers.Reconcile(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestReconcile_IndexError(t *testing.T) {
+	mock := &mockSearchClient{indexedErr: errors.New("scroll failed")}
+	logger := testutil.NewLogger(t)
+	handlers := NewHandlers(mock, logger)
+
+	req := httptest.NewRequest("POST", "/admin/reconcile", strings.NewReader(`{"ids": [1]}`))
+	rec := httptest.NewRecorder()
+
+	handlers.Reconcile(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+}
+
+func TestReconcile_RequiresAdminKey(t *testing.T) {
+	mock := &mockSearchClient{indexedIDs: []int64{1, 2}}
+	cfg := testRouterConfig()
+	cfg.AdminAPIKey = testAdminKey
+	router := NewRouter(mock, testutil.NewLogger(t), cfg)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("POST", "/admin/reconcile?fix=delete_extra&confirm=true", strings.NewReader(`{"ids": []}`)))
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, rec.Code)
+	}
+	if len(mock.deletedIDs) != 0 {
+		t.Errorf("expected no deletes, got %v", mock.deletedIDs)
+	}
+}
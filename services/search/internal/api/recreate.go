@@ -0,0 +1,72 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"search/internal/audit"
+	"search/internal/port"
+)
+
+// ConsumerPauser is implemented by the Kafka consumer.
+type ConsumerPauser interface {
+	Pause()
+	Resume()
+}
+
+type recreateRequest struct {
+	Confirm       string `json:"confirm"`
+	PauseConsumer bool   `json:"pause_consumer"`
+}
+
+type recreateResponse struct {
+	Status         string `json:"status"`
+	OldCount       int64  `json:"old_count"`
+	NewCount       int64  `json:"new_count"`
+	ConsumerPaused bool   `json:"consumer_paused"`
+}
+
+// RecreateIndex drops the tenant's index and creates it empty from the current
+// mapping. Callers resync from Django afterwards.
+func (h *Handlers) RecreateIndex(w http.ResponseWriter, r *http.Request) {
+	var req recreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	// The index name must be sent as "confirm", so a tenant mix-up cannot
+	// wipe the wrong marketplace.
+	index := port.IndexFor(r.Context())
+	if req.Confirm != index {
+		respondError(w, http.StatusBadRequest, `Recreating the index deletes every document; send "confirm": "`+index+`"`)
+		return
+	}
+
+	paused := req.PauseConsumer && h.consumer != nil
+	if paused {
+		h.consumer.Pause()
+		defer h.consumer.Resume()
+	}
+
+	result, err := h.os.RecreateIndex(r.Context())
+	if err != nil {
+		h.logger.Error("Failed to recreate index", "error", err)
+		respondBackendError(w, err, "Failed to recreate index")
+		return
+	}
+
+	audit.SetCount(r.Context(), int(result.OldCount))
+	h.logger.Warn("Index recreated",
+		"index", index,
+		"old_count", result.OldCount,
+		"new_count", result.NewCount,
+		"consumer_paused", paused,
+	)
+
+	respondJSON(w, http.StatusOK, recreateResponse{
+		Status:         "recreated",
+		OldCount:       result.OldCount,
+		NewCount:       result.NewCount,
+		ConsumerPaused: paused,
+	})
+}
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"search/internal/tenant"
+	"search/internal/testutil"
+)
+
+type fakePauser struct {
+	calls []string
+}
+
+func (p *fakePauser) Pause()  { p.calls = append(p.calls, "pause") }
+func (p *fakePauser) Resume() { p.calls = append(p.calls, "resume") }
+
+func TestRecreateIndex_RequiresConfirmation(t *testing.T) {
+	logger := testutil.NewLogger(t)
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"missing confirm", `{}`},
+		{"wrong confirm", `{"confirm": "yes"}`},
+		{"confirm is case sensitive", `{"confirm": "Tutors"}`},
+		{"invalid body", `confirm=tutors`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{}
+			handlers := NewHandlers(mock, logger)
+
+			req := httptest.NewRequest("POST", "/admin/index/recreate", bytes.NewReader([]byte(tt.body)))
+			rec := httptest.NewRecorder()
+
+			handlers.RecreateIndex(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+			}
+			if mock.recreated {
+				t.Error("expected index not to be recreated")
+			}
+		})
+	}
+}
+
+func TestRecreateIndex_Success(t *testing.T) {
+	mock := &mockSearchClient{indexedIDs: []int64{1, 2, 3}}
+	pauser := &fakePauser{}
+	logger := testutil.NewLogger(t)
+	handlers := NewHandlers(mock, logger, WithConsumerPauser(pauser))
+
+	body := []byte(`{"confirm": "tutors", "pause_consumer": true}`)
+	req := httptest.NewRequest("POST", "/admin/index/recreate", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	handlers.RecreateIndex(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if !mock.recreated {
+		t.Error("expected index to be recreated")
+	}
+
+	var response recreateResponse
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	if response.OldCount != 3 || response.NewCount != 0 || !response.ConsumerPaused {
+		t.Errorf("unexpected response %+v", response)
+	}
+	if len(pauser.calls) != 2 || pauser.calls[0] != "pause" || pauser.calls[1] != "resume" {
+		t.Errorf("expected pause then resume, got %v", pauser.calls)
+	}
+}
+
+func TestRecreateIndex_WithoutPause(t *testing.T) {
+	mock := &mockSearchClient{}
+	pauser := &fakePauser{}
+	logger := testutil.NewLogger(t)
+	handlers := NewHandlers(mock, logger, WithConsumerPauser(pauser))
+
+	req := httptest.NewRequest("POST", "/admin/index/recreate", bytes.NewReader([]byte(`{"confirm": "tutors"}`)))
+	rec := httptest.NewRecorder()
+
+	handlers.RecreateIndex(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if len(pauser.calls) != 0 {
+		t.Errorf("expected consumer not to be paused, got %v", pauser.calls)
+	}
+}
+
+func TestRecreateIndex_ErrorResumesConsumer(t *testing.T) {
+	mock := &mockSearchClient{recreateErr: errors.New("cluster unavailable")}
+	pauser := &fakePauser{}
+	logger := testutil.NewLogger(t)
+	handlers := NewHandlers(mock, logger, WithConsumerPauser(pauser))
+
+	body := []byte(`{"confirm": "tutors", "pause_consumer": true}`)
+	req := httptest.NewRequest("POST", "/admin/index/recreate", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+
+	handlers.RecreateIndex(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+	if len(pauser.calls) != 2 || pauser.calls[1] != "resume" {
+		t.Errorf("expected consumer to be resumed, got %v", pauser.calls)
+	}
+}
+
+func TestRouter_RecreateIndexRequiresAdminKey(t *testing.T) {
+	logger := testutil.NewLogger(t)
+	body := `{"confirm": "tutors"}`
+
+	tests := []struct {
+		name       string
+		apiKey     string
+		authHeader string
+		wantStatus int
+	}{
+		{"no key configured", "", "Bearer anything", http.StatusForbidden},
+		{"missing header", "secret", "", http.StatusUnauthorized},
+		{"wrong key", "secret", "Bearer wrong", http.StatusUnauthorized},
+		{"wrong scheme", "secret", "Basic secret", http.StatusUnauthorized},
+		{"valid key", "secret", "Bearer secret", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{}
+			cfg := testRouterConfig()
+			cfg.AdminAPIKey = tt.apiKey
+			router := NewRouter(mock, logger, cfg)
+
+			req := httptest.NewRequest("POST", "/admin/index/recreate", bytes.NewReader([]byte(body)))
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if mock.recreated != (tt.wantStatus == http.StatusOK) {
+				t.Errorf("unexpected recreated=%v", mock.recreated)
+			}
+		})
+	}
+}
+
+func TestRecreateIndex_ConfirmsTenantIndex(t *testing.T) {
+	logger := testutil.NewLogger(t)
+	ctx := tenant.NewContext(context.Background(), tenant.Tenant{Name: "de", Index: "tutors-de"})
+
+	tests := []struct {
+		confirm    string
+		wantStatus int
+	}{
+		{"tutors", http.StatusBadRequest},
+		{"tutors-de", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.confirm, func(t *testing.T) {
+			mock := &mockSearchClient{}
+			handlers := NewHandlers(mock, logger)
+
+			body := `{"confirm": "` + tt.confirm + `"}`
+			req := httptest.NewRequest("POST", "/admin/index/recreate", bytes.NewReader([]byte(body))).WithContext(ctx)
+			rec := httptest.NewRecorder()
+
+			handlers.RecreateIndex(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if mock.recreated != (tt.wantStatus == http.StatusOK) {
+				t.Errorf("expected recreated=%v", tt.wantStatus == http.StatusOK)
+			}
+		})
+	}
+}
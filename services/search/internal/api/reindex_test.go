@@ -0,0 +1,117 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"search/internal/reindex"
+	"search/internal/testutil"
+)
+
+type fakeReindexJob struct {
+	startErr error
+	started  []string
+	ctxErr   error
+	last     *reindex.Run
+	running  bool
+}
+
+func (f *fakeReindexJob) Start(ctx context.Context, trigger string) error {
+	if f.startErr != nil {
+		return f.startErr
+	}
+	f.started = append(f.started, trigger)
+	f.ctxErr = ctx.Err()
+	return nil
+}
+
+func (f *fakeReindexJob) Status() (*reindex.Run, bool) {
+	return f.last, f.running
+}
+
+func TestReindex_StartsJob(t *testing.T) {
+	job := &fakeReindexJob{}
+	handlers := NewHandlers(&mockSearchClient{}, testutil.NewLogger(t), WithReindexJob(job))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest("POST", "/admin/reindex", nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	handlers.Reindex(rec, req)
+	cancel()
+
+	if rec.Code != http.StatusAccepted {
+		t.Errorf("expected status %d, got %d", http.StatusAccepted, rec.Code)
+	}
+	if len(job.started) != 1 || job.started[0] != reindex.TriggerManual {
+		t.Errorf("expected one manual run, got %v", job.started)
+	}
+	if job.ctxErr != nil {
+		t.Errorf("expected the run context to outlive the request, got %v", job.ctxErr)
+	}
+}
+
+func TestReindex_AlreadyRunning(t *testing.T) {
+	job := &fakeReindexJob{startErr: reindex.ErrRunning}
+	handlers := NewHandlers(&mockSearchClient{}, testutil.NewLogger(t), WithReindexJob(job))
+
+	rec := httptest.NewRecorder()
+	handlers.Reindex(rec, httptest.NewRequest("POST", "/admin/reindex", nil))
+
+	if rec.Code != http.StatusConflict {
+		t.Errorf("expected status %d, got %d", http.StatusConflict, rec.Code)
+	}
+}
+
+func TestReindexStatus(t *testing.T) {
+	finished := time.Date(2026, 3, 10, 3, 31, 0, 0, time.UTC)
+	job := &fakeReindexJob{
+		running: true,
+		last:    &reindex.Run{Trigger: reindex.TriggerSchedule, Status: reindex.StatusSucceeded, FinishedAt: finished, Synced: 42},
+	}
+	handlers := NewHandlers(&mockSearchClient{}, testutil.NewLogger(t), WithReindexJob(job))
+
+	rec := httptest.NewRecorder()
+	handlers.ReindexStatus(rec, httptest.NewRequest("GET", "/admin/reindex/last", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	var response struct {
+		Running bool         `json:"running"`
+		LastRun *reindex.Run `json:"last_run"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	if !response.Running {
+		t.Error("expected running=true")
+	}
+	if response.LastRun == nil || response.LastRun.Synced != 42 || !response.LastRun.FinishedAt.Equal(finished) {
+		t.Errorf("unexpected last run %+v", response.LastRun)
+	}
+}
+
+func TestReindexStatus_NoRunYet(t *testing.T) {
+	handlers := NewHandlers(&mockSearchClient{}, testutil.NewLogger(t), WithReindexJob(&fakeReindexJob{}))
+
+	rec := httptest.NewRecorder()
+	handlers.ReindexStatus(rec, httptest.NewRequest("GET", "/admin/reindex/last", nil))
+
+	if body := rec.Body.String(); body != "{\"last_run\":null,\"running\":false}\n" {
+		t.Errorf("unexpected body %s", body)
+	}
+}
+
+func TestReindexStatus_NotConfigured(t *testing.T) {
+	cfg := testRouterConfig()
+	cfg.AdminAPIKey = testAdminKey
+	router := NewRouter(&mockSearchClient{}, testutil.NewLogger(t), cfg)
+
+	rec := serve(router, "GET", "/admin/reindex/last", "", "Bearer "+testAdminKey)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
@@ -0,0 +1,54 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// relatedSubjectsSize is how many co-taught subjects GET
+// /subjects/{subject}/related suggests.
+const relatedSubjectsSize = 10
+
+type relatedSubjectsResponse struct {
+	Subject string `json:"subject"`
+	Label   string `json:"label"`
+	// Total is how many tutors teach the subject.
+	Total   int            `json:"total"`
+	Related []subjectFacet `json:"related"`
+}
+
+// RelatedSubjects suggests the subjects most often taught by the tutors
+// teaching a subject ("people also filter by"). The subject goes through
+// the catalog like a search filter. 404 when nobody teaches it.
+func (h *Handlers) RelatedSubjects(w http.ResponseWriter, r *http.Request) {
+	raw := r.PathValue("subject")
+	if strings.TrimSpace(raw) == "" || utf8.RuneCountInString(raw) > DefaultMaxTextLength {
+		respondError(w, http.StatusBadRequest, "Invalid subject")
+		return
+	}
+	subject := h.subjects.Key(raw)
+
+	result, err := h.os.RelatedSubjects(r.Context(), subject, relatedSubjectsSize)
+	if err != nil {
+		h.logger.Error("Failed to aggregate related subjects", "subject", subject, "error", err)
+		respondBackendError(w, err, "Failed to load related subjects")
+		return
+	}
+	if result.Total == 0 {
+		respondError(w, http.StatusNotFound, "No tutors teach this subject")
+		return
+	}
+
+	lang := h.language(w, r)
+	resp := relatedSubjectsResponse{
+		Subject: subject,
+		Label:   h.subjectLabel(subject, lang),
+		Total:   result.Total,
+		Related: make([]subjectFacet, len(result.Subjects)),
+	}
+	for i, s := range result.Subjects {
+		resp.Related[i] = subjectFacet{Key: s.Key, Label: h.subjectLabel(s.Key, lang), Count: s.Count}
+	}
+	respondJSON(w, http.StatusOK, resp)
+}
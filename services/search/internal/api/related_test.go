@@ -0,0 +1,90 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func TestRelatedSubjects(t *testing.T) {
+	mock := &mockSearchClient{related: port.RelatedSubjects{
+		Total:    12,
+		Subjects: []port.SubjectCount{{Key: "physics", Count: 7}, {Key: "quantum-knitting", Count: 2}},
+	}}
+	router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/subjects/Maths/related", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if mock.relatedSubject != "math" || mock.relatedSize != relatedSubjectsSize {
+		t.Errorf("expected the canonical subject and the top %d, got %q, %d", relatedSubjectsSize, mock.relatedSubject, mock.relatedSize)
+	}
+	var resp relatedSubjectsResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if resp.Subject != "math" || resp.Label != "Mathematics" || resp.Total != 12 {
+		t.Errorf("unexpected response %s", rec.Body.String())
+	}
+	want := []subjectFacet{
+		{Key: "physics", Label: "Physics", Count: 7},
+		{Key: "quantum-knitting", Label: "quantum-knitting", Count: 2},
+	}
+	if len(resp.Related) != len(want) || resp.Related[0] != want[0] || resp.Related[1] != want[1] {
+		t.Errorf("expected %+v in order, got %+v", want, resp.Related)
+	}
+}
+
+func TestRelatedSubjects_NothingCoTaught(t *testing.T) {
+	mock := &mockSearchClient{related: port.RelatedSubjects{Total: 1, Subjects: []port.SubjectCount{}}}
+	router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/subjects/physics/related", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), `"related":[]`) {
+		t.Errorf("expected an empty list, got %s", rec.Body.String())
+	}
+}
+
+func TestRelatedSubjects_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		related    port.RelatedSubjects
+		err        error
+		wantStatus int
+	}{
+		{"unknown subject", "/subjects/astrology/related", port.RelatedSubjects{}, nil, http.StatusNotFound},
+		{"subject too long", "/subjects/" + strings.Repeat("a", DefaultMaxTextLength+1) + "/related", port.RelatedSubjects{Total: 1}, nil, http.StatusBadRequest},
+		{"blank subject", "/subjects/%20/related", port.RelatedSubjects{Total: 1}, nil, http.StatusBadRequest},
+		{"backend overloaded", "/subjects/math/related", port.RelatedSubjects{}, port.ErrOverloaded, http.StatusServiceUnavailable},
+		{"backend failure", "/subjects/math/related", port.RelatedSubjects{}, errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{related: tt.related, relatedErr: tt.err}
+			router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest("GET", tt.path, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+		})
+	}
+}
@@ -3,29 +3,293 @@ package api
 import (
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
-	"search/internal/opensearch"
+	"search/internal/activity"
+	"search/internal/audit"
+	"search/internal/auth"
+	"search/internal/domain"
+	"search/internal/experiment"
+	"search/internal/feedback"
+	"search/internal/outbox"
+	"search/internal/port"
+	"search/internal/store"
+	"search/internal/tenant"
 )
 
-func NewRouter(os opensearch.SearchClient, logger *slog.Logger, allowedOrigins string) http.Handler {
+// RouterConfig holds the HTTP-layer settings and optional dependencies for
+// NewRouter.
+type RouterConfig struct {
+	AllowedOrigins string
+	Timeouts       Timeouts
+	Activity       *activity.Hub
+	// AdminAPIKey guards destructive admin endpoints; they are refused when
+	// it is empty.
+	AdminAPIKey string
+	// RawQuery enables POST /admin/query.
+	RawQuery bool
+	// Consumer, if set, is paused during index maintenance.
+	Consumer ConsumerPauser
+	// ConsumerOffsets, if set, backs /admin/consumer/offsets and
+	// /admin/consumer/seek.
+	ConsumerOffsets ConsumerOffsets
+	// ConsumerStatus, if set, backs /admin/consumer/status.
+	ConsumerStatus ConsumerStatusReporter
+	// Auth verifies user tokens for the /me endpoints; nil rejects them all.
+	Auth *auth.Verifier
+	// Store, if set, enables the /me endpoints.
+	Store store.Store
+	// Kafka, if set, adds broker connectivity to /health.
+	Kafka KafkaChecker
+	// Events, if set, supplies the newest Kafka event per tutor for
+	// freshness checks.
+	Events EventTracker
+	// Snapshots, if set, reports bootstrap snapshot ingestion progress.
+	Snapshots SnapshotTracker
+	// EventStats, if set, reports Kafka event handling counts.
+	EventStats EventStatsReporter
+	// Reindex, if set, backs /admin/reindex with a resync from Django.
+	Reindex ReindexJob
+	// Tenants maps X-Tenant values to indices. Nil serves only the default
+	// tutors index.
+	Tenants *tenant.Registry
+	// Experiment, if set, splits searches between its relevance variants.
+	Experiment *experiment.Experiment
+	// MaxTutorID is the largest tutor ID path parameters accept; zero uses
+	// domain.DefaultMaxTutorID.
+	MaxTutorID int64
+	// MaxListItems caps indexed subjects and formats; zero uses
+	// domain.DefaultMaxListItems.
+	MaxListItems int
+	// Avatars normalizes avatar URLs. The zero value only enforces absolute
+	// http and https URLs.
+	Avatars domain.AvatarPolicy
+	// RatingMode is domain.RatingModeStrict or domain.RatingModeLenient,
+	// the default.
+	RatingMode string
+	// Subjects maps raw subjects to canonical keys and labels; nil uses
+	// domain.DefaultSubjectCatalog.
+	Subjects *domain.SubjectCatalog
+	// Translations labels subjects in responses asked for with
+	// localize=true; nil uses the embedded translations.
+	Translations *domain.Translations
+	// Scrubber removes contact details from indexed bios and headlines;
+	// nil indexes them as sent.
+	Scrubber domain.Scrubber
+	// QueryLimits caps the filter values per search request; zero fields
+	// are uncapped.
+	QueryLimits QueryLimits
+	// Readiness, if set, answers every route but /health/live with a 503
+	// until startup has finished.
+	Readiness ReadinessChecker
+	// Audit, if set, records every mutating request and backs
+	// GET /admin/audit.
+	Audit *audit.Log
+	// Watermark, if set, backs GET /admin/freshness and adds the index lag
+	// to /health/ready.
+	Watermark WatermarkSource
+	// MaxStaleness fails /health/ready while the index is further behind
+	// with messages waiting; zero disables it.
+	MaxStaleness time.Duration
+	// Drift, if set, backs GET /admin/drift.
+	Drift DriftReporter
+	// Lease, if set, backs GET /admin/lock.
+	Lease LeaseReporter
+	// IndexingRate, if set, adds the current tutor writes per second to
+	// GET /admin/index/stats.
+	IndexingRate RateReporter
+	// Connections, if set, adds the counts of new and reused OpenSearch
+	// connections to GET /admin/index/stats.
+	Connections ConnectionReporter
+	// Journal, if set, queues failed tutor upserts and deletes for replay
+	// and backs GET /admin/pending.
+	Journal *outbox.Journal
+	// Draining, if set, is closed when the server starts shutting down, and
+	// ends streaming responses with a final record.
+	Draining <-chan struct{}
+	// Relevance, if set, backs POST /admin/config/reload.
+	Relevance RelevanceReloader
+	// Effective, if set, is the service config GET /admin/config reports.
+	Effective slog.LogValuer
+	// Feedback, if set, enables POST /feedback.
+	Feedback feedback.Sink
+	// FeedbackRate, if set, limits POST /feedback per client.
+	FeedbackRate RateLimiter
+	// MaxFeedbackBatch caps the events per feedback request; zero uses
+	// DefaultMaxFeedbackBatch.
+	MaxFeedbackBatch int
+	// MaxResponseBytes is the size JSON search responses are trimmed
+	// towards; zero disables the budget.
+	MaxResponseBytes int
+}
+
+// Timeouts are the handler deadlines applied per route group.
+type Timeouts struct {
+	Search   time.Duration
+	Mutation time.Duration
+	Admin    time.Duration
+	// Quick bounds GET /search/quick, far tighter than Search.
+	Quick time.Duration
+}
+
+func NewRouter(os port.SearchClient, logger *slog.Logger, cfg RouterConfig) http.Handler {
 	r := chi.NewRouter()
 
 	r.Use(RecoveryMiddleware(logger))
 	r.Use(LoggingMiddleware(logger))
-	r.Use(CORSMiddleware(allowedOrigins))
+	r.Use(HeadMiddleware)
+	r.Use(OptionsMiddleware)
+	r.Use(CORSMiddleware(cfg.AllowedOrigins))
+	r.Use(ReadinessGateMiddleware(cfg.Readiness))
+
+	tenants := cfg.Tenants
+	if tenants == nil {
+		tenants = tenant.Single(port.IndexName)
+	}
+	r.Use(TenantMiddleware(tenants))
+
+	handlers := NewHandlers(os, logger,
+		WithActivityHub(cfg.Activity),
+		WithConsumerPauser(cfg.Consumer),
+		WithConsumerOffsets(cfg.ConsumerOffsets),
+		WithConsumerStatus(cfg.ConsumerStatus),
+		WithStore(cfg.Store),
+		WithKafkaChecker(cfg.Kafka),
+		WithReindexJob(cfg.Reindex),
+		WithEventTracker(cfg.Events),
+		WithSnapshotTracker(cfg.Snapshots),
+		WithEventStats(cfg.EventStats),
+		WithExperiment(cfg.Experiment),
+		WithMaxTutorID(cfg.MaxTutorID),
+		WithMaxListItems(cfg.MaxListItems),
+		WithAvatarPolicy(cfg.Avatars),
+		WithRatingMode(cfg.RatingMode),
+		WithSubjectCatalog(cfg.Subjects),
+		WithTranslations(cfg.Translations),
+		WithScrubber(cfg.Scrubber),
+		WithQueryLimits(cfg.QueryLimits),
+		WithReadiness(cfg.Readiness),
+		WithAuditLog(cfg.Audit),
+		WithWatermark(cfg.Watermark),
+		WithMaxStaleness(cfg.MaxStaleness),
+		WithDriftReporter(cfg.Drift),
+		WithLeaseReporter(cfg.Lease),
+		WithIndexingRate(cfg.IndexingRate),
+		WithConnectionStats(cfg.Connections),
+		WithWriteJournal(cfg.Journal),
+		WithDraining(cfg.Draining),
+		WithRelevanceReloader(cfg.Relevance),
+		WithEffectiveConfig(cfg.Effective),
+		WithFeedbackSink(cfg.Feedback),
+		WithFeedbackRateLimit(cfg.FeedbackRate),
+		WithMaxFeedbackBatch(cfg.MaxFeedbackBatch),
+		WithMaxResponseBytes(cfg.MaxResponseBytes),
+	)
+	audited := AuditMiddleware(cfg.Audit, cfg.AdminAPIKey, cfg.Auth)
+	admin := AdminAuthMiddleware(cfg.AdminAPIKey)
+
+	r.With(TimeoutMiddleware(cfg.Timeouts.Search)).Get("/health", handlers.Health)
+	r.Get("/health/live", handlers.Live)
+	r.Get("/health/ready", handlers.Ready)
+	r.With(TimeoutMiddleware(cfg.Timeouts.Search)).Get("/subjects", handlers.Subjects)
+	r.With(TimeoutMiddleware(cfg.Timeouts.Search)).Get("/subjects/{subject}/price-stats", handlers.SubjectPriceStats)
+	r.With(TimeoutMiddleware(cfg.Timeouts.Search)).Get("/subjects/{subject}/related", handlers.RelatedSubjects)
+	r.With(TimeoutMiddleware(cfg.Timeouts.Quick)).Get("/search/quick", handlers.QuickSearch)
+	r.With(TimeoutMiddleware(cfg.Timeouts.Quick)).Post("/feedback", handlers.Feedback)
+	r.With(TimeoutMiddleware(cfg.Timeouts.Search)).Get("/tutors/top", handlers.TopTutors)
+	r.With(TimeoutMiddleware(cfg.Timeouts.Search)).Get("/tutors/{id}", handlers.GetTutor)
+	r.With(TimeoutMiddleware(cfg.Timeouts.Search)).Get("/tutors/slug/{slug}", handlers.GetTutorBySlug)
+	r.With(TimeoutMiddleware(cfg.Timeouts.Search)).Get("/tutors/{id}/alternatives", handlers.TutorAlternatives)
+
+	// CSV exports stream up to 10k rows, so they get the admin deadline
+	// without the buffering timeout middleware; JSON searches are unchanged.
+	searchJSON := TimeoutMiddleware(cfg.Timeouts.Search)(http.HandlerFunc(handlers.SearchTutors))
+	searchCSV := DeadlineMiddleware(cfg.Timeouts.Admin)(http.HandlerFunc(handlers.ExportTutorsCSV))
+	r.With(AuthMiddleware(cfg.Auth)).Get("/tutors/search", func(w http.ResponseWriter, r *http.Request) {
+		if wantsCSV(r) {
+			searchCSV.ServeHTTP(w, r)
+			return
+		}
+		searchJSON.ServeHTTP(w, r)
+	})
+
+	r.Group(func(r chi.Router) {
+		r.Use(TimeoutMiddleware(cfg.Timeouts.Mutation))
+
+		r.With(audited).Put("/tutors/{id}", handlers.UpsertTutor)
+		r.With(audited).Delete("/tutors/{id}", handlers.DeleteTutor)
+	})
+
+	// Every /admin route needs the admin key. Mutations are audited
+	// outside the key check, so refused attempts are recorded too.
+	r.Group(func(r chi.Router) {
+		r.Use(TimeoutMiddleware(cfg.Timeouts.Admin), audited, admin)
+
+		r.Post("/admin/sync", handlers.SyncTutors)
+		r.Post("/admin/reindex", handlers.Reindex)
+		r.Post("/admin/reconcile", handlers.Reconcile)
+		r.Post("/admin/index/recreate", handlers.RecreateIndex)
+		r.Put("/admin/index/settings", handlers.UpdateIndexSettings)
+		r.Put("/admin/index/aliases", handlers.PointIndexAliases)
+		r.Post("/admin/experiment/index", handlers.CreateExperimentIndex)
+		r.Delete("/admin/experiment/index", handlers.DeleteExperimentIndex)
+		r.Post("/admin/recompute-popularity", handlers.RecomputePopularity)
+		r.Post("/admin/tutors/delete", handlers.BulkDeleteTutors)
+		r.Post("/admin/tutors/{id}/badges", handlers.UpdateTutorBadges)
+		r.Post("/admin/consumer/seek", handlers.SeekConsumer)
+		r.Post("/admin/config/reload", handlers.ReloadConfig)
+	})
+	r.Group(func(r chi.Router) {
+		r.Use(TimeoutMiddleware(cfg.Timeouts.Admin), admin)
+
+		r.Get("/admin/reindex/last", handlers.ReindexStatus)
+		r.Get("/admin/tutors/{id}/freshness", handlers.TutorFreshness)
+		r.Get("/admin/snapshot-ingest/status", handlers.SnapshotIngestStatus)
+		r.Get("/admin/events/stats", handlers.EventStats)
+		r.Get("/admin/index/stats", handlers.IndexStats)
+		r.Get("/admin/dashboard", handlers.Dashboard)
+		r.Get("/admin/freshness", handlers.IndexFreshness)
+		r.Get("/admin/drift", handlers.IndexDrift)
+		r.Get("/admin/lock", handlers.JobLease)
+		r.Get("/admin/quality", handlers.DataQuality)
+		r.Get("/admin/checksum", handlers.IndexChecksum)
+		r.Get("/admin/pending", handlers.PendingWrites)
+		r.Get("/admin/index/aliases", handlers.IndexAliases)
+		r.Get("/admin/consumer/offsets", handlers.ConsumerGroupOffsets)
+		r.Get("/admin/consumer/status", handlers.ConsumerStatus)
+		r.Get("/admin/audit", handlers.AuditLog)
+		r.Get("/admin/config", handlers.EffectiveConfig)
+		if cfg.RawQuery {
+			r.Post("/admin/query", handlers.RawQuery)
+		}
+	})
+
+	if cfg.Store != nil {
+		r.Group(func(r chi.Router) {
+			r.Use(TimeoutMiddleware(cfg.Timeouts.Search))
+			r.Use(AuthMiddleware(cfg.Auth))
+			r.Use(RequireUser)
 
-	handlers := NewHandlers(os, logger)
+			r.Get("/me/searches", handlers.ListSavedSearches)
+			r.Post("/me/searches", handlers.CreateSavedSearch)
+			r.Delete("/me/searches/{id}", handlers.DeleteSavedSearch)
+			r.Get("/me/searches/{id}/run", handlers.RunSavedSearch)
 
-	r.Get("/health", handlers.Health)
+			r.Get("/me/hidden", handlers.ListHiddenTutors)
+			r.Post("/me/hidden/{tutor_id}", handlers.HideTutor)
+			r.Delete("/me/hidden/{tutor_id}", handlers.UnhideTutor)
+		})
+	}
 
-	r.Put("/tutors/{id}", handlers.UpsertTutor)
-	r.Delete("/tutors/{id}", handlers.DeleteTutor)
-	r.Get("/tutors/search", handlers.SearchTutors)
+	// Streaming endpoints manage their own lifetime and bypass the buffering
+	// timeout middleware.
+	r.Group(func(r chi.Router) {
+		r.Use(admin)
 
-	r.Post("/admin/sync", handlers.SyncTutors)
-	r.Post("/admin/reindex", handlers.Reindex)
+		r.Get("/admin/events", handlers.StreamActivity)
+	})
 
 	return r
 }
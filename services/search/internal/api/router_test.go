@@ -0,0 +1,576 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"search/internal/domain"
+	"search/internal/limiter"
+	"search/internal/opensearch"
+	"search/internal/port"
+	"search/internal/tenant"
+	"search/internal/testutil"
+)
+
+// slowSearchClient blocks every call for delay or until the context ends.
+type slowSearchClient struct {
+	delay time.Duration
+}
+
+func (s *slowSearchClient) wait(ctx context.Context) error {
+	select {
+	case <-time.After(s.delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *slowSearchClient) Ping(ctx context.Context) error {
+	return s.wait(ctx)
+}
+
+func (s *slowSearchClient) EnsureIndex(ctx context.Context) error {
+	return s.wait(ctx)
+}
+
+func (s *slowSearchClient) UpsertTutor(ctx context.Context, tutor *domain.Tutor) error {
+	return s.wait(ctx)
+}
+
+func (s *slowSearchClient) BulkUpsertTutors(ctx context.Context, tutors []*domain.Tutor) (*port.BulkResult, error) {
+	return nil, s.wait(ctx)
+}
+
+func (s *slowSearchClient) UpsertSnapshotTutor(ctx context.Context, tutor *domain.Tutor, snapshotID string) (bool, error) {
+	return false, s.wait(ctx)
+}
+
+func (s *slowSearchClient) BookSlot(ctx context.Context, tutorID int64, slot time.Time) error {
+	return s.wait(ctx)
+}
+
+func (s *slowSearchClient) FreeSlot(ctx context.Context, tutorID int64, slot time.Time) error {
+	return s.wait(ctx)
+}
+
+func (s *slowSearchClient) CheckQuality(ctx context.Context, rules []string, limit, offset int) ([]port.QualityRuleResult, error) {
+	return nil, s.wait(ctx)
+}
+
+func (s *slowSearchClient) UpdateBadges(ctx context.Context, tutorID int64, add, remove []string) error {
+	return s.wait(ctx)
+}
+
+func (s *slowSearchClient) SetVerified(ctx context.Context, tutorID int64, verified bool, at time.Time) error {
+	return s.wait(ctx)
+}
+
+func (s *slowSearchClient) SetOffersTrial(ctx context.Context, tutorID int64, offers bool) error {
+	return s.wait(ctx)
+}
+
+func (s *slowSearchClient) DeleteTutor(ctx context.Context, id int64) error {
+	return s.wait(ctx)
+}
+
+func (s *slowSearchClient) BulkDeleteTutors(ctx context.Context, ids []int64) ([]port.BulkDeleteResult, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	return []port.BulkDeleteResult{}, nil
+}
+
+func (s *slowSearchClient) GetTutor(ctx context.Context, id int64) (*domain.Tutor, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	return nil, port.ErrNotFound
+}
+
+func (s *slowSearchClient) GetTutorBySlug(ctx context.Context, slug string) (*domain.Tutor, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	return nil, port.ErrNotFound
+}
+
+func (s *slowSearchClient) ChangeSlug(ctx context.Context, tutorID int64, oldSlug, newSlug string) error {
+	return s.wait(ctx)
+}
+
+func (s *slowSearchClient) GetTutors(ctx context.Context, ids []int64) (map[int64]domain.Tutor, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	return map[int64]domain.Tutor{}, nil
+}
+
+func (s *slowSearchClient) SearchTutors(ctx context.Context, query port.SearchQuery) (*port.SearchResponse, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	return &port.SearchResponse{Results: []domain.Tutor{}}, nil
+}
+
+func (s *slowSearchClient) SearchTutorsWithFacets(ctx context.Context, query port.SearchQuery, facetSize int) (*port.SearchResponse, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	return &port.SearchResponse{Results: []domain.Tutor{}, Facets: &port.FacetCounts{}}, nil
+}
+
+func (s *slowSearchClient) SearchTutorsMulti(ctx context.Context, queries []port.SearchQuery) ([]*port.SearchResponse, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	results := make([]*port.SearchResponse, len(queries))
+	for i := range queries {
+		results[i] = &port.SearchResponse{Results: []domain.Tutor{}}
+	}
+	return results, nil
+}
+
+func (s *slowSearchClient) ScanTutors(ctx context.Context, query port.SearchQuery, limit int, fn func(domain.Tutor) error) error {
+	return s.wait(ctx)
+}
+
+func (s *slowSearchClient) TopTutorsBySubject(ctx context.Context, subjects []string, perSubject int) (map[string][]domain.Tutor, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	return map[string][]domain.Tutor{}, nil
+}
+
+func (s *slowSearchClient) RelatedSubjects(ctx context.Context, subject string, size int) (*port.RelatedSubjects, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	return &port.RelatedSubjects{}, nil
+}
+
+func (s *slowSearchClient) PriceStats(ctx context.Context, subject string, byFormat bool) (*port.PriceStats, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	return &port.PriceStats{}, nil
+}
+
+func (s *slowSearchClient) FacetCounts(ctx context.Context, size int) (*port.FacetCounts, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	return &port.FacetCounts{}, nil
+}
+
+func (s *slowSearchClient) QuickSearch(ctx context.Context, prefix string, size int) (*port.QuickSearchResult, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	return &port.QuickSearchResult{}, nil
+}
+
+func (s *slowSearchClient) RawSearch(ctx context.Context, body []byte) (json.RawMessage, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	return json.RawMessage(`{}`), nil
+}
+
+func (s *slowSearchClient) ScanTutorVersions(ctx context.Context, fn func(port.TutorVersion) error) error {
+	return s.wait(ctx)
+}
+
+func (s *slowSearchClient) IndexedTutorIDs(ctx context.Context) ([]int64, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	return []int64{}, nil
+}
+
+func (s *slowSearchClient) CountTutors(ctx context.Context) (int64, error) {
+	if err := s.wait(ctx); err != nil {
+		return 0, err
+	}
+	return 0, nil
+}
+
+func (s *slowSearchClient) RecreateIndex(ctx context.Context) (*port.RecreateResult, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	return &port.RecreateResult{}, nil
+}
+
+func (s *slowSearchClient) RecomputePopularity(ctx context.Context) (*port.PopularityResult, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	return &port.PopularityResult{}, nil
+}
+
+func (s *slowSearchClient) UpdateIndexSettings(ctx context.Context, replicas int) error {
+	return s.wait(ctx)
+}
+
+func (s *slowSearchClient) CreateExperimentIndex(ctx context.Context, mapping port.ExperimentMapping) (*port.ExperimentIndex, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	return &port.ExperimentIndex{}, nil
+}
+
+func (s *slowSearchClient) DeleteExperimentIndex(ctx context.Context) error {
+	return s.wait(ctx)
+}
+
+func (s *slowSearchClient) IndexAliases(ctx context.Context) (*port.IndexAliases, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	return &port.IndexAliases{}, nil
+}
+
+func (s *slowSearchClient) PointIndexAliases(ctx context.Context, read, write string) (*port.IndexAliases, error) {
+	if err := s.wait(ctx); err != nil {
+		return nil, err
+	}
+	return &port.IndexAliases{}, nil
+}
+
+func testRouterConfig() RouterConfig {
+	return RouterConfig{
+		AllowedOrigins: "*",
+		Timeouts: Timeouts{
+			Search:   50 * time.Millisecond,
+			Mutation: 100 * time.Millisecond,
+			Admin:    time.Second,
+			Quick:    30 * time.Millisecond,
+		},
+	}
+}
+
+func TestRouter_RouteGroupTimeouts(t *testing.T) {
+	logger := testutil.NewLogger(t)
+	tutors, _ := json.Marshal([]domain.Tutor{{ID: 1}})
+
+	tests := []struct {
+		name       string
+		delay      time.Duration
+		method     string
+		path       string
+		body       []byte
+		wantStatus int
+	}{
+		{"search within deadline", 10 * time.Millisecond, "GET", "/tutors/search", nil, http.StatusOK},
+		{"search exceeds deadline", 200 * time.Millisecond, "GET", "/tutors/search", nil, http.StatusGatewayTimeout},
+		{"health exceeds deadline", 200 * time.Millisecond, "GET", "/health", nil, http.StatusGatewayTimeout},
+		{"mutation within deadline", 75 * time.Millisecond, "DELETE", "/tutors/1", nil, http.StatusOK},
+		{"mutation exceeds deadline", 300 * time.Millisecond, "DELETE", "/tutors/1", nil, http.StatusGatewayTimeout},
+		{"quick search within deadline", 5 * time.Millisecond, "GET", "/search/quick?q=ma", nil, http.StatusOK},
+		{"quick search exceeds deadline", 100 * time.Millisecond, "GET", "/search/quick?q=ma", nil, http.StatusGatewayTimeout},
+		{"admin outlives mutation deadline", 300 * time.Millisecond, "POST", "/admin/sync", tutors, http.StatusOK},
+		{"admin exceeds deadline", 2 * time.Second, "POST", "/admin/sync", tutors, http.StatusGatewayTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := testRouterConfig()
+			cfg.AdminAPIKey = testAdminKey
+			router := NewRouter(&slowSearchClient{delay: tt.delay}, logger, cfg)
+
+			rec := serve(router, tt.method, tt.path, string(tt.body), "Bearer "+testAdminKey)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if tt.wantStatus == http.StatusGatewayTimeout {
+				var response map[string]string
+				json.Unmarshal(rec.Body.Bytes(), &response)
+				if response["error"] != "Request timed out" {
+					t.Errorf("expected timeout error body, got %s", rec.Body.String())
+				}
+			}
+		})
+	}
+}
+
+func TestRouter_SearchConcurrencyLimit(t *testing.T) {
+	logger := testutil.NewLogger(t)
+	limited := limiter.New(&slowSearchClient{delay: time.Second}, 1, limiter.WithWait(5*time.Millisecond))
+	router := NewRouter(limited, logger, testRouterConfig())
+
+	go router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/tutors/search", nil))
+	deadline := time.Now().Add(time.Second)
+	for limited.InFlight() == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("first search never reached the backend")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/tutors/search", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusServiceUnavailable, rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Retry-After"); got != "1" {
+		t.Errorf("expected Retry-After 1, got %q", got)
+	}
+}
+
+func TestTimeoutMiddleware_ForwardsHandlerResponse(t *testing.T) {
+	handler := TimeoutMiddleware(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Deadline(); !ok {
+			t.Error("expected request context to carry a deadline")
+		}
+		w.Header().Set("X-Test", "yes")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("created"))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/test", nil))
+
+	if rec.Code != http.StatusCreated {
+		t.Errorf("expected status %d, got %d", http.StatusCreated, rec.Code)
+	}
+	if rec.Header().Get("X-Test") != "yes" {
+		t.Error("expected handler header to be forwarded")
+	}
+	if rec.Body.String() != "created" {
+		t.Errorf("expected body 'created', got %s", rec.Body.String())
+	}
+}
+
+func TestTimeoutMiddleware_PropagatesPanic(t *testing.T) {
+	logger := testutil.NewLogger(t)
+	handler := RecoveryMiddleware(logger)(TimeoutMiddleware(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest("GET", "/test", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+}
+
+func TestRouter_HeadMatchesGet(t *testing.T) {
+	logger := testutil.NewLogger(t)
+	mock := &mockSearchClient{
+		searchResult: &port.SearchResponse{
+			Results: []domain.Tutor{{ID: 1, FullName: "Tutor 1"}},
+			Total:   1,
+		},
+	}
+	router := NewRouter(mock, logger, testRouterConfig())
+
+	for _, path := range []string{"/health", "/tutors/search?q=math"} {
+		t.Run(path, func(t *testing.T) {
+			getRec := httptest.NewRecorder()
+			router.ServeHTTP(getRec, httptest.NewRequest("GET", path, nil))
+
+			headRec := httptest.NewRecorder()
+			router.ServeHTTP(headRec, httptest.NewRequest("HEAD", path, nil))
+
+			if headRec.Code != http.StatusOK {
+				t.Errorf("expected status %d, got %d", http.StatusOK, headRec.Code)
+			}
+			if headRec.Code != getRec.Code {
+				t.Errorf("HEAD status %d differs from GET status %d", headRec.Code, getRec.Code)
+			}
+			if headRec.Body.Len() != 0 {
+				t.Errorf("expected empty body for HEAD, got %s", headRec.Body.String())
+			}
+			if getRec.Body.Len() == 0 {
+				t.Error("expected GET to return a body")
+			}
+			for key, values := range getRec.Header() {
+				if key == "Server-Timing" {
+					// Durations differ between any two requests.
+					if headRec.Header().Get(key) == "" {
+						t.Error("expected HEAD to carry Server-Timing")
+					}
+					continue
+				}
+				if headRec.Header().Get(key) != values[0] {
+					t.Errorf("header %s: HEAD %q, GET %q", key, headRec.Header().Get(key), values[0])
+				}
+			}
+		})
+	}
+}
+
+func TestRouter_OptionsListsAllowedMethods(t *testing.T) {
+	logger := testutil.NewLogger(t)
+	router := NewRouter(&mockSearchClient{}, logger, testRouterConfig())
+
+	tests := []struct {
+		path       string
+		wantStatus int
+		wantAllow  string
+	}{
+		{"/tutors/123", http.StatusOK, "GET, HEAD, PUT, DELETE, OPTIONS"},
+		{"/health", http.StatusOK, "GET, HEAD, OPTIONS"},
+		{"/admin/sync", http.StatusOK, "POST, OPTIONS"},
+		{"/unknown", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			// No Origin header: this is a plain OPTIONS request, not a CORS preflight.
+			req := httptest.NewRequest("OPTIONS", tt.path, nil)
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if rec.Header().Get("Allow") != tt.wantAllow {
+				t.Errorf("expected Allow %q, got %q", tt.wantAllow, rec.Header().Get("Allow"))
+			}
+		})
+	}
+}
+
+func TestRouter_MemoryBackendRoundTrip(t *testing.T) {
+	logger := testutil.NewLogger(t)
+	router := NewRouter(opensearch.NewMemoryClient(), logger, testRouterConfig())
+
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, path, bytes.NewReader([]byte(body))))
+		return rec
+	}
+	searchTotal := func(path string) int {
+		var response port.SearchResponse
+		json.Unmarshal(serve("GET", path, "").Body.Bytes(), &response)
+		return response.Total
+	}
+
+	if rec := serve("PUT", "/tutors/1", `{"full_name": "Ada Lovelace", "subjects": ["math"], "hourly_rate": 40}`); rec.Code != http.StatusOK {
+		t.Fatalf("expected upsert status %d, got %d", http.StatusOK, rec.Code)
+	}
+	serve("PUT", "/tutors/2", `{"full_name": "Marie Curie", "subjects": ["physics"], "hourly_rate": 60}`)
+
+	if got := searchTotal("/tutors/search?subjects=math"); got != 1 {
+		t.Errorf("expected 1 math tutor, got %d", got)
+	}
+	if got := searchTotal("/tutors/search?q=curie&max_price=50"); got != 0 {
+		t.Errorf("expected price filter to exclude Curie, got %d", got)
+	}
+
+	if rec := serve("DELETE", "/tutors/1", ""); rec.Code != http.StatusOK {
+		t.Errorf("expected delete status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if rec := serve("DELETE", "/tutors/1", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("expected second delete status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+	if got := searchTotal("/tutors/search"); got != 1 {
+		t.Errorf("expected 1 tutor left, got %d", got)
+	}
+}
+
+func TestRouter_TenantRouting(t *testing.T) {
+	tenants, err := tenant.NewRegistry([]tenant.Tenant{{Name: "us", Index: "tutors-us"}, {Name: "de", Index: "tutors-de"}}, "us")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := testRouterConfig()
+	cfg.Tenants = tenants
+	router := NewRouter(opensearch.NewMemoryClient(), testutil.NewLogger(t), cfg)
+
+	serve := func(method, path, tenantHeader, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, bytes.NewReader([]byte(body)))
+		if tenantHeader != "" {
+			req.Header.Set("X-Tenant", tenantHeader)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	serve("PUT", "/tutors/1", "de", `{"full_name": "Berlin Tutor"}`)
+	serve("PUT", "/tutors/2", "", `{"full_name": "Boston Tutor"}`)
+
+	tests := []struct {
+		name       string
+		path       string
+		header     string
+		wantStatus int
+		wantTotal  int
+	}{
+		{"header", "/tutors/search", "de", http.StatusOK, 1},
+		{"query parameter", "/tutors/search?tenant=de", "", http.StatusOK, 1},
+		{"default tenant", "/tutors/search", "", http.StatusOK, 1},
+		{"explicit default", "/tutors/search", "us", http.StatusOK, 1},
+		{"unknown tenant", "/tutors/search", "fr", http.StatusBadRequest, 0},
+		{"header and parameter disagree", "/tutors/search?tenant=us", "de", http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve("GET", tt.path, tt.header, "")
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			var response port.SearchResponse
+			json.Unmarshal(rec.Body.Bytes(), &response)
+			if response.Total != tt.wantTotal {
+				t.Errorf("expected total %d, got %d", tt.wantTotal, response.Total)
+			}
+		})
+	}
+
+	if rec := serve("GET", "/tutors/1", "us", ""); rec.Code != http.StatusNotFound {
+		t.Errorf("expected tutor 1 to be invisible to tenant us, got %d", rec.Code)
+	}
+	if rec := serve("GET", "/tutors/1", "de", ""); rec.Code != http.StatusOK {
+		t.Errorf("expected tutor 1 in tenant de, got %d", rec.Code)
+	}
+}
+
+func TestRouter_AdminRoutesRequireKey(t *testing.T) {
+	cfg := testRouterConfig()
+	cfg.AdminAPIKey = testAdminKey
+	cfg.RawQuery = true
+	router := NewRouter(&mockSearchClient{}, testutil.NewLogger(t), cfg)
+
+	var admin []string
+	err := chi.Walk(router.(chi.Routes), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if strings.HasPrefix(route, "/admin/") {
+			admin = append(admin, method+" "+route)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to walk routes: %v", err)
+	}
+	if len(admin) < 30 {
+		t.Fatalf("expected every admin route to be walked, got %v", admin)
+	}
+
+	for _, r := range admin {
+		method, route, _ := strings.Cut(r, " ")
+		path := strings.ReplaceAll(route, "{id}", "7")
+		for _, authHeader := range []string{"", "Bearer wrong-key"} {
+			if rec := serve(router, method, path, "{}", authHeader); rec.Code != http.StatusUnauthorized {
+				t.Errorf("%s %s with %q: expected status %d, got %d", method, route, authHeader, http.StatusUnauthorized, rec.Code)
+			}
+		}
+	}
+}
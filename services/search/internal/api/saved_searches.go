@@ -0,0 +1,232 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"search/internal/auth"
+	"search/internal/port"
+	"search/internal/store"
+)
+
+const maxSavedSearchNameLength = 100
+
+type savedSearchRequest struct {
+	Name string `json:"name"`
+	// Params uses the /tutors/search query string format,
+	// e.g. "subjects=physics&format=online&max_price=40".
+	Params string `json:"params"`
+}
+
+type savedSearchView struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Params    string    `json:"params"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func newSavedSearchView(s store.SavedSearch) savedSearchView {
+	return savedSearchView{
+		ID:        s.ID,
+		Name:      s.Name,
+		Params:    encodeSearchValues(s.Query).Encode(),
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+// CreateSavedSearch stores a named search for the authenticated user.
+func (h *Handlers) CreateSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID, _ := auth.UserIDFrom(r.Context())
+
+	var req savedSearchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		respondError(w, http.StatusBadRequest, "Name is required")
+		return
+	}
+	if utf8.RuneCountInString(req.Name) > maxSavedSearchNameLength {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("Name must be at most %d characters", maxSavedSearchNameLength))
+		return
+	}
+	if _, err := url.ParseQuery(req.Params); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid params")
+		return
+	}
+	params, ok := h.validateQuery(w, req.Params)
+	if !ok {
+		return
+	}
+
+	query := parseSearchValues(params)
+	query.Subjects = h.subjects.Keys(query.Subjects)
+	saved, err := h.store.CreateSavedSearch(r.Context(), userID, store.SavedSearch{
+		Name:  req.Name,
+		Query: query,
+	})
+	if errors.Is(err, store.ErrLimitReached) {
+		respondError(w, http.StatusConflict, fmt.Sprintf("At most %d saved searches are allowed", store.MaxSavedSearches))
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to save search", "user_id", userID, "error", err)
+		respondError(w, http.StatusInternalServerError, "Failed to save search")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, newSavedSearchView(*saved))
+}
+
+// ListSavedSearches returns the authenticated user's saved searches.
+func (h *Handlers) ListSavedSearches(w http.ResponseWriter, r *http.Request) {
+	userID, _ := auth.UserIDFrom(r.Context())
+
+	searches, err := h.store.ListSavedSearches(r.Context(), userID)
+	if err != nil {
+		h.logger.Error("Failed to list saved searches", "user_id", userID, "error", err)
+		respondError(w, http.StatusInternalServerError, "Failed to list saved searches")
+		return
+	}
+
+	views := make([]savedSearchView, len(searches))
+	for i, s := range searches {
+		views[i] = newSavedSearchView(s)
+	}
+	respondJSON(w, http.StatusOK, map[string]any{"searches": views})
+}
+
+// DeleteSavedSearch removes one of the authenticated user's saved searches.
+func (h *Handlers) DeleteSavedSearch(w http.ResponseWriter, r *http.Request) {
+	userID, _ := auth.UserIDFrom(r.Context())
+	id := r.PathValue("id")
+
+	err := h.store.DeleteSavedSearch(r.Context(), userID, id)
+	if errors.Is(err, store.ErrNotFound) {
+		respondError(w, http.StatusNotFound, "Saved search not found")
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to delete saved search", "user_id", userID, "id", id, "error", err)
+		respondError(w, http.StatusInternalServerError, "Failed to delete saved search")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{
+		"status": "deleted",
+		"id":     id,
+	})
+}
+
+// RunSavedSearch executes a saved search. limit and offset in the request
+// query override the stored values so results can be paged.
+func (h *Handlers) RunSavedSearch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, _ := auth.UserIDFrom(ctx)
+	id := r.PathValue("id")
+
+	saved, err := h.store.GetSavedSearch(ctx, userID, id)
+	if errors.Is(err, store.ErrNotFound) {
+		respondError(w, http.StatusNotFound, "Saved search not found")
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to load saved search", "user_id", userID, "id", id, "error", err)
+		respondError(w, http.StatusInternalServerError, "Failed to load saved search")
+		return
+	}
+
+	// Searches saved under an older catalog may hold since-aliased subjects.
+	query := saved.Query
+	query.Subjects = h.subjects.Keys(query.Subjects)
+	pageParams, ok := h.validateQuery(w, r.URL.RawQuery)
+	if !ok {
+		return
+	}
+	page := parseSearchValues(pageParams)
+	if page.Limit != 0 {
+		query.Limit = page.Limit
+	}
+	if page.Offset != 0 {
+		query.Offset = page.Offset
+	}
+
+	result, err := h.search(ctx, query, 0)
+	if err != nil {
+		h.logger.Error("Failed to search tutors", "error", err)
+		respondBackendError(w, err, "Failed to search tutors")
+		return
+	}
+	if rejectPartial(w, r, result) {
+		return
+	}
+	lang := h.language(w, r)
+	stripIndexMeta(r, result.Results)
+	h.localizeSubjects(result.Results, lang)
+	applied := query.Normalized()
+	result.AppliedFilters = &applied
+
+	respondJSON(w, http.StatusOK, h.fitResponse(r, result, lang))
+}
+
+// encodeSearchValues is the inverse of parseSearchValues. Variant and
+// BelowPrice have no query parameter and are left out.
+func encodeSearchValues(query port.SearchQuery) url.Values {
+	v := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			v.Set(key, value)
+		}
+	}
+	setFloat := func(key string, f *float64) {
+		if f != nil {
+			v.Set(key, strconv.FormatFloat(*f, 'f', -1, 64))
+		}
+	}
+
+	set("q", query.Text)
+	for _, s := range query.Subjects {
+		v.Add("subjects", s)
+	}
+	setFloat("min_price", query.MinPrice)
+	setFloat("max_price", query.MaxPrice)
+	setFloat("min_rating", query.MinRating)
+	set("format", query.Format)
+	set("location", query.Location)
+	for _, l := range query.Levels {
+		v.Add("level", l)
+	}
+	set("certification", query.Certification)
+	set("badge", query.Badge)
+	if query.Trial {
+		v.Set("trial", "true")
+	}
+	if query.AvailableWithinDays > 0 {
+		v.Set("available_within_days", strconv.Itoa(query.AvailableWithinDays))
+	}
+	set("available_between", formatWindowUTC(query.AvailableBetween))
+	if query.IncludeBio {
+		v.Set("fields", "bio")
+	}
+	set("sort", query.Sort)
+	set("diversify_by", query.DiversifyBy)
+	for _, id := range query.ExcludeIDs {
+		v.Add("exclude_ids", strconv.FormatInt(id, 10))
+	}
+	if query.Limit != 0 {
+		v.Set("limit", strconv.Itoa(query.Limit))
+	}
+	if query.Offset != 0 {
+		v.Set("offset", strconv.Itoa(query.Offset))
+	}
+	return v
+}
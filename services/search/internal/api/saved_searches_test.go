@@ -0,0 +1,314 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"search/internal/auth"
+	"search/internal/domain"
+	"search/internal/port"
+	"search/internal/store"
+	"search/internal/testutil"
+)
+
+func newSavedSearchHandlers(t *testing.T, mock *mockSearchClient) *Handlers {
+	logger := testutil.NewLogger(t)
+	return NewHandlers(mock, logger, WithStore(store.NewMemory()))
+}
+
+// asUser returns req authenticated as userID.
+func asUser(req *http.Request, userID string) *http.Request {
+	return req.WithContext(auth.WithUserID(req.Context(), userID))
+}
+
+func createSavedSearch(t *testing.T, h *Handlers, userID, body string) savedSearchView {
+	t.Helper()
+
+	req := asUser(httptest.NewRequest("POST", "/me/searches", bytes.NewReader([]byte(body))), userID)
+	rec := httptest.NewRecorder()
+	h.CreateSavedSearch(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusCreated, rec.Code, rec.Body.String())
+	}
+	var view savedSearchView
+	json.Unmarshal(rec.Body.Bytes(), &view)
+	return view
+}
+
+func TestCreateSavedSearch(t *testing.T) {
+	h := newSavedSearchHandlers(t, &mockSearchClient{})
+
+	view := createSavedSearch(t, h, "1",
+		`{"name": "online physics", "params": "subjects=physics&format=online&max_price=40&min_rating=4.5"}`)
+
+	if view.ID == "" {
+		t.Error("expected an ID to be assigned")
+	}
+	if view.Name != "online physics" {
+		t.Errorf("expected name 'online physics', got %s", view.Name)
+	}
+	want := "format=online&max_price=40&min_rating=4.5&subjects=physics"
+	if view.Params != want {
+		t.Errorf("expected params %q, got %q", want, view.Params)
+	}
+}
+
+func TestCreateSavedSearch_InvalidRequests(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"invalid json", `{`},
+		{"missing name", `{"params": "q=math"}`},
+		{"blank name", `{"name": "  "}`},
+		{"name too long", fmt.Sprintf(`{"name": "%0101d"}`, 0)},
+		{"invalid params", `{"name": "x", "params": "q=%zz"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newSavedSearchHandlers(t, &mockSearchClient{})
+
+			req := asUser(httptest.NewRequest("POST", "/me/searches", bytes.NewReader([]byte(tt.body))), "1")
+			rec := httptest.NewRecorder()
+			h.CreateSavedSearch(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+			}
+		})
+	}
+}
+
+func TestCreateSavedSearch_Limit(t *testing.T) {
+	h := newSavedSearchHandlers(t, &mockSearchClient{})
+
+	for i := range store.MaxSavedSearches {
+		createSavedSearch(t, h, "1", fmt.Sprintf(`{"name": "search %d"}`, i))
+	}
+
+	req := asUser(httptest.NewRequest("POST", "/me/searches", bytes.NewReader([]byte(`{"name": "extra"}`))), "1")
+	rec := httptest.NewRecorder()
+	h.CreateSavedSearch(rec, req)
+
+	if rec.Code != http.StatusConflict {
+		t.Errorf("expected status %d, got %d", http.StatusConflict, rec.Code)
+	}
+}
+
+func TestListSavedSearches_ScopedToUser(t *testing.T) {
+	h := newSavedSearchHandlers(t, &mockSearchClient{})
+	createSavedSearch(t, h, "1", `{"name": "mine"}`)
+	createSavedSearch(t, h, "2", `{"name": "theirs"}`)
+
+	rec := httptest.NewRecorder()
+	h.ListSavedSearches(rec, asUser(httptest.NewRequest("GET", "/me/searches", nil), "1"))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	var response struct {
+		Searches []savedSearchView `json:"searches"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	if len(response.Searches) != 1 || response.Searches[0].Name != "mine" {
+		t.Errorf("expected only the user's own search, got %+v", response.Searches)
+	}
+}
+
+func TestDeleteSavedSearch(t *testing.T) {
+	h := newSavedSearchHandlers(t, &mockSearchClient{})
+	view := createSavedSearch(t, h, "1", `{"name": "mine"}`)
+
+	del := func(userID string) int {
+		req := asUser(httptest.NewRequest("DELETE", "/me/searches/"+view.ID, nil), userID)
+		req.SetPathValue("id", view.ID)
+		rec := httptest.NewRecorder()
+		h.DeleteSavedSearch(rec, req)
+		return rec.Code
+	}
+
+	if code := del("2"); code != http.StatusNotFound {
+		t.Errorf("expected another user's delete to get %d, got %d", http.StatusNotFound, code)
+	}
+	if code := del("1"); code != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, code)
+	}
+	if code := del("1"); code != http.StatusNotFound {
+		t.Errorf("expected second delete to get %d, got %d", http.StatusNotFound, code)
+	}
+}
+
+func TestRunSavedSearch(t *testing.T) {
+	mock := &mockSearchClient{
+		searchResult: &port.SearchResponse{Results: []domain.Tutor{{ID: 1}}, Total: 1},
+	}
+	h := newSavedSearchHandlers(t, mock)
+	view := createSavedSearch(t, h, "1", `{"name": "physics", "params": "subjects=physics&max_price=40&limit=5"}`)
+
+	req := asUser(httptest.NewRequest("GET", "/me/searches/"+view.ID+"/run?offset=10", nil), "1")
+	req.SetPathValue("id", view.ID)
+	rec := httptest.NewRecorder()
+	h.RunSavedSearch(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	q := mock.searchedQuery
+	if len(q.Subjects) != 1 || q.Subjects[0] != "physics" {
+		t.Errorf("expected stored subjects, got %v", q.Subjects)
+	}
+	if q.MaxPrice == nil || *q.MaxPrice != 40 {
+		t.Errorf("expected stored max price 40, got %v", q.MaxPrice)
+	}
+	if q.Limit != 5 || q.Offset != 10 {
+		t.Errorf("expected limit 5 and offset 10, got %d and %d", q.Limit, q.Offset)
+	}
+}
+
+func TestRunSavedSearch_NotFound(t *testing.T) {
+	h := newSavedSearchHandlers(t, &mockSearchClient{})
+
+	req := asUser(httptest.NewRequest("GET", "/me/searches/missing/run", nil), "1")
+	req.SetPathValue("id", "missing")
+	rec := httptest.NewRecorder()
+	h.RunSavedSearch(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
+
+func TestRouter_MeRoutesRequireAuthentication(t *testing.T) {
+	logger := testutil.NewLogger(t)
+	const secret = "django-secret"
+
+	token := func(secret string) string {
+		signed, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"user_id": 42,
+			"exp":     time.Now().Add(time.Hour).Unix(),
+		}).SignedString([]byte(secret))
+		return "Bearer " + signed
+	}
+
+	tests := []struct {
+		name       string
+		verifier   *auth.Verifier
+		authHeader string
+		wantStatus int
+	}{
+		{"no token", auth.NewVerifier(secret), "", http.StatusUnauthorized},
+		{"bad token", auth.NewVerifier(secret), token("wrong-secret"), http.StatusUnauthorized},
+		{"valid token", auth.NewVerifier(secret), token(secret), http.StatusOK},
+		{"auth not configured", nil, token(secret), http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testRouterConfig()
+			cfg.Auth = tt.verifier
+			cfg.Store = store.NewMemory()
+			router := NewRouter(&mockSearchClient{}, logger, cfg)
+
+			req := httptest.NewRequest("GET", "/me/searches", nil)
+			if tt.authHeader != "" {
+				req.Header.Set("Authorization", tt.authHeader)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+		})
+	}
+}
+
+func TestEncodeSearchValues_RoundTripsEveryField(t *testing.T) {
+	minPrice, maxPrice, minRating := 10.5, 40.0, 4.5
+	query := port.SearchQuery{
+		Text:                "algebra",
+		Subjects:            []string{"math", "physics"},
+		MinPrice:            &minPrice,
+		MaxPrice:            &maxPrice,
+		MinRating:           &minRating,
+		Format:              "online",
+		Location:            "Berlin",
+		Levels:              []string{"school", "university"},
+		Certification:       "CELTA",
+		Badge:               domain.BadgeFeatured,
+		Trial:               true,
+		AvailableWithinDays: 14,
+		// 22:00-01:00 UTC, split at midnight.
+		AvailableBetween: []domain.MinuteRange{{Start: 0, End: 60}, {Start: 1320, End: 1440}},
+		ExcludeIDs:       []int64{3, 9},
+		IncludeBio:       true,
+		Sort:             port.SortRating,
+		DiversifyBy:      port.DiversifyLocation,
+		Limit:            10,
+		Offset:           20,
+	}
+	// Neither has a query parameter: Variant comes from a header and
+	// BelowPrice from the alternatives endpoint.
+	unencoded := map[string]bool{"Variant": true, "BelowPrice": true}
+	v := reflect.ValueOf(query)
+	for i := range v.NumField() {
+		if name := v.Type().Field(i).Name; !unencoded[name] && v.Field(i).IsZero() {
+			t.Fatalf("set %s so the round trip covers it", name)
+		}
+	}
+
+	if got := parseSearchValues(encodeSearchValues(query)); !reflect.DeepEqual(got, query) {
+		t.Errorf("round trip changed the query:\nwant %+v\n got %+v", query, got)
+	}
+}
+
+func TestEncodeSearchValues_RoundTrip(t *testing.T) {
+	minPrice, maxPrice, minRating := 10.5, 40.0, 4.5
+	query := port.SearchQuery{
+		Text:      "algebra",
+		Subjects:  []string{"math", "physics"},
+		MinPrice:  &minPrice,
+		MaxPrice:  &maxPrice,
+		MinRating: &minRating,
+		Format:    "online",
+		Location:  "Berlin",
+		Levels:    []string{"school", "university"},
+		Limit:     10,
+		Offset:    20,
+
+		Certification: "CELTA",
+		DiversifyBy:   port.DiversifyLocation,
+		Trial:         true,
+	}
+
+	got := parseSearchValues(encodeSearchValues(query))
+
+	if got.Text != query.Text || got.Format != query.Format || got.Location != query.Location ||
+		got.Certification != query.Certification || got.DiversifyBy != query.DiversifyBy || got.Trial != query.Trial ||
+		got.Limit != query.Limit || got.Offset != query.Offset {
+		t.Errorf("scalar fields differ: got %+v", got)
+	}
+	if len(got.Subjects) != 2 || got.Subjects[1] != "physics" {
+		t.Errorf("expected subjects %v, got %v", query.Subjects, got.Subjects)
+	}
+	if !slices.Equal(got.Levels, query.Levels) {
+		t.Errorf("expected levels %v, got %v", query.Levels, got.Levels)
+	}
+	if *got.MinPrice != minPrice || *got.MaxPrice != maxPrice || *got.MinRating != minRating {
+		t.Errorf("expected numeric filters to round-trip, got %v %v %v", *got.MinPrice, *got.MaxPrice, *got.MinRating)
+	}
+	if encodeSearchValues(port.SearchQuery{}).Encode() != "" {
+		t.Error("expected an empty query to encode to nothing")
+	}
+}
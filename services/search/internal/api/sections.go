@@ -0,0 +1,164 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"search/internal/domain"
+	"search/internal/port"
+)
+
+// groupBySubject is the group_by value that sections search results by the
+// selected subjects, the only one supported.
+const groupBySubject = "subject"
+
+// maxSections bounds the subjects a grouped search sections results by, as
+// each one is a search of its own.
+const maxSections = 5
+
+// groupByFields are the values group_by accepts.
+var groupByFields = []string{groupBySubject}
+
+// checkGroupBy returns a 400 body when group_by names something results
+// cannot be grouped by, or the search it groups selects no subject, more
+// than maxSections of them, or is diversified. subjects are the selected
+// subjects as catalog keys.
+func checkGroupBy(q url.Values, subjects []string) *QueryError {
+	if field := q.Get("group_by"); !strings.EqualFold(field, groupBySubject) {
+		return &QueryError{
+			Error: fmt.Sprintf("cannot group by %q", field),
+			Param: "group_by",
+			Valid: groupByFields,
+		}
+	}
+	if len(subjects) == 0 {
+		return &QueryError{
+			Error: "group_by=subject requires at least one subject",
+			Param: "subjects",
+		}
+	}
+	if len(subjects) > maxSections {
+		return &QueryError{
+			Error: fmt.Sprintf("too many subjects to group by: got %d, at most %d allowed", len(subjects), maxSections),
+			Param: "subjects",
+			Limit: maxSections,
+		}
+	}
+	if q.Get("diversify_by") != "" {
+		return &QueryError{
+			Error: "cannot group and diversify results at once",
+			Param: "group_by",
+		}
+	}
+	return nil
+}
+
+// sectionsResponse is the body of a search with group_by=subject.
+type sectionsResponse struct {
+	Sections []section `json:"sections"`
+	// Total counts the tutors matching the search in any of its subjects,
+	// each once.
+	Total          int               `json:"total"`
+	Variant        string            `json:"variant,omitempty"`
+	Partial        bool              `json:"partial,omitempty"`
+	AppliedFilters *port.SearchQuery `json:"applied_filters"`
+	// TruncatedFields names the optional fields dropped to keep the
+	// response within budget, as on a flat search.
+	TruncatedFields []string `json:"truncated_fields,omitempty"`
+}
+
+// section is the tutors of one selected subject.
+type section struct {
+	Subject string `json:"subject"`
+	Label   string `json:"label"`
+	// Total counts the tutors teaching Subject that match, including those
+	// shown in another section.
+	Total  int            `json:"total"`
+	Tutors []domain.Tutor `json:"tutors"`
+}
+
+// groupSections builds a section from each subject's search result. A
+// tutor found under several subjects is kept only in the section it ranks
+// highest in, the first of them on a tie.
+func groupSections(subjects []string, results []*port.SearchResponse) []section {
+	type placement struct{ section, rank int }
+	best := make(map[int64]placement)
+	for s, result := range results {
+		for rank, t := range result.Results {
+			if p, ok := best[t.ID]; !ok || rank < p.rank {
+				best[t.ID] = placement{s, rank}
+			}
+		}
+	}
+
+	sections := make([]section, len(subjects))
+	for s, result := range results {
+		sections[s] = section{Subject: subjects[s], Total: result.Total, Tutors: []domain.Tutor{}}
+		for rank, t := range result.Results {
+			if best[t.ID] == (placement{s, rank}) {
+				sections[s].Tutors = append(sections[s].Tutors, t)
+			}
+		}
+	}
+	return sections
+}
+
+// searchSections answers a search with group_by=subject: each selected
+// subject is searched on its own with the other filters, limit and offset
+// applying per section, and the whole search once more for the total, all
+// in one round-trip.
+func (h *Handlers) searchSections(ctx context.Context, w http.ResponseWriter, r *http.Request, query port.SearchQuery, timing *port.Timing, start time.Time) {
+	applied := query.Normalized()
+	hash := query.Hash()
+	query = h.excludeHidden(ctx, query)
+
+	queries := make([]port.SearchQuery, 0, len(query.Subjects)+1)
+	for _, subject := range query.Subjects {
+		q := query
+		q.Subjects = []string{subject}
+		queries = append(queries, q)
+	}
+	// The whole search is only run for its total.
+	whole := query
+	whole.Limit, whole.Offset = 1, 0
+	queries = append(queries, whole)
+
+	results, err := h.os.SearchTutorsMulti(ctx, queries)
+	if err != nil {
+		h.logger.Error("Failed to search tutors by subject", "error", err)
+		respondBackendError(w, err, "Failed to search tutors")
+		return
+	}
+	for _, result := range results {
+		if rejectPartial(w, r, result) {
+			return
+		}
+	}
+
+	lang := h.language(w, r)
+	total := results[len(results)-1]
+	resp := sectionsResponse{
+		Sections:       groupSections(query.Subjects, results[:len(query.Subjects)]),
+		Total:          total.Total,
+		Variant:        total.Variant,
+		AppliedFilters: &applied,
+	}
+	for _, result := range results {
+		resp.Partial = resp.Partial || result.Partial
+	}
+	for i := range resp.Sections {
+		s := &resp.Sections[i]
+		s.Label = h.subjectLabel(s.Subject, lang)
+		stripIndexMeta(r, s.Tutors)
+		h.localizeSubjects(s.Tutors, lang)
+	}
+	h.fitSections(&resp)
+
+	w.Header().Set(QueryHashHeader, hash)
+	setServerTiming(w, timing, start)
+	respondJSON(w, http.StatusOK, resp)
+}
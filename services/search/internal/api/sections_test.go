@@ -0,0 +1,173 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"search/internal/domain"
+	"search/internal/opensearch"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func tutorsWithIDs(ids ...int64) *port.SearchResponse {
+	result := &port.SearchResponse{Total: len(ids) + 10}
+	for _, id := range ids {
+		result.Results = append(result.Results, domain.Tutor{ID: id})
+	}
+	return result
+}
+
+func sectionIDs(s section) []int64 {
+	ids := []int64{}
+	for _, t := range s.Tutors {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+func TestGroupSections(t *testing.T) {
+	sections := groupSections([]string{"math", "physics", "chess"}, []*port.SearchResponse{
+		tutorsWithIDs(1, 2, 4),
+		tutorsWithIDs(2, 7, 1, 4),
+		tutorsWithIDs(5, 7),
+	})
+
+	want := map[string][]int64{
+		// 1 and 4 rank higher here than in physics.
+		"math": {1, 4},
+		// 2 ranks first here; 7 ties second with chess and goes to the
+		// earlier section.
+		"physics": {2, 7},
+		"chess":   {5},
+	}
+	if len(sections) != 3 {
+		t.Fatalf("expected 3 sections, got %+v", sections)
+	}
+	for i, subject := range []string{"math", "physics", "chess"} {
+		if sections[i].Subject != subject {
+			t.Errorf("section %d: expected %s, got %s", i, subject, sections[i].Subject)
+		}
+		if got := sectionIDs(sections[i]); !slices.Equal(got, want[subject]) {
+			t.Errorf("%s: expected tutors %v, got %v", subject, want[subject], got)
+		}
+	}
+	if sections[1].Total != 14 {
+		t.Errorf("expected a section's total to count its duplicates, got %d", sections[1].Total)
+	}
+}
+
+func TestSearchTutors_GroupBySubject(t *testing.T) {
+	client := opensearch.NewMemoryClient()
+	for _, tutor := range []domain.Tutor{
+		{ID: 1, FullName: "Marie Curie", Subjects: []string{"math", "physics"}, Rating: 5},
+		{ID: 2, FullName: "Alan Turing", Subjects: []string{"math"}, Rating: 4},
+		{ID: 3, FullName: "Richard Feynman", Subjects: []string{"physics"}, Rating: 4.5},
+		{ID: 4, FullName: "Rosalind Franklin", Subjects: []string{"chemistry"}, Rating: 4.8},
+	} {
+		if err := client.UpsertTutor(context.Background(), &tutor); err != nil {
+			t.Fatalf("failed to seed tutor %d: %v", tutor.ID, err)
+		}
+	}
+	router := NewRouter(client, testutil.NewLogger(t), testRouterConfig())
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/tutors/search?group_by=subject&subjects=Maths&subjects=physics&sort=rating", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if rec.Header().Get(QueryHashHeader) == "" {
+		t.Error("expected a query hash")
+	}
+	var resp struct {
+		Sections []struct {
+			Subject string `json:"subject"`
+			Label   string `json:"label"`
+			Total   int    `json:"total"`
+			Tutors  []struct {
+				ID int64 `json:"id"`
+			} `json:"tutors"`
+		} `json:"sections"`
+		Total          int              `json:"total"`
+		AppliedFilters port.SearchQuery `json:"applied_filters"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	// Tutor 1 tops both subjects and is shown under the first only.
+	want := []struct {
+		subject, label string
+		total          int
+		ids            []int64
+	}{
+		{"math", "Mathematics", 2, []int64{1, 2}},
+		{"physics", "Physics", 2, []int64{3}},
+	}
+	if len(resp.Sections) != len(want) || resp.Total != 3 {
+		t.Fatalf("expected 2 sections of 3 tutors, got %s", rec.Body.String())
+	}
+	for i, w := range want {
+		s := resp.Sections[i]
+		var ids []int64
+		for _, tutor := range s.Tutors {
+			ids = append(ids, tutor.ID)
+		}
+		if s.Subject != w.subject || s.Label != w.label || s.Total != w.total || !slices.Equal(ids, w.ids) {
+			t.Errorf("section %d: expected %+v, got %+v", i, w, s)
+		}
+	}
+	if !slices.Equal(resp.AppliedFilters.Subjects, []string{"math", "physics"}) {
+		t.Errorf("expected the applied subjects, got %v", resp.AppliedFilters.Subjects)
+	}
+}
+
+func TestSearchTutors_GroupByErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		param string
+		limit float64
+	}{
+		{"unknown field", "group_by=level&subjects=math", "group_by", 0},
+		{"no subjects", "group_by=subject", "subjects", 0},
+		{"too many subjects", "group_by=subject&subjects=a&subjects=b&subjects=c&subjects=d&subjects=e&subjects=f", "subjects", maxSections},
+		{"diversified", "group_by=subject&subjects=math&diversify_by=location", "group_by", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{searchResult: &port.SearchResponse{}}
+			router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest("GET", "/tutors/search?"+tt.query, nil))
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+			}
+			var body QueryError
+			json.Unmarshal(rec.Body.Bytes(), &body)
+			if body.Param != tt.param || body.Limit != tt.limit {
+				t.Errorf("expected param %q with limit %g, got %+v", tt.param, tt.limit, body)
+			}
+		})
+	}
+}
+
+func TestSearchTutors_GroupByBackendError(t *testing.T) {
+	mock := &mockSearchClient{searchErr: errors.New("cluster unavailable")}
+	router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/tutors/search?group_by=subject&subjects=math", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, rec.Code)
+	}
+}
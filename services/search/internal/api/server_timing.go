@@ -0,0 +1,25 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"search/internal/port"
+)
+
+// ServerTimingHeader reports on successful JSON searches where the time
+// went: os is the search time OpenSearch reports, app the handler's wall
+// clock, which includes it, and retries how many backend requests were
+// sent again.
+const ServerTimingHeader = "Server-Timing"
+
+// setServerTiming writes the Server-Timing header for a request the handler
+// started at start, from what the backend noted in timing.
+func setServerTiming(w http.ResponseWriter, timing *port.Timing, start time.Time) {
+	w.Header().Set(ServerTimingHeader, fmt.Sprintf("os;dur=%d, app;dur=%.1f, retries;desc=%d",
+		timing.Took().Milliseconds(),
+		float64(time.Since(start).Microseconds())/1000,
+		timing.Retries(),
+	))
+}
@@ -0,0 +1,80 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+// timedSearchClient reports backend time and retries into the search's
+// Timing, as the OpenSearch client does.
+type timedSearchClient struct {
+	*mockSearchClient
+	took    time.Duration
+	retries int
+}
+
+func (c *timedSearchClient) SearchTutors(ctx context.Context, query port.SearchQuery) (*port.SearchResponse, error) {
+	timing := port.TimingFrom(ctx)
+	timing.AddTook(c.took)
+	for range c.retries + 1 {
+		timing.Attempt("search")
+	}
+	return c.mockSearchClient.SearchTutors(ctx, query)
+}
+
+func TestSearchTutors_ServerTiming(t *testing.T) {
+	client := &timedSearchClient{
+		mockSearchClient: &mockSearchClient{searchResult: &port.SearchResponse{}},
+		took:             12 * time.Millisecond,
+		retries:          2,
+	}
+	router := NewRouter(client, testutil.NewLogger(t), testRouterConfig())
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/tutors/search?q=math", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	got := rec.Header().Get(ServerTimingHeader)
+	if !regexp.MustCompile(`^os;dur=12, app;dur=\d+\.\d, retries;desc=2$`).MatchString(got) {
+		t.Errorf("unexpected %s header %q", ServerTimingHeader, got)
+	}
+}
+
+func TestSearchTutors_ServerTimingOmittedOnError(t *testing.T) {
+	tests := []struct {
+		name   string
+		mock   *mockSearchClient
+		path   string
+		status int
+	}{
+		{"backend error", &mockSearchClient{searchErr: errors.New("search error")}, "/tutors/search", http.StatusInternalServerError},
+		{"invalid query", &mockSearchClient{searchResult: &port.SearchResponse{}}, "/tutors/search?sort=nope", http.StatusBadRequest},
+		{"partial results refused", &mockSearchClient{searchResult: &port.SearchResponse{Partial: true, ShardsTotal: 2, ShardsFailed: 1}}, "/tutors/search?allow_partial=false", http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &timedSearchClient{mockSearchClient: tt.mock, took: time.Millisecond}
+			router := NewRouter(client, testutil.NewLogger(t), testRouterConfig())
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest("GET", tt.path, nil))
+
+			if rec.Code != tt.status {
+				t.Fatalf("expected status %d, got %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+			if got := rec.Header().Get(ServerTimingHeader); got != "" {
+				t.Errorf("expected no %s header, got %q", ServerTimingHeader, got)
+			}
+		})
+	}
+}
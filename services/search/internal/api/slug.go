@@ -0,0 +1,39 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"search/internal/domain"
+	"search/internal/port"
+)
+
+// slugLookupResponse is a tutor found by slug. CanonicalSlug is the slug
+// the tutor has now; when it differs from the one requested, clients
+// should redirect to it.
+type slugLookupResponse struct {
+	*domain.Tutor
+	CanonicalSlug string `json:"canonical_slug"`
+}
+
+// GetTutorBySlug returns the tutor with a slug, current or previous.
+func (h *Handlers) GetTutorBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := r.PathValue("slug")
+	if !domain.ValidSlug(slug) {
+		respondError(w, http.StatusBadRequest, "Invalid slug")
+		return
+	}
+
+	tutor, err := h.os.GetTutorBySlug(r.Context(), slug)
+	if errors.Is(err, port.ErrNotFound) {
+		respondError(w, http.StatusNotFound, "Tutor not found")
+		return
+	}
+	if err != nil {
+		h.logger.Error("Failed to get tutor by slug", "slug", slug, "error", err)
+		respondBackendError(w, err, "Failed to get tutor")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, slugLookupResponse{Tutor: tutor, CanonicalSlug: tutor.Slug})
+}
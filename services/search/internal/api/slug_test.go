@@ -0,0 +1,75 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"search/internal/domain"
+	"search/internal/testutil"
+)
+
+func TestGetTutorBySlug(t *testing.T) {
+	mock := &mockSearchClient{tutor: &domain.Tutor{ID: 7, Slug: "ann-lee", PreviousSlugs: []string{"ann-smith"}}}
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+	tests := []struct {
+		name       string
+		slug       string
+		getErr     error
+		wantStatus int
+	}{
+		{"current slug", "ann-lee", nil, http.StatusOK},
+		{"previous slug", "ann-smith", nil, http.StatusOK},
+		{"unknown slug", "bob", nil, http.StatusNotFound},
+		{"invalid slug", "Ann%20Lee", nil, http.StatusBadRequest},
+		{"backend error", "ann-lee", errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock.getErr = tt.getErr
+			req := httptest.NewRequest("GET", "/tutors/slug/"+tt.slug, nil)
+			req.SetPathValue("slug", tt.slug)
+			rec := httptest.NewRecorder()
+
+			handlers.GetTutorBySlug(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var got struct {
+				ID            int64  `json:"id"`
+				Slug          string `json:"slug"`
+				CanonicalSlug string `json:"canonical_slug"`
+			}
+			json.Unmarshal(rec.Body.Bytes(), &got)
+			if got.ID != 7 || got.Slug != "ann-lee" || got.CanonicalSlug != "ann-lee" {
+				t.Errorf("unexpected response %s", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestRouter_GetTutorBySlug(t *testing.T) {
+	mock := &mockSearchClient{tutor: &domain.Tutor{ID: 7, Slug: "ann-lee", PreviousSlugs: []string{"ann-smith"}}}
+	router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+	req := httptest.NewRequest("GET", "/tutors/slug/ann-smith", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var got map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &got)
+	if got["canonical_slug"] != "ann-lee" {
+		t.Errorf("expected canonical_slug ann-lee, got %v", got["canonical_slug"])
+	}
+}
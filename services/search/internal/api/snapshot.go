@@ -0,0 +1,25 @@
+package api
+
+import (
+	"net/http"
+
+	"search/internal/handler"
+)
+
+// SnapshotTracker is implemented by *handler.EventHandler.
+type SnapshotTracker interface {
+	SnapshotProgress() []handler.SnapshotProgress
+}
+
+// SnapshotIngestStatus reports how many records of each bootstrap snapshot
+// have been indexed, superseded by live events or quarantined.
+func (h *Handlers) SnapshotIngestStatus(w http.ResponseWriter, r *http.Request) {
+	if h.snapshots == nil {
+		respondError(w, http.StatusNotFound, "Snapshot ingestion requires the Kafka consumer")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]any{
+		"snapshots": h.snapshots.SnapshotProgress(),
+	})
+}
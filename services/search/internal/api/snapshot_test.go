@@ -0,0 +1,47 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"search/internal/handler"
+	"search/internal/testutil"
+)
+
+type fakeSnapshotTracker []handler.SnapshotProgress
+
+func (f fakeSnapshotTracker) SnapshotProgress() []handler.SnapshotProgress {
+	return f
+}
+
+func TestSnapshotIngestStatus(t *testing.T) {
+	tracker := fakeSnapshotTracker{{SnapshotID: "snap-1", Indexed: 40, Superseded: 2, Invalid: 1}}
+	handlers := NewHandlers(&mockSearchClient{}, testutil.NewLogger(t), WithSnapshotTracker(tracker))
+
+	rec := httptest.NewRecorder()
+	handlers.SnapshotIngestStatus(rec, httptest.NewRequest("GET", "/admin/snapshot-ingest/status", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	var response struct {
+		Snapshots []handler.SnapshotProgress `json:"snapshots"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &response)
+	if len(response.Snapshots) != 1 || response.Snapshots[0] != tracker[0] {
+		t.Errorf("expected %v, got %v", tracker, response.Snapshots)
+	}
+}
+
+func TestSnapshotIngestStatus_ConsumerDisabled(t *testing.T) {
+	handlers := NewHandlers(&mockSearchClient{}, testutil.NewLogger(t))
+
+	rec := httptest.NewRecorder()
+	handlers.SnapshotIngestStatus(rec, httptest.NewRequest("GET", "/admin/snapshot-ingest/status", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d, got %d", http.StatusNotFound, rec.Code)
+	}
+}
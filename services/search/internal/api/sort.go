@@ -0,0 +1,34 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"search/internal/port"
+)
+
+// sortOrders maps the accepted values of the sort parameter to
+// SearchQuery.Sort.
+var sortOrders = map[string]string{
+	"relevance":         port.SortRelevance,
+	port.SortRating:     port.SortRating,
+	port.SortPopularity: port.SortPopularity,
+}
+
+// validSorts lists the sort values in the order a 400 shows them.
+var validSorts = []string{"relevance", port.SortRating, port.SortPopularity}
+
+// checkSort returns a 400 body listing the valid orders when the sort
+// parameter is set to an unknown one.
+func checkSort(q url.Values) *QueryError {
+	sort := q.Get("sort")
+	if _, ok := sortOrders[strings.ToLower(sort)]; ok || sort == "" {
+		return nil
+	}
+	return &QueryError{
+		Error: fmt.Sprintf("unknown sort %q", sort),
+		Param: "sort",
+		Valid: validSorts,
+	}
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func TestSearchTutors_Sort(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus int
+		wantSort   string
+	}{
+		{"default", "/tutors/search", http.StatusOK, port.SortRelevance},
+		{"relevance", "/tutors/search?sort=relevance", http.StatusOK, port.SortRelevance},
+		{"rating", "/tutors/search?sort=rating", http.StatusOK, port.SortRating},
+		{"popularity", "/tutors/search?sort=Popularity", http.StatusOK, port.SortPopularity},
+		{"unknown", "/tutors/search?sort=price", http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{searchResult: &port.SearchResponse{}}
+			router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest("GET", tt.url, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if tt.wantStatus == http.StatusOK {
+				if mock.searchedQuery.Sort != tt.wantSort {
+					t.Errorf("expected sort %q, got %q", tt.wantSort, mock.searchedQuery.Sort)
+				}
+				return
+			}
+
+			var resp struct {
+				Error string   `json:"error"`
+				Param string   `json:"param"`
+				Valid []string `json:"valid"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Param != "sort" || resp.Error != `unknown sort "price"` {
+				t.Errorf("unexpected error response %+v", resp)
+			}
+			if !slices.Equal(resp.Valid, validSorts) {
+				t.Errorf("expected valid sorts %q, got %q", validSorts, resp.Valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+
+	"search/internal/domain"
+	"search/internal/port"
+)
+
+// subjectFacet is one entry of GET /subjects.
+type subjectFacet struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// levelFacet is one entry of the levels in GET /subjects.
+type levelFacet struct {
+	Key   string `json:"key"`
+	Count int    `json:"count"`
+}
+
+// facetsBody is the body of GET /subjects, also sent under "facets" with
+// the results of a search with include_facets=true.
+type facetsBody struct {
+	// Levels first keeps GET /subjects encoded as before.
+	Levels   []levelFacet   `json:"levels"`
+	Subjects []subjectFacet `json:"subjects"`
+	// Trial is how many tutors offer a free trial lesson, for the trial
+	// filter.
+	Trial int `json:"trial"`
+}
+
+// facetedResponse is a SearchResponse with its facets.
+type facetedResponse struct {
+	*port.SearchResponse
+	Facets *facetsBody `json:"facets"`
+}
+
+// Subjects lists every indexed subject with its display label and how many
+// tutors teach it, most taught first, and every teaching level with its
+// tutor count in domain.Levels order. The keys are what /tutors/search
+// accepts in subjects and level. facet_size keeps only that many subjects,
+// up to and by default the configured cap.
+func (h *Handlers) Subjects(w http.ResponseWriter, r *http.Request) {
+	size, qerr := h.queries.Limits.facetSize(r.URL.Query())
+	if qerr != nil {
+		respondJSON(w, http.StatusBadRequest, qerr)
+		return
+	}
+	lang := h.language(w, r)
+	counts, err := h.os.FacetCounts(r.Context(), size)
+	if err != nil {
+		h.logger.Error("Failed to count facets", "error", err)
+		respondBackendError(w, err, "Failed to load subjects")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, h.facetsBody(counts, lang))
+}
+
+// facetsBody labels counts in lang and lists the levels in domain.Levels
+// order.
+func (h *Handlers) facetsBody(counts *port.FacetCounts, lang string) facetsBody {
+	levels := make([]levelFacet, len(domain.Levels))
+	for i, level := range domain.Levels {
+		levels[i] = levelFacet{Key: level, Count: counts.Levels[level]}
+	}
+	return facetsBody{Subjects: h.subjectFacets(counts.Subjects, lang), Levels: levels, Trial: counts.Trial}
+}
+
+// subjectFacets labels counts in lang, most taught first and then by key.
+// An empty lang uses the catalog labels.
+func (h *Handlers) subjectFacets(counts map[string]int, lang string) []subjectFacet {
+	facets := make([]subjectFacet, 0, len(counts))
+	for key, n := range counts {
+		facets = append(facets, subjectFacet{Key: key, Label: h.subjectLabel(key, lang), Count: n})
+	}
+	slices.SortFunc(facets, func(a, b subjectFacet) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Key, b.Key)
+	})
+	return facets
+}
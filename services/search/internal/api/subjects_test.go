@@ -0,0 +1,254 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"search/internal/domain"
+	"search/internal/opensearch"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func TestSubjects(t *testing.T) {
+	mock := &mockSearchClient{facetCounts: port.FacetCounts{
+		Subjects: map[string]int{"physics": 2, "math": 5, "calculus": 2},
+		Levels:   map[string]int{"adult": 4, "school": 1},
+	}}
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+	rec := httptest.NewRecorder()
+	handlers.Subjects(rec, httptest.NewRequest("GET", "/subjects", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var resp struct {
+		Subjects []subjectFacet `json:"subjects"`
+		Levels   []levelFacet   `json:"levels"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	want := []subjectFacet{
+		{Key: "math", Label: "Mathematics", Count: 5},
+		{Key: "calculus", Label: "calculus", Count: 2},
+		{Key: "physics", Label: "Physics", Count: 2},
+	}
+	if !slices.Equal(resp.Subjects, want) {
+		t.Errorf("expected %+v, got %+v", want, resp.Subjects)
+	}
+	wantLevels := []levelFacet{
+		{Key: "school", Count: 1},
+		{Key: "university", Count: 0},
+		{Key: "adult", Count: 4},
+	}
+	if !slices.Equal(resp.Levels, wantLevels) {
+		t.Errorf("expected levels %+v, got %+v", wantLevels, resp.Levels)
+	}
+}
+
+func TestSubjects_Empty(t *testing.T) {
+	handlers := NewHandlers(&mockSearchClient{}, testutil.NewLogger(t))
+
+	rec := httptest.NewRecorder()
+	handlers.Subjects(rec, httptest.NewRequest("GET", "/subjects", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	want := `{"levels":[{"key":"school","count":0},{"key":"university","count":0},{"key":"adult","count":0}],"subjects":[],"trial":0}`
+	if got := strings.TrimSpace(rec.Body.String()); got != want {
+		t.Errorf("expected no subjects and zero level counts, got %s", got)
+	}
+}
+
+func TestSubjects_BackendErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"failure", errors.New("cluster unavailable"), http.StatusInternalServerError},
+		{"overloaded", port.ErrOverloaded, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{countsErr: tt.err}
+			handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+			rec := httptest.NewRecorder()
+			handlers.Subjects(rec, httptest.NewRequest("GET", "/subjects", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+		})
+	}
+}
+
+// TestSubjects_CanonicalFilters indexes raw spellings and checks that
+// searches, top tutors and the facet all agree on the canonical keys.
+func TestSubjects_CanonicalFilters(t *testing.T) {
+	client := opensearch.NewMemoryClient()
+	router := NewRouter(client, testutil.NewLogger(t), testRouterConfig())
+
+	for id, body := range map[string]string{
+		"1": `{"full_name": "Ada Lovelace", "subjects": ["Maths", "Calculus"]}`,
+		"2": `{"full_name": "Alan Turing", "subjects": ["math", "Computer Science"]}`,
+		"3": `{"full_name": "Marie Curie", "subjects": ["Physics"]}`,
+	} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest("PUT", "/tutors/"+id, bytes.NewReader([]byte(body))))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("failed to index tutor %s: %d %s", id, rec.Code, rec.Body.String())
+		}
+	}
+
+	tutor, err := client.GetTutor(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"math", "calculus"}; !slices.Equal(tutor.Subjects, want) {
+		t.Errorf("expected indexed subjects %q, got %q", want, tutor.Subjects)
+	}
+	if want := []string{"Mathematics", "Calculus"}; !slices.Equal(tutor.SubjectsDisplay, want) {
+		t.Errorf("expected indexed labels %q, got %q", want, tutor.SubjectsDisplay)
+	}
+
+	for _, raw := range []string{"math", "Mathematics", "MATHS"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest("GET", "/tutors/search?subjects="+raw, nil))
+		var resp port.SearchResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if resp.Total != 2 {
+			t.Errorf("subjects=%s: expected 2 tutors, got %d", raw, resp.Total)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/tutors/top?subjects=Computer%20Science", nil))
+	var top map[string][]domain.Tutor
+	if err := json.Unmarshal(rec.Body.Bytes(), &top); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(top["computer-science"]) != 1 {
+		t.Errorf("expected top tutors under the canonical key, got %v", top)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/subjects", nil))
+	var facets struct {
+		Subjects []subjectFacet `json:"subjects"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &facets); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	want := []subjectFacet{
+		{Key: "math", Label: "Mathematics", Count: 2},
+		{Key: "calculus", Label: "calculus", Count: 1},
+		{Key: "computer-science", Label: "Computer Science", Count: 1},
+		{Key: "physics", Label: "Physics", Count: 1},
+	}
+	if !slices.Equal(facets.Subjects, want) {
+		t.Errorf("expected %+v, got %+v", want, facets.Subjects)
+	}
+}
+
+func TestSearchTutors_IncludeFacets(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		result    port.SearchResponse
+		wantAlts  bool
+		wantFacet bool
+	}{
+		{name: "without facets", url: "/tutors/search?q=math", result: port.SearchResponse{Results: []domain.Tutor{{ID: 1}}, Total: 1}},
+		{name: "with facets", url: "/tutors/search?q=math&include_facets=true", result: port.SearchResponse{Results: []domain.Tutor{{ID: 1}}, Total: 1}, wantFacet: true},
+		{
+			name: "diversified with facets",
+			url:  "/tutors/search?diversify_by=location&include_facets=true",
+			result: port.SearchResponse{
+				Results:    []domain.Tutor{{ID: 1, Location: "London"}},
+				Total:      2,
+				Alternates: map[int64][]domain.Tutor{1: {{ID: 2, Location: "London"}}},
+			},
+			wantAlts:  true,
+			wantFacet: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{
+				searchResult: &tt.result,
+				facetCounts:  port.FacetCounts{Subjects: map[string]int{"math": 5}, Levels: map[string]int{"adult": 4}},
+			}
+			router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest("GET", tt.url, nil))
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+			}
+			if mock.searchedWithFacets != tt.wantFacet {
+				t.Errorf("expected searched with facets %v, got %v", tt.wantFacet, mock.searchedWithFacets)
+			}
+			var resp struct {
+				Results []struct {
+					ID         int64          `json:"id"`
+					Alternates []domain.Tutor `json:"alternates"`
+				} `json:"results"`
+				Total  int         `json:"total"`
+				Facets *facetsBody `json:"facets"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(resp.Results) != 1 || resp.Total != tt.result.Total {
+				t.Errorf("unexpected results %s", rec.Body.String())
+			}
+			if got := len(resp.Results) == 1 && len(resp.Results[0].Alternates) == 1; got != tt.wantAlts {
+				t.Errorf("expected alternates %v, got %s", tt.wantAlts, rec.Body.String())
+			}
+			if !tt.wantFacet {
+				if resp.Facets != nil {
+					t.Errorf("expected no facets, got %s", rec.Body.String())
+				}
+				return
+			}
+			if resp.Facets == nil {
+				t.Fatalf("expected facets, got %s", rec.Body.String())
+			}
+			if want := []subjectFacet{{Key: "math", Label: "Mathematics", Count: 5}}; !slices.Equal(resp.Facets.Subjects, want) {
+				t.Errorf("expected subjects %+v, got %+v", want, resp.Facets.Subjects)
+			}
+			if len(resp.Facets.Levels) != len(domain.Levels) || resp.Facets.Levels[2] != (levelFacet{Key: "adult", Count: 4}) {
+				t.Errorf("expected every level, got %+v", resp.Facets.Levels)
+			}
+		})
+	}
+}
+
+func TestSearchTutors_IncludeFacetsError(t *testing.T) {
+	mock := &mockSearchClient{searchResult: &port.SearchResponse{}, countsErr: port.ErrOverloaded}
+	router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/tutors/search?include_facets=true", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d, got %d", http.StatusServiceUnavailable, rec.Code)
+	}
+}
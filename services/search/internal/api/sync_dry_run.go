@@ -0,0 +1,89 @@
+package api
+
+import (
+	"net/http"
+	"slices"
+
+	"search/internal/audit"
+	"search/internal/domain"
+)
+
+// maxDryRunUpdates caps the changed-field lists a sync dry run reports.
+const maxDryRunUpdates = 100
+
+type syncUpdate struct {
+	ID      int64    `json:"id"`
+	Changed []string `json:"changed"`
+}
+
+type syncDryRunResponse struct {
+	DryRun      bool `json:"dry_run"`
+	Total       int  `json:"total"`
+	WouldCreate int  `json:"would_create"`
+	WouldUpdate int  `json:"would_update"`
+	Unchanged   int  `json:"unchanged"`
+	// Invalid counts tutors a real sync would skip.
+	Invalid int `json:"invalid"`
+	// Updates lists the fields of the first maxDryRunUpdates would-be
+	// updates, in request order.
+	Updates []syncUpdate `json:"updates"`
+}
+
+// syncDryRun reports what SyncTutors would do with tutors without writing
+// anything: each tutor is prepared as a sync would, then compared with its
+// indexed document. A tutor repeated in the body is compared with its
+// earlier entry, as the sync would have written it by then.
+func (h *Handlers) syncDryRun(w http.ResponseWriter, r *http.Request, tutors []domain.Tutor) {
+	ctx := r.Context()
+	audit.Skip(ctx)
+
+	ids := make([]int64, 0, len(tutors))
+	for _, t := range tutors {
+		ids = append(ids, t.ID)
+	}
+	slices.Sort(ids)
+	indexed, err := h.os.GetTutors(ctx, slices.Compact(ids))
+	if err != nil {
+		h.logger.Error("Failed to load indexed tutors for sync dry run", "error", err)
+		respondBackendError(w, err, "Failed to read index")
+		return
+	}
+
+	resp := h.compareSync(tutors, indexed)
+	h.logger.Info("Sync dry run",
+		"total", resp.Total,
+		"would_create", resp.WouldCreate,
+		"would_update", resp.WouldUpdate,
+		"unchanged", resp.Unchanged,
+		"invalid", resp.Invalid,
+	)
+	respondJSON(w, http.StatusOK, resp)
+}
+
+// compareSync classifies each of tutors against indexed, which it updates
+// with every valid tutor.
+func (h *Handlers) compareSync(tutors []domain.Tutor, indexed map[int64]domain.Tutor) syncDryRunResponse {
+	resp := syncDryRunResponse{DryRun: true, Total: len(tutors), Updates: []syncUpdate{}}
+	for _, tutor := range tutors {
+		err := h.sanitize(&tutor)
+		if err == nil {
+			err = tutor.Validate()
+		}
+		if err != nil {
+			resp.Invalid++
+			continue
+		}
+		if current, ok := indexed[tutor.ID]; !ok {
+			resp.WouldCreate++
+		} else if changed := domain.ChangedFields(&current, &tutor); len(changed) == 0 {
+			resp.Unchanged++
+		} else {
+			resp.WouldUpdate++
+			if len(resp.Updates) < maxDryRunUpdates {
+				resp.Updates = append(resp.Updates, syncUpdate{ID: tutor.ID, Changed: changed})
+			}
+		}
+		indexed[tutor.ID] = tutor
+	}
+	return resp
+}
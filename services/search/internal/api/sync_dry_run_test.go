@@ -0,0 +1,92 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"search/internal/domain"
+	"search/internal/testutil"
+)
+
+func postSyncDryRun(t *testing.T, h *Handlers, tutors []domain.Tutor) (int, syncDryRunResponse) {
+	t.Helper()
+
+	body, _ := json.Marshal(tutors)
+	rec := httptest.NewRecorder()
+	h.SyncTutors(rec, httptest.NewRequest("POST", "/admin/sync?dry_run=true", bytes.NewReader(body)))
+
+	var resp syncDryRunResponse
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	return rec.Code, resp
+}
+
+func TestSyncTutors_DryRun(t *testing.T) {
+	mock := &mockSearchClient{indexed: map[int64]domain.Tutor{
+		2: {ID: 2, FullName: "Tutor 2", HourlyRate: 40},
+		3: {ID: 3, FullName: "Tutor 3", HourlyRate: 30},
+	}}
+	handlers := NewHandlers(mock, testutil.NewLogger(t),
+		WithRatingMode(domain.RatingModeStrict))
+
+	code, resp := postSyncDryRun(t, handlers, []domain.Tutor{
+		{ID: 1, FullName: "Tutor 1"},
+		{ID: 2, FullName: "Tutor 2", HourlyRate: 45, Bio: "New bio"},
+		{ID: 3, FullName: "Tutor 3", HourlyRate: 30},
+		{ID: 4, FullName: "Tutor 4", Rating: 5},
+		{ID: 1, FullName: "Tutor 1"},
+	})
+
+	if code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d", http.StatusOK, code)
+	}
+	if !resp.DryRun || resp.Total != 5 {
+		t.Errorf("expected a dry run over 5 tutors, got %+v", resp)
+	}
+	if resp.WouldCreate != 1 || resp.WouldUpdate != 1 || resp.Unchanged != 2 || resp.Invalid != 1 {
+		t.Errorf("expected 1 create, 1 update, 2 unchanged and 1 invalid, got %+v", resp)
+	}
+	if len(resp.Updates) != 1 || resp.Updates[0].ID != 2 || !slices.Equal(resp.Updates[0].Changed, []string{"bio", "hourly_rate"}) {
+		t.Errorf("expected tutor 2 to change bio and hourly_rate, got %+v", resp.Updates)
+	}
+	if mock.upsertedTutor != nil {
+		t.Errorf("expected no writes, got an upsert of tutor %d", mock.upsertedTutor.ID)
+	}
+}
+
+func TestSyncTutors_DryRunCapsUpdates(t *testing.T) {
+	mock := &mockSearchClient{indexed: map[int64]domain.Tutor{}}
+	var tutors []domain.Tutor
+	for id := range int64(maxDryRunUpdates + 5) {
+		mock.indexed[id+1] = domain.Tutor{ID: id + 1, FullName: "Old"}
+		tutors = append(tutors, domain.Tutor{ID: id + 1, FullName: "New"})
+	}
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+	_, resp := postSyncDryRun(t, handlers, tutors)
+
+	if resp.WouldUpdate != maxDryRunUpdates+5 {
+		t.Errorf("expected %d updates, got %d", maxDryRunUpdates+5, resp.WouldUpdate)
+	}
+	if len(resp.Updates) != maxDryRunUpdates || resp.Updates[0].ID != 1 {
+		t.Errorf("expected the first %d updates listed, got %d", maxDryRunUpdates, len(resp.Updates))
+	}
+}
+
+func TestSyncTutors_DryRunBackendError(t *testing.T) {
+	mock := &mockSearchClient{getErr: errors.New("cluster unavailable")}
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+	code, _ := postSyncDryRun(t, handlers, []domain.Tutor{{ID: 1}})
+
+	if code != http.StatusInternalServerError {
+		t.Errorf("expected status %d, got %d", http.StatusInternalServerError, code)
+	}
+	if mock.upsertedTutor != nil {
+		t.Error("expected no writes")
+	}
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Bounds on GET /tutors/top, which the landing page calls with a handful of
+// subjects.
+const (
+	maxTopSubjects       = 10
+	maxTopPerSubject     = 10
+	defaultTopPerSubject = 4
+)
+
+// TopTutors returns the best rated tutors for each requested subject, keyed
+// by subject, with a single backend request. subjects is comma-separated or
+// repeated; per_subject defaults to 4.
+func (h *Handlers) TopTutors(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	subjects := h.subjects.Keys(parseSubjectList(q["subjects"]))
+	if len(subjects) == 0 {
+		respondError(w, http.StatusBadRequest, "subjects is required")
+		return
+	}
+	if len(subjects) > maxTopSubjects {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("At most %d subjects are allowed", maxTopSubjects))
+		return
+	}
+
+	perSubject := defaultTopPerSubject
+	if raw := q.Get("per_subject"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v < 1 || v > maxTopPerSubject {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("per_subject must be between 1 and %d", maxTopPerSubject))
+			return
+		}
+		perSubject = v
+	}
+
+	result, err := h.os.TopTutorsBySubject(r.Context(), subjects, perSubject)
+	if err != nil {
+		h.logger.Error("Failed to load top tutors", "subjects", subjects, "error", err)
+		respondBackendError(w, err, "Failed to load top tutors")
+		return
+	}
+	lang := h.language(w, r)
+	for _, tutors := range result {
+		stripIndexMeta(r, tutors)
+		h.localizeSubjects(tutors, lang)
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// parseSubjectList splits comma-separated values, dropping blanks and
+// repeats while keeping the first-seen order.
+func parseSubjectList(values []string) []string {
+	var subjects []string
+	seen := make(map[string]bool)
+	for _, raw := range values {
+		for _, part := range strings.Split(raw, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" || seen[part] {
+				continue
+			}
+			seen[part] = true
+			subjects = append(subjects, part)
+		}
+	}
+	return subjects
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"search/internal/domain"
+	"search/internal/opensearch"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func TestTopTutors(t *testing.T) {
+	client := opensearch.NewMemoryClient()
+	ctx := context.Background()
+	for _, tutor := range []domain.Tutor{
+		{ID: 1, FullName: "Marie Curie", Subjects: []string{"physics"}, Rating: 5, ReviewsCount: 10},
+		{ID: 2, FullName: "Alan Turing", Subjects: []string{"math"}, Rating: 4.6, ReviewsCount: 8},
+		{ID: 3, FullName: "Ada Lovelace", Subjects: []string{"math"}, Rating: 4.9, ReviewsCount: 20},
+	} {
+		client.UpsertTutor(ctx, &tutor)
+	}
+	router := NewRouter(client, testutil.NewLogger(t), testRouterConfig())
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/tutors/top?subjects=math,physics,english&per_subject=1", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var resp map[string][]domain.Tutor
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp) != 3 {
+		t.Errorf("expected three subjects, got %v", resp)
+	}
+	if len(resp["math"]) != 1 || resp["math"][0].ID != 3 {
+		t.Errorf("expected the best rated math tutor, got %+v", resp["math"])
+	}
+	if len(resp["physics"]) != 1 || resp["physics"][0].ID != 1 {
+		t.Errorf("expected the physics tutor, got %+v", resp["physics"])
+	}
+	if tutors, ok := resp["english"]; !ok || len(tutors) != 0 {
+		t.Errorf("expected an empty english list, got %v", resp["english"])
+	}
+}
+
+func TestTopTutors_Params(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		wantStatus     int
+		wantSubjects   []string
+		wantPerSubject int
+	}{
+		{"comma separated", "subjects=math,physics&per_subject=2", http.StatusOK, []string{"math", "physics"}, 2},
+		{"repeated", "subjects=math&subjects=physics", http.StatusOK, []string{"math", "physics"}, 4},
+		{"blanks and repeats dropped", "subjects=math,,%20math%20,physics,math", http.StatusOK, []string{"math", "physics"}, 4},
+		{"ten subjects", "subjects=a,b,c,d,e,f,g,h,i,j", http.StatusOK, []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}, 4},
+		{"per_subject at cap", "subjects=math&per_subject=10", http.StatusOK, []string{"math"}, 10},
+		{"missing subjects", "per_subject=2", http.StatusBadRequest, nil, 0},
+		{"blank subjects", "subjects=,%20", http.StatusBadRequest, nil, 0},
+		{"too many subjects", "subjects=a,b,c,d,e,f,g,h,i,j,k", http.StatusBadRequest, nil, 0},
+		{"per_subject above cap", "subjects=math&per_subject=11", http.StatusBadRequest, nil, 0},
+		{"per_subject zero", "subjects=math&per_subject=0", http.StatusBadRequest, nil, 0},
+		{"per_subject not a number", "subjects=math&per_subject=four", http.StatusBadRequest, nil, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{}
+			handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+			rec := httptest.NewRecorder()
+			handlers.TopTutors(rec, httptest.NewRequest("GET", "/tutors/top?"+tt.query, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			if !slices.Equal(mock.topSubjects, tt.wantSubjects) {
+				t.Errorf("expected subjects %q, got %q", tt.wantSubjects, mock.topSubjects)
+			}
+			if mock.topPerSubject != tt.wantPerSubject {
+				t.Errorf("expected per_subject %d, got %d", tt.wantPerSubject, mock.topPerSubject)
+			}
+		})
+	}
+}
+
+func TestTopTutors_BackendErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"failure", errors.New("cluster unavailable"), http.StatusInternalServerError},
+		{"overloaded", port.ErrOverloaded, http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{topErr: tt.err}
+			handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+			rec := httptest.NewRecorder()
+			handlers.TopTutors(rec, httptest.NewRequest("GET", "/tutors/top?subjects=math", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if !strings.Contains(rec.Body.String(), "error") {
+				t.Errorf("expected an error body, got %s", rec.Body.String())
+			}
+		})
+	}
+}
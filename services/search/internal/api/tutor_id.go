@@ -0,0 +1,26 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// CodeInvalidID is the error code of a 400 for a tutor ID path parameter
+// that is not a number between 1 and the configured maximum.
+const CodeInvalidID = "invalid_id"
+
+// tutorID parses the tutor ID in the path parameter name. An ID that is not
+// an integer from 1 to h.maxTutorID gets a 400 with code CodeInvalidID and
+// false.
+func (h *Handlers) tutorID(w http.ResponseWriter, r *http.Request, name string) (int64, bool) {
+	id, err := strconv.ParseInt(r.PathValue(name), 10, 64)
+	if err != nil || id < 1 || id > h.maxTutorID {
+		respondJSON(w, http.StatusBadRequest, map[string]string{
+			"error": fmt.Sprintf("Invalid tutor ID: must be an integer from 1 to %d", h.maxTutorID),
+			"code":  CodeInvalidID,
+		})
+		return 0, false
+	}
+	return id, true
+}
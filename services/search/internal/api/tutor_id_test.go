@@ -0,0 +1,86 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"search/internal/domain"
+	"search/internal/testutil"
+)
+
+func TestTutorIDPathParameter(t *testing.T) {
+	mock := &mockSearchClient{tutor: &domain.Tutor{ID: 7, FullName: "Ann", Subjects: []string{"math"}}}
+	router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+	requests := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{"GET", "/tutors/%s", ""},
+		{"GET", "/tutors/%s/alternatives", ""},
+		{"PUT", "/tutors/%s", `{"full_name": "Ann"}`},
+		{"DELETE", "/tutors/%s?idempotent=true", ""},
+	}
+	tests := []struct {
+		id      string
+		wantBad bool
+	}{
+		{"1", false},
+		{"7", false},
+		{"2147483647", false},
+		{"2147483648", false},
+		{"9007199254740991", false},
+		{"0", true},
+		{"-1", true},
+		{"9007199254740992", true},
+		{"9223372036854775807", true},
+		{"9223372036854775808", true},
+		{"abc", true},
+		{"1.5", true},
+	}
+
+	for _, req := range requests {
+		for _, tt := range tests {
+			path := fmt.Sprintf(req.path, tt.id)
+			t.Run(req.method+" "+path, func(t *testing.T) {
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, httptest.NewRequest(req.method, path, bytes.NewReader([]byte(req.body))))
+
+				if !tt.wantBad {
+					if rec.Code == http.StatusBadRequest {
+						t.Errorf("expected ID %s accepted, got 400: %s", tt.id, rec.Body.String())
+					}
+					return
+				}
+				if rec.Code != http.StatusBadRequest {
+					t.Fatalf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+				}
+				var resp map[string]string
+				json.Unmarshal(rec.Body.Bytes(), &resp)
+				if resp["code"] != CodeInvalidID {
+					t.Errorf("expected code %q, got %v", CodeInvalidID, resp)
+				}
+			})
+		}
+	}
+}
+
+func TestTutorIDPathParameter_ConfiguredMax(t *testing.T) {
+	mock := &mockSearchClient{tutor: &domain.Tutor{ID: 7}}
+	cfg := testRouterConfig()
+	cfg.MaxTutorID = 1000
+	router := NewRouter(mock, testutil.NewLogger(t), cfg)
+
+	for id, want := range map[string]int{"1000": http.StatusNotFound, "1001": http.StatusBadRequest} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest("GET", "/tutors/"+id, nil))
+		if rec.Code != want {
+			t.Errorf("ID %s: expected status %d, got %d", id, want, rec.Code)
+		}
+	}
+}
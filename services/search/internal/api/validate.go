@@ -0,0 +1,189 @@
+package api
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Hard caps QueryValidator applies whatever the configured QueryLimits.
+const (
+	// DefaultMaxQueryBytes bounds the raw query string. It leaves room for
+	// SEARCH_MAX_EXCLUDE_IDS worth of IDs.
+	DefaultMaxQueryBytes = 16 << 10
+	// DefaultMaxValuesPerKey bounds how often one parameter may repeat,
+	// unless QueryLimits caps that parameter itself.
+	DefaultMaxValuesPerKey = 50
+	// DefaultMaxTextLength bounds the free-text parameters, in characters.
+	DefaultMaxTextLength = 256
+)
+
+// textParams are the parameters matched as free text against the index.
+var textParams = []string{"q", "location", "certification", "badge"}
+
+// numericCaps bounds the magnitude of the numeric parameters. Values within
+// a cap are clamped or ignored as before; beyond it the request is rejected
+// rather than handed to the query builder.
+var numericCaps = []struct {
+	param string
+	max   float64
+}{
+	{"limit", 10 * maxExportLimit},
+	// OpenSearch refuses to page past index.max_result_window.
+	{"offset", maxQualityWindow},
+	{"min_price", 1e6},
+	{"max_price", 1e6},
+	{"min_rating", 5},
+	{"available_within_days", 366},
+}
+
+// QueryError is a 400 body naming the query parameter at fault, with the
+// cap it broke or the values it accepts where those apply.
+type QueryError struct {
+	Error string   `json:"error"`
+	Param string   `json:"param"`
+	Limit float64  `json:"limit,omitempty"`
+	Valid []string `json:"valid,omitempty"`
+}
+
+// QueryValidator checks /tutors/search parameters before they are parsed
+// into a SearchQuery, so polluted or absurd query strings never reach the
+// query builder. Searches, exports and saved searches share one.
+type QueryValidator struct {
+	Limits QueryLimits
+	// MaxQueryBytes, MaxValuesPerKey and MaxTextLength are the hard caps;
+	// zero disables one.
+	MaxQueryBytes   int
+	MaxValuesPerKey int
+	MaxTextLength   int
+}
+
+// NewQueryValidator returns a QueryValidator enforcing limits and the
+// default hard caps.
+func NewQueryValidator(limits QueryLimits) QueryValidator {
+	return QueryValidator{
+		Limits:          limits,
+		MaxQueryBytes:   DefaultMaxQueryBytes,
+		MaxValuesPerKey: DefaultMaxValuesPerKey,
+		MaxTextLength:   DefaultMaxTextLength,
+	}
+}
+
+// Validate parses the raw query string and checks it against the caps,
+// facet_size, the known levels and sort orders and the available_between window, returning
+// the first violation. Malformed pairs are dropped as url.URL.Query does.
+func (v QueryValidator) Validate(raw string) (url.Values, *QueryError) {
+	if v.MaxQueryBytes > 0 && len(raw) > v.MaxQueryBytes {
+		return nil, &QueryError{
+			Error: fmt.Sprintf("query string too long: got %d bytes, at most %d allowed", len(raw), v.MaxQueryBytes),
+			Limit: float64(v.MaxQueryBytes),
+		}
+	}
+	q, _ := url.ParseQuery(raw)
+
+	if err := v.Limits.check(q); err != nil {
+		return nil, &QueryError{Error: err.Error(), Param: err.Param, Limit: float64(err.Limit)}
+	}
+	if _, err := v.Limits.facetSize(q); err != nil {
+		return nil, err
+	}
+	if err := v.checkRepeats(q); err != nil {
+		return nil, err
+	}
+	if err := v.checkText(q); err != nil {
+		return nil, err
+	}
+	if err := checkNumbers(q); err != nil {
+		return nil, err
+	}
+	if err := checkLevels(q); err != nil {
+		return nil, err
+	}
+	if err := checkSort(q); err != nil {
+		return nil, err
+	}
+	if err := checkDiversify(q); err != nil {
+		return nil, err
+	}
+	if _, err := availableBetween(q, time.Now()); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// checkRepeats caps how many values each parameter lists, leaving those
+// with a QueryLimits cap of their own to it.
+func (v QueryValidator) checkRepeats(q url.Values) *QueryError {
+	if v.MaxValuesPerKey <= 0 {
+		return nil
+	}
+	for param, values := range q {
+		if v.Limits.caps(param) || len(values) <= v.MaxValuesPerKey {
+			continue
+		}
+		return &QueryError{
+			Error: fmt.Sprintf("too many %s values: got %d, at most %d allowed", param, len(values), v.MaxValuesPerKey),
+			Param: param,
+			Limit: float64(v.MaxValuesPerKey),
+		}
+	}
+	return nil
+}
+
+func (v QueryValidator) checkText(q url.Values) *QueryError {
+	if v.MaxTextLength <= 0 {
+		return nil
+	}
+	for _, param := range textParams {
+		for _, value := range q[param] {
+			if n := utf8.RuneCountInString(value); n > v.MaxTextLength {
+				return &QueryError{
+					Error: fmt.Sprintf("%s too long: got %d characters, at most %d allowed", param, n, v.MaxTextLength),
+					Param: param,
+					Limit: float64(v.MaxTextLength),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// checkNumbers rejects numeric parameters beyond their cap, including ones
+// too large to parse, and NaN. Other unparseable values are left for
+// parseSearchValues to ignore.
+func checkNumbers(q url.Values) *QueryError {
+	for _, c := range numericCaps {
+		raw := q.Get(c.param)
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil && !math.IsInf(n, 0) {
+			continue
+		}
+		if math.IsNaN(n) || math.Abs(n) > c.max {
+			return &QueryError{
+				Error: fmt.Sprintf("%s out of range: at most %g allowed", c.param, c.max),
+				Param: c.param,
+				Limit: c.max,
+			}
+		}
+	}
+	return nil
+}
+
+// validateQuery writes a 400 with the first violation and returns false
+// when the raw query string fails h.queries.
+func (h *Handlers) validateQuery(w http.ResponseWriter, raw string) (url.Values, bool) {
+	q, err := h.queries.Validate(raw)
+	if err != nil {
+		respondJSON(w, http.StatusBadRequest, err)
+		return nil, false
+	}
+	return q, true
+}
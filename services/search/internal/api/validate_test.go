@@ -0,0 +1,176 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func TestSearchTutors_HostileQueries(t *testing.T) {
+	tests := []struct {
+		name      string
+		query     string
+		wantParam string
+	}{
+		{"huge limit", "limit=999999999", "limit"},
+		{"limit beyond int", "limit=99999999999999999999999", "limit"},
+		{"deep offset", "offset=20000", "offset"},
+		{"repeated subjects", strings.Repeat("subjects=math&", 500), "subjects"},
+		{"repeated unknown param", strings.Repeat("utm=x&", 51), "utm"},
+		{"long text", "q=" + strings.Repeat("a", 10000), "q"},
+		{"long location", "location=" + strings.Repeat("é", DefaultMaxTextLength+1), "location"},
+		{"NaN price", "min_price=NaN", "min_price"},
+		{"infinite price", "max_price=1e999", "max_price"},
+		{"negative infinite price", "max_price=-Inf", "max_price"},
+		{"rating out of range", "min_rating=50", "min_rating"},
+		{"far availability", "available_within_days=100000", "available_within_days"},
+		{"oversized query string", "fields=" + strings.Repeat("bio,", DefaultMaxQueryBytes/4), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockSearchClient{searchResult: &port.SearchResponse{}}
+			router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest("GET", "/tutors/search?"+tt.query, nil))
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+			}
+			var resp QueryError
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Param != tt.wantParam || resp.Error == "" || resp.Limit == 0 {
+				t.Errorf("unexpected error response %+v", resp)
+			}
+			if mock.searchedQuery.Text != "" || mock.searchedQuery.Limit != 0 {
+				t.Errorf("expected no search, got %+v", mock.searchedQuery)
+			}
+		})
+	}
+}
+
+func TestSearchTutors_WithinHardCaps(t *testing.T) {
+	query := url.Values{
+		"q":                     {strings.Repeat("a", DefaultMaxTextLength)},
+		"limit":                 {"5000"},
+		"offset":                {"-3"},
+		"min_price":             {"0"},
+		"max_price":             {"1000000"},
+		"min_rating":            {"4.5"},
+		"available_within_days": {"not-a-number"},
+	}
+	mock := &mockSearchClient{searchResult: &port.SearchResponse{}}
+	router := NewRouter(mock, testutil.NewLogger(t), testRouterConfig())
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("GET", "/tutors/search?"+query.Encode(), nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if mock.searchedQuery.Limit != 5000 || mock.searchedQuery.AvailableWithinDays != 0 {
+		t.Errorf("expected the query to pass through unchanged, got %+v", mock.searchedQuery)
+	}
+}
+
+func TestExportTutorsCSV_HostileQuery(t *testing.T) {
+	mock := &mockSearchClient{searchResult: &port.SearchResponse{}}
+	handlers := NewHandlers(mock, testutil.NewLogger(t))
+
+	rec := httptest.NewRecorder()
+	handlers.ExportTutorsCSV(rec, httptest.NewRequest("GET", "/tutors/search?format=csv&limit=999999999", nil))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status %d, got %d", http.StatusBadRequest, rec.Code)
+	}
+	if mock.scanLimit != 0 {
+		t.Errorf("expected no export, got scan limit %d", mock.scanLimit)
+	}
+}
+
+// hostileQuery builds a random query string from the search parameters,
+// mixing in repeats, oversized and non-numeric values, malformed escapes
+// and unknown keys.
+func hostileQuery(rng *rand.Rand) string {
+	keys := []string{
+		"q", "subjects", "location", "certification", "badge", "level", "sort", "format",
+		"min_price", "max_price", "min_rating", "available_within_days", "available_between",
+		"student_tz", "exclude_ids", "fields", "limit", "offset", "x",
+	}
+	values := []func() string{
+		func() string { return fmt.Sprint(rng.Intn(100)) },
+		func() string { return fmt.Sprint(rng.Int63()) },
+		func() string { return fmt.Sprint(-rng.Int63()) },
+		func() string { return fmt.Sprintf("%g", rng.NormFloat64()*math.Pow(10, float64(rng.Intn(400)))) },
+		func() string { return []string{"NaN", "Inf", "-Inf", "+inf", "1e309", "0x1p-2", " 7", ""}[rng.Intn(8)] },
+		func() string { return strings.Repeat("ß", rng.Intn(2*DefaultMaxTextLength)) },
+		func() string { return strings.Repeat("1,", rng.Intn(1000)) },
+		func() string {
+			return []string{"school", "adult", "rating", "18:00-21:00", "25:00-", "Europe/Berlin"}[rng.Intn(6)]
+		},
+		func() string { return "%zz%" },
+	}
+
+	var b strings.Builder
+	for range rng.Intn(60) {
+		key := keys[rng.Intn(len(keys))]
+		repeats := 1
+		if rng.Intn(10) == 0 {
+			repeats = rng.Intn(100)
+		}
+		for range repeats {
+			fmt.Fprintf(&b, "%s=%s&", key, url.QueryEscape(values[rng.Intn(len(values))]()))
+		}
+	}
+	return b.String()
+}
+
+func TestQueryValidator_RandomHostileQueries(t *testing.T) {
+	v := NewQueryValidator(QueryLimits{Subjects: 20, Locations: 10, ExcludeIDs: 500})
+	rng := rand.New(rand.NewSource(1))
+
+	rejected := 0
+	for i := range 1000 {
+		raw := hostileQuery(rng)
+		q, qerr := v.Validate(raw)
+		if qerr != nil {
+			rejected++
+			if qerr.Error == "" {
+				t.Fatalf("query %d: rejected without a message: %q", i, raw)
+			}
+			continue
+		}
+
+		query := parseSearchValues(q)
+		if n := utf8.RuneCountInString(query.Text); n > v.MaxTextLength {
+			t.Fatalf("query %d: q of %d characters passed", i, n)
+		}
+		if len(query.Subjects) > v.Limits.Subjects || len(query.ExcludeIDs) > v.Limits.ExcludeIDs || len(query.Levels) > v.MaxValuesPerKey {
+			t.Fatalf("query %d: %d subjects, %d exclude_ids and %d levels passed", i, len(query.Subjects), len(query.ExcludeIDs), len(query.Levels))
+		}
+		if query.Limit > 10*maxExportLimit || query.Offset > maxQualityWindow || query.AvailableWithinDays > 366 {
+			t.Fatalf("query %d: limit %d, offset %d, available_within_days %d passed", i, query.Limit, query.Offset, query.AvailableWithinDays)
+		}
+		for _, p := range []*float64{query.MinPrice, query.MaxPrice, query.MinRating} {
+			if p != nil && (math.IsNaN(*p) || math.Abs(*p) > 1e6) {
+				t.Fatalf("query %d: number %v passed", i, *p)
+			}
+		}
+	}
+	if rejected == 0 || rejected == 1000 {
+		t.Errorf("expected a mix of accepted and rejected queries, %d of 1000 rejected", rejected)
+	}
+}
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"search/internal/kafka"
+)
+
+// WatermarkSource is implemented by *watermark.Tracker.
+type WatermarkSource interface {
+	Watermark() (time.Time, bool)
+}
+
+// indexFreshness describes how far the whole index is behind Django.
+type indexFreshness struct {
+	// Watermark is the newest created_at among processed events; nil
+	// before the first one.
+	Watermark *time.Time `json:"watermark"`
+	// LagSeconds is the time since Watermark.
+	LagSeconds *float64 `json:"lag_seconds"`
+	// MessagesBehind sums the consumer lag over the partitions that report
+	// one; nil when none does or offsets were not loaded.
+	MessagesBehind  *int64                  `json:"messages_behind"`
+	Partitions      []kafka.PartitionOffset `json:"partitions,omitempty"`
+	PartitionsError string                  `json:"partitions_error,omitempty"`
+	// MaxStalenessSeconds is the readiness threshold; zero disables it.
+	MaxStalenessSeconds float64 `json:"max_staleness_seconds"`
+	// Stale is set when LagSeconds exceeds the threshold while messages are
+	// waiting. A quiet topic is not stale however old its last event, and
+	// neither is a backlog that cannot be measured.
+	Stale bool `json:"stale"`
+}
+
+// IndexFreshness reports the event watermark, how long ago it was and the
+// consumer lag per partition: one figure for "search is X seconds behind".
+func (h *Handlers) IndexFreshness(w http.ResponseWriter, r *http.Request) {
+	if h.watermark == nil {
+		respondError(w, http.StatusNotFound, "Kafka consumer is not enabled")
+		return
+	}
+	respondJSON(w, http.StatusOK, h.indexFreshness(r.Context(), true))
+}
+
+// indexFreshness builds the report. Partition offsets cost a round trip to
+// the brokers, so unless withPartitions is set they are only loaded when
+// the lag alone exceeds the threshold.
+func (h *Handlers) indexFreshness(ctx context.Context, withPartitions bool) indexFreshness {
+	f := indexFreshness{MaxStalenessSeconds: h.maxStaleness.Seconds()}
+
+	at, ok := h.watermark.Watermark()
+	var lag time.Duration
+	if ok {
+		lag = max(time.Since(at), 0)
+		seconds := lag.Seconds()
+		f.Watermark = &at
+		f.LagSeconds = &seconds
+	}
+	overdue := ok && h.maxStaleness > 0 && lag > h.maxStaleness
+	if h.consumerOffsets == nil || (!withPartitions && !overdue) {
+		return f
+	}
+
+	partitions, err := h.consumerOffsets.Offsets(ctx)
+	if err != nil {
+		h.logger.Warn("Failed to load consumer offsets for freshness", "error", err)
+		f.PartitionsError = err.Error()
+		return f
+	}
+	f.Partitions = partitions
+	var behind int64
+	known := false
+	for _, p := range partitions {
+		if p.Lag >= 0 {
+			behind += p.Lag
+			known = true
+		}
+	}
+	if known {
+		f.MessagesBehind = &behind
+	}
+	f.Stale = overdue && known && behind > 0
+	return f
+}
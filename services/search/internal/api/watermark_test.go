@@ -0,0 +1,135 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"search/internal/kafka"
+	"search/internal/testutil"
+)
+
+// fakeWatermark is a fixed watermark; the zero value has none yet.
+type fakeWatermark time.Time
+
+func (f fakeWatermark) Watermark() (time.Time, bool) {
+	return time.Time(f), !time.Time(f).IsZero()
+}
+
+func TestIndexFreshness(t *testing.T) {
+	at := time.Now().Add(-90 * time.Second).UTC()
+	consumer := &fakeConsumerOffsets{offsets: []kafka.PartitionOffset{
+		{Topic: "tutor-events", Partition: 0, Committed: 10, HighWater: 14, Lag: 4},
+		{Topic: "tutor-events", Partition: 1, Committed: -1, HighWater: 3, Lag: -1},
+		{Topic: "booking-events", Partition: 0, Committed: 7, HighWater: 8, Lag: 1},
+	}}
+	handlers := NewHandlers(&mockSearchClient{}, testutil.NewLogger(t),
+		WithWatermark(fakeWatermark(at)),
+		WithConsumerOffsets(consumer),
+		WithMaxStaleness(time.Minute),
+	)
+
+	rec := httptest.NewRecorder()
+	handlers.IndexFreshness(rec, httptest.NewRequest("GET", "/admin/freshness", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var resp indexFreshness
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Watermark == nil || !resp.Watermark.Equal(at) {
+		t.Errorf("expected watermark %s, got %v", at, resp.Watermark)
+	}
+	if resp.LagSeconds == nil || *resp.LagSeconds < 90 || *resp.LagSeconds > 120 {
+		t.Errorf("expected a lag of about 90s, got %v", resp.LagSeconds)
+	}
+	if resp.MessagesBehind == nil || *resp.MessagesBehind != 5 {
+		t.Errorf("expected 5 messages behind, skipping the uncommitted partition, got %v", resp.MessagesBehind)
+	}
+	if len(resp.Partitions) != 3 {
+		t.Errorf("expected 3 partitions, got %d", len(resp.Partitions))
+	}
+	if !resp.Stale || resp.MaxStalenessSeconds != 60 {
+		t.Errorf("expected stale against a 60s threshold, got %+v", resp)
+	}
+}
+
+func TestIndexFreshness_NoWatermark(t *testing.T) {
+	handlers := NewHandlers(&mockSearchClient{}, testutil.NewLogger(t))
+
+	rec := httptest.NewRecorder()
+	handlers.IndexFreshness(rec, httptest.NewRequest("GET", "/admin/freshness", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("expected status %d without a consumer, got %d", http.StatusNotFound, rec.Code)
+	}
+
+	handlers = NewHandlers(&mockSearchClient{}, testutil.NewLogger(t),
+		WithWatermark(fakeWatermark{}))
+	rec = httptest.NewRecorder()
+	handlers.IndexFreshness(rec, httptest.NewRequest("GET", "/admin/freshness", nil))
+
+	var resp indexFreshness
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Watermark != nil || resp.LagSeconds != nil || resp.Stale {
+		t.Errorf("expected no watermark or lag before the first event, got %+v", resp)
+	}
+}
+
+func TestReady_MaxStaleness(t *testing.T) {
+	behind := []kafka.PartitionOffset{{Topic: "tutor-events", Committed: 10, HighWater: 12, Lag: 2}}
+	caughtUp := []kafka.PartitionOffset{{Topic: "tutor-events", Committed: 12, HighWater: 12, Lag: 0}}
+
+	tests := []struct {
+		name         string
+		age          time.Duration
+		maxStaleness time.Duration
+		consumer     *fakeConsumerOffsets
+		wantStatus   int
+		wantState    string
+	}{
+		{"fresh", 10 * time.Second, time.Minute, &fakeConsumerOffsets{offsets: behind}, http.StatusOK, "ready"},
+		{"stale with a backlog", 5 * time.Minute, time.Minute, &fakeConsumerOffsets{offsets: behind}, http.StatusServiceUnavailable, "stale"},
+		{"old but caught up", 5 * time.Minute, time.Minute, &fakeConsumerOffsets{offsets: caughtUp}, http.StatusOK, "ready"},
+		{"backlog unknown", 5 * time.Minute, time.Minute, &fakeConsumerOffsets{err: errors.New("no coordinator")}, http.StatusOK, "ready"},
+		{"threshold disabled", 5 * time.Minute, 0, &fakeConsumerOffsets{offsets: behind}, http.StatusOK, "ready"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testRouterConfig()
+			cfg.Readiness = fakeReadiness(true)
+			cfg.Watermark = fakeWatermark(time.Now().Add(-tt.age))
+			cfg.MaxStaleness = tt.maxStaleness
+			cfg.ConsumerOffsets = tt.consumer
+			router := NewRouter(&mockSearchClient{}, testutil.NewLogger(t), cfg)
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest("GET", "/health/ready", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.wantStatus, rec.Code, rec.Body.String())
+			}
+			var resp struct {
+				Status     string   `json:"status"`
+				LagSeconds *float64 `json:"lag_seconds"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if resp.Status != tt.wantState {
+				t.Errorf("expected status %q, got %q", tt.wantState, resp.Status)
+			}
+			if resp.LagSeconds == nil || *resp.LagSeconds < tt.age.Seconds() {
+				t.Errorf("expected lag_seconds of at least %v, got %v", tt.age.Seconds(), resp.LagSeconds)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+// Package audit keeps a trail of who changed the index through the HTTP
+// API: an in-memory ring buffer of recent entries, mirrored to the service
+// log and optionally to an append-only JSON Lines file.
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// DefaultCapacity is how many entries a Log keeps in memory by default.
+const DefaultCapacity = 1000
+
+// Outcomes of an audited request, from its response status.
+const (
+	OutcomeSuccess  = "success"
+	OutcomeRejected = "rejected"
+	OutcomeFailed   = "failed"
+)
+
+// Entry records one mutating request.
+type Entry struct {
+	Time   time.Time `json:"time"`
+	Method string    `json:"method"`
+	// Route is the matched pattern, such as /tutors/{id}; Path is the
+	// request path.
+	Route  string `json:"route"`
+	Path   string `json:"path"`
+	Tenant string `json:"tenant,omitempty"`
+	// Actor identifies the caller: "admin_key:<key ID>", "user:<JWT
+	// subject>" or "anonymous".
+	Actor      string `json:"actor"`
+	RemoteAddr string `json:"remote_addr,omitempty"`
+	// TutorIDs lists the tutors a single-tutor request targeted; Count is
+	// the number of documents a batch request changed.
+	TutorIDs []int64 `json:"tutor_ids,omitempty"`
+	Count    int     `json:"count,omitempty"`
+	Status   int     `json:"status"`
+	Outcome  string  `json:"outcome"`
+}
+
+// OutcomeFor classifies an HTTP status.
+func OutcomeFor(status int) string {
+	switch {
+	case status >= 500:
+		return OutcomeFailed
+	case status >= 400:
+		return OutcomeRejected
+	default:
+		return OutcomeSuccess
+	}
+}
+
+// Log stores recent entries. A nil *Log is valid and discards everything.
+type Log struct {
+	logger *slog.Logger
+	w      io.Writer
+
+	mu      sync.Mutex
+	entries []Entry
+	// next is where the following entry goes once entries is full.
+	next int
+}
+
+// Option configures a Log.
+type Option func(*Log)
+
+// WithWriter appends every entry to w as one JSON line, typically a file
+// opened with os.O_APPEND.
+func WithWriter(w io.Writer) Option {
+	return func(l *Log) {
+		l.w = w
+	}
+}
+
+// New returns a Log keeping the last capacity entries, at least one.
+func New(logger *slog.Logger, capacity int, opts ...Option) *Log {
+	l := &Log{
+		logger:  logger,
+		entries: make([]Entry, 0, max(capacity, 1)),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Record stores e, logs it and appends it to the writer, if any. A failed
+// write is logged and does not affect the in-memory copy.
+func (l *Log) Record(e Entry) {
+	if l == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.entries) < cap(l.entries) {
+		l.entries = append(l.entries, e)
+	} else {
+		l.entries[l.next] = e
+		l.next = (l.next + 1) % len(l.entries)
+	}
+
+	l.logger.Info("Audit",
+		"method", e.Method,
+		"route", e.Route,
+		"tenant", e.Tenant,
+		"actor", e.Actor,
+		"tutor_ids", e.TutorIDs,
+		"count", e.Count,
+		"status", e.Status,
+		"outcome", e.Outcome,
+	)
+	if l.w == nil {
+		return
+	}
+	line, err := json.Marshal(e)
+	if err == nil {
+		_, err = l.w.Write(append(line, '\n'))
+	}
+	if err != nil {
+		l.logger.Error("Failed to write audit log entry", "error", err)
+	}
+}
+
+// Since returns the stored entries recorded at or after t, oldest first.
+func (l *Log) Since(t time.Time) []Entry {
+	result := []Entry{}
+	if l == nil {
+		return result
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i := range l.entries {
+		e := l.entries[(l.next+i)%len(l.entries)]
+		if !e.Time.Before(t) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// Details collects what a handler changed, for the entry its request
+// produces. Handlers may annotate it after a timeout has already answered
+// the request, so it is safe for concurrent use.
+type Details struct {
+	mu       sync.Mutex
+	tutorIDs []int64
+	count    int
+	skip     bool
+}
+
+type detailsKey struct{}
+
+// NewContext returns a copy of ctx carrying fresh Details.
+func NewContext(ctx context.Context) (context.Context, *Details) {
+	d := &Details{}
+	return context.WithValue(ctx, detailsKey{}, d), d
+}
+
+func fromContext(ctx context.Context) *Details {
+	d, _ := ctx.Value(detailsKey{}).(*Details)
+	return d
+}
+
+// AddTutorIDs notes the tutors the request targets. It does nothing on a
+// context without Details.
+func AddTutorIDs(ctx context.Context, ids ...int64) {
+	if d := fromContext(ctx); d != nil {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		d.tutorIDs = append(d.tutorIDs, ids...)
+	}
+}
+
+// SetCount notes how many documents the request changed.
+func SetCount(ctx context.Context, n int) {
+	if d := fromContext(ctx); d != nil {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		d.count = n
+	}
+}
+
+// Skip drops the entry for a request that turned out not to change
+// anything, such as a dry run.
+func Skip(ctx context.Context) {
+	if d := fromContext(ctx); d != nil {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		d.skip = true
+	}
+}
+
+// Apply copies the collected details into e. It returns false if the entry
+// should be skipped.
+func (d *Details) Apply(e *Entry) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	e.TutorIDs = append([]int64(nil), d.tutorIDs...)
+	e.Count = d.count
+	return !d.skip
+}
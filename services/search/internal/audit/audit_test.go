@@ -0,0 +1,107 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/testutil"
+)
+
+func routes(entries []Entry) []string {
+	result := make([]string, len(entries))
+	for i, e := range entries {
+		result[i] = e.Route
+	}
+	return result
+}
+
+func TestLog_KeepsLastEntriesOldestFirst(t *testing.T) {
+	l := New(testutil.NewLogger(t), 3)
+	base := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	for i, route := range []string{"/a", "/b", "/c", "/d", "/e"} {
+		l.Record(Entry{Time: base.Add(time.Duration(i) * time.Minute), Route: route})
+	}
+
+	assert.Equal(t, []string{"/c", "/d", "/e"}, routes(l.Since(time.Time{})))
+	assert.Equal(t, []string{"/d", "/e"}, routes(l.Since(base.Add(3*time.Minute))), "since is inclusive")
+	assert.Empty(t, l.Since(base.Add(time.Hour)))
+}
+
+func TestLog_StampsTime(t *testing.T) {
+	l := New(testutil.NewLogger(t), 1)
+	before := time.Now()
+	l.Record(Entry{Route: "/a"})
+
+	entries := l.Since(time.Time{})
+	require.Len(t, entries, 1)
+	assert.False(t, entries[0].Time.Before(before.Truncate(time.Second)))
+}
+
+func TestLog_AppendsJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	l := New(testutil.NewLogger(t), 10, WithWriter(&buf))
+	l.Record(Entry{Route: "/tutors/{id}", Actor: "user:42", TutorIDs: []int64{7}, Status: 200, Outcome: OutcomeSuccess})
+	l.Record(Entry{Route: "/admin/sync", Count: 3, Status: 200, Outcome: OutcomeSuccess})
+
+	var lines []Entry
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var e Entry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &e))
+		lines = append(lines, e)
+	}
+	require.Len(t, lines, 2)
+	assert.Equal(t, []int64{7}, lines[0].TutorIDs)
+	assert.Equal(t, "user:42", lines[0].Actor)
+	assert.Equal(t, 3, lines[1].Count)
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestLog_WriteFailureKeepsEntry(t *testing.T) {
+	l := New(testutil.NewLogger(t), 10, WithWriter(failingWriter{}))
+	l.Record(Entry{Route: "/a"})
+	assert.Len(t, l.Since(time.Time{}), 1)
+}
+
+func TestLog_NilDiscards(t *testing.T) {
+	var l *Log
+	l.Record(Entry{Route: "/a"})
+	assert.Empty(t, l.Since(time.Time{}))
+}
+
+func TestOutcomeFor(t *testing.T) {
+	assert.Equal(t, OutcomeSuccess, OutcomeFor(200))
+	assert.Equal(t, OutcomeSuccess, OutcomeFor(202))
+	assert.Equal(t, OutcomeRejected, OutcomeFor(404))
+	assert.Equal(t, OutcomeFailed, OutcomeFor(503))
+}
+
+func TestDetails(t *testing.T) {
+	ctx, d := NewContext(context.Background())
+	AddTutorIDs(ctx, 1, 2)
+	SetCount(ctx, 5)
+
+	var e Entry
+	assert.True(t, d.Apply(&e))
+	assert.Equal(t, []int64{1, 2}, e.TutorIDs)
+	assert.Equal(t, 5, e.Count)
+
+	Skip(ctx)
+	assert.False(t, d.Apply(&e))
+
+	// Annotating a context without Details is a no-op.
+	AddTutorIDs(context.Background(), 3)
+	SetCount(context.Background(), 1)
+	Skip(context.Background())
+}
@@ -0,0 +1,74 @@
+// Package auth verifies the access tokens issued by the Django backend and
+// carries the authenticated user through request contexts.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidToken is returned for any token that fails verification.
+var ErrInvalidToken = errors.New("invalid token")
+
+// Verifier checks HS256 access tokens signed with the shared Django secret.
+type Verifier struct {
+	secret []byte
+	parser *jwt.Parser
+}
+
+// NewVerifier returns a Verifier for tokens signed with secret.
+func NewVerifier(secret string) *Verifier {
+	return &Verifier{
+		secret: []byte(secret),
+		parser: jwt.NewParser(
+			jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+			jwt.WithExpirationRequired(),
+		),
+	}
+}
+
+// UserID verifies token and returns the user it was issued to, taken from
+// the user_id claim (djangorestframework-simplejwt) or, failing that, sub.
+func (v *Verifier) UserID(token string) (string, error) {
+	claims := jwt.MapClaims{}
+	_, err := v.parser.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) {
+		return v.secret, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidToken, err)
+	}
+
+	if tokenType, ok := claims["token_type"]; ok && tokenType != "access" {
+		return "", fmt.Errorf("%w: token_type %v is not access", ErrInvalidToken, tokenType)
+	}
+
+	switch id := claims["user_id"].(type) {
+	case float64:
+		return strconv.FormatInt(int64(id), 10), nil
+	case string:
+		if id != "" {
+			return id, nil
+		}
+	}
+	if sub, _ := claims.GetSubject(); sub != "" {
+		return sub, nil
+	}
+	return "", fmt.Errorf("%w: no user_id or sub claim", ErrInvalidToken)
+}
+
+type userIDKey struct{}
+
+// WithUserID returns a copy of ctx carrying the authenticated user ID.
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// UserIDFrom returns the authenticated user ID stored in ctx, if any.
+func UserIDFrom(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(userIDKey{}).(string)
+	return id, ok && id != ""
+}
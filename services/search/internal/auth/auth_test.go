@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "django-secret"
+
+func sign(t *testing.T, method jwt.SigningMethod, key any, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	require.NoError(t, err)
+	return token
+}
+
+func TestVerifier_UserID(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Unix()
+	v := NewVerifier(testSecret)
+
+	tests := []struct {
+		name    string
+		token   func(t *testing.T) string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "simplejwt access token",
+			token: func(t *testing.T) string {
+				return sign(t, jwt.SigningMethodHS256, []byte(testSecret), jwt.MapClaims{"user_id": 42, "token_type": "access", "exp": exp})
+			},
+			want: "42",
+		},
+		{
+			name: "string user id",
+			token: func(t *testing.T) string {
+				return sign(t, jwt.SigningMethodHS256, []byte(testSecret), jwt.MapClaims{"user_id": "u-7", "exp": exp})
+			},
+			want: "u-7",
+		},
+		{
+			name: "falls back to sub",
+			token: func(t *testing.T) string {
+				return sign(t, jwt.SigningMethodHS256, []byte(testSecret), jwt.MapClaims{"sub": "9", "exp": exp})
+			},
+			want: "9",
+		},
+		{
+			name: "wrong secret",
+			token: func(t *testing.T) string {
+				return sign(t, jwt.SigningMethodHS256, []byte("other"), jwt.MapClaims{"user_id": 42, "exp": exp})
+			},
+			wantErr: true,
+		},
+		{
+			name: "expired",
+			token: func(t *testing.T) string {
+				return sign(t, jwt.SigningMethodHS256, []byte(testSecret), jwt.MapClaims{"user_id": 42, "exp": time.Now().Add(-time.Minute).Unix()})
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing exp",
+			token: func(t *testing.T) string {
+				return sign(t, jwt.SigningMethodHS256, []byte(testSecret), jwt.MapClaims{"user_id": 42})
+			},
+			wantErr: true,
+		},
+		{
+			name: "refresh token",
+			token: func(t *testing.T) string {
+				return sign(t, jwt.SigningMethodHS256, []byte(testSecret), jwt.MapClaims{"user_id": 42, "token_type": "refresh", "exp": exp})
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsigned token",
+			token: func(t *testing.T) string {
+				return sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, jwt.MapClaims{"user_id": 42, "exp": exp})
+			},
+			wantErr: true,
+		},
+		{
+			name: "no user claim",
+			token: func(t *testing.T) string {
+				return sign(t, jwt.SigningMethodHS256, []byte(testSecret), jwt.MapClaims{"exp": exp})
+			},
+			wantErr: true,
+		},
+		{
+			name:    "garbage",
+			token:   func(t *testing.T) string { return "not-a-jwt" },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.UserID(tt.token(t))
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidToken)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUserIDContext(t *testing.T) {
+	_, ok := UserIDFrom(context.Background())
+	assert.False(t, ok)
+
+	id, ok := UserIDFrom(WithUserID(context.Background(), "42"))
+	assert.True(t, ok)
+	assert.Equal(t, "42", id)
+}
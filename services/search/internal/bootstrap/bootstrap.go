@@ -0,0 +1,220 @@
+// Package bootstrap brings the search backend up at startup and starts the
+// parts of the service that depend on it once it is reachable.
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Startup modes accepted in STARTUP_MODE.
+const (
+	// ModeStrict waits for the backend before serving and gives up after a
+	// bounded number of attempts.
+	ModeStrict = "strict"
+	// ModeLazy serves immediately, not ready, and keeps retrying the
+	// backend in the background.
+	ModeLazy = "lazy"
+)
+
+// Defaults for the strict wait and the lazy backoff.
+const (
+	DefaultStrictAttempts = 30
+	DefaultStrictInterval = 2 * time.Second
+	DefaultBackoff        = time.Second
+	DefaultMaxBackoff     = 30 * time.Second
+)
+
+// Pinger is implemented by port.SearchClient.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Bootstrap waits for the backend, prepares it, typically by ensuring the
+// indices exist, and then runs the OnReady hooks and reports ready.
+type Bootstrap struct {
+	backend Pinger
+	prepare func(context.Context) error
+	logger  *slog.Logger
+	onReady []func(context.Context)
+
+	mode           string
+	strictAttempts int
+	strictInterval time.Duration
+	backoff        time.Duration
+	maxBackoff     time.Duration
+
+	ready atomic.Bool
+	// done is closed once the bootstrap succeeds or gives up.
+	done     chan struct{}
+	doneOnce sync.Once
+}
+
+// Option configures a Bootstrap.
+type Option func(*Bootstrap)
+
+// WithMode selects ModeStrict (the default) or ModeLazy.
+func WithMode(mode string) Option {
+	return func(b *Bootstrap) {
+		b.mode = mode
+	}
+}
+
+// WithStrictRetries sets how many times strict mode pings the backend, and
+// how long it waits between pings, before giving up.
+func WithStrictRetries(attempts int, interval time.Duration) Option {
+	return func(b *Bootstrap) {
+		b.strictAttempts = attempts
+		b.strictInterval = interval
+	}
+}
+
+// WithBackoff sets the delay before lazy mode's first retry and the cap it
+// doubles up to.
+func WithBackoff(initial, maxBackoff time.Duration) Option {
+	return func(b *Bootstrap) {
+		b.backoff = initial
+		b.maxBackoff = maxBackoff
+	}
+}
+
+// WithOnReady runs fn once the backend is up, before the service reports
+// ready. Hooks run in the order given and must not block.
+func WithOnReady(fn func(context.Context)) Option {
+	return func(b *Bootstrap) {
+		b.onReady = append(b.onReady, fn)
+	}
+}
+
+// New creates a Bootstrap that pings backend and then runs prepare. A nil
+// prepare skips that step.
+func New(backend Pinger, prepare func(context.Context) error, logger *slog.Logger, opts ...Option) *Bootstrap {
+	b := &Bootstrap{
+		backend:        backend,
+		prepare:        prepare,
+		logger:         logger,
+		mode:           ModeStrict,
+		strictAttempts: DefaultStrictAttempts,
+		strictInterval: DefaultStrictInterval,
+		backoff:        DefaultBackoff,
+		maxBackoff:     DefaultMaxBackoff,
+		done:           make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Start brings the backend up according to the mode. In strict mode it
+// blocks until the service is ready and returns an error if the backend
+// stays unreachable or cannot be prepared. In lazy mode it returns at once
+// and retries in the background until ready or ctx ends.
+func (b *Bootstrap) Start(ctx context.Context) error {
+	if b.mode == ModeLazy {
+		b.logger.Info("Starting lazily; dependencies are retried in the background")
+		go func() {
+			defer b.finish()
+			b.runLazy(ctx)
+		}()
+		return nil
+	}
+
+	defer b.finish()
+	return b.runStrict(ctx)
+}
+
+// Ready reports whether the backend is up and the OnReady hooks have run.
+func (b *Bootstrap) Ready() bool {
+	return b.ready.Load()
+}
+
+// Done is closed once Start has succeeded or given up.
+func (b *Bootstrap) Done() <-chan struct{} {
+	return b.done
+}
+
+func (b *Bootstrap) finish() {
+	b.doneOnce.Do(func() { close(b.done) })
+}
+
+func (b *Bootstrap) runStrict(ctx context.Context) error {
+	var err error
+	for attempt := 1; attempt <= b.strictAttempts; attempt++ {
+		if err = b.backend.Ping(ctx); err == nil {
+			break
+		}
+		b.logger.Info("Waiting for search backend...", "attempt", attempt, "error", err)
+		if attempt == b.strictAttempts {
+			return fmt.Errorf("search backend unreachable after %d attempts: %w", b.strictAttempts, err)
+		}
+		if err := sleep(ctx, b.strictInterval); err != nil {
+			return err
+		}
+	}
+	b.logger.Info("Search backend connection established")
+
+	if err := b.runPrepare(ctx); err != nil {
+		return err
+	}
+	b.markReady(ctx)
+	return nil
+}
+
+// runLazy retries the ping and prepare steps with exponential backoff until
+// both succeed, then marks the service ready.
+func (b *Bootstrap) runLazy(ctx context.Context) {
+	backoff := b.backoff
+	for attempt := 1; ; attempt++ {
+		err := b.backend.Ping(ctx)
+		if err == nil {
+			err = b.runPrepare(ctx)
+		}
+		if err == nil {
+			b.logger.Info("Search backend ready", "attempts", attempt)
+			b.markReady(ctx)
+			return
+		}
+
+		b.logger.Warn("Search backend not ready, will retry",
+			"attempt", attempt,
+			"retry_in", backoff,
+			"error", err,
+		)
+		if sleep(ctx, backoff) != nil {
+			return
+		}
+		backoff = min(backoff*2, b.maxBackoff)
+	}
+}
+
+func (b *Bootstrap) runPrepare(ctx context.Context) error {
+	if b.prepare == nil {
+		return nil
+	}
+	if err := b.prepare(ctx); err != nil {
+		return fmt.Errorf("failed to prepare search backend: %w", err)
+	}
+	return nil
+}
+
+func (b *Bootstrap) markReady(ctx context.Context) {
+	for _, fn := range b.onReady {
+		fn(ctx)
+	}
+	b.ready.Store(true)
+}
+
+// sleep waits for d, returning ctx's error if it ends first.
+func sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
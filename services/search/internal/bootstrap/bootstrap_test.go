@@ -0,0 +1,128 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/testutil"
+)
+
+// fakeBackend fails its first failures pings.
+type fakeBackend struct {
+	failures int32
+	pings    atomic.Int32
+}
+
+func (f *fakeBackend) Ping(ctx context.Context) error {
+	if f.pings.Add(1) <= f.failures {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func TestStart_StrictWaitsThenPreparesAndStarts(t *testing.T) {
+	backend := &fakeBackend{failures: 2}
+	var steps []string
+	b := New(backend, func(context.Context) error {
+		steps = append(steps, "prepare")
+		return nil
+	}, testutil.NewLogger(t),
+		WithStrictRetries(5, time.Millisecond),
+		WithOnReady(func(context.Context) { steps = append(steps, "consumer") }),
+	)
+
+	require.NoError(t, b.Start(context.Background()))
+
+	assert.Equal(t, int32(3), backend.pings.Load())
+	assert.Equal(t, []string{"prepare", "consumer"}, steps)
+	assert.True(t, b.Ready())
+	select {
+	case <-b.Done():
+	default:
+		t.Error("expected Done to be closed once a strict Start returns")
+	}
+}
+
+func TestStart_StrictGivesUp(t *testing.T) {
+	backend := &fakeBackend{failures: 100}
+	started := false
+	b := New(backend, nil, testutil.NewLogger(t),
+		WithStrictRetries(3, time.Millisecond),
+		WithOnReady(func(context.Context) { started = true }),
+	)
+
+	err := b.Start(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "after 3 attempts")
+	assert.Equal(t, int32(3), backend.pings.Load())
+	assert.False(t, started)
+	assert.False(t, b.Ready())
+}
+
+func TestStart_StrictPrepareFailureIsFatal(t *testing.T) {
+	b := New(&fakeBackend{}, func(context.Context) error {
+		return errors.New("index creation refused")
+	}, testutil.NewLogger(t), WithStrictRetries(3, time.Millisecond))
+
+	err := b.Start(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "index creation refused")
+	assert.False(t, b.Ready())
+}
+
+func TestStart_LazyReturnsAtOnceAndRetriesInBackground(t *testing.T) {
+	backend := &fakeBackend{failures: 2}
+	prepareFailures := 1
+	var started atomic.Bool
+	b := New(backend, func(context.Context) error {
+		if prepareFailures > 0 {
+			prepareFailures--
+			return errors.New("cluster still electing a master")
+		}
+		return nil
+	}, testutil.NewLogger(t),
+		WithMode(ModeLazy),
+		WithBackoff(time.Millisecond, 4*time.Millisecond),
+		WithOnReady(func(context.Context) { started.Store(true) }),
+	)
+
+	require.NoError(t, b.Start(context.Background()))
+
+	select {
+	case <-b.Done():
+	case <-time.After(time.Second):
+		t.Fatal("lazy bootstrap did not finish")
+	}
+	assert.True(t, b.Ready())
+	assert.True(t, started.Load(), "the consumer starts once the backend is ready")
+	assert.Equal(t, int32(4), backend.pings.Load(), "two failed pings, one failed prepare, then success")
+}
+
+func TestStart_LazyNotReadyUntilBackendUp(t *testing.T) {
+	backend := &fakeBackend{failures: 1 << 30}
+	ctx, cancel := context.WithCancel(context.Background())
+	b := New(backend, nil, testutil.NewLogger(t),
+		WithMode(ModeLazy),
+		WithBackoff(time.Millisecond, time.Millisecond),
+	)
+
+	require.NoError(t, b.Start(ctx))
+	assert.Eventually(t, func() bool { return backend.pings.Load() >= 3 }, time.Second, time.Millisecond)
+	assert.False(t, b.Ready())
+
+	cancel()
+	select {
+	case <-b.Done():
+	case <-time.After(time.Second):
+		t.Fatal("lazy bootstrap did not stop with its context")
+	}
+	assert.False(t, b.Ready())
+}
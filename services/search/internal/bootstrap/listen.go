@@ -0,0 +1,47 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+)
+
+// ErrPortInUse is returned by Listen when another process holds the port.
+var ErrPortInUse = errors.New("port already in use")
+
+// Listen binds a TCP listener on port, any free port when it is zero. It
+// is meant to run before any dependency waits, so a port conflict fails
+// startup at once rather than after the backend is up.
+func Listen(port int) (net.Listener, error) {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if errors.Is(err, syscall.EADDRINUSE) {
+		return nil, fmt.Errorf("%w: %d; is another instance running?", ErrPortInUse, port)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on port %d: %w", port, err)
+	}
+	return lis, nil
+}
+
+// Serve serves srv on lis until srv is shut down, when it returns nil.
+// Any other error means the server stopped on its own.
+func Serve(srv *http.Server, lis net.Listener) error {
+	if err := srv.Serve(lis); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown stops srv gracefully, giving up when ctx ends. Keep-alives are
+// turned off first, so idle connections close at once and responses still
+// in flight tell their clients not to reuse the connection; otherwise a
+// client pooling connections keeps one open past the grace period. Hooks
+// registered with srv.RegisterOnShutdown run once Shutdown starts, which is
+// how streaming handlers learn to end their responses.
+func Shutdown(ctx context.Context, srv *http.Server) error {
+	srv.SetKeepAlivesEnabled(false)
+	return srv.Shutdown(ctx)
+}
@@ -0,0 +1,104 @@
+package bootstrap
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListen_PortZeroServes(t *testing.T) {
+	lis, err := Listen(0)
+	require.NoError(t, err)
+
+	port := lis.Addr().(*net.TCPAddr).Port
+	assert.NotZero(t, port, "the bound port is reported")
+
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	})}
+	served := make(chan error, 1)
+	go func() { served <- Serve(srv, lis) }()
+
+	resp, err := http.Get("http://" + lis.Addr().String())
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, "ok", string(body))
+
+	require.NoError(t, srv.Shutdown(context.Background()))
+	assert.NoError(t, <-served, "a shut down server is not an error")
+}
+
+func TestListen_PortInUse(t *testing.T) {
+	occupied, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	defer occupied.Close()
+	port := occupied.Addr().(*net.TCPAddr).Port
+
+	lis, err := Listen(port)
+
+	assert.Nil(t, lis)
+	require.ErrorIs(t, err, ErrPortInUse)
+	assert.Contains(t, err.Error(), "is another instance running?")
+}
+
+func TestServe_ReportsListenerFailure(t *testing.T) {
+	lis, err := Listen(0)
+	require.NoError(t, err)
+	lis.Close()
+
+	assert.Error(t, Serve(&http.Server{}, lis))
+}
+
+func TestShutdown_ClosesKeepAliveConnections(t *testing.T) {
+	lis, err := Listen(0)
+	require.NoError(t, err)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			close(started)
+			<-release
+		}
+		io.WriteString(w, "ok")
+	})}
+	// Hooks run once Shutdown has begun, after keep-alives are off.
+	srv.RegisterOnShutdown(func() { close(release) })
+	served := make(chan error, 1)
+	go func() { served <- Serve(srv, lis) }()
+
+	client := &http.Client{Transport: &http.Transport{}}
+	url := "http://" + lis.Addr().String()
+	resp, err := client.Get(url + "/fast")
+	require.NoError(t, err)
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	assert.False(t, resp.Close, "keep-alives are on while serving")
+
+	slow := make(chan *http.Response, 1)
+	go func() {
+		resp, err := client.Get(url + "/slow")
+		if assert.NoError(t, err) {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		slow <- resp
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, Shutdown(ctx, srv))
+	assert.NoError(t, <-served)
+
+	resp = <-slow
+	require.NotNil(t, resp)
+	assert.True(t, resp.Close, "a response in flight at shutdown closes its connection")
+}
@@ -0,0 +1,1348 @@
+// Package config loads the search service configuration from the environment.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"search/internal/audit"
+	"search/internal/bootstrap"
+	"search/internal/domain"
+	"search/internal/experiment"
+	"search/internal/outbox"
+	"search/internal/port"
+	"search/internal/relevance"
+	"search/internal/schedule"
+	"search/internal/tenant"
+)
+
+// Config is the fully parsed service configuration.
+type Config struct {
+	Server     ServerConfig
+	Search     SearchConfig
+	Indexing   IndexingConfig
+	OpenSearch OpenSearchConfig
+	Kafka      KafkaConfig
+	CORS       CORSConfig
+	Admin      AdminConfig
+	Auth       AuthConfig
+	Django     DjangoConfig
+	Reindex    ReindexConfig
+	Drift      DriftConfig
+	Journal    JournalConfig
+	Tenant     TenantConfig
+	Experiment ExperimentConfig
+	Relevance  RelevanceConfig
+	Feedback   FeedbackConfig
+	Shadow     ShadowConfig
+	Log        LogConfig
+	Features   FeatureFlags
+}
+
+// ServerConfig holds HTTP server settings.
+type ServerConfig struct {
+	Port         int
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	// ShutdownGrace bounds how long shutdown waits for in-flight requests
+	// and RPCs before closing their connections.
+	ShutdownGrace time.Duration
+
+	// Per-route-group handler deadlines. WriteTimeout is only a backstop and
+	// must exceed all of them.
+	SearchTimeout   time.Duration
+	MutationTimeout time.Duration
+	AdminTimeout    time.Duration
+	// QuickSearchTimeout bounds GET /search/quick, which runs on every
+	// keystroke and is worthless once the user has typed on.
+	QuickSearchTimeout time.Duration
+
+	// GRPCPort is the port for the internal gRPC API. Zero disables it.
+	GRPCPort int
+
+	// StartupMode is bootstrap.ModeStrict, which waits for the search
+	// backend before serving and exits if it stays down, or
+	// bootstrap.ModeLazy, which serves at once and retries in the background.
+	StartupMode string
+}
+
+// SearchConfig selects the search backend and bounds search requests.
+type SearchConfig struct {
+	Backend string
+
+	// Caps on how many values one request may list per filter, so a very
+	// broad search cannot expand into a huge terms query.
+	MaxSubjects   int
+	MaxLocations  int
+	MaxExcludeIDs int
+
+	// MaxFacetSize caps, and is the default for, facet_size: how many
+	// subjects GET /subjects and include_facets count.
+	MaxFacetSize int
+
+	// MaxConcurrent caps in-flight search backend calls made by HTTP
+	// handlers. A call that finds no free slot within AcquireTimeout is
+	// answered with 503.
+	MaxConcurrent  int
+	AcquireTimeout time.Duration
+
+	// PinnedBadge ranks tutors carrying it first in relevance-ordered
+	// searches, unless an experiment variant pins its own. Empty pins none.
+	PinnedBadge string
+
+	// MaxResponseBytes is the size JSON search responses are trimmed
+	// towards by dropping optional fields. Zero disables the budget.
+	MaxResponseBytes int
+}
+
+// Default per-request filter caps.
+const (
+	DefaultMaxSubjects   = 20
+	DefaultMaxLocations  = 10
+	DefaultMaxExcludeIDs = 500
+)
+
+// DefaultMaxResponseBytes is the default JSON search response budget.
+const DefaultMaxResponseBytes = 1 << 20
+
+// Default search backend concurrency limits. Indexing gets its own, smaller
+// pool so searches and Kafka events cannot starve each other.
+const (
+	DefaultMaxConcurrentSearches = 64
+	DefaultSearchAcquireTimeout  = 100 * time.Millisecond
+	DefaultMaxConcurrentIndexing = 8
+)
+
+// IndexingConfig holds limits applied to tutors before they are indexed.
+type IndexingConfig struct {
+	// MaxListItems caps subjects and formats after deduplication.
+	MaxListItems int
+	// MaxTutorID is the largest tutor ID path parameters accept.
+	MaxTutorID int64
+	// AvatarCDNBase resolves protocol-relative and root-relative avatar
+	// URLs. Empty rejects root-relative ones.
+	AvatarCDNBase string
+	// AvatarStripParams are query parameters removed from avatar URLs.
+	AvatarStripParams []string
+	// RatingMode is domain.RatingModeLenient, which zeroes the rating of a
+	// tutor without reviews, or domain.RatingModeStrict, which rejects it.
+	RatingMode string
+	// SubjectsFile is a JSON subject catalog merged over the embedded one;
+	// empty uses the embedded catalog alone.
+	SubjectsFile string
+	// TranslationsFile holds subject labels per language merged over the
+	// embedded ones; empty uses the embedded translations alone.
+	TranslationsFile string
+	// MaxConcurrent caps in-flight search backend calls made while handling
+	// Kafka events. Events wait for a free slot rather than fail.
+	MaxConcurrent int
+	// Scrub removes emails, URLs and phone numbers from bios and headlines
+	// before indexing.
+	Scrub bool
+	// ScrubExtraPattern is a regular expression for further text to
+	// remove; empty adds nothing.
+	ScrubExtraPattern string
+	// Popularity weights the popularity score stored with each tutor.
+	Popularity domain.PopularityWeights
+}
+
+// AvatarPolicy returns the avatar URL rules for this environment.
+func (c IndexingConfig) AvatarPolicy() domain.AvatarPolicy {
+	return domain.AvatarPolicy{CDNBase: c.AvatarCDNBase, StripParams: c.AvatarStripParams}
+}
+
+// SubjectCatalog returns the embedded subject catalog, with SubjectsFile
+// merged over it when set.
+func (c IndexingConfig) SubjectCatalog() (*domain.SubjectCatalog, error) {
+	if c.SubjectsFile == "" {
+		return domain.DefaultSubjectCatalog(), nil
+	}
+	return domain.LoadSubjectCatalog(c.SubjectsFile)
+}
+
+// Translations returns the embedded subject translations, with
+// TranslationsFile merged over them when set.
+func (c IndexingConfig) Translations() (*domain.Translations, error) {
+	if c.TranslationsFile == "" {
+		return domain.DefaultTranslations(), nil
+	}
+	return domain.LoadTranslations(c.TranslationsFile)
+}
+
+// Scrubber returns the scrubber for bios and headlines, or nil when
+// scrubbing is off.
+func (c IndexingConfig) Scrubber() (domain.Scrubber, error) {
+	if !c.Scrub {
+		return nil, nil
+	}
+	s, err := domain.NewPatternScrubber(c.ScrubExtraPattern)
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Search backends accepted in SEARCH_BACKEND.
+const (
+	BackendOpenSearch = "opensearch"
+	BackendMemory     = "memory"
+)
+
+// OpenSearchConfig holds OpenSearch connection and index settings.
+type OpenSearchConfig struct {
+	URL string
+	// Shards and Replicas are applied when an index is created. Replicas
+	// can be changed later through PUT /admin/index/settings.
+	Shards   int
+	Replicas int
+	// Index is the index used without TENANTS.
+	Index    string
+	Username string
+	Password string
+	// MaxRetries bounds retries of requests failing with 502, 503 or 504;
+	// zero disables them. RetryBackoff is multiplied by the attempt number.
+	MaxRetries   int
+	RetryBackoff time.Duration
+	// Refresh is the refresh policy for single-document writes: true,
+	// wait_for or false.
+	Refresh string
+	// PingMode picks the reachability check: root asks the cluster for
+	// its info, index sends HEAD to the index. PingTimeout bounds it.
+	PingMode    string
+	PingTimeout time.Duration
+	// IndexRate is the most tutor writes per second sent to OpenSearch.
+	// It halves whenever OpenSearch answers 429 and recovers as writes
+	// succeed; zero sends writes unpaced and fails them on 429.
+	IndexRate float64
+	// IndexExperiments enables POST /admin/experiment/index. Tutor writes
+	// then check every 30 seconds whether an index experiment is running
+	// and write to its index too.
+	IndexExperiments bool
+	// IndexAliases sends searches to <index>-read and tutor writes to
+	// <index>-write, which /admin/index/aliases points independently.
+	IndexAliases bool
+	// Connection pool to the cluster: idle connections kept in all and
+	// per node, and how long one may sit idle. Zero MaxIdleConns keeps
+	// any number.
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+	// TLSHandshakeTimeout bounds a new connection's handshake and
+	// ResponseHeaderTimeout the wait for a response once a request is
+	// sent; zero leaves either unbounded.
+	TLSHandshakeTimeout   time.Duration
+	ResponseHeaderTimeout time.Duration
+}
+
+// OpenSearch client defaults.
+const (
+	DefaultOpenSearchMaxRetries   = 3
+	DefaultOpenSearchRetryBackoff = 100 * time.Millisecond
+	DefaultOpenSearchPingTimeout  = 2 * time.Second
+	DefaultOpenSearchIndexRate    = 200
+
+	DefaultOpenSearchMaxIdleConns          = 100
+	DefaultOpenSearchMaxIdleConnsPerHost   = 100
+	DefaultOpenSearchIdleConnTimeout       = 90 * time.Second
+	DefaultOpenSearchTLSHandshakeTimeout   = 10 * time.Second
+	DefaultOpenSearchResponseHeaderTimeout = 30 * time.Second
+)
+
+// Refresh policies accepted in OPENSEARCH_REFRESH.
+const (
+	RefreshTrue    = "true"
+	RefreshWaitFor = "wait_for"
+	RefreshFalse   = "false"
+)
+
+// Ping modes accepted in OPENSEARCH_PING_MODE.
+const (
+	PingRoot  = "root"
+	PingIndex = "index"
+)
+
+// IndexSettings returns the shard and replica counts for new indices.
+func (c OpenSearchConfig) IndexSettings() port.IndexSettings {
+	return port.IndexSettings{Shards: c.Shards, Replicas: c.Replicas}
+}
+
+// LogConfig selects how the service logs.
+type LogConfig struct {
+	// Level is the least severe level logged, one of the LogLevel values.
+	Level string
+	// Format is LogFormatJSON or LogFormatText, the latter for reading
+	// logs in a terminal.
+	Format string
+	// AddSource adds the file and line of each log call.
+	AddSource bool
+}
+
+// Levels accepted in LOG_LEVEL.
+const (
+	LogLevelDebug = "debug"
+	LogLevelInfo  = "info"
+	LogLevelWarn  = "warn"
+	LogLevelError = "error"
+)
+
+// Formats accepted in LOG_FORMAT.
+const (
+	LogFormatJSON = "json"
+	LogFormatText = "text"
+)
+
+// Components that log through a child logger of their own, tagged with a
+// "component" attribute and with a level that can be set apart from the
+// rest.
+const (
+	ComponentKafka      = "kafka"
+	ComponentOpenSearch = "opensearch"
+	ComponentAPI        = "api"
+)
+
+// SlogLevel returns Level as a slog.Level, slog.LevelInfo for an
+// unknown one.
+func (c LogConfig) SlogLevel() slog.Level {
+	switch c.Level {
+	case LogLevelDebug:
+		return slog.LevelDebug
+	case LogLevelWarn:
+		return slog.LevelWarn
+	case LogLevelError:
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// NewHandler returns a handler writing to w in Format, with AddSource,
+// that drops records below level.
+func (c LogConfig) NewHandler(w io.Writer, level slog.Leveler) slog.Handler {
+	opts := &slog.HandlerOptions{Level: level, AddSource: c.AddSource}
+	if c.Format == LogFormatText {
+		return slog.NewTextHandler(w, opts)
+	}
+	return slog.NewJSONHandler(w, opts)
+}
+
+// KafkaConfig holds Kafka consumer settings.
+type KafkaConfig struct {
+	Brokers []string
+	Topic   string
+	// BookingTopic carries booking events that maintain tutor availability.
+	// Empty disables availability updates.
+	BookingTopic string
+	GroupID      string
+	StartOffset  string
+	// HealthGracePeriod is how long the brokers may be unreachable before
+	// /health reports the service unhealthy.
+	HealthGracePeriod time.Duration
+	// MaxStaleness fails /health/ready while the index is further behind
+	// the newest processed event with messages waiting. Zero disables it.
+	MaxStaleness time.Duration
+	// WatermarkFile, if set, keeps the event watermark across restarts.
+	WatermarkFile string
+	// MaxMessageBytes is the largest event the consumer decodes; larger
+	// ones are quarantined unread.
+	MaxMessageBytes int
+	// HandleTimeout bounds one attempt at handling an event. Zero disables
+	// it.
+	HandleTimeout time.Duration
+	// IdleHeartbeat is how long the consumer may go without a message
+	// before it logs that it is idle, and then how often. Zero disables it.
+	IdleHeartbeat time.Duration
+	// SkipUnchanged skips tutor writes whose content matches the index,
+	// fetching the indexed document for tutors not among the last
+	// UnchangedCacheSize written.
+	SkipUnchanged      bool
+	UnchangedCacheSize int
+	// StrictSequence stashes events older than the last applied for their
+	// aggregate, up to SequenceBufferSize at once, and retries them after
+	// the aggregate's next event or SequenceTimeout.
+	StrictSequence     bool
+	SequenceBufferSize int
+	SequenceTimeout    time.Duration
+	// BatchSize above 1 hands the handler up to that many events at once,
+	// waiting at most BatchWait for them, and writes tutor upserts among
+	// them with one bulk request. Ignored with StrictSequence.
+	BatchSize int
+	BatchWait time.Duration
+}
+
+// Kafka consumer defaults.
+const (
+	DefaultKafkaMaxMessageBytes = 1 << 20
+	DefaultKafkaHandleTimeout   = 30 * time.Second
+	DefaultKafkaIdleHeartbeat   = time.Minute
+	DefaultUnchangedCacheSize   = 10000
+	DefaultSequenceBufferSize   = 100
+	DefaultSequenceTimeout      = 5 * time.Second
+	DefaultKafkaBatchWait       = 100 * time.Millisecond
+	// MaxKafkaBatchSize keeps a batch within one bulk request.
+	MaxKafkaBatchSize = 500
+	// kafkaFetchBytes is the most the consumer fetches at once; a larger
+	// message could never be read.
+	kafkaFetchBytes = 10_000_000
+)
+
+// CORSConfig holds CORS settings.
+type CORSConfig struct {
+	AllowedOrigins string
+}
+
+// AdminConfig holds settings for destructive admin endpoints.
+type AdminConfig struct {
+	// APIKey is the bearer token those endpoints require. When empty they
+	// are disabled.
+	APIKey string
+	// RawQuery enables POST /admin/query, which runs caller-supplied
+	// OpenSearch queries.
+	RawQuery bool
+	// AuditLogSize is how many audit entries GET /admin/audit can return.
+	AuditLogSize int
+	// AuditLogFile, if set, is a JSON Lines file every audit entry is
+	// appended to.
+	AuditLogFile string
+}
+
+// AuthConfig holds settings for verifying end-user tokens.
+type AuthConfig struct {
+	// JWTSecret is the HS256 key Django signs access tokens with. When empty
+	// the per-user /me endpoints reject every request.
+	JWTSecret string
+}
+
+// DjangoConfig holds settings for reading tutors back from Django.
+type DjangoConfig struct {
+	// APIURL is the Django backend base URL. When empty, reindexing from
+	// Django is unavailable.
+	APIURL string
+}
+
+// ReindexConfig holds settings for the built-in full resync.
+type ReindexConfig struct {
+	// Schedule is an interval or cron expression; empty disables scheduled
+	// reindexing.
+	Schedule string
+	// LeaseTTL is how long a replica holds the job lease without renewing
+	// it; only the holder runs scheduled reindexes. Zero lets every
+	// replica run them.
+	LeaseTTL time.Duration
+	// LeaseHolder names this replica in the lease; empty means the host
+	// name and process ID.
+	LeaseHolder string
+}
+
+// DefaultReindexLeaseTTL is the default REINDEX_LEASE_TTL.
+const DefaultReindexLeaseTTL = 30 * time.Second
+
+// minReindexLeaseTTL keeps the heartbeat, a third of the TTL, well above
+// the round trips it makes.
+const minReindexLeaseTTL = 3 * time.Second
+
+// DriftConfig holds settings for comparing the index size with Django's.
+type DriftConfig struct {
+	// Interval is how often the counts are compared; zero disables the
+	// check. It needs DJANGO_API_URL.
+	Interval time.Duration
+	// Threshold is the relative difference, e.g. 0.01 for 1%, above which
+	// a drift is reported.
+	Threshold float64
+}
+
+// JournalConfig holds settings for keeping index writes that failed on the
+// HTTP path until they can be replayed.
+type JournalConfig struct {
+	// File, if set, keeps pending writes across restarts. When empty they
+	// are kept in memory only.
+	File string
+	// MaxEntries caps the backlog; writes beyond it fail as before.
+	MaxEntries int
+}
+
+// TenantConfig maps marketplaces to their own indices.
+type TenantConfig struct {
+	// Tenants is a comma-separated list of name=index pairs. Empty serves
+	// only the default tutors index.
+	Tenants string
+	// Default names the tenant used when a request or event names none;
+	// empty means the first one listed.
+	Default string
+}
+
+// Registry builds the tenant registry, or returns nil when no tenants are
+// configured.
+func (c TenantConfig) Registry() (*tenant.Registry, error) {
+	if c.Tenants == "" {
+		return nil, nil
+	}
+	tenants, err := tenant.Parse(c.Tenants)
+	if err != nil {
+		return nil, err
+	}
+	return tenant.NewRegistry(tenants, c.Default)
+}
+
+// ExperimentConfig holds the relevance A/B test definition.
+type ExperimentConfig struct {
+	// File is a JSON experiment definition; empty runs no experiment.
+	File string
+}
+
+// Load reads the experiment, or returns nil when none is configured.
+func (c ExperimentConfig) Load() (*experiment.Experiment, error) {
+	if c.File == "" {
+		return nil, nil
+	}
+	return experiment.Load(c.File)
+}
+
+// RelevanceConfig holds the relevance settings file, reloaded while the
+// service runs.
+type RelevanceConfig struct {
+	// File is a JSON object overriding fields of
+	// opensearch.DefaultRelevance for searches outside an experiment
+	// variant; empty uses the defaults.
+	File string
+	// PollInterval is how often File is checked for changes.
+	PollInterval time.Duration
+}
+
+// DefaultRelevancePollInterval is the default RELEVANCE_CONFIG_POLL_INTERVAL.
+const DefaultRelevancePollInterval = 10 * time.Second
+
+// ShadowConfig selects a candidate relevance config to compare against the
+// current one on a sample of live searches.
+type ShadowConfig struct {
+	// File is a relevance config like RELEVANCE_CONFIG_FILE; empty
+	// disables shadow reads.
+	File string
+	// SamplePercent is the share of eligible searches, 0 to 100, also run
+	// with File.
+	SamplePercent float64
+}
+
+// DefaultShadowSamplePercent is the default SHADOW_SAMPLE_PERCENT.
+const DefaultShadowSamplePercent = 1
+
+// FeedbackConfig holds settings for POST /feedback.
+type FeedbackConfig struct {
+	// Enabled registers the endpoint.
+	Enabled bool
+	// KafkaTopic, if set, publishes feedback to that topic on
+	// KAFKA_BROKERS instead of logging it.
+	KafkaTopic string
+	// MaxBatch caps the events per request.
+	MaxBatch int
+	// RateLimit is the requests per second allowed per client, in bursts
+	// of up to RateBurst.
+	RateLimit float64
+	RateBurst int
+}
+
+// Feedback defaults.
+const (
+	DefaultFeedbackMaxBatch  = 100
+	DefaultFeedbackRateLimit = 5
+	DefaultFeedbackRateBurst = 20
+	// maxFeedbackBatch keeps a full batch well inside the request body
+	// limit.
+	maxFeedbackBatch = 500
+)
+
+// FeatureFlags toggles optional subsystems.
+type FeatureFlags struct {
+	KafkaConsumer bool
+	// TutorBackfill fetches tutors that a booking event finds missing from
+	// the index back from Django. It needs DJANGO_API_URL.
+	TutorBackfill bool
+}
+
+// Kafka start offsets accepted in KAFKA_START_OFFSET.
+const (
+	StartOffsetEarliest = "earliest"
+	StartOffsetLatest   = "latest"
+)
+
+// Load reads the configuration from the process environment.
+func Load() (*Config, error) {
+	return LoadFrom(os.Getenv)
+}
+
+// LoadFrom reads the configuration using getenv and validates it.
+// All parse and validation problems are reported together in one error.
+func LoadFrom(getenv func(string) string) (*Config, error) {
+	l := &loader{getenv: getenv}
+
+	cfg := &Config{
+		Server: ServerConfig{
+			Port:         l.int("PORT", 8080),
+			ReadTimeout:  l.duration("HTTP_READ_TIMEOUT", 15*time.Second),
+			WriteTimeout: l.duration("HTTP_WRITE_TIMEOUT", 11*time.Minute),
+			IdleTimeout:  l.duration("HTTP_IDLE_TIMEOUT", 60*time.Second),
+
+			ShutdownGrace: l.duration("HTTP_SHUTDOWN_GRACE", 10*time.Second),
+
+			SearchTimeout:   l.duration("HTTP_SEARCH_TIMEOUT", 3*time.Second),
+			MutationTimeout: l.duration("HTTP_MUTATION_TIMEOUT", 10*time.Second),
+			AdminTimeout:    l.duration("HTTP_ADMIN_TIMEOUT", 10*time.Minute),
+
+			QuickSearchTimeout: l.duration("HTTP_QUICK_SEARCH_TIMEOUT", 100*time.Millisecond),
+
+			GRPCPort: l.int("GRPC_PORT", 0),
+
+			StartupMode: l.string("STARTUP_MODE", bootstrap.ModeStrict),
+		},
+		Search: SearchConfig{
+			Backend: l.string("SEARCH_BACKEND", BackendOpenSearch),
+
+			MaxSubjects:   l.int("SEARCH_MAX_SUBJECTS", DefaultMaxSubjects),
+			MaxLocations:  l.int("SEARCH_MAX_LOCATIONS", DefaultMaxLocations),
+			MaxExcludeIDs: l.int("SEARCH_MAX_EXCLUDE_IDS", DefaultMaxExcludeIDs),
+			MaxFacetSize:  l.int("SEARCH_MAX_FACET_SIZE", port.MaxFacetSize),
+
+			MaxConcurrent:  l.int("MAX_CONCURRENT_SEARCHES", DefaultMaxConcurrentSearches),
+			AcquireTimeout: l.duration("SEARCH_ACQUIRE_TIMEOUT", DefaultSearchAcquireTimeout),
+
+			PinnedBadge: l.string("SEARCH_PINNED_BADGE", ""),
+
+			MaxResponseBytes: l.int("SEARCH_MAX_RESPONSE_BYTES", DefaultMaxResponseBytes),
+		},
+		Indexing: IndexingConfig{
+			MaxListItems:      l.int("TUTOR_MAX_LIST_ITEMS", domain.DefaultMaxListItems),
+			MaxTutorID:        int64(l.int("TUTOR_MAX_ID", domain.DefaultMaxTutorID)),
+			AvatarCDNBase:     l.string("AVATAR_CDN_BASE", ""),
+			AvatarStripParams: l.listOr("AVATAR_STRIP_PARAMS", domain.DefaultAvatarStripParams),
+			RatingMode:        l.string("RATING_CONSISTENCY", domain.RatingModeLenient),
+			SubjectsFile:      l.string("SUBJECTS_FILE", ""),
+			TranslationsFile:  l.string("SUBJECT_TRANSLATIONS_FILE", ""),
+			MaxConcurrent:     l.int("MAX_CONCURRENT_INDEXING", DefaultMaxConcurrentIndexing),
+			Scrub:             l.bool("SCRUB_CONTACT_DETAILS", true),
+			ScrubExtraPattern: l.string("SCRUB_EXTRA_PATTERN", ""),
+			Popularity: domain.PopularityWeights{
+				Rating:          l.float("POPULARITY_RATING_WEIGHT", domain.DefaultPopularityWeights.Rating),
+				Reviews:         l.float("POPULARITY_REVIEWS_WEIGHT", domain.DefaultPopularityWeights.Reviews),
+				Verified:        l.float("POPULARITY_VERIFIED_WEIGHT", domain.DefaultPopularityWeights.Verified),
+				Recency:         l.float("POPULARITY_RECENCY_WEIGHT", domain.DefaultPopularityWeights.Recency),
+				RecencyHalfLife: l.duration("POPULARITY_RECENCY_HALF_LIFE", domain.DefaultPopularityWeights.RecencyHalfLife),
+			},
+		},
+		OpenSearch: OpenSearchConfig{
+			URL:      l.string("OPENSEARCH_URL", ""),
+			Shards:   l.int("OPENSEARCH_SHARDS", port.DefaultIndexSettings.Shards),
+			Replicas: l.int("OPENSEARCH_REPLICAS", port.DefaultIndexSettings.Replicas),
+			Index:    l.string("OPENSEARCH_INDEX", port.IndexName),
+			Username: l.string("OPENSEARCH_USERNAME", ""),
+			Password: l.string("OPENSEARCH_PASSWORD", ""),
+
+			MaxRetries:   l.int("OPENSEARCH_MAX_RETRIES", DefaultOpenSearchMaxRetries),
+			RetryBackoff: l.duration("OPENSEARCH_RETRY_BACKOFF", DefaultOpenSearchRetryBackoff),
+			Refresh:      l.string("OPENSEARCH_REFRESH", RefreshTrue),
+			PingMode:     l.string("OPENSEARCH_PING_MODE", PingRoot),
+			PingTimeout:  l.duration("OPENSEARCH_PING_TIMEOUT", DefaultOpenSearchPingTimeout),
+			IndexRate:    l.float("OPENSEARCH_INDEX_RATE", DefaultOpenSearchIndexRate),
+
+			IndexExperiments: l.bool("OPENSEARCH_INDEX_EXPERIMENTS", false),
+			IndexAliases:     l.bool("OPENSEARCH_INDEX_ALIASES", false),
+
+			MaxIdleConns:          l.int("OPENSEARCH_MAX_IDLE_CONNS", DefaultOpenSearchMaxIdleConns),
+			MaxIdleConnsPerHost:   l.int("OPENSEARCH_MAX_IDLE_CONNS_PER_HOST", DefaultOpenSearchMaxIdleConnsPerHost),
+			IdleConnTimeout:       l.duration("OPENSEARCH_IDLE_CONN_TIMEOUT", DefaultOpenSearchIdleConnTimeout),
+			TLSHandshakeTimeout:   l.duration("OPENSEARCH_TLS_HANDSHAKE_TIMEOUT", DefaultOpenSearchTLSHandshakeTimeout),
+			ResponseHeaderTimeout: l.duration("OPENSEARCH_RESPONSE_HEADER_TIMEOUT", DefaultOpenSearchResponseHeaderTimeout),
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: l.string("CORS_ALLOWED_ORIGINS", "*"),
+		},
+		Admin: AdminConfig{
+			APIKey:       l.string("ADMIN_API_KEY", ""),
+			RawQuery:     l.bool("ADMIN_RAW_QUERY", false),
+			AuditLogSize: l.int("AUDIT_LOG_SIZE", audit.DefaultCapacity),
+			AuditLogFile: l.string("AUDIT_LOG_FILE", ""),
+		},
+		Auth: AuthConfig{
+			JWTSecret: l.string("JWT_SECRET", ""),
+		},
+		Django: DjangoConfig{
+			APIURL: l.string("DJANGO_API_URL", ""),
+		},
+		Reindex: ReindexConfig{
+			Schedule:    l.string("REINDEX_SCHEDULE", ""),
+			LeaseTTL:    l.duration("REINDEX_LEASE_TTL", DefaultReindexLeaseTTL),
+			LeaseHolder: l.string("REINDEX_LEASE_HOLDER", ""),
+		},
+		Drift: DriftConfig{
+			Interval:  l.duration("DRIFT_CHECK_INTERVAL", 0),
+			Threshold: l.float("DRIFT_THRESHOLD", 0.01),
+		},
+		Journal: JournalConfig{
+			File:       l.string("WRITE_JOURNAL_FILE", ""),
+			MaxEntries: l.int("WRITE_JOURNAL_MAX_ENTRIES", outbox.DefaultMaxEntries),
+		},
+		Tenant: TenantConfig{
+			Tenants: l.string("TENANTS", ""),
+			Default: l.string("DEFAULT_TENANT", ""),
+		},
+		Experiment: ExperimentConfig{
+			File: l.string("EXPERIMENT_CONFIG_FILE", ""),
+		},
+		Relevance: RelevanceConfig{
+			File:         l.string("RELEVANCE_CONFIG_FILE", ""),
+			PollInterval: l.duration("RELEVANCE_CONFIG_POLL_INTERVAL", DefaultRelevancePollInterval),
+		},
+		Shadow: ShadowConfig{
+			File:          l.string("SHADOW_RELEVANCE_FILE", ""),
+			SamplePercent: l.float("SHADOW_SAMPLE_PERCENT", DefaultShadowSamplePercent),
+		},
+		Feedback: FeedbackConfig{
+			Enabled:    l.bool("FEEDBACK_ENABLED", true),
+			KafkaTopic: l.string("FEEDBACK_KAFKA_TOPIC", ""),
+			MaxBatch:   l.int("FEEDBACK_MAX_BATCH", DefaultFeedbackMaxBatch),
+			RateLimit:  l.float("FEEDBACK_RATE_LIMIT", DefaultFeedbackRateLimit),
+			RateBurst:  l.int("FEEDBACK_RATE_BURST", DefaultFeedbackRateBurst),
+		},
+		Log: LogConfig{
+			Level:     strings.ToLower(l.string("LOG_LEVEL", LogLevelInfo)),
+			Format:    strings.ToLower(l.string("LOG_FORMAT", LogFormatJSON)),
+			AddSource: l.bool("LOG_SOURCE", false),
+		},
+		Features: FeatureFlags{
+			KafkaConsumer: l.bool("KAFKA_CONSUMER_ENABLED", true),
+			TutorBackfill: l.bool("TUTOR_BACKFILL_ENABLED", true),
+		},
+	}
+
+	cfg.Kafka = KafkaConfig{
+		Brokers:      l.list("KAFKA_BROKERS"),
+		Topic:        l.string("KAFKA_TOPIC", "tutor-events"),
+		BookingTopic: l.string("KAFKA_BOOKING_TOPIC", ""),
+		GroupID:      l.string("KAFKA_GROUP_ID", "search-service"),
+		StartOffset:  l.string("KAFKA_START_OFFSET", StartOffsetEarliest),
+
+		HealthGracePeriod: l.duration("KAFKA_HEALTH_GRACE_PERIOD", time.Minute),
+		MaxStaleness:      l.duration("KAFKA_MAX_STALENESS", 0),
+		WatermarkFile:     l.string("KAFKA_WATERMARK_FILE", ""),
+		MaxMessageBytes:   l.int("KAFKA_MAX_MESSAGE_BYTES", DefaultKafkaMaxMessageBytes),
+		HandleTimeout:     l.duration("KAFKA_HANDLE_TIMEOUT", DefaultKafkaHandleTimeout),
+		IdleHeartbeat:     l.duration("KAFKA_IDLE_HEARTBEAT", DefaultKafkaIdleHeartbeat),
+
+		SkipUnchanged:      l.bool("KAFKA_SKIP_UNCHANGED", false),
+		UnchangedCacheSize: l.int("KAFKA_UNCHANGED_CACHE_SIZE", DefaultUnchangedCacheSize),
+
+		StrictSequence:     l.bool("KAFKA_STRICT_SEQUENCE", false),
+		SequenceBufferSize: l.int("KAFKA_SEQUENCE_BUFFER_SIZE", DefaultSequenceBufferSize),
+		SequenceTimeout:    l.duration("KAFKA_SEQUENCE_TIMEOUT", DefaultSequenceTimeout),
+		BatchSize:          l.int("KAFKA_BATCH_SIZE", 1),
+		BatchWait:          l.duration("KAFKA_BATCH_WAIT", DefaultKafkaBatchWait),
+	}
+
+	if err := errors.Join(append(l.errs, cfg.Validate())...); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+// Validate checks semantic constraints and returns every violation joined
+// into a single error, or nil when the configuration is usable.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.Server.Port < 0 || c.Server.Port > 65535 {
+		errs = append(errs, fmt.Errorf("PORT: must be between 0 and 65535, got %d", c.Server.Port))
+	}
+	if c.Server.GRPCPort != 0 {
+		if c.Server.GRPCPort < 1 || c.Server.GRPCPort > 65535 {
+			errs = append(errs, fmt.Errorf("GRPC_PORT: must be between 1 and 65535, got %d", c.Server.GRPCPort))
+		} else if c.Server.GRPCPort == c.Server.Port {
+			errs = append(errs, fmt.Errorf("GRPC_PORT: must differ from PORT (%d)", c.Server.Port))
+		}
+	}
+	switch c.Server.StartupMode {
+	case bootstrap.ModeStrict, bootstrap.ModeLazy:
+	default:
+		errs = append(errs, fmt.Errorf("STARTUP_MODE: must be one of %s|%s, got %q",
+			bootstrap.ModeStrict, bootstrap.ModeLazy, c.Server.StartupMode))
+	}
+	errs = append(errs,
+		positive("HTTP_READ_TIMEOUT", c.Server.ReadTimeout),
+		positive("HTTP_WRITE_TIMEOUT", c.Server.WriteTimeout),
+		positive("HTTP_IDLE_TIMEOUT", c.Server.IdleTimeout),
+		positive("HTTP_SHUTDOWN_GRACE", c.Server.ShutdownGrace),
+		positive("HTTP_SEARCH_TIMEOUT", c.Server.SearchTimeout),
+		positive("HTTP_MUTATION_TIMEOUT", c.Server.MutationTimeout),
+		positive("HTTP_ADMIN_TIMEOUT", c.Server.AdminTimeout),
+		positive("HTTP_QUICK_SEARCH_TIMEOUT", c.Server.QuickSearchTimeout),
+	)
+	longest := max(c.Server.SearchTimeout, c.Server.MutationTimeout, c.Server.AdminTimeout, c.Server.QuickSearchTimeout)
+	if c.Server.WriteTimeout > 0 && c.Server.WriteTimeout <= longest {
+		errs = append(errs, fmt.Errorf("HTTP_WRITE_TIMEOUT: must exceed the longest route timeout (%s), got %s",
+			longest, c.Server.WriteTimeout))
+	}
+
+	for _, limit := range []struct {
+		key   string
+		value int
+	}{
+		{"SEARCH_MAX_SUBJECTS", c.Search.MaxSubjects},
+		{"SEARCH_MAX_LOCATIONS", c.Search.MaxLocations},
+		{"SEARCH_MAX_EXCLUDE_IDS", c.Search.MaxExcludeIDs},
+		{"MAX_CONCURRENT_SEARCHES", c.Search.MaxConcurrent},
+		{"MAX_CONCURRENT_INDEXING", c.Indexing.MaxConcurrent},
+		{"AUDIT_LOG_SIZE", c.Admin.AuditLogSize},
+	} {
+		if limit.value < 1 {
+			errs = append(errs, fmt.Errorf("%s: must be positive, got %d", limit.key, limit.value))
+		}
+	}
+	if c.Search.MaxFacetSize < 1 || c.Search.MaxFacetSize > port.MaxFacetSize {
+		errs = append(errs, fmt.Errorf("SEARCH_MAX_FACET_SIZE: must be between 1 and %d, got %d",
+			port.MaxFacetSize, c.Search.MaxFacetSize))
+	}
+	errs = append(errs, positive("SEARCH_ACQUIRE_TIMEOUT", c.Search.AcquireTimeout))
+	if c.Search.PinnedBadge != "" {
+		if err := domain.ValidateBadge(c.Search.PinnedBadge); err != nil {
+			errs = append(errs, fmt.Errorf("SEARCH_PINNED_BADGE: %w", err))
+		}
+	}
+	if c.Search.MaxResponseBytes < 0 {
+		errs = append(errs, fmt.Errorf("SEARCH_MAX_RESPONSE_BYTES: must not be negative, got %d", c.Search.MaxResponseBytes))
+	}
+
+	if c.Indexing.MaxListItems < 1 {
+		errs = append(errs, fmt.Errorf("TUTOR_MAX_LIST_ITEMS: must be positive, got %d", c.Indexing.MaxListItems))
+	}
+	if c.Indexing.MaxTutorID < 1 {
+		errs = append(errs, fmt.Errorf("TUTOR_MAX_ID: must be positive, got %d", c.Indexing.MaxTutorID))
+	}
+	for _, weight := range []struct {
+		key   string
+		value float64
+	}{
+		{"POPULARITY_RATING_WEIGHT", c.Indexing.Popularity.Rating},
+		{"POPULARITY_REVIEWS_WEIGHT", c.Indexing.Popularity.Reviews},
+		{"POPULARITY_VERIFIED_WEIGHT", c.Indexing.Popularity.Verified},
+		{"POPULARITY_RECENCY_WEIGHT", c.Indexing.Popularity.Recency},
+	} {
+		if weight.value < 0 {
+			errs = append(errs, fmt.Errorf("%s: must not be negative, got %g", weight.key, weight.value))
+		}
+	}
+	errs = append(errs, positive("POPULARITY_RECENCY_HALF_LIFE", c.Indexing.Popularity.RecencyHalfLife))
+
+	switch c.Indexing.RatingMode {
+	case domain.RatingModeLenient, domain.RatingModeStrict:
+	default:
+		errs = append(errs, fmt.Errorf("RATING_CONSISTENCY: must be one of %s|%s, got %q",
+			domain.RatingModeLenient, domain.RatingModeStrict, c.Indexing.RatingMode))
+	}
+
+	if c.Indexing.AvatarCDNBase != "" {
+		if err := validateHTTPURL(c.Indexing.AvatarCDNBase); err != nil {
+			errs = append(errs, fmt.Errorf("AVATAR_CDN_BASE: %w", err))
+		}
+	}
+
+	if _, err := c.Indexing.SubjectCatalog(); err != nil {
+		errs = append(errs, fmt.Errorf("SUBJECTS_FILE: %w", err))
+	}
+	if _, err := c.Indexing.Translations(); err != nil {
+		errs = append(errs, fmt.Errorf("SUBJECT_TRANSLATIONS_FILE: %w", err))
+	}
+	if _, err := c.Indexing.Scrubber(); err != nil {
+		errs = append(errs, fmt.Errorf("SCRUB_EXTRA_PATTERN: %w", err))
+	}
+
+	switch c.Search.Backend {
+	case BackendOpenSearch:
+		if c.OpenSearch.URL == "" {
+			errs = append(errs, errors.New("OPENSEARCH_URL: required but not set"))
+		} else if err := validateHTTPURL(c.OpenSearch.URL); err != nil {
+			errs = append(errs, fmt.Errorf("OPENSEARCH_URL: %w", err))
+		}
+		if c.OpenSearch.Shards < 1 || c.OpenSearch.Shards > port.MaxShards {
+			errs = append(errs, fmt.Errorf("OPENSEARCH_SHARDS: must be between 1 and %d, got %d", port.MaxShards, c.OpenSearch.Shards))
+		}
+		if err := port.ValidateReplicas(c.OpenSearch.Replicas); err != nil {
+			errs = append(errs, fmt.Errorf("OPENSEARCH_REPLICAS: %w", err))
+		}
+		if !tenant.ValidIndex(c.OpenSearch.Index) {
+			errs = append(errs, fmt.Errorf("OPENSEARCH_INDEX: %q is not a valid index name", c.OpenSearch.Index))
+		}
+		if c.OpenSearch.Password != "" && c.OpenSearch.Username == "" {
+			errs = append(errs, errors.New("OPENSEARCH_PASSWORD: set without OPENSEARCH_USERNAME"))
+		}
+		if c.OpenSearch.MaxRetries < 0 {
+			errs = append(errs, fmt.Errorf("OPENSEARCH_MAX_RETRIES: must not be negative, got %d", c.OpenSearch.MaxRetries))
+		}
+		if c.OpenSearch.RetryBackoff < 0 {
+			errs = append(errs, fmt.Errorf("OPENSEARCH_RETRY_BACKOFF: must not be negative, got %s", c.OpenSearch.RetryBackoff))
+		}
+		switch c.OpenSearch.Refresh {
+		case RefreshTrue, RefreshWaitFor, RefreshFalse:
+		default:
+			errs = append(errs, fmt.Errorf("OPENSEARCH_REFRESH: must be one of %s|%s|%s, got %q",
+				RefreshTrue, RefreshWaitFor, RefreshFalse, c.OpenSearch.Refresh))
+		}
+		switch c.OpenSearch.PingMode {
+		case PingRoot, PingIndex:
+		default:
+			errs = append(errs, fmt.Errorf("OPENSEARCH_PING_MODE: must be one of %s|%s, got %q",
+				PingRoot, PingIndex, c.OpenSearch.PingMode))
+		}
+		if c.OpenSearch.PingTimeout <= 0 {
+			errs = append(errs, fmt.Errorf("OPENSEARCH_PING_TIMEOUT: must be positive, got %s", c.OpenSearch.PingTimeout))
+		}
+		if c.OpenSearch.IndexRate < 0 {
+			errs = append(errs, fmt.Errorf("OPENSEARCH_INDEX_RATE: must not be negative, got %g", c.OpenSearch.IndexRate))
+		}
+		for _, n := range []struct {
+			key   string
+			value int
+		}{
+			{"OPENSEARCH_MAX_IDLE_CONNS", c.OpenSearch.MaxIdleConns},
+			{"OPENSEARCH_MAX_IDLE_CONNS_PER_HOST", c.OpenSearch.MaxIdleConnsPerHost},
+		} {
+			if n.value < 0 {
+				errs = append(errs, fmt.Errorf("%s: must not be negative, got %d", n.key, n.value))
+			}
+		}
+		for _, d := range []struct {
+			key   string
+			value time.Duration
+		}{
+			{"OPENSEARCH_IDLE_CONN_TIMEOUT", c.OpenSearch.IdleConnTimeout},
+			{"OPENSEARCH_TLS_HANDSHAKE_TIMEOUT", c.OpenSearch.TLSHandshakeTimeout},
+			{"OPENSEARCH_RESPONSE_HEADER_TIMEOUT", c.OpenSearch.ResponseHeaderTimeout},
+		} {
+			if d.value < 0 {
+				errs = append(errs, fmt.Errorf("%s: must not be negative, got %s", d.key, d.value))
+			}
+		}
+	case BackendMemory:
+	default:
+		errs = append(errs, fmt.Errorf("SEARCH_BACKEND: must be one of %s|%s, got %q",
+			BackendOpenSearch, BackendMemory, c.Search.Backend))
+	}
+
+	if c.Django.APIURL != "" {
+		if err := validateHTTPURL(c.Django.APIURL); err != nil {
+			errs = append(errs, fmt.Errorf("DJANGO_API_URL: %w", err))
+		}
+	}
+	if c.Reindex.Schedule != "" {
+		if _, err := schedule.Parse(c.Reindex.Schedule); err != nil {
+			errs = append(errs, fmt.Errorf("REINDEX_SCHEDULE: %w", err))
+		}
+		if c.Django.APIURL == "" {
+			errs = append(errs, errors.New("DJANGO_API_URL: required when REINDEX_SCHEDULE is set"))
+		}
+	}
+	if c.Reindex.LeaseTTL != 0 && c.Reindex.LeaseTTL < minReindexLeaseTTL {
+		errs = append(errs, fmt.Errorf("REINDEX_LEASE_TTL: must be 0 or at least %s, got %s", minReindexLeaseTTL, c.Reindex.LeaseTTL))
+	}
+	if c.Drift.Interval < 0 {
+		errs = append(errs, fmt.Errorf("DRIFT_CHECK_INTERVAL: must not be negative, got %s", c.Drift.Interval))
+	}
+	if c.Drift.Interval > 0 && c.Django.APIURL == "" {
+		errs = append(errs, errors.New("DJANGO_API_URL: required when DRIFT_CHECK_INTERVAL is set"))
+	}
+	if !(c.Drift.Threshold > 0) {
+		errs = append(errs, fmt.Errorf("DRIFT_THRESHOLD: must be positive, got %g", c.Drift.Threshold))
+	}
+	if c.Journal.MaxEntries < 1 {
+		errs = append(errs, fmt.Errorf("WRITE_JOURNAL_MAX_ENTRIES: must be positive, got %d", c.Journal.MaxEntries))
+	}
+
+	if _, err := c.Tenant.Registry(); err != nil {
+		errs = append(errs, fmt.Errorf("TENANTS: %w", err))
+	}
+	if c.Tenant.Default != "" && c.Tenant.Tenants == "" {
+		errs = append(errs, errors.New("TENANTS: required when DEFAULT_TENANT is set"))
+	}
+
+	if _, err := c.Experiment.Load(); err != nil {
+		errs = append(errs, fmt.Errorf("EXPERIMENT_CONFIG_FILE: %w", err))
+	}
+	if c.Relevance.File != "" {
+		if _, err := relevance.Load(c.Relevance.File); err != nil {
+			errs = append(errs, fmt.Errorf("RELEVANCE_CONFIG_FILE: %w", err))
+		}
+	}
+	if err := positive("RELEVANCE_CONFIG_POLL_INTERVAL", c.Relevance.PollInterval); err != nil {
+		errs = append(errs, err)
+	}
+
+	if c.Shadow.File != "" {
+		if _, err := relevance.Load(c.Shadow.File); err != nil {
+			errs = append(errs, fmt.Errorf("SHADOW_RELEVANCE_FILE: %w", err))
+		}
+		if c.Search.Backend != BackendOpenSearch {
+			errs = append(errs, fmt.Errorf("SHADOW_RELEVANCE_FILE: requires SEARCH_BACKEND=%s", BackendOpenSearch))
+		}
+	}
+	if !(c.Shadow.SamplePercent >= 0 && c.Shadow.SamplePercent <= 100) {
+		errs = append(errs, fmt.Errorf("SHADOW_SAMPLE_PERCENT: must be between 0 and 100, got %g", c.Shadow.SamplePercent))
+	}
+
+	if c.Feedback.MaxBatch < 1 || c.Feedback.MaxBatch > maxFeedbackBatch {
+		errs = append(errs, fmt.Errorf("FEEDBACK_MAX_BATCH: must be between 1 and %d, got %d", maxFeedbackBatch, c.Feedback.MaxBatch))
+	}
+	if !(c.Feedback.RateLimit > 0) {
+		errs = append(errs, fmt.Errorf("FEEDBACK_RATE_LIMIT: must be positive, got %g", c.Feedback.RateLimit))
+	}
+	if c.Feedback.RateBurst < 1 {
+		errs = append(errs, fmt.Errorf("FEEDBACK_RATE_BURST: must be positive, got %d", c.Feedback.RateBurst))
+	}
+	if c.Feedback.KafkaTopic != "" && len(c.Kafka.Brokers) == 0 {
+		errs = append(errs, errors.New("KAFKA_BROKERS: required when FEEDBACK_KAFKA_TOPIC is set"))
+	}
+
+	if c.Features.KafkaConsumer {
+		if len(c.Kafka.Brokers) == 0 {
+			errs = append(errs, errors.New("KAFKA_BROKERS: required when KAFKA_CONSUMER_ENABLED is true"))
+		}
+		for _, b := range c.Kafka.Brokers {
+			if _, _, err := net.SplitHostPort(b); err != nil {
+				errs = append(errs, fmt.Errorf("KAFKA_BROKERS: %q is not host:port", b))
+			}
+		}
+		if c.Kafka.Topic == "" {
+			errs = append(errs, errors.New("KAFKA_TOPIC: must not be empty"))
+		}
+		if c.Kafka.BookingTopic != "" && c.Kafka.BookingTopic == c.Kafka.Topic {
+			errs = append(errs, errors.New("KAFKA_BOOKING_TOPIC: must differ from KAFKA_TOPIC"))
+		}
+		if c.Kafka.GroupID == "" {
+			errs = append(errs, errors.New("KAFKA_GROUP_ID: must not be empty"))
+		}
+		errs = append(errs, positive("KAFKA_HEALTH_GRACE_PERIOD", c.Kafka.HealthGracePeriod))
+		if c.Kafka.MaxStaleness < 0 {
+			errs = append(errs, fmt.Errorf("KAFKA_MAX_STALENESS: must not be negative, got %s", c.Kafka.MaxStaleness))
+		}
+		if c.Kafka.MaxMessageBytes <= 0 || c.Kafka.MaxMessageBytes > kafkaFetchBytes {
+			errs = append(errs, fmt.Errorf("KAFKA_MAX_MESSAGE_BYTES: must be between 1 and %d, got %d",
+				kafkaFetchBytes, c.Kafka.MaxMessageBytes))
+		}
+		if c.Kafka.HandleTimeout < 0 {
+			errs = append(errs, fmt.Errorf("KAFKA_HANDLE_TIMEOUT: must not be negative, got %s", c.Kafka.HandleTimeout))
+		}
+		if c.Kafka.IdleHeartbeat < 0 {
+			errs = append(errs, fmt.Errorf("KAFKA_IDLE_HEARTBEAT: must not be negative, got %s", c.Kafka.IdleHeartbeat))
+		}
+		if c.Kafka.SkipUnchanged && c.Kafka.UnchangedCacheSize <= 0 {
+			errs = append(errs, fmt.Errorf("KAFKA_UNCHANGED_CACHE_SIZE: must be positive, got %d", c.Kafka.UnchangedCacheSize))
+		}
+		if c.Kafka.StrictSequence && c.Kafka.SequenceBufferSize <= 0 {
+			errs = append(errs, fmt.Errorf("KAFKA_SEQUENCE_BUFFER_SIZE: must be positive, got %d", c.Kafka.SequenceBufferSize))
+		}
+		if c.Kafka.StrictSequence && c.Kafka.SequenceTimeout <= 0 {
+			errs = append(errs, fmt.Errorf("KAFKA_SEQUENCE_TIMEOUT: must be positive, got %s", c.Kafka.SequenceTimeout))
+		}
+		if c.Kafka.BatchSize < 1 || c.Kafka.BatchSize > MaxKafkaBatchSize {
+			errs = append(errs, fmt.Errorf("KAFKA_BATCH_SIZE: must be between 1 and %d, got %d", MaxKafkaBatchSize, c.Kafka.BatchSize))
+		}
+		if c.Kafka.BatchSize > 1 && c.Kafka.BatchWait <= 0 {
+			errs = append(errs, fmt.Errorf("KAFKA_BATCH_WAIT: must be positive, got %s", c.Kafka.BatchWait))
+		}
+	}
+	switch c.Kafka.StartOffset {
+	case StartOffsetEarliest, StartOffsetLatest:
+	default:
+		errs = append(errs, fmt.Errorf("KAFKA_START_OFFSET: must be one of %s|%s, got %q",
+			StartOffsetEarliest, StartOffsetLatest, c.Kafka.StartOffset))
+	}
+
+	switch c.Log.Level {
+	case LogLevelDebug, LogLevelInfo, LogLevelWarn, LogLevelError:
+	default:
+		errs = append(errs, fmt.Errorf("LOG_LEVEL: must be one of %s|%s|%s|%s, got %q",
+			LogLevelDebug, LogLevelInfo, LogLevelWarn, LogLevelError, c.Log.Level))
+	}
+	switch c.Log.Format {
+	case LogFormatJSON, LogFormatText:
+	default:
+		errs = append(errs, fmt.Errorf("LOG_FORMAT: must be one of %s|%s, got %q",
+			LogFormatJSON, LogFormatText, c.Log.Format))
+	}
+
+	return errors.Join(errs...)
+}
+
+// LogValue renders the effective configuration for startup logging with
+// credentials redacted.
+func (c *Config) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Group("server",
+			"port", c.Server.Port,
+			"read_timeout", c.Server.ReadTimeout.String(),
+			"write_timeout", c.Server.WriteTimeout.String(),
+			"idle_timeout", c.Server.IdleTimeout.String(),
+			"shutdown_grace", c.Server.ShutdownGrace.String(),
+			"search_timeout", c.Server.SearchTimeout.String(),
+			"mutation_timeout", c.Server.MutationTimeout.String(),
+			"admin_timeout", c.Server.AdminTimeout.String(),
+			"quick_search_timeout", c.Server.QuickSearchTimeout.String(),
+			"grpc_port", c.Server.GRPCPort,
+			"startup_mode", c.Server.StartupMode,
+		),
+		slog.Group("search",
+			"backend", c.Search.Backend,
+			"max_subjects", c.Search.MaxSubjects,
+			"max_locations", c.Search.MaxLocations,
+			"max_exclude_ids", c.Search.MaxExcludeIDs,
+			"max_facet_size", c.Search.MaxFacetSize,
+			"max_concurrent", c.Search.MaxConcurrent,
+			"acquire_timeout", c.Search.AcquireTimeout.String(),
+			"pinned_badge", c.Search.PinnedBadge,
+			"max_response_bytes", c.Search.MaxResponseBytes,
+		),
+		slog.Group("indexing",
+			"max_list_items", c.Indexing.MaxListItems,
+			"max_tutor_id", c.Indexing.MaxTutorID,
+			"avatar_cdn_base", c.Indexing.AvatarCDNBase,
+			"avatar_strip_params", strings.Join(c.Indexing.AvatarStripParams, ","),
+			"rating_mode", c.Indexing.RatingMode,
+			"subjects_file", c.Indexing.SubjectsFile,
+			"translations_file", c.Indexing.TranslationsFile,
+			"max_concurrent", c.Indexing.MaxConcurrent,
+			"scrub", c.Indexing.Scrub,
+			"scrub_extra_pattern", c.Indexing.ScrubExtraPattern,
+			slog.Group("popularity",
+				"rating_weight", c.Indexing.Popularity.Rating,
+				"reviews_weight", c.Indexing.Popularity.Reviews,
+				"verified_weight", c.Indexing.Popularity.Verified,
+				"recency_weight", c.Indexing.Popularity.Recency,
+				"recency_half_life", c.Indexing.Popularity.RecencyHalfLife.String(),
+			),
+		),
+		slog.Group("opensearch",
+			"url", redactURL(c.OpenSearch.URL),
+			"shards", c.OpenSearch.Shards,
+			"replicas", c.OpenSearch.Replicas,
+			"index", c.OpenSearch.Index,
+			"username", c.OpenSearch.Username,
+			"password_set", c.OpenSearch.Password != "",
+			"max_retries", c.OpenSearch.MaxRetries,
+			"retry_backoff", c.OpenSearch.RetryBackoff,
+			"refresh", c.OpenSearch.Refresh,
+			"ping_mode", c.OpenSearch.PingMode,
+			"ping_timeout", c.OpenSearch.PingTimeout,
+			"index_rate", c.OpenSearch.IndexRate,
+			"index_experiments", c.OpenSearch.IndexExperiments,
+			"index_aliases", c.OpenSearch.IndexAliases,
+			"max_idle_conns", c.OpenSearch.MaxIdleConns,
+			"max_idle_conns_per_host", c.OpenSearch.MaxIdleConnsPerHost,
+			"idle_conn_timeout", c.OpenSearch.IdleConnTimeout,
+			"tls_handshake_timeout", c.OpenSearch.TLSHandshakeTimeout,
+			"response_header_timeout", c.OpenSearch.ResponseHeaderTimeout,
+		),
+		slog.Group("kafka",
+			"brokers", strings.Join(c.Kafka.Brokers, ","),
+			"topic", c.Kafka.Topic,
+			"booking_topic", c.Kafka.BookingTopic,
+			"group_id", c.Kafka.GroupID,
+			"start_offset", c.Kafka.StartOffset,
+			"health_grace_period", c.Kafka.HealthGracePeriod.String(),
+			"max_staleness", c.Kafka.MaxStaleness.String(),
+			"watermark_file", c.Kafka.WatermarkFile,
+			"max_message_bytes", c.Kafka.MaxMessageBytes,
+			"handle_timeout", c.Kafka.HandleTimeout.String(),
+			"idle_heartbeat", c.Kafka.IdleHeartbeat.String(),
+			"skip_unchanged", c.Kafka.SkipUnchanged,
+			"unchanged_cache_size", c.Kafka.UnchangedCacheSize,
+			"strict_sequence", c.Kafka.StrictSequence,
+			"sequence_buffer_size", c.Kafka.SequenceBufferSize,
+			"sequence_timeout", c.Kafka.SequenceTimeout.String(),
+			"batch_size", c.Kafka.BatchSize,
+			"batch_wait", c.Kafka.BatchWait.String(),
+		),
+		slog.Group("cors",
+			"allowed_origins", c.CORS.AllowedOrigins,
+		),
+		slog.Group("admin",
+			"api_key_set", c.Admin.APIKey != "",
+			"raw_query", c.Admin.RawQuery,
+			"audit_log_size", c.Admin.AuditLogSize,
+			"audit_log_file", c.Admin.AuditLogFile,
+		),
+		slog.Group("auth",
+			"jwt_secret_set", c.Auth.JWTSecret != "",
+		),
+		slog.Group("django",
+			"api_url", redactURL(c.Django.APIURL),
+		),
+		slog.Group("reindex",
+			"schedule", c.Reindex.Schedule,
+			"lease_ttl", c.Reindex.LeaseTTL,
+			"lease_holder", c.Reindex.LeaseHolder,
+		),
+		slog.Group("drift",
+			"interval", c.Drift.Interval,
+			"threshold", c.Drift.Threshold,
+		),
+		slog.Group("journal",
+			"file", c.Journal.File,
+			"max_entries", c.Journal.MaxEntries,
+		),
+		slog.Group("tenant",
+			"tenants", c.Tenant.Tenants,
+			"default", c.Tenant.Default,
+		),
+		slog.Group("experiment",
+			"config_file", c.Experiment.File,
+		),
+		slog.Group("relevance",
+			"config_file", c.Relevance.File,
+			"poll_interval", c.Relevance.PollInterval,
+		),
+		slog.Group("shadow",
+			"relevance_file", c.Shadow.File,
+			"sample_percent", c.Shadow.SamplePercent,
+		),
+		slog.Group("log",
+			"level", c.Log.Level,
+			"format", c.Log.Format,
+			"add_source", c.Log.AddSource,
+		),
+		slog.Group("feedback",
+			"enabled", c.Feedback.Enabled,
+			"kafka_topic", c.Feedback.KafkaTopic,
+			"max_batch", c.Feedback.MaxBatch,
+			"rate_limit", c.Feedback.RateLimit,
+			"rate_burst", c.Feedback.RateBurst,
+		),
+		slog.Group("features",
+			"kafka_consumer", c.Features.KafkaConsumer,
+			"tutor_backfill", c.Features.TutorBackfill,
+		),
+	)
+}
+
+func positive(key string, d time.Duration) error {
+	if d <= 0 {
+		return fmt.Errorf("%s: must be positive, got %s", key, d)
+	}
+	return nil
+}
+
+func validateHTTPURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return errors.New("not a valid URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("scheme must be http or https, got %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.New("missing host")
+	}
+	return nil
+}
+
+// redactURL hides any password embedded in the URL userinfo.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "[invalid]"
+	}
+	return u.Redacted()
+}
+
+// loader reads typed values from the environment and collects parse errors
+// instead of failing on the first one.
+type loader struct {
+	getenv func(string) string
+	errs   []error
+}
+
+func (l *loader) string(key, defaultValue string) string {
+	if value := strings.TrimSpace(l.getenv(key)); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func (l *loader) int(key string, defaultValue int) int {
+	raw := l.string(key, "")
+	if raw == "" {
+		return defaultValue
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s: expected an integer, got %q", key, raw))
+		return defaultValue
+	}
+	return v
+}
+
+func (l *loader) float(key string, defaultValue float64) float64 {
+	raw := l.string(key, "")
+	if raw == "" {
+		return defaultValue
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s: expected a number, got %q", key, raw))
+		return defaultValue
+	}
+	return v
+}
+
+func (l *loader) bool(key string, defaultValue bool) bool {
+	raw := l.string(key, "")
+	if raw == "" {
+		return defaultValue
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s: expected a boolean, got %q", key, raw))
+		return defaultValue
+	}
+	return v
+}
+
+func (l *loader) duration(key string, defaultValue time.Duration) time.Duration {
+	raw := l.string(key, "")
+	if raw == "" {
+		return defaultValue
+	}
+	v, err := time.ParseDuration(raw)
+	if err != nil {
+		l.errs = append(l.errs, fmt.Errorf("%s: expected a duration like 15s, got %q", key, raw))
+		return defaultValue
+	}
+	return v
+}
+
+// list splits a comma-separated value, dropping blank entries.
+func (l *loader) list(key string) []string {
+	var out []string
+	for _, part := range strings.Split(l.getenv(key), ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// listOr is list with a default for an unset value; "none" yields an empty
+// list.
+func (l *loader) listOr(key string, defaultValue []string) []string {
+	switch l.string(key, "") {
+	case "":
+		return slices.Clone(defaultValue)
+	case "none":
+		return nil
+	}
+	return l.list(key)
+}
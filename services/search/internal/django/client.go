@@ -0,0 +1,207 @@
+// Package django reads tutors from the Django backend's public REST API.
+package django
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"search/internal/domain"
+)
+
+// DefaultTimeout bounds each request to Django.
+const DefaultTimeout = 30 * time.Second
+
+// ErrNotFound is returned when Django answers 404.
+var ErrNotFound = errors.New("not found in Django")
+
+// Client is a read-only client for Django's /api/tutors endpoints.
+type Client struct {
+	baseURL string
+	http    *http.Client
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient replaces the default HTTP client.
+func WithHTTPClient(c *http.Client) Option {
+	return func(client *Client) {
+		client.http = c
+	}
+}
+
+// NewClient creates a client for the Django backend at baseURL, e.g.
+// http://backend:8000.
+func NewClient(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		http:    &http.Client{Timeout: DefaultTimeout},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// tutorPage is one page of Django's paginated tutor list.
+type tutorPage struct {
+	Count   int     `json:"count"`
+	Next    *string `json:"next"`
+	Results []tutor `json:"results"`
+}
+
+// tutor is a tutor as Django's TutorSerializer renders it. Decimal fields
+// arrive as strings.
+type tutor struct {
+	ID           int64     `json:"id"`
+	Slug         string    `json:"slug"`
+	FullName     string    `json:"full_name"`
+	AvatarURL    string    `json:"avatar_url"`
+	Headline     string    `json:"headline"`
+	Bio          string    `json:"bio"`
+	Subjects     []string  `json:"subjects"`
+	HourlyRate   decimal   `json:"hourly_rate"`
+	OnlineRate   decimal   `json:"online_rate"`
+	OfflineRate  decimal   `json:"offline_rate"`
+	Rating       decimal   `json:"rating"`
+	ReviewsCount int       `json:"reviews_count"`
+	IsVerified   bool      `json:"is_verified"`
+	OffersTrial  bool      `json:"offers_trial"`
+	Location     string    `json:"location"`
+	Formats      []string  `json:"formats"`
+	Levels       []string  `json:"levels"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	Education      []domain.Education `json:"education"`
+	Certifications []string           `json:"certifications"`
+}
+
+func (t tutor) domain() domain.Tutor {
+	return domain.Tutor{
+		ID:           t.ID,
+		Slug:         t.Slug,
+		FullName:     t.FullName,
+		AvatarURL:    t.AvatarURL,
+		Headline:     t.Headline,
+		Bio:          t.Bio,
+		Subjects:     t.Subjects,
+		HourlyRate:   float64(t.HourlyRate),
+		OnlineRate:   float64(t.OnlineRate),
+		OfflineRate:  float64(t.OfflineRate),
+		Rating:       float64(t.Rating),
+		ReviewsCount: t.ReviewsCount,
+		IsVerified:   t.IsVerified,
+		OffersTrial:  t.OffersTrial,
+		Location:     t.Location,
+		Formats:      t.Formats,
+		Levels:       t.Levels,
+		CreatedAt:    t.CreatedAt,
+		UpdatedAt:    t.UpdatedAt,
+
+		Education:      t.Education,
+		Certifications: t.Certifications,
+	}
+}
+
+// decimal accepts a DRF DecimalField, which is a JSON string, or a number.
+type decimal float64
+
+func (d *decimal) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		return nil
+	}
+	raw := string(b)
+	if unquoted, err := strconv.Unquote(raw); err == nil {
+		raw = unquoted
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return fmt.Errorf("invalid decimal %s", b)
+	}
+	*d = decimal(v)
+	return nil
+}
+
+// ListTutors walks every page of GET /api/tutors/, oldest first, and calls fn
+// with each page. It stops at the first error from Django or fn.
+func (c *Client) ListTutors(ctx context.Context, fn func([]domain.Tutor) error) error {
+	next := c.baseURL + "/api/tutors/?" + url.Values{"ordering": {"created_at"}}.Encode()
+
+	for next != "" {
+		var page tutorPage
+		if err := c.get(ctx, next, &page); err != nil {
+			return err
+		}
+
+		tutors := make([]domain.Tutor, len(page.Results))
+		for i, t := range page.Results {
+			tutors[i] = t.domain()
+		}
+		if err := fn(tutors); err != nil {
+			return err
+		}
+
+		next = ""
+		if page.Next != nil {
+			next = *page.Next
+		}
+	}
+	return nil
+}
+
+// GetTutor fetches GET /api/tutors/{id}/. It returns ErrNotFound when the
+// tutor does not exist.
+func (c *Client) GetTutor(ctx context.Context, id int64) (*domain.Tutor, error) {
+	var t tutor
+	if err := c.get(ctx, c.baseURL+"/api/tutors/"+strconv.FormatInt(id, 10)+"/", &t); err != nil {
+		return nil, err
+	}
+	tutor := t.domain()
+	return &tutor, nil
+}
+
+// CountTutors returns the total Django reports for GET /api/tutors/,
+// fetching a single-item page.
+func (c *Client) CountTutors(ctx context.Context) (int64, error) {
+	var page tutorPage
+	if err := c.get(ctx, c.baseURL+"/api/tutors/?"+url.Values{"page_size": {"1"}}.Encode(), &page); err != nil {
+		return 0, err
+	}
+	return int64(page.Count), nil
+}
+
+func (c *Client) get(ctx context.Context, rawURL string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to build Django request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call Django: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrNotFound, req.URL.Path)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("django returned %d for %s: %s", resp.StatusCode, req.URL.Path, bytes.TrimSpace(body))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode Django response: %w", err)
+	}
+	return nil
+}
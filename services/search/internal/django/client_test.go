@@ -0,0 +1,156 @@
+package django
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/domain"
+)
+
+func TestListTutors_FollowsPages(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/tutors/", r.URL.Path)
+		assert.Equal(t, "created_at", r.URL.Query().Get("ordering"))
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Query().Get("page") {
+		case "":
+			fmt.Fprintf(w, `{"count": 3, "next": "%s/api/tutors/?ordering=created_at&page=2", "results": [
+				{"id": 1, "slug": "ada", "full_name": "Ada Lovelace", "subjects": ["math"], "hourly_rate": "42.50", "rating": "4.90", "reviews_count": 3, "is_verified": true, "formats": ["online"]},
+				{"id": 2, "slug": "marie", "full_name": "Marie Curie", "hourly_rate": 60, "online_rate": "55.00", "offline_rate": 60, "rating": null}
+			]}`, server.URL)
+		case "2":
+			fmt.Fprint(w, `{"count": 3, "next": null, "results": [{"id": 3, "slug": "emmy", "hourly_rate": "0.00", "rating": "0.00"}]}`)
+		default:
+			t.Errorf("unexpected page %q", r.URL.Query().Get("page"))
+		}
+	}))
+	defer server.Close()
+
+	var pages [][]domain.Tutor
+	err := NewClient(server.URL+"/").ListTutors(context.Background(), func(tutors []domain.Tutor) error {
+		pages = append(pages, tutors)
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, pages, 2)
+	require.Len(t, pages[0], 2)
+	assert.Equal(t, domain.Tutor{
+		ID:           1,
+		Slug:         "ada",
+		FullName:     "Ada Lovelace",
+		Subjects:     []string{"math"},
+		HourlyRate:   42.5,
+		Rating:       4.9,
+		ReviewsCount: 3,
+		IsVerified:   true,
+		Formats:      []string{"online"},
+	}, pages[0][0])
+	assert.Equal(t, 60.0, pages[0][1].HourlyRate)
+	assert.Equal(t, 55.0, pages[0][1].OnlineRate)
+	assert.Equal(t, 60.0, pages[0][1].OfflineRate)
+	assert.Zero(t, pages[0][1].Rating)
+	assert.Equal(t, int64(3), pages[1][0].ID)
+}
+
+func TestListTutors_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "database unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := NewClient(server.URL).ListTutors(context.Background(), func([]domain.Tutor) error {
+		t.Error("callback must not run")
+		return nil
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "503")
+	assert.Contains(t, err.Error(), "database unavailable")
+}
+
+func TestListTutors_InvalidDecimal(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"count": 1, "next": null, "results": [{"id": 1, "hourly_rate": "cheap"}]}`)
+	}))
+	defer server.Close()
+
+	err := NewClient(server.URL).ListTutors(context.Background(), func([]domain.Tutor) error { return nil })
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid decimal")
+}
+
+func TestListTutors_CallbackErrorStops(t *testing.T) {
+	requests := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprintf(w, `{"count": 2, "next": "%s/api/tutors/?page=2", "results": [{"id": 1}]}`, server.URL)
+	}))
+	defer server.Close()
+	errStop := errors.New("stop")
+
+	err := NewClient(server.URL).ListTutors(context.Background(), func([]domain.Tutor) error { return errStop })
+
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, 1, requests)
+}
+
+func TestGetTutor(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/tutors/7/", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"id": 7, "slug": "ada", "full_name": "Ada Lovelace", "hourly_rate": "42.50", "rating": "4.90"}`)
+	}))
+	defer server.Close()
+
+	tutor, err := NewClient(server.URL).GetTutor(context.Background(), 7)
+	require.NoError(t, err)
+	assert.Equal(t, &domain.Tutor{ID: 7, Slug: "ada", FullName: "Ada Lovelace", HourlyRate: 42.5, Rating: 4.9}, tutor)
+}
+
+func TestGetTutor_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"detail": "Not found."}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL).GetTutor(context.Background(), 7)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestCountTutors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/tutors/", r.URL.Path)
+		assert.Equal(t, "1", r.URL.Query().Get("page_size"))
+		fmt.Fprint(w, `{"count": 1234, "next": "http://backend/api/tutors/?page=2&page_size=1", "results": [{"id": 1}]}`)
+	}))
+	defer server.Close()
+
+	count, err := NewClient(server.URL).CountTutors(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1234), count)
+}
+
+func TestCountTutors_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "database unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := NewClient(server.URL).CountTutors(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "503")
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DefaultAvatarStripParams are the tracking parameters removed from avatar
+// URLs unless configured otherwise. A trailing * matches any suffix.
+var DefaultAvatarStripParams = []string{"utm_*", "fbclid", "gclid", "mc_cid", "mc_eid"}
+
+// AvatarPolicy normalizes avatar URLs before indexing, so the frontend only
+// ever receives absolute http or https URLs.
+type AvatarPolicy struct {
+	// CDNBase resolves protocol-relative URLs ("//cdn/a.jpg"), which take its
+	// scheme, and root-relative paths ("/media/a.jpg"). Without it they get
+	// https and are rejected respectively.
+	CDNBase string
+	// StripParams lists query parameters to remove; a trailing * matches
+	// any parameter starting with the rest.
+	StripParams []string
+}
+
+// Normalize returns raw as an absolute http or https URL without tracking
+// parameters or credentials, or an error if it cannot be made one. An empty
+// raw stays empty.
+func (p AvatarPolicy) Normalize(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", errors.New("not a valid URL")
+	}
+
+	switch {
+	case u.Scheme == "" && strings.HasPrefix(raw, "//"):
+		u.Scheme = "https"
+		if base, err := url.Parse(p.CDNBase); err == nil && base.Scheme != "" {
+			u.Scheme = base.Scheme
+		}
+	case u.Scheme == "" && strings.HasPrefix(raw, "/"):
+		if p.CDNBase == "" {
+			return "", errors.New("relative path without a CDN base")
+		}
+		base, err := url.Parse(p.CDNBase)
+		if err != nil {
+			return "", errors.New("invalid CDN base")
+		}
+		u = base.ResolveReference(u)
+	case u.Scheme == "":
+		return "", errors.New("relative URL")
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return "", fmt.Errorf("scheme must be http or https, got %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return "", errors.New("missing host")
+	}
+	u.User = nil
+	p.stripParams(u)
+	return u.String(), nil
+}
+
+func (p AvatarPolicy) stripParams(u *url.URL) {
+	if u.RawQuery == "" || len(p.StripParams) == 0 {
+		return
+	}
+	q := u.Query()
+	stripped := false
+	for name := range q {
+		if p.strips(name) {
+			q.Del(name)
+			stripped = true
+		}
+	}
+	// Re-encoding sorts the parameters, so leave untouched queries alone.
+	if stripped {
+		u.RawQuery = q.Encode()
+	}
+}
+
+func (p AvatarPolicy) strips(name string) bool {
+	name = strings.ToLower(name)
+	for _, rule := range p.StripParams {
+		rule = strings.ToLower(rule)
+		if prefix, ok := strings.CutSuffix(rule, "*"); ok {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+		} else if name == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeAvatar rewrites AvatarURL with p. An avatar that cannot be
+// normalized is blanked and the reason returned; the tutor is still
+// indexable.
+func (t *Tutor) NormalizeAvatar(p AvatarPolicy) error {
+	normalized, err := p.Normalize(t.AvatarURL)
+	if err != nil {
+		t.AvatarURL = ""
+		return err
+	}
+	t.AvatarURL = normalized
+	return nil
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// BadgeFeatured marks tutors marketing pins to the top of searches.
+const BadgeFeatured = "featured"
+
+// MaxBadgeLength bounds a badge name.
+const MaxBadgeLength = 32
+
+var badgePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ValidateBadge reports whether badge is a usable badge name: lowercase
+// letters, digits, '-' or '_', at most MaxBadgeLength long.
+func ValidateBadge(badge string) error {
+	if len(badge) > MaxBadgeLength {
+		return fmt.Errorf("badge %q is longer than %d characters", badge, MaxBadgeLength)
+	}
+	if !badgePattern.MatchString(badge) {
+		return fmt.Errorf("badge %q must be lowercase letters, digits, '-' or '_'", badge)
+	}
+	return nil
+}
+
+// ApplyBadges returns current with each of add appended unless already
+// present and each of remove taken out, comparing case-insensitively. A
+// badge in both lists is removed. current is not modified.
+func ApplyBadges(current, add, remove []string) []string {
+	removed := func(b string) bool {
+		return slices.ContainsFunc(remove, func(r string) bool { return strings.EqualFold(r, b) })
+	}
+	badges := make([]string, 0, len(current)+len(add))
+	for _, b := range slices.Concat(current, add) {
+		if removed(b) || slices.ContainsFunc(badges, func(have string) bool { return strings.EqualFold(have, b) }) {
+			continue
+		}
+		badges = append(badges, b)
+	}
+	return badges
+}
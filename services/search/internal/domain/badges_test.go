@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestValidateBadge(t *testing.T) {
+	for _, badge := range []string{"featured", "top-10", "staff_pick"} {
+		if err := ValidateBadge(badge); err != nil {
+			t.Errorf("expected %q to be valid, got %v", badge, err)
+		}
+	}
+	for _, badge := range []string{"", "Featured", "top pick", "-new", strings.Repeat("a", MaxBadgeLength+1)} {
+		if err := ValidateBadge(badge); err == nil {
+			t.Errorf("expected %q to be rejected", badge)
+		}
+	}
+}
+
+func TestApplyBadges(t *testing.T) {
+	tests := []struct {
+		name                 string
+		current, add, remove []string
+		want                 []string
+	}{
+		{"add to none", nil, []string{"featured"}, nil, []string{"featured"}},
+		{"add keeps order and skips present", []string{"new", "Featured"}, []string{"featured", "top"}, nil, []string{"new", "Featured", "top"}},
+		{"remove ignores case", []string{"new", "Featured"}, nil, []string{"featured"}, []string{"new"}},
+		{"remove wins over add", []string{"new"}, []string{"featured"}, []string{"featured"}, []string{"new"}},
+		{"remove missing is a no-op", []string{"new"}, nil, []string{"gone"}, []string{"new"}},
+		{"remove all", []string{"new"}, nil, []string{"new"}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := slices.Clone(tt.current)
+			got := ApplyBadges(current, tt.add, tt.remove)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+			if !slices.Equal(current, tt.current) {
+				t.Errorf("expected current to be left alone, got %q", current)
+			}
+		})
+	}
+}
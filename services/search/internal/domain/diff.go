@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"slices"
+)
+
+// ChangedFields returns the JSON names, sorted, of the fields writing
+// incoming over current as a partial update would change. Fields incoming
+// omits, such as empty Badges, are kept by such an update and so never
+// count; neither do indexed_at, which every write restamps, and
+// bio_snippet and popularity, which are derived from other fields. An
+// empty and a missing list are the same.
+func ChangedFields(current, incoming *Tutor) []string {
+	cur, inc := fieldsOf(current), fieldsOf(incoming)
+
+	var changed []string
+	for name, value := range inc {
+		if derivedField(name) || sameField(cur[name], value) {
+			continue
+		}
+		changed = append(changed, name)
+	}
+	slices.Sort(changed)
+	return changed
+}
+
+// ContentHash returns a short hash of the fields writing t as a partial
+// update would set, with the same exceptions as ChangedFields. Two tutors
+// with equal hashes write the same content.
+func ContentHash(t *Tutor) string {
+	fields := fieldsOf(t)
+	for name, value := range fields {
+		if derivedField(name) {
+			delete(fields, name)
+			continue
+		}
+		fields[name] = emptyAsNull(value)
+	}
+	// Maps encode with sorted keys, so equal fields hash the same.
+	raw, _ := json.Marshal(fields)
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:8])
+}
+
+// derivedField reports whether the field named name is set by the service
+// on every write rather than taken from the event.
+func derivedField(name string) bool {
+	return name == "indexed_at" || name == "bio_snippet" || name == "popularity"
+}
+
+// fieldsOf returns t's encoded fields by JSON name. A Tutor always
+// encodes, so errors cannot occur.
+func fieldsOf(t *Tutor) map[string]json.RawMessage {
+	raw, _ := json.Marshal(t)
+	var fields map[string]json.RawMessage
+	json.Unmarshal(raw, &fields)
+	return fields
+}
+
+func sameField(a, b json.RawMessage) bool {
+	return bytes.Equal(emptyAsNull(a), emptyAsNull(b))
+}
+
+func emptyAsNull(v json.RawMessage) json.RawMessage {
+	if v == nil || bytes.Equal(v, []byte("[]")) {
+		return json.RawMessage("null")
+	}
+	return v
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestChangedFields(t *testing.T) {
+	created := time.Date(2024, time.May, 1, 13, 0, 0, 0, time.UTC)
+	indexedAt := created.Add(time.Hour)
+	slot := created.Add(48 * time.Hour)
+	current := Tutor{
+		ID:              1,
+		FullName:        "Ada Lovelace",
+		Subjects:        []string{"math"},
+		HourlyRate:      40,
+		CreatedAt:       created,
+		IndexedAt:       &indexedAt,
+		NextAvailableAt: &slot,
+		Badges:          []string{BadgeFeatured},
+		BioSnippet:      "Teaches maths.",
+	}
+
+	tests := []struct {
+		name   string
+		update func(t *Tutor)
+		want   []string
+	}{
+		{"identical", func(t *Tutor) {}, nil},
+		{"scalar changed", func(t *Tutor) { t.HourlyRate = 45 }, []string{"hourly_rate"}},
+		{"several changed, sorted", func(t *Tutor) {
+			t.FullName = "Augusta Ada King"
+			t.Subjects = []string{"math", "physics"}
+			t.Bio = "Countess"
+		}, []string{"bio", "full_name", "subjects"}},
+		{"time changed", func(t *Tutor) { t.CreatedAt = created.Add(time.Millisecond) }, []string{"created_at"}},
+		{"indexed_at ignored", func(t *Tutor) { now := time.Now(); t.IndexedAt = &now }, nil},
+		{"bio_snippet ignored", func(t *Tutor) { t.BioSnippet = "" }, nil},
+		{"popularity ignored", func(t *Tutor) { t.Popularity = 9.5 }, nil},
+		{"omitted availability and badges kept", func(t *Tutor) {
+			t.NextAvailableAt = nil
+			t.Badges = nil
+		}, nil},
+		{"badges replaced", func(t *Tutor) { t.Badges = []string{"verified-pro"} }, []string{"badges"}},
+		{"empty and missing lists equal", func(t *Tutor) { t.Formats = []string{} }, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			incoming := current
+			incoming.Subjects = slices.Clone(current.Subjects)
+			tt.update(&incoming)
+
+			if got := ChangedFields(&current, &incoming); !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestContentHash(t *testing.T) {
+	created := time.Date(2024, time.May, 1, 13, 0, 0, 0, time.UTC)
+	base := Tutor{
+		ID:         1,
+		FullName:   "Ada Lovelace",
+		Subjects:   []string{"math"},
+		HourlyRate: 40,
+		CreatedAt:  created,
+	}
+
+	tests := []struct {
+		name   string
+		update func(t *Tutor)
+		same   bool
+	}{
+		{"identical", func(t *Tutor) {}, true},
+		{"indexed_at ignored", func(t *Tutor) { now := time.Now(); t.IndexedAt = &now }, true},
+		{"derived fields ignored", func(t *Tutor) { t.BioSnippet = "Teaches maths."; t.Popularity = 9.5 }, true},
+		{"empty and missing lists equal", func(t *Tutor) { t.Formats = []string{} }, true},
+		{"scalar changed", func(t *Tutor) { t.HourlyRate = 45 }, false},
+		{"list changed", func(t *Tutor) { t.Subjects = []string{"math", "physics"} }, false},
+		{"badges set", func(t *Tutor) { t.Badges = []string{BadgeFeatured} }, false},
+	}
+
+	want := ContentHash(&base)
+	if len(want) != 16 {
+		t.Fatalf("expected a 16 character hash, got %q", want)
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			incoming := base
+			incoming.Subjects = slices.Clone(base.Subjects)
+			tt.update(&incoming)
+
+			if got := ContentHash(&incoming); (got == want) != tt.same {
+				t.Errorf("expected same hash %v, got %q and %q", tt.same, want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MinutesPerDay bounds the minute-of-day values of a MinuteRange.
+const MinutesPerDay = 24 * 60
+
+// WorkingHours is a daily window on the 24-hour clock, "HH:MM", in the
+// tutor's Timezone. An End before Start runs past midnight, and End may be
+// "24:00".
+type WorkingHours struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// MinuteRange is the half-open range [Start, End) of minutes since
+// midnight, with 0 <= Start < End <= MinutesPerDay.
+type MinuteRange struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// Overlaps reports whether r and o share at least one minute.
+func (r MinuteRange) Overlaps(o MinuteRange) bool {
+	return r.Start < o.End && o.Start < r.End
+}
+
+// ParseClock returns the minutes since midnight of a "HH:MM" time, from
+// "00:00" to "24:00".
+func ParseClock(s string) (int, error) {
+	hh, mm, ok := strings.Cut(s, ":")
+	if !ok || len(hh) != 2 || len(mm) != 2 {
+		return 0, fmt.Errorf("must be HH:MM, got %q", s)
+	}
+	h, errH := strconv.Atoi(hh)
+	m, errM := strconv.Atoi(mm)
+	if errH != nil || errM != nil || h < 0 || m < 0 || m > 59 || h*60+m > MinutesPerDay {
+		return 0, fmt.Errorf("must be a time from 00:00 to 24:00, got %q", s)
+	}
+	return h*60 + m, nil
+}
+
+// ParseWindow parses a "HH:MM-HH:MM" window into its start and end
+// minutes; see Minutes.
+func ParseWindow(s string) (start, end int, err error) {
+	from, to, ok := strings.Cut(s, "-")
+	if !ok {
+		return 0, 0, fmt.Errorf("must be HH:MM-HH:MM, got %q", s)
+	}
+	return WorkingHours{Start: from, End: to}.Minutes()
+}
+
+// Minutes returns the window's start and end in minutes since midnight.
+// Start must be before 24:00 and differ from End; "00:00"-"24:00" is the
+// whole day.
+func (w WorkingHours) Minutes() (start, end int, err error) {
+	if start, err = ParseClock(w.Start); err != nil {
+		return 0, 0, err
+	}
+	if end, err = ParseClock(w.End); err != nil {
+		return 0, 0, err
+	}
+	if start == MinutesPerDay {
+		return 0, 0, fmt.Errorf("start must be before 24:00, got %q", w.Start)
+	}
+	if start == end {
+		return 0, 0, fmt.Errorf("start and end must differ, got %s-%s", w.Start, w.End)
+	}
+	return start, end, nil
+}
+
+// LoadTimezone returns the location of an IANA time zone name such as
+// "Europe/Berlin". Unlike time.LoadLocation it rejects "" and "Local",
+// which name no particular zone.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, fmt.Errorf("must be an IANA time zone like Europe/Berlin, got %q", name)
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("must be an IANA time zone like Europe/Berlin, got %q", name)
+	}
+	return loc, nil
+}
+
+// ShiftToUTC converts the local window [start, end) to minutes of the UTC
+// day, for a zone offsetSeconds east of UTC. Only minute-of-day arithmetic
+// is involved, so the result depends on the offset alone, never on a
+// date; the caller picks the offset in effect, which DST changes. A
+// window that wraps around UTC midnight comes back as two ranges.
+func ShiftToUTC(start, end, offsetSeconds int) []MinuteRange {
+	length := end - start
+	if length <= 0 {
+		length += MinutesPerDay
+	}
+	if length >= MinutesPerDay {
+		return []MinuteRange{{Start: 0, End: MinutesPerDay}}
+	}
+
+	from := mod(start-offsetSeconds/60, MinutesPerDay)
+	to := from + length
+	if to <= MinutesPerDay {
+		return []MinuteRange{{Start: from, End: to}}
+	}
+	return []MinuteRange{{Start: 0, End: to - MinutesPerDay}, {Start: from, End: MinutesPerDay}}
+}
+
+// WindowUTC converts the local window [start, end) in loc to UTC minute
+// ranges, using the offset loc has at now.
+func WindowUTC(start, end int, loc *time.Location, now time.Time) []MinuteRange {
+	_, offset := now.In(loc).Zone()
+	return ShiftToUTC(start, end, offset)
+}
+
+// AvailabilityUTC returns the tutor's WorkingHours as sorted, merged UTC
+// minute ranges, using the offset Timezone has at now. It returns nil
+// when the tutor has no working hours or no valid time zone.
+func (t *Tutor) AvailabilityUTC(now time.Time) []MinuteRange {
+	if len(t.WorkingHours) == 0 {
+		return nil
+	}
+	loc, err := LoadTimezone(t.Timezone)
+	if err != nil {
+		return nil
+	}
+	var ranges []MinuteRange
+	for _, wh := range t.WorkingHours {
+		start, end, err := wh.Minutes()
+		if err != nil {
+			continue
+		}
+		ranges = append(ranges, WindowUTC(start, end, loc, now)...)
+	}
+	return mergeRanges(ranges)
+}
+
+// mergeRanges sorts ranges and joins those that overlap or touch.
+func mergeRanges(ranges []MinuteRange) []MinuteRange {
+	slices.SortFunc(ranges, func(a, b MinuteRange) int { return a.Start - b.Start })
+	var merged []MinuteRange
+	for _, r := range ranges {
+		if n := len(merged); n > 0 && r.Start <= merged[n-1].End {
+			merged[n-1].End = max(merged[n-1].End, r.End)
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
+
+// validateHours reports the problems with the tutor's Timezone and
+// WorkingHours as field errors.
+func (t *Tutor) validateHours() []FieldError {
+	var violations []FieldError
+	if t.Timezone != "" {
+		if _, err := LoadTimezone(t.Timezone); err != nil {
+			violations = append(violations, FieldError{Field: "timezone", Code: CodeInvalidValue, Message: err.Error()})
+		}
+	}
+	for i, wh := range t.WorkingHours {
+		if _, _, err := wh.Minutes(); err != nil {
+			violations = append(violations, FieldError{Field: fmt.Sprintf("working_hours[%d]", i), Code: CodeInvalidFormat, Message: err.Error()})
+		}
+	}
+	if len(t.WorkingHours) > 0 && t.Timezone == "" {
+		violations = append(violations, FieldError{Field: "timezone", Code: CodeInconsistent, Message: "is required with working_hours"})
+	}
+	return violations
+}
+
+func mod(a, b int) int {
+	return (a%b + b) % b
+}
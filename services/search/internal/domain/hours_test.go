@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{"00:00", 0, false},
+		{"09:30", 570, false},
+		{"23:59", 1439, false},
+		{"24:00", 1440, false},
+		{"24:01", 0, true},
+		{"9:30", 0, true},
+		{"09:60", 0, true},
+		{"-1:00", 0, true},
+		{"0930", 0, true},
+		{"", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseClock(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseClock(%q) = %d, %v; want %d, error %v", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseWindow(t *testing.T) {
+	tests := []struct {
+		in        string
+		wantStart int
+		wantEnd   int
+		wantErr   bool
+	}{
+		{"18:00-21:00", 1080, 1260, false},
+		{"22:00-02:00", 1320, 120, false},
+		{"00:00-24:00", 0, 1440, false},
+		{"09:00-09:00", 0, 0, true},
+		{"24:00-01:00", 0, 0, true},
+		{"18:00", 0, 0, true},
+		{"18:00-25:00", 0, 0, true},
+	}
+	for _, tt := range tests {
+		start, end, err := ParseWindow(tt.in)
+		if (err != nil) != tt.wantErr || start != tt.wantStart || end != tt.wantEnd {
+			t.Errorf("ParseWindow(%q) = %d, %d, %v; want %d, %d, error %v", tt.in, start, end, err, tt.wantStart, tt.wantEnd, tt.wantErr)
+		}
+	}
+}
+
+func TestShiftToUTC(t *testing.T) {
+	const hour = 3600
+	tests := []struct {
+		name       string
+		start, end int
+		offset     int
+		want       []MinuteRange
+	}{
+		{"utc", 1080, 1260, 0, []MinuteRange{{1080, 1260}}},
+		{"east of utc", 1080, 1260, 1 * hour, []MinuteRange{{1020, 1200}}},
+		{"west of utc", 1200, 1380, -5 * hour, []MinuteRange{{60, 240}}},
+		{"half-hour offset", 540, 1020, 5*hour + 30*60, []MinuteRange{{210, 690}}},
+		{"negative half-hour offset", 0, 60, -(3*hour + 30*60), []MinuteRange{{210, 270}}},
+		{"wraps before utc midnight", 480, 600, 9 * hour, []MinuteRange{{0, 60}, {1380, 1440}}},
+		{"wraps after utc midnight", 1320, 1439, -1 * hour, []MinuteRange{{0, 59}, {1380, 1440}}},
+		{"shifted past utc midnight", 1320, 1439, -3 * hour, []MinuteRange{{60, 179}}},
+		{"overnight local window", 1320, 120, 0, []MinuteRange{{0, 120}, {1320, 1440}}},
+		{"overnight window shifted whole", 1320, 120, -3 * hour, []MinuteRange{{60, 300}}},
+		{"ends at local midnight", 1200, 1440, 2 * hour, []MinuteRange{{1080, 1320}}},
+		{"whole day", 0, 1440, 9 * hour, []MinuteRange{{0, 1440}}},
+		{"offset beyond a day is taken modulo", 60, 120, 14 * hour, []MinuteRange{{660, 720}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ShiftToUTC(tt.start, tt.end, tt.offset); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWindowUTC_UsesOffsetAtNow(t *testing.T) {
+	berlin, err := LoadTimezone("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("failed to load zone: %v", err)
+	}
+	winter := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+	summer := time.Date(2026, 7, 15, 12, 0, 0, 0, time.UTC)
+
+	if got, want := WindowUTC(1080, 1260, berlin, winter), []MinuteRange{{1020, 1200}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("winter: expected %v, got %v", want, got)
+	}
+	if got, want := WindowUTC(1080, 1260, berlin, summer), []MinuteRange{{960, 1140}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("summer: expected %v, got %v", want, got)
+	}
+}
+
+func TestTutor_AvailabilityUTC(t *testing.T) {
+	now := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		tutor Tutor
+		want  []MinuteRange
+	}{
+		{"no hours", Tutor{Timezone: "Europe/Berlin"}, nil},
+		{"no zone", Tutor{WorkingHours: []WorkingHours{{"09:00", "17:00"}}}, nil},
+		{"invalid zone", Tutor{Timezone: "Mars/Olympus", WorkingHours: []WorkingHours{{"09:00", "17:00"}}}, nil},
+		{"sorted and merged", Tutor{Timezone: "Asia/Tokyo", WorkingHours: []WorkingHours{
+			{"18:00", "21:00"}, {"08:00", "10:00"}, {"09:30", "12:00"},
+		}}, []MinuteRange{{0, 180}, {540, 720}, {1380, 1440}}},
+		{"touching windows join", Tutor{Timezone: "UTC", WorkingHours: []WorkingHours{
+			{"09:00", "12:00"}, {"12:00", "13:00"},
+		}}, []MinuteRange{{540, 780}}},
+		{"invalid window skipped", Tutor{Timezone: "UTC", WorkingHours: []WorkingHours{
+			{"09:00", "09:00"}, {"10:00", "11:00"},
+		}}, []MinuteRange{{600, 660}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tutor.AvailabilityUTC(now); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMinuteRange_Overlaps(t *testing.T) {
+	r := MinuteRange{600, 720}
+	for _, tt := range []struct {
+		other MinuteRange
+		want  bool
+	}{
+		{MinuteRange{700, 800}, true},
+		{MinuteRange{500, 601}, true},
+		{MinuteRange{0, 1440}, true},
+		{MinuteRange{720, 800}, false},
+		{MinuteRange{500, 600}, false},
+	} {
+		if got := r.Overlaps(tt.other); got != tt.want {
+			t.Errorf("%v overlaps %v: expected %v, got %v", r, tt.other, tt.want, got)
+		}
+	}
+}
+
+func TestTutor_Validate_Hours(t *testing.T) {
+	tests := []struct {
+		name      string
+		tutor     Tutor
+		wantField string
+		wantCode  string
+	}{
+		{"valid", Tutor{Timezone: "America/New_York", WorkingHours: []WorkingHours{{"09:00", "17:00"}}}, "", ""},
+		{"zone without hours", Tutor{Timezone: "Europe/Berlin"}, "", ""},
+		{"unknown zone", Tutor{Timezone: "Europe/Atlantis"}, "timezone", CodeInvalidValue},
+		{"local zone", Tutor{Timezone: "Local"}, "timezone", CodeInvalidValue},
+		{"bad window", Tutor{Timezone: "UTC", WorkingHours: []WorkingHours{{"9am", "5pm"}}}, "working_hours[0]", CodeInvalidFormat},
+		{"hours without zone", Tutor{WorkingHours: []WorkingHours{{"09:00", "17:00"}}}, "timezone", CodeInconsistent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tutor.Validate()
+			if tt.wantField == "" {
+				if err != nil {
+					t.Errorf("expected no error, got %v", err)
+				}
+				return
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) || len(verr.Violations) != 1 {
+				t.Fatalf("expected one violation, got %v", err)
+			}
+			if v := verr.Violations[0]; v.Field != tt.wantField || v.Code != tt.wantCode {
+				t.Errorf("expected %s %s, got %+v", tt.wantField, tt.wantCode, v)
+			}
+		})
+	}
+}
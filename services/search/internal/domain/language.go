@@ -0,0 +1,42 @@
+package domain
+
+import "unicode"
+
+// Languages DetectLanguage tells apart.
+const (
+	LanguageEnglish = "en"
+	LanguageRussian = "ru"
+	// LanguageMixed is text with a substantial share of both scripts.
+	LanguageMixed = "mixed"
+)
+
+// dominantScriptShare is the share of letters one script needs for text
+// to count as written in its language.
+const dominantScriptShare = 0.8
+
+// DetectLanguage guesses the language of text from its letters: mostly
+// Cyrillic is LanguageRussian, mostly Latin LanguageEnglish, and a
+// substantial share of both LanguageMixed. Digits, punctuation, emoji and
+// letters of other scripts are ignored; text without Cyrillic or Latin
+// letters returns "".
+func DetectLanguage(text string) string {
+	var cyrillic, latin int
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Cyrillic, r) && unicode.IsLetter(r):
+			cyrillic++
+		case unicode.Is(unicode.Latin, r) && unicode.IsLetter(r):
+			latin++
+		}
+	}
+	total := cyrillic + latin
+	switch {
+	case total == 0:
+		return ""
+	case float64(cyrillic) >= dominantScriptShare*float64(total):
+		return LanguageRussian
+	case float64(latin) >= dominantScriptShare*float64(total):
+		return LanguageEnglish
+	}
+	return LanguageMixed
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "testing"
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"pure Russian", "Репетитор по математике, готовлю к ЕГЭ и ОГЭ.", LanguageRussian},
+		{"pure English", "Math tutor preparing students for the SAT.", LanguageEnglish},
+		{"single English word", "math", LanguageEnglish},
+		{"Russian with a few English terms", "Преподаю Python и машинное обучение студентам вузов", LanguageRussian},
+		{"English with a Russian name", "Native English speaker with ten years of teaching experience, born in Новгород", LanguageEnglish},
+		{"mixed", "Учу английскому: grammar, speaking and IELTS preparation", LanguageMixed},
+		{"emoji only", "🎓📚✨ 👍", ""},
+		{"digits and punctuation", "+7 (999) 123-45-67!", ""},
+		{"empty", "", ""},
+		{"other script", "数学老师", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectLanguage(tt.text); got != tt.want {
+				t.Errorf("DetectLanguage(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+)
+
+// Teaching levels a tutor may list in Levels.
+const (
+	LevelSchool     = "school"
+	LevelUniversity = "university"
+	LevelAdult      = "adult"
+)
+
+// Levels lists every valid teaching level, youngest learners first.
+var Levels = []string{LevelSchool, LevelUniversity, LevelAdult}
+
+// IsLevel reports whether level is one of Levels.
+func IsLevel(level string) bool {
+	return slices.Contains(Levels, level)
+}
+
+// normalizeLevels lowercases levels in place so "School" indexes as
+// LevelSchool.
+func normalizeLevels(levels []string) {
+	for i, l := range levels {
+		levels[i] = strings.ToLower(l)
+	}
+}
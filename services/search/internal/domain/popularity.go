@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// PopularityWeights weights the terms of a tutor's popularity score.
+type PopularityWeights struct {
+	// Rating multiplies the 0-5 rating.
+	Rating float64
+	// Reviews multiplies log(reviews_count+1), so each further review
+	// counts for less.
+	Reviews float64
+	// Verified is added for verified tutors.
+	Verified float64
+	// Recency is added in full for a profile updated just now, halving
+	// every RecencyHalfLife since updated_at.
+	Recency         float64
+	RecencyHalfLife time.Duration
+}
+
+// DefaultPopularityWeights is used unless configured otherwise.
+var DefaultPopularityWeights = PopularityWeights{
+	Rating:          1,
+	Reviews:         0.5,
+	Verified:        0.5,
+	Recency:         1,
+	RecencyHalfLife: 90 * 24 * time.Hour,
+}
+
+// Validate reports the first weight the score cannot use.
+func (w PopularityWeights) Validate() error {
+	if w.Rating < 0 || w.Reviews < 0 || w.Verified < 0 || w.Recency < 0 {
+		return errors.New("weights must not be negative")
+	}
+	if w.RecencyHalfLife <= 0 {
+		return fmt.Errorf("recency half-life must be positive, got %s", w.RecencyHalfLife)
+	}
+	return nil
+}
+
+// Popularity scores t as of now:
+//
+//	rating*Rating + log(reviews_count+1)*Reviews + Verified if verified
+//	  + Recency * 0.5^(time since updated_at / RecencyHalfLife)
+//
+// A tutor without updated_at gets no recency term, and one updated in the
+// future the full term. The score is rounded to four decimal places.
+func (w PopularityWeights) Popularity(t *Tutor, now time.Time) float64 {
+	score := w.Rating*t.Rating + w.Reviews*math.Log1p(float64(max(t.ReviewsCount, 0)))
+	if t.IsVerified {
+		score += w.Verified
+	}
+	if !t.UpdatedAt.IsZero() && w.RecencyHalfLife > 0 {
+		age := max(now.Sub(t.UpdatedAt), 0)
+		score += w.Recency * math.Pow(0.5, float64(age)/float64(w.RecencyHalfLife))
+	}
+	return math.Round(score*10000) / 10000
+}
+
+// SetPopularity fills Popularity from w as of now.
+func (t *Tutor) SetPopularity(w PopularityWeights, now time.Time) {
+	t.Popularity = w.Popularity(t, now)
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPopularityWeights_Popularity(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	w := PopularityWeights{Rating: 1, Reviews: 0.5, Verified: 0.5, Recency: 1, RecencyHalfLife: 10 * 24 * time.Hour}
+
+	tests := []struct {
+		name  string
+		tutor Tutor
+		want  float64
+	}{
+		{"empty", Tutor{}, 0},
+		{"rating", Tutor{Rating: 4.5}, 4.5},
+		{"reviews are logarithmic", Tutor{ReviewsCount: 99}, 2.3026},
+		{"verified", Tutor{IsVerified: true}, 0.5},
+		{"updated now", Tutor{UpdatedAt: now}, 1},
+		{"updated one half-life ago", Tutor{UpdatedAt: now.Add(-10 * 24 * time.Hour)}, 0.5},
+		{"updated two half-lives ago", Tutor{UpdatedAt: now.Add(-20 * 24 * time.Hour)}, 0.25},
+		{"updated in the future", Tutor{UpdatedAt: now.Add(time.Hour)}, 1},
+		{"negative reviews count as none", Tutor{ReviewsCount: -3}, 0},
+		{"all terms", Tutor{Rating: 4, ReviewsCount: 9, IsVerified: true, UpdatedAt: now.Add(-10 * 24 * time.Hour)}, 6.1513},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := w.Popularity(&tt.tutor, now); got != tt.want {
+				t.Errorf("expected %g, got %g", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPopularityWeights_ZeroWeight(t *testing.T) {
+	now := time.Now()
+	tutor := Tutor{Rating: 5, ReviewsCount: 100, IsVerified: true, UpdatedAt: now}
+	w := DefaultPopularityWeights
+	w.Reviews = 0
+
+	if got, want := w.Popularity(&tutor, now), 5+0.5+1.0; got != want {
+		t.Errorf("expected %g, got %g", want, got)
+	}
+}
+
+func TestPopularityWeights_Validate(t *testing.T) {
+	if err := DefaultPopularityWeights.Validate(); err != nil {
+		t.Errorf("expected defaults to be valid, got %v", err)
+	}
+
+	negative := DefaultPopularityWeights
+	negative.Verified = -1
+	if err := negative.Validate(); err == nil {
+		t.Error("expected error for a negative weight")
+	}
+
+	noHalfLife := DefaultPopularityWeights
+	noHalfLife.RecencyHalfLife = 0
+	if err := noHalfLife.Validate(); err == nil {
+		t.Error("expected error for a zero half-life")
+	}
+}
+
+func TestTutor_SetPopularity(t *testing.T) {
+	tutor := Tutor{Rating: 4.2}
+	tutor.SetPopularity(DefaultPopularityWeights, time.Now())
+
+	if tutor.Popularity != 4.2 {
+		t.Errorf("expected popularity 4.2, got %g", tutor.Popularity)
+	}
+}
@@ -0,0 +1,40 @@
+package domain
+
+// Lesson formats listed in Tutor.Formats that can carry their own rate.
+const (
+	FormatOnline  = "online"
+	FormatOffline = "offline"
+)
+
+// RateFor returns what an hour of a lesson in format costs: the format's
+// own rate when the tutor has one, HourlyRate otherwise, including for
+// formats without a rate field.
+func (t *Tutor) RateFor(format string) float64 {
+	var rate float64
+	switch format {
+	case FormatOnline:
+		rate = t.OnlineRate
+	case FormatOffline:
+		rate = t.OfflineRate
+	}
+	if rate > 0 {
+		return rate
+	}
+	return t.HourlyRate
+}
+
+// NormalizeRates sets HourlyRate to the lowest of OnlineRate and
+// OfflineRate that is set, so clients and filters that only know
+// hourly_rate see the cheapest lesson. A tutor without per-format rates
+// keeps HourlyRate.
+func (t *Tutor) NormalizeRates() {
+	lowest := 0.0
+	for _, rate := range []float64{t.OnlineRate, t.OfflineRate} {
+		if rate > 0 && (lowest == 0 || rate < lowest) {
+			lowest = rate
+		}
+	}
+	if lowest > 0 {
+		t.HourlyRate = lowest
+	}
+}
@@ -0,0 +1,44 @@
+package domain
+
+import "testing"
+
+func TestTutor_NormalizeRates(t *testing.T) {
+	tests := []struct {
+		name                          string
+		hourly, online, offline, want float64
+	}{
+		{"no per-format rates keeps hourly rate", 40, 0, 0, 40},
+		{"lowest of both", 70, 45, 60, 45},
+		{"offline cheaper", 70, 50, 35, 35},
+		{"only offline set", 0, 0, 55, 55},
+		{"only online set overrides a higher hourly rate", 90, 30, 0, 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tutor := Tutor{HourlyRate: tt.hourly, OnlineRate: tt.online, OfflineRate: tt.offline}
+			tutor.NormalizeRates()
+			if tutor.HourlyRate != tt.want {
+				t.Errorf("expected hourly_rate %g, got %g", tt.want, tutor.HourlyRate)
+			}
+			if tutor.OnlineRate != tt.online || tutor.OfflineRate != tt.offline {
+				t.Errorf("per-format rates must be kept, got %g/%g", tutor.OnlineRate, tutor.OfflineRate)
+			}
+		})
+	}
+}
+
+func TestTutor_RateFor(t *testing.T) {
+	tutor := Tutor{HourlyRate: 40, OfflineRate: 60}
+
+	for format, want := range map[string]float64{
+		FormatOffline: 60,
+		FormatOnline:  40,
+		"group":       40,
+		"":            40,
+	} {
+		if got := tutor.RateFor(format); got != want {
+			t.Errorf("RateFor(%q): expected %g, got %g", format, want, got)
+		}
+	}
+}
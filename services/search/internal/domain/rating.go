@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+)
+
+// Rating consistency modes accepted in RATING_CONSISTENCY.
+const (
+	// RatingModeLenient zeroes the rating of a tutor without reviews.
+	RatingModeLenient = "lenient"
+	// RatingModeStrict rejects a tutor that has a rating but no reviews.
+	RatingModeStrict = "strict"
+)
+
+// NormalizeRating rounds Rating to two decimal places and makes sure a
+// tutor without reviews is unrated. In RatingModeStrict such a tutor is
+// left unchanged and a *ValidationError returned; in any other mode its
+// rating is set to 0 and zeroed reports that it was. Ratings outside 0-5
+// are left for Validate to reject.
+func (t *Tutor) NormalizeRating(mode string) (zeroed bool, err error) {
+	t.Rating = math.Round(t.Rating*100) / 100
+	if t.ReviewsCount != 0 || t.Rating <= 0 || t.Rating > 5 {
+		return false, nil
+	}
+	if mode == RatingModeStrict {
+		return false, &ValidationError{Violations: []FieldError{{
+			Field:   "rating",
+			Code:    CodeInconsistent,
+			Message: fmt.Sprintf("must be 0 when reviews_count is 0, got %g", t.Rating),
+		}}}
+	}
+	t.Rating = 0
+	return true, nil
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTutor_NormalizeRating(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       string
+		rating     float64
+		reviews    int
+		wantRating float64
+		wantZeroed bool
+		wantErr    bool
+	}{
+		{"consistent", RatingModeLenient, 4.8, 42, 4.8, false, false},
+		{"unrated without reviews", RatingModeStrict, 0, 0, 0, false, false},
+		{"rounds to two decimals", RatingModeLenient, 4.8765, 3, 4.88, false, false},
+		{"rounds half up", RatingModeLenient, 4.125, 3, 4.13, false, false},
+		{"lenient zeroes rating without reviews", RatingModeLenient, 4.8, 0, 0, true, false},
+		{"empty mode is lenient", "", 4.8, 0, 0, true, false},
+		{"strict rejects rating without reviews", RatingModeStrict, 4.8, 0, 4.8, false, true},
+		{"strict accepts consistent rating", RatingModeStrict, 4.8, 1, 4.8, false, false},
+		{"rounding to zero is consistent", RatingModeStrict, 0.004, 0, 0, false, false},
+		{"out of range is left for Validate", RatingModeLenient, 12.345, 7, 12.35, false, false},
+		{"out of range without reviews is not zeroed", RatingModeLenient, 12, 0, 12, false, false},
+		{"negative without reviews is not zeroed", RatingModeStrict, -1, 0, -1, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tutor := Tutor{ID: 1, Rating: tt.rating, ReviewsCount: tt.reviews}
+
+			zeroed, err := tutor.NormalizeRating(tt.mode)
+
+			if tutor.Rating != tt.wantRating {
+				t.Errorf("expected rating %g, got %g", tt.wantRating, tutor.Rating)
+			}
+			if zeroed != tt.wantZeroed {
+				t.Errorf("expected zeroed %v, got %v", tt.wantZeroed, zeroed)
+			}
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("expected *ValidationError, got %v", err)
+			}
+			if len(verr.Violations) != 1 || verr.Violations[0].Field != "rating" || verr.Violations[0].Code != CodeInconsistent {
+				t.Errorf("unexpected violations %+v", verr.Violations)
+			}
+		})
+	}
+}
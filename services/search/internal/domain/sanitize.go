@@ -0,0 +1,59 @@
+package domain
+
+import "strings"
+
+// DefaultMaxListItems caps Subjects, Formats, Levels, Certifications and
+// Badges unless configured otherwise.
+const DefaultMaxListItems = 50
+
+// Sanitize cleans Subjects, Formats, Levels, Certifications and Badges in
+// place: entries are trimmed, empty ones dropped, and case-insensitive
+// duplicates removed, keeping the first spelling and the original order.
+// Levels are then lowercased. A list still longer than maxItems is cut to
+// its first maxItems entries; the JSON names of cut fields are returned.
+// maxItems <= 0 leaves the length uncapped.
+func (t *Tutor) Sanitize(maxItems int) (truncated []string) {
+	var cut bool
+	if t.Subjects, cut = sanitizeList(t.Subjects, maxItems); cut {
+		truncated = append(truncated, "subjects")
+	}
+	if t.Formats, cut = sanitizeList(t.Formats, maxItems); cut {
+		truncated = append(truncated, "formats")
+	}
+	if t.Levels, cut = sanitizeList(t.Levels, maxItems); cut {
+		truncated = append(truncated, "levels")
+	}
+	normalizeLevels(t.Levels)
+	if t.Certifications, cut = sanitizeList(t.Certifications, maxItems); cut {
+		truncated = append(truncated, "certifications")
+	}
+	if t.Badges, cut = sanitizeList(t.Badges, maxItems); cut {
+		truncated = append(truncated, "badges")
+	}
+	return truncated
+}
+
+func sanitizeList(items []string, maxItems int) ([]string, bool) {
+	if items == nil {
+		return nil, false
+	}
+	size := len(items)
+	if maxItems > 0 {
+		size = min(size, maxItems)
+	}
+	clean := make([]string, 0, size)
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		key := strings.ToLower(item)
+		if item == "" || seen[key] {
+			continue
+		}
+		if maxItems > 0 && len(clean) == maxItems {
+			return clean, true
+		}
+		seen[key] = true
+		clean = append(clean, item)
+	}
+	return clean, false
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestTutor_Sanitize(t *testing.T) {
+	tests := []struct {
+		name          string
+		subjects      []string
+		maxItems      int
+		wantSubjects  []string
+		wantTruncated bool
+	}{
+		{"nil stays nil", nil, 50, nil, false},
+		{"clean list unchanged", []string{"math", "physics"}, 50, []string{"math", "physics"}, false},
+		{"trims whitespace", []string{"  math ", "\tphysics\n"}, 50, []string{"math", "physics"}, false},
+		{"drops empty entries", []string{"", "math", "   "}, 50, []string{"math"}, false},
+		{"dedupes case-insensitively keeping the first spelling", []string{"Math", "physics", "math", " MATH "}, 50, []string{"Math", "physics"}, false},
+		{"keeps first-seen order", []string{"physics", "chemistry", "Physics", "biology"}, 50, []string{"physics", "chemistry", "biology"}, false},
+		{"caps after dedupe", []string{"a", "A", "b", "c", "d"}, 3, []string{"a", "b", "c"}, true},
+		{"exactly at the cap", []string{"a", "b", "c", "c"}, 3, []string{"a", "b", "c"}, false},
+		{"no cap", []string{"a", "b", "c", "d"}, 0, []string{"a", "b", "c", "d"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tutor := Tutor{Subjects: tt.subjects}
+			truncated := tutor.Sanitize(tt.maxItems)
+
+			if !slices.Equal(tutor.Subjects, tt.wantSubjects) || (tutor.Subjects == nil) != (tt.wantSubjects == nil) {
+				t.Errorf("expected subjects %q, got %q", tt.wantSubjects, tutor.Subjects)
+			}
+			if got := slices.Contains(truncated, "subjects"); got != tt.wantTruncated {
+				t.Errorf("expected truncated %v, got %v", tt.wantTruncated, truncated)
+			}
+		})
+	}
+}
+
+func TestTutor_Sanitize_ThousandsOfDuplicates(t *testing.T) {
+	subjects := make([]string, 0, 4000)
+	for i := range 4000 {
+		subjects = append(subjects, fmt.Sprintf("Subject %d", i%3))
+	}
+	tutor := Tutor{
+		Subjects: subjects,
+		Formats:  []string{"online", "Online", "in-person"},
+	}
+
+	if truncated := tutor.Sanitize(DefaultMaxListItems); len(truncated) != 0 {
+		t.Errorf("expected no truncation after dedupe, got %v", truncated)
+	}
+	if want := []string{"Subject 0", "Subject 1", "Subject 2"}; !slices.Equal(tutor.Subjects, want) {
+		t.Errorf("expected subjects %q, got %q", want, tutor.Subjects)
+	}
+	if want := []string{"online", "in-person"}; !slices.Equal(tutor.Formats, want) {
+		t.Errorf("expected formats %q, got %q", want, tutor.Formats)
+	}
+}
+
+func TestTutor_Sanitize_ReportsEachTruncatedField(t *testing.T) {
+	tutor := Tutor{
+		Subjects: []string{"a", "b", "c"},
+		Formats:  []string{"online", "in-person", "group"},
+	}
+
+	truncated := tutor.Sanitize(2)
+
+	if !slices.Equal(truncated, []string{"subjects", "formats"}) {
+		t.Errorf("expected both fields truncated, got %v", truncated)
+	}
+	if len(tutor.Subjects) != 2 || len(tutor.Formats) != 2 {
+		t.Errorf("expected two items each, got %q and %q", tutor.Subjects, tutor.Formats)
+	}
+}
+
+func TestTutor_Sanitize_Certifications(t *testing.T) {
+	tutor := Tutor{Certifications: []string{" CELTA ", "celta", "", "DELTA", "TEFL"}}
+
+	truncated := tutor.Sanitize(2)
+
+	if want := []string{"CELTA", "DELTA"}; !slices.Equal(tutor.Certifications, want) {
+		t.Errorf("expected certifications %q, got %q", want, tutor.Certifications)
+	}
+	if !slices.Equal(truncated, []string{"certifications"}) {
+		t.Errorf("expected certifications to be reported as truncated, got %v", truncated)
+	}
+}
+
+func TestTutor_Sanitize_Levels(t *testing.T) {
+	tutor := Tutor{Levels: []string{" School", "school", "", "ADULT"}}
+
+	tutor.Sanitize(DefaultMaxListItems)
+
+	if want := []string{LevelSchool, LevelAdult}; !slices.Equal(tutor.Levels, want) {
+		t.Errorf("expected levels %q, got %q", want, tutor.Levels)
+	}
+
+	missing := Tutor{}
+	missing.Sanitize(DefaultMaxListItems)
+	if missing.Levels != nil {
+		t.Errorf("expected missing levels to stay nil, got %q", missing.Levels)
+	}
+	if err := missing.Validate(); err != nil {
+		t.Errorf("expected a tutor without levels to be valid, got %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// ScrubReplacement stands in for text a Scrubber removed.
+const ScrubReplacement = "[removed]"
+
+// Scrubber removes text that must not be searchable, such as contact
+// details, from free-form profile text.
+type Scrubber interface {
+	Scrub(text string) string
+}
+
+// defaultScrubPatterns match emails, URLs and phone numbers, in that order
+// so the digits of an email or URL are not taken for a phone number. Letters
+// and digits may be from any script.
+var defaultScrubPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`[\p{L}\p{N}._%+-]+@[\p{L}\p{N}-]+(?:\.[\p{L}\p{N}-]+)+`),
+	regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"]*[^\s<>".,;:!?)]`),
+	// Nine or more digits, optionally separated by spaces, dots, dashes or
+	// parentheses. Years and year ranges stay shorter.
+	regexp.MustCompile(`\+?\(?\p{Nd}(?:[\s().-]*\p{Nd}){8,}`),
+}
+
+// PatternScrubber replaces every match of its patterns with
+// ScrubReplacement.
+type PatternScrubber struct {
+	patterns []*regexp.Regexp
+}
+
+// NewPatternScrubber returns a PatternScrubber for emails, URLs and phone
+// numbers, plus extra, a regular expression for anything else to remove.
+// An empty extra adds nothing.
+func NewPatternScrubber(extra string) (*PatternScrubber, error) {
+	patterns := defaultScrubPatterns
+	if extra != "" {
+		re, err := regexp.Compile(extra)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern: %w", err)
+		}
+		patterns = append(patterns[:len(patterns):len(patterns)], re)
+	}
+	return &PatternScrubber{patterns: patterns}, nil
+}
+
+// Scrub returns text with every match replaced.
+func (s *PatternScrubber) Scrub(text string) string {
+	for _, re := range s.patterns {
+		text = re.ReplaceAllLiteralString(text, ScrubReplacement)
+	}
+	return text
+}
+
+// Scrub runs Bio and Headline through s and returns the JSON names of the
+// fields it changed. A nil s leaves them as they are.
+func (t *Tutor) Scrub(s Scrubber) (scrubbed []string) {
+	if s == nil {
+		return nil
+	}
+	if clean := s.Scrub(t.Headline); clean != t.Headline {
+		t.Headline = clean
+		scrubbed = append(scrubbed, "headline")
+	}
+	if clean := s.Scrub(t.Bio); clean != t.Bio {
+		t.Bio = clean
+		scrubbed = append(scrubbed, "bio")
+	}
+	return scrubbed
+}
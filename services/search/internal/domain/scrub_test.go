@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPatternScrubber_Defaults(t *testing.T) {
+	s, err := NewPatternScrubber("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"plain text unchanged", "Patient maths tutor, 10 years of experience", "Patient maths tutor, 10 years of experience"},
+		{"email", "Write to anna.smith+tutor@example.co.uk for lessons", "Write to [removed] for lessons"},
+		{"unicode email", "Почта: иван@пример.рф", "Почта: [removed]"},
+		{"url with scheme", "See https://example.com/about?ref=1.", "See [removed]."},
+		{"url with www", "Portfolio at WWW.example.org, updated weekly", "Portfolio at [removed], updated weekly"},
+		{"international phone", "Call +44 20 7946 0958 today", "Call [removed] today"},
+		{"us phone with parentheses", "Text (555) 123-4567", "Text [removed]"},
+		{"dotted phone", "555.123.4567", "[removed]"},
+		{"fullwidth digits", "電話 ０９０１２３４５６７８", "電話 [removed]"},
+		{"years are kept", "Taught at Oxford 2010-2015 and 2018", "Taught at Oxford 2010-2015 and 2018"},
+		{"short numbers are kept", "Grades 5-8, 45 min lessons", "Grades 5-8, 45 min lessons"},
+		{"several matches", "me@x.io or 0123 456 789", "[removed] or [removed]"},
+		{"accented text unchanged", "Professeur de français à Montréal", "Professeur de français à Montréal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.Scrub(tt.text); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPatternScrubber_ExtraPattern(t *testing.T) {
+	s, err := NewPatternScrubber(`(?i)\b(?:telegram|whatsapp)\b`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := s.Scrub("Ping me on WhatsApp or at a@b.com")
+	if want := "Ping me on [removed] or at [removed]"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+
+	if _, err := NewPatternScrubber(`(unclosed`); err == nil {
+		t.Error("expected an error for an invalid pattern")
+	}
+}
+
+func TestTutor_Scrub(t *testing.T) {
+	s, _ := NewPatternScrubber("")
+	tutor := Tutor{
+		Headline: "Maths tutor",
+		Bio:      "Email me: tutor@example.com",
+	}
+
+	scrubbed := tutor.Scrub(s)
+
+	if !slices.Equal(scrubbed, []string{"bio"}) {
+		t.Errorf("expected scrubbed [bio], got %q", scrubbed)
+	}
+	if tutor.Bio != "Email me: [removed]" {
+		t.Errorf("unexpected bio %q", tutor.Bio)
+	}
+	if tutor.Headline != "Maths tutor" {
+		t.Errorf("unexpected headline %q", tutor.Headline)
+	}
+
+	if scrubbed := tutor.Scrub(nil); scrubbed != nil {
+		t.Errorf("expected a nil scrubber to change nothing, got %q", scrubbed)
+	}
+}
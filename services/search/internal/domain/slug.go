@@ -0,0 +1,31 @@
+package domain
+
+import "slices"
+
+// MaxPreviousSlugs bounds a tutor's slug history. Beyond it the oldest
+// slugs are dropped and their links stop resolving.
+const MaxPreviousSlugs = 20
+
+// ValidSlug reports whether s is a non-empty slug of lowercase letters,
+// digits and hyphens.
+func ValidSlug(s string) bool {
+	return slugPattern.MatchString(s)
+}
+
+// PreviousSlugsAfter returns the slug history of a tutor whose slug changes
+// from current, which the event names old, to newSlug: previous with old
+// and current appended unless already present, without newSlug, and cut to
+// the newest MaxPreviousSlugs. Recording current as well keeps a slug
+// reachable when an event for it was missed. previous is not modified.
+func PreviousSlugsAfter(previous []string, current, old, newSlug string) []string {
+	history := slices.DeleteFunc(slices.Clone(previous), func(s string) bool { return s == newSlug })
+	for _, s := range []string{old, current} {
+		if s != "" && s != newSlug && !slices.Contains(history, s) {
+			history = append(history, s)
+		}
+	}
+	if len(history) > MaxPreviousSlugs {
+		history = history[len(history)-MaxPreviousSlugs:]
+	}
+	return history
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestPreviousSlugsAfter(t *testing.T) {
+	full := make([]string, MaxPreviousSlugs)
+	for i := range full {
+		full[i] = fmt.Sprintf("slug-%d", i)
+	}
+
+	tests := []struct {
+		name     string
+		previous []string
+		current  string
+		old      string
+		newSlug  string
+		want     []string
+	}{
+		{"first change", nil, "ada", "ada", "ada-lovelace", []string{"ada"}},
+		{"appends", []string{"ada"}, "ada-l", "ada-l", "ada-lovelace", []string{"ada", "ada-l"}},
+		{"already recorded", []string{"ada"}, "ada", "ada", "ada-lovelace", []string{"ada"}},
+		{"back to an old slug", []string{"ada", "ada-l"}, "ada-lovelace", "ada-lovelace", "ada", []string{"ada-l", "ada-lovelace"}},
+		{"current differs from old", nil, "ada-x", "ada", "ada-lovelace", []string{"ada", "ada-x"}},
+		{"already applied", nil, "ada-lovelace", "ada", "ada-lovelace", []string{"ada"}},
+		{"oldest dropped", full, "latest", "latest", "next", append(slices.Clone(full[1:]), "latest")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			previous := slices.Clone(tt.previous)
+			got := PreviousSlugsAfter(previous, tt.current, tt.old, tt.newSlug)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+			if !slices.Equal(previous, tt.previous) {
+				t.Errorf("expected previous to be left alone, got %v", previous)
+			}
+		})
+	}
+}
+
+func TestValidSlug(t *testing.T) {
+	for slug, want := range map[string]bool{"ada-lovelace-2": true, "": false, "Ada": false, "ada lovelace": false, "ada/x": false} {
+		if got := ValidSlug(slug); got != want {
+			t.Errorf("ValidSlug(%q) = %v, want %v", slug, got, want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// BioSnippetLength is the most characters a tutor's BioSnippet holds.
+const BioSnippetLength = 160
+
+// ellipsis marks a snippet cut inside a sentence.
+const ellipsis = "…"
+
+// Snippet shortens text to at most limit characters (runes) for display,
+// with its whitespace collapsed. Text that fits is returned whole.
+// Otherwise the snippet ends after the last full sentence that fits, if
+// that keeps at least half of limit, or else at the last word boundary
+// followed by an ellipsis. A single word longer than limit is cut mid-word.
+func Snippet(text string, limit int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	if limit <= 0 {
+		return ""
+	}
+	if len(runes) <= limit {
+		return text
+	}
+
+	// A sentence ends at terminal punctuation followed by a space, so the
+	// character just past the limit decides whether the last one fits.
+	for i := limit - 1; i >= limit/2; i-- {
+		if isSentenceEnd(runes[i]) && runes[i+1] == ' ' {
+			return string(runes[:i+1])
+		}
+	}
+
+	// Leave room for the ellipsis, ending at a space when there is one.
+	cut := runes[:limit-1]
+	if i := lastSpace(runes[:limit]); i > 0 {
+		cut = runes[:i]
+	}
+	return strings.TrimRightFunc(string(cut), isTrailingPunct) + ellipsis
+}
+
+func isSentenceEnd(r rune) bool {
+	switch r {
+	case '.', '!', '?', '…', '。', '！', '？':
+		return true
+	}
+	return false
+}
+
+// isTrailingPunct reports whether r reads badly before an ellipsis.
+func isTrailingPunct(r rune) bool {
+	return unicode.IsSpace(r) || strings.ContainsRune(",;:-–—", r)
+}
+
+// lastSpace returns the index of the last space in runes, or -1.
+func lastSpace(runes []rune) int {
+	for i := len(runes) - 1; i >= 0; i-- {
+		if runes[i] == ' ' {
+			return i
+		}
+	}
+	return -1
+}
+
+// SetBioSnippet fills BioSnippet from Bio.
+func (t *Tutor) SetBioSnippet() {
+	t.BioSnippet = Snippet(t.Bio, BioSnippetLength)
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSnippet(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		limit int
+		want  string
+	}{
+		{"shorter than limit", "Patient maths tutor.", 40, "Patient maths tutor."},
+		{"exactly the limit", "abcde", 5, "abcde"},
+		{"whitespace collapsed", "  Patient\n\nmaths   tutor. ", 40, "Patient maths tutor."},
+		{"empty", "", 40, ""},
+		{"zero limit", "Anything", 0, ""},
+		{"cut after last full sentence", "I teach maths. I love physics. Book a lesson today!", 35, "I teach maths. I love physics."},
+		{"sentence ending right at the limit", "One two three. Four", 14, "One two three."},
+		{"other terminal punctuation", "Ready to help? Lessons online and in person", 25, "Ready to help?"},
+		{"sentence too short falls back to words", "Hi. I have taught mathematics and physics for many years", 30, "Hi. I have taught mathematics…"},
+		{"no sentence boundary", "I have taught mathematics and physics for many years", 30, "I have taught mathematics and…"},
+		{"decimal point is not a sentence end", "Rated 4.9 by students who passed their exams", 20, "Rated 4.9 by…"},
+		{"trailing punctuation dropped before ellipsis", "Maths, physics, chemistry, biology", 18, "Maths, physics…"},
+		{"single long word cut mid-word", "Supercalifragilisticexpialidocious", 10, "Supercali…"},
+		{"multibyte words", "Преподаю математику и физику для школьников и студентов", 30, "Преподаю математику и физику…"},
+		{"multibyte sentence", "Преподаю математику. Готовлю к экзаменам и олимпиадам", 30, "Преподаю математику."},
+		{"emoji", "🎓📚✏️ tutoring in every subject you could need", 12, "🎓📚✏️…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Snippet(tt.text, tt.limit)
+			if got != tt.want {
+				t.Errorf("Snippet(%q, %d) = %q, want %q", tt.text, tt.limit, got, tt.want)
+			}
+			if n := utf8.RuneCountInString(got); n > tt.limit && tt.limit > 0 {
+				t.Errorf("snippet has %d characters, over the limit of %d", n, tt.limit)
+			}
+			if !utf8.ValidString(got) {
+				t.Errorf("snippet %q is not valid UTF-8", got)
+			}
+		})
+	}
+}
+
+func TestSetBioSnippet(t *testing.T) {
+	tutor := Tutor{Bio: strings.Repeat("word ", 100)}
+
+	tutor.SetBioSnippet()
+
+	if n := utf8.RuneCountInString(tutor.BioSnippet); n > BioSnippetLength || n < BioSnippetLength-10 {
+		t.Errorf("expected a snippet of about %d characters, got %d", BioSnippetLength, n)
+	}
+	if !strings.HasSuffix(tutor.BioSnippet, "word…") {
+		t.Errorf("expected the snippet to end on a whole word, got %q", tutor.BioSnippet)
+	}
+}
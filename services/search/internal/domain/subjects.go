@@ -0,0 +1,201 @@
+package domain
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+)
+
+//go:embed subjects.json
+var defaultSubjects []byte
+
+// Subject is a canonical subject: Key is what the index stores and filters
+// match, Label what the frontend shows. Aliases are further raw spellings
+// that map to it; the key and label always do.
+type Subject struct {
+	Key     string   `json:"key"`
+	Label   string   `json:"label"`
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// SubjectCatalog maps the free-form subjects tutors enter to canonical
+// keys and display labels. A nil catalog knows no subjects.
+type SubjectCatalog struct {
+	subjects map[string]Subject
+	// keys maps normalized spellings to subject keys.
+	keys map[string]string
+}
+
+type subjectFile struct {
+	Subjects []Subject `json:"subjects"`
+}
+
+// DefaultSubjectCatalog returns the catalog embedded in the binary.
+func DefaultSubjectCatalog() *SubjectCatalog {
+	c, err := parseSubjectCatalog(nil, defaultSubjects)
+	if err != nil {
+		panic(fmt.Sprintf("embedded subjects.json: %v", err))
+	}
+	return c
+}
+
+// LoadSubjectCatalog returns the default catalog with the subjects in the
+// JSON file at path added. An entry whose key is already known replaces
+// the default entry, aliases included.
+func LoadSubjectCatalog(path string) (*SubjectCatalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read subjects file: %w", err)
+	}
+	return parseSubjectCatalog(DefaultSubjectCatalog(), data)
+}
+
+// parseSubjectCatalog merges the subjects in data over base, which may be
+// nil.
+func parseSubjectCatalog(base *SubjectCatalog, data []byte) (*SubjectCatalog, error) {
+	var file subjectFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse subjects: %w", err)
+	}
+
+	merged := make(map[string]Subject)
+	var order []string
+	if base != nil {
+		for _, key := range base.order() {
+			merged[key] = base.subjects[key]
+			order = append(order, key)
+		}
+	}
+
+	seen := make(map[string]bool, len(file.Subjects))
+	for i, s := range file.Subjects {
+		if err := s.validate(); err != nil {
+			return nil, fmt.Errorf("subject %d: %w", i, err)
+		}
+		if seen[s.Key] {
+			return nil, fmt.Errorf("subject %d: duplicate key %q", i, s.Key)
+		}
+		seen[s.Key] = true
+		if _, ok := merged[s.Key]; !ok {
+			order = append(order, s.Key)
+		}
+		merged[s.Key] = s
+	}
+
+	c := &SubjectCatalog{
+		subjects: merged,
+		keys:     make(map[string]string),
+	}
+	// Later subjects win an alias claimed twice.
+	for _, key := range order {
+		s := merged[key]
+		for _, spelling := range append([]string{s.Key, s.Label}, s.Aliases...) {
+			if n := normalizeSubject(spelling); n != "" {
+				c.keys[n] = s.Key
+			}
+		}
+	}
+	return c, nil
+}
+
+func (s Subject) validate() error {
+	if s.Key == "" {
+		return errors.New("key is required")
+	}
+	if s.Key != normalizeSubject(s.Key) {
+		return fmt.Errorf("key %q must be lowercase without surrounding or repeated spaces", s.Key)
+	}
+	if strings.TrimSpace(s.Label) == "" {
+		return fmt.Errorf("label is required for %q", s.Key)
+	}
+	return nil
+}
+
+// order returns the catalog's keys sorted.
+func (c *SubjectCatalog) order() []string {
+	return slices.Sorted(maps.Keys(c.subjects))
+}
+
+// Canonical returns the key and label for raw. A spelling the catalog does
+// not know passes through: its key is raw lowercased and its label raw as
+// entered, both trimmed. A blank raw returns empty strings.
+func (c *SubjectCatalog) Canonical(raw string) (key, label string) {
+	n := normalizeSubject(raw)
+	if n == "" {
+		return "", ""
+	}
+	if c != nil {
+		if key, ok := c.keys[n]; ok {
+			return key, c.subjects[key].Label
+		}
+	}
+	return n, strings.Join(strings.Fields(raw), " ")
+}
+
+// Key returns the canonical key for raw, which is how search filters name
+// subjects.
+func (c *SubjectCatalog) Key(raw string) string {
+	key, _ := c.Canonical(raw)
+	return key
+}
+
+// Keys maps every entry of raw to its key, dropping blanks and repeats.
+// A nil or empty raw returns nil.
+func (c *SubjectCatalog) Keys(raw []string) []string {
+	var keys []string
+	seen := make(map[string]bool, len(raw))
+	for _, r := range raw {
+		key := c.Key(r)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Label returns the display label for a canonical key, or the key itself
+// when the catalog does not know it.
+func (c *SubjectCatalog) Label(key string) string {
+	if c != nil {
+		if s, ok := c.subjects[key]; ok {
+			return s.Label
+		}
+	}
+	return key
+}
+
+// CanonicalizeSubjects replaces Subjects with their canonical keys, without
+// repeats and in their original order, and sets SubjectsDisplay to the
+// matching labels.
+func (t *Tutor) CanonicalizeSubjects(c *SubjectCatalog) {
+	if t.Subjects == nil {
+		t.SubjectsDisplay = nil
+		return
+	}
+	keys := make([]string, 0, len(t.Subjects))
+	labels := make([]string, 0, len(t.Subjects))
+	seen := make(map[string]bool, len(t.Subjects))
+	for _, raw := range t.Subjects {
+		key, label := c.Canonical(raw)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+		labels = append(labels, label)
+	}
+	t.Subjects = keys
+	t.SubjectsDisplay = labels
+}
+
+// normalizeSubject lowercases s and collapses its whitespace.
+func normalizeSubject(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
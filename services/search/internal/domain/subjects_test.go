@@ -0,0 +1,146 @@
+package domain
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSubjectCatalog_Canonical(t *testing.T) {
+	c := DefaultSubjectCatalog()
+
+	tests := []struct {
+		raw       string
+		wantKey   string
+		wantLabel string
+	}{
+		{"math", "math", "Mathematics"},
+		{"Math", "math", "Mathematics"},
+		{" Mathematics ", "math", "Mathematics"},
+		{"MATHS", "math", "Mathematics"},
+		{"Computer  Science", "computer-science", "Computer Science"},
+		{"computer-science", "computer-science", "Computer Science"},
+		{"Calculus", "calculus", "Calculus"},
+		{"  Organic   Chemistry ", "organic chemistry", "Organic Chemistry"},
+		{"   ", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			key, label := c.Canonical(tt.raw)
+			if key != tt.wantKey || label != tt.wantLabel {
+				t.Errorf("Canonical(%q) = %q, %q, want %q, %q", tt.raw, key, label, tt.wantKey, tt.wantLabel)
+			}
+		})
+	}
+}
+
+func TestSubjectCatalog_Nil(t *testing.T) {
+	var c *SubjectCatalog
+	if key, label := c.Canonical(" Math "); key != "math" || label != "Math" {
+		t.Errorf("expected passthrough, got %q, %q", key, label)
+	}
+	if got := c.Label("math"); got != "math" {
+		t.Errorf("expected the key as label, got %q", got)
+	}
+}
+
+func TestSubjectCatalog_Keys(t *testing.T) {
+	got := DefaultSubjectCatalog().Keys([]string{"Maths", "", "physics", "math", "Calculus"})
+	if want := []string{"math", "physics", "calculus"}; !slices.Equal(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if got := DefaultSubjectCatalog().Keys(nil); got != nil {
+		t.Errorf("expected nil, got %q", got)
+	}
+}
+
+func TestSubjectCatalog_Label(t *testing.T) {
+	c := DefaultSubjectCatalog()
+	if got := c.Label("computer-science"); got != "Computer Science" {
+		t.Errorf("expected catalog label, got %q", got)
+	}
+	if got := c.Label("calculus"); got != "calculus" {
+		t.Errorf("expected unknown key as label, got %q", got)
+	}
+}
+
+func TestTutor_CanonicalizeSubjects(t *testing.T) {
+	tutor := Tutor{Subjects: []string{"Math", "Maths", "Physics", "Calculus", "calculus"}}
+	tutor.CanonicalizeSubjects(DefaultSubjectCatalog())
+
+	if want := []string{"math", "physics", "calculus"}; !slices.Equal(tutor.Subjects, want) {
+		t.Errorf("expected subjects %q, got %q", want, tutor.Subjects)
+	}
+	if want := []string{"Mathematics", "Physics", "Calculus"}; !slices.Equal(tutor.SubjectsDisplay, want) {
+		t.Errorf("expected labels %q, got %q", want, tutor.SubjectsDisplay)
+	}
+
+	empty := Tutor{SubjectsDisplay: []string{"stale"}}
+	empty.CanonicalizeSubjects(DefaultSubjectCatalog())
+	if empty.Subjects != nil || empty.SubjectsDisplay != nil {
+		t.Errorf("expected nil lists, got %q, %q", empty.Subjects, empty.SubjectsDisplay)
+	}
+}
+
+func TestLoadSubjectCatalog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "subjects.json")
+	data := `{"subjects": [
+		{"key": "math", "label": "Maths", "aliases": ["sums"]},
+		{"key": "calculus", "label": "Calculus", "aliases": ["analysis"]}
+	]}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := LoadSubjectCatalog(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		raw       string
+		wantKey   string
+		wantLabel string
+	}{
+		{"sums", "math", "Maths"},
+		{"Analysis", "calculus", "Calculus"},
+		{"physics", "physics", "Physics"},
+		// The file entry replaces the default one, aliases included.
+		{"mathematics", "mathematics", "mathematics"},
+	}
+	for _, tt := range tests {
+		if key, label := c.Canonical(tt.raw); key != tt.wantKey || label != tt.wantLabel {
+			t.Errorf("Canonical(%q) = %q, %q, want %q, %q", tt.raw, key, label, tt.wantKey, tt.wantLabel)
+		}
+	}
+}
+
+func TestLoadSubjectCatalog_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"malformed", `{"subjects": [`, "parse subjects"},
+		{"missing key", `{"subjects": [{"label": "Math"}]}`, "key is required"},
+		{"uppercase key", `{"subjects": [{"key": "Math", "label": "Math"}]}`, "must be lowercase"},
+		{"missing label", `{"subjects": [{"key": "math"}]}`, "label is required"},
+		{"duplicate key", `{"subjects": [{"key": "art", "label": "Art"}, {"key": "art", "label": "Arts"}]}`, "duplicate key"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "subjects.json")
+			if err := os.WriteFile(path, []byte(tt.data), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadSubjectCatalog(path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+// TimePrecision is the resolution of indexed timestamps, matching what
+// OpenSearch date fields store.
+const TimePrecision = time.Millisecond
+
+// Bounds outside which CreatedAt and UpdatedAt are taken to be bogus.
+var (
+	// MinProfileTime predates every tutor profile.
+	MinProfileTime = time.Date(2000, time.January, 1, 0, 0, 0, 0, time.UTC)
+	// MaxProfileClockSkew is how far ahead of now a profile time may be,
+	// allowing for clock drift between Django and this service.
+	MaxProfileClockSkew = 24 * time.Hour
+)
+
+// normalizeTime returns at in UTC truncated to TimePrecision, so equal
+// instants from any zone encode identically.
+func normalizeTime(at time.Time) time.Time {
+	return at.UTC().Truncate(TimePrecision)
+}
+
+// NormalizeTimes converts CreatedAt, UpdatedAt, NextAvailableAt and
+// VerifiedAt to UTC truncated to TimePrecision. A CreatedAt or UpdatedAt before
+// MinProfileTime or more than MaxProfileClockSkew after now is zeroed; the
+// JSON names of zeroed fields are returned. Zero times are left alone.
+func (t *Tutor) NormalizeTimes(now time.Time) (zeroed []string) {
+	latest := now.Add(MaxProfileClockSkew)
+	for _, f := range []struct {
+		name string
+		at   *time.Time
+	}{
+		{"created_at", &t.CreatedAt},
+		{"updated_at", &t.UpdatedAt},
+	} {
+		if f.at.IsZero() {
+			continue
+		}
+		if f.at.Before(MinProfileTime) || f.at.After(latest) {
+			*f.at = time.Time{}
+			zeroed = append(zeroed, f.name)
+			continue
+		}
+		*f.at = normalizeTime(*f.at)
+	}
+	// A free slot may be weeks ahead, so only its encoding is normalized.
+	if t.NextAvailableAt != nil {
+		at := normalizeTime(*t.NextAvailableAt)
+		t.NextAvailableAt = &at
+	}
+	if t.VerifiedAt != nil {
+		at := normalizeTime(*t.VerifiedAt)
+		t.VerifiedAt = &at
+	}
+	return zeroed
+}
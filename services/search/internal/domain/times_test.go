@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTutor_NormalizeTimes(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC)
+	berlin := time.FixedZone("CET", 60*60)
+	slot := time.Date(2026, time.June, 1, 9, 30, 0, 999_999_999, berlin)
+
+	tests := []struct {
+		name        string
+		createdAt   time.Time
+		wantCreated time.Time
+		wantZeroed  []string
+	}{
+		{
+			name:        "zone converted to UTC",
+			createdAt:   time.Date(2024, time.May, 1, 14, 0, 0, 0, berlin),
+			wantCreated: time.Date(2024, time.May, 1, 13, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "truncated to milliseconds",
+			createdAt:   time.Date(2024, time.May, 1, 13, 0, 0, 123_456_789, time.UTC),
+			wantCreated: time.Date(2024, time.May, 1, 13, 0, 0, 123_000_000, time.UTC),
+		},
+		{
+			name:        "zero time kept",
+			createdAt:   time.Time{},
+			wantCreated: time.Time{},
+		},
+		{
+			name:       "before 2000 zeroed",
+			createdAt:  time.Date(1999, time.December, 31, 23, 59, 59, 0, time.UTC),
+			wantZeroed: []string{"created_at"},
+		},
+		{
+			name:        "within a day ahead kept",
+			createdAt:   now.Add(23 * time.Hour),
+			wantCreated: now.Add(23 * time.Hour),
+		},
+		{
+			name:       "over a day ahead zeroed",
+			createdAt:  now.Add(25 * time.Hour),
+			wantZeroed: []string{"created_at"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tutor := Tutor{
+				CreatedAt:       tt.createdAt,
+				UpdatedAt:       time.Date(2025, time.January, 2, 3, 4, 5, 0, berlin),
+				NextAvailableAt: &slot,
+			}
+
+			zeroed := tutor.NormalizeTimes(now)
+
+			if !slices.Equal(zeroed, tt.wantZeroed) {
+				t.Errorf("expected zeroed %v, got %v", tt.wantZeroed, zeroed)
+			}
+			if tutor.CreatedAt != tt.wantCreated {
+				t.Errorf("expected created_at %v, got %v", tt.wantCreated, tutor.CreatedAt)
+			}
+			if want := time.Date(2025, time.January, 2, 2, 4, 5, 0, time.UTC); tutor.UpdatedAt != want {
+				t.Errorf("expected updated_at %v, got %v", want, tutor.UpdatedAt)
+			}
+			if want := time.Date(2026, time.June, 1, 8, 30, 0, 999_000_000, time.UTC); *tutor.NextAvailableAt != want {
+				t.Errorf("expected next_available_at %v, got %v", want, *tutor.NextAvailableAt)
+			}
+		})
+	}
+}
+
+func TestTutor_NormalizeTimes_FarFutureSlotKept(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC)
+	slot := now.AddDate(0, 2, 0)
+	tutor := Tutor{NextAvailableAt: &slot}
+
+	if zeroed := tutor.NormalizeTimes(now); zeroed != nil {
+		t.Errorf("expected nothing zeroed, got %v", zeroed)
+	}
+	if tutor.NextAvailableAt == nil || !tutor.NextAvailableAt.Equal(slot) {
+		t.Errorf("expected the slot to be kept, got %v", tutor.NextAvailableAt)
+	}
+}
+
+func TestTutor_NormalizeTimes_DeterministicEncoding(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 12, 0, 0, 0, time.UTC)
+	instant := time.Date(2024, time.May, 1, 13, 0, 0, 500_000_001, time.UTC)
+
+	var encoded []string
+	for _, zone := range []*time.Location{time.UTC, time.FixedZone("EST", -5*60*60), time.FixedZone("IST", 5*60*60+30*60)} {
+		tutor := Tutor{CreatedAt: instant.In(zone)}
+		tutor.NormalizeTimes(now)
+		raw, _ := json.Marshal(tutor.CreatedAt)
+		encoded = append(encoded, string(raw))
+	}
+
+	for _, got := range encoded {
+		if want := `"2024-05-01T13:00:00.5Z"`; got != want {
+			t.Errorf("expected %s, got %s", want, got)
+		}
+	}
+}
+
+func TestTutor_MarkIndexed(t *testing.T) {
+	tutor := Tutor{}
+	tutor.MarkIndexed(time.Date(2026, time.March, 10, 13, 0, 0, 987_654_321, time.FixedZone("CET", 60*60)))
+
+	if want := time.Date(2026, time.March, 10, 12, 0, 0, 987_000_000, time.UTC); tutor.IndexedAt == nil || *tutor.IndexedAt != want {
+		t.Errorf("expected indexed_at %v, got %v", want, tutor.IndexedAt)
+	}
+}
@@ -0,0 +1,168 @@
+package domain
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+//go:embed translations.json
+var defaultTranslations []byte
+
+// DefaultLanguage is the language labels fall back to.
+const DefaultLanguage = "en"
+
+// Translations holds the display label of each subject key per language.
+// A nil Translations knows no labels.
+type Translations struct {
+	// labels maps subject keys to labels keyed by language.
+	labels map[string]map[string]string
+}
+
+type translationFile struct {
+	Translations map[string]map[string]string `json:"translations"`
+}
+
+// DefaultTranslations returns the translations embedded in the binary.
+func DefaultTranslations() *Translations {
+	t, err := parseTranslations(nil, defaultTranslations)
+	if err != nil {
+		panic(fmt.Sprintf("embedded translations.json: %v", err))
+	}
+	return t
+}
+
+// LoadTranslations returns the default translations with those in the
+// JSON file at path merged over them, label by label.
+func LoadTranslations(path string) (*Translations, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read translations file: %w", err)
+	}
+	return parseTranslations(DefaultTranslations(), data)
+}
+
+// parseTranslations merges the labels in data over base, which may be nil.
+func parseTranslations(base *Translations, data []byte) (*Translations, error) {
+	var file translationFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parse translations: %w", err)
+	}
+
+	t := &Translations{labels: make(map[string]map[string]string)}
+	if base != nil {
+		for key, labels := range base.labels {
+			t.labels[key] = maps.Clone(labels)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(file.Translations)) {
+		if key != normalizeSubject(key) || key == "" {
+			return nil, fmt.Errorf("key %q must be a subject key", key)
+		}
+		if t.labels[key] == nil {
+			t.labels[key] = make(map[string]string)
+		}
+		for lang, label := range file.Translations[key] {
+			if lang != normalizeLanguage(lang) || lang == "" {
+				return nil, fmt.Errorf("%s: language %q must be a lowercase tag like en or pt-br", key, lang)
+			}
+			if strings.TrimSpace(label) == "" {
+				return nil, fmt.Errorf("%s: label is required for %q", key, lang)
+			}
+			t.labels[key][lang] = label
+		}
+	}
+	return t, nil
+}
+
+// Label returns the label of key in lang, falling back to its
+// DefaultLanguage label, and false when it has neither.
+func (t *Translations) Label(key, lang string) (string, bool) {
+	if t == nil {
+		return "", false
+	}
+	labels := t.labels[key]
+	if label, ok := labels[lang]; ok {
+		return label, true
+	}
+	label, ok := labels[DefaultLanguage]
+	return label, ok
+}
+
+// Languages lists every language some label is translated into, with
+// DefaultLanguage first and the rest sorted.
+func (t *Translations) Languages() []string {
+	seen := map[string]bool{DefaultLanguage: true}
+	if t != nil {
+		for _, labels := range t.labels {
+			for lang := range labels {
+				seen[lang] = true
+			}
+		}
+	}
+	delete(seen, DefaultLanguage)
+	return append([]string{DefaultLanguage}, slices.Sorted(maps.Keys(seen))...)
+}
+
+// NegotiateLanguage picks the entry of supported that an Accept-Language
+// header prefers. A range matches a language equal to it or to its
+// primary subtag, so ru-RU picks ru, and * picks the first of supported.
+// Ranges are tried by descending q-value, then in header order; q=0 and
+// malformed ranges are skipped. Without a match it returns
+// DefaultLanguage.
+func NegotiateLanguage(header string, supported []string) string {
+	type langRange struct {
+		tag string
+		q   float64
+	}
+	var ranges []langRange
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		r := langRange{tag: normalizeLanguage(tag), q: 1}
+		if r.tag == "" {
+			continue
+		}
+		if name, value, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(name) == "q" {
+			q, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || q < 0 || q > 1 {
+				continue
+			}
+			r.q = q
+		}
+		if r.q > 0 {
+			ranges = append(ranges, r)
+		}
+	}
+	slices.SortStableFunc(ranges, func(a, b langRange) int {
+		switch {
+		case a.q > b.q:
+			return -1
+		case a.q < b.q:
+			return 1
+		}
+		return 0
+	})
+
+	for _, r := range ranges {
+		if r.tag == "*" && len(supported) > 0 {
+			return supported[0]
+		}
+		primary, _, _ := strings.Cut(r.tag, "-")
+		for _, candidate := range []string{r.tag, primary} {
+			if slices.Contains(supported, candidate) {
+				return candidate
+			}
+		}
+	}
+	return DefaultLanguage
+}
+
+// normalizeLanguage lowercases a language tag and uses - between subtags.
+func normalizeLanguage(tag string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(tag)), "_", "-")
+}
@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNegotiateLanguage(t *testing.T) {
+	supported := []string{"en", "ru"}
+
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"", "en"},
+		{"ru", "ru"},
+		{"RU", "ru"},
+		{"ru-RU,ru;q=0.9,en-US;q=0.8,en;q=0.7", "ru"},
+		{"en-US,en;q=0.9,ru;q=0.8", "en"},
+		{"en;q=0.5, ru;q=0.8", "ru"},
+		{"de-DE,de;q=0.9,ru;q=0.3", "ru"},
+		{"de, fr", "en"},
+		{"ru;q=0, en;q=0.1", "en"},
+		{"ru;q=0", "en"},
+		{"ru;q=abc, en;q=0.2", "en"},
+		{"ru;q=1.5", "en"},
+		{"*", "en"},
+		{"de, *;q=0.5", "en"},
+		{"ru_RU", "ru"},
+		{"en;q=0.8, ru;q=0.8", "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			if got := NegotiateLanguage(tt.header, supported); got != tt.want {
+				t.Errorf("NegotiateLanguage(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranslations_Label(t *testing.T) {
+	tr := DefaultTranslations()
+
+	tests := []struct {
+		key, lang string
+		want      string
+		wantOK    bool
+	}{
+		{"math", "ru", "Математика", true},
+		{"math", "en", "Mathematics", true},
+		// A language without a label falls back to English.
+		{"math", "de", "Mathematics", true},
+		{"calculus", "ru", "", false},
+	}
+	for _, tt := range tests {
+		if got, ok := tr.Label(tt.key, tt.lang); got != tt.want || ok != tt.wantOK {
+			t.Errorf("Label(%q, %q) = %q, %v, want %q, %v", tt.key, tt.lang, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	var none *Translations
+	if _, ok := none.Label("math", "en"); ok {
+		t.Error("expected a nil Translations to know no labels")
+	}
+	if langs := none.Languages(); !slices.Equal(langs, []string{"en"}) {
+		t.Errorf("expected only the default language, got %v", langs)
+	}
+}
+
+func TestDefaultTranslations_MatchCatalog(t *testing.T) {
+	tr := DefaultTranslations()
+	catalog := DefaultSubjectCatalog()
+
+	for _, key := range catalog.order() {
+		if label, _ := tr.Label(key, DefaultLanguage); label != catalog.Label(key) {
+			t.Errorf("%s: English translation %q differs from catalog label %q", key, label, catalog.Label(key))
+		}
+		if _, ok := tr.labels[key]["ru"]; !ok {
+			t.Errorf("%s: missing Russian label", key)
+		}
+	}
+	if langs := tr.Languages(); !slices.Equal(langs, []string{"en", "ru"}) {
+		t.Errorf("expected languages [en ru], got %v", langs)
+	}
+}
+
+func TestLoadTranslations(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "translations.json")
+	data := `{"translations": {
+		"math": {"ru": "Алгебра и геометрия"},
+		"calculus": {"en": "Calculus", "de": "Analysis"}
+	}}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tr, err := LoadTranslations(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		key, lang string
+		want      string
+	}{
+		{"math", "ru", "Алгебра и геометрия"},
+		// Labels the file leaves out keep their defaults.
+		{"math", "en", "Mathematics"},
+		{"calculus", "de", "Analysis"},
+		{"calculus", "ru", "Calculus"},
+	}
+	for _, tt := range tests {
+		if got, _ := tr.Label(tt.key, tt.lang); got != tt.want {
+			t.Errorf("Label(%q, %q) = %q, want %q", tt.key, tt.lang, got, tt.want)
+		}
+	}
+	if langs := tr.Languages(); !slices.Equal(langs, []string{"en", "de", "ru"}) {
+		t.Errorf("expected languages [en de ru], got %v", langs)
+	}
+}
+
+func TestLoadTranslations_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"malformed", `{"translations": {`, "parse translations"},
+		{"key not canonical", `{"translations": {"Math": {"en": "Maths"}}}`, `key "Math" must be a subject key`},
+		{"language not lowercase", `{"translations": {"math": {"RU": "Математика"}}}`, `language "RU"`},
+		{"blank label", `{"translations": {"math": {"ru": " "}}}`, `label is required for "ru"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "translations.json")
+			if err := os.WriteFile(path, []byte(tt.data), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadTranslations(path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if _, err := LoadTranslations(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -2,6 +2,11 @@ package domain
 
 import "time"
 
+// DefaultMaxTutorID is the largest tutor ID accepted unless configured
+// otherwise: 2^53-1, the largest integer a JSON number survives a
+// round-trip through JavaScript with.
+const DefaultMaxTutorID = 1<<53 - 1
+
 type Tutor struct {
 	ID           int64     `json:"id"`
 	Slug         string    `json:"slug"`
@@ -16,6 +21,71 @@ type Tutor struct {
 	IsVerified   bool      `json:"is_verified"`
 	Location     string    `json:"location"`
 	Formats      []string  `json:"formats"`
+	Levels       []string  `json:"levels"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
+	// OffersTrial marks tutors who give a free trial lesson. It is also
+	// maintained from trial setting events. Documents indexed before it
+	// existed lack it, which reads as false.
+	OffersTrial bool `json:"offers_trial"`
+	// BioSnippet is the start of Bio for result lists, at most
+	// BioSnippetLength characters. It is generated when the tutor is
+	// indexed; see Snippet.
+	BioSnippet string `json:"bio_snippet"`
+	// Popularity ranks tutors for sort=popularity and boosts relevance
+	// searches. It is computed when the tutor is indexed; see
+	// PopularityWeights.
+	Popularity float64 `json:"popularity,omitempty"`
+	// IndexedAt is when this service last wrote the tutor to the index. It is
+	// set by the service, never taken from Django.
+	IndexedAt *time.Time `json:"indexed_at,omitempty"`
+	// NextAvailableAt is the earliest known free booking slot. It is
+	// maintained from booking events and survives profile updates.
+	NextAvailableAt *time.Time `json:"next_available_at,omitempty"`
+	// VerifiedAt is when the tutor was last verified. It is maintained
+	// from verification events, survives profile updates and is cleared
+	// when the tutor is unverified.
+	VerifiedAt *time.Time `json:"verified_at,omitempty"`
+	// SubjectsDisplay holds the display label of each entry of Subjects,
+	// which are canonical keys once indexed. See CanonicalizeSubjects. It
+	// is always sent so partial updates cannot keep stale labels.
+	SubjectsDisplay []string `json:"subjects_display"`
+	// Education and Certifications are searchable by name: "Oxford",
+	// "CELTA".
+	Education      []Education `json:"education"`
+	Certifications []string    `json:"certifications"`
+	// Badges mark tutors for promotional placement, such as BadgeFeatured.
+	// Omitted when empty, so profile updates that do not send them keep
+	// badges set through the admin API.
+	Badges []string `json:"badges,omitempty"`
+	// PreviousSlugs lists the slugs the tutor had before, oldest first,
+	// maintained from slug change events so old profile links can redirect.
+	// Omitted when empty, so profile updates keep it.
+	PreviousSlugs []string `json:"previous_slugs,omitempty"`
+	// Timezone is the IANA zone, such as "Europe/Berlin", WorkingHours are
+	// given in. Both are always sent, so a partial update cannot leave
+	// hours in a zone the tutor no longer uses.
+	Timezone     string         `json:"timezone"`
+	WorkingHours []WorkingHours `json:"working_hours"`
+	// OnlineRate and OfflineRate are the hourly rates of FormatOnline and
+	// FormatOffline lessons; zero means the format costs HourlyRate. Both
+	// are always sent, so an update cannot keep a rate the tutor dropped.
+	// See RateFor and NormalizeRates.
+	OnlineRate  float64 `json:"online_rate"`
+	OfflineRate float64 `json:"offline_rate"`
+}
+
+// Education is one entry of a tutor's education history.
+type Education struct {
+	Institution string `json:"institution"`
+	Degree      string `json:"degree"`
+	// Year is the graduation year; zero when unknown.
+	Year int `json:"year,omitempty"`
+}
+
+// MarkIndexed records now, normalized like NormalizeTimes, as the time the
+// tutor was written to the index.
+func (t *Tutor) MarkIndexed(now time.Time) {
+	at := normalizeTime(now)
+	t.IndexedAt = &at
 }
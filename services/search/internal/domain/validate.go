@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Length caps for free-text tutor fields, in characters.
+const (
+	MaxHeadlineLength = 200
+	MaxBioLength      = 5000
+)
+
+// Violation codes reported in FieldError.Code.
+const (
+	CodeOutOfRange    = "out_of_range"
+	CodeInvalidFormat = "invalid_format"
+	CodeTooLong       = "too_long"
+	CodeEmpty         = "empty"
+	CodeInconsistent  = "inconsistent"
+	CodeInvalidValue  = "invalid_value"
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9-]+$`)
+
+// FieldError describes a single invalid field.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ValidationError lists every field that failed validation.
+type ValidationError struct {
+	Violations []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		parts[i] = v.Field + ": " + v.Message
+	}
+	return "invalid tutor: " + strings.Join(parts, "; ")
+}
+
+// Validate checks the tutor against the index invariants and returns a
+// *ValidationError listing every violation, or nil when the tutor is valid.
+func (t *Tutor) Validate() error {
+	var violations []FieldError
+	add := func(field, code, format string, args ...any) {
+		violations = append(violations, FieldError{Field: field, Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if t.Rating < 0 || t.Rating > 5 {
+		add("rating", CodeOutOfRange, "must be between 0 and 5, got %g", t.Rating)
+	}
+	if t.HourlyRate < 0 {
+		add("hourly_rate", CodeOutOfRange, "must not be negative, got %g", t.HourlyRate)
+	}
+	if t.OnlineRate < 0 {
+		add("online_rate", CodeOutOfRange, "must not be negative, got %g", t.OnlineRate)
+	}
+	if t.OfflineRate < 0 {
+		add("offline_rate", CodeOutOfRange, "must not be negative, got %g", t.OfflineRate)
+	}
+	if t.ReviewsCount < 0 {
+		add("reviews_count", CodeOutOfRange, "must not be negative, got %d", t.ReviewsCount)
+	}
+	if t.Slug != "" && !slugPattern.MatchString(t.Slug) {
+		add("slug", CodeInvalidFormat, "must contain only lowercase letters, digits and hyphens")
+	}
+	if n := utf8.RuneCountInString(t.Headline); n > MaxHeadlineLength {
+		add("headline", CodeTooLong, "must be at most %d characters, got %d", MaxHeadlineLength, n)
+	}
+	if n := utf8.RuneCountInString(t.Bio); n > MaxBioLength {
+		add("bio", CodeTooLong, "must be at most %d characters, got %d", MaxBioLength, n)
+	}
+	for i, s := range t.Subjects {
+		if strings.TrimSpace(s) == "" {
+			add(fmt.Sprintf("subjects[%d]", i), CodeEmpty, "must not be empty")
+		}
+	}
+	for i, f := range t.Formats {
+		if strings.TrimSpace(f) == "" {
+			add(fmt.Sprintf("formats[%d]", i), CodeEmpty, "must not be empty")
+		}
+	}
+	for i, l := range t.Levels {
+		if !IsLevel(l) {
+			add(fmt.Sprintf("levels[%d]", i), CodeInvalidValue, "must be one of %s, got %q", strings.Join(Levels, "|"), l)
+		}
+	}
+	violations = append(violations, t.validateHours()...)
+
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func validTutor() Tutor {
+	return Tutor{
+		ID:           1,
+		Slug:         "ivan-petrov-2",
+		FullName:     "Ivan Petrov",
+		Headline:     "Math tutor",
+		Bio:          "Ten years of experience",
+		Subjects:     []string{"math"},
+		HourlyRate:   1500,
+		Rating:       4.8,
+		ReviewsCount: 42,
+		Formats:      []string{"online"},
+	}
+}
+
+func TestTutor_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		mutate    func(*Tutor)
+		wantField string
+		wantCode  string
+	}{
+		{"valid", func(*Tutor) {}, "", ""},
+		{"empty slug is allowed", func(tu *Tutor) { tu.Slug = "" }, "", ""},
+		{"rating boundaries", func(tu *Tutor) { tu.Rating = 5 }, "", ""},
+		{"rating above 5", func(tu *Tutor) { tu.Rating = 12 }, "rating", CodeOutOfRange},
+		{"negative rating", func(tu *Tutor) { tu.Rating = -0.1 }, "rating", CodeOutOfRange},
+		{"negative hourly rate", func(tu *Tutor) { tu.HourlyRate = -1 }, "hourly_rate", CodeOutOfRange},
+		{"negative online rate", func(tu *Tutor) { tu.OnlineRate = -1 }, "online_rate", CodeOutOfRange},
+		{"negative offline rate", func(tu *Tutor) { tu.OfflineRate = -5 }, "offline_rate", CodeOutOfRange},
+		{"negative reviews count", func(tu *Tutor) { tu.ReviewsCount = -3 }, "reviews_count", CodeOutOfRange},
+		{"uppercase slug", func(tu *Tutor) { tu.Slug = "Ivan-Petrov" }, "slug", CodeInvalidFormat},
+		{"slug with spaces", func(tu *Tutor) { tu.Slug = "ivan petrov" }, "slug", CodeInvalidFormat},
+		{"headline at cap", func(tu *Tutor) { tu.Headline = strings.Repeat("я", MaxHeadlineLength) }, "", ""},
+		{"headline too long", func(tu *Tutor) { tu.Headline = strings.Repeat("a", MaxHeadlineLength+1) }, "headline", CodeTooLong},
+		{"bio too long", func(tu *Tutor) { tu.Bio = strings.Repeat("a", MaxBioLength+1) }, "bio", CodeTooLong},
+		{"blank subject", func(tu *Tutor) { tu.Subjects = []string{"math", " "} }, "subjects[1]", CodeEmpty},
+		{"empty format", func(tu *Tutor) { tu.Formats = []string{""} }, "formats[0]", CodeEmpty},
+		{"known levels", func(tu *Tutor) { tu.Levels = []string{LevelSchool, LevelAdult} }, "", ""},
+		{"unknown level", func(tu *Tutor) { tu.Levels = []string{LevelSchool, "phd"} }, "levels[1]", CodeInvalidValue},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tutor := validTutor()
+			tt.mutate(&tutor)
+
+			err := tutor.Validate()
+
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("expected *ValidationError, got %v", err)
+			}
+			if len(verr.Violations) != 1 {
+				t.Fatalf("expected 1 violation, got %d: %v", len(verr.Violations), verr.Violations)
+			}
+			if got := verr.Violations[0]; got.Field != tt.wantField || got.Code != tt.wantCode {
+				t.Errorf("expected %s/%s, got %s/%s", tt.wantField, tt.wantCode, got.Field, got.Code)
+			}
+		})
+	}
+}
+
+func TestTutor_Validate_ReportsAllViolations(t *testing.T) {
+	tutor := validTutor()
+	tutor.Rating = 9
+	tutor.HourlyRate = -5
+	tutor.Slug = "Bad Slug"
+
+	var verr *ValidationError
+	if !errors.As(tutor.Validate(), &verr) {
+		t.Fatal("expected *ValidationError")
+	}
+	if len(verr.Violations) != 3 {
+		t.Errorf("expected 3 violations, got %d", len(verr.Violations))
+	}
+	if !strings.Contains(verr.Error(), "hourly_rate:") {
+		t.Errorf("expected error message to mention hourly_rate, got %q", verr.Error())
+	}
+}
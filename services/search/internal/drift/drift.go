@@ -0,0 +1,153 @@
+// Package drift compares how many tutors the index holds with how many
+// Django reports, to catch a consumer that silently drops events.
+package drift
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Counter counts tutors. *django.Client and port.SearchClient implement it.
+type Counter interface {
+	CountTutors(ctx context.Context) (int64, error)
+}
+
+// Result is one comparison of the two counts.
+type Result struct {
+	CheckedAt   time.Time `json:"checked_at"`
+	DjangoCount int64     `json:"django_count"`
+	IndexCount  int64     `json:"index_count"`
+	// Difference is IndexCount minus DjangoCount: negative when the index
+	// is missing tutors.
+	Difference int64 `json:"difference"`
+	// Drift is the absolute Difference relative to DjangoCount.
+	Drift     float64 `json:"drift"`
+	Threshold float64 `json:"threshold"`
+	Exceeded  bool    `json:"exceeded"`
+	// Error is set when either count could not be read; the counts are
+	// then zero.
+	Error string `json:"error,omitempty"`
+}
+
+// Checker compares the counts on demand or periodically and keeps the
+// latest result.
+type Checker struct {
+	django    Counter
+	index     Counter
+	threshold float64
+	logger    *slog.Logger
+	now       func() time.Time
+
+	mu     sync.Mutex
+	latest *Result
+}
+
+// Option configures a Checker.
+type Option func(*Checker)
+
+// WithClock replaces time.Now for result timestamps.
+func WithClock(now func() time.Time) Option {
+	return func(c *Checker) {
+		c.now = now
+	}
+}
+
+// New creates a checker that reports a drift above threshold, a fraction
+// of the Django count.
+func New(django, index Counter, threshold float64, logger *slog.Logger, opts ...Option) *Checker {
+	c := &Checker{
+		django:    django,
+		index:     index,
+		threshold: threshold,
+		logger:    logger,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Check reads both counts, records the result as the latest and logs a
+// warning when the drift exceeds the threshold.
+func (c *Checker) Check(ctx context.Context) Result {
+	res := c.compare(ctx)
+
+	c.mu.Lock()
+	c.latest = &res
+	c.mu.Unlock()
+
+	switch {
+	case res.Error != "":
+		c.logger.Warn("Failed to check index drift", "error", res.Error)
+	case res.Exceeded:
+		c.logger.Warn("Index document count drifted from Django",
+			"django_count", res.DjangoCount,
+			"index_count", res.IndexCount,
+			"difference", res.Difference,
+			"drift", res.Drift,
+			"threshold", res.Threshold,
+		)
+	default:
+		c.logger.Debug("Index document count matches Django",
+			"django_count", res.DjangoCount,
+			"index_count", res.IndexCount,
+		)
+	}
+	return res
+}
+
+func (c *Checker) compare(ctx context.Context) Result {
+	res := Result{CheckedAt: c.now().UTC(), Threshold: c.threshold}
+
+	djangoCount, err := c.django.CountTutors(ctx)
+	if err != nil {
+		res.Error = "django: " + err.Error()
+		return res
+	}
+	indexCount, err := c.index.CountTutors(ctx)
+	if err != nil {
+		res.Error = "index: " + err.Error()
+		return res
+	}
+
+	res.DjangoCount, res.IndexCount = djangoCount, indexCount
+	res.Difference = indexCount - djangoCount
+	diff := res.Difference
+	if diff < 0 {
+		diff = -diff
+	}
+	// An empty Django makes any indexed tutor a full drift.
+	res.Drift = float64(diff) / float64(max(djangoCount, 1))
+	res.Exceeded = res.Drift > c.threshold
+	return res
+}
+
+// Latest returns the most recent result, or nil before the first check.
+func (c *Checker) Latest() *Result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.latest == nil {
+		return nil
+	}
+	res := *c.latest
+	return &res
+}
+
+// Run checks immediately and then every interval until ctx ends.
+func (c *Checker) Run(ctx context.Context, interval time.Duration) {
+	c.Check(ctx)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.Check(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
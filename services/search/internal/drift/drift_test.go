@@ -0,0 +1,105 @@
+package drift
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/testutil"
+)
+
+type fakeCounter struct {
+	count int64
+	err   error
+}
+
+func (f fakeCounter) CountTutors(context.Context) (int64, error) {
+	return f.count, f.err
+}
+
+func newTestChecker(t *testing.T, django, index Counter, threshold float64) *Checker {
+	at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	return New(django, index, threshold, testutil.NewLogger(t),
+		WithClock(func() time.Time { return at }))
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		django     int64
+		index      int64
+		wantDiff   int64
+		wantDrift  float64
+		wantExceed bool
+	}{
+		{"in sync", 200, 200, 0, 0, false},
+		{"within threshold", 200, 199, -1, 0.005, false},
+		{"index missing tutors", 200, 180, -20, 0.1, true},
+		{"index has extra tutors", 100, 103, 3, 0.03, true},
+		{"empty Django", 0, 2, 2, 2, true},
+		{"both empty", 0, 0, 0, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := newTestChecker(t, fakeCounter{count: tt.django}, fakeCounter{count: tt.index}, 0.01)
+
+			res := checker.Check(context.Background())
+
+			assert.Empty(t, res.Error)
+			assert.Equal(t, tt.django, res.DjangoCount)
+			assert.Equal(t, tt.index, res.IndexCount)
+			assert.Equal(t, tt.wantDiff, res.Difference)
+			assert.InDelta(t, tt.wantDrift, res.Drift, 1e-9)
+			assert.Equal(t, tt.wantExceed, res.Exceeded)
+			assert.Equal(t, 0.01, res.Threshold)
+		})
+	}
+}
+
+func TestCheck_Errors(t *testing.T) {
+	down := errors.New("connection refused")
+
+	res := newTestChecker(t, fakeCounter{err: down}, fakeCounter{count: 5}, 0.01).Check(context.Background())
+	assert.Equal(t, "django: connection refused", res.Error)
+	assert.False(t, res.Exceeded)
+	assert.Zero(t, res.IndexCount, "counts are left empty on error")
+
+	res = newTestChecker(t, fakeCounter{count: 5}, fakeCounter{err: down}, 0.01).Check(context.Background())
+	assert.Equal(t, "index: connection refused", res.Error)
+	assert.Zero(t, res.DjangoCount)
+}
+
+func TestLatest(t *testing.T) {
+	checker := newTestChecker(t, fakeCounter{count: 10}, fakeCounter{count: 8}, 0.05)
+	assert.Nil(t, checker.Latest(), "no result before the first check")
+
+	checker.Check(context.Background())
+
+	latest := checker.Latest()
+	require.NotNil(t, latest)
+	assert.Equal(t, int64(-2), latest.Difference)
+	assert.True(t, latest.Exceeded)
+	assert.Equal(t, time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC), latest.CheckedAt)
+
+	latest.IndexCount = 99
+	assert.Equal(t, int64(8), checker.Latest().IndexCount, "Latest returns a copy")
+}
+
+func TestRun_ChecksImmediately(t *testing.T) {
+	checker := newTestChecker(t, fakeCounter{count: 3}, fakeCounter{count: 3}, 0.01)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		checker.Run(ctx, time.Hour)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool { return checker.Latest() != nil }, time.Second, 5*time.Millisecond)
+	cancel()
+	<-done
+}
@@ -0,0 +1,146 @@
+// Package experiment assigns searches to the variants of a relevance A/B
+// test.
+package experiment
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"regexp"
+
+	"search/internal/opensearch"
+)
+
+// file is the JSON layout of an experiment definition:
+//
+//	{
+//	  "name": "headline-boost",
+//	  "variants": [
+//	    {"name": "control", "weight": 50},
+//	    {"name": "boosted", "weight": 50, "relevance": {"headline_boost": 4}}
+//	  ]
+//	}
+type file struct {
+	Name     string    `json:"name"`
+	Variants []variant `json:"variants"`
+}
+
+type variant struct {
+	Name   string `json:"name"`
+	Weight int    `json:"weight"`
+	// Relevance overrides fields of opensearch.DefaultRelevance.
+	Relevance json.RawMessage `json:"relevance,omitempty"`
+}
+
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Experiment splits traffic between weighted variants, each with its own
+// relevance config. It is immutable after construction.
+type Experiment struct {
+	name      string
+	variants  []variant
+	total     int
+	relevance opensearch.RelevanceRegistry
+}
+
+// Load reads an experiment definition from the JSON file at path.
+func Load(path string) (*Experiment, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse reads an experiment definition from JSON. Unknown fields are
+// rejected so a misspelt boost cannot silently fall back to the default.
+func Parse(data []byte) (*Experiment, error) {
+	var f file
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&f); err != nil {
+		return nil, fmt.Errorf("invalid experiment JSON: %w", err)
+	}
+
+	if !namePattern.MatchString(f.Name) {
+		return nil, fmt.Errorf("experiment name %q must be lowercase letters, digits, '-' or '_'", f.Name)
+	}
+	if len(f.Variants) == 0 {
+		return nil, errors.New("experiment has no variants")
+	}
+
+	e := &Experiment{
+		name:      f.Name,
+		variants:  f.Variants,
+		relevance: make(opensearch.RelevanceRegistry, len(f.Variants)),
+	}
+	for _, v := range f.Variants {
+		if !namePattern.MatchString(v.Name) {
+			return nil, fmt.Errorf("variant name %q must be lowercase letters, digits, '-' or '_'", v.Name)
+		}
+		if _, dup := e.relevance[v.Name]; dup {
+			return nil, fmt.Errorf("variant %q listed twice", v.Name)
+		}
+		if v.Weight < 0 {
+			return nil, fmt.Errorf("variant %q: weight must not be negative, got %d", v.Name, v.Weight)
+		}
+
+		rc := opensearch.DefaultRelevance
+		if len(v.Relevance) > 0 {
+			dec := json.NewDecoder(bytes.NewReader(v.Relevance))
+			dec.DisallowUnknownFields()
+			if err := dec.Decode(&rc); err != nil {
+				return nil, fmt.Errorf("variant %q: invalid relevance: %w", v.Name, err)
+			}
+		}
+		if err := rc.Validate(); err != nil {
+			return nil, fmt.Errorf("variant %q: %w", v.Name, err)
+		}
+		e.relevance[v.Name] = rc
+		e.total += v.Weight
+	}
+	if e.total == 0 {
+		return nil, errors.New("variant weights must not all be zero")
+	}
+	return e, nil
+}
+
+// Name returns the experiment name.
+func (e *Experiment) Name() string {
+	return e.name
+}
+
+// Has reports whether variant belongs to the experiment.
+func (e *Experiment) Has(variant string) bool {
+	_, ok := e.relevance[variant]
+	return ok
+}
+
+// Assign deterministically picks a variant for clientID in proportion to
+// the variant weights. The experiment name salts the hash, so a new
+// experiment reshuffles clients.
+func (e *Experiment) Assign(clientID string) string {
+	h := fnv.New32a()
+	h.Write([]byte(e.name))
+	h.Write([]byte{0})
+	h.Write([]byte(clientID))
+	bucket := int(h.Sum32() % uint32(e.total))
+
+	for _, v := range e.variants {
+		if bucket < v.Weight {
+			return v.Name
+		}
+		bucket -= v.Weight
+	}
+	// Unreachable: the weights sum to total.
+	return e.variants[len(e.variants)-1].Name
+}
+
+// Relevance returns each variant's relevance config, for
+// opensearch.WithRelevance.
+func (e *Experiment) Relevance() opensearch.RelevanceRegistry {
+	return e.relevance
+}
@@ -0,0 +1,109 @@
+package experiment
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/opensearch"
+)
+
+const testExperiment = `{
+  "name": "headline-boost",
+  "variants": [
+    {"name": "control", "weight": 50},
+    {"name": "boosted", "weight": 50, "relevance": {"headline_boost": 4, "fuzziness": "1"}},
+    {"name": "paused", "weight": 0, "relevance": {"bio_boost": 3}}
+  ]
+}`
+
+func TestParse_RelevanceOverridesOnlyItsVariant(t *testing.T) {
+	e, err := Parse([]byte(testExperiment))
+	require.NoError(t, err)
+
+	assert.Equal(t, "headline-boost", e.Name())
+	r := e.Relevance()
+	assert.Equal(t, opensearch.DefaultRelevance, r.For("control"))
+	assert.Equal(t, opensearch.DefaultRelevance, r.For(""))
+
+	boosted := opensearch.DefaultRelevance
+	boosted.HeadlineBoost = 4
+	boosted.Fuzziness = "1"
+	assert.Equal(t, boosted, r.For("boosted"))
+
+	paused := opensearch.DefaultRelevance
+	paused.BioBoost = 3
+	assert.Equal(t, paused, r.For("paused"))
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := map[string]string{
+		"malformed":         `{`,
+		"unknown field":     `{"name": "x", "variants": [{"name": "a", "weight": 1}], "extra": 1}`,
+		"bad name":          `{"name": "X Y", "variants": [{"name": "a", "weight": 1}]}`,
+		"no variants":       `{"name": "x", "variants": []}`,
+		"duplicate variant": `{"name": "x", "variants": [{"name": "a", "weight": 1}, {"name": "a", "weight": 1}]}`,
+		"negative weight":   `{"name": "x", "variants": [{"name": "a", "weight": -1}]}`,
+		"all zero weights":  `{"name": "x", "variants": [{"name": "a", "weight": 0}]}`,
+		"misspelt boost":    `{"name": "x", "variants": [{"name": "a", "weight": 1, "relevance": {"headline_bost": 2}}]}`,
+		"zero boost":        `{"name": "x", "variants": [{"name": "a", "weight": 1, "relevance": {"bio_boost": 0}}]}`,
+		"bad fuzziness":     `{"name": "x", "variants": [{"name": "a", "weight": 1, "relevance": {"fuzziness": "3"}}]}`,
+	}
+	for name, data := range tests {
+		_, err := Parse([]byte(data))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestAssign_IsDeterministic(t *testing.T) {
+	e, err := Parse([]byte(testExperiment))
+	require.NoError(t, err)
+	again, err := Parse([]byte(testExperiment))
+	require.NoError(t, err)
+
+	counts := map[string]int{}
+	for i := range 2000 {
+		id := fmt.Sprintf("client-%d", i)
+		v := e.Assign(id)
+		assert.Equal(t, v, e.Assign(id), "same client, same variant")
+		assert.Equal(t, v, again.Assign(id), "same client, same variant after a restart")
+		counts[v]++
+	}
+
+	assert.Zero(t, counts["paused"], "zero-weight variants get no traffic")
+	assert.InDelta(t, 1000, counts["control"], 150)
+	assert.InDelta(t, 1000, counts["boosted"], 150)
+}
+
+func TestAssign_NameSaltsTheHash(t *testing.T) {
+	a, err := Parse([]byte(`{"name": "a", "variants": [{"name": "x", "weight": 1}, {"name": "y", "weight": 1}]}`))
+	require.NoError(t, err)
+	b, err := Parse([]byte(`{"name": "b", "variants": [{"name": "x", "weight": 1}, {"name": "y", "weight": 1}]}`))
+	require.NoError(t, err)
+
+	differ := 0
+	for i := range 100 {
+		id := fmt.Sprintf("client-%d", i)
+		if a.Assign(id) != b.Assign(id) {
+			differ++
+		}
+	}
+	assert.Positive(t, differ)
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "experiment.json")
+	require.NoError(t, os.WriteFile(path, []byte(testExperiment), 0o600))
+
+	e, err := Load(path)
+	require.NoError(t, err)
+	assert.True(t, e.Has("boosted"))
+	assert.False(t, e.Has("unknown"))
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
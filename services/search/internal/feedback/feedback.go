@@ -0,0 +1,166 @@
+// Package feedback collects what users did with search results, so
+// ranking can later learn from click-through.
+package feedback
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Actions a user can take on a search result.
+const (
+	ActionImpression = "impression"
+	ActionClick      = "click"
+	ActionContact    = "contact"
+)
+
+// queryHashPattern matches port.SearchQuery.Hash values, with room for a
+// longer hash later.
+var queryHashPattern = regexp.MustCompile(`^[0-9a-f]{8,64}$`)
+
+// Event is one action on one search result.
+type Event struct {
+	// QueryHash is the X-Query-Hash of the search that showed the tutor.
+	QueryHash string `json:"query_hash"`
+	TutorID   int64  `json:"tutor_id"`
+	Action    string `json:"action"`
+}
+
+// Validate reports the first field a consumer of the feedback stream could
+// not use. Tutor IDs above maxTutorID are rejected.
+func (e Event) Validate(maxTutorID int64) error {
+	if !queryHashPattern.MatchString(e.QueryHash) {
+		return errors.New("query_hash must be 8 to 64 lowercase hex digits")
+	}
+	if e.TutorID < 1 || e.TutorID > maxTutorID {
+		return fmt.Errorf("tutor_id must be between 1 and %d", maxTutorID)
+	}
+	switch e.Action {
+	case ActionImpression, ActionClick, ActionContact:
+	default:
+		return fmt.Errorf("action must be %s, %s or %s, got %q", ActionImpression, ActionClick, ActionContact, e.Action)
+	}
+	return nil
+}
+
+// Batch is the events of one request with where and when they came from.
+type Batch struct {
+	ClientID   string
+	Tenant     string
+	ReceivedAt time.Time
+	Events     []Event
+}
+
+// record is the shape of each event downstream: the event with its
+// batch's context flattened in.
+type record struct {
+	Event
+	ClientID   string    `json:"client_id,omitempty"`
+	Tenant     string    `json:"tenant,omitempty"`
+	ReceivedAt time.Time `json:"received_at"`
+}
+
+func (b Batch) records() []record {
+	out := make([]record, len(b.Events))
+	for i, e := range b.Events {
+		out[i] = record{Event: e, ClientID: b.ClientID, Tenant: b.Tenant, ReceivedAt: b.ReceivedAt}
+	}
+	return out
+}
+
+// Sink receives accepted batches. Record runs on the request path, so it
+// must hand the batch off without waiting on I/O.
+type Sink interface {
+	Record(b Batch)
+}
+
+// LogSink writes each event as an Info log line, for collection by the log
+// pipeline.
+type LogSink struct {
+	logger *slog.Logger
+}
+
+// NewLogSink logs feedback to logger.
+func NewLogSink(logger *slog.Logger) *LogSink {
+	return &LogSink{logger: logger}
+}
+
+// Record logs every event of b.
+func (s *LogSink) Record(b Batch) {
+	for _, r := range b.records() {
+		s.logger.Info("Search feedback",
+			"query_hash", r.QueryHash,
+			"tutor_id", r.TutorID,
+			"action", r.Action,
+			"client_id", r.ClientID,
+			"tenant", r.Tenant,
+			"received_at", r.ReceivedAt,
+		)
+	}
+}
+
+// KafkaSink publishes each event as a JSON message keyed by tutor ID, so
+// a tutor's feedback stays in order on one partition.
+type KafkaSink struct {
+	writer *kafka.Writer
+	logger *slog.Logger
+}
+
+// NewKafkaSink publishes to topic on brokers. Writes are asynchronous:
+// failures are logged and the events dropped, never retried on the request
+// path.
+func NewKafkaSink(brokers []string, topic string, logger *slog.Logger) *KafkaSink {
+	s := &KafkaSink{logger: logger}
+	s.writer = &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		Async:        true,
+		BatchTimeout: 100 * time.Millisecond,
+		Completion:   s.completed,
+	}
+	return s
+}
+
+// Record queues b's events for publishing.
+func (s *KafkaSink) Record(b Batch) {
+	msgs, err := messages(b)
+	if err != nil {
+		s.logger.Error("Failed to encode search feedback", "error", err)
+		return
+	}
+	// An async writer returns at once; errors arrive in completed.
+	_ = s.writer.WriteMessages(context.Background(), msgs...)
+}
+
+func (s *KafkaSink) completed(msgs []kafka.Message, err error) {
+	if err != nil {
+		s.logger.Warn("Failed to publish search feedback", "topic", s.writer.Topic, "events", len(msgs), "error", err)
+	}
+}
+
+// Close flushes queued events and closes the writer.
+func (s *KafkaSink) Close() error {
+	return s.writer.Close()
+}
+
+func messages(b Batch) ([]kafka.Message, error) {
+	records := b.records()
+	msgs := make([]kafka.Message, len(records))
+	for i, r := range records {
+		value, err := json.Marshal(r)
+		if err != nil {
+			return nil, err
+		}
+		msgs[i] = kafka.Message{Key: []byte(strconv.FormatInt(r.TutorID, 10)), Value: value, Time: r.ReceivedAt}
+	}
+	return msgs, nil
+}
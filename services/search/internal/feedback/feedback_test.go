@@ -0,0 +1,67 @@
+package feedback
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/testutil"
+)
+
+func TestEvent_Validate(t *testing.T) {
+	valid := Event{QueryHash: "0123456789abcdef", TutorID: 42, Action: ActionClick}
+	require.NoError(t, valid.Validate(1000))
+
+	tests := map[string]func(e *Event){
+		"short hash":     func(e *Event) { e.QueryHash = "abc" },
+		"uppercase hash": func(e *Event) { e.QueryHash = "0123456789ABCDEF" },
+		"zero tutor":     func(e *Event) { e.TutorID = 0 },
+		"tutor too big":  func(e *Event) { e.TutorID = 1001 },
+		"unknown action": func(e *Event) { e.Action = "view" },
+		"empty action":   func(e *Event) { e.Action = "" },
+	}
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := valid
+			mutate(&e)
+			assert.Error(t, e.Validate(1000))
+		})
+	}
+}
+
+func testBatch() Batch {
+	return Batch{
+		ClientID:   "client-1",
+		Tenant:     "default",
+		ReceivedAt: time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC),
+		Events: []Event{
+			{QueryHash: "0123456789abcdef", TutorID: 7, Action: ActionImpression},
+			{QueryHash: "0123456789abcdef", TutorID: 8, Action: ActionContact},
+		},
+	}
+}
+
+func TestLogSink_LogsEachEvent(t *testing.T) {
+	logger, logs := testutil.NewCapturingLogger()
+	NewLogSink(logger).Record(testBatch())
+
+	records := logs.Find("Search feedback")
+	require.Len(t, records, 2)
+	line := records[1].Attrs
+	assert.Equal(t, "0123456789abcdef", line["query_hash"])
+	assert.Equal(t, int64(8), line["tutor_id"])
+	assert.Equal(t, ActionContact, line["action"])
+	assert.Equal(t, "client-1", line["client_id"])
+}
+
+func TestMessages_KeyedByTutor(t *testing.T) {
+	msgs, err := messages(testBatch())
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+
+	assert.Equal(t, "7", string(msgs[0].Key))
+	assert.JSONEq(t, `{"query_hash":"0123456789abcdef","tutor_id":7,"action":"impression","client_id":"client-1","tenant":"default","received_at":"2026-10-17T12:00:00Z"}`, string(msgs[0].Value))
+	assert.Equal(t, "8", string(msgs[1].Key))
+}
@@ -0,0 +1,74 @@
+package grpc
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"search/internal/domain"
+	"search/internal/grpc/searchv1"
+	"search/internal/port"
+)
+
+func tutorToProto(t *domain.Tutor) *searchv1.Tutor {
+	p := &searchv1.Tutor{
+		Id:           t.ID,
+		Slug:         t.Slug,
+		FullName:     t.FullName,
+		AvatarUrl:    t.AvatarURL,
+		Headline:     t.Headline,
+		Bio:          t.Bio,
+		Subjects:     t.Subjects,
+		HourlyRate:   t.HourlyRate,
+		Rating:       t.Rating,
+		ReviewsCount: int32(t.ReviewsCount),
+		IsVerified:   t.IsVerified,
+		Location:     t.Location,
+		Formats:      t.Formats,
+	}
+	if !t.CreatedAt.IsZero() {
+		p.CreatedAt = timestamppb.New(t.CreatedAt)
+	}
+	if !t.UpdatedAt.IsZero() {
+		p.UpdatedAt = timestamppb.New(t.UpdatedAt)
+	}
+	return p
+}
+
+func tutorFromProto(p *searchv1.Tutor) *domain.Tutor {
+	t := &domain.Tutor{
+		ID:           p.GetId(),
+		Slug:         p.GetSlug(),
+		FullName:     p.GetFullName(),
+		AvatarURL:    p.GetAvatarUrl(),
+		Headline:     p.GetHeadline(),
+		Bio:          p.GetBio(),
+		Subjects:     p.GetSubjects(),
+		HourlyRate:   p.GetHourlyRate(),
+		Rating:       p.GetRating(),
+		ReviewsCount: int(p.GetReviewsCount()),
+		IsVerified:   p.GetIsVerified(),
+		Location:     p.GetLocation(),
+		Formats:      p.GetFormats(),
+	}
+	if p.CreatedAt != nil {
+		t.CreatedAt = p.GetCreatedAt().AsTime()
+	}
+	if p.UpdatedAt != nil {
+		t.UpdatedAt = p.GetUpdatedAt().AsTime()
+	}
+	return t
+}
+
+func searchQueryFromProto(req *searchv1.SearchTutorsRequest) port.SearchQuery {
+	return port.SearchQuery{
+		Text:       req.GetQ(),
+		Subjects:   req.GetSubjects(),
+		MinPrice:   req.MinPrice,
+		MaxPrice:   req.MaxPrice,
+		MinRating:  req.MinRating,
+		Format:     req.GetFormat(),
+		Location:   req.GetLocation(),
+		ExcludeIDs: req.GetExcludeIds(),
+		Limit:      int(req.GetLimit()),
+		Offset:     int(req.GetOffset()),
+	}
+}
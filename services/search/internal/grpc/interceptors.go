@@ -0,0 +1,83 @@
+package grpc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDKey is the metadata key carrying the request ID in both
+// directions.
+const RequestIDKey = "x-request-id"
+
+type requestIDKey struct{}
+
+// RequestIDFrom returns the request ID attached by RequestIDInterceptor, or
+// "" if there is none.
+func RequestIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// RequestIDInterceptor takes the caller's x-request-id, or generates one,
+// stores it in the handler context and echoes it in the response header so
+// both sides can correlate logs.
+func RequestIDInterceptor() grpclib.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (any, error) {
+		var id string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(RequestIDKey); len(values) > 0 {
+				id = values[0]
+			}
+		}
+		if id == "" {
+			id = newRequestID()
+		}
+		_ = grpclib.SetHeader(ctx, metadata.Pairs(RequestIDKey, id))
+		return handler(context.WithValue(ctx, requestIDKey{}, id), req)
+	}
+}
+
+func LoggingInterceptor(logger *slog.Logger) grpclib.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		logger.Info("gRPC request",
+			"method", info.FullMethod,
+			"code", status.Code(err).String(),
+			"duration_ms", time.Since(start).Milliseconds(),
+			"request_id", RequestIDFrom(ctx),
+		)
+		return resp, err
+	}
+}
+
+func RecoveryInterceptor(logger *slog.Logger) grpclib.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpclib.UnaryServerInfo, handler grpclib.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("Panic recovered",
+					"error", r,
+					"method", info.FullMethod,
+					"request_id", RequestIDFrom(ctx),
+				)
+				err = status.Error(codes.Internal, "Internal Server Error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
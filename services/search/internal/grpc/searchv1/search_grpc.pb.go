@@ -0,0 +1,255 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: search/v1/search.proto
+
+package searchv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	SearchService_SearchTutors_FullMethodName = "/search.v1.SearchService/SearchTutors"
+	SearchService_GetTutor_FullMethodName     = "/search.v1.SearchService/GetTutor"
+	SearchService_UpsertTutor_FullMethodName  = "/search.v1.SearchService/UpsertTutor"
+	SearchService_DeleteTutor_FullMethodName  = "/search.v1.SearchService/DeleteTutor"
+)
+
+// SearchServiceClient is the client API for SearchService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SearchService is the internal gRPC API for other backend services. It
+// shares the HTTP API's index, query semantics and validation rules.
+type SearchServiceClient interface {
+	// SearchTutors mirrors GET /tutors/search.
+	SearchTutors(ctx context.Context, in *SearchTutorsRequest, opts ...grpc.CallOption) (*SearchTutorsResponse, error)
+	// GetTutor returns one indexed tutor, or NOT_FOUND.
+	GetTutor(ctx context.Context, in *GetTutorRequest, opts ...grpc.CallOption) (*GetTutorResponse, error)
+	// UpsertTutor mirrors PUT /tutors/{id}. Validation failures return
+	// INVALID_ARGUMENT with a google.rpc.BadRequest detail listing every
+	// violation.
+	UpsertTutor(ctx context.Context, in *UpsertTutorRequest, opts ...grpc.CallOption) (*UpsertTutorResponse, error)
+	// DeleteTutor mirrors DELETE /tutors/{id}: NOT_FOUND for a tutor that is
+	// not indexed unless idempotent is set.
+	DeleteTutor(ctx context.Context, in *DeleteTutorRequest, opts ...grpc.CallOption) (*DeleteTutorResponse, error)
+}
+
+type searchServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSearchServiceClient(cc grpc.ClientConnInterface) SearchServiceClient {
+	return &searchServiceClient{cc}
+}
+
+func (c *searchServiceClient) SearchTutors(ctx context.Context, in *SearchTutorsRequest, opts ...grpc.CallOption) (*SearchTutorsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchTutorsResponse)
+	err := c.cc.Invoke(ctx, SearchService_SearchTutors_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchServiceClient) GetTutor(ctx context.Context, in *GetTutorRequest, opts ...grpc.CallOption) (*GetTutorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTutorResponse)
+	err := c.cc.Invoke(ctx, SearchService_GetTutor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchServiceClient) UpsertTutor(ctx context.Context, in *UpsertTutorRequest, opts ...grpc.CallOption) (*UpsertTutorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpsertTutorResponse)
+	err := c.cc.Invoke(ctx, SearchService_UpsertTutor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchServiceClient) DeleteTutor(ctx context.Context, in *DeleteTutorRequest, opts ...grpc.CallOption) (*DeleteTutorResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTutorResponse)
+	err := c.cc.Invoke(ctx, SearchService_DeleteTutor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SearchServiceServer is the server API for SearchService service.
+// All implementations must embed UnimplementedSearchServiceServer
+// for forward compatibility.
+//
+// SearchService is the internal gRPC API for other backend services. It
+// shares the HTTP API's index, query semantics and validation rules.
+type SearchServiceServer interface {
+	// SearchTutors mirrors GET /tutors/search.
+	SearchTutors(context.Context, *SearchTutorsRequest) (*SearchTutorsResponse, error)
+	// GetTutor returns one indexed tutor, or NOT_FOUND.
+	GetTutor(context.Context, *GetTutorRequest) (*GetTutorResponse, error)
+	// UpsertTutor mirrors PUT /tutors/{id}. Validation failures return
+	// INVALID_ARGUMENT with a google.rpc.BadRequest detail listing every
+	// violation.
+	UpsertTutor(context.Context, *UpsertTutorRequest) (*UpsertTutorResponse, error)
+	// DeleteTutor mirrors DELETE /tutors/{id}: NOT_FOUND for a tutor that is
+	// not indexed unless idempotent is set.
+	DeleteTutor(context.Context, *DeleteTutorRequest) (*DeleteTutorResponse, error)
+	mustEmbedUnimplementedSearchServiceServer()
+}
+
+// UnimplementedSearchServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSearchServiceServer struct{}
+
+func (UnimplementedSearchServiceServer) SearchTutors(context.Context, *SearchTutorsRequest) (*SearchTutorsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchTutors not implemented")
+}
+func (UnimplementedSearchServiceServer) GetTutor(context.Context, *GetTutorRequest) (*GetTutorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTutor not implemented")
+}
+func (UnimplementedSearchServiceServer) UpsertTutor(context.Context, *UpsertTutorRequest) (*UpsertTutorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpsertTutor not implemented")
+}
+func (UnimplementedSearchServiceServer) DeleteTutor(context.Context, *DeleteTutorRequest) (*DeleteTutorResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTutor not implemented")
+}
+func (UnimplementedSearchServiceServer) mustEmbedUnimplementedSearchServiceServer() {}
+func (UnimplementedSearchServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeSearchServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SearchServiceServer will
+// result in compilation errors.
+type UnsafeSearchServiceServer interface {
+	mustEmbedUnimplementedSearchServiceServer()
+}
+
+func RegisterSearchServiceServer(s grpc.ServiceRegistrar, srv SearchServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSearchServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SearchService_ServiceDesc, srv)
+}
+
+func _SearchService_SearchTutors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchTutorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServiceServer).SearchTutors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchService_SearchTutors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServiceServer).SearchTutors(ctx, req.(*SearchTutorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchService_GetTutor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTutorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServiceServer).GetTutor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchService_GetTutor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServiceServer).GetTutor(ctx, req.(*GetTutorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchService_UpsertTutor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpsertTutorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServiceServer).UpsertTutor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchService_UpsertTutor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServiceServer).UpsertTutor(ctx, req.(*UpsertTutorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchService_DeleteTutor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTutorRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServiceServer).DeleteTutor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchService_DeleteTutor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServiceServer).DeleteTutor(ctx, req.(*DeleteTutorRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SearchService_ServiceDesc is the grpc.ServiceDesc for SearchService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SearchService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "search.v1.SearchService",
+	HandlerType: (*SearchServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SearchTutors",
+			Handler:    _SearchService_SearchTutors_Handler,
+		},
+		{
+			MethodName: "GetTutor",
+			Handler:    _SearchService_GetTutor_Handler,
+		},
+		{
+			MethodName: "UpsertTutor",
+			Handler:    _SearchService_UpsertTutor_Handler,
+		},
+		{
+			MethodName: "DeleteTutor",
+			Handler:    _SearchService_DeleteTutor_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "search/v1/search.proto",
+}
@@ -0,0 +1,200 @@
+// Package grpc serves the internal gRPC API defined in
+// proto/search/v1/search.proto. It shares the HTTP API's SearchClient and
+// validation so both transports behave the same.
+package grpc
+
+//go:generate protoc -I ../../proto --go_out=../.. --go_opt=module=search --go-grpc_out=../.. --go-grpc_opt=module=search search/v1/search.proto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"search/internal/activity"
+	"search/internal/domain"
+	"search/internal/grpc/searchv1"
+	"search/internal/port"
+)
+
+// Server implements searchv1.SearchServiceServer.
+type Server struct {
+	searchv1.UnimplementedSearchServiceServer
+
+	os       port.SearchClient
+	logger   *slog.Logger
+	activity *activity.Hub
+	subjects *domain.SubjectCatalog
+	scrubber domain.Scrubber
+}
+
+// Option configures optional Server dependencies.
+type Option func(*Server)
+
+// WithActivityHub publishes every successful index change to hub.
+func WithActivityHub(hub *activity.Hub) Option {
+	return func(s *Server) {
+		s.activity = hub
+	}
+}
+
+// WithSubjectCatalog maps indexed and searched subjects to c's keys instead
+// of the embedded defaults.
+func WithSubjectCatalog(c *domain.SubjectCatalog) Option {
+	return func(s *Server) {
+		s.subjects = c
+	}
+}
+
+// WithScrubber runs the bio and headline of every indexed tutor through s.
+func WithScrubber(sc domain.Scrubber) Option {
+	return func(s *Server) {
+		s.scrubber = sc
+	}
+}
+
+// New creates a Server backed by os.
+func New(os port.SearchClient, logger *slog.Logger, opts ...Option) *Server {
+	s := &Server{os: os, logger: logger, subjects: domain.DefaultSubjectCatalog()}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// NewGRPCServer returns a gRPC server with s registered behind the request
+// ID, logging and recovery interceptors.
+func NewGRPCServer(s *Server, logger *slog.Logger) *grpclib.Server {
+	srv := grpclib.NewServer(grpclib.ChainUnaryInterceptor(
+		RequestIDInterceptor(),
+		LoggingInterceptor(logger),
+		RecoveryInterceptor(logger),
+	))
+	searchv1.RegisterSearchServiceServer(srv, s)
+	return srv
+}
+
+func (s *Server) SearchTutors(ctx context.Context, req *searchv1.SearchTutorsRequest) (*searchv1.SearchTutorsResponse, error) {
+	query := searchQueryFromProto(req)
+	query.Subjects = s.subjects.Keys(query.Subjects)
+	// The proto Tutor has no snippet, so gRPC callers keep the full bio.
+	query.IncludeBio = true
+	result, err := s.os.SearchTutors(ctx, query)
+	if err != nil {
+		s.logger.Error("Failed to search tutors", "error", err, "request_id", RequestIDFrom(ctx))
+		return nil, backendError(ctx, "Failed to search tutors")
+	}
+
+	resp := &searchv1.SearchTutorsResponse{
+		Results: make([]*searchv1.Tutor, len(result.Results)),
+		Total:   int64(result.Total),
+	}
+	for i := range result.Results {
+		resp.Results[i] = tutorToProto(&result.Results[i])
+	}
+	return resp, nil
+}
+
+func (s *Server) GetTutor(ctx context.Context, req *searchv1.GetTutorRequest) (*searchv1.GetTutorResponse, error) {
+	tutor, err := s.os.GetTutor(ctx, req.GetId())
+	if errors.Is(err, port.ErrNotFound) {
+		return nil, status.Error(codes.NotFound, "Tutor not found")
+	}
+	if err != nil {
+		s.logger.Error("Failed to get tutor", "id", req.GetId(), "error", err, "request_id", RequestIDFrom(ctx))
+		return nil, backendError(ctx, "Failed to get tutor")
+	}
+	return &searchv1.GetTutorResponse{Tutor: tutorToProto(tutor)}, nil
+}
+
+func (s *Server) UpsertTutor(ctx context.Context, req *searchv1.UpsertTutorRequest) (*searchv1.UpsertTutorResponse, error) {
+	id := req.GetId()
+	if req.GetTutor() == nil {
+		return nil, status.Error(codes.InvalidArgument, "tutor is required")
+	}
+	tutor := tutorFromProto(req.GetTutor())
+
+	// As with PUT /tutors/{id}, the request ID is authoritative and a
+	// conflicting tutor ID is rejected rather than silently overwritten.
+	if tutor.ID != 0 && tutor.ID != id {
+		return nil, status.Errorf(codes.InvalidArgument, "Body ID %d does not match request ID %d", tutor.ID, id)
+	}
+	tutor.ID = id
+
+	if err := tutor.Validate(); err != nil {
+		return nil, validationError(err)
+	}
+	now := time.Now()
+	if zeroed := tutor.NormalizeTimes(now); len(zeroed) > 0 {
+		s.logger.Warn("Zeroed implausible tutor timestamps", "id", id, "fields", zeroed, "request_id", RequestIDFrom(ctx))
+	}
+	tutor.Scrub(s.scrubber)
+	tutor.CanonicalizeSubjects(s.subjects)
+	tutor.NormalizeRates()
+	tutor.MarkIndexed(now)
+
+	if err := s.os.UpsertTutor(ctx, tutor); err != nil {
+		s.logger.Error("Failed to upsert tutor", "id", id, "error", err, "request_id", RequestIDFrom(ctx))
+		return nil, backendError(ctx, "Failed to index tutor")
+	}
+
+	s.activity.Publish(activity.Event{Type: activity.TypeUpsert, Source: activity.SourceGRPC, TutorID: id})
+
+	return &searchv1.UpsertTutorResponse{TutorId: id}, nil
+}
+
+func (s *Server) DeleteTutor(ctx context.Context, req *searchv1.DeleteTutorRequest) (*searchv1.DeleteTutorResponse, error) {
+	id := req.GetId()
+
+	err := s.os.DeleteTutor(ctx, id)
+	switch {
+	case errors.Is(err, port.ErrNotFound) && !req.GetIdempotent():
+		return nil, status.Error(codes.NotFound, "Tutor not found")
+	case err != nil && !errors.Is(err, port.ErrNotFound):
+		s.logger.Error("Failed to delete tutor", "id", id, "error", err, "request_id", RequestIDFrom(ctx))
+		return nil, backendError(ctx, "Failed to delete tutor")
+	}
+
+	s.activity.Publish(activity.Event{Type: activity.TypeDelete, Source: activity.SourceGRPC, TutorID: id})
+
+	return &searchv1.DeleteTutorResponse{TutorId: id}, nil
+}
+
+// backendError reports a SearchClient failure. A failure caused by the
+// caller's deadline or cancellation keeps that status; anything else is
+// Internal with message, like the HTTP API's 500s.
+func backendError(ctx context.Context, message string) error {
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+	return status.Error(codes.Internal, message)
+}
+
+// validationError converts a *domain.ValidationError into InvalidArgument
+// with a BadRequest detail holding one field violation per problem.
+func validationError(err error) error {
+	var verr *domain.ValidationError
+	if !errors.As(err, &verr) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	detail := &errdetails.BadRequest{}
+	for _, v := range verr.Violations {
+		detail.FieldViolations = append(detail.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: fmt.Sprintf("%s: %s", v.Code, v.Message),
+		})
+	}
+
+	st, detailErr := status.New(codes.InvalidArgument, "Invalid tutor").WithDetails(detail)
+	if detailErr != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return st.Err()
+}
@@ -0,0 +1,273 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	grpclib "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+
+	"search/internal/domain"
+	"search/internal/grpc/searchv1"
+	"search/internal/opensearch"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+// failingSearchClient wraps a SearchClient and fails or panics on demand.
+type failingSearchClient struct {
+	port.SearchClient
+	err   error
+	panic bool
+}
+
+func (f *failingSearchClient) SearchTutors(ctx context.Context, query port.SearchQuery) (*port.SearchResponse, error) {
+	if f.panic {
+		panic("boom")
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.SearchClient.SearchTutors(ctx, query)
+}
+
+func (f *failingSearchClient) UpsertTutor(ctx context.Context, tutor *domain.Tutor) error {
+	if f.err != nil {
+		return f.err
+	}
+	return f.SearchClient.UpsertTutor(ctx, tutor)
+}
+
+// newTestClient serves a Server backed by os over an in-memory connection.
+func newTestClient(t *testing.T, os port.SearchClient) searchv1.SearchServiceClient {
+	t.Helper()
+	logger := testutil.NewLogger(t)
+
+	lis := bufconn.Listen(1 << 20)
+	srv := NewGRPCServer(New(os, logger), logger)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpclib.NewClient("passthrough:///bufnet",
+		grpclib.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpclib.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return searchv1.NewSearchServiceClient(conn)
+}
+
+func newFixtureIndex(t *testing.T) *opensearch.MemoryClient {
+	t.Helper()
+	index := opensearch.NewMemoryClient()
+	for _, tutor := range []domain.Tutor{
+		{ID: 1, FullName: "Marie Curie", Subjects: []string{"physics"}, HourlyRate: 60, Rating: 5, Formats: []string{"offline"}},
+		{ID: 2, FullName: "Alan Turing", Subjects: []string{"math"}, HourlyRate: 35, Rating: 4.6, Formats: []string{"online"}},
+		{ID: 3, FullName: "Ada Lovelace", Subjects: []string{"math"}, HourlyRate: 45, Rating: 4.9, Formats: []string{"online"}},
+	} {
+		require.NoError(t, index.UpsertTutor(context.Background(), &tutor))
+	}
+	return index
+}
+
+func resultIDs(resp *searchv1.SearchTutorsResponse) []int64 {
+	ids := make([]int64, len(resp.GetResults()))
+	for i, tutor := range resp.GetResults() {
+		ids[i] = tutor.GetId()
+	}
+	return ids
+}
+
+func TestServer_SearchTutors(t *testing.T) {
+	client := newTestClient(t, newFixtureIndex(t))
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		req       *searchv1.SearchTutorsRequest
+		wantIDs   []int64
+		wantTotal int64
+	}{
+		{"all", &searchv1.SearchTutorsRequest{}, []int64{1, 2, 3}, 3},
+		{"text", &searchv1.SearchTutorsRequest{Q: "turing"}, []int64{2}, 1},
+		{"subjects", &searchv1.SearchTutorsRequest{Subjects: []string{"math"}}, []int64{2, 3}, 2},
+		{"max price", &searchv1.SearchTutorsRequest{MaxPrice: proto.Float64(40)}, []int64{2}, 1},
+		{"min rating", &searchv1.SearchTutorsRequest{MinRating: proto.Float64(4.8)}, []int64{1, 3}, 2},
+		{"format", &searchv1.SearchTutorsRequest{Format: "offline"}, []int64{1}, 1},
+		{"exclude ids", &searchv1.SearchTutorsRequest{ExcludeIds: []int64{1, 2}}, []int64{3}, 1},
+		{"pagination", &searchv1.SearchTutorsRequest{Limit: 1, Offset: 1}, []int64{2}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.SearchTutors(ctx, tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantIDs, resultIDs(resp))
+			assert.Equal(t, tt.wantTotal, resp.GetTotal())
+		})
+	}
+}
+
+func TestServer_SearchTutors_BackendError(t *testing.T) {
+	client := newTestClient(t, &failingSearchClient{SearchClient: opensearch.NewMemoryClient(), err: errors.New("connection refused")})
+
+	_, err := client.SearchTutors(context.Background(), &searchv1.SearchTutorsRequest{})
+
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, "Failed to search tutors", status.Convert(err).Message())
+}
+
+func TestServer_GetTutor(t *testing.T) {
+	client := newTestClient(t, newFixtureIndex(t))
+	ctx := context.Background()
+
+	resp, err := client.GetTutor(ctx, &searchv1.GetTutorRequest{Id: 3})
+	require.NoError(t, err)
+	assert.Equal(t, "Ada Lovelace", resp.GetTutor().GetFullName())
+	assert.Equal(t, []string{"math"}, resp.GetTutor().GetSubjects())
+
+	_, err = client.GetTutor(ctx, &searchv1.GetTutorRequest{Id: 99})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServer_UpsertTutor(t *testing.T) {
+	index := opensearch.NewMemoryClient()
+	client := newTestClient(t, index)
+	ctx := context.Background()
+
+	resp, err := client.UpsertTutor(ctx, &searchv1.UpsertTutorRequest{
+		Id:    7,
+		Tutor: &searchv1.Tutor{FullName: "Emmy Noether", Rating: 4.5, Subjects: []string{"math"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), resp.GetTutorId())
+
+	stored, err := index.GetTutor(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, "Emmy Noether", stored.FullName)
+	assert.True(t, stored.CreatedAt.IsZero(), "unset timestamps stay zero")
+}
+
+func TestServer_UpsertTutor_PreservesTimestamps(t *testing.T) {
+	index := opensearch.NewMemoryClient()
+	client := newTestClient(t, index)
+	ctx := context.Background()
+	created := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
+
+	_, err := client.UpsertTutor(ctx, &searchv1.UpsertTutorRequest{
+		Id:    7,
+		Tutor: tutorToProto(&domain.Tutor{FullName: "Emmy Noether", CreatedAt: created}),
+	})
+	require.NoError(t, err)
+
+	resp, err := client.GetTutor(ctx, &searchv1.GetTutorRequest{Id: 7})
+	require.NoError(t, err)
+	assert.True(t, created.Equal(resp.GetTutor().GetCreatedAt().AsTime()))
+	assert.Nil(t, resp.GetTutor().GetUpdatedAt())
+}
+
+func TestServer_UpsertTutor_Rejected(t *testing.T) {
+	client := newTestClient(t, opensearch.NewMemoryClient())
+
+	tests := []struct {
+		name    string
+		req     *searchv1.UpsertTutorRequest
+		wantMsg string
+	}{
+		{"missing tutor", &searchv1.UpsertTutorRequest{Id: 7}, "tutor is required"},
+		{"id mismatch", &searchv1.UpsertTutorRequest{Id: 7, Tutor: &searchv1.Tutor{Id: 8}}, "Body ID 8 does not match request ID 7"},
+		{"invalid", &searchv1.UpsertTutorRequest{Id: 7, Tutor: &searchv1.Tutor{Rating: 12}}, "Invalid tutor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.UpsertTutor(context.Background(), tt.req)
+
+			st := status.Convert(err)
+			assert.Equal(t, codes.InvalidArgument, st.Code())
+			assert.Equal(t, tt.wantMsg, st.Message())
+		})
+	}
+}
+
+func TestServer_UpsertTutor_ValidationDetails(t *testing.T) {
+	client := newTestClient(t, opensearch.NewMemoryClient())
+
+	_, err := client.UpsertTutor(context.Background(), &searchv1.UpsertTutorRequest{
+		Id:    7,
+		Tutor: &searchv1.Tutor{Rating: 12, HourlyRate: -1},
+	})
+
+	details := status.Convert(err).Details()
+	require.Len(t, details, 1)
+	badRequest, ok := details[0].(*errdetails.BadRequest)
+	require.True(t, ok, "expected a BadRequest detail, got %T", details[0])
+	require.Len(t, badRequest.GetFieldViolations(), 2)
+	assert.Equal(t, "rating", badRequest.GetFieldViolations()[0].GetField())
+	assert.Contains(t, badRequest.GetFieldViolations()[0].GetDescription(), domain.CodeOutOfRange)
+	assert.Equal(t, "hourly_rate", badRequest.GetFieldViolations()[1].GetField())
+}
+
+func TestServer_DeleteTutor(t *testing.T) {
+	client := newTestClient(t, newFixtureIndex(t))
+	ctx := context.Background()
+
+	resp, err := client.DeleteTutor(ctx, &searchv1.DeleteTutorRequest{Id: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.GetTutorId())
+
+	_, err = client.DeleteTutor(ctx, &searchv1.DeleteTutorRequest{Id: 1})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.DeleteTutor(ctx, &searchv1.DeleteTutorRequest{Id: 1, Idempotent: true})
+	assert.NoError(t, err)
+}
+
+func TestRequestIDInterceptor(t *testing.T) {
+	client := newTestClient(t, newFixtureIndex(t))
+
+	t.Run("propagates caller ID", func(t *testing.T) {
+		ctx := metadata.AppendToOutgoingContext(context.Background(), RequestIDKey, "booking-123")
+		var header metadata.MD
+
+		_, err := client.GetTutor(ctx, &searchv1.GetTutorRequest{Id: 1}, grpclib.Header(&header))
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"booking-123"}, header.Get(RequestIDKey))
+	})
+
+	t.Run("generates ID when missing", func(t *testing.T) {
+		var header metadata.MD
+
+		_, err := client.GetTutor(context.Background(), &searchv1.GetTutorRequest{Id: 99}, grpclib.Header(&header))
+
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		require.Len(t, header.Get(RequestIDKey), 1)
+		assert.Len(t, header.Get(RequestIDKey)[0], 16)
+	})
+}
+
+func TestRecoveryInterceptor(t *testing.T) {
+	client := newTestClient(t, &failingSearchClient{SearchClient: newFixtureIndex(t), panic: true})
+	ctx := context.Background()
+
+	_, err := client.SearchTutors(ctx, &searchv1.SearchTutorsRequest{})
+	assert.Equal(t, codes.Internal, status.Code(err))
+
+	_, err = client.GetTutor(ctx, &searchv1.GetTutorRequest{Id: 1})
+	assert.NoError(t, err, "the server keeps serving after a panic")
+}
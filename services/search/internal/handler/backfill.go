@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"search/internal/activity"
+	"search/internal/django"
+	"search/internal/domain"
+	"search/internal/kafka"
+	"search/internal/port"
+)
+
+// TutorSource is implemented by *django.Client. GetTutor returns
+// django.ErrNotFound for a tutor that no longer exists.
+type TutorSource interface {
+	GetTutor(ctx context.Context, id int64) (*domain.Tutor, error)
+}
+
+// WithBackfill indexes tutors missing from the index from src when a partial
+// update finds no document to update, typically because the create event
+// was lost.
+func WithBackfill(src TutorSource) Option {
+	return func(h *EventHandler) {
+		h.source = src
+	}
+}
+
+// withBackfill runs apply, and if the tutor is not indexed and a
+// TutorSource is configured, indexes it from the source and runs apply
+// again. It returns port.ErrNotFound when the tutor is gone from the source
+// too, or was deleted by a live event. Partial updates can change fields a
+// tutor's content hash covers, so the recorded content is forgotten.
+func (h *EventHandler) withBackfill(ctx context.Context, event kafka.Event, tutorID int64, apply func() error) error {
+	h.forgetContent(ctx, tutorID)
+	err := apply()
+	if !errors.Is(err, port.ErrNotFound) || h.source == nil {
+		return err
+	}
+	if h.deletedLive(ctx, tutorID) {
+		return err
+	}
+
+	if err := h.backfill(ctx, event, tutorID); err != nil {
+		return err
+	}
+	return apply()
+}
+
+// backfill fetches tutorID from the source and indexes it.
+func (h *EventHandler) backfill(ctx context.Context, event kafka.Event, tutorID int64) error {
+	tutor, err := h.source.GetTutor(ctx, tutorID)
+	if errors.Is(err, django.ErrNotFound) {
+		h.stats.recordBackfill(func(b *BackfillStats) { b.Gone++ })
+		h.logger.Info("Tutor missing from index is gone from Django, treating as deleted",
+			"event_id", event.EventID,
+			"tutor_id", tutorID,
+		)
+		return port.ErrNotFound
+	}
+	if err != nil {
+		h.stats.recordBackfill(func(b *BackfillStats) { b.Failed++ })
+		return fmt.Errorf("failed to fetch tutor %d for backfill: %w", tutorID, err)
+	}
+
+	err = h.sanitize(event, tutor)
+	if err == nil {
+		err = tutor.Validate()
+	}
+	if err != nil {
+		h.stats.recordBackfill(func(b *BackfillStats) { b.Failed++ })
+		return kafka.Permanent(fmt.Errorf("invalid tutor %d from Django: %w", tutorID, err))
+	}
+	tutor.MarkIndexed(time.Now())
+
+	if err := h.os.UpsertTutor(ctx, tutor); err != nil {
+		h.stats.recordBackfill(func(b *BackfillStats) { b.Failed++ })
+		return writeError(fmt.Errorf("failed to backfill tutor %d: %w", tutorID, err))
+	}
+	h.stats.recordBackfill(func(b *BackfillStats) { b.Indexed++ })
+
+	h.logger.Warn("Backfilled tutor missing from index",
+		"event_id", event.EventID,
+		"event_type", event.EventType,
+		"tutor_id", tutorID,
+	)
+	h.activity.Publish(activity.Event{
+		Type:    activity.TypeUpsert,
+		Source:  activity.SourceKafka,
+		TutorID: tutorID,
+		EventID: event.EventID,
+	})
+	return nil
+}
@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/django"
+	"search/internal/kafka"
+	"search/internal/opensearch"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+// newDjangoStub serves GET /api/tutors/{id}/ with status and body, counting
+// requests.
+func newDjangoStub(t *testing.T, status int, body string) (*django.Client, *atomic.Int32) {
+	t.Helper()
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		assert.Equal(t, "/api/tutors/5/", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return django.NewClient(server.URL), &calls
+}
+
+const djangoTutor = `{"id": 5, "slug": "ada", "full_name": "Ada Lovelace", "headline": "Math", "subjects": ["math"], "hourly_rate": "40.00", "rating": "4.50", "formats": ["online"]}`
+
+func TestEventHandler_Backfill_FetchesThenUpdates(t *testing.T) {
+	t.Parallel()
+
+	os := opensearch.NewMemoryClient()
+	source, calls := newDjangoStub(t, http.StatusOK, djangoTutor)
+	handler := New(os, testutil.NewLogger(t), WithBackfill(source))
+
+	slot := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+	require.NoError(t, handler.Handle(context.Background(), bookingEvent("BookingCancelled", 5, slot)))
+
+	assert.Equal(t, int32(1), calls.Load())
+	tutor, err := os.GetTutor(context.Background(), 5)
+	require.NoError(t, err)
+	assert.Equal(t, "Ada Lovelace", tutor.FullName)
+	require.NotNil(t, tutor.NextAvailableAt, "the booking must be applied after the backfill")
+	assert.True(t, tutor.NextAvailableAt.Equal(slot))
+	assert.Equal(t, int64(1), handler.Stats().Backfills.Indexed)
+
+	// The tutor is indexed now, so the next partial update needs no fetch.
+	require.NoError(t, handler.Handle(context.Background(), bookingEvent("BookingCreated", 5, slot)))
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestEventHandler_Backfill_GoneFromDjango(t *testing.T) {
+	t.Parallel()
+
+	os := opensearch.NewMemoryClient()
+	source, calls := newDjangoStub(t, http.StatusNotFound, `{"detail": "Not found."}`)
+	handler := New(os, testutil.NewLogger(t), WithBackfill(source))
+
+	slot := time.Now().Add(48 * time.Hour)
+	require.NoError(t, handler.Handle(context.Background(), bookingEvent("BookingCancelled", 5, slot)))
+
+	assert.Equal(t, int32(1), calls.Load())
+	_, err := os.GetTutor(context.Background(), 5)
+	assert.ErrorIs(t, err, port.ErrNotFound, "a tutor Django no longer has must stay unindexed")
+	assert.Equal(t, int64(1), handler.Stats().Backfills.Gone)
+}
+
+func TestEventHandler_Backfill_DjangoErrorIsRetried(t *testing.T) {
+	t.Parallel()
+
+	source, _ := newDjangoStub(t, http.StatusServiceUnavailable, `{}`)
+	handler := New(opensearch.NewMemoryClient(), testutil.NewLogger(t), WithBackfill(source))
+
+	err := handler.Handle(context.Background(), bookingEvent("BookingCancelled", 5, time.Now().Add(48*time.Hour)))
+	require.Error(t, err)
+	assert.False(t, kafka.IsPermanent(err), "a Django outage is transient")
+	assert.Equal(t, int64(1), handler.Stats().Backfills.Failed)
+}
+
+func TestEventHandler_Backfill_SkipsLiveDeletedTutor(t *testing.T) {
+	t.Parallel()
+
+	source, calls := newDjangoStub(t, http.StatusOK, djangoTutor)
+	handler := New(opensearch.NewMemoryClient(), testutil.NewLogger(t), WithBackfill(source))
+
+	payload, _ := json.Marshal(map[string]int64{"id": 5})
+	require.NoError(t, handler.Handle(context.Background(), kafka.Event{
+		EventID:   "d-1",
+		EventType: "TutorDeleted",
+		Payload:   payload,
+		CreatedAt: time.Now().Format(time.RFC3339Nano),
+	}))
+	require.NoError(t, handler.Handle(context.Background(), bookingEvent("BookingCancelled", 5, time.Now().Add(48*time.Hour))))
+
+	assert.Zero(t, calls.Load(), "a tutor deleted by a live event must not be fetched back")
+}
+
+func TestEventHandler_Backfill_DisabledSkipsBooking(t *testing.T) {
+	t.Parallel()
+
+	os := opensearch.NewMemoryClient()
+	handler := New(os, testutil.NewLogger(t))
+
+	require.NoError(t, handler.Handle(context.Background(), bookingEvent("BookingCancelled", 5, time.Now().Add(48*time.Hour))))
+
+	_, err := os.GetTutor(context.Background(), 5)
+	assert.ErrorIs(t, err, port.ErrNotFound)
+	assert.Zero(t, handler.Stats().Backfills)
+}
@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"search/internal/domain"
+	"search/internal/kafka"
+	"search/internal/port"
+)
+
+// bulkEventTypes are the event types HandleBatch writes in bulk.
+var bulkEventTypes = map[string]bool{
+	"TutorCreated": true,
+	"TutorUpdated": true,
+}
+
+// HandleBatch handles events in order, as Handle would one by one, and
+// returns the error for each. Runs of TutorCreated and TutorUpdated events
+// for one tenant are written with a single bulk request: a document
+// OpenSearch refuses fails its own event permanently while the rest of the
+// run is indexed, and a bulk request failing as a whole fails every event
+// of the run, to be retried on its own. A run never writes a tutor twice;
+// a second event for it starts a new run. With WithStrictSequence every
+// event goes through Handle.
+//
+// Once an event fails for a retry, every later event for its aggregate
+// fails unapplied with errHeldBack, so the consumer retries them after it
+// in order instead of, say, deleting a tutor the retry then brings back.
+func (h *EventHandler) HandleBatch(ctx context.Context, events []kafka.Event) []error {
+	errs := make([]error, len(events))
+	held := make(heldAggregates)
+	if h.sequence != nil {
+		for i, event := range events {
+			if errs[i] = held.check(event); errs[i] != nil {
+				continue
+			}
+			errs[i] = h.Handle(ctx, event)
+			held.note(event, errs[i])
+		}
+		return errs
+	}
+
+	var run bulkRun
+	for i, event := range events {
+		if run.writes(event) {
+			// Learn how the earlier event for the aggregate went first.
+			h.writeRun(&run, errs, held)
+		}
+		if errs[i] = held.check(event); errs[i] != nil {
+			continue
+		}
+		if !bulkEventTypes[event.EventType] {
+			h.writeRun(&run, errs, held)
+			errs[i] = h.dispatch(ctx, event)
+			held.note(event, errs[i])
+			continue
+		}
+
+		h.logProcessing(event)
+		u, err := h.prepareUpsert(ctx, event)
+		if err != nil {
+			errs[i] = err
+			h.recordOutcome(event, err)
+			held.note(event, err)
+			continue
+		}
+		if !run.accepts(u) {
+			// Write the earlier event first, so this one is compared
+			// with the content it wrote.
+			h.writeRun(&run, errs, held)
+		}
+		if h.skipUnchanged(u) {
+			h.recordOutcome(event, nil)
+			continue
+		}
+		u.tutor.MarkIndexed(time.Now())
+		run.add(i, u)
+	}
+	h.writeRun(&run, errs, held)
+	return errs
+}
+
+// errHeldBack fails the events of a batch that wait for an earlier event
+// for their aggregate to be retried.
+var errHeldBack = errors.New("held back behind an earlier event left for a retry")
+
+// heldAggregates are the aggregates of a batch with an event left for a
+// retry. Events without an aggregate ID are never held back.
+type heldAggregates map[sequenceKey]bool
+
+// note holds back event's aggregate if handling it failed with err for a
+// retry.
+func (held heldAggregates) note(event kafka.Event, err error) {
+	if err == nil || kafka.IsPermanent(err) {
+		return
+	}
+	if key, ok := aggregateOf(event); ok {
+		held[key] = true
+	}
+}
+
+// check returns the error to fail event with unapplied, or nil.
+func (held heldAggregates) check(event kafka.Event) error {
+	key, ok := aggregateOf(event)
+	if !ok || !held[key] {
+		return nil
+	}
+	return fmt.Errorf("%s for %s %s: %w", event.EventType, event.AggregateType, event.AggregateID, errHeldBack)
+}
+
+// bulkRun collects the upserts of a batch to write in one bulk request.
+type bulkRun struct {
+	// indexes are the positions of upserts' events in the batch.
+	indexes []int
+	upserts []*upsert
+	ids     map[int64]bool
+	// aggregates are those of the upserts' events.
+	aggregates map[sequenceKey]bool
+}
+
+// accepts reports whether u can join the run: it is for the run's tenant
+// and a tutor the run does not write yet.
+func (r *bulkRun) accepts(u *upsert) bool {
+	if len(r.upserts) == 0 {
+		return true
+	}
+	return u.event.Tenant == r.upserts[0].event.Tenant && !r.ids[u.tutor.ID]
+}
+
+// writes reports whether the run holds an event for event's aggregate.
+func (r *bulkRun) writes(event kafka.Event) bool {
+	key, ok := aggregateOf(event)
+	return ok && r.aggregates[key]
+}
+
+func (r *bulkRun) add(index int, u *upsert) {
+	if r.ids == nil {
+		r.ids = make(map[int64]bool)
+		r.aggregates = make(map[sequenceKey]bool)
+	}
+	r.indexes = append(r.indexes, index)
+	r.upserts = append(r.upserts, u)
+	r.ids[u.tutor.ID] = true
+	if key, ok := aggregateOf(u.event); ok {
+		r.aggregates[key] = true
+	}
+}
+
+// writeRun bulk upserts the tutors of run, sets the error of each of its
+// events in errs, holding back the aggregates of those left for a retry,
+// and empties it.
+func (h *EventHandler) writeRun(run *bulkRun, errs []error, held heldAggregates) {
+	if len(run.upserts) == 0 {
+		return
+	}
+	tutors := make([]*domain.Tutor, len(run.upserts))
+	for i, u := range run.upserts {
+		tutors[i] = &u.tutor
+	}
+
+	// Every upsert of the run carries its tenant.
+	result, err := h.os.BulkUpsertTutors(run.upserts[0].ctx, tutors)
+	failed := 0
+	for n, u := range run.upserts {
+		i := run.indexes[n]
+		errs[i] = h.finishUpsert(u, bulkItemError(result, err, n))
+		h.recordOutcome(u.event, errs[i])
+		held.note(u.event, errs[i])
+		if errs[i] != nil {
+			failed++
+		}
+	}
+	h.logger.Info("Bulk upserted tutors", "tutors", len(tutors), "failed", failed)
+	*run = bulkRun{}
+}
+
+// bulkItemError returns the error of writing the nth tutor of a bulk upsert
+// that returned result and err.
+func bulkItemError(result *port.BulkResult, err error, n int) error {
+	if err != nil {
+		return err
+	}
+	if n >= len(result.Items) {
+		return errors.New("missing from bulk result")
+	}
+	return result.Items[n].Err
+}
@@ -0,0 +1,287 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	segkafka "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/domain"
+	"search/internal/kafka"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func upsertEvent(id int64) kafka.Event {
+	payload, _ := json.Marshal(domain.Tutor{ID: id, FullName: fmt.Sprintf("Tutor %d", id)})
+	return kafka.Event{
+		EventID:     fmt.Sprintf("event-%d", id),
+		EventType:   "TutorUpdated",
+		AggregateID: fmt.Sprint(id),
+		Payload:     payload,
+		CreatedAt:   time.Now().Format(time.RFC3339),
+	}
+}
+
+// rejectingBulk answers bulk upserts, rejecting the tutors in rejected, and
+// records the IDs of each call.
+func rejectingBulk(calls *[][]int64, rejected ...int64) func(context.Context, []*domain.Tutor) (*port.BulkResult, error) {
+	return func(ctx context.Context, tutors []*domain.Tutor) (*port.BulkResult, error) {
+		result := &port.BulkResult{}
+		var ids []int64
+		for _, tutor := range tutors {
+			item := port.BulkItem{ID: tutor.ID}
+			for _, id := range rejected {
+				if tutor.ID == id {
+					item.Err = mappingRejection(id)
+				}
+			}
+			result.Items = append(result.Items, item)
+			ids = append(ids, tutor.ID)
+		}
+		*calls = append(*calls, ids)
+		return result, nil
+	}
+}
+
+func TestEventHandler_HandleBatch_PartialBulkFailure(t *testing.T) {
+	var calls [][]int64
+	handler := New(&mockSearchClient{bulkFunc: rejectingBulk(&calls, 2, 5)}, testutil.NewLogger(t))
+
+	var events []kafka.Event
+	for id := int64(1); id <= 6; id++ {
+		events = append(events, upsertEvent(id))
+	}
+	errs := handler.HandleBatch(context.Background(), events)
+
+	assert.Equal(t, [][]int64{{1, 2, 3, 4, 5, 6}}, calls, "one bulk request for the whole run")
+	require.Len(t, errs, 6)
+	for i, err := range errs {
+		if i == 1 || i == 4 {
+			assert.True(t, kafka.IsPermanent(err), "event %d: a rejected document would be rejected again", i)
+			assert.ErrorIs(t, err, port.ErrDocumentRejected)
+			continue
+		}
+		assert.NoError(t, err, "event %d", i)
+	}
+	stats := handler.Stats().EventTypes["TutorUpdated"]
+	assert.Equal(t, int64(4), stats.Succeeded)
+	assert.Equal(t, int64(2), stats.Quarantined)
+}
+
+func TestEventHandler_HandleBatch_SplitsRuns(t *testing.T) {
+	var ops []string
+	mockOS := &mockSearchClient{
+		bulkFunc: func(ctx context.Context, tutors []*domain.Tutor) (*port.BulkResult, error) {
+			result := &port.BulkResult{}
+			var ids []int64
+			for _, tutor := range tutors {
+				result.Items = append(result.Items, port.BulkItem{ID: tutor.ID})
+				ids = append(ids, tutor.ID)
+			}
+			ops = append(ops, fmt.Sprint("bulk ", ids))
+			return result, nil
+		},
+		deleteFunc: func(ctx context.Context, id int64) error {
+			ops = append(ops, fmt.Sprint("delete ", id))
+			return nil
+		},
+	}
+	handler := New(mockOS, testutil.NewLogger(t))
+
+	deletePayload, _ := json.Marshal(map[string]int64{"id": 3})
+	events := []kafka.Event{
+		upsertEvent(1),
+		upsertEvent(2),
+		upsertEvent(1),
+		{EventID: "delete-3", EventType: "TutorDeleted", AggregateID: "3", Payload: deletePayload},
+		upsertEvent(4),
+		{EventID: "broken", EventType: "TutorUpdated", Payload: json.RawMessage(`"not a tutor"`)},
+		upsertEvent(5),
+	}
+	errs := handler.HandleBatch(context.Background(), events)
+
+	assert.Equal(t, []string{"bulk [1 2]", "bulk [1]", "delete 3", "bulk [4 5]"}, ops,
+		"a tutor is written once per run, and other events keep their place")
+	for i, err := range errs {
+		if i == 5 {
+			assert.True(t, kafka.IsPermanent(err), "an undecodable payload fails only its own event")
+			continue
+		}
+		assert.NoError(t, err, "event %d", i)
+	}
+}
+
+func TestEventHandler_HandleBatch_RequestFailure(t *testing.T) {
+	mockOS := &mockSearchClient{
+		bulkFunc: func(ctx context.Context, tutors []*domain.Tutor) (*port.BulkResult, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+	handler := New(mockOS, testutil.NewLogger(t))
+
+	errs := handler.HandleBatch(context.Background(), []kafka.Event{upsertEvent(1), upsertEvent(2)})
+
+	require.Len(t, errs, 2)
+	for _, err := range errs {
+		require.Error(t, err)
+		assert.False(t, kafka.IsPermanent(err), "a failed request is retried event by event")
+	}
+}
+
+func TestConsumer_Batch_RejectedDocumentsDoNotHoldBackOthers(t *testing.T) {
+	var mu sync.Mutex
+	var calls [][]int64
+	bulk := rejectingBulk(&calls, 2, 5)
+	mockOS := &mockSearchClient{
+		bulkFunc: func(ctx context.Context, tutors []*domain.Tutor) (*port.BulkResult, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			return bulk(ctx, tutors)
+		},
+	}
+
+	reader := &sliceReader{}
+	for id := int64(1); id <= 6; id++ {
+		value, _ := json.Marshal(upsertEvent(id))
+		reader.messages = append(reader.messages, segkafka.Message{Topic: "tutor-events", Offset: 100 + id - 1, Value: value})
+	}
+
+	var quarantined []string
+	consumer := kafka.NewConsumerWithReader(reader, New(mockOS, testutil.NewLogger(t)), testutil.NewLogger(t),
+		kafka.WithBatching(10, 20*time.Millisecond),
+		// A transient classification would retry the first rejection forever.
+		kafka.WithRetryBackoff(time.Hour, time.Hour),
+		kafka.WithErrorHook(func(event *kafka.Event, err error) {
+			if event != nil && kafka.IsPermanent(err) {
+				quarantined = append(quarantined, event.EventID)
+			}
+		}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- consumer.Start(ctx) }()
+
+	require.Eventually(t, func() bool { return len(reader.getCommitted()) == 1 }, time.Second, time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+
+	assert.Equal(t, []int64{105}, reader.getCommitted(), "the batch is committed through its last offset at once")
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, [][]int64{{1, 2, 3, 4, 5, 6}}, calls)
+	assert.Equal(t, []string{"event-2", "event-5"}, quarantined)
+}
+
+func TestEventHandler_HandleBatch_HoldsBackLaterEventsForAFailedTutor(t *testing.T) {
+	deletePayload, _ := json.Marshal(map[string]int64{"id": 1})
+	deleted := kafka.Event{EventID: "delete-1", EventType: "TutorDeleted", AggregateID: "1", Payload: deletePayload,
+		CreatedAt: time.Now().Add(time.Second).Format(time.RFC3339)}
+
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{"bulk", nil},
+		{"strict sequence", []Option{WithStrictSequence(0, time.Minute)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deletes []int64
+			mockOS := &mockSearchClient{
+				upsertFunc: func(ctx context.Context, tutor *domain.Tutor) error {
+					return errors.New("connection refused")
+				},
+				bulkFunc: func(ctx context.Context, tutors []*domain.Tutor) (*port.BulkResult, error) {
+					return nil, errors.New("connection refused")
+				},
+				deleteFunc: func(ctx context.Context, id int64) error {
+					deletes = append(deletes, id)
+					return nil
+				},
+			}
+			handler := New(mockOS, testutil.NewLogger(t), tt.opts...)
+
+			other, _ := json.Marshal(map[string]int64{"id": 2})
+			errs := handler.HandleBatch(context.Background(), []kafka.Event{
+				upsertEvent(1),
+				deleted,
+				{EventID: "delete-2", EventType: "TutorDeleted", AggregateID: "2", Payload: other, CreatedAt: deleted.CreatedAt},
+			})
+
+			require.Len(t, errs, 3)
+			require.Error(t, errs[0])
+			assert.False(t, kafka.IsPermanent(errs[0]))
+			assert.ErrorIs(t, errs[1], errHeldBack, "the delete must wait for the failed update")
+			assert.False(t, kafka.IsPermanent(errs[1]))
+			assert.NoError(t, errs[2], "other tutors are not held back")
+			assert.Equal(t, []int64{2}, deletes)
+		})
+	}
+}
+
+func TestConsumer_Batch_FailedUpdateDoesNotOvertakeDelete(t *testing.T) {
+	var mu sync.Mutex
+	var ops []string
+	indexed := map[int64]bool{}
+	mockOS := &mockSearchClient{
+		bulkFunc: func(ctx context.Context, tutors []*domain.Tutor) (*port.BulkResult, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			ops = append(ops, "bulk")
+			return nil, errors.New("connection refused")
+		},
+		upsertFunc: func(ctx context.Context, tutor *domain.Tutor) error {
+			mu.Lock()
+			defer mu.Unlock()
+			ops = append(ops, fmt.Sprint("upsert ", tutor.ID))
+			indexed[tutor.ID] = true
+			return nil
+		},
+		deleteFunc: func(ctx context.Context, id int64) error {
+			mu.Lock()
+			defer mu.Unlock()
+			ops = append(ops, fmt.Sprint("delete ", id))
+			delete(indexed, id)
+			return nil
+		},
+	}
+
+	deletePayload, _ := json.Marshal(map[string]int64{"id": 1})
+	updated, _ := json.Marshal(upsertEvent(1))
+	deleted, _ := json.Marshal(kafka.Event{EventID: "delete-1", EventType: "TutorDeleted", AggregateID: "1", Payload: deletePayload})
+	reader := &sliceReader{messages: []segkafka.Message{
+		{Topic: "tutor-events", Offset: 100, Value: updated},
+		{Topic: "tutor-events", Offset: 101, Value: deleted},
+	}}
+
+	consumer := kafka.NewConsumerWithReader(reader, New(mockOS, testutil.NewLogger(t)), testutil.NewLogger(t),
+		kafka.WithBatching(10, 20*time.Millisecond),
+		kafka.WithRetryBackoff(time.Millisecond, time.Millisecond),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- consumer.Start(ctx) }()
+
+	require.Eventually(t, func() bool { return slices.Contains(reader.getCommitted(), 101) }, time.Second, time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"bulk", "upsert 1", "delete 1"}, ops, "the update is retried before the delete")
+	assert.False(t, indexed[1], "tutor 1 stays deleted")
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"search/internal/kafka"
+	"search/internal/port"
+)
+
+// bookingPayload is the payload of BookingCreated and BookingCancelled
+// events from the booking-events topic.
+type bookingPayload struct {
+	TutorID   int64     `json:"tutor_id"`
+	SlotStart time.Time `json:"slot_start"`
+}
+
+func (h *EventHandler) handleBookingCreated(ctx context.Context, event kafka.Event) error {
+	return h.handleBooking(ctx, event, h.os.BookSlot)
+}
+
+func (h *EventHandler) handleBookingCancelled(ctx context.Context, event kafka.Event) error {
+	return h.handleBooking(ctx, event, h.os.FreeSlot)
+}
+
+// handleBooking applies a booking change to the tutor's next_available_at.
+// A tutor that is not indexed is backfilled when a TutorSource is
+// configured. Otherwise, or when the source no longer has the tutor, the
+// booking is skipped: the tutor has been deleted or not created yet, and
+// there is nothing to update.
+func (h *EventHandler) handleBooking(ctx context.Context, event kafka.Event, apply func(context.Context, int64, time.Time) error) error {
+	ctx, err := h.route(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	var payload bookingPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return kafka.Permanent(fmt.Errorf("failed to unmarshal booking payload: %w", err))
+	}
+	if payload.TutorID <= 0 {
+		return kafka.Permanent(fmt.Errorf("invalid tutor ID in booking payload: %d", payload.TutorID))
+	}
+	if payload.SlotStart.IsZero() {
+		return kafka.Permanent(errors.New("booking payload has no slot_start"))
+	}
+
+	// A slot that has already started cannot make the tutor available.
+	if event.EventType == "BookingCancelled" && !payload.SlotStart.After(time.Now()) {
+		h.logger.Info("Cancelled slot is in the past, skipping",
+			"event_id", event.EventID,
+			"tutor_id", payload.TutorID,
+			"slot_start", payload.SlotStart,
+		)
+		return nil
+	}
+
+	err = h.withBackfill(ctx, event, payload.TutorID, func() error {
+		return apply(ctx, payload.TutorID, payload.SlotStart)
+	})
+	if errors.Is(err, port.ErrNotFound) {
+		h.logger.Info("Booking for tutor not in index, skipping",
+			"event_id", event.EventID,
+			"tutor_id", payload.TutorID,
+		)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update availability of tutor %d: %w", payload.TutorID, err)
+	}
+
+	h.logger.Info("Tutor availability updated",
+		"event_id", event.EventID,
+		"event_type", event.EventType,
+		"tutor_id", payload.TutorID,
+		"slot_start", payload.SlotStart,
+	)
+	return nil
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/kafka"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+type slotCall struct {
+	booked  bool
+	tutorID int64
+	slot    time.Time
+}
+
+func bookingEvent(eventType string, tutorID int64, slot time.Time) kafka.Event {
+	payload, _ := json.Marshal(map[string]any{"tutor_id": tutorID, "slot_start": slot})
+	return kafka.Event{EventID: "b-1", EventType: eventType, AggregateType: "Booking", Payload: payload}
+}
+
+func TestEventHandler_BookingEvents(t *testing.T) {
+	t.Parallel()
+
+	var calls []slotCall
+	handler := New(&mockSearchClient{
+		slotFunc: func(ctx context.Context, booked bool, tutorID int64, slot time.Time) error {
+			calls = append(calls, slotCall{booked, tutorID, slot})
+			return nil
+		},
+	}, testutil.NewLogger(t))
+
+	slot := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+	require.NoError(t, handler.Handle(context.Background(), bookingEvent("BookingCreated", 5, slot)))
+	require.NoError(t, handler.Handle(context.Background(), bookingEvent("BookingCancelled", 5, slot)))
+
+	require.Len(t, calls, 2)
+	assert.Equal(t, slotCall{true, 5, slot}, slotCall{calls[0].booked, calls[0].tutorID, calls[0].slot.UTC()})
+	assert.Equal(t, slotCall{false, 5, slot}, slotCall{calls[1].booked, calls[1].tutorID, calls[1].slot.UTC()})
+}
+
+func TestEventHandler_BookingCancelled_PastSlotIsSkipped(t *testing.T) {
+	t.Parallel()
+
+	called := false
+	handler := New(&mockSearchClient{
+		slotFunc: func(ctx context.Context, booked bool, tutorID int64, slot time.Time) error {
+			called = true
+			return nil
+		},
+	}, testutil.NewLogger(t))
+
+	err := handler.Handle(context.Background(), bookingEvent("BookingCancelled", 5, time.Now().Add(-time.Hour)))
+
+	assert.NoError(t, err)
+	assert.False(t, called)
+}
+
+func TestEventHandler_BookingForUnindexedTutor_IsSkipped(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&mockSearchClient{
+		slotFunc: func(ctx context.Context, booked bool, tutorID int64, slot time.Time) error {
+			return port.ErrNotFound
+		},
+	}, testutil.NewLogger(t))
+
+	err := handler.Handle(context.Background(), bookingEvent("BookingCreated", 5, time.Now().Add(time.Hour)))
+	assert.NoError(t, err)
+}
+
+func TestEventHandler_BookingErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		event         kafka.Event
+		slotErr       error
+		wantPermanent bool
+	}{
+		{
+			name:          "malformed payload",
+			event:         kafka.Event{EventType: "BookingCreated", Payload: json.RawMessage(`{"tutor_id": "x"}`)},
+			wantPermanent: true,
+		},
+		{
+			name:          "missing tutor ID",
+			event:         kafka.Event{EventType: "BookingCreated", Payload: json.RawMessage(`{"slot_start": "2030-01-01T10:00:00Z"}`)},
+			wantPermanent: true,
+		},
+		{
+			name:          "missing slot",
+			event:         kafka.Event{EventType: "BookingCancelled", Payload: json.RawMessage(`{"tutor_id": 5}`)},
+			wantPermanent: true,
+		},
+		{
+			name:    "backend failure is retried",
+			event:   bookingEvent("BookingCreated", 5, time.Now().Add(time.Hour)),
+			slotErr: errors.New("opensearch unavailable"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := New(&mockSearchClient{
+				slotFunc: func(ctx context.Context, booked bool, tutorID int64, slot time.Time) error {
+					return tt.slotErr
+				},
+			}, testutil.NewLogger(t))
+
+			err := handler.Handle(context.Background(), tt.event)
+
+			require.Error(t, err)
+			assert.Equal(t, tt.wantPermanent, kafka.IsPermanent(err))
+		})
+	}
+}
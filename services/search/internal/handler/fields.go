@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+
+	"search/internal/domain"
+	"search/internal/kafka"
+)
+
+// Lowercased JSON names of the payload fields tutor events decode.
+var (
+	tutorFields    = jsonFields(reflect.TypeFor[domain.Tutor]())
+	snapshotFields = jsonFields(reflect.TypeFor[snapshotPayload]())
+)
+
+// jsonFields returns the lowercased JSON names encoding/json decodes into
+// struct type t, following embedded structs.
+func jsonFields(t reflect.Type) map[string]bool {
+	fields := make(map[string]bool)
+	for i := range t.NumField() {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			for n := range jsonFields(f.Type) {
+				fields[n] = true
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[strings.ToLower(name)] = true
+	}
+	return fields
+}
+
+// unknownFields returns the sorted top-level keys of payload that no field
+// in known decodes. encoding/json matches keys case-insensitively, and so
+// does this. A payload that is not a JSON object has none.
+func unknownFields(payload json.RawMessage, known map[string]bool) []string {
+	var raw map[string]json.RawMessage
+	if json.Unmarshal(payload, &raw) != nil {
+		return nil
+	}
+	var unknown []string
+	for key := range raw {
+		if !known[strings.ToLower(key)] {
+			unknown = append(unknown, key)
+		}
+	}
+	slices.Sort(unknown)
+	return unknown
+}
+
+// warnUnknownFields logs the payload fields of event that the tutor struct
+// has no place for. They are dropped rather than indexed: documents are
+// built from the struct and the index mapping is strict, so a field Django
+// starts sending before this service knows it never reaches the index.
+func (h *EventHandler) warnUnknownFields(event kafka.Event, tutorID int64, known map[string]bool) {
+	unknown := unknownFields(event.Payload, known)
+	if len(unknown) == 0 {
+		return
+	}
+	h.logger.Warn("Dropped unknown tutor payload fields",
+		"event_id", event.EventID,
+		"event_type", event.EventType,
+		"tutor_id", tutorID,
+		"fields", unknown,
+	)
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/domain"
+	"search/internal/kafka"
+	"search/internal/testutil"
+)
+
+func TestUnknownFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		payload string
+		known   map[string]bool
+		want    []string
+	}{
+		{"all known", `{"id": 1, "full_name": "Ada", "subjects": ["math"]}`, tutorFields, nil},
+		{"new Django fields", `{"id": 1, "pronouns": "she/her", "intro_video": "x"}`, tutorFields, []string{"intro_video", "pronouns"}},
+		{"keys match case-insensitively", `{"ID": 1, "Full_Name": "Ada"}`, tutorFields, nil},
+		{"snapshot_id is a tutor field only in snapshots", `{"id": 1, "snapshot_id": "s1"}`, tutorFields, []string{"snapshot_id"}},
+		{"snapshot fields include the embedded tutor", `{"id": 1, "full_name": "Ada", "snapshot_id": "s1"}`, snapshotFields, nil},
+		{"not an object", `[1, 2]`, tutorFields, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, unknownFields(json.RawMessage(tt.payload), tt.known))
+		})
+	}
+}
+
+func TestEventHandler_UnknownFieldsAreLoggedAndDropped(t *testing.T) {
+	t.Parallel()
+
+	var indexed *domain.Tutor
+	logger, logs := testutil.NewCapturingLogger()
+	handler := New(&mockSearchClient{
+		upsertFunc: func(ctx context.Context, tutor *domain.Tutor) error {
+			indexed = tutor
+			return nil
+		},
+	}, logger)
+
+	event := kafka.Event{
+		EventID:   "event-unknown",
+		EventType: "TutorUpdated",
+		Payload:   json.RawMessage(`{"id": 42, "full_name": "Ada Lovelace", "pronouns": "she/her", "intro_video": "https://example.com/v.mp4"}`),
+	}
+	require.NoError(t, handler.Handle(context.Background(), event))
+
+	require.NotNil(t, indexed)
+	assert.Equal(t, "Ada Lovelace", indexed.FullName)
+	raw, err := json.Marshal(indexed)
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "pronouns", "unknown fields must not reach the index")
+
+	warnings := logs.Find("Dropped unknown tutor payload fields")
+	require.Len(t, warnings, 1)
+	assert.Equal(t, int64(42), warnings[0].Attrs["tutor_id"])
+	assert.Equal(t, []string{"intro_video", "pronouns"}, warnings[0].Attrs["fields"])
+}
+
+func TestEventHandler_KnownFieldsLogNoWarning(t *testing.T) {
+	t.Parallel()
+
+	logger, logs := testutil.NewCapturingLogger()
+	handler := New(&mockSearchClient{}, logger)
+
+	event := testutil.TutorEvent(t, "TutorCreated", testutil.NewTutor(42))
+	require.NoError(t, handler.Handle(context.Background(), event))
+
+	assert.Empty(t, logs.Find("Dropped unknown tutor payload fields"))
+}
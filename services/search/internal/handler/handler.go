@@ -3,79 +3,350 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"sync"
+	"time"
 
+	"search/internal/activity"
 	"search/internal/domain"
 	"search/internal/kafka"
-	"search/internal/opensearch"
+	"search/internal/port"
+	"search/internal/tenant"
+	"search/internal/watermark"
 )
 
 // EventHandler processes Kafka events and updates OpenSearch.
 type EventHandler struct {
-	os     opensearch.SearchClient
-	logger *slog.Logger
+	os       port.SearchClient
+	logger   *slog.Logger
+	activity *activity.Hub
+	tenants  *tenant.Registry
+	// maxListItems caps subjects and formats of indexed tutors.
+	maxListItems int
+	avatars      domain.AvatarPolicy
+	ratingMode   string
+	subjects     *domain.SubjectCatalog
+	// scrubber, if set, removes contact details from bios and headlines.
+	scrubber domain.Scrubber
+	// watermark, if set, advances past every event handled or skipped.
+	watermark *watermark.Tracker
+	// source, if set, backfills tutors that partial updates find missing.
+	source TutorSource
+	// unchanged, if set, holds the content last written for each tutor so
+	// that writes changing nothing can be skipped.
+	unchanged *contentCache
+	// sequence, if set, stashes events that arrive out of order.
+	sequence *sequencer
+	// handlers maps event types to their handling method.
+	handlers map[string]func(context.Context, kafka.Event) error
+	stats    *stats
+
+	mu         sync.Mutex
+	lastEvents map[eventKey]LastEvent
+	snapshots  map[string]*SnapshotProgress
+}
+
+// eventKey identifies a tutor across tenants, whose IDs may overlap.
+type eventKey struct {
+	tenant string
+	id     int64
+}
+
+// LastEvent is the newest event seen for a tutor, whether or not handling it
+// succeeded.
+type LastEvent struct {
+	Type string
+	At   time.Time
+}
+
+// Option configures optional EventHandler dependencies.
+type Option func(*EventHandler)
+
+// WithActivityHub publishes every successful index change to hub.
+func WithActivityHub(hub *activity.Hub) Option {
+	return func(h *EventHandler) {
+		h.activity = hub
+	}
+}
+
+// WithTenants routes each event to the index of the tenant it names.
+// Without it every event goes to the default tutors index.
+func WithTenants(r *tenant.Registry) Option {
+	return func(h *EventHandler) {
+		h.tenants = r
+	}
+}
+
+// WithMaxListItems caps the subjects and formats of each indexed tutor at
+// n entries instead of domain.DefaultMaxListItems. Zero keeps the default.
+func WithMaxListItems(n int) Option {
+	return func(h *EventHandler) {
+		if n > 0 {
+			h.maxListItems = n
+		}
+	}
+}
+
+// WithAvatarPolicy normalizes avatar URLs with p instead of only stripping
+// the default tracking parameters.
+func WithAvatarPolicy(p domain.AvatarPolicy) Option {
+	return func(h *EventHandler) {
+		h.avatars = p
+	}
+}
+
+// WithRatingMode sets how a tutor with a rating but no reviews is handled:
+// domain.RatingModeStrict rejects the event, the default zeroes the rating.
+func WithRatingMode(mode string) Option {
+	return func(h *EventHandler) {
+		h.ratingMode = mode
+	}
+}
+
+// WithSubjectCatalog maps indexed subjects to c's keys and labels instead
+// of the embedded defaults.
+func WithSubjectCatalog(c *domain.SubjectCatalog) Option {
+	return func(h *EventHandler) {
+		h.subjects = c
+	}
+}
+
+// WithScrubber runs the bio and headline of every indexed tutor through s.
+// Nil indexes them as sent.
+func WithScrubber(s domain.Scrubber) Option {
+	return func(h *EventHandler) {
+		h.scrubber = s
+	}
+}
+
+// WithWatermark advances w to the created_at of every event that is done
+// with: handled, skipped as unknown or failed permanently. Events left for
+// a retry do not move it.
+func WithWatermark(w *watermark.Tracker) Option {
+	return func(h *EventHandler) {
+		h.watermark = w
+	}
 }
 
 // New creates a new EventHandler.
-func New(os opensearch.SearchClient, logger *slog.Logger) *EventHandler {
-	return &EventHandler{os: os, logger: logger}
+func New(os port.SearchClient, logger *slog.Logger, opts ...Option) *EventHandler {
+	h := &EventHandler{
+		os:           os,
+		logger:       logger,
+		tenants:      tenant.Single(port.IndexName),
+		maxListItems: domain.DefaultMaxListItems,
+		avatars:      domain.AvatarPolicy{StripParams: domain.DefaultAvatarStripParams},
+		subjects:     domain.DefaultSubjectCatalog(),
+		stats:        newStats(time.Now()),
+		lastEvents:   make(map[eventKey]LastEvent),
+		snapshots:    make(map[string]*SnapshotProgress),
+	}
+	h.handlers = map[string]func(context.Context, kafka.Event) error{
+		"TutorCreated":             h.handleTutorUpsert,
+		"TutorUpdated":             h.handleTutorUpsert,
+		"TutorDeleted":             h.handleTutorDelete,
+		"TutorSnapshot":            h.handleTutorSnapshot,
+		"BookingCreated":           h.handleBookingCreated,
+		"BookingCancelled":         h.handleBookingCancelled,
+		"TutorVerified":            h.handleTutorVerified,
+		"TutorUnverified":          h.handleTutorUnverified,
+		"TutorSlugChanged":         h.handleTutorSlugChanged,
+		"TutorTrialSettingChanged": h.handleTutorTrialSettingChanged,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 // Handle processes a single event and updates OpenSearch accordingly.
 func (h *EventHandler) Handle(ctx context.Context, event kafka.Event) error {
-	h.logger.Info("Processing event",
-		"event_id", event.EventID,
-		"event_type", event.EventType,
-		"aggregate_id", event.AggregateID,
-	)
+	if h.sequence != nil {
+		return h.handleInSequence(ctx, event)
+	}
+	return h.dispatch(ctx, event)
+}
+
+// dispatch hands event to the method for its type and counts the outcome.
+func (h *EventHandler) dispatch(ctx context.Context, event kafka.Event) error {
+	h.logProcessing(event)
 
-	switch event.EventType {
-	case "TutorCreated", "TutorUpdated":
-		return h.handleTutorUpsert(ctx, event)
-	case "TutorDeleted":
-		return h.handleTutorDelete(ctx, event)
-	default:
+	handle, ok := h.handlers[event.EventType]
+	if !ok {
 		h.logger.Warn("Unknown event type, skipping",
 			"event_type", event.EventType,
 			"event_id", event.EventID,
 		)
+		h.stats.record(event.EventType, false, nil, time.Now())
+		h.advanceWatermark(event)
 		return nil
 	}
+	err := handle(ctx, event)
+	h.recordOutcome(event, err)
+	return err
+}
+
+func (h *EventHandler) logProcessing(event kafka.Event) {
+	h.logger.Info("Processing event",
+		"event_id", event.EventID,
+		"event_type", event.EventType,
+		"aggregate_id", event.AggregateID,
+		"tenant", event.Tenant,
+	)
+}
+
+// recordOutcome counts how handling event ended and advances the watermark
+// past it unless it is left for a retry.
+func (h *EventHandler) recordOutcome(event kafka.Event, err error) {
+	h.stats.record(event.EventType, true, err, time.Now())
+	if err == nil || kafka.IsPermanent(err) {
+		h.advanceWatermark(event)
+	}
+}
+
+// writeError returns err, marked permanent when the backend rejected the
+// document itself: retrying would fail the same way and hold back every
+// later event on the partition, so the event is quarantined instead.
+func writeError(err error) error {
+	if errors.Is(err, port.ErrDocumentRejected) {
+		return kafka.Permanent(err)
+	}
+	return err
+}
+
+// advanceWatermark moves the watermark to event's created_at. Events
+// without a parseable created_at leave it alone.
+func (h *EventHandler) advanceWatermark(event kafka.Event) {
+	if at, err := time.Parse(time.RFC3339Nano, event.CreatedAt); err == nil {
+		h.watermark.Advance(at)
+	}
 }
 
 func (h *EventHandler) handleTutorUpsert(ctx context.Context, event kafka.Event) error {
-	var tutor domain.Tutor
-	if err := json.Unmarshal(event.Payload, &tutor); err != nil {
-		return fmt.Errorf("failed to unmarshal tutor payload: %w", err)
+	u, err := h.prepareUpsert(ctx, event)
+	if err != nil {
+		return err
+	}
+	if h.skipUnchanged(u) {
+		return nil
+	}
+	u.tutor.MarkIndexed(time.Now())
+	return h.finishUpsert(u, h.os.UpsertTutor(u.ctx, &u.tutor))
+}
+
+// upsert is a tutor write decoded from a TutorCreated or TutorUpdated event.
+type upsert struct {
+	// ctx carries the event's tenant.
+	ctx   context.Context
+	event kafka.Event
+	tutor domain.Tutor
+	// hash is the tutor's content hash once skipUnchanged has run.
+	hash string
+}
+
+// prepareUpsert decodes, sanitizes and validates the tutor of an upsert
+// event. Its errors are permanent.
+func (h *EventHandler) prepareUpsert(ctx context.Context, event kafka.Event) (*upsert, error) {
+	ctx, err := h.route(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	u := &upsert{ctx: ctx, event: event}
+	if err := json.Unmarshal(event.Payload, &u.tutor); err != nil {
+		return nil, kafka.Permanent(fmt.Errorf("failed to unmarshal tutor payload: %w", err))
+	}
+
+	h.checkAggregateID(event, u.tutor.ID)
+	h.recordEvent(ctx, event, u.tutor.ID)
+	h.warnUnknownFields(event, u.tutor.ID, tutorFields)
+
+	if err := h.sanitize(event, &u.tutor); err != nil {
+		return nil, kafka.Permanent(fmt.Errorf("invalid tutor %d: %w", u.tutor.ID, err))
 	}
+	if err := u.tutor.Validate(); err != nil {
+		return nil, kafka.Permanent(fmt.Errorf("invalid tutor %d: %w", u.tutor.ID, err))
+	}
+	return u, nil
+}
+
+// skipUnchanged reports whether u would write the content last written for
+// its tutor, counting and logging the skip.
+func (h *EventHandler) skipUnchanged(u *upsert) bool {
+	hash, unchanged := h.unchangedContent(u.ctx, u.event, &u.tutor)
+	u.hash = hash
+	if !unchanged {
+		return false
+	}
+	h.stats.recordUnchanged(func(s *UnchangedStats) { s.Skipped++ })
+	h.logger.Info("Tutor unchanged, skipping write",
+		"event_id", u.event.EventID,
+		"tutor_id", u.tutor.ID,
+		"event_type", u.event.EventType,
+	)
+	return true
+}
 
-	if err := h.os.UpsertTutor(ctx, &tutor); err != nil {
-		return fmt.Errorf("failed to upsert tutor %d: %w", tutor.ID, err)
+// finishUpsert records the outcome of writing u's tutor, which failed with
+// err if not nil, and returns the error to handle the event with.
+func (h *EventHandler) finishUpsert(u *upsert, err error) error {
+	if err != nil {
+		h.forgetContent(u.ctx, u.tutor.ID)
+		return writeError(fmt.Errorf("failed to upsert tutor %d: %w", u.tutor.ID, err))
 	}
+	h.rememberContent(u.ctx, u.tutor.ID, u.hash)
 
 	h.logger.Info("Tutor upserted successfully",
-		"event_id", event.EventID,
-		"tutor_id", tutor.ID,
-		"event_type", event.EventType,
+		"event_id", u.event.EventID,
+		"tutor_id", u.tutor.ID,
+		"event_type", u.event.EventType,
 	)
 
+	h.activity.Publish(activity.Event{
+		Type:    activity.TypeUpsert,
+		Source:  activity.SourceKafka,
+		TutorID: u.tutor.ID,
+		EventID: u.event.EventID,
+	})
+
 	return nil
 }
 
 func (h *EventHandler) handleTutorDelete(ctx context.Context, event kafka.Event) error {
+	ctx, err := h.route(ctx, event)
+	if err != nil {
+		return err
+	}
+
 	var payload struct {
 		ID int64 `json:"id"`
 	}
 	if err := json.Unmarshal(event.Payload, &payload); err != nil {
-		return fmt.Errorf("failed to unmarshal delete payload: %w", err)
+		return kafka.Permanent(fmt.Errorf("failed to unmarshal delete payload: %w", err))
 	}
 
 	if payload.ID <= 0 {
-		return fmt.Errorf("invalid tutor ID in delete payload: %d", payload.ID)
+		return kafka.Permanent(fmt.Errorf("invalid tutor ID in delete payload: %d", payload.ID))
 	}
 
-	if err := h.os.DeleteTutor(ctx, payload.ID); err != nil {
+	h.checkAggregateID(event, payload.ID)
+	h.recordEvent(ctx, event, payload.ID)
+	h.forgetContent(ctx, payload.ID)
+
+	err = h.os.DeleteTutor(ctx, payload.ID)
+	if errors.Is(err, port.ErrNotFound) {
+		// Deletes are idempotent: a redelivered or out-of-order event for a
+		// tutor that is already gone is not a failure.
+		h.logger.Info("Tutor already absent from index",
+			"event_id", event.EventID,
+			"tutor_id", payload.ID,
+		)
+		return nil
+	}
+	if err != nil {
 		return fmt.Errorf("failed to delete tutor %d: %w", payload.ID, err)
 	}
 
@@ -84,5 +355,116 @@ func (h *EventHandler) handleTutorDelete(ctx context.Context, event kafka.Event)
 		"tutor_id", payload.ID,
 	)
 
+	h.activity.Publish(activity.Event{
+		Type:    activity.TypeDelete,
+		Source:  activity.SourceKafka,
+		TutorID: payload.ID,
+		EventID: event.EventID,
+	})
+
 	return nil
 }
+
+// route attaches the tenant named by event to ctx. Events without a tenant
+// belong to the default one; an unknown tenant can never be indexed.
+func (h *EventHandler) route(ctx context.Context, event kafka.Event) (context.Context, error) {
+	t, ok := h.tenants.Lookup(event.Tenant)
+	if !ok {
+		return ctx, kafka.Permanent(fmt.Errorf("unknown tenant %q", event.Tenant))
+	}
+	return tenant.NewContext(ctx, t), nil
+}
+
+// LastEvent returns the newest event seen for the tenant's tutorID, by the
+// event's created_at.
+func (h *EventHandler) LastEvent(tenantName string, tutorID int64) (LastEvent, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	e, ok := h.lastEvents[eventKey{tenant: tenantName, id: tutorID}]
+	return e, ok
+}
+
+// recordEvent remembers event as the newest for tutorID unless a newer one
+// was already seen. Events without a parseable created_at are ignored.
+func (h *EventHandler) recordEvent(ctx context.Context, event kafka.Event, tutorID int64) {
+	at, err := time.Parse(time.RFC3339Nano, event.CreatedAt)
+	if err != nil {
+		return
+	}
+	t, _ := tenant.FromContext(ctx)
+	key := eventKey{tenant: t.Name, id: tutorID}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if prev, ok := h.lastEvents[key]; ok && !at.After(prev.At) {
+		return
+	}
+	h.lastEvents[key] = LastEvent{Type: event.EventType, At: at.UTC()}
+}
+
+// sanitize normalizes the tutor's rating, cleans its lists and avatar URL
+// and maps subjects to canonical keys, warning when a rating was zeroed, a
+// list had to be cut to the cap or the avatar was dropped. It returns a
+// *domain.ValidationError for a rating without reviews in strict mode.
+func (h *EventHandler) sanitize(event kafka.Event, tutor *domain.Tutor) error {
+	rating := tutor.Rating
+	zeroed, err := tutor.NormalizeRating(h.ratingMode)
+	if err != nil {
+		return err
+	}
+	if zeroed {
+		h.logger.Warn("Zeroed rating of tutor without reviews",
+			"event_id", event.EventID,
+			"tutor_id", tutor.ID,
+			"rating", rating,
+		)
+	}
+	raw := tutor.AvatarURL
+	if err := tutor.NormalizeAvatar(h.avatars); err != nil {
+		h.logger.Warn("Dropped invalid avatar URL",
+			"event_id", event.EventID,
+			"tutor_id", tutor.ID,
+			"avatar_url", raw,
+			"error", err,
+		)
+	}
+	if truncated := tutor.Sanitize(h.maxListItems); len(truncated) > 0 {
+		h.logger.Warn("Truncated oversized tutor lists",
+			"event_id", event.EventID,
+			"tutor_id", tutor.ID,
+			"fields", truncated,
+			"max_items", h.maxListItems,
+		)
+	}
+	if zeroed := tutor.NormalizeTimes(time.Now()); len(zeroed) > 0 {
+		h.logger.Warn("Zeroed implausible tutor timestamps",
+			"event_id", event.EventID,
+			"tutor_id", tutor.ID,
+			"fields", zeroed,
+		)
+	}
+	if scrubbed := tutor.Scrub(h.scrubber); len(scrubbed) > 0 {
+		h.logger.Info("Scrubbed tutor text",
+			"event_id", event.EventID,
+			"tutor_id", tutor.ID,
+			"fields", scrubbed,
+		)
+	}
+	tutor.CanonicalizeSubjects(h.subjects)
+	tutor.NormalizeRates()
+	return nil
+}
+
+// checkAggregateID warns when the event envelope names a different tutor
+// than its payload. The payload is trusted because it is what gets indexed.
+func (h *EventHandler) checkAggregateID(event kafka.Event, payloadID int64) {
+	if event.AggregateID == "" || event.AggregateID == strconv.FormatInt(payloadID, 10) {
+		return
+	}
+	h.logger.Warn("Aggregate ID does not match payload ID, using payload",
+		"event_id", event.EventID,
+		"event_type", event.EventType,
+		"aggregate_id", event.AggregateID,
+		"payload_id", payloadID,
+	)
+}
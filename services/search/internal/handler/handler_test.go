@@ -4,22 +4,35 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"log/slog"
 	"testing"
 	"time"
 
+	"search/internal/activity"
 	"search/internal/domain"
 	"search/internal/kafka"
-	"search/internal/opensearch"
+	"search/internal/port"
+	"search/internal/tenant"
+	"search/internal/testutil"
+	"search/internal/watermark"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-// mockSearchClient is a mock implementation of opensearch.SearchClient for testing.
+// mockSearchClient is a mock implementation of port.SearchClient for testing.
 type mockSearchClient struct {
 	upsertFunc func(ctx context.Context, tutor *domain.Tutor) error
+	// bulkFunc defaults to writing each tutor through upsertFunc.
+	bulkFunc   func(ctx context.Context, tutors []*domain.Tutor) (*port.BulkResult, error)
 	deleteFunc func(ctx context.Context, id int64) error
+	// getFunc defaults to finding no tutor.
+	getFunc    func(ctx context.Context, id int64) (*domain.Tutor, error)
+	slotFunc   func(ctx context.Context, booked bool, tutorID int64, slot time.Time) error
+	verifyFunc func(ctx context.Context, tutorID int64, verified bool, at time.Time) error
+	slugFunc   func(ctx context.Context, tutorID int64, oldSlug, newSlug string) error
+	trialFunc  func(ctx context.Context, tutorID int64, offers bool) error
+	// snapshotFunc defaults to applying every snapshot record.
+	snapshotFunc func(ctx context.Context, tutor *domain.Tutor, snapshotID string) (bool, error)
 }
 
 func (m *mockSearchClient) Ping(ctx context.Context) error {
@@ -37,6 +50,60 @@ func (m *mockSearchClient) UpsertTutor(ctx context.Context, tutor *domain.Tutor)
 	return nil
 }
 
+func (m *mockSearchClient) BulkUpsertTutors(ctx context.Context, tutors []*domain.Tutor) (*port.BulkResult, error) {
+	if m.bulkFunc != nil {
+		return m.bulkFunc(ctx, tutors)
+	}
+	result := &port.BulkResult{Items: make([]port.BulkItem, len(tutors))}
+	for i, tutor := range tutors {
+		result.Items[i] = port.BulkItem{ID: tutor.ID, Err: m.UpsertTutor(ctx, tutor)}
+	}
+	return result, nil
+}
+
+func (m *mockSearchClient) UpsertSnapshotTutor(ctx context.Context, tutor *domain.Tutor, snapshotID string) (bool, error) {
+	if m.snapshotFunc != nil {
+		return m.snapshotFunc(ctx, tutor, snapshotID)
+	}
+	return true, nil
+}
+
+func (m *mockSearchClient) BookSlot(ctx context.Context, tutorID int64, slot time.Time) error {
+	if m.slotFunc != nil {
+		return m.slotFunc(ctx, true, tutorID, slot)
+	}
+	return nil
+}
+
+func (m *mockSearchClient) FreeSlot(ctx context.Context, tutorID int64, slot time.Time) error {
+	if m.slotFunc != nil {
+		return m.slotFunc(ctx, false, tutorID, slot)
+	}
+	return nil
+}
+
+func (m *mockSearchClient) CheckQuality(ctx context.Context, rules []string, limit, offset int) ([]port.QualityRuleResult, error) {
+	return nil, nil
+}
+
+func (m *mockSearchClient) UpdateBadges(ctx context.Context, tutorID int64, add, remove []string) error {
+	return nil
+}
+
+func (m *mockSearchClient) SetVerified(ctx context.Context, tutorID int64, verified bool, at time.Time) error {
+	if m.verifyFunc != nil {
+		return m.verifyFunc(ctx, tutorID, verified, at)
+	}
+	return nil
+}
+
+func (m *mockSearchClient) SetOffersTrial(ctx context.Context, tutorID int64, offers bool) error {
+	if m.trialFunc != nil {
+		return m.trialFunc(ctx, tutorID, offers)
+	}
+	return nil
+}
+
 func (m *mockSearchClient) DeleteTutor(ctx context.Context, id int64) error {
 	if m.deleteFunc != nil {
 		return m.deleteFunc(ctx, id)
@@ -44,22 +111,121 @@ func (m *mockSearchClient) DeleteTutor(ctx context.Context, id int64) error {
 	return nil
 }
 
-func (m *mockSearchClient) SearchTutors(ctx context.Context, query opensearch.SearchQuery) (*opensearch.SearchResponse, error) {
-	return &opensearch.SearchResponse{Results: []domain.Tutor{}, Total: 0}, nil
+func (m *mockSearchClient) BulkDeleteTutors(ctx context.Context, ids []int64) ([]port.BulkDeleteResult, error) {
+	return nil, nil
+}
+
+func (m *mockSearchClient) GetTutor(ctx context.Context, id int64) (*domain.Tutor, error) {
+	if m.getFunc != nil {
+		return m.getFunc(ctx, id)
+	}
+	return nil, port.ErrNotFound
+}
+
+func (m *mockSearchClient) GetTutorBySlug(ctx context.Context, slug string) (*domain.Tutor, error) {
+	return nil, port.ErrNotFound
+}
+
+func (m *mockSearchClient) ChangeSlug(ctx context.Context, tutorID int64, oldSlug, newSlug string) error {
+	if m.slugFunc != nil {
+		return m.slugFunc(ctx, tutorID, oldSlug, newSlug)
+	}
+	return nil
+}
+
+func (m *mockSearchClient) GetTutors(ctx context.Context, ids []int64) (map[int64]domain.Tutor, error) {
+	return map[int64]domain.Tutor{}, nil
+}
+
+func (m *mockSearchClient) SearchTutors(ctx context.Context, query port.SearchQuery) (*port.SearchResponse, error) {
+	return &port.SearchResponse{Results: []domain.Tutor{}, Total: 0}, nil
+}
+
+func (m *mockSearchClient) SearchTutorsWithFacets(ctx context.Context, query port.SearchQuery, facetSize int) (*port.SearchResponse, error) {
+	return &port.SearchResponse{Results: []domain.Tutor{}, Total: 0, Facets: &port.FacetCounts{}}, nil
+}
+
+func (m *mockSearchClient) SearchTutorsMulti(ctx context.Context, queries []port.SearchQuery) ([]*port.SearchResponse, error) {
+	results := make([]*port.SearchResponse, len(queries))
+	for i := range queries {
+		results[i] = &port.SearchResponse{Results: []domain.Tutor{}}
+	}
+	return results, nil
+}
+
+func (m *mockSearchClient) ScanTutors(ctx context.Context, query port.SearchQuery, limit int, fn func(domain.Tutor) error) error {
+	return nil
+}
+
+func (m *mockSearchClient) TopTutorsBySubject(ctx context.Context, subjects []string, perSubject int) (map[string][]domain.Tutor, error) {
+	return map[string][]domain.Tutor{}, nil
+}
+
+func (m *mockSearchClient) RelatedSubjects(ctx context.Context, subject string, size int) (*port.RelatedSubjects, error) {
+	return &port.RelatedSubjects{}, nil
+}
+
+func (m *mockSearchClient) PriceStats(ctx context.Context, subject string, byFormat bool) (*port.PriceStats, error) {
+	return &port.PriceStats{}, nil
+}
+
+func (m *mockSearchClient) FacetCounts(ctx context.Context, size int) (*port.FacetCounts, error) {
+	return &port.FacetCounts{}, nil
+}
+
+func (m *mockSearchClient) QuickSearch(ctx context.Context, prefix string, size int) (*port.QuickSearchResult, error) {
+	return &port.QuickSearchResult{}, nil
+}
+
+func (m *mockSearchClient) RawSearch(ctx context.Context, body []byte) (json.RawMessage, error) {
+	return nil, nil
+}
+
+func (m *mockSearchClient) IndexedTutorIDs(ctx context.Context) ([]int64, error) {
+	return []int64{}, nil
+}
+
+func (m *mockSearchClient) ScanTutorVersions(ctx context.Context, fn func(port.TutorVersion) error) error {
+	return nil
+}
+
+func (m *mockSearchClient) CountTutors(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockSearchClient) RecreateIndex(ctx context.Context) (*port.RecreateResult, error) {
+	return &port.RecreateResult{}, nil
+}
+
+func (m *mockSearchClient) RecomputePopularity(ctx context.Context) (*port.PopularityResult, error) {
+	return &port.PopularityResult{}, nil
+}
+
+func (m *mockSearchClient) UpdateIndexSettings(ctx context.Context, replicas int) error {
+	return nil
+}
+
+func (m *mockSearchClient) CreateExperimentIndex(ctx context.Context, mapping port.ExperimentMapping) (*port.ExperimentIndex, error) {
+	return nil, port.ErrUnsupported
+}
+
+func (m *mockSearchClient) DeleteExperimentIndex(ctx context.Context) error {
+	return port.ErrUnsupported
+}
+
+func (m *mockSearchClient) IndexAliases(ctx context.Context) (*port.IndexAliases, error) {
+	return nil, port.ErrUnsupported
 }
 
-// Helper function to create a test logger that discards output.
-func newTestLogger() *slog.Logger {
-	return slog.New(slog.NewTextHandler(nil, &slog.HandlerOptions{
-		Level: slog.LevelError, // Only log errors to keep test output clean
-	}))
+func (m *mockSearchClient) PointIndexAliases(ctx context.Context, read, write string) (*port.IndexAliases, error) {
+	return nil, port.ErrUnsupported
 }
 
 func TestNew(t *testing.T) {
 	t.Parallel()
 
 	mockOS := &mockSearchClient{}
-	logger := newTestLogger()
+	logger := testutil.NewLogger(t)
 
 	handler := New(mockOS, logger)
 
@@ -79,38 +245,14 @@ func TestEventHandler_Handle_TutorCreated(t *testing.T) {
 		},
 	}
 
-	handler := New(mockOS, newTestLogger())
+	handler := New(mockOS, testutil.NewLogger(t))
 
-	tutor := domain.Tutor{
-		ID:           123,
-		Slug:         "john-doe",
-		FullName:     "John Doe",
-		Headline:     "Math Tutor",
-		Bio:          "Experienced math teacher",
-		Subjects:     []string{"math", "algebra"},
-		HourlyRate:   50.0,
-		Rating:       4.5,
-		ReviewsCount: 10,
-		IsVerified:   true,
-		Location:     "New York",
-		Formats:      []string{"online", "in-person"},
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
-	}
+	tutor := testutil.NewTutor(123)
+	tutor.FullName = "John Doe"
+	tutor.Subjects = []string{"math", "algebra"}
+	tutor.Formats = []string{"online", "in-person"}
 
-	payload, err := json.Marshal(tutor)
-	require.NoError(t, err)
-
-	event := kafka.Event{
-		EventID:       "event-123",
-		EventType:     "TutorCreated",
-		AggregateType: "Tutor",
-		AggregateID:   "123",
-		Payload:       payload,
-		CreatedAt:     time.Now().Format(time.RFC3339),
-	}
-
-	err = handler.Handle(context.Background(), event)
+	err := handler.Handle(context.Background(), testutil.TutorEvent(t, "TutorCreated", tutor))
 
 	assert.NoError(t, err)
 	assert.NotNil(t, capturedTutor)
@@ -130,33 +272,15 @@ func TestEventHandler_Handle_TutorUpdated(t *testing.T) {
 		},
 	}
 
-	handler := New(mockOS, newTestLogger())
+	handler := New(mockOS, testutil.NewLogger(t))
 
-	tutor := domain.Tutor{
-		ID:           456,
-		FullName:     "Jane Smith",
-		Headline:     "Physics Tutor",
-		Bio:          "PhD in Physics",
-		Subjects:     []string{"physics"},
-		HourlyRate:   75.0,
-		Rating:       4.8,
-		ReviewsCount: 25,
-		IsVerified:   true,
-	}
+	tutor := testutil.NewTutor(456)
+	tutor.FullName = "Jane Smith"
+	tutor.Headline = "Physics Tutor"
+	tutor.Subjects = []string{"physics"}
+	tutor.Rating = 4.8
 
-	payload, err := json.Marshal(tutor)
-	require.NoError(t, err)
-
-	event := kafka.Event{
-		EventID:       "event-456",
-		EventType:     "TutorUpdated",
-		AggregateType: "Tutor",
-		AggregateID:   "456",
-		Payload:       payload,
-		CreatedAt:     time.Now().Format(time.RFC3339),
-	}
-
-	err = handler.Handle(context.Background(), event)
+	err := handler.Handle(context.Background(), testutil.TutorEvent(t, "TutorUpdated", tutor))
 
 	assert.NoError(t, err)
 	assert.NotNil(t, capturedTutor)
@@ -176,21 +300,9 @@ func TestEventHandler_Handle_TutorDeleted(t *testing.T) {
 		},
 	}
 
-	handler := New(mockOS, newTestLogger())
+	handler := New(mockOS, testutil.NewLogger(t))
 
-	payload, err := json.Marshal(map[string]int64{"id": 789})
-	require.NoError(t, err)
-
-	event := kafka.Event{
-		EventID:       "event-789",
-		EventType:     "TutorDeleted",
-		AggregateType: "Tutor",
-		AggregateID:   "789",
-		Payload:       payload,
-		CreatedAt:     time.Now().Format(time.RFC3339),
-	}
-
-	err = handler.Handle(context.Background(), event)
+	err := handler.Handle(context.Background(), testutil.DeleteEvent(t, 789))
 
 	assert.NoError(t, err)
 	assert.Equal(t, int64(789), deletedID)
@@ -213,7 +325,7 @@ func TestEventHandler_Handle_UnknownEventType(t *testing.T) {
 		},
 	}
 
-	handler := New(mockOS, newTestLogger())
+	handler := New(mockOS, testutil.NewLogger(t))
 
 	event := kafka.Event{
 		EventID:       "event-999",
@@ -237,6 +349,7 @@ func TestEventHandler_Handle_TableDriven(t *testing.T) {
 	tests := []struct {
 		name         string
 		eventType    string
+		id           int64
 		payload      interface{}
 		expectUpsert bool
 		expectDelete bool
@@ -246,6 +359,7 @@ func TestEventHandler_Handle_TableDriven(t *testing.T) {
 		{
 			name:      "TutorCreated success",
 			eventType: "TutorCreated",
+			id:        1,
 			payload: domain.Tutor{
 				ID:       1,
 				FullName: "Test User",
@@ -257,6 +371,7 @@ func TestEventHandler_Handle_TableDriven(t *testing.T) {
 		{
 			name:      "TutorUpdated success",
 			eventType: "TutorUpdated",
+			id:        2,
 			payload: domain.Tutor{
 				ID:       2,
 				FullName: "Updated User",
@@ -268,6 +383,7 @@ func TestEventHandler_Handle_TableDriven(t *testing.T) {
 		{
 			name:      "TutorDeleted success",
 			eventType: "TutorDeleted",
+			id:        3,
 			payload: map[string]int64{
 				"id": 3,
 			},
@@ -278,6 +394,7 @@ func TestEventHandler_Handle_TableDriven(t *testing.T) {
 		{
 			name:         "Unknown event type",
 			eventType:    "TutorSuspended",
+			id:           4,
 			payload:      map[string]string{"test": "data"},
 			expectUpsert: false,
 			expectDelete: false,
@@ -303,21 +420,9 @@ func TestEventHandler_Handle_TableDriven(t *testing.T) {
 				},
 			}
 
-			handler := New(mockOS, newTestLogger())
-
-			payload, err := json.Marshal(tt.payload)
-			require.NoError(t, err)
-
-			event := kafka.Event{
-				EventID:       "event-test",
-				EventType:     tt.eventType,
-				AggregateType: "Tutor",
-				AggregateID:   "123",
-				Payload:       payload,
-				CreatedAt:     time.Now().Format(time.RFC3339),
-			}
+			handler := New(mockOS, testutil.NewLogger(t))
 
-			err = handler.Handle(context.Background(), event)
+			err := handler.Handle(context.Background(), testutil.NewEvent(t, tt.eventType, tt.id, tt.payload))
 
 			if tt.shouldError {
 				assert.Error(t, err)
@@ -369,7 +474,7 @@ func TestEventHandler_HandleTutorUpsert_InvalidPayload(t *testing.T) {
 			t.Parallel()
 
 			mockOS := &mockSearchClient{}
-			handler := New(mockOS, newTestLogger())
+			handler := New(mockOS, testutil.NewLogger(t))
 
 			event := kafka.Event{
 				EventID:       "event-invalid",
@@ -384,6 +489,7 @@ func TestEventHandler_HandleTutorUpsert_InvalidPayload(t *testing.T) {
 
 			assert.Error(t, err)
 			assert.Contains(t, err.Error(), tt.errorMsg)
+			assert.True(t, kafka.IsPermanent(err), "malformed payloads must not be retried")
 		})
 	}
 }
@@ -432,7 +538,7 @@ func TestEventHandler_HandleTutorDelete_InvalidPayload(t *testing.T) {
 					return nil
 				},
 			}
-			handler := New(mockOS, newTestLogger())
+			handler := New(mockOS, testutil.NewLogger(t))
 
 			event := kafka.Event{
 				EventID:       "event-invalid",
@@ -447,6 +553,172 @@ func TestEventHandler_HandleTutorDelete_InvalidPayload(t *testing.T) {
 
 			assert.Error(t, err)
 			assert.Contains(t, err.Error(), tt.errorMsg)
+			assert.True(t, kafka.IsPermanent(err), "malformed payloads must not be retried")
+		})
+	}
+}
+
+func TestEventHandler_InvalidTutor_IsPermanent(t *testing.T) {
+	t.Parallel()
+
+	upsertCalled := false
+	mockOS := &mockSearchClient{
+		upsertFunc: func(ctx context.Context, tutor *domain.Tutor) error {
+			upsertCalled = true
+			return nil
+		},
+	}
+
+	handler := New(mockOS, testutil.NewLogger(t))
+
+	event := kafka.Event{
+		EventID:       "event-invalid-tutor",
+		EventType:     "TutorUpdated",
+		AggregateType: "Tutor",
+		AggregateID:   "7",
+		Payload:       json.RawMessage(`{"id": 7, "rating": 12}`),
+		CreatedAt:     time.Now().Format(time.RFC3339),
+	}
+
+	err := handler.Handle(context.Background(), event)
+
+	require.Error(t, err)
+	assert.True(t, kafka.IsPermanent(err), "validation failures must not be retried")
+	var verr *domain.ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, "rating", verr.Violations[0].Field)
+	assert.False(t, upsertCalled)
+}
+
+func TestEventHandler_TutorWorkingHours(t *testing.T) {
+	t.Parallel()
+
+	var captured *domain.Tutor
+	mockOS := &mockSearchClient{
+		upsertFunc: func(ctx context.Context, tutor *domain.Tutor) error {
+			captured = tutor
+			return nil
+		},
+	}
+	handler := New(mockOS, testutil.NewLogger(t))
+
+	event := kafka.Event{
+		EventID:       "event-hours",
+		EventType:     "TutorUpdated",
+		AggregateType: "Tutor",
+		AggregateID:   "7",
+		Payload:       json.RawMessage(`{"id": 7, "timezone": "Europe/Berlin", "working_hours": [{"start": "17:00", "end": "21:00"}]}`),
+		CreatedAt:     time.Now().Format(time.RFC3339),
+	}
+	require.NoError(t, handler.Handle(context.Background(), event))
+	require.NotNil(t, captured)
+	assert.Equal(t, "Europe/Berlin", captured.Timezone)
+	assert.Equal(t, []domain.WorkingHours{{Start: "17:00", End: "21:00"}}, captured.WorkingHours)
+
+	event.Payload = json.RawMessage(`{"id": 7, "timezone": "Berlin", "working_hours": [{"start": "17:00", "end": "21:00"}]}`)
+	err := handler.Handle(context.Background(), event)
+	require.Error(t, err)
+	assert.True(t, kafka.IsPermanent(err), "an unknown time zone must not be retried")
+}
+
+func TestEventHandler_RatingConsistency(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		mode          string
+		payload       string
+		wantRating    float64
+		wantPermanent bool
+	}{
+		{"rounds to two decimals", domain.RatingModeStrict, `{"id": 7, "rating": 4.876, "reviews_count": 3}`, 4.88, false},
+		{"lenient zeroes rating without reviews", domain.RatingModeLenient, `{"id": 7, "rating": 4.8, "reviews_count": 0}`, 0, false},
+		{"strict rejects rating without reviews", domain.RatingModeStrict, `{"id": 7, "rating": 4.8, "reviews_count": 0}`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var captured *domain.Tutor
+			mockOS := &mockSearchClient{
+				upsertFunc: func(ctx context.Context, tutor *domain.Tutor) error {
+					captured = tutor
+					return nil
+				},
+			}
+			handler := New(mockOS, testutil.NewLogger(t), WithRatingMode(tt.mode))
+
+			err := handler.Handle(context.Background(), kafka.Event{
+				EventID:   "event-rating",
+				EventType: "TutorUpdated",
+				Payload:   json.RawMessage(tt.payload),
+				CreatedAt: time.Now().Format(time.RFC3339),
+			})
+
+			if tt.wantPermanent {
+				require.Error(t, err)
+				assert.True(t, kafka.IsPermanent(err), "an inconsistent rating will not fix itself on retry")
+				var verr *domain.ValidationError
+				require.ErrorAs(t, err, &verr)
+				assert.Equal(t, domain.CodeInconsistent, verr.Violations[0].Code)
+				assert.Nil(t, captured)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, captured)
+			assert.Equal(t, tt.wantRating, captured.Rating)
+		})
+	}
+}
+
+func TestEventHandler_AggregateIDMismatch_TrustsPayload(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		eventType   string
+		aggregateID string
+		wantWarning bool
+	}{
+		{"upsert ids match", "TutorUpdated", "42", false},
+		{"upsert aggregate omitted", "TutorUpdated", "", false},
+		{"upsert ids differ", "TutorUpdated", "43", true},
+		{"delete ids match", "TutorDeleted", "42", false},
+		{"delete aggregate omitted", "TutorDeleted", "", false},
+		{"delete ids differ", "TutorDeleted", "43", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var upsertedID, deletedID int64
+			mockOS := &mockSearchClient{
+				upsertFunc: func(ctx context.Context, tutor *domain.Tutor) error {
+					upsertedID = tutor.ID
+					return nil
+				},
+				deleteFunc: func(ctx context.Context, id int64) error {
+					deletedID = id
+					return nil
+				},
+			}
+
+			logger, logs := testutil.NewCapturingLogger()
+			handler := New(mockOS, logger)
+
+			event := kafka.Event{
+				EventID:     "event-mismatch",
+				EventType:   tt.eventType,
+				AggregateID: tt.aggregateID,
+				Payload:     json.RawMessage(`{"id": 42}`),
+			}
+
+			require.NoError(t, handler.Handle(context.Background(), event))
+
+			assert.Equal(t, int64(42), upsertedID+deletedID, "payload ID must be used")
+			assert.Equal(t, tt.wantWarning, len(logs.Find("Aggregate ID does not match payload ID, using payload")) == 1)
 		})
 	}
 }
@@ -462,7 +734,7 @@ func TestEventHandler_UpsertError_PropagatesError(t *testing.T) {
 		},
 	}
 
-	handler := New(mockOS, newTestLogger())
+	handler := New(mockOS, testutil.NewLogger(t))
 
 	tutor := domain.Tutor{ID: 100, FullName: "Test"}
 	payload, _ := json.Marshal(tutor)
@@ -494,7 +766,7 @@ func TestEventHandler_DeleteError_PropagatesError(t *testing.T) {
 		},
 	}
 
-	handler := New(mockOS, newTestLogger())
+	handler := New(mockOS, testutil.NewLogger(t))
 
 	payload, _ := json.Marshal(map[string]int64{"id": 200})
 
@@ -514,6 +786,29 @@ func TestEventHandler_DeleteError_PropagatesError(t *testing.T) {
 	assert.ErrorIs(t, err, expectedErr)
 }
 
+func TestEventHandler_DeleteNotFound_IsIdempotent(t *testing.T) {
+	t.Parallel()
+
+	mockOS := &mockSearchClient{
+		deleteFunc: func(ctx context.Context, id int64) error {
+			return port.ErrNotFound
+		},
+	}
+
+	handler := New(mockOS, testutil.NewLogger(t))
+
+	event := kafka.Event{
+		EventID:       "event-gone",
+		EventType:     "TutorDeleted",
+		AggregateType: "Tutor",
+		AggregateID:   "200",
+		Payload:       json.RawMessage(`{"id": 200}`),
+		CreatedAt:     time.Now().Format(time.RFC3339),
+	}
+
+	assert.NoError(t, handler.Handle(context.Background(), event))
+}
+
 func TestEventHandler_ContextCancellation(t *testing.T) {
 	t.Parallel()
 
@@ -530,7 +825,7 @@ func TestEventHandler_ContextCancellation(t *testing.T) {
 		},
 	}
 
-	handler := New(mockOS, newTestLogger())
+	handler := New(mockOS, testutil.NewLogger(t))
 
 	tutor := domain.Tutor{ID: 300}
 	payload, _ := json.Marshal(tutor)
@@ -690,7 +985,7 @@ func TestEventHandler_AllEventTypes_TableDriven(t *testing.T) {
 			t.Parallel()
 
 			mockOS := tt.setupMock()
-			handler := New(mockOS, newTestLogger())
+			handler := New(mockOS, testutil.NewLogger(t))
 			event := tt.createEvent()
 
 			err := handler.Handle(context.Background(), event)
@@ -718,7 +1013,7 @@ func TestEventHandler_ComplexPayloadStructures(t *testing.T) {
 			},
 		}
 
-		handler := New(mockOS, newTestLogger())
+		handler := New(mockOS, testutil.NewLogger(t))
 
 		tutor := domain.Tutor{
 			ID:           999,
@@ -771,7 +1066,7 @@ func TestEventHandler_ComplexPayloadStructures(t *testing.T) {
 			},
 		}
 
-		handler := New(mockOS, newTestLogger())
+		handler := New(mockOS, testutil.NewLogger(t))
 
 		tutor := domain.Tutor{
 			ID:       1,
@@ -807,7 +1102,7 @@ func TestEventHandler_ComplexPayloadStructures(t *testing.T) {
 			},
 		}
 
-		handler := New(mockOS, newTestLogger())
+		handler := New(mockOS, testutil.NewLogger(t))
 
 		tutor := domain.Tutor{
 			ID:       2,
@@ -834,3 +1129,336 @@ func TestEventHandler_ComplexPayloadStructures(t *testing.T) {
 		assert.Empty(t, capturedTutor.Formats)
 	})
 }
+
+func TestEventHandler_PublishesActivity(t *testing.T) {
+	t.Parallel()
+
+	hub := activity.NewHub(8)
+	sub := hub.Subscribe()
+	defer sub.Close()
+
+	handler := New(&mockSearchClient{}, testutil.NewLogger(t), WithActivityHub(hub))
+
+	upsert := kafka.Event{EventID: "e-1", EventType: "TutorCreated", Payload: json.RawMessage(`{"id": 5}`)}
+	require.NoError(t, handler.Handle(context.Background(), upsert))
+
+	del := kafka.Event{EventID: "e-2", EventType: "TutorDeleted", Payload: json.RawMessage(`{"id": 5}`)}
+	require.NoError(t, handler.Handle(context.Background(), del))
+
+	unknown := kafka.Event{EventID: "e-3", EventType: "Unknown"}
+	require.NoError(t, handler.Handle(context.Background(), unknown))
+
+	first := <-sub.Events()
+	assert.Equal(t, activity.TypeUpsert, first.Type)
+	assert.Equal(t, activity.SourceKafka, first.Source)
+	assert.Equal(t, int64(5), first.TutorID)
+	assert.Equal(t, "e-1", first.EventID)
+
+	second := <-sub.Events()
+	assert.Equal(t, activity.TypeDelete, second.Type)
+	assert.Equal(t, "e-2", second.EventID)
+
+	select {
+	case e := <-sub.Events():
+		t.Errorf("unexpected event for unknown type: %+v", e)
+	default:
+	}
+}
+
+func TestEventHandler_StampsIndexedAt(t *testing.T) {
+	t.Parallel()
+
+	var captured *domain.Tutor
+	handler := New(&mockSearchClient{
+		upsertFunc: func(ctx context.Context, tutor *domain.Tutor) error {
+			captured = tutor
+			return nil
+		},
+	}, testutil.NewLogger(t))
+
+	before := time.Now().Truncate(domain.TimePrecision)
+	event := kafka.Event{EventID: "e-1", EventType: "TutorUpdated", Payload: json.RawMessage(`{"id": 5}`)}
+	require.NoError(t, handler.Handle(context.Background(), event))
+
+	require.NotNil(t, captured.IndexedAt)
+	assert.False(t, captured.IndexedAt.Before(before))
+	assert.Equal(t, time.UTC, captured.IndexedAt.Location())
+}
+
+func TestEventHandler_NormalizesTimestamps(t *testing.T) {
+	t.Parallel()
+
+	var captured *domain.Tutor
+	handler := New(&mockSearchClient{
+		upsertFunc: func(ctx context.Context, tutor *domain.Tutor) error {
+			captured = tutor
+			return nil
+		},
+	}, testutil.NewLogger(t))
+
+	payload := `{"id": 5, "created_at": "2024-05-01T15:00:00.123456+02:00", "updated_at": "1970-01-01T00:00:00Z"}`
+	event := kafka.Event{EventID: "e-1", EventType: "TutorUpdated", Payload: json.RawMessage(payload)}
+	require.NoError(t, handler.Handle(context.Background(), event))
+
+	assert.Equal(t, time.Date(2024, time.May, 1, 13, 0, 0, 123_000_000, time.UTC), captured.CreatedAt)
+	assert.True(t, captured.UpdatedAt.IsZero(), "a 1970 timestamp is implausible and zeroed")
+}
+
+func TestEventHandler_LastEvent_KeepsNewest(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&mockSearchClient{}, testutil.NewLogger(t))
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	events := []kafka.Event{
+		{EventID: "e-1", EventType: "TutorCreated", Payload: json.RawMessage(`{"id": 5}`), CreatedAt: t0.Format(time.RFC3339Nano)},
+		{EventID: "e-3", EventType: "TutorDeleted", Payload: json.RawMessage(`{"id": 5}`), CreatedAt: t0.Add(2 * time.Second).Format(time.RFC3339Nano)},
+		// Redelivered out of order; must not replace the newer delete.
+		{EventID: "e-2", EventType: "TutorUpdated", Payload: json.RawMessage(`{"id": 5}`), CreatedAt: t0.Add(time.Second).Format(time.RFC3339Nano)},
+		// Unparseable timestamps are ignored.
+		{EventID: "e-4", EventType: "TutorUpdated", Payload: json.RawMessage(`{"id": 5}`), CreatedAt: "yesterday"},
+	}
+	for _, e := range events {
+		require.NoError(t, handler.Handle(context.Background(), e))
+	}
+
+	last, ok := handler.LastEvent(tenant.DefaultName, 5)
+	require.True(t, ok)
+	assert.Equal(t, "TutorDeleted", last.Type)
+	assert.True(t, last.At.Equal(t0.Add(2*time.Second)))
+
+	_, ok = handler.LastEvent(tenant.DefaultName, 6)
+	assert.False(t, ok)
+}
+
+func TestEventHandler_RoutesByTenant(t *testing.T) {
+	t.Parallel()
+
+	tenants, err := tenant.NewRegistry([]tenant.Tenant{{Name: "us", Index: "tutors-us"}, {Name: "de", Index: "tutors-de"}}, "us")
+	require.NoError(t, err)
+
+	var indices []string
+	handler := New(&mockSearchClient{
+		upsertFunc: func(ctx context.Context, tutor *domain.Tutor) error {
+			indices = append(indices, port.IndexFor(ctx))
+			return nil
+		},
+		deleteFunc: func(ctx context.Context, id int64) error {
+			indices = append(indices, port.IndexFor(ctx))
+			return nil
+		},
+	}, testutil.NewLogger(t), WithTenants(tenants))
+
+	events := []kafka.Event{
+		{EventID: "e-1", EventType: "TutorCreated", Payload: json.RawMessage(`{"id": 5}`), Tenant: "de"},
+		{EventID: "e-2", EventType: "TutorUpdated", Payload: json.RawMessage(`{"id": 5}`)},
+		{EventID: "e-3", EventType: "TutorDeleted", Payload: json.RawMessage(`{"id": 5}`), Tenant: "us"},
+	}
+	for _, e := range events {
+		require.NoError(t, handler.Handle(context.Background(), e))
+	}
+
+	assert.Equal(t, []string{"tutors-de", "tutors-us", "tutors-us"}, indices)
+}
+
+func TestEventHandler_UnknownTenant_IsPermanent(t *testing.T) {
+	t.Parallel()
+
+	called := false
+	handler := New(&mockSearchClient{
+		upsertFunc: func(ctx context.Context, tutor *domain.Tutor) error {
+			called = true
+			return nil
+		},
+	}, testutil.NewLogger(t))
+
+	event := kafka.Event{EventID: "e-1", EventType: "TutorCreated", Payload: json.RawMessage(`{"id": 5}`), Tenant: "fr"}
+	err := handler.Handle(context.Background(), event)
+
+	assert.True(t, kafka.IsPermanent(err))
+	assert.False(t, called)
+}
+
+func TestEventHandler_LastEvent_PerTenant(t *testing.T) {
+	t.Parallel()
+
+	tenants, err := tenant.NewRegistry([]tenant.Tenant{{Name: "us", Index: "tutors-us"}, {Name: "de", Index: "tutors-de"}}, "")
+	require.NoError(t, err)
+	handler := New(&mockSearchClient{}, testutil.NewLogger(t), WithTenants(tenants))
+
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC).Format(time.RFC3339Nano)
+	event := kafka.Event{EventID: "e-1", EventType: "TutorCreated", Payload: json.RawMessage(`{"id": 5}`), CreatedAt: created, Tenant: "de"}
+	require.NoError(t, handler.Handle(context.Background(), event))
+
+	_, ok := handler.LastEvent("de", 5)
+	assert.True(t, ok)
+	_, ok = handler.LastEvent("us", 5)
+	assert.False(t, ok)
+}
+
+func TestEventHandler_TutorUpsert_SanitizesLists(t *testing.T) {
+	t.Parallel()
+
+	var captured *domain.Tutor
+	handler := New(&mockSearchClient{
+		upsertFunc: func(ctx context.Context, tutor *domain.Tutor) error {
+			captured = tutor
+			return nil
+		},
+	}, testutil.NewLogger(t), WithMaxListItems(3))
+
+	subjects := make([]string, 0, 4000)
+	for i := range 4000 {
+		subjects = append(subjects, []string{"Math", "math ", "", "Physics", "art", "music"}[i%6])
+	}
+	payload, err := json.Marshal(domain.Tutor{ID: 9, FullName: "Dup Tutor", Subjects: subjects, Formats: []string{"online", " ONLINE"}})
+	require.NoError(t, err)
+
+	err = handler.Handle(context.Background(), kafka.Event{EventID: "e-1", EventType: "TutorUpdated", Payload: payload})
+
+	require.NoError(t, err, "empty entries are dropped rather than rejected")
+	require.NotNil(t, captured)
+	assert.Equal(t, []string{"math", "physics", "art"}, captured.Subjects)
+	assert.Equal(t, []string{"Mathematics", "Physics", "Art"}, captured.SubjectsDisplay)
+	assert.Equal(t, []string{"online"}, captured.Formats)
+}
+
+func TestEventHandler_TutorUpsert_ScrubsText(t *testing.T) {
+	t.Parallel()
+
+	var captured *domain.Tutor
+	scrubber, err := domain.NewPatternScrubber("")
+	require.NoError(t, err)
+	handler := New(&mockSearchClient{
+		upsertFunc: func(ctx context.Context, tutor *domain.Tutor) error {
+			captured = tutor
+			return nil
+		},
+	}, testutil.NewLogger(t), WithScrubber(scrubber))
+
+	payload, err := json.Marshal(domain.Tutor{
+		ID:       9,
+		FullName: "Chatty Tutor",
+		Headline: "Maths, call +1 555 123 4567",
+		Bio:      "Book at https://tutor.example.com or mail me@tutor.example.com",
+	})
+	require.NoError(t, err)
+
+	err = handler.Handle(context.Background(), kafka.Event{EventID: "e-1", EventType: "TutorUpdated", Payload: payload})
+
+	require.NoError(t, err)
+	require.NotNil(t, captured)
+	assert.Equal(t, "Maths, call [removed]", captured.Headline)
+	assert.Equal(t, "Book at [removed] or mail [removed]", captured.Bio)
+}
+
+func TestEventHandler_TutorUpsert_NormalizesAvatar(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		avatar string
+		want   string
+	}{
+		{"resolved against the CDN", "/media/a.jpg", "https://cdn.example.com/media/a.jpg"},
+		{"tracking stripped", "https://img.example.com/a.jpg?utm_source=x", "https://img.example.com/a.jpg"},
+		{"invalid blanked", "javascript:alert(1)", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var captured *domain.Tutor
+			handler := New(&mockSearchClient{
+				upsertFunc: func(ctx context.Context, tutor *domain.Tutor) error {
+					captured = tutor
+					return nil
+				},
+			}, testutil.NewLogger(t), WithAvatarPolicy(domain.AvatarPolicy{
+				CDNBase:     "https://cdn.example.com",
+				StripParams: domain.DefaultAvatarStripParams,
+			}))
+
+			payload, err := json.Marshal(domain.Tutor{ID: 9, FullName: "Avatar Tutor", AvatarURL: tt.avatar})
+			require.NoError(t, err)
+
+			err = handler.Handle(context.Background(), kafka.Event{EventID: "e-1", EventType: "TutorUpdated", Payload: payload})
+
+			require.NoError(t, err, "a bad avatar must not fail the event")
+			require.NotNil(t, captured)
+			assert.Equal(t, tt.want, captured.AvatarURL)
+		})
+	}
+}
+
+func TestEventHandler_AdvancesWatermark(t *testing.T) {
+	t.Parallel()
+
+	failing := errors.New("cluster unavailable")
+	var upsertErr error
+	wm, err := watermark.New()
+	require.NoError(t, err)
+	handler := New(&mockSearchClient{
+		upsertFunc: func(ctx context.Context, tutor *domain.Tutor) error {
+			return upsertErr
+		},
+	}, testutil.NewLogger(t), WithWatermark(wm))
+
+	base := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	event := func(eventType string, offset time.Duration, payload string) kafka.Event {
+		return kafka.Event{
+			EventID:   "e-" + offset.String(),
+			EventType: eventType,
+			CreatedAt: base.Add(offset).Format(time.RFC3339Nano),
+			Payload:   json.RawMessage(payload),
+		}
+	}
+	current := func() time.Time {
+		at, ok := wm.Watermark()
+		require.True(t, ok)
+		return at
+	}
+
+	require.NoError(t, handler.Handle(context.Background(), event("TutorUpdated", 0, `{"id": 1, "full_name": "Ada"}`)))
+	assert.Equal(t, base, current())
+
+	require.NoError(t, handler.Handle(context.Background(), event("TutorUpdated", -time.Minute, `{"id": 1, "full_name": "Ada"}`)))
+	assert.Equal(t, base, current(), "an out-of-order event must not move it back")
+
+	require.NoError(t, handler.Handle(context.Background(), event("TutorArchived", time.Second, `{}`)))
+	assert.Equal(t, base.Add(time.Second), current(), "skipped unknown events are processed")
+
+	err = handler.Handle(context.Background(), event("TutorUpdated", 2*time.Second, `not json`))
+	require.True(t, kafka.IsPermanent(err))
+	assert.Equal(t, base.Add(2*time.Second), current(), "permanently failed events are not retried")
+
+	upsertErr = failing
+	err = handler.Handle(context.Background(), event("TutorUpdated", 3*time.Second, `{"id": 1, "full_name": "Ada"}`))
+	require.ErrorIs(t, err, failing)
+	assert.Equal(t, base.Add(2*time.Second), current(), "an event left for a retry does not move it")
+}
+
+func TestEventHandler_Upsert_HourlyRateIsLowestFormatRate(t *testing.T) {
+	t.Parallel()
+
+	var indexed *domain.Tutor
+	handler := New(&mockSearchClient{
+		upsertFunc: func(ctx context.Context, tutor *domain.Tutor) error {
+			indexed = tutor
+			return nil
+		},
+	}, testutil.NewLogger(t))
+
+	tutor := testutil.NewTutor(7)
+	tutor.Formats = []string{domain.FormatOnline, domain.FormatOffline}
+	tutor.HourlyRate = 90
+	tutor.OnlineRate = 40
+	tutor.OfflineRate = 65
+	require.NoError(t, handler.Handle(context.Background(), testutil.TutorEvent(t, "TutorUpdated", tutor)))
+
+	require.NotNil(t, indexed)
+	assert.Equal(t, 40.0, indexed.HourlyRate, "hourly_rate must be the cheapest lesson")
+	assert.Equal(t, 40.0, indexed.OnlineRate)
+	assert.Equal(t, 65.0, indexed.OfflineRate)
+}
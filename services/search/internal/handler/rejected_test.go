@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	segkafka "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/domain"
+	"search/internal/kafka"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+// sliceReader feeds a fixed list of messages to a kafka.Consumer and
+// records the offsets it commits.
+type sliceReader struct {
+	mu        sync.Mutex
+	messages  []segkafka.Message
+	next      int
+	committed []int64
+}
+
+func (r *sliceReader) FetchMessage(ctx context.Context) (segkafka.Message, error) {
+	r.mu.Lock()
+	if r.next < len(r.messages) {
+		msg := r.messages[r.next]
+		r.next++
+		r.mu.Unlock()
+		return msg, nil
+	}
+	r.mu.Unlock()
+	<-ctx.Done()
+	return segkafka.Message{}, ctx.Err()
+}
+
+func (r *sliceReader) CommitMessages(_ context.Context, msgs ...segkafka.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, msg := range msgs {
+		r.committed = append(r.committed, msg.Offset)
+	}
+	return nil
+}
+
+func (r *sliceReader) getCommitted() []int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int64{}, r.committed...)
+}
+
+func (r *sliceReader) Close() error { return nil }
+
+func (r *sliceReader) Config() segkafka.ReaderConfig {
+	return segkafka.ReaderConfig{Topic: "tutor-events", GroupID: "search"}
+}
+
+// mappingRejection is what the OpenSearch client returns for a document
+// that does not fit the index mapping.
+func mappingRejection(id int64) error {
+	return fmt.Errorf("failed to index tutor: %w: mapper_parsing_exception: failed to parse field [hourly_rate] of tutor %d",
+		port.ErrDocumentRejected, id)
+}
+
+func TestEventHandler_RejectedDocument_IsPermanent(t *testing.T) {
+	mockOS := &mockSearchClient{
+		upsertFunc: func(ctx context.Context, tutor *domain.Tutor) error {
+			return mappingRejection(tutor.ID)
+		},
+	}
+	handler := New(mockOS, testutil.NewLogger(t))
+	payload, _ := json.Marshal(domain.Tutor{ID: 100, FullName: "Test"})
+
+	err := handler.Handle(context.Background(), kafka.Event{
+		EventID:     "event-rejected",
+		EventType:   "TutorUpdated",
+		AggregateID: "100",
+		Payload:     payload,
+		CreatedAt:   time.Now().Format(time.RFC3339),
+	})
+
+	require.Error(t, err)
+	assert.True(t, kafka.IsPermanent(err), "a rejected document would be rejected again")
+	assert.ErrorIs(t, err, port.ErrDocumentRejected)
+}
+
+func TestConsumer_RejectedDocumentsDoNotHoldBackOthers(t *testing.T) {
+	var mu sync.Mutex
+	var indexed []int64
+	mockOS := &mockSearchClient{
+		upsertFunc: func(ctx context.Context, tutor *domain.Tutor) error {
+			if tutor.ID == 2 || tutor.ID == 5 {
+				return mappingRejection(tutor.ID)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			indexed = append(indexed, tutor.ID)
+			return nil
+		},
+	}
+
+	reader := &sliceReader{}
+	for id := int64(1); id <= 6; id++ {
+		payload, _ := json.Marshal(domain.Tutor{ID: id, FullName: fmt.Sprintf("Tutor %d", id)})
+		value, _ := json.Marshal(kafka.Event{
+			EventID:     fmt.Sprintf("event-%d", id),
+			EventType:   "TutorUpdated",
+			AggregateID: fmt.Sprint(id),
+			Payload:     payload,
+			CreatedAt:   time.Now().Format(time.RFC3339),
+		})
+		reader.messages = append(reader.messages, segkafka.Message{Topic: "tutor-events", Offset: 100 + id - 1, Value: value})
+	}
+
+	var quarantined []string
+	consumer := kafka.NewConsumerWithReader(reader, New(mockOS, testutil.NewLogger(t)), testutil.NewLogger(t),
+		// A transient classification would retry the first rejection forever.
+		kafka.WithRetryBackoff(time.Hour, time.Hour),
+		kafka.WithErrorHook(func(event *kafka.Event, err error) {
+			if event != nil && kafka.IsPermanent(err) {
+				quarantined = append(quarantined, event.EventID)
+			}
+		}),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- consumer.Start(ctx) }()
+
+	require.Eventually(t, func() bool { return len(reader.getCommitted()) == 6 }, time.Second, time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+
+	assert.Equal(t, []int64{100, 101, 102, 103, 104, 105}, reader.getCommitted(), "every offset is committed, in order")
+	assert.Equal(t, []int64{1, 3, 4, 6}, indexed)
+	assert.Equal(t, []string{"event-2", "event-5"}, quarantined)
+}
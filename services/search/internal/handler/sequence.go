@@ -0,0 +1,276 @@
+package handler
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"search/internal/kafka"
+)
+
+// Defaults for WithStrictSequence.
+const (
+	DefaultSequenceBufferSize = 100
+	DefaultSequenceTimeout    = 5 * time.Second
+)
+
+// fullStateEvents fix a tutor's whole document, so an older event of one of
+// these types has nothing left to add once a newer one is applied.
+var fullStateEvents = map[string]bool{
+	"TutorCreated": true,
+	"TutorUpdated": true,
+	"TutorDeleted": true,
+}
+
+// WithStrictSequence holds back events that arrive out of order. An event
+// whose created_at is older than the last event applied for its aggregate,
+// and of a different type, is stashed instead of handled: typically a
+// TutorCreated arriving after an update it preceded. Stashed events are
+// retried after the next event handled for their aggregate, or once they
+// have waited timeout. A TutorCreated, TutorUpdated or TutorDeleted that is
+// older than one of those already applied is dropped rather than retried,
+// since it would overwrite newer state.
+//
+// At most size events are stashed at once; an out-of-order event that finds
+// the buffer full is handled straight away. Zero size or timeout keeps the
+// default. Stashed events are acknowledged to Kafka, so a restart loses
+// them, and all events are handled one at a time while this is on.
+func WithStrictSequence(size int, timeout time.Duration) Option {
+	return func(h *EventHandler) {
+		if size <= 0 {
+			size = DefaultSequenceBufferSize
+		}
+		if timeout <= 0 {
+			timeout = DefaultSequenceTimeout
+		}
+		h.sequence = &sequencer{
+			size:    size,
+			timeout: timeout,
+			now:     time.Now,
+			applied: make(map[sequenceKey]appliedMark),
+		}
+	}
+}
+
+// sequencer tracks the newest event applied per aggregate and the events
+// stashed because they arrived behind it.
+type sequencer struct {
+	size    int
+	timeout time.Duration
+	now     func() time.Time
+
+	// run serializes handling, so a retry never races a live event.
+	run     sync.Mutex
+	applied map[sequenceKey]appliedMark
+	pending []pendingEvent
+}
+
+// sequenceKey identifies an aggregate across tenants.
+type sequenceKey struct {
+	tenant        string
+	aggregateType string
+	aggregateID   string
+}
+
+// appliedMark is the newest event applied for an aggregate, and the newest
+// full-state one.
+type appliedMark struct {
+	eventType string
+	at        time.Time
+	fullAt    time.Time
+}
+
+type pendingEvent struct {
+	event     kafka.Event
+	key       sequenceKey
+	at        time.Time
+	stashedAt time.Time
+}
+
+// sequenceOf returns the aggregate and created_at of event. Events without
+// an aggregate ID or a parseable created_at are never reordered.
+func sequenceOf(event kafka.Event) (sequenceKey, time.Time, bool) {
+	key, ok := aggregateOf(event)
+	if !ok {
+		return sequenceKey{}, time.Time{}, false
+	}
+	at, err := time.Parse(time.RFC3339Nano, event.CreatedAt)
+	if err != nil {
+		return sequenceKey{}, time.Time{}, false
+	}
+	return key, at, true
+}
+
+// aggregateOf returns the aggregate event belongs to, if it names one.
+func aggregateOf(event kafka.Event) (sequenceKey, bool) {
+	if event.AggregateID == "" {
+		return sequenceKey{}, false
+	}
+	return sequenceKey{tenant: event.Tenant, aggregateType: event.AggregateType, aggregateID: event.AggregateID}, true
+}
+
+// handleInSequence handles event unless it arrived out of order, then
+// retries whatever was stashed for its aggregate or has waited too long.
+func (h *EventHandler) handleInSequence(ctx context.Context, event kafka.Event) error {
+	s := h.sequence
+	s.run.Lock()
+	defer s.run.Unlock()
+
+	h.retryExpired(ctx)
+
+	key, at, ok := sequenceOf(event)
+	if !ok {
+		return h.dispatch(ctx, event)
+	}
+	if h.stash(event, key, at) {
+		return nil
+	}
+	err := h.dispatch(ctx, event)
+	if err == nil {
+		s.markApplied(key, event.EventType, at)
+	}
+	if err == nil || kafka.IsPermanent(err) {
+		h.retryPending(ctx, func(p pendingEvent) bool { return p.key == key })
+	}
+	return err
+}
+
+// stash holds event back if it is older than the last event applied for its
+// aggregate and of a different type, reporting whether it did.
+func (h *EventHandler) stash(event kafka.Event, key sequenceKey, at time.Time) bool {
+	s := h.sequence
+	last, ok := s.applied[key]
+	if !ok || !at.Before(last.at) || event.EventType == last.eventType {
+		return false
+	}
+
+	if len(s.pending) >= s.size {
+		h.stats.recordSequence(func(q *SequenceStats) { q.Reordered++ })
+		h.logger.Warn("Sequence buffer full, handling out-of-order event now",
+			"event_id", event.EventID,
+			"event_type", event.EventType,
+			"aggregate_id", event.AggregateID,
+			"buffer_size", s.size,
+		)
+		return false
+	}
+	s.pending = append(s.pending, pendingEvent{event: event, key: key, at: at, stashedAt: s.now()})
+	h.stats.recordSequence(func(q *SequenceStats) {
+		q.Reordered++
+		q.Stashed++
+		q.Pending++
+	})
+	h.logger.Info("Stashed out-of-order event",
+		"event_id", event.EventID,
+		"event_type", event.EventType,
+		"aggregate_id", event.AggregateID,
+		"created_at", event.CreatedAt,
+		"last_applied_type", last.eventType,
+		"last_applied_at", last.at,
+	)
+	return true
+}
+
+// retryExpired retries the stashed events that have waited the timeout.
+func (h *EventHandler) retryExpired(ctx context.Context) {
+	s := h.sequence
+	cutoff := s.now().Add(-s.timeout)
+	h.retryPending(ctx, func(p pendingEvent) bool { return !p.stashedAt.After(cutoff) })
+}
+
+// retryPending takes the stashed events matching due out of the buffer and
+// retries them oldest first.
+func (h *EventHandler) retryPending(ctx context.Context, due func(pendingEvent) bool) {
+	s := h.sequence
+	var ready []pendingEvent
+	kept := s.pending[:0]
+	for _, p := range s.pending {
+		if due(p) {
+			ready = append(ready, p)
+		} else {
+			kept = append(kept, p)
+		}
+	}
+	clear(s.pending[len(kept):])
+	s.pending = kept
+	sort.SliceStable(ready, func(i, j int) bool { return ready[i].at.Before(ready[j].at) })
+
+	for _, p := range ready {
+		h.retryStashed(ctx, p)
+	}
+}
+
+// retryStashed handles a stashed event, or drops it when a newer full-state
+// event for its aggregate already applied everything it carries. The event
+// was acknowledged when stashed, so a failure is logged and not retried.
+func (h *EventHandler) retryStashed(ctx context.Context, p pendingEvent) {
+	s := h.sequence
+	event := p.event
+	last := s.applied[p.key]
+	if (fullStateEvents[event.EventType] && p.at.Before(last.fullAt)) ||
+		(last.eventType == "TutorDeleted" && p.at.Before(last.at)) {
+		h.stats.recordSequence(func(q *SequenceStats) {
+			q.Pending--
+			q.Superseded++
+		})
+		h.logger.Info("Dropped out-of-order event superseded by a newer one",
+			"event_id", event.EventID,
+			"event_type", event.EventType,
+			"aggregate_id", event.AggregateID,
+			"last_applied_type", last.eventType,
+		)
+		h.advanceWatermark(event)
+		return
+	}
+
+	h.stats.recordSequence(func(q *SequenceStats) {
+		q.Pending--
+		q.Retried++
+	})
+	if err := h.dispatch(ctx, event); err != nil {
+		h.logger.Error("Failed to handle stashed event, dropping it",
+			"event_id", event.EventID,
+			"event_type", event.EventType,
+			"aggregate_id", event.AggregateID,
+			"error", err,
+		)
+		return
+	}
+	s.markApplied(p.key, event.EventType, p.at)
+}
+
+// markApplied records an event of eventType created at at as applied to key,
+// unless a newer one already was.
+func (s *sequencer) markApplied(key sequenceKey, eventType string, at time.Time) {
+	mark := s.applied[key]
+	if fullStateEvents[eventType] && at.After(mark.fullAt) {
+		mark.fullAt = at
+	}
+	if at.After(mark.at) {
+		mark.eventType, mark.at = eventType, at
+	}
+	s.applied[key] = mark
+}
+
+// RunSequence retries stashed events that have waited the strict-sequence
+// timeout even while no new events arrive, until ctx is done. It returns
+// at once without WithStrictSequence.
+func (h *EventHandler) RunSequence(ctx context.Context) {
+	s := h.sequence
+	if s == nil {
+		return
+	}
+	ticker := time.NewTicker(s.timeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.run.Lock()
+			h.retryExpired(ctx)
+			s.run.Unlock()
+		}
+	}
+}
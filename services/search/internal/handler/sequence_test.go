@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/domain"
+	"search/internal/kafka"
+	"search/internal/opensearch"
+	"search/internal/testutil"
+)
+
+var sequenceT0 = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func sequenceEvent(id, eventType string, offset time.Duration, payload any) kafka.Event {
+	raw, _ := json.Marshal(payload)
+	return kafka.Event{
+		EventID:       id,
+		EventType:     eventType,
+		AggregateType: "Tutor",
+		AggregateID:   "5",
+		CreatedAt:     sequenceT0.Add(offset).Format(time.RFC3339Nano),
+		Payload:       raw,
+	}
+}
+
+// sequenceHandler returns a strict-sequence handler over a memory index
+// whose clock only moves when told to.
+func sequenceHandler(t *testing.T, size int) (*EventHandler, *opensearch.MemoryClient, *time.Time) {
+	os := opensearch.NewMemoryClient()
+	h := New(os, testutil.NewLogger(t), WithStrictSequence(size, time.Minute))
+	now := sequenceT0
+	h.sequence.now = func() time.Time { return now }
+	return h, os, &now
+}
+
+func TestStrictSequence_CreateAfterUpdate(t *testing.T) {
+	t.Parallel()
+
+	h, os, _ := sequenceHandler(t, 0)
+	ctx := context.Background()
+
+	updated := sequenceEvent("e-2", "TutorUpdated", time.Second, domain.Tutor{ID: 5, FullName: "Ada King"})
+	created := sequenceEvent("e-1", "TutorCreated", 0, domain.Tutor{ID: 5, FullName: "Ada Lovelace"})
+	verified := sequenceEvent("e-3", "TutorVerified", 2*time.Second, map[string]any{"id": 5, "is_verified": true})
+
+	require.NoError(t, h.Handle(ctx, updated))
+	require.NoError(t, h.Handle(ctx, created))
+	assert.Equal(t, SequenceStats{Reordered: 1, Stashed: 1, Pending: 1}, h.Stats().Sequence)
+
+	// The next event for the tutor releases the stashed create, which the
+	// newer update already superseded.
+	require.NoError(t, h.Handle(ctx, verified))
+
+	tutor, err := os.GetTutor(ctx, 5)
+	require.NoError(t, err)
+	assert.Equal(t, "Ada King", tutor.FullName, "the late create must not overwrite the update")
+	assert.True(t, tutor.IsVerified)
+	assert.Equal(t, SequenceStats{Reordered: 1, Stashed: 1, Superseded: 1}, h.Stats().Sequence)
+	assert.Equal(t, int64(0), h.Stats().EventTypes["TutorCreated"].Succeeded)
+}
+
+func TestStrictSequence_RetriesPartialUpdate(t *testing.T) {
+	t.Parallel()
+
+	h, os, _ := sequenceHandler(t, 0)
+	ctx := context.Background()
+
+	require.NoError(t, h.Handle(ctx, sequenceEvent("e-2", "TutorCreated", time.Second, domain.Tutor{ID: 5, FullName: "Ada Lovelace"})))
+	// Verified before the create was produced, but arrived after it.
+	require.NoError(t, h.Handle(ctx, sequenceEvent("e-1", "TutorVerified", 0, map[string]any{"id": 5, "is_verified": true})))
+	require.NoError(t, h.Handle(ctx, sequenceEvent("e-3", "TutorSlugChanged", 2*time.Second, map[string]any{"id": 5, "new_slug": "ada-lovelace"})))
+
+	tutor, err := os.GetTutor(ctx, 5)
+	require.NoError(t, err)
+	assert.True(t, tutor.IsVerified, "a stashed partial update is applied on retry")
+	assert.Equal(t, SequenceStats{Reordered: 1, Stashed: 1, Retried: 1}, h.Stats().Sequence)
+}
+
+func TestStrictSequence_RetriesAfterTimeout(t *testing.T) {
+	t.Parallel()
+
+	h, os, now := sequenceHandler(t, 0)
+	ctx := context.Background()
+
+	require.NoError(t, h.Handle(ctx, sequenceEvent("e-2", "TutorCreated", time.Second, domain.Tutor{ID: 5, FullName: "Ada Lovelace"})))
+	require.NoError(t, h.Handle(ctx, sequenceEvent("e-1", "TutorVerified", 0, map[string]any{"id": 5, "is_verified": true})))
+
+	// An event for another tutor retries nothing until the timeout passes.
+	other := sequenceEvent("o-1", "TutorCreated", 0, domain.Tutor{ID: 6, FullName: "Grace Hopper"})
+	other.AggregateID = "6"
+	require.NoError(t, h.Handle(ctx, other))
+	assert.Equal(t, int64(1), h.Stats().Sequence.Pending)
+
+	*now = now.Add(time.Minute)
+	other.EventID, other.EventType = "o-2", "TutorUpdated"
+	require.NoError(t, h.Handle(ctx, other))
+
+	tutor, err := os.GetTutor(ctx, 5)
+	require.NoError(t, err)
+	assert.True(t, tutor.IsVerified)
+	assert.Equal(t, SequenceStats{Reordered: 1, Stashed: 1, Retried: 1}, h.Stats().Sequence)
+}
+
+func TestStrictSequence_RunSequenceRetriesWhenIdle(t *testing.T) {
+	t.Parallel()
+
+	os := opensearch.NewMemoryClient()
+	h := New(os, testutil.NewLogger(t), WithStrictSequence(0, 20*time.Millisecond))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go h.RunSequence(ctx)
+
+	require.NoError(t, h.Handle(ctx, sequenceEvent("e-2", "TutorCreated", time.Second, domain.Tutor{ID: 5, FullName: "Ada Lovelace"})))
+	require.NoError(t, h.Handle(ctx, sequenceEvent("e-1", "TutorVerified", 0, map[string]any{"id": 5, "is_verified": true})))
+
+	require.Eventually(t, func() bool {
+		return h.Stats().Sequence.Retried == 1
+	}, time.Second, 5*time.Millisecond)
+	tutor, err := os.GetTutor(context.Background(), 5)
+	require.NoError(t, err)
+	assert.True(t, tutor.IsVerified)
+}
+
+func TestStrictSequence_BufferFullHandlesAtOnce(t *testing.T) {
+	t.Parallel()
+
+	h, os, _ := sequenceHandler(t, 1)
+	ctx := context.Background()
+
+	require.NoError(t, h.Handle(ctx, sequenceEvent("e-3", "TutorCreated", 2*time.Second, domain.Tutor{ID: 5, FullName: "Ada Lovelace"})))
+	require.NoError(t, h.Handle(ctx, sequenceEvent("e-1", "TutorVerified", 0, map[string]any{"id": 5, "is_verified": true})))
+	require.NoError(t, h.Handle(ctx, sequenceEvent("e-2", "TutorSlugChanged", time.Second, map[string]any{"id": 5, "new_slug": "ada-lovelace"})))
+
+	tutor, err := os.GetTutor(ctx, 5)
+	require.NoError(t, err)
+	assert.Equal(t, "ada-lovelace", tutor.Slug, "the event that found the buffer full is handled")
+	assert.True(t, tutor.IsVerified, "and releases the one stashed for the same tutor")
+	assert.Equal(t, SequenceStats{Reordered: 2, Stashed: 1, Retried: 1}, h.Stats().Sequence)
+}
+
+func TestStrictSequence_HandlesInOrderAndSameType(t *testing.T) {
+	t.Parallel()
+
+	h, os, _ := sequenceHandler(t, 0)
+	ctx := context.Background()
+
+	require.NoError(t, h.Handle(ctx, sequenceEvent("e-1", "TutorCreated", 0, domain.Tutor{ID: 5, FullName: "Ada Lovelace"})))
+	require.NoError(t, h.Handle(ctx, sequenceEvent("e-3", "TutorUpdated", 2*time.Second, domain.Tutor{ID: 5, FullName: "Ada King"})))
+	// An older event of the type last applied is a redelivery, not a
+	// reordering, and is handled as before.
+	require.NoError(t, h.Handle(ctx, sequenceEvent("e-2", "TutorUpdated", time.Second, domain.Tutor{ID: 5, FullName: "Ada Byron"})))
+
+	tutor, err := os.GetTutor(ctx, 5)
+	require.NoError(t, err)
+	assert.Equal(t, "Ada Byron", tutor.FullName)
+	assert.Equal(t, SequenceStats{}, h.Stats().Sequence)
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"search/internal/activity"
+	"search/internal/domain"
+	"search/internal/kafka"
+	"search/internal/port"
+)
+
+// slugChangePayload is the payload of TutorSlugChanged events. OldSlug is
+// optional; the tutor's indexed slug is recorded in its history either way.
+type slugChangePayload struct {
+	ID      int64  `json:"id"`
+	OldSlug string `json:"old_slug"`
+	NewSlug string `json:"new_slug"`
+}
+
+// handleTutorSlugChanged moves the tutor to the new slug and keeps the old
+// one in previous_slugs, so links to it still resolve. A tutor that is not
+// indexed is backfilled when a TutorSource is configured, and skipped
+// otherwise, like a verification.
+func (h *EventHandler) handleTutorSlugChanged(ctx context.Context, event kafka.Event) error {
+	ctx, err := h.route(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	var payload slugChangePayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return kafka.Permanent(fmt.Errorf("failed to unmarshal slug change payload: %w", err))
+	}
+	if payload.ID <= 0 {
+		return kafka.Permanent(fmt.Errorf("invalid tutor ID in slug change payload: %d", payload.ID))
+	}
+	if !domain.ValidSlug(payload.NewSlug) {
+		return kafka.Permanent(fmt.Errorf("invalid new_slug %q in slug change payload for tutor %d", payload.NewSlug, payload.ID))
+	}
+	if payload.OldSlug != "" && !domain.ValidSlug(payload.OldSlug) {
+		return kafka.Permanent(fmt.Errorf("invalid old_slug %q in slug change payload for tutor %d", payload.OldSlug, payload.ID))
+	}
+
+	h.checkAggregateID(event, payload.ID)
+	h.recordEvent(ctx, event, payload.ID)
+
+	err = h.withBackfill(ctx, event, payload.ID, func() error {
+		return h.os.ChangeSlug(ctx, payload.ID, payload.OldSlug, payload.NewSlug)
+	})
+	if errors.Is(err, port.ErrNotFound) {
+		h.logger.Info("Slug change for tutor not in index, skipping",
+			"event_id", event.EventID,
+			"tutor_id", payload.ID,
+		)
+		return nil
+	}
+	if err != nil {
+		return writeError(fmt.Errorf("failed to change slug of tutor %d: %w", payload.ID, err))
+	}
+
+	h.logger.Info("Tutor slug changed",
+		"event_id", event.EventID,
+		"tutor_id", payload.ID,
+		"old_slug", payload.OldSlug,
+		"new_slug", payload.NewSlug,
+	)
+
+	h.activity.Publish(activity.Event{
+		Type:    activity.TypeUpsert,
+		Source:  activity.SourceKafka,
+		TutorID: payload.ID,
+		EventID: event.EventID,
+	})
+	return nil
+}
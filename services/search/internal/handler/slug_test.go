@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/domain"
+	"search/internal/kafka"
+	"search/internal/opensearch"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func slugChangedEvent(payload string) kafka.Event {
+	return kafka.Event{
+		EventID:       "s-1",
+		EventType:     "TutorSlugChanged",
+		AggregateType: "Tutor",
+		Payload:       json.RawMessage(payload),
+	}
+}
+
+func TestEventHandler_TutorSlugChanged(t *testing.T) {
+	t.Parallel()
+
+	os := opensearch.NewMemoryClient()
+	require.NoError(t, os.UpsertTutor(context.Background(), &domain.Tutor{ID: 5, FullName: "Ada Lovelace", Slug: "ada-lovelace"}))
+	handler := New(os, testutil.NewLogger(t))
+
+	require.NoError(t, handler.Handle(context.Background(), slugChangedEvent(`{"id": 5, "old_slug": "ada-lovelace", "new_slug": "ada-king"}`)))
+	require.NoError(t, handler.Handle(context.Background(), slugChangedEvent(`{"id": 5, "old_slug": "ada-king", "new_slug": "countess-of-lovelace"}`)))
+
+	tutor, err := os.GetTutor(context.Background(), 5)
+	require.NoError(t, err)
+	assert.Equal(t, "countess-of-lovelace", tutor.Slug)
+	assert.Equal(t, []string{"ada-lovelace", "ada-king"}, tutor.PreviousSlugs)
+	assert.Equal(t, "Ada Lovelace", tutor.FullName, "other fields must be kept")
+
+	// Changing back to an old slug takes it out of the history.
+	require.NoError(t, handler.Handle(context.Background(), slugChangedEvent(`{"id": 5, "old_slug": "countess-of-lovelace", "new_slug": "ada-lovelace"}`)))
+	tutor, err = os.GetTutor(context.Background(), 5)
+	require.NoError(t, err)
+	assert.Equal(t, "ada-lovelace", tutor.Slug)
+	assert.Equal(t, []string{"ada-king", "countess-of-lovelace"}, tutor.PreviousSlugs)
+
+	byOldSlug, err := os.GetTutorBySlug(context.Background(), "ada-king")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), byOldSlug.ID)
+}
+
+func TestEventHandler_TutorSlugChangedForUnindexedTutor_IsSkipped(t *testing.T) {
+	t.Parallel()
+
+	handler := New(opensearch.NewMemoryClient(), testutil.NewLogger(t))
+
+	err := handler.Handle(context.Background(), slugChangedEvent(`{"id": 5, "old_slug": "a", "new_slug": "b"}`))
+	assert.NoError(t, err)
+}
+
+func TestEventHandler_TutorSlugChangedErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		payload       string
+		slugErr       error
+		wantPermanent bool
+	}{
+		{"malformed payload", `{"id": 5, "new_slug": 7}`, nil, true},
+		{"missing tutor ID", `{"old_slug": "a", "new_slug": "b"}`, nil, true},
+		{"missing new slug", `{"id": 5, "old_slug": "a"}`, nil, true},
+		{"invalid new slug", `{"id": 5, "old_slug": "a", "new_slug": "Ada Lovelace"}`, nil, true},
+		{"invalid old slug", `{"id": 5, "old_slug": "a/b", "new_slug": "b"}`, nil, true},
+		{"rejected by the mapping", `{"id": 5, "new_slug": "b"}`, port.ErrDocumentRejected, true},
+		{"backend failure is retried", `{"id": 5, "new_slug": "b"}`, errors.New("opensearch unavailable"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := New(&mockSearchClient{
+				slugFunc: func(ctx context.Context, tutorID int64, oldSlug, newSlug string) error {
+					return tt.slugErr
+				},
+			}, testutil.NewLogger(t))
+
+			err := handler.Handle(context.Background(), slugChangedEvent(tt.payload))
+
+			require.Error(t, err)
+			assert.Equal(t, tt.wantPermanent, kafka.IsPermanent(err))
+		})
+	}
+}
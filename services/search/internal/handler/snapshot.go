@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"search/internal/activity"
+	"search/internal/domain"
+	"search/internal/kafka"
+	"search/internal/tenant"
+)
+
+// SnapshotProgress counts the TutorSnapshot records of one snapshot handled
+// since startup.
+type SnapshotProgress struct {
+	SnapshotID string `json:"snapshot_id"`
+	// Indexed records were written to the index.
+	Indexed int `json:"indexed"`
+	// Superseded records were skipped because a live event for the tutor
+	// had already been applied.
+	Superseded int `json:"superseded"`
+	// Invalid records were quarantined.
+	Invalid     int       `json:"invalid"`
+	FirstSeenAt time.Time `json:"first_seen_at"`
+	LastSeenAt  time.Time `json:"last_seen_at"`
+}
+
+// snapshotPayload is a full tutor with the snapshot it belongs to.
+type snapshotPayload struct {
+	domain.Tutor
+	SnapshotID string `json:"snapshot_id"`
+}
+
+// handleTutorSnapshot indexes one record of a bootstrap snapshot. Snapshot
+// records rank below live events: they never overwrite a document a live
+// TutorCreated or TutorUpdated wrote, and never recreate a tutor whose
+// TutorDeleted was seen.
+func (h *EventHandler) handleTutorSnapshot(ctx context.Context, event kafka.Event) error {
+	ctx, err := h.route(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	var payload snapshotPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return kafka.Permanent(fmt.Errorf("failed to unmarshal snapshot payload: %w", err))
+	}
+	if payload.SnapshotID == "" {
+		return kafka.Permanent(errors.New("snapshot payload has no snapshot_id"))
+	}
+	tutor := payload.Tutor
+
+	h.checkAggregateID(event, tutor.ID)
+	h.warnUnknownFields(event, tutor.ID, snapshotFields)
+
+	err = h.sanitize(event, &tutor)
+	if err == nil {
+		err = tutor.Validate()
+	}
+	if err != nil {
+		h.countSnapshot(payload.SnapshotID, func(p *SnapshotProgress) { p.Invalid++ })
+		return kafka.Permanent(fmt.Errorf("invalid tutor %d in snapshot %s: %w", tutor.ID, payload.SnapshotID, err))
+	}
+
+	if h.deletedLive(ctx, tutor.ID) {
+		h.logger.Info("Tutor deleted by a live event, skipping snapshot record",
+			"event_id", event.EventID,
+			"snapshot_id", payload.SnapshotID,
+			"tutor_id", tutor.ID,
+		)
+		h.countSnapshot(payload.SnapshotID, func(p *SnapshotProgress) { p.Superseded++ })
+		return nil
+	}
+
+	tutor.MarkIndexed(time.Now())
+	h.forgetContent(ctx, tutor.ID)
+	applied, err := h.os.UpsertSnapshotTutor(ctx, &tutor, payload.SnapshotID)
+	if err != nil {
+		return writeError(fmt.Errorf("failed to upsert snapshot tutor %d: %w", tutor.ID, err))
+	}
+	if !applied {
+		h.logger.Info("Tutor written by a live event, skipping snapshot record",
+			"event_id", event.EventID,
+			"snapshot_id", payload.SnapshotID,
+			"tutor_id", tutor.ID,
+		)
+		h.countSnapshot(payload.SnapshotID, func(p *SnapshotProgress) { p.Superseded++ })
+		return nil
+	}
+
+	h.countSnapshot(payload.SnapshotID, func(p *SnapshotProgress) { p.Indexed++ })
+	h.activity.Publish(activity.Event{
+		Type:    activity.TypeUpsert,
+		Source:  activity.SourceKafka,
+		TutorID: tutor.ID,
+		EventID: event.EventID,
+	})
+	return nil
+}
+
+// deletedLive reports whether the newest live event seen for the tutor is a
+// delete.
+func (h *EventHandler) deletedLive(ctx context.Context, tutorID int64) bool {
+	t, _ := tenant.FromContext(ctx)
+	last, ok := h.LastEvent(t.Name, tutorID)
+	return ok && last.Type == "TutorDeleted"
+}
+
+func (h *EventHandler) countSnapshot(snapshotID string, update func(*SnapshotProgress)) {
+	now := time.Now().UTC()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	p, ok := h.snapshots[snapshotID]
+	if !ok {
+		p = &SnapshotProgress{SnapshotID: snapshotID, FirstSeenAt: now}
+		h.snapshots[snapshotID] = p
+	}
+	p.LastSeenAt = now
+	update(p)
+}
+
+// SnapshotProgress returns the progress of every snapshot seen since
+// startup, most recently active first.
+func (h *EventHandler) SnapshotProgress() []SnapshotProgress {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	progress := make([]SnapshotProgress, 0, len(h.snapshots))
+	for _, p := range h.snapshots {
+		progress = append(progress, *p)
+	}
+	slices.SortFunc(progress, func(a, b SnapshotProgress) int {
+		return cmp.Or(b.LastSeenAt.Compare(a.LastSeenAt), cmp.Compare(a.SnapshotID, b.SnapshotID))
+	})
+	return progress
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/domain"
+	"search/internal/kafka"
+	"search/internal/opensearch"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func snapshotTutor(id int64, headline string) domain.Tutor {
+	return domain.Tutor{
+		ID:         id,
+		Slug:       "tutor",
+		FullName:   "Jane Roe",
+		Headline:   headline,
+		Subjects:   []string{"math"},
+		HourlyRate: 40,
+		Formats:    []string{"online"},
+	}
+}
+
+func snapshotEvent(snapshotID string, tutor domain.Tutor) kafka.Event {
+	payload, _ := json.Marshal(snapshotPayload{Tutor: tutor, SnapshotID: snapshotID})
+	return kafka.Event{EventID: "s-1", EventType: "TutorSnapshot", AggregateType: "Tutor", Payload: payload}
+}
+
+func liveEvent(eventType string, tutor domain.Tutor, at time.Time) kafka.Event {
+	payload, _ := json.Marshal(tutor)
+	return kafka.Event{
+		EventID:       "e-1",
+		EventType:     eventType,
+		AggregateType: "Tutor",
+		Payload:       payload,
+		CreatedAt:     at.Format(time.RFC3339Nano),
+	}
+}
+
+func TestEventHandler_TutorSnapshot_VersionPrecedence(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	tests := []struct {
+		name         string
+		events       []kafka.Event
+		wantHeadline string // empty means not indexed
+	}{
+		{
+			name:         "snapshot indexes a new tutor",
+			events:       []kafka.Event{snapshotEvent("snap-1", snapshotTutor(1, "snapshot"))},
+			wantHeadline: "snapshot",
+		},
+		{
+			name: "live update overwrites a snapshot record",
+			events: []kafka.Event{
+				snapshotEvent("snap-1", snapshotTutor(1, "snapshot")),
+				liveEvent("TutorUpdated", snapshotTutor(1, "live"), now),
+			},
+			wantHeadline: "live",
+		},
+		{
+			name: "snapshot record does not overwrite a live update",
+			events: []kafka.Event{
+				liveEvent("TutorUpdated", snapshotTutor(1, "live"), now),
+				snapshotEvent("snap-1", snapshotTutor(1, "snapshot")),
+			},
+			wantHeadline: "live",
+		},
+		{
+			name: "snapshot record does not overwrite a live update made during the snapshot",
+			events: []kafka.Event{
+				snapshotEvent("snap-1", snapshotTutor(1, "snapshot")),
+				liveEvent("TutorUpdated", snapshotTutor(1, "live"), now),
+				snapshotEvent("snap-1", snapshotTutor(1, "snapshot again")),
+			},
+			wantHeadline: "live",
+		},
+		{
+			name: "snapshot record does not recreate a tutor deleted live",
+			events: []kafka.Event{
+				liveEvent("TutorCreated", snapshotTutor(1, "live"), now),
+				liveEvent("TutorDeleted", snapshotTutor(1, "live"), now.Add(time.Second)),
+				snapshotEvent("snap-1", snapshotTutor(1, "snapshot")),
+			},
+		},
+		{
+			name: "later snapshot overwrites an earlier one",
+			events: []kafka.Event{
+				snapshotEvent("snap-1", snapshotTutor(1, "first")),
+				snapshotEvent("snap-2", snapshotTutor(1, "second")),
+			},
+			wantHeadline: "second",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			os := opensearch.NewMemoryClient()
+			handler := New(os, testutil.NewLogger(t))
+			for _, event := range tt.events {
+				require.NoError(t, handler.Handle(context.Background(), event))
+			}
+
+			tutor, err := os.GetTutor(context.Background(), 1)
+			if tt.wantHeadline == "" {
+				assert.ErrorIs(t, err, port.ErrNotFound)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantHeadline, tutor.Headline)
+		})
+	}
+}
+
+func TestEventHandler_TutorSnapshot_Progress(t *testing.T) {
+	t.Parallel()
+
+	handler := New(opensearch.NewMemoryClient(), testutil.NewLogger(t))
+	ctx := context.Background()
+
+	require.NoError(t, handler.Handle(ctx, liveEvent("TutorCreated", snapshotTutor(1, "live"), time.Now())))
+	require.NoError(t, handler.Handle(ctx, snapshotEvent("snap-1", snapshotTutor(1, "snapshot"))))
+	require.NoError(t, handler.Handle(ctx, snapshotEvent("snap-1", snapshotTutor(2, "snapshot"))))
+	require.NoError(t, handler.Handle(ctx, snapshotEvent("snap-1", snapshotTutor(3, "snapshot"))))
+	invalid := snapshotTutor(4, "snapshot")
+	invalid.Rating = 7
+	err := handler.Handle(ctx, snapshotEvent("snap-1", invalid))
+	assert.True(t, kafka.IsPermanent(err), "invalid snapshot records must not be retried")
+
+	progress := handler.SnapshotProgress()
+	require.Len(t, progress, 1)
+	assert.Equal(t, "snap-1", progress[0].SnapshotID)
+	assert.Equal(t, 2, progress[0].Indexed)
+	assert.Equal(t, 1, progress[0].Superseded)
+	assert.Equal(t, 1, progress[0].Invalid)
+	assert.False(t, progress[0].FirstSeenAt.After(progress[0].LastSeenAt))
+}
+
+func TestEventHandler_TutorSnapshot_InvalidPayload(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&mockSearchClient{}, testutil.NewLogger(t))
+
+	tests := []struct {
+		name    string
+		payload string
+	}{
+		{name: "malformed JSON", payload: `{invalid json`},
+		{name: "missing snapshot_id", payload: `{"id": 1, "slug": "tutor", "full_name": "Jane Roe"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := handler.Handle(context.Background(), kafka.Event{
+				EventID:   "s-1",
+				EventType: "TutorSnapshot",
+				Payload:   json.RawMessage(tt.payload),
+			})
+			assert.True(t, kafka.IsPermanent(err))
+		})
+	}
+	assert.Empty(t, handler.SnapshotProgress())
+}
@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"sync"
+	"time"
+
+	"search/internal/kafka"
+)
+
+// EventTypeStats counts the handling attempts for one event type. A
+// transient failure counts once per attempt, since the consumer retries it.
+type EventTypeStats struct {
+	Succeeded   int64 `json:"succeeded"`
+	Failed      int64 `json:"failed"`
+	Quarantined int64 `json:"quarantined"`
+	// Ignored counts events of a type the handler does not know.
+	Ignored int64 `json:"ignored"`
+	// FailureRate is the share of attempts that failed or were quarantined.
+	FailureRate float64      `json:"failure_rate"`
+	LastError   *LastFailure `json:"last_error,omitempty"`
+}
+
+// LastFailure is the most recent error for an event type.
+type LastFailure struct {
+	Error string    `json:"error"`
+	At    time.Time `json:"at"`
+}
+
+// BackfillStats counts attempts to index tutors that a partial update found
+// missing.
+type BackfillStats struct {
+	Indexed int64 `json:"indexed"`
+	// Gone counts tutors Django no longer has.
+	Gone   int64 `json:"gone"`
+	Failed int64 `json:"failed"`
+}
+
+// UnchangedStats counts TutorCreated and TutorUpdated events whose write was
+// skipped because they matched the indexed content. Skipped events also
+// count as succeeded.
+type UnchangedStats struct {
+	Skipped int64 `json:"skipped"`
+	// Lookups counts fetches of the indexed document for tutors whose
+	// content was not cached.
+	Lookups int64 `json:"lookups"`
+}
+
+// SequenceStats counts events that arrived out of order with
+// WithStrictSequence on.
+type SequenceStats struct {
+	// Reordered counts events older than the last one applied for their
+	// aggregate, including those handled at once because the buffer was full.
+	Reordered int64 `json:"reordered"`
+	Stashed   int64 `json:"stashed"`
+	// Retried counts stashed events handled later; Superseded those dropped
+	// because a newer event had replaced what they carried.
+	Retried    int64 `json:"retried"`
+	Superseded int64 `json:"superseded"`
+	// Pending is how many events are stashed now.
+	Pending int64 `json:"pending"`
+}
+
+// EventStats is a point-in-time copy of the handler's counters.
+type EventStats struct {
+	Since      time.Time                 `json:"since"`
+	EventTypes map[string]EventTypeStats `json:"event_types"`
+	Backfills  BackfillStats             `json:"backfills"`
+	Unchanged  UnchangedStats            `json:"unchanged"`
+	Sequence   SequenceStats             `json:"sequence"`
+}
+
+// stats collects EventStats. It is safe for concurrent use.
+type stats struct {
+	since time.Time
+
+	mu        sync.Mutex
+	byType    map[string]*EventTypeStats
+	backfills BackfillStats
+	unchanged UnchangedStats
+	sequence  SequenceStats
+}
+
+func newStats(now time.Time) *stats {
+	return &stats{since: now.UTC(), byType: make(map[string]*EventTypeStats)}
+}
+
+// record counts one handling attempt of eventType that ended with err.
+func (s *stats) record(eventType string, known bool, err error, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, ok := s.byType[eventType]
+	if !ok {
+		st = &EventTypeStats{}
+		s.byType[eventType] = st
+	}
+	switch {
+	case !known:
+		st.Ignored++
+	case err == nil:
+		st.Succeeded++
+	case kafka.IsPermanent(err):
+		st.Quarantined++
+	default:
+		st.Failed++
+	}
+	if err != nil {
+		st.LastError = &LastFailure{Error: err.Error(), At: now.UTC()}
+	}
+}
+
+// recordBackfill counts one backfill outcome.
+func (s *stats) recordBackfill(update func(*BackfillStats)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	update(&s.backfills)
+}
+
+// recordUnchanged counts one outcome of the unchanged-content check.
+func (s *stats) recordUnchanged(update func(*UnchangedStats)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	update(&s.unchanged)
+}
+
+// recordSequence counts one outcome of strict sequencing.
+func (s *stats) recordSequence(update func(*SequenceStats)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	update(&s.sequence)
+}
+
+func (s *stats) snapshot() EventStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := EventStats{
+		Since:      s.since,
+		EventTypes: make(map[string]EventTypeStats, len(s.byType)),
+		Backfills:  s.backfills,
+		Unchanged:  s.unchanged,
+		Sequence:   s.sequence,
+	}
+	for eventType, st := range s.byType {
+		c := *st
+		if c.LastError != nil {
+			last := *c.LastError
+			c.LastError = &last
+		}
+		if attempts := c.Succeeded + c.Failed + c.Quarantined; attempts > 0 {
+			c.FailureRate = float64(c.Failed+c.Quarantined) / float64(attempts)
+		}
+		out.EventTypes[eventType] = c
+	}
+	return out
+}
+
+// Stats returns per-event-type processing counts since startup.
+func (h *EventHandler) Stats() EventStats {
+	return h.stats.snapshot()
+}
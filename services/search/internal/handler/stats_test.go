@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/domain"
+	"search/internal/kafka"
+	"search/internal/testutil"
+)
+
+func TestEventHandler_Stats_Outcomes(t *testing.T) {
+	t.Parallel()
+
+	upsertErr := errors.New("opensearch unavailable")
+	failUpserts := true
+	handler := New(&mockSearchClient{
+		upsertFunc: func(ctx context.Context, tutor *domain.Tutor) error {
+			if failUpserts {
+				return upsertErr
+			}
+			return nil
+		},
+	}, testutil.NewLogger(t))
+	ctx := context.Background()
+
+	valid, _ := json.Marshal(domain.Tutor{ID: 1, FullName: "Stats Tutor"})
+	assert.Error(t, handler.Handle(ctx, kafka.Event{EventType: "TutorUpdated", Payload: valid}))
+	failUpserts = false
+	assert.NoError(t, handler.Handle(ctx, kafka.Event{EventType: "TutorUpdated", Payload: valid}))
+	assert.NoError(t, handler.Handle(ctx, kafka.Event{EventType: "TutorCreated", Payload: valid}))
+	assert.Error(t, handler.Handle(ctx, kafka.Event{EventType: "TutorDeleted", Payload: json.RawMessage(`{"id": 0}`)}))
+	assert.NoError(t, handler.Handle(ctx, kafka.Event{EventType: "TutorArchived"}))
+
+	stats := handler.Stats()
+
+	updated := stats.EventTypes["TutorUpdated"]
+	assert.Equal(t, int64(1), updated.Succeeded)
+	assert.Equal(t, int64(1), updated.Failed)
+	assert.InDelta(t, 0.5, updated.FailureRate, 1e-9)
+	require.NotNil(t, updated.LastError)
+	assert.Contains(t, updated.LastError.Error, "opensearch unavailable")
+
+	assert.Equal(t, EventTypeStats{Succeeded: 1}, stats.EventTypes["TutorCreated"])
+
+	deleted := stats.EventTypes["TutorDeleted"]
+	assert.Equal(t, int64(1), deleted.Quarantined)
+	assert.InDelta(t, 1.0, deleted.FailureRate, 1e-9)
+
+	assert.Equal(t, EventTypeStats{Ignored: 1}, stats.EventTypes["TutorArchived"])
+	assert.False(t, stats.Since.IsZero())
+}
+
+func TestEventHandler_Stats_ConcurrentIncrements(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&mockSearchClient{}, testutil.NewLogger(t))
+	payload, _ := json.Marshal(domain.Tutor{ID: 1, FullName: "Stats Tutor"})
+
+	const workers, perWorker = 8, 250
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range perWorker {
+				handler.Handle(context.Background(), kafka.Event{EventType: "TutorUpdated", Payload: payload})
+				handler.Handle(context.Background(), kafka.Event{EventType: "Unknown"})
+				handler.Stats()
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := handler.Stats()
+	assert.Equal(t, int64(workers*perWorker), stats.EventTypes["TutorUpdated"].Succeeded)
+	assert.Equal(t, int64(workers*perWorker), stats.EventTypes["Unknown"].Ignored)
+}
+
+func TestStats_SnapshotIsACopy(t *testing.T) {
+	t.Parallel()
+
+	s := newStats(time.Now())
+	s.record("TutorUpdated", true, errors.New("boom"), time.Now())
+
+	snap := s.snapshot()
+	snap.EventTypes["TutorUpdated"].LastError.Error = "changed"
+	s.record("TutorUpdated", true, nil, time.Now())
+
+	assert.Equal(t, int64(0), snap.EventTypes["TutorUpdated"].Succeeded)
+	assert.Equal(t, int64(1), s.snapshot().EventTypes["TutorUpdated"].Succeeded)
+	assert.Equal(t, "boom", s.snapshot().EventTypes["TutorUpdated"].LastError.Error)
+}
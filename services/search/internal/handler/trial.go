@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"search/internal/activity"
+	"search/internal/kafka"
+	"search/internal/port"
+)
+
+// trialPayload is the payload of TutorTrialSettingChanged events.
+type trialPayload struct {
+	ID          int64 `json:"id"`
+	OffersTrial *bool `json:"offers_trial"`
+}
+
+// handleTutorTrialSettingChanged sets the tutor's offers_trial. A tutor
+// that is not indexed is backfilled when a TutorSource is configured, and
+// skipped otherwise, like a verification.
+func (h *EventHandler) handleTutorTrialSettingChanged(ctx context.Context, event kafka.Event) error {
+	ctx, err := h.route(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	var payload trialPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return kafka.Permanent(fmt.Errorf("failed to unmarshal trial setting payload: %w", err))
+	}
+	if payload.ID <= 0 {
+		return kafka.Permanent(fmt.Errorf("invalid tutor ID in trial setting payload: %d", payload.ID))
+	}
+	if payload.OffersTrial == nil {
+		return kafka.Permanent(fmt.Errorf("trial setting payload for tutor %d has no offers_trial", payload.ID))
+	}
+
+	h.checkAggregateID(event, payload.ID)
+	h.recordEvent(ctx, event, payload.ID)
+
+	err = h.withBackfill(ctx, event, payload.ID, func() error {
+		return h.os.SetOffersTrial(ctx, payload.ID, *payload.OffersTrial)
+	})
+	if errors.Is(err, port.ErrNotFound) {
+		h.logger.Info("Trial setting for tutor not in index, skipping",
+			"event_id", event.EventID,
+			"tutor_id", payload.ID,
+		)
+		return nil
+	}
+	if err != nil {
+		return writeError(fmt.Errorf("failed to update trial setting of tutor %d: %w", payload.ID, err))
+	}
+
+	h.logger.Info("Tutor trial setting updated",
+		"event_id", event.EventID,
+		"tutor_id", payload.ID,
+		"offers_trial", *payload.OffersTrial,
+	)
+
+	h.activity.Publish(activity.Event{
+		Type:    activity.TypeUpsert,
+		Source:  activity.SourceKafka,
+		TutorID: payload.ID,
+		EventID: event.EventID,
+	})
+	return nil
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/domain"
+	"search/internal/kafka"
+	"search/internal/opensearch"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func trialEvent(payload string) kafka.Event {
+	return kafka.Event{
+		EventID:       "t-1",
+		EventType:     "TutorTrialSettingChanged",
+		AggregateType: "Tutor",
+		CreatedAt:     "2026-03-01T09:30:00Z",
+		Payload:       json.RawMessage(payload),
+	}
+}
+
+func TestEventHandler_TrialSettingChanged(t *testing.T) {
+	t.Parallel()
+
+	os := opensearch.NewMemoryClient()
+	require.NoError(t, os.UpsertTutor(context.Background(), &domain.Tutor{ID: 5, FullName: "Ada Lovelace"}))
+	handler := New(os, testutil.NewLogger(t))
+
+	require.NoError(t, handler.Handle(context.Background(), trialEvent(`{"id": 5, "offers_trial": true}`)))
+
+	tutor, err := os.GetTutor(context.Background(), 5)
+	require.NoError(t, err)
+	assert.True(t, tutor.OffersTrial)
+	assert.Equal(t, "Ada Lovelace", tutor.FullName, "other fields must be kept")
+
+	require.NoError(t, handler.Handle(context.Background(), trialEvent(`{"id": 5, "offers_trial": false}`)))
+
+	tutor, err = os.GetTutor(context.Background(), 5)
+	require.NoError(t, err)
+	assert.False(t, tutor.OffersTrial)
+}
+
+func TestEventHandler_TrialSettingForUnindexedTutor_IsSkipped(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&mockSearchClient{
+		trialFunc: func(ctx context.Context, tutorID int64, offers bool) error {
+			return port.ErrNotFound
+		},
+	}, testutil.NewLogger(t))
+
+	err := handler.Handle(context.Background(), trialEvent(`{"id": 5, "offers_trial": true}`))
+	assert.NoError(t, err)
+}
+
+func TestEventHandler_TrialSettingErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		payload       string
+		trialErr      error
+		wantPermanent bool
+	}{
+		{name: "malformed payload", payload: `{"id": 5, "offers_trial": "yes"}`, wantPermanent: true},
+		{name: "missing tutor ID", payload: `{"offers_trial": true}`, wantPermanent: true},
+		{name: "missing offers_trial", payload: `{"id": 5}`, wantPermanent: true},
+		{name: "backend failure is retried", payload: `{"id": 5, "offers_trial": true}`, trialErr: errors.New("opensearch unavailable")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := New(&mockSearchClient{
+				trialFunc: func(ctx context.Context, tutorID int64, offers bool) error {
+					return tt.trialErr
+				},
+			}, testutil.NewLogger(t))
+
+			err := handler.Handle(context.Background(), trialEvent(tt.payload))
+
+			require.Error(t, err)
+			assert.Equal(t, tt.wantPermanent, kafka.IsPermanent(err))
+		})
+	}
+}
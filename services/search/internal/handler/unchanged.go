@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+
+	"search/internal/domain"
+	"search/internal/kafka"
+	"search/internal/port"
+	"search/internal/tenant"
+)
+
+// DefaultUnchangedCacheSize is how many tutors' content hashes
+// WithSkipUnchanged keeps when given no size.
+const DefaultUnchangedCacheSize = 10000
+
+// WithSkipUnchanged skips the write of a TutorCreated or TutorUpdated whose
+// content matches what is indexed, as for a Django save that changed
+// nothing. The content hashes of the size tutors written most recently are
+// kept in memory; for any other tutor the indexed document is fetched and
+// compared, at the cost of a GET. Zero keeps DefaultUnchangedCacheSize.
+//
+// Only writes made by this handler update the cache, so a document changed
+// through the admin API can keep stale content until the tutor's next real
+// change or eviction.
+func WithSkipUnchanged(size int) Option {
+	return func(h *EventHandler) {
+		if size <= 0 {
+			size = DefaultUnchangedCacheSize
+		}
+		h.unchanged = newContentCache(size)
+	}
+}
+
+// unchangedContent returns the content hash of tutor and whether it matches
+// the indexed document. It never reports a match when skipping is off, and
+// when the indexed document cannot be fetched it assumes a change.
+func (h *EventHandler) unchangedContent(ctx context.Context, event kafka.Event, tutor *domain.Tutor) (string, bool) {
+	if h.unchanged == nil {
+		return "", false
+	}
+	key := contentKey(ctx, tutor.ID)
+	hash := domain.ContentHash(tutor)
+	if cached, ok := h.unchanged.get(key); ok {
+		return hash, cached == hash
+	}
+
+	h.stats.recordUnchanged(func(u *UnchangedStats) { u.Lookups++ })
+	current, err := h.os.GetTutor(ctx, tutor.ID)
+	if err != nil {
+		if !errors.Is(err, port.ErrNotFound) {
+			h.logger.Warn("Failed to fetch indexed tutor, writing it anyway",
+				"event_id", event.EventID,
+				"tutor_id", tutor.ID,
+				"error", err,
+			)
+		}
+		return hash, false
+	}
+	if len(domain.ChangedFields(current, tutor)) > 0 {
+		return hash, false
+	}
+	h.unchanged.put(key, hash)
+	return hash, true
+}
+
+// rememberContent records hash as the indexed content of tutorID.
+func (h *EventHandler) rememberContent(ctx context.Context, tutorID int64, hash string) {
+	if h.unchanged != nil {
+		h.unchanged.put(contentKey(ctx, tutorID), hash)
+	}
+}
+
+// forgetContent drops the recorded content of tutorID, for writes that
+// change it in ways the hash cannot follow.
+func (h *EventHandler) forgetContent(ctx context.Context, tutorID int64) {
+	if h.unchanged != nil {
+		h.unchanged.forget(contentKey(ctx, tutorID))
+	}
+}
+
+func contentKey(ctx context.Context, tutorID int64) eventKey {
+	t, _ := tenant.FromContext(ctx)
+	return eventKey{tenant: t.Name, id: tutorID}
+}
+
+// contentCache maps tutors to the content hash last written for them,
+// evicting the least recently used beyond size. It is safe for concurrent
+// use.
+type contentCache struct {
+	size int
+
+	mu      sync.Mutex
+	order   *list.List // of *contentEntry, most recently used first
+	entries map[eventKey]*list.Element
+}
+
+type contentEntry struct {
+	key  eventKey
+	hash string
+}
+
+func newContentCache(size int) *contentCache {
+	return &contentCache{size: size, order: list.New(), entries: make(map[eventKey]*list.Element)}
+}
+
+func (c *contentCache) get(key eventKey) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*contentEntry).hash, true
+}
+
+func (c *contentCache) put(key eventKey, hash string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*contentEntry).hash = hash
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&contentEntry{key: key, hash: hash})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*contentEntry).key)
+	}
+}
+
+func (c *contentCache) forget(key eventKey) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		c.order.Remove(e)
+		delete(c.entries, key)
+	}
+}
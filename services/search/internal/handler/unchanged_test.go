@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/domain"
+	"search/internal/opensearch"
+	"search/internal/testutil"
+)
+
+// countingSearchClient counts the upserts and fetches reaching a
+// mockSearchClient, whose fetches fail.
+func countingSearchClient() (*mockSearchClient, *int, *int) {
+	var upserts, gets int
+	return &mockSearchClient{
+		upsertFunc: func(ctx context.Context, tutor *domain.Tutor) error {
+			upserts++
+			return nil
+		},
+		getFunc: func(ctx context.Context, id int64) (*domain.Tutor, error) {
+			gets++
+			return nil, errors.New("not indexed")
+		},
+	}, &upserts, &gets
+}
+
+func TestEventHandler_SkipUnchanged_IdenticalPayload(t *testing.T) {
+	t.Parallel()
+
+	mockOS, upserts, gets := countingSearchClient()
+	handler := New(mockOS, testutil.NewLogger(t), WithSkipUnchanged(10))
+
+	tutor := snapshotTutor(1, "Math")
+	for range 3 {
+		require.NoError(t, handler.Handle(context.Background(), liveEvent("TutorUpdated", tutor, time.Now())))
+	}
+
+	assert.Equal(t, 1, *upserts, "repeats of a written payload must not be written again")
+	assert.Equal(t, 1, *gets, "only the first event misses the cache")
+	stats := handler.Stats()
+	assert.Equal(t, UnchangedStats{Skipped: 2, Lookups: 1}, stats.Unchanged)
+	assert.Equal(t, int64(3), stats.EventTypes["TutorUpdated"].Succeeded)
+}
+
+func TestEventHandler_SkipUnchanged_ChangedPayload(t *testing.T) {
+	t.Parallel()
+
+	mockOS, upserts, _ := countingSearchClient()
+	handler := New(mockOS, testutil.NewLogger(t), WithSkipUnchanged(10))
+
+	tutor := snapshotTutor(1, "Math")
+	require.NoError(t, handler.Handle(context.Background(), liveEvent("TutorUpdated", tutor, time.Now())))
+	tutor.HourlyRate = 45
+	require.NoError(t, handler.Handle(context.Background(), liveEvent("TutorUpdated", tutor, time.Now())))
+	tutor.HourlyRate = 40
+	require.NoError(t, handler.Handle(context.Background(), liveEvent("TutorUpdated", tutor, time.Now())))
+
+	assert.Equal(t, 3, *upserts, "every change, including one back, must be written")
+	assert.Zero(t, handler.Stats().Unchanged.Skipped)
+}
+
+func TestEventHandler_SkipUnchanged_Eviction(t *testing.T) {
+	t.Parallel()
+
+	mockOS, upserts, gets := countingSearchClient()
+	handler := New(mockOS, testutil.NewLogger(t), WithSkipUnchanged(1))
+
+	first, second := snapshotTutor(1, "Math"), snapshotTutor(2, "Physics")
+	require.NoError(t, handler.Handle(context.Background(), liveEvent("TutorUpdated", first, time.Now())))
+	require.NoError(t, handler.Handle(context.Background(), liveEvent("TutorUpdated", second, time.Now())))
+	require.NoError(t, handler.Handle(context.Background(), liveEvent("TutorUpdated", first, time.Now())))
+
+	assert.Equal(t, 3, *gets, "the first tutor was evicted, so its content must be fetched again")
+	assert.Equal(t, 3, *upserts, "a tutor that cannot be fetched must be written")
+
+	require.NoError(t, handler.Handle(context.Background(), liveEvent("TutorUpdated", first, time.Now())))
+	assert.Equal(t, 3, *gets)
+	assert.Equal(t, 3, *upserts)
+}
+
+func TestEventHandler_SkipUnchanged_ComparesIndexedDocument(t *testing.T) {
+	t.Parallel()
+
+	os := opensearch.NewMemoryClient()
+	tutor := snapshotTutor(1, "Math")
+	require.NoError(t, New(os, testutil.NewLogger(t)).Handle(context.Background(), liveEvent("TutorCreated", tutor, time.Now())))
+	indexed, err := os.GetTutor(context.Background(), 1)
+	require.NoError(t, err)
+
+	// A restarted handler has an empty cache and compares with the index.
+	handler := New(os, testutil.NewLogger(t), WithSkipUnchanged(10))
+	require.NoError(t, handler.Handle(context.Background(), liveEvent("TutorUpdated", tutor, time.Now())))
+
+	after, err := os.GetTutor(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, indexed.IndexedAt, after.IndexedAt, "an unchanged tutor must not be rewritten")
+	assert.Equal(t, UnchangedStats{Skipped: 1, Lookups: 1}, handler.Stats().Unchanged)
+
+	tutor.Headline = "Algebra"
+	require.NoError(t, handler.Handle(context.Background(), liveEvent("TutorUpdated", tutor, time.Now())))
+	after, err = os.GetTutor(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Algebra", after.Headline)
+}
+
+func TestEventHandler_SkipUnchanged_ForgetsOtherWrites(t *testing.T) {
+	t.Parallel()
+
+	mockOS, upserts, gets := countingSearchClient()
+	handler := New(mockOS, testutil.NewLogger(t), WithSkipUnchanged(10))
+
+	tutor := snapshotTutor(1, "Math")
+	require.NoError(t, handler.Handle(context.Background(), liveEvent("TutorCreated", tutor, time.Now())))
+	require.NoError(t, handler.Handle(context.Background(), verificationEvent("TutorVerified", `{"id": 1}`)))
+	require.NoError(t, handler.Handle(context.Background(), liveEvent("TutorUpdated", tutor, time.Now())))
+
+	assert.Equal(t, 2, *gets, "a verification changes is_verified, so the content must be fetched again")
+	assert.Equal(t, 2, *upserts)
+}
+
+func TestEventHandler_SkipUnchanged_DisabledByDefault(t *testing.T) {
+	t.Parallel()
+
+	mockOS, upserts, gets := countingSearchClient()
+	handler := New(mockOS, testutil.NewLogger(t))
+
+	tutor := snapshotTutor(1, "Math")
+	for range 2 {
+		require.NoError(t, handler.Handle(context.Background(), liveEvent("TutorUpdated", tutor, time.Now())))
+	}
+
+	assert.Equal(t, 2, *upserts)
+	assert.Zero(t, *gets, "without the option no document is fetched")
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"search/internal/activity"
+	"search/internal/domain"
+	"search/internal/kafka"
+	"search/internal/port"
+)
+
+// verificationPayload is the payload of TutorVerified and TutorUnverified
+// events. IsVerified is optional; when sent it must agree with the event
+// type.
+type verificationPayload struct {
+	ID         int64 `json:"id"`
+	IsVerified *bool `json:"is_verified"`
+}
+
+func (h *EventHandler) handleTutorVerified(ctx context.Context, event kafka.Event) error {
+	return h.handleVerification(ctx, event, true)
+}
+
+func (h *EventHandler) handleTutorUnverified(ctx context.Context, event kafka.Event) error {
+	return h.handleVerification(ctx, event, false)
+}
+
+// handleVerification sets the tutor's is_verified, stamping verified_at
+// with the event's created_at, or the current time when it has none. A
+// tutor that is not indexed is backfilled when a TutorSource is
+// configured, and skipped otherwise, like a booking.
+func (h *EventHandler) handleVerification(ctx context.Context, event kafka.Event, verified bool) error {
+	ctx, err := h.route(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	var payload verificationPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		return kafka.Permanent(fmt.Errorf("failed to unmarshal verification payload: %w", err))
+	}
+	if payload.ID <= 0 {
+		return kafka.Permanent(fmt.Errorf("invalid tutor ID in verification payload: %d", payload.ID))
+	}
+	if payload.IsVerified != nil && *payload.IsVerified != verified {
+		return kafka.Permanent(fmt.Errorf("%s payload for tutor %d has is_verified %t", event.EventType, payload.ID, *payload.IsVerified))
+	}
+
+	h.checkAggregateID(event, payload.ID)
+	h.recordEvent(ctx, event, payload.ID)
+
+	at, err := time.Parse(time.RFC3339Nano, event.CreatedAt)
+	if err != nil {
+		at = time.Now()
+	}
+	at = at.UTC().Truncate(domain.TimePrecision)
+
+	err = h.withBackfill(ctx, event, payload.ID, func() error {
+		return h.os.SetVerified(ctx, payload.ID, verified, at)
+	})
+	if errors.Is(err, port.ErrNotFound) {
+		h.logger.Info("Verification for tutor not in index, skipping",
+			"event_id", event.EventID,
+			"tutor_id", payload.ID,
+		)
+		return nil
+	}
+	if err != nil {
+		return writeError(fmt.Errorf("failed to update verification of tutor %d: %w", payload.ID, err))
+	}
+
+	h.logger.Info("Tutor verification updated",
+		"event_id", event.EventID,
+		"event_type", event.EventType,
+		"tutor_id", payload.ID,
+		"is_verified", verified,
+	)
+
+	h.activity.Publish(activity.Event{
+		Type:    activity.TypeUpsert,
+		Source:  activity.SourceKafka,
+		TutorID: payload.ID,
+		EventID: event.EventID,
+	})
+	return nil
+}
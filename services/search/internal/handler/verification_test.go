@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/domain"
+	"search/internal/kafka"
+	"search/internal/opensearch"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+func verificationEvent(eventType string, payload string) kafka.Event {
+	return kafka.Event{
+		EventID:       "v-1",
+		EventType:     eventType,
+		AggregateType: "Tutor",
+		CreatedAt:     "2026-03-01T09:30:00.123456+02:00",
+		Payload:       json.RawMessage(payload),
+	}
+}
+
+func TestEventHandler_VerificationEvents(t *testing.T) {
+	t.Parallel()
+
+	os := opensearch.NewMemoryClient()
+	require.NoError(t, os.UpsertTutor(context.Background(), &domain.Tutor{ID: 5, FullName: "Ada Lovelace", Rating: 4.5, ReviewsCount: 10}))
+	handler := New(os, testutil.NewLogger(t))
+
+	require.NoError(t, handler.Handle(context.Background(), verificationEvent("TutorVerified", `{"id": 5, "is_verified": true}`)))
+
+	tutor, err := os.GetTutor(context.Background(), 5)
+	require.NoError(t, err)
+	assert.True(t, tutor.IsVerified)
+	require.NotNil(t, tutor.VerifiedAt)
+	assert.Equal(t, time.Date(2026, 3, 1, 7, 30, 0, 123000000, time.UTC), *tutor.VerifiedAt)
+	assert.Equal(t, "Ada Lovelace", tutor.FullName, "other fields must be kept")
+
+	// A profile update without verified_at keeps it.
+	require.NoError(t, os.UpsertTutor(context.Background(), &domain.Tutor{ID: 5, FullName: "Ada King", IsVerified: true}))
+	tutor, err = os.GetTutor(context.Background(), 5)
+	require.NoError(t, err)
+	assert.NotNil(t, tutor.VerifiedAt)
+
+	require.NoError(t, handler.Handle(context.Background(), verificationEvent("TutorUnverified", `{"id": 5}`)))
+
+	tutor, err = os.GetTutor(context.Background(), 5)
+	require.NoError(t, err)
+	assert.False(t, tutor.IsVerified)
+	assert.Nil(t, tutor.VerifiedAt)
+}
+
+func TestEventHandler_VerificationPassesEventTime(t *testing.T) {
+	t.Parallel()
+
+	type call struct {
+		tutorID  int64
+		verified bool
+		at       time.Time
+	}
+	var calls []call
+	handler := New(&mockSearchClient{
+		verifyFunc: func(ctx context.Context, tutorID int64, verified bool, at time.Time) error {
+			calls = append(calls, call{tutorID, verified, at})
+			return nil
+		},
+	}, testutil.NewLogger(t))
+
+	require.NoError(t, handler.Handle(context.Background(), verificationEvent("TutorVerified", `{"id": 5}`)))
+	event := verificationEvent("TutorUnverified", `{"id": 6, "is_verified": false}`)
+	event.CreatedAt = ""
+	before := time.Now()
+	require.NoError(t, handler.Handle(context.Background(), event))
+
+	require.Len(t, calls, 2)
+	assert.Equal(t, call{5, true, time.Date(2026, 3, 1, 7, 30, 0, 123000000, time.UTC)}, calls[0])
+	assert.Equal(t, int64(6), calls[1].tutorID)
+	assert.False(t, calls[1].verified)
+	assert.False(t, calls[1].at.Before(before.Truncate(time.Millisecond)), "an event without created_at is stamped now")
+}
+
+func TestEventHandler_VerificationForUnindexedTutor_IsSkipped(t *testing.T) {
+	t.Parallel()
+
+	handler := New(&mockSearchClient{
+		verifyFunc: func(ctx context.Context, tutorID int64, verified bool, at time.Time) error {
+			return port.ErrNotFound
+		},
+	}, testutil.NewLogger(t))
+
+	err := handler.Handle(context.Background(), verificationEvent("TutorVerified", `{"id": 5}`))
+	assert.NoError(t, err)
+}
+
+func TestEventHandler_Verification_Backfill(t *testing.T) {
+	t.Parallel()
+
+	os := opensearch.NewMemoryClient()
+	source, calls := newDjangoStub(t, http.StatusOK, djangoTutor)
+	handler := New(os, testutil.NewLogger(t), WithBackfill(source))
+
+	require.NoError(t, handler.Handle(context.Background(), verificationEvent("TutorVerified", `{"id": 5}`)))
+
+	assert.Equal(t, int32(1), calls.Load())
+	tutor, err := os.GetTutor(context.Background(), 5)
+	require.NoError(t, err)
+	assert.Equal(t, "Ada Lovelace", tutor.FullName)
+	assert.True(t, tutor.IsVerified, "the verification must be applied after the backfill")
+	assert.NotNil(t, tutor.VerifiedAt)
+}
+
+func TestEventHandler_VerificationErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		event         kafka.Event
+		verifyErr     error
+		wantPermanent bool
+	}{
+		{
+			name:          "malformed payload",
+			event:         verificationEvent("TutorVerified", `{"id": "x"}`),
+			wantPermanent: true,
+		},
+		{
+			name:          "missing tutor ID",
+			event:         verificationEvent("TutorUnverified", `{"is_verified": false}`),
+			wantPermanent: true,
+		},
+		{
+			name:          "is_verified contradicts the event type",
+			event:         verificationEvent("TutorVerified", `{"id": 5, "is_verified": false}`),
+			wantPermanent: true,
+		},
+		{
+			name:      "backend failure is retried",
+			event:     verificationEvent("TutorUnverified", `{"id": 5}`),
+			verifyErr: errors.New("opensearch unavailable"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := New(&mockSearchClient{
+				verifyFunc: func(ctx context.Context, tutorID int64, verified bool, at time.Time) error {
+					return tt.verifyErr
+				},
+			}, testutil.NewLogger(t))
+
+			err := handler.Handle(context.Background(), tt.event)
+
+			require.Error(t, err)
+			assert.Equal(t, tt.wantPermanent, kafka.IsPermanent(err))
+		})
+	}
+}
@@ -0,0 +1,7 @@
+// Package integration holds end-to-end tests that run the service's
+// components against real infrastructure started with testcontainers. They
+// are built only with the integration tag and skip when Docker is not
+// available:
+//
+//	go test -tags integration ./internal/integration/...
+package integration
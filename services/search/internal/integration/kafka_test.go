@@ -0,0 +1,327 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+
+	"search/internal/domain"
+	"search/internal/handler"
+	"search/internal/kafka"
+	"search/internal/opensearch"
+	"search/internal/testutil"
+)
+
+// pipeline is a Consumer wired to an EventHandler and an in-memory index,
+// reading from its own topic and consumer group on the shared broker.
+type pipeline struct {
+	broker string
+	topic  string
+	group  string
+	index  *opensearch.MemoryClient
+}
+
+func newPipeline(t *testing.T) *pipeline {
+	t.Helper()
+	broker := redpandaBrokerAddress(t)
+
+	name := strings.NewReplacer("/", "-", "_", "-").Replace(strings.ToLower(t.Name()))
+	p := &pipeline{
+		broker: broker,
+		topic:  name,
+		group:  name + "-group",
+		index:  opensearch.NewMemoryClient(),
+	}
+	createTopic(t, broker, p.topic)
+	return p
+}
+
+func createTopic(t *testing.T, broker, topic string) {
+	t.Helper()
+	conn, err := kafkago.Dial("tcp", broker)
+	if err != nil {
+		t.Fatalf("failed to dial broker: %v", err)
+	}
+	defer conn.Close()
+
+	controller, err := conn.Controller()
+	if err != nil {
+		t.Fatalf("failed to find controller: %v", err)
+	}
+	cc, err := kafkago.Dial("tcp", net.JoinHostPort(controller.Host, strconv.Itoa(controller.Port)))
+	if err != nil {
+		t.Fatalf("failed to dial controller: %v", err)
+	}
+	defer cc.Close()
+
+	if err := cc.CreateTopics(kafkago.TopicConfig{Topic: topic, NumPartitions: 1, ReplicationFactor: 1}); err != nil {
+		t.Fatalf("failed to create topic %s: %v", topic, err)
+	}
+}
+
+// produce publishes events the way the Django outbox relay does: JSON
+// envelopes keyed by aggregate ID.
+func (p *pipeline) produce(t *testing.T, events ...kafka.Event) {
+	t.Helper()
+	w := &kafkago.Writer{
+		Addr:         kafkago.TCP(p.broker),
+		Topic:        p.topic,
+		RequiredAcks: kafkago.RequireAll,
+	}
+	defer w.Close()
+
+	msgs := make([]kafkago.Message, len(events))
+	for i, e := range events {
+		value, err := json.Marshal(e)
+		if err != nil {
+			t.Fatalf("failed to encode event: %v", err)
+		}
+		msgs[i] = kafkago.Message{Key: []byte(e.AggregateID), Value: value}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := w.WriteMessages(ctx, msgs...); err != nil {
+		t.Fatalf("failed to produce events: %v", err)
+	}
+}
+
+// start runs a consumer for the pipeline with h in front of the real event
+// handler and returns a function that stops it and waits for it to exit.
+func (p *pipeline) start(t *testing.T, h kafka.EventHandler) (stop func()) {
+	t.Helper()
+	consumer := kafka.NewConsumer(kafka.Config{
+		Brokers:     []string{p.broker},
+		Topic:       p.topic,
+		GroupID:     p.group,
+		StartOffset: kafkago.FirstOffset,
+	}, h, testutil.DiscardLogger(), kafka.WithRetryBackoff(20*time.Millisecond, 100*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- consumer.Start(ctx) }()
+
+	var once sync.Once
+	stop = func() {
+		once.Do(func() {
+			cancel()
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Errorf("consumer exited with error: %v", err)
+				}
+			case <-time.After(10 * time.Second):
+				t.Error("consumer did not stop")
+			}
+		})
+	}
+	t.Cleanup(stop)
+	return stop
+}
+
+func (p *pipeline) handler() kafka.EventHandler {
+	return handler.New(p.index, testutil.DiscardLogger())
+}
+
+// recordingHandler records every event ID it is given and fails transiently
+// for the IDs in failing until they are removed.
+type recordingHandler struct {
+	next kafka.EventHandler
+
+	mu      sync.Mutex
+	seen    []string
+	failing map[string]bool
+}
+
+func (r *recordingHandler) Handle(ctx context.Context, event kafka.Event) error {
+	r.mu.Lock()
+	r.seen = append(r.seen, event.EventID)
+	fail := r.failing[event.EventID]
+	r.mu.Unlock()
+
+	if fail {
+		return errors.New("simulated OpenSearch outage")
+	}
+	return r.next.Handle(ctx, event)
+}
+
+func (r *recordingHandler) heal(eventID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.failing, eventID)
+}
+
+func (r *recordingHandler) count(eventID string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, id := range r.seen {
+		if id == eventID {
+			n++
+		}
+	}
+	return n
+}
+
+func (r *recordingHandler) handled() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return slices.Compact(slices.Clone(r.seen))
+}
+
+func upsertEvent(t *testing.T, eventID, eventType string, tutor domain.Tutor) kafka.Event {
+	t.Helper()
+	payload, err := json.Marshal(tutor)
+	if err != nil {
+		t.Fatalf("failed to encode tutor: %v", err)
+	}
+	return kafka.Event{
+		EventID:       eventID,
+		EventType:     eventType,
+		AggregateType: "Tutor",
+		AggregateID:   strconv.FormatInt(tutor.ID, 10),
+		Payload:       payload,
+		CreatedAt:     time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+func deleteEvent(eventID string, id int64) kafka.Event {
+	return kafka.Event{
+		EventID:       eventID,
+		EventType:     "TutorDeleted",
+		AggregateType: "Tutor",
+		AggregateID:   strconv.FormatInt(id, 10),
+		Payload:       json.RawMessage(fmt.Sprintf(`{"id": %d}`, id)),
+		CreatedAt:     time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+// eventually polls cond until it holds or the deadline passes.
+func eventually(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(15 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+func indexedIDs(t *testing.T, index opensearch.SearchClient) []int64 {
+	t.Helper()
+	ids, err := index.IndexedTutorIDs(context.Background())
+	if err != nil {
+		t.Fatalf("IndexedTutorIDs failed: %v", err)
+	}
+	return ids
+}
+
+func TestKafka_PipelineIndexesAndDeletes(t *testing.T) {
+	p := newPipeline(t)
+	p.start(t, p.handler())
+
+	renamed := fixtureTutors[0]
+	renamed.Headline = "Radioactivity"
+	invalid := domain.Tutor{ID: 5, FullName: "Invalid Rating", Rating: 12}
+
+	p.produce(t,
+		upsertEvent(t, "e1", "TutorCreated", fixtureTutors[0]),
+		upsertEvent(t, "e2", "TutorCreated", fixtureTutors[1]),
+		upsertEvent(t, "e3", "TutorCreated", fixtureTutors[2]),
+		upsertEvent(t, "e4", "TutorUpdated", renamed),
+		deleteEvent("e5", fixtureTutors[1].ID),
+		deleteEvent("e6", 999),
+		upsertEvent(t, "e7", "TutorCreated", invalid),
+		upsertEvent(t, "e8", "TutorCreated", fixtureTutors[3]),
+	)
+
+	// e8 is last, so once tutor 4 is indexed every earlier event has been
+	// handled, including the quarantined e7.
+	eventually(t, "tutor 4 to be indexed", func() bool {
+		return slices.Contains(indexedIDs(t, p.index), fixtureTutors[3].ID)
+	})
+
+	if got := indexedIDs(t, p.index); !slices.Equal(got, []int64{1, 3, 4}) {
+		t.Errorf("expected indexed ids [1 3 4] (2 deleted, 5 quarantined), got %v", got)
+	}
+	resp, err := p.index.SearchTutors(context.Background(), opensearch.SearchQuery{Text: "radioactivity"})
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if ids := resultIDs(resp); !slices.Equal(ids, []int64{1}) {
+		t.Errorf("expected the update to be applied, got %v", ids)
+	}
+}
+
+// TestKafka_RetriesTransientFailureWithoutSkipping checks that a failing
+// event blocks the partition and is retried rather than dropped.
+func TestKafka_RetriesTransientFailureWithoutSkipping(t *testing.T) {
+	p := newPipeline(t)
+	rec := &recordingHandler{next: p.handler(), failing: map[string]bool{"e2": true}}
+	p.start(t, rec)
+
+	p.produce(t,
+		upsertEvent(t, "e1", "TutorCreated", fixtureTutors[0]),
+		upsertEvent(t, "e2", "TutorCreated", fixtureTutors[1]),
+		upsertEvent(t, "e3", "TutorCreated", fixtureTutors[2]),
+	)
+
+	eventually(t, "e2 to be retried", func() bool { return rec.count("e2") >= 3 })
+	if got := rec.count("e3"); got != 0 {
+		t.Fatalf("expected e3 to wait behind the failing e2, handled %d times", got)
+	}
+
+	rec.heal("e2")
+	eventually(t, "all tutors to be indexed", func() bool {
+		return len(indexedIDs(t, p.index)) == 3
+	})
+	if got := rec.handled(); !slices.Equal(got, []string{"e1", "e2", "e3"}) {
+		t.Errorf("expected events in order [e1 e2 e3], got %v", got)
+	}
+}
+
+// TestKafka_RestartResumesFromUncommittedOffset stops a consumer while an
+// event is failing and checks that its replacement in the same group
+// redelivers that event, but not the ones already committed.
+func TestKafka_RestartResumesFromUncommittedOffset(t *testing.T) {
+	p := newPipeline(t)
+	first := &recordingHandler{next: p.handler(), failing: map[string]bool{"e3": true}}
+	stop := p.start(t, first)
+
+	p.produce(t,
+		upsertEvent(t, "e1", "TutorCreated", fixtureTutors[0]),
+		upsertEvent(t, "e2", "TutorCreated", fixtureTutors[1]),
+		upsertEvent(t, "e3", "TutorCreated", fixtureTutors[2]),
+		upsertEvent(t, "e4", "TutorCreated", fixtureTutors[3]),
+	)
+
+	eventually(t, "e3 to fail", func() bool { return first.count("e3") >= 1 })
+	stop()
+
+	if got := indexedIDs(t, p.index); !slices.Equal(got, []int64{1, 2}) {
+		t.Fatalf("expected only e1 and e2 applied before restart, got %v", got)
+	}
+
+	second := &recordingHandler{next: p.handler()}
+	p.start(t, second)
+
+	eventually(t, "remaining tutors to be indexed", func() bool {
+		return len(indexedIDs(t, p.index)) == 4
+	})
+	if got := second.handled(); !slices.Equal(got, []string{"e3", "e4"}) {
+		t.Errorf("expected the restarted consumer to resume at e3, got %v", got)
+	}
+}
@@ -0,0 +1,126 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go"
+	tcopensearch "github.com/testcontainers/testcontainers-go/modules/opensearch"
+	"github.com/testcontainers/testcontainers-go/modules/redpanda"
+
+	"search/internal/opensearch"
+	"search/internal/testutil"
+)
+
+// Images match the versions in docker-compose.yml.
+const (
+	openSearchImage = "opensearchproject/opensearch:2.19.0"
+	redpandaImage   = "redpandadata/redpanda:v25.3.2"
+)
+
+// Containers are started on first use and shared by every test in the
+// package; TestMain terminates them after the run.
+var (
+	containersMu sync.Mutex
+	containers   []testcontainers.Container
+
+	openSearchOnce sync.Once
+	openSearchURL  string
+	openSearchErr  error
+
+	redpandaOnce   sync.Once
+	redpandaBroker string
+	redpandaErr    error
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+
+	containersMu.Lock()
+	for _, c := range containers {
+		_ = testcontainers.TerminateContainer(c)
+	}
+	containersMu.Unlock()
+
+	os.Exit(code)
+}
+
+func track(c testcontainers.Container) {
+	containersMu.Lock()
+	defer containersMu.Unlock()
+	containers = append(containers, c)
+}
+
+// skipWithoutDocker skips the test when no healthy Docker daemon is
+// reachable. testcontainers panics instead of erroring when it cannot find a
+// Docker host at all, so that case is recovered and turned into a skip too.
+func skipWithoutDocker(t *testing.T) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r != nil {
+			t.Skipf("Docker is not available: %v", r)
+		}
+	}()
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+}
+
+// newOpenSearchClient returns a client for the shared OpenSearch container
+// with a freshly recreated, empty tutors index. It skips the test when
+// Docker is unavailable.
+func newOpenSearchClient(t *testing.T) *opensearch.Client {
+	t.Helper()
+	skipWithoutDocker(t)
+
+	openSearchOnce.Do(func() {
+		ctx := context.Background()
+		c, err := tcopensearch.Run(ctx, openSearchImage)
+		if c != nil {
+			track(c)
+		}
+		if err != nil {
+			openSearchErr = err
+			return
+		}
+		openSearchURL, openSearchErr = c.Address(ctx)
+	})
+	if openSearchErr != nil {
+		t.Fatalf("failed to start OpenSearch: %v", openSearchErr)
+	}
+
+	client, err := opensearch.NewClient(openSearchURL, testutil.DiscardLogger())
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	if _, err := client.RecreateIndex(context.Background()); err != nil {
+		t.Fatalf("failed to reset index: %v", err)
+	}
+	return client
+}
+
+// redpandaBrokerAddress returns the seed broker of the shared Redpanda
+// container. It skips the test when Docker is unavailable.
+func redpandaBrokerAddress(t *testing.T) string {
+	t.Helper()
+	skipWithoutDocker(t)
+
+	redpandaOnce.Do(func() {
+		ctx := context.Background()
+		c, err := redpanda.Run(ctx, redpandaImage)
+		if c != nil {
+			track(c)
+		}
+		if err != nil {
+			redpandaErr = err
+			return
+		}
+		redpandaBroker, redpandaErr = c.KafkaSeedBroker(ctx)
+	})
+	if redpandaErr != nil {
+		t.Fatalf("failed to start Redpanda: %v", redpandaErr)
+	}
+	return redpandaBroker
+}
@@ -0,0 +1,278 @@
+//go:build integration
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"search/internal/domain"
+	"search/internal/opensearch"
+)
+
+var fixtureTutors = []domain.Tutor{
+	{ID: 1, Slug: "marie-curie", FullName: "Marie Curie", Headline: "Physics and chemistry", Bio: "Nobel laureate", Subjects: []string{"physics", "chemistry"}, HourlyRate: 60, Rating: 5, Location: "Paris", Formats: []string{"offline"}},
+	{ID: 2, Slug: "alan-turing", FullName: "Alan Turing", Headline: "Math and computing", Bio: "Enjoys physics puzzles", Subjects: []string{"math"}, HourlyRate: 35, Rating: 4.6, Location: "London", Formats: []string{"online"}},
+	{ID: 3, Slug: "ada-lovelace", FullName: "Ada Lovelace", Headline: "Mathematics tutor", Bio: "First programmer", Subjects: []string{"math", "programming"}, HourlyRate: 45, Rating: 4.9, Location: "London", Formats: []string{"online", "offline"}},
+	{ID: 4, Slug: "richard-feynman", FullName: "Richard Feynman", Headline: "Physics made fun", Bio: "Bongo player", Subjects: []string{"physics"}, HourlyRate: 80, Rating: 4.2, Location: "Pasadena", Formats: []string{"online"}},
+}
+
+func ptr(v float64) *float64 { return &v }
+
+func indexFixtures(t *testing.T, client opensearch.SearchClient) {
+	t.Helper()
+	for i := range fixtureTutors {
+		tutor := fixtureTutors[i]
+		if err := client.UpsertTutor(context.Background(), &tutor); err != nil {
+			t.Fatalf("failed to index tutor %d: %v", tutor.ID, err)
+		}
+	}
+}
+
+func resultIDs(resp *opensearch.SearchResponse) []int64 {
+	ids := make([]int64, len(resp.Results))
+	for i, tutor := range resp.Results {
+		ids[i] = tutor.ID
+	}
+	return ids
+}
+
+func TestOpenSearch_EnsureIndexIsIdempotent(t *testing.T) {
+	client := newOpenSearchClient(t)
+	ctx := context.Background()
+
+	for range 2 {
+		if err := client.EnsureIndex(ctx); err != nil {
+			t.Fatalf("EnsureIndex failed: %v", err)
+		}
+	}
+	if err := client.Ping(ctx); err != nil {
+		t.Errorf("ping failed: %v", err)
+	}
+}
+
+// TestOpenSearch_FiltersMatchMemoryClient runs every filter against the real
+// cluster and the in-memory client; both must return the same tutors.
+func TestOpenSearch_FiltersMatchMemoryClient(t *testing.T) {
+	client := newOpenSearchClient(t)
+	memory := opensearch.NewMemoryClient()
+	indexFixtures(t, client)
+	indexFixtures(t, memory)
+
+	tests := []struct {
+		name  string
+		query opensearch.SearchQuery
+		want  []int64
+	}{
+		{"match all", opensearch.SearchQuery{}, []int64{1, 2, 3, 4}},
+		{"subjects", opensearch.SearchQuery{Subjects: []string{"chemistry", "programming"}}, []int64{1, 3}},
+		{"min price", opensearch.SearchQuery{MinPrice: ptr(60)}, []int64{1, 4}},
+		{"max price", opensearch.SearchQuery{MaxPrice: ptr(45)}, []int64{2, 3}},
+		{"price range", opensearch.SearchQuery{MinPrice: ptr(40), MaxPrice: ptr(70)}, []int64{1, 3}},
+		{"min rating", opensearch.SearchQuery{MinRating: ptr(4.8)}, []int64{1, 3}},
+		{"format", opensearch.SearchQuery{Format: "offline"}, []int64{1, 3}},
+		{"location", opensearch.SearchQuery{Location: "London"}, []int64{2, 3}},
+		{"exclude ids", opensearch.SearchQuery{ExcludeIDs: []int64{1, 3}}, []int64{2, 4}},
+		{"combined", opensearch.SearchQuery{Subjects: []string{"math"}, Format: "online", MinRating: ptr(4.7)}, []int64{3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, c := range map[string]opensearch.SearchClient{"opensearch": client, "memory": memory} {
+				resp, err := c.SearchTutors(context.Background(), tt.query)
+				if err != nil {
+					t.Fatalf("%s: search failed: %v", name, err)
+				}
+				got := resultIDs(resp)
+				slices.Sort(got)
+				if !slices.Equal(got, tt.want) {
+					t.Errorf("%s: expected %v, got %v", name, tt.want, got)
+				}
+				if resp.Total != len(tt.want) {
+					t.Errorf("%s: expected total %d, got %d", name, len(tt.want), resp.Total)
+				}
+			}
+		})
+	}
+}
+
+func TestOpenSearch_TextSearch(t *testing.T) {
+	client := newOpenSearchClient(t)
+	indexFixtures(t, client)
+
+	tests := []struct {
+		name     string
+		text     string
+		wantIDs  []int64
+		wantTop  int64
+		anyOrder bool
+	}{
+		{name: "exact name", text: "Marie", wantIDs: []int64{1}, wantTop: 1},
+		{name: "typo is tolerated", text: "marei", wantIDs: []int64{1}, wantTop: 1},
+		{name: "prefix", text: "Lovel", wantIDs: []int64{3}, wantTop: 3},
+		{name: "stemming", text: "puzzle", wantIDs: []int64{2}, wantTop: 2},
+		{name: "headline outranks bio", text: "physics", wantIDs: []int64{1, 2, 4}, wantTop: 4, anyOrder: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.SearchTutors(context.Background(), opensearch.SearchQuery{Text: tt.text})
+			if err != nil {
+				t.Fatalf("search failed: %v", err)
+			}
+			got := resultIDs(resp)
+			if len(got) == 0 {
+				t.Fatalf("expected results for %q", tt.text)
+			}
+
+			sorted := slices.Sorted(slices.Values(got))
+			if !slices.Equal(sorted, tt.wantIDs) {
+				t.Errorf("expected ids %v, got %v", tt.wantIDs, got)
+			}
+			if tt.anyOrder {
+				// Both headline matches must rank above the bio-only match.
+				if got[len(got)-1] != 2 {
+					t.Errorf("expected bio-only match 2 to rank last, got %v", got)
+				}
+			} else if got[0] != tt.wantTop {
+				t.Errorf("expected %d first, got %v", tt.wantTop, got)
+			}
+		})
+	}
+}
+
+func TestOpenSearch_Pagination(t *testing.T) {
+	client := newOpenSearchClient(t)
+	indexFixtures(t, client)
+	ctx := context.Background()
+
+	var seen []int64
+	for offset := 0; offset < len(fixtureTutors); offset += 3 {
+		resp, err := client.SearchTutors(ctx, opensearch.SearchQuery{Limit: 3, Offset: offset})
+		if err != nil {
+			t.Fatalf("search failed: %v", err)
+		}
+		if resp.Total != len(fixtureTutors) {
+			t.Errorf("expected total %d on every page, got %d", len(fixtureTutors), resp.Total)
+		}
+		seen = append(seen, resultIDs(resp)...)
+	}
+
+	slices.Sort(seen)
+	if !slices.Equal(seen, []int64{1, 2, 3, 4}) {
+		t.Errorf("expected pages to cover every tutor exactly once, got %v", seen)
+	}
+}
+
+func TestOpenSearch_UpsertDeleteRoundTrip(t *testing.T) {
+	client := newOpenSearchClient(t)
+	ctx := context.Background()
+	indexFixtures(t, client)
+
+	updated := fixtureTutors[1]
+	updated.Headline = "Cryptography"
+	if err := client.UpsertTutor(ctx, &updated); err != nil {
+		t.Fatalf("upsert failed: %v", err)
+	}
+	resp, err := client.SearchTutors(ctx, opensearch.SearchQuery{Text: "cryptography"})
+	if err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if ids := resultIDs(resp); !slices.Equal(ids, []int64{2}) {
+		t.Errorf("expected updated tutor to be searchable immediately, got %v", ids)
+	}
+
+	if err := client.DeleteTutor(ctx, 2); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if err := client.DeleteTutor(ctx, 2); !errors.Is(err, opensearch.ErrNotFound) {
+		t.Errorf("expected ErrNotFound on second delete, got %v", err)
+	}
+
+	ids, err := client.IndexedTutorIDs(ctx)
+	if err != nil {
+		t.Fatalf("IndexedTutorIDs failed: %v", err)
+	}
+	if !slices.Equal(ids, []int64{1, 3, 4}) {
+		t.Errorf("expected indexed ids [1 3 4], got %v", ids)
+	}
+}
+
+func TestOpenSearch_IDAbove32Bits(t *testing.T) {
+	client := newOpenSearchClient(t)
+	ctx := context.Background()
+
+	tutor := domain.Tutor{ID: 1 << 31, Slug: "big-id", FullName: "Big ID", Subjects: []string{"math"}}
+	if err := client.UpsertTutor(ctx, &tutor); err != nil {
+		t.Fatalf("upsert failed: %v", err)
+	}
+	got, err := client.GetTutor(ctx, tutor.ID)
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if got.ID != tutor.ID {
+		t.Errorf("expected ID %d, got %d", tutor.ID, got.ID)
+	}
+}
+
+func TestOpenSearch_RecreateIndexReportsCounts(t *testing.T) {
+	client := newOpenSearchClient(t)
+	indexFixtures(t, client)
+
+	result, err := client.RecreateIndex(context.Background())
+	if err != nil {
+		t.Fatalf("recreate failed: %v", err)
+	}
+	if result.OldCount != int64(len(fixtureTutors)) || result.NewCount != 0 {
+		t.Errorf("expected counts %d/0, got %d/%d", len(fixtureTutors), result.OldCount, result.NewCount)
+	}
+}
+
+// TestOpenSearch_AvailabilityMatchesMemoryClient runs the booking scripts
+// against the real cluster and checks they agree with the in-memory client.
+func TestOpenSearch_AvailabilityMatchesMemoryClient(t *testing.T) {
+	client := newOpenSearchClient(t)
+	memory := opensearch.NewMemoryClient()
+	ctx := context.Background()
+	indexFixtures(t, client)
+	indexFixtures(t, memory)
+
+	soon := time.Now().Add(36 * time.Hour).UTC().Truncate(time.Second)
+	later := soon.Add(5 * 24 * time.Hour)
+
+	for _, c := range []opensearch.SearchClient{client, memory} {
+		steps := []error{
+			c.FreeSlot(ctx, 1, later),
+			c.FreeSlot(ctx, 1, soon),
+			c.FreeSlot(ctx, 2, later),
+			c.BookSlot(ctx, 2, later),
+			c.FreeSlot(ctx, 3, later),
+		}
+		if err := errors.Join(steps...); err != nil {
+			t.Fatalf("availability update failed: %v", err)
+		}
+		// Profile updates keep availability.
+		tutor := fixtureTutors[0]
+		if err := c.UpsertTutor(ctx, &tutor); err != nil {
+			t.Fatalf("upsert failed: %v", err)
+		}
+	}
+
+	for _, days := range []int{1, 2, 7} {
+		want, _ := memory.SearchTutors(ctx, opensearch.SearchQuery{AvailableWithinDays: days})
+		got, err := client.SearchTutors(ctx, opensearch.SearchQuery{AvailableWithinDays: days})
+		if err != nil {
+			t.Fatalf("search failed: %v", err)
+		}
+		if !slices.Equal(resultIDs(got), resultIDs(want)) {
+			t.Errorf("available_within_days=%d: expected %v, got %v", days, resultIDs(want), resultIDs(got))
+		}
+	}
+
+	if err := client.BookSlot(ctx, 99, soon); !errors.Is(err, opensearch.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unindexed tutor, got %v", err)
+	}
+}
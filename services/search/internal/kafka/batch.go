@@ -0,0 +1,259 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// DefaultBatchWait is how long a batch waits to fill unless WithBatching
+// says otherwise.
+const DefaultBatchWait = 100 * time.Millisecond
+
+// BatchEventHandler is an EventHandler that can also handle several events
+// at once.
+type BatchEventHandler interface {
+	EventHandler
+	// HandleBatch handles events in order and returns the error for each,
+	// nil for those that succeeded. Once an event fails other than
+	// permanently, later events that must not overtake it fail too without
+	// being applied: each is retried on its own after it.
+	HandleBatch(ctx context.Context, events []Event) []error
+}
+
+// WithBatching makes the consumer fetch up to size messages, waiting at
+// most wait after the first for the rest, and hand their events to the
+// handler's HandleBatch together. Offsets are still committed per message;
+// see consumeBatch. Sizes below 2, or a handler that is not a
+// BatchEventHandler, keep handling one message at a time. Zero wait keeps
+// DefaultBatchWait.
+func WithBatching(size int, wait time.Duration) ConsumerOption {
+	return func(c *Consumer) {
+		if wait <= 0 {
+			wait = DefaultBatchWait
+		}
+		c.batchSize = size
+		c.batchWait = wait
+	}
+}
+
+// batchHandler returns the handler to pass batches to, if batching is on.
+func (c *Consumer) batchHandler() (BatchEventHandler, bool) {
+	if c.batchSize < 2 {
+		return nil, false
+	}
+	h, ok := c.handler.(BatchEventHandler)
+	return h, ok
+}
+
+// batchMessage is a fetched message of a batch.
+type batchMessage struct {
+	msg   kafka.Message
+	event Event
+	// decoded is false for a message quarantined or dropped unread.
+	decoded bool
+	// done is set once the message may be committed: handled, quarantined,
+	// or never decodable.
+	done      bool
+	committed bool
+}
+
+// partitionKey identifies a partition across the consumer's topics.
+type partitionKey struct {
+	topic     string
+	partition int
+}
+
+// consumeBatch fetches, handles and commits one batch from reader. It
+// returns false if ctx ended, so the consumer must stop.
+//
+// The batch's events go to HandleBatch in one call. Those that succeeded
+// or failed permanently (and are quarantined) are done; on each partition
+// the offsets of the done messages before the first one that is not are
+// committed straight away. Every failed event is then retried on its own,
+// as process does, in fetch order (which is why HandleBatch fails the
+// events that must wait for one), and committed as soon as it and the
+// messages before it on its partition are done. A message is only ever
+// committed once all earlier ones on its partition are, so a restart
+// resumes from the first unfinished one, and nothing is committed once
+// Seek has replaced the reader.
+func (c *Consumer) consumeBatch(ctx context.Context, reader MessageReader, generation uint64, handler BatchEventHandler) bool {
+	batch, ok := c.fetchBatch(ctx, reader, generation)
+	if !ok {
+		return ctx.Err() == nil
+	}
+	var events []Event
+	var handled []*batchMessage
+	for _, m := range batch {
+		if m.decoded {
+			events = append(events, m.event)
+			handled = append(handled, m)
+		}
+	}
+
+	if len(events) > 0 {
+		if !c.enter(ctx, generation) {
+			return ctx.Err() == nil
+		}
+		errs := c.handleBatch(ctx, handler, events)
+		c.exit()
+
+		for i, m := range handled {
+			var err error
+			if i < len(errs) {
+				err = errs[i]
+			} else {
+				err = errors.New("no result from batch handler")
+			}
+			switch {
+			case err == nil:
+				m.done = true
+				c.logProcessed(m.msg, m.event)
+			case IsPermanent(err):
+				m.done = true
+				c.quarantine(m.msg, m.event, err)
+			default:
+				c.logger.Warn("Failed to handle event in batch, retrying on its own",
+					"event_id", m.event.EventID,
+					"event_type", m.event.EventType,
+					"aggregate_id", m.event.AggregateID,
+					"partition", m.msg.Partition,
+					"offset", m.msg.Offset,
+					"error", err,
+				)
+				c.reportError(&m.event, err)
+			}
+		}
+	}
+
+	if c.replaced(generation) {
+		return true
+	}
+	c.commitDone(ctx, reader, batch)
+
+	for _, m := range batch {
+		if m.done {
+			continue
+		}
+		if !c.process(ctx, generation, m.msg, m.event) {
+			return ctx.Err() == nil
+		}
+		if c.replaced(generation) {
+			return true
+		}
+		m.done = true
+		c.commitDone(ctx, reader, batch)
+	}
+	return true
+}
+
+// fetchBatch fetches up to batchSize messages, blocking for the first and
+// waiting at most batchWait for the rest. Oversized and undecodable
+// messages are quarantined or logged as for single messages and come back
+// done. It returns false if ctx ended, or Seek replaced the reader, before
+// anything was fetched.
+func (c *Consumer) fetchBatch(ctx context.Context, reader MessageReader, generation uint64) ([]*batchMessage, bool) {
+	msg, err := reader.FetchMessage(ctx)
+	if err != nil {
+		if ctx.Err() == nil && !c.replaced(generation) {
+			c.logger.Error("Failed to read message", "error", err)
+			c.reportError(nil, err)
+		}
+		return nil, false
+	}
+	batch := []*batchMessage{c.decode(msg)}
+
+	fill, cancel := context.WithTimeout(ctx, c.batchWait)
+	defer cancel()
+	for len(batch) < c.batchSize {
+		msg, err := reader.FetchMessage(fill)
+		if err != nil {
+			if fill.Err() == nil && !c.replaced(generation) {
+				c.logger.Error("Failed to read message", "error", err)
+				c.reportError(nil, err)
+			}
+			break
+		}
+		batch = append(batch, c.decode(msg))
+	}
+	return batch, true
+}
+
+// decode reads the event of msg, quarantining it when too large and
+// logging it when invalid.
+func (c *Consumer) decode(msg kafka.Message) *batchMessage {
+	c.recordRead(msg)
+	m := &batchMessage{msg: msg}
+	if len(msg.Value) > c.maxMessageBytes {
+		c.quarantineOversized(msg)
+		m.done = true
+		return m
+	}
+	if err := json.Unmarshal(msg.Value, &m.event); err != nil {
+		c.logger.Error("Failed to unmarshal event",
+			"error", err,
+			"offset", msg.Offset,
+		)
+		c.reportError(nil, err)
+		m.done = true
+		return m
+	}
+	m.decoded = true
+	return m
+}
+
+// handleBatch makes one attempt at events within the handle timeout.
+func (c *Consumer) handleBatch(ctx context.Context, handler BatchEventHandler, events []Event) []error {
+	if c.handleTimeout <= 0 {
+		return handler.HandleBatch(ctx, events)
+	}
+	hctx, cancel := context.WithTimeout(ctx, c.handleTimeout)
+	defer cancel()
+	errs := handler.HandleBatch(hctx, events)
+	if ctx.Err() == nil && errors.Is(hctx.Err(), context.DeadlineExceeded) {
+		for i, err := range errs {
+			if err != nil && !IsPermanent(err) {
+				errs[i] = fmt.Errorf("handling timed out after %s: %w", c.handleTimeout, err)
+			}
+		}
+	}
+	return errs
+}
+
+// commitDone commits, on each partition, the last done message of batch
+// that has only done messages before it, unless it is committed already.
+func (c *Consumer) commitDone(ctx context.Context, reader MessageReader, batch []*batchMessage) {
+	blocked := make(map[partitionKey]bool)
+	var order []partitionKey
+	prefixes := make(map[partitionKey][]*batchMessage)
+	for _, m := range batch {
+		key := partitionKey{topic: m.msg.Topic, partition: m.msg.Partition}
+		if blocked[key] {
+			continue
+		}
+		if !m.done {
+			blocked[key] = true
+			continue
+		}
+		if _, ok := prefixes[key]; !ok {
+			order = append(order, key)
+		}
+		prefixes[key] = append(prefixes[key], m)
+	}
+
+	for _, key := range order {
+		prefix := prefixes[key]
+		last := prefix[len(prefix)-1]
+		if last.committed {
+			continue
+		}
+		c.commit(ctx, reader, last.msg)
+		for _, m := range prefix {
+			m.committed = true
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockBatchHandler fails the events named in batchErrs when they come in a
+// batch; retried on their own through Handle, they succeed.
+type mockBatchHandler struct {
+	mockEventHandler
+	batchErrs map[string]error
+
+	batchMu sync.Mutex
+	batches [][]string
+}
+
+func (m *mockBatchHandler) HandleBatch(_ context.Context, events []Event) []error {
+	m.batchMu.Lock()
+	defer m.batchMu.Unlock()
+	errs := make([]error, len(events))
+	var ids []string
+	for i, event := range events {
+		errs[i] = m.batchErrs[event.EventID]
+		ids = append(ids, event.EventID)
+	}
+	m.batches = append(m.batches, ids)
+	return errs
+}
+
+func (m *mockBatchHandler) getBatches() [][]string {
+	m.batchMu.Lock()
+	defer m.batchMu.Unlock()
+	return append([][]string{}, m.batches...)
+}
+
+func batchMessages(partition int, offsets ...int64) []kafka.Message {
+	msgs := make([]kafka.Message, len(offsets))
+	for i, offset := range offsets {
+		value, _ := json.Marshal(Event{EventID: fmt.Sprintf("p%d-%d", partition, offset), EventType: "TutorUpdated"})
+		msgs[i] = kafka.Message{Topic: "tutor-events", Partition: partition, Offset: offset, Value: value}
+	}
+	return msgs
+}
+
+// runUntilCommitted starts consumer and stops it once reader has committed
+// n times.
+func runUntilCommitted(t *testing.T, consumer *Consumer, reader *mockKafkaReader, n int) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- consumer.Start(ctx) }()
+
+	require.Eventually(t, func() bool { return len(reader.getCommitted()) >= n }, time.Second, time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestConsumer_Batch_RetriesFailedItemsOnTheirOwn(t *testing.T) {
+	reader := &mockKafkaReader{messages: batchMessages(0, 0, 1, 2, 3, 4, 5)}
+	transient := errors.New("shard unavailable")
+	handler := &mockBatchHandler{batchErrs: map[string]error{"p0-1": transient, "p0-4": transient}}
+	consumer := NewConsumerWithReader(reader, handler, discardLogger(),
+		WithBatching(10, 10*time.Millisecond),
+		WithRetryBackoff(time.Millisecond, time.Millisecond),
+	)
+
+	runUntilCommitted(t, consumer, reader, 3)
+
+	assert.Equal(t, [][]string{{"p0-0", "p0-1", "p0-2", "p0-3", "p0-4", "p0-5"}}, handler.getBatches())
+	var retried []string
+	for _, e := range handler.getHandledEvents() {
+		retried = append(retried, e.EventID)
+	}
+	assert.Equal(t, []string{"p0-1", "p0-4"}, retried, "only the failed events are retried")
+	assert.Equal(t, []int64{0, 3, 5}, reader.getCommitted(),
+		"each commit covers the done messages before the next unfinished one")
+}
+
+func TestConsumer_Batch_QuarantinesPermanentFailures(t *testing.T) {
+	reader := &mockKafkaReader{messages: batchMessages(0, 0, 1, 2, 3, 4, 5)}
+	rejected := Permanent(errors.New("mapper_parsing_exception"))
+	handler := &mockBatchHandler{batchErrs: map[string]error{"p0-1": rejected, "p0-4": rejected}}
+	var quarantined []string
+	consumer := NewConsumerWithReader(reader, handler, discardLogger(),
+		WithBatching(10, 10*time.Millisecond),
+		WithRetryBackoff(time.Hour, time.Hour),
+		WithErrorHook(func(event *Event, err error) {
+			if event != nil && IsPermanent(err) {
+				quarantined = append(quarantined, event.EventID)
+			}
+		}),
+	)
+
+	runUntilCommitted(t, consumer, reader, 1)
+
+	assert.Equal(t, []int64{5}, reader.getCommitted())
+	assert.Equal(t, []string{"p0-1", "p0-4"}, quarantined)
+	assert.Empty(t, handler.getHandledEvents(), "quarantined events are not retried")
+}
+
+func TestConsumer_Batch_PartitionsCommitIndependently(t *testing.T) {
+	p0, p1 := batchMessages(0, 10, 11), batchMessages(1, 20, 21)
+	reader := &mockKafkaReader{messages: []kafka.Message{p0[0], p1[0], p0[1], p1[1]}}
+	handler := &mockBatchHandler{batchErrs: map[string]error{"p0-10": errors.New("timeout")}}
+	consumer := NewConsumerWithReader(reader, handler, discardLogger(),
+		WithBatching(10, 10*time.Millisecond),
+		WithRetryBackoff(time.Millisecond, time.Millisecond),
+	)
+
+	runUntilCommitted(t, consumer, reader, 2)
+
+	assert.Equal(t, []int64{21, 11}, reader.getCommitted(),
+		"partition 1 is committed while partition 0 waits for its retry")
+}
+
+func TestConsumer_Batch_HandlesUndecodableMessages(t *testing.T) {
+	msgs := batchMessages(0, 0, 1, 2)
+	msgs[1].Value = []byte("not json")
+	reader := &mockKafkaReader{messages: msgs}
+	handler := &mockBatchHandler{}
+	consumer := NewConsumerWithReader(reader, handler, discardLogger(), WithBatching(10, 10*time.Millisecond))
+
+	runUntilCommitted(t, consumer, reader, 1)
+
+	assert.Equal(t, [][]string{{"p0-0", "p0-2"}}, handler.getBatches())
+	assert.Equal(t, []int64{2}, reader.getCommitted())
+}
+
+func TestConsumer_Batch_NeedsABatchHandler(t *testing.T) {
+	reader := &mockKafkaReader{messages: batchMessages(0, 0, 1, 2)}
+	handler := &mockEventHandler{}
+	consumer := NewConsumerWithReader(reader, handler, discardLogger(), WithBatching(10, 10*time.Millisecond))
+
+	runUntilCommitted(t, consumer, reader, 3)
+
+	assert.Equal(t, []int64{0, 1, 2}, reader.getCommitted(), "a plain handler gets one message at a time")
+	assert.Len(t, handler.getHandledEvents(), 3)
+}
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
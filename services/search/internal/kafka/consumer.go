@@ -3,14 +3,43 @@ package kafka
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/segmentio/kafka-go"
 )
 
-// MessageReader is an interface for reading Kafka messages.
+// Default backoff between attempts at an event whose handler failed with a
+// transient error.
+const (
+	DefaultRetryBackoff    = time.Second
+	DefaultMaxRetryBackoff = 30 * time.Second
+)
+
+// DefaultMaxMessageBytes is the largest message the consumer decodes unless
+// WithMaxMessageBytes says otherwise. Tutor events are a few kilobytes.
+const DefaultMaxMessageBytes = 1 << 20
+
+// DefaultHandleTimeout bounds one attempt at handling an event unless
+// WithHandleTimeout says otherwise.
+const DefaultHandleTimeout = 30 * time.Second
+
+// MaxFetchBytes is the most the reader fetches from a partition at once,
+// and so the largest message it can deliver.
+const MaxFetchBytes = 10e6
+
+// sampleBytes is how much of an oversized message is logged.
+const sampleBytes = 512
+
+// MessageReader is an interface for reading Kafka messages. Offsets are
+// committed explicitly so that a message is only acknowledged once handled.
 type MessageReader interface {
-	ReadMessage(ctx context.Context) (kafka.Message, error)
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
 	Close() error
 	Config() kafka.ReaderConfig
 }
@@ -20,65 +49,240 @@ type EventHandler interface {
 	Handle(ctx context.Context, event Event) error
 }
 
+// ErrorHook is notified of every message the consumer fails to read, decode
+// or handle. event is nil when the failure happened before decoding.
+type ErrorHook func(event *Event, err error)
+
 // Consumer reads events from Kafka and processes them.
 type Consumer struct {
-	reader  MessageReader
 	handler EventHandler
 	logger  *slog.Logger
+	onError ErrorHook
+
+	// newReader and offsets back Seek; either may be nil.
+	newReader func() MessageReader
+	offsets   GroupOffsets
+	// seekMu serializes Seek calls.
+	seekMu sync.Mutex
+
+	retryBackoff    time.Duration
+	maxRetryBackoff time.Duration
+	maxMessageBytes int
+	handleTimeout   time.Duration
+	idleHeartbeat   time.Duration
+	// batchSize and batchWait are set by WithBatching.
+	batchSize int
+	batchWait time.Duration
+	now       func() time.Time
+
+	// lastMessage is the Unix nanosecond time of the last fetched message.
+	lastMessage atomic.Int64
+
+	// statusMu guards startedAt, lastPosition, heartbeats and
+	// lastHeartbeat.
+	statusMu      sync.Mutex
+	startedAt     time.Time
+	lastPosition  *Position
+	heartbeats    int64
+	lastHeartbeat time.Time
+
+	// mu guards reader, generation, seeking, paused and busy; cond signals
+	// changes to them.
+	mu     sync.Mutex
+	cond   *sync.Cond
+	reader MessageReader
+	// generation counts the readers Seek has replaced.
+	generation uint64
+	seeking    bool
+	paused     bool
+	busy       bool
+}
+
+// ConsumerOption configures optional Consumer behaviour.
+type ConsumerOption func(*Consumer)
+
+// WithErrorHook registers hook to observe consumer failures.
+func WithErrorHook(hook ErrorHook) ConsumerOption {
+	return func(c *Consumer) {
+		c.onError = hook
+	}
+}
+
+// WithRetryBackoff sets the delay before the first retry of a transiently
+// failed event and the cap it doubles up to.
+func WithRetryBackoff(initial, maxBackoff time.Duration) ConsumerOption {
+	return func(c *Consumer) {
+		c.retryBackoff = initial
+		c.maxRetryBackoff = maxBackoff
+	}
+}
+
+// WithMaxMessageBytes sets the size above which a message is quarantined
+// without being decoded.
+func WithMaxMessageBytes(n int) ConsumerOption {
+	return func(c *Consumer) {
+		c.maxMessageBytes = n
+	}
+}
+
+// WithHandleTimeout bounds each attempt at handling an event. An attempt
+// that runs out of time is retried like any transient failure. Zero
+// disables the timeout.
+func WithHandleTimeout(d time.Duration) ConsumerOption {
+	return func(c *Consumer) {
+		c.handleTimeout = d
+	}
+}
+
+// WithGroupOffsets gives the consumer access to its group's offsets for
+// Offsets and Seek. NewConsumer sets it from its Config.
+func WithGroupOffsets(g GroupOffsets) ConsumerOption {
+	return func(c *Consumer) {
+		c.offsets = g
+	}
+}
+
+// WithReaderFactory sets how Seek replaces the reader after moving the
+// group's offsets. NewConsumer sets it from its Config.
+func WithReaderFactory(newReader func() MessageReader) ConsumerOption {
+	return func(c *Consumer) {
+		c.newReader = newReader
+	}
 }
 
 // Config holds Kafka consumer configuration.
 type Config struct {
 	Brokers []string
 	Topic   string
-	GroupID string
+	// ExtraTopics are read by the same consumer group alongside Topic. Their
+	// events go to the same handler.
+	ExtraTopics []string
+	GroupID     string
+	// StartOffset is where a new consumer group starts reading:
+	// kafka.FirstOffset (the default) or kafka.LastOffset.
+	StartOffset int64
 }
 
 // NewConsumer creates a new Kafka consumer.
-func NewConsumer(cfg Config, handler EventHandler, logger *slog.Logger) *Consumer {
-	reader := kafka.NewReader(kafka.ReaderConfig{
-		Brokers:  cfg.Brokers,
-		Topic:    cfg.Topic,
-		GroupID:  cfg.GroupID,
-		MinBytes: 1,
-		MaxBytes: 10e6,
-	})
+func NewConsumer(cfg Config, handler EventHandler, logger *slog.Logger, opts ...ConsumerOption) *Consumer {
+	newReader := func() MessageReader {
+		return kafka.NewReader(readerConfig(cfg))
+	}
+	opts = append([]ConsumerOption{
+		WithReaderFactory(newReader),
+		WithGroupOffsets(NewGroupOffsetAdmin(cfg)),
+	}, opts...)
+	return NewConsumerWithReader(newReader(), handler, logger, opts...)
+}
 
-	return &Consumer{
-		reader:  reader,
-		handler: handler,
-		logger:  logger,
+// readerConfig subscribes to Topic alone, or to Topic and ExtraTopics as
+// group topics when there are several.
+func readerConfig(cfg Config) kafka.ReaderConfig {
+	rc := kafka.ReaderConfig{
+		Brokers:     cfg.Brokers,
+		Topic:       cfg.Topic,
+		GroupID:     cfg.GroupID,
+		StartOffset: cfg.StartOffset,
+		MinBytes:    1,
+		MaxBytes:    MaxFetchBytes,
+	}
+	if len(cfg.ExtraTopics) > 0 {
+		rc.Topic = ""
+		rc.GroupTopics = append([]string{cfg.Topic}, cfg.ExtraTopics...)
 	}
+	return rc
 }
 
 // NewConsumerWithReader creates a new Kafka consumer with a custom reader (for testing).
-func NewConsumerWithReader(reader MessageReader, handler EventHandler, logger *slog.Logger) *Consumer {
-	return &Consumer{
-		reader:  reader,
-		handler: handler,
-		logger:  logger,
+func NewConsumerWithReader(reader MessageReader, handler EventHandler, logger *slog.Logger, opts ...ConsumerOption) *Consumer {
+	c := &Consumer{
+		reader:          reader,
+		handler:         handler,
+		logger:          logger,
+		retryBackoff:    DefaultRetryBackoff,
+		maxRetryBackoff: DefaultMaxRetryBackoff,
+		maxMessageBytes: DefaultMaxMessageBytes,
+		handleTimeout:   DefaultHandleTimeout,
+		idleHeartbeat:   DefaultIdleHeartbeat,
+		now:             time.Now,
 	}
+	c.cond = sync.NewCond(&c.mu)
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // Start begins consuming messages from Kafka.
+//
+// Delivery is at-least-once: a message's offset is committed only after it
+// has been handled, quarantined, found undecodable or found too large to
+// decode. A transient handler
+// failure is retried in place with exponential backoff, so a restart
+// resumes from the first message that was not finished. With WithBatching
+// the same holds per partition; see consumeBatch.
 func (c *Consumer) Start(ctx context.Context) error {
+	rc := c.currentReader().Config()
+	topics := rc.GroupTopics
+	if len(topics) == 0 {
+		topics = []string{rc.Topic}
+	}
 	c.logger.Info("Starting Kafka consumer",
-		"topic", c.reader.Config().Topic,
-		"group_id", c.reader.Config().GroupID,
+		"topics", topics,
+		"group_id", rc.GroupID,
 	)
 
+	// Wake a paused consumer on shutdown.
+	stop := context.AfterFunc(ctx, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.cond.Broadcast()
+	})
+	defer stop()
+
+	c.statusMu.Lock()
+	c.startedAt = c.now()
+	c.statusMu.Unlock()
+	if c.idleHeartbeat > 0 {
+		go c.runHeartbeat(ctx)
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
 			c.logger.Info("Kafka consumer stopping")
-			return c.reader.Close()
+			return c.Close()
 		default:
-			msg, err := c.reader.ReadMessage(ctx)
+			reader, generation, ok := c.waitReader(ctx)
+			if !ok {
+				c.logger.Info("Kafka consumer stopping")
+				return c.Close()
+			}
+			if handler, ok := c.batchHandler(); ok {
+				if !c.consumeBatch(ctx, reader, generation, handler) {
+					c.logger.Info("Kafka consumer stopping")
+					return c.Close()
+				}
+				continue
+			}
+			msg, err := reader.FetchMessage(ctx)
 			if err != nil {
 				if ctx.Err() != nil {
 					return nil
 				}
+				if c.replaced(generation) {
+					// Seek closed the reader under us.
+					continue
+				}
 				c.logger.Error("Failed to read message", "error", err)
+				c.reportError(nil, err)
+				continue
+			}
+			c.recordRead(msg)
+
+			if len(msg.Value) > c.maxMessageBytes {
+				c.quarantineOversized(msg)
+				c.commit(ctx, reader, msg)
 				continue
 			}
 
@@ -88,30 +292,293 @@ func (c *Consumer) Start(ctx context.Context) error {
 					"error", err,
 					"offset", msg.Offset,
 				)
+				c.reportError(nil, err)
+				c.commit(ctx, reader, msg)
 				continue
 			}
 
-			if err := c.handler.Handle(ctx, event); err != nil {
-				c.logger.Error("Failed to handle event",
-					"event_id", event.EventID,
-					"event_type", event.EventType,
-					"aggregate_id", event.AggregateID,
-					"error", err,
-				)
+			if !c.process(ctx, generation, msg, event) {
+				if ctx.Err() == nil {
+					// Seek moved the group past msg; drop it uncommitted.
+					continue
+				}
+				c.logger.Info("Kafka consumer stopping")
+				return c.Close()
+			}
+			if c.replaced(generation) {
+				// The reader is closed and the group's offsets were moved.
 				continue
 			}
+			c.commit(ctx, reader, msg)
+		}
+	}
+}
+
+// process handles event until it succeeds or fails permanently, retrying
+// transient failures with backoff. It returns false if ctx ends or Seek
+// replaces the reader of generation first, in which case the message must
+// not be committed.
+func (c *Consumer) process(ctx context.Context, generation uint64, msg kafka.Message, event Event) bool {
+	backoff := c.retryBackoff
+	for attempt := 1; ; attempt++ {
+		if !c.enter(ctx, generation) {
+			return false
+		}
+		err := c.handle(ctx, event)
+		c.exit()
 
-			c.logger.Info("Event processed successfully",
-				"event_id", event.EventID,
-				"event_type", event.EventType,
-				"aggregate_id", event.AggregateID,
-				"offset", msg.Offset,
-			)
+		if err == nil {
+			c.logProcessed(msg, event)
+			return true
+		}
+		if IsPermanent(err) {
+			c.quarantine(msg, event, err)
+			return true
 		}
+
+		c.logger.Error("Failed to handle event, will retry",
+			"event_id", event.EventID,
+			"event_type", event.EventType,
+			"aggregate_id", event.AggregateID,
+			"offset", msg.Offset,
+			"attempt", attempt,
+			"retry_in", backoff,
+			"error", err,
+		)
+		c.reportError(&event, err)
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, c.maxRetryBackoff)
+	}
+}
+
+func (c *Consumer) logProcessed(msg kafka.Message, event Event) {
+	c.logger.Info("Event processed successfully",
+		"topic", msg.Topic,
+		"event_id", event.EventID,
+		"event_type", event.EventType,
+		"aggregate_id", event.AggregateID,
+		"offset", msg.Offset,
+	)
+}
+
+// handle makes one attempt at event within the handle timeout.
+func (c *Consumer) handle(ctx context.Context, event Event) error {
+	if c.handleTimeout <= 0 {
+		return c.handler.Handle(ctx, event)
+	}
+	hctx, cancel := context.WithTimeout(ctx, c.handleTimeout)
+	defer cancel()
+	err := c.handler.Handle(hctx, event)
+	if err != nil && ctx.Err() == nil && errors.Is(hctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("handling timed out after %s: %w", c.handleTimeout, err)
+	}
+	return err
+}
+
+// commit acknowledges msg. A failed commit is logged but not retried: the
+// message will be redelivered after a restart, and handling is idempotent.
+func (c *Consumer) commit(ctx context.Context, reader MessageReader, msg kafka.Message) {
+	if err := reader.CommitMessages(ctx, msg); err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		c.logger.Error("Failed to commit offset",
+			"partition", msg.Partition,
+			"offset", msg.Offset,
+			"error", err,
+		)
+		c.reportError(nil, err)
+	}
+}
+
+// LastMessageAt returns when the consumer last fetched a message, or the
+// zero time if it has not fetched any.
+func (c *Consumer) LastMessageAt() time.Time {
+	ns := c.lastMessage.Load()
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
+
+// Pause stops the consumer from handling further messages and waits for the
+// message currently being handled, if any, to finish. A message read while
+// paused is held until Resume.
+func (c *Consumer) Pause() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.paused = true
+	for c.busy {
+		c.cond.Wait()
+	}
+	c.logger.Info("Kafka consumer paused")
+}
+
+// Resume lets a paused consumer continue handling messages.
+func (c *Consumer) Resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.paused = false
+	c.cond.Broadcast()
+	c.logger.Info("Kafka consumer resumed")
+}
+
+// enter blocks while the consumer is paused or seeking, then marks it busy.
+// It returns false if ctx ends first or the reader of generation has been
+// replaced.
+func (c *Consumer) enter(ctx context.Context, generation uint64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for (c.paused || c.seeking) && ctx.Err() == nil {
+		c.cond.Wait()
+	}
+	if ctx.Err() != nil || c.generation != generation {
+		return false
+	}
+	c.busy = true
+	return true
+}
+
+func (c *Consumer) exit() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.busy = false
+	c.cond.Broadcast()
+}
+
+// quarantine sets aside an event that can never be processed. The full
+// payload is logged so it can be inspected and replayed once fixed upstream.
+func (c *Consumer) quarantine(msg kafka.Message, event Event, err error) {
+	c.logger.Warn("Quarantined invalid event",
+		"event_id", event.EventID,
+		"event_type", event.EventType,
+		"aggregate_id", event.AggregateID,
+		"partition", msg.Partition,
+		"offset", msg.Offset,
+		"payload", string(event.Payload),
+		"error", err,
+	)
+	c.reportError(&event, err)
+}
+
+// quarantineOversized sets aside a message larger than the consumer will
+// decode. Only the start of it is logged; the rest is never read.
+func (c *Consumer) quarantineOversized(msg kafka.Message) {
+	err := Permanent(fmt.Errorf("%w: %d bytes, limit %d", ErrMessageTooLarge, len(msg.Value), c.maxMessageBytes))
+	c.logger.Warn("Quarantined oversized message",
+		"topic", msg.Topic,
+		"partition", msg.Partition,
+		"offset", msg.Offset,
+		"key", string(msg.Key),
+		"size", len(msg.Value),
+		"limit", c.maxMessageBytes,
+		"sample", string(msg.Value[:min(len(msg.Value), sampleBytes)]),
+		"error", err,
+	)
+	c.reportError(nil, err)
+}
+
+func (c *Consumer) reportError(event *Event, err error) {
+	if c.onError != nil {
+		c.onError(event, err)
 	}
 }
 
 // Close closes the consumer connection.
 func (c *Consumer) Close() error {
-	return c.reader.Close()
+	return c.currentReader().Close()
+}
+
+func (c *Consumer) currentReader() MessageReader {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.reader
+}
+
+// waitReader returns the reader to fetch from once no Seek is in progress,
+// with its generation. It returns false if ctx ends first.
+func (c *Consumer) waitReader(ctx context.Context) (MessageReader, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for c.seeking && ctx.Err() == nil {
+		c.cond.Wait()
+	}
+	return c.reader, c.generation, ctx.Err() == nil
+}
+
+// replaced reports whether Seek has started or finished since generation
+// was current.
+func (c *Consumer) replaced(generation uint64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.seeking || c.generation != generation
+}
+
+// GroupID returns the consumer group the consumer reads as.
+func (c *Consumer) GroupID() string {
+	return c.currentReader().Config().GroupID
+}
+
+// Offsets reports the group's committed offset and high-water mark on every
+// partition.
+func (c *Consumer) Offsets(ctx context.Context) ([]PartitionOffset, error) {
+	if c.offsets == nil {
+		return nil, ErrSeekUnsupported
+	}
+	return c.offsets.Offsets(ctx)
+}
+
+// Seek moves the consumer group to target on every partition. It waits for
+// the message being handled to finish, closes the reader so the group has
+// no active member, commits the new offsets and resumes with a fresh
+// reader, which starts from them. Messages fetched but not handled before
+// the seek are dropped.
+//
+// The reader is replaced even when the commit fails, leaving the group at
+// its previous offsets.
+func (c *Consumer) Seek(ctx context.Context, target SeekTarget) ([]PartitionOffset, error) {
+	if err := target.Validate(); err != nil {
+		return nil, err
+	}
+	if c.offsets == nil || c.newReader == nil {
+		return nil, ErrSeekUnsupported
+	}
+
+	c.seekMu.Lock()
+	defer c.seekMu.Unlock()
+
+	c.mu.Lock()
+	c.seeking = true
+	for c.busy {
+		c.cond.Wait()
+	}
+	old := c.reader
+	c.mu.Unlock()
+
+	if err := old.Close(); err != nil {
+		c.logger.Warn("Failed to close Kafka reader before seek", "error", err)
+	}
+	offsets, err := c.offsets.Commit(ctx, target)
+
+	c.mu.Lock()
+	c.reader = c.newReader()
+	c.generation++
+	c.seeking = false
+	c.cond.Broadcast()
+	c.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+	c.logger.Warn("Kafka consumer group offsets moved",
+		"to", target.To,
+		"timestamp", target.Timestamp,
+		"partitions", len(offsets),
+	)
+	return offsets, nil
 }
@@ -1,11 +1,15 @@
 package kafka
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -23,9 +27,12 @@ type mockKafkaReader struct {
 	closeError   error
 	closeCalled  bool
 	configReturn kafka.ReaderConfig
+
+	mu        sync.Mutex
+	committed []int64
 }
 
-func (m *mockKafkaReader) ReadMessage(ctx context.Context) (kafka.Message, error) {
+func (m *mockKafkaReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
 	if m.readError != nil {
 		return kafka.Message{}, m.readError
 	}
@@ -40,6 +47,21 @@ func (m *mockKafkaReader) ReadMessage(ctx context.Context) (kafka.Message, error
 	return msg, nil
 }
 
+func (m *mockKafkaReader) CommitMessages(_ context.Context, msgs ...kafka.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, msg := range msgs {
+		m.committed = append(m.committed, msg.Offset)
+	}
+	return nil
+}
+
+func (m *mockKafkaReader) getCommitted() []int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]int64{}, m.committed...)
+}
+
 func (m *mockKafkaReader) Close() error {
 	m.closeCalled = true
 	return m.closeError
@@ -72,6 +94,17 @@ func (m *mockEventHandler) getHandledEvents() []Event {
 	return append([]Event{}, m.handledEvents...)
 }
 
+func TestReaderConfig_Topics(t *testing.T) {
+	single := readerConfig(Config{Brokers: []string{"b:9092"}, Topic: "tutor-events", GroupID: "g"})
+	assert.Equal(t, "tutor-events", single.Topic)
+	assert.Empty(t, single.GroupTopics)
+
+	multi := readerConfig(Config{Brokers: []string{"b:9092"}, Topic: "tutor-events", ExtraTopics: []string{"booking-events"}, GroupID: "g"})
+	assert.Empty(t, multi.Topic)
+	assert.Equal(t, []string{"tutor-events", "booking-events"}, multi.GroupTopics)
+	require.NoError(t, multi.Validate())
+}
+
 func TestNewConsumer(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -364,3 +397,365 @@ func TestConsumer_Start_ContextCancellation(t *testing.T) {
 		t.Fatal("Consumer did not stop within timeout")
 	}
 }
+
+func TestConsumer_Start_ReportsErrorsToHook(t *testing.T) {
+	event := Event{
+		EventID:   "event-1",
+		EventType: "TutorCreated",
+		Payload:   json.RawMessage(`{"id": 1}`),
+	}
+	eventBytes, _ := json.Marshal(event)
+
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+	mockReader := &mockKafkaReader{
+		messages: []kafka.Message{
+			{Key: []byte("1"), Value: []byte(`{invalid json}`), Offset: 0},
+			{Key: []byte("1"), Value: eventBytes, Offset: 1},
+		},
+	}
+	handler := &mockEventHandler{handleError: errors.New("handler error")}
+
+	var mu sync.Mutex
+	var reported []*Event
+	consumer := NewConsumerWithReader(mockReader, handler, logger, WithErrorHook(func(e *Event, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.Error(t, err)
+		reported = append(reported, e)
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	require.NoError(t, consumer.Start(ctx))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, reported, 2)
+	assert.Nil(t, reported[0], "decode failures have no event")
+	require.NotNil(t, reported[1])
+	assert.Equal(t, "event-1", reported[1].EventID)
+}
+
+func TestConsumer_Start_QuarantinesPermanentErrors(t *testing.T) {
+	event := Event{
+		EventID:   "event-bad",
+		EventType: "TutorCreated",
+		Payload:   json.RawMessage(`{"id": 1, "rating": 12}`),
+	}
+	eventBytes, _ := json.Marshal(event)
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+	mockReader := &mockKafkaReader{
+		messages: []kafka.Message{
+			{Key: []byte("1"), Value: eventBytes, Partition: 2, Offset: 7},
+		},
+	}
+	handler := &mockEventHandler{handleError: Permanent(errors.New("invalid tutor"))}
+
+	var reported int
+	consumer := NewConsumerWithReader(mockReader, handler, logger, WithErrorHook(func(e *Event, err error) {
+		reported++
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	require.NoError(t, consumer.Start(ctx))
+
+	assert.Equal(t, 1, reported)
+	assert.Contains(t, logs.String(), `"msg":"Quarantined invalid event"`)
+	assert.Contains(t, logs.String(), `"offset":7`)
+	assert.Contains(t, logs.String(), `\"rating\":12`)
+	assert.NotContains(t, logs.String(), "Failed to handle event")
+}
+
+func TestPermanent(t *testing.T) {
+	base := errors.New("bad payload")
+
+	assert.Nil(t, Permanent(nil))
+	assert.True(t, IsPermanent(Permanent(base)))
+	assert.True(t, IsPermanent(fmt.Errorf("wrapped: %w", Permanent(base))))
+	assert.False(t, IsPermanent(base))
+	assert.ErrorIs(t, Permanent(base), base)
+}
+
+// blockingEventHandler signals when it starts handling and waits for release.
+type blockingEventHandler struct {
+	started chan string
+	release chan struct{}
+}
+
+func (b *blockingEventHandler) Handle(ctx context.Context, event Event) error {
+	b.started <- event.EventID
+	<-b.release
+	return nil
+}
+
+func TestConsumer_PauseWaitsForInFlightAndHoldsMessages(t *testing.T) {
+	var messages []kafka.Message
+	for i, id := range []string{"event-1", "event-2"} {
+		value, _ := json.Marshal(Event{EventID: id, EventType: "TutorCreated"})
+		messages = append(messages, kafka.Message{Value: value, Offset: int64(i)})
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	handler := &blockingEventHandler{started: make(chan string), release: make(chan struct{})}
+	consumer := NewConsumerWithReader(&mockKafkaReader{messages: messages}, handler, logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- consumer.Start(ctx) }()
+
+	require.Equal(t, "event-1", <-handler.started)
+
+	paused := make(chan struct{})
+	go func() {
+		consumer.Pause()
+		close(paused)
+	}()
+
+	select {
+	case <-paused:
+		t.Fatal("Pause returned while a message was still being handled")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	handler.release <- struct{}{}
+	<-paused
+
+	select {
+	case id := <-handler.started:
+		t.Fatalf("handled %s while paused", id)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	consumer.Resume()
+	require.Equal(t, "event-2", <-handler.started)
+	handler.release <- struct{}{}
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestConsumer_StopsWhilePaused(t *testing.T) {
+	value, _ := json.Marshal(Event{EventID: "event-1", EventType: "TutorCreated"})
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	handler := &mockEventHandler{}
+	reader := &mockKafkaReader{messages: []kafka.Message{{Value: value}}}
+	consumer := NewConsumerWithReader(reader, handler, logger)
+
+	consumer.Pause()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- consumer.Start(ctx) }()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("paused consumer did not stop on cancellation")
+	}
+	assert.Empty(t, handler.getHandledEvents())
+	assert.True(t, reader.closeCalled)
+}
+
+// flakyEventHandler fails the first failures calls, then succeeds.
+type flakyEventHandler struct {
+	mu       sync.Mutex
+	failures int
+	calls    int
+}
+
+func (f *flakyEventHandler) Handle(context.Context, Event) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.calls <= f.failures {
+		return errors.New("opensearch unavailable")
+	}
+	return nil
+}
+
+func (f *flakyEventHandler) getCalls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func TestConsumer_Start_CommitsAfterHandling(t *testing.T) {
+	value, _ := json.Marshal(Event{EventID: "event-1", EventType: "TutorCreated"})
+	bad := Event{EventID: "event-bad", EventType: "TutorCreated"}
+	badValue, _ := json.Marshal(bad)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	reader := &mockKafkaReader{messages: []kafka.Message{
+		{Value: value, Offset: 0},
+		{Value: []byte(`{invalid json}`), Offset: 1},
+		{Value: badValue, Offset: 2},
+	}}
+	handler := &permanentForEventHandler{eventID: "event-bad"}
+	consumer := NewConsumerWithReader(reader, handler, logger)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	require.NoError(t, consumer.Start(ctx))
+	assert.Equal(t, []int64{0, 1, 2}, reader.getCommitted(),
+		"handled, undecodable and quarantined messages are all committed")
+}
+
+// permanentForEventHandler fails permanently for one event ID.
+type permanentForEventHandler struct {
+	eventID string
+}
+
+func (p *permanentForEventHandler) Handle(_ context.Context, event Event) error {
+	if event.EventID == p.eventID {
+		return Permanent(errors.New("invalid tutor"))
+	}
+	return nil
+}
+
+func TestConsumer_Start_RetriesTransientFailuresBeforeCommitting(t *testing.T) {
+	var messages []kafka.Message
+	for i, id := range []string{"event-1", "event-2"} {
+		value, _ := json.Marshal(Event{EventID: id, EventType: "TutorCreated"})
+		messages = append(messages, kafka.Message{Value: value, Offset: int64(i)})
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	reader := &mockKafkaReader{messages: messages}
+	handler := &flakyEventHandler{failures: 2}
+	var reported int
+	consumer := NewConsumerWithReader(reader, handler, logger,
+		WithRetryBackoff(time.Millisecond, 2*time.Millisecond),
+		WithErrorHook(func(*Event, error) { reported++ }),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	require.NoError(t, consumer.Start(ctx))
+	assert.Equal(t, 4, handler.getCalls(), "event-1 is attempted three times, event-2 once")
+	assert.Equal(t, 2, reported)
+	assert.Equal(t, []int64{0, 1}, reader.getCommitted())
+}
+
+func TestConsumer_Start_DoesNotCommitUnfinishedMessageOnShutdown(t *testing.T) {
+	value, _ := json.Marshal(Event{EventID: "event-1", EventType: "TutorCreated"})
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	reader := &mockKafkaReader{messages: []kafka.Message{{Value: value, Offset: 5}}}
+	handler := &flakyEventHandler{failures: 1000}
+	consumer := NewConsumerWithReader(reader, handler, logger,
+		WithRetryBackoff(time.Millisecond, 5*time.Millisecond),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	require.NoError(t, consumer.Start(ctx))
+	assert.Greater(t, handler.getCalls(), 1)
+	assert.Empty(t, reader.getCommitted(), "a restart must redeliver the failed message")
+	assert.True(t, reader.closeCalled)
+}
+
+func TestConsumer_Start_QuarantinesOversizedMessages(t *testing.T) {
+	small, _ := json.Marshal(Event{EventID: "event-1", EventType: "TutorCreated"})
+	large, _ := json.Marshal(Event{
+		EventID:   "event-large",
+		EventType: "TutorCreated",
+		Payload:   json.RawMessage(`{"bio": "` + strings.Repeat("x", 4096) + `"}`),
+	})
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+	reader := &mockKafkaReader{messages: []kafka.Message{
+		{Value: large, Partition: 1, Offset: 0},
+		{Value: small, Partition: 1, Offset: 1},
+	}}
+	handler := &mockEventHandler{}
+	var reported []error
+	consumer := NewConsumerWithReader(reader, handler, logger,
+		WithMaxMessageBytes(1024),
+		WithErrorHook(func(e *Event, err error) {
+			assert.Nil(t, e, "an oversized message is never decoded")
+			reported = append(reported, err)
+		}),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	require.NoError(t, consumer.Start(ctx))
+
+	handled := handler.getHandledEvents()
+	require.Len(t, handled, 1)
+	assert.Equal(t, "event-1", handled[0].EventID)
+	assert.Equal(t, []int64{0, 1}, reader.getCommitted())
+	require.Len(t, reported, 1)
+	assert.ErrorIs(t, reported[0], ErrMessageTooLarge)
+	assert.True(t, IsPermanent(reported[0]))
+
+	assert.Contains(t, logs.String(), `"msg":"Quarantined oversized message"`)
+	assert.Contains(t, logs.String(), fmt.Sprintf(`"size":%d`, len(large)))
+	assert.Contains(t, logs.String(), `"limit":1024`)
+	assert.Contains(t, logs.String(), `event-large`, "the sample shows the start of the message")
+	assert.NotContains(t, logs.String(), strings.Repeat("x", sampleBytes), "the sample is truncated")
+}
+
+// slowOnceEventHandler blocks its first call until the context ends.
+type slowOnceEventHandler struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (s *slowOnceEventHandler) Handle(ctx context.Context, _ Event) error {
+	s.mu.Lock()
+	s.calls++
+	first := s.calls == 1
+	s.mu.Unlock()
+	if first {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	return nil
+}
+
+func (s *slowOnceEventHandler) getCalls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.calls
+}
+
+func TestConsumer_Start_TimesOutSlowHandling(t *testing.T) {
+	value, _ := json.Marshal(Event{EventID: "event-1", EventType: "TutorCreated"})
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	reader := &mockKafkaReader{messages: []kafka.Message{{Value: value, Offset: 3}}}
+	handler := &slowOnceEventHandler{}
+	var reported []error
+	consumer := NewConsumerWithReader(reader, handler, logger,
+		WithHandleTimeout(10*time.Millisecond),
+		WithRetryBackoff(time.Millisecond, time.Millisecond),
+		WithErrorHook(func(_ *Event, err error) { reported = append(reported, err) }),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	require.NoError(t, consumer.Start(ctx))
+	assert.Equal(t, 2, handler.getCalls(), "the timed-out attempt is retried")
+	assert.Equal(t, []int64{3}, reader.getCommitted())
+	require.Len(t, reported, 1)
+	assert.ErrorIs(t, reported[0], context.DeadlineExceeded)
+	assert.False(t, IsPermanent(reported[0]))
+	assert.Contains(t, reported[0].Error(), "handling timed out after 10ms")
+}
@@ -0,0 +1,36 @@
+package kafka
+
+import "errors"
+
+// ErrMessageTooLarge is reported for a message the consumer quarantined
+// without decoding because it exceeds the size limit.
+var ErrMessageTooLarge = errors.New("message too large")
+
+// PermanentError marks a handler failure that will fail again on every
+// redelivery, such as an invalid payload. The consumer quarantines these
+// events instead of treating them as transient failures.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// Permanent wraps err as a PermanentError. It returns nil if err is nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &PermanentError{Err: err}
+}
+
+// IsPermanent reports whether err, or any error it wraps, is a PermanentError.
+func IsPermanent(err error) bool {
+	var pe *PermanentError
+	return errors.As(err, &pe)
+}
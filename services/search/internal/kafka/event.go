@@ -10,4 +10,7 @@ type Event struct {
 	AggregateID   string          `json:"aggregate_id"`
 	Payload       json.RawMessage `json:"payload"`
 	CreatedAt     string          `json:"created_at"`
+	// Tenant names the marketplace the event belongs to; empty means the
+	// default tenant.
+	Tenant string `json:"tenant,omitempty"`
 }
@@ -0,0 +1,117 @@
+package kafka
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// DefaultHealthDialTimeout bounds how long a health check waits for brokers.
+const DefaultHealthDialTimeout = time.Second
+
+// BrokerDialer opens and closes a connection to broker, returning an error
+// if it is unreachable.
+type BrokerDialer func(ctx context.Context, broker string) error
+
+// Health is the result of a HealthChecker probe.
+type Health struct {
+	// Connected reports whether any broker accepted a connection.
+	Connected bool
+	// Healthy is false once the brokers have been unreachable for longer
+	// than the grace period.
+	Healthy bool
+	// LastMessageAt is when the consumer last fetched a message, or zero if
+	// it has not fetched any since startup.
+	LastMessageAt time.Time
+}
+
+// HealthChecker probes broker connectivity for the readiness check. Brief
+// outages, such as a leader election, are tolerated for a grace period
+// before the service reports itself unhealthy.
+type HealthChecker struct {
+	brokers     []string
+	grace       time.Duration
+	timeout     time.Duration
+	dial        BrokerDialer
+	lastMessage func() time.Time
+	now         func() time.Time
+
+	mu        sync.Mutex
+	downSince time.Time
+}
+
+// HealthOption configures optional HealthChecker behaviour.
+type HealthOption func(*HealthChecker)
+
+// WithBrokerDialer replaces the connectivity probe (for testing).
+func WithBrokerDialer(dial BrokerDialer) HealthOption {
+	return func(h *HealthChecker) {
+		h.dial = dial
+	}
+}
+
+// WithClock replaces time.Now (for testing).
+func WithClock(now func() time.Time) HealthOption {
+	return func(h *HealthChecker) {
+		h.now = now
+	}
+}
+
+// NewHealthChecker returns a checker for brokers. lastMessage, typically
+// Consumer.LastMessageAt, may be nil.
+func NewHealthChecker(brokers []string, grace time.Duration, lastMessage func() time.Time, opts ...HealthOption) *HealthChecker {
+	h := &HealthChecker{
+		brokers:     brokers,
+		grace:       grace,
+		timeout:     DefaultHealthDialTimeout,
+		dial:        dialBroker,
+		lastMessage: lastMessage,
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Check dials the brokers in order until one answers.
+func (h *HealthChecker) Check(ctx context.Context) Health {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	connected := false
+	for _, broker := range h.brokers {
+		if h.dial(ctx, broker) == nil {
+			connected = true
+			break
+		}
+	}
+
+	health := Health{Connected: connected, Healthy: true}
+	if h.lastMessage != nil {
+		health.LastMessageAt = h.lastMessage()
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := h.now()
+	switch {
+	case connected:
+		h.downSince = time.Time{}
+	case h.downSince.IsZero():
+		h.downSince = now
+	default:
+		health.Healthy = now.Sub(h.downSince) <= h.grace
+	}
+	return health
+}
+
+func dialBroker(ctx context.Context, broker string) error {
+	conn, err := kafka.DialContext(ctx, "tcp", broker)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
@@ -0,0 +1,88 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubBrokers is a BrokerDialer whose reachable brokers can be changed.
+type stubBrokers struct {
+	up     map[string]bool
+	dialed []string
+}
+
+func (s *stubBrokers) dial(_ context.Context, broker string) error {
+	s.dialed = append(s.dialed, broker)
+	if s.up[broker] {
+		return nil
+	}
+	return errors.New("connection refused")
+}
+
+func TestHealthChecker_TriesBrokersInOrder(t *testing.T) {
+	brokers := &stubBrokers{up: map[string]bool{"b:9092": true}}
+	checker := NewHealthChecker([]string{"a:9092", "b:9092", "c:9092"}, time.Minute, nil,
+		WithBrokerDialer(brokers.dial))
+
+	health := checker.Check(context.Background())
+
+	assert.True(t, health.Connected)
+	assert.True(t, health.Healthy)
+	assert.Equal(t, []string{"a:9092", "b:9092"}, brokers.dialed, "stops at the first reachable broker")
+}
+
+func TestHealthChecker_GracePeriod(t *testing.T) {
+	brokers := &stubBrokers{up: map[string]bool{}}
+	now := time.Date(2025, 12, 20, 10, 0, 0, 0, time.UTC)
+	checker := NewHealthChecker([]string{"a:9092"}, 30*time.Second, nil,
+		WithBrokerDialer(brokers.dial),
+		WithClock(func() time.Time { return now }))
+
+	health := checker.Check(context.Background())
+	assert.False(t, health.Connected)
+	assert.True(t, health.Healthy, "a fresh outage is within the grace period")
+
+	now = now.Add(30 * time.Second)
+	assert.True(t, checker.Check(context.Background()).Healthy)
+
+	now = now.Add(time.Second)
+	assert.False(t, checker.Check(context.Background()).Healthy, "outage outlasted the grace period")
+
+	brokers.up["a:9092"] = true
+	health = checker.Check(context.Background())
+	assert.True(t, health.Connected)
+	assert.True(t, health.Healthy)
+
+	brokers.up["a:9092"] = false
+	now = now.Add(time.Hour)
+	assert.True(t, checker.Check(context.Background()).Healthy, "recovery resets the grace period")
+}
+
+func TestHealthChecker_ReportsLastMessage(t *testing.T) {
+	last := time.Date(2025, 12, 20, 9, 59, 0, 0, time.UTC)
+	checker := NewHealthChecker([]string{"a:9092"}, time.Minute, func() time.Time { return last },
+		WithBrokerDialer(func(context.Context, string) error { return nil }))
+
+	assert.Equal(t, last, checker.Check(context.Background()).LastMessageAt)
+}
+
+func TestConsumer_LastMessageAt(t *testing.T) {
+	consumer := NewConsumerWithReader(&mockKafkaReader{
+		messages: []kafka.Message{{Value: []byte(`{invalid json}`)}},
+	}, &mockEventHandler{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	assert.True(t, consumer.LastMessageAt().IsZero())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	before := time.Now()
+	_ = consumer.Start(ctx)
+
+	assert.False(t, consumer.LastMessageAt().Before(before), "undecodable messages still count as received")
+}
@@ -0,0 +1,147 @@
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// DefaultIdleHeartbeat is how long the consumer goes without a message
+// before it logs a heartbeat, unless WithIdleHeartbeat says otherwise.
+const DefaultIdleHeartbeat = 60 * time.Second
+
+// Position is where the consumer last read.
+type Position struct {
+	Topic     string `json:"topic"`
+	Partition int    `json:"partition"`
+	Offset    int64  `json:"offset"`
+	// Lag is how many messages the partition held beyond Offset when it
+	// was read.
+	Lag int64 `json:"lag"`
+}
+
+// Status describes the consumer's progress, for telling a quiet topic from
+// a wedged consumer.
+type Status struct {
+	GroupID string `json:"group_id"`
+	Paused  bool   `json:"paused"`
+	// LastMessageAt is when a message was last fetched; nil before the
+	// first.
+	LastMessageAt *time.Time `json:"last_message_at"`
+	LastPosition  *Position  `json:"last_position"`
+	// IdleSeconds is how long the consumer has gone without a message,
+	// counted from its start before the first.
+	IdleSeconds int64 `json:"idle_seconds"`
+	// Heartbeats counts the idle heartbeats logged since the start.
+	Heartbeats      int64      `json:"heartbeats"`
+	LastHeartbeatAt *time.Time `json:"last_heartbeat_at"`
+}
+
+// WithIdleHeartbeat sets how long the consumer may go without a message
+// before it logs a heartbeat, and then how often it repeats one. Zero
+// disables heartbeats.
+func WithIdleHeartbeat(d time.Duration) ConsumerOption {
+	return func(c *Consumer) {
+		c.idleHeartbeat = d
+	}
+}
+
+// WithConsumerClock replaces time.Now for the message and heartbeat
+// timestamps (for testing).
+func WithConsumerClock(now func() time.Time) ConsumerOption {
+	return func(c *Consumer) {
+		c.now = now
+	}
+}
+
+// Status reports the consumer's progress.
+func (c *Consumer) Status() Status {
+	now := c.now()
+	c.mu.Lock()
+	paused := c.paused
+	c.mu.Unlock()
+
+	c.statusMu.Lock()
+	defer c.statusMu.Unlock()
+	s := Status{
+		GroupID:    c.GroupID(),
+		Paused:     paused,
+		Heartbeats: c.heartbeats,
+	}
+	if last := c.LastMessageAt(); !last.IsZero() {
+		s.LastMessageAt = &last
+	}
+	if c.lastPosition != nil {
+		pos := *c.lastPosition
+		s.LastPosition = &pos
+	}
+	if idleSince := c.idleSince(); !idleSince.IsZero() {
+		s.IdleSeconds = int64(now.Sub(idleSince).Seconds())
+	}
+	if !c.lastHeartbeat.IsZero() {
+		hb := c.lastHeartbeat
+		s.LastHeartbeatAt = &hb
+	}
+	return s
+}
+
+// recordRead notes msg as the last message fetched.
+func (c *Consumer) recordRead(msg kafka.Message) {
+	c.lastMessage.Store(c.now().UnixNano())
+
+	pos := Position{Topic: msg.Topic, Partition: msg.Partition, Offset: msg.Offset}
+	if msg.HighWaterMark > 0 {
+		pos.Lag = max(msg.HighWaterMark-msg.Offset-1, 0)
+	}
+	c.statusMu.Lock()
+	c.lastPosition = &pos
+	c.statusMu.Unlock()
+}
+
+// runHeartbeat checks every idle heartbeat interval whether the consumer
+// has gone that long without a message, until ctx ends.
+func (c *Consumer) runHeartbeat(ctx context.Context) {
+	ticker := time.NewTicker(c.idleHeartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.heartbeat()
+		}
+	}
+}
+
+// heartbeat logs that the consumer is alive but idle, and counts it, when
+// no message has been fetched for the idle heartbeat interval. It reports
+// whether it did.
+func (c *Consumer) heartbeat() bool {
+	now := c.now()
+	c.statusMu.Lock()
+	defer c.statusMu.Unlock()
+
+	idleSince := c.idleSince()
+	if idleSince.IsZero() || now.Sub(idleSince) < c.idleHeartbeat {
+		return false
+	}
+	c.heartbeats++
+	c.lastHeartbeat = now
+
+	attrs := []any{"idle", now.Sub(idleSince).Round(time.Second), "heartbeats", c.heartbeats}
+	if pos := c.lastPosition; pos != nil {
+		attrs = append(attrs, "topic", pos.Topic, "partition", pos.Partition, "offset", pos.Offset, "lag", pos.Lag)
+	}
+	c.logger.Info("Kafka consumer idle", attrs...)
+	return true
+}
+
+// idleSince returns when the consumer last fetched a message, or when it
+// started if it has fetched none. It is zero before Start.
+func (c *Consumer) idleSince() time.Time {
+	if last := c.LastMessageAt(); !last.IsZero() {
+		return last
+	}
+	return c.startedAt
+}
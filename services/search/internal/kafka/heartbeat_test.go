@@ -0,0 +1,133 @@
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a settable clock safe for use from the consumer goroutine.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *fakeClock) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = t
+}
+
+// startConsumer runs c until the test ends and waits for it to read n
+// messages.
+func startConsumer(t *testing.T, c *Consumer, reader *mockKafkaReader, handler *mockEventHandler, n int) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.Start(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	require.Eventually(t, func() bool {
+		return len(handler.getHandledEvents()) == n && !c.startedAtZero()
+	}, time.Second, time.Millisecond)
+}
+
+func (c *Consumer) startedAtZero() bool {
+	c.statusMu.Lock()
+	defer c.statusMu.Unlock()
+	return c.startedAt.IsZero()
+}
+
+func TestConsumer_HeartbeatOnlyWhenIdle(t *testing.T) {
+	t0 := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	clock := &fakeClock{now: t0}
+	value, _ := json.Marshal(Event{EventID: "event-1", EventType: "TutorUpdated", AggregateID: "1", Payload: json.RawMessage(`{"id": 1}`)})
+	reader := &mockKafkaReader{
+		messages:     []kafka.Message{{Topic: "tutor-events", Partition: 2, Offset: 41, HighWaterMark: 45, Value: value}},
+		configReturn: kafka.ReaderConfig{Topic: "tutor-events", GroupID: "search"},
+	}
+	handler := &mockEventHandler{}
+	var logs bytes.Buffer
+	consumer := NewConsumerWithReader(reader, handler, slog.New(slog.NewJSONHandler(&logs, nil)),
+		WithIdleHeartbeat(time.Minute),
+		WithConsumerClock(clock.Now),
+	)
+	startConsumer(t, consumer, reader, handler, 1)
+
+	clock.Set(t0.Add(59 * time.Second))
+	assert.False(t, consumer.heartbeat(), "a message was read within the interval")
+	assert.Zero(t, consumer.Status().Heartbeats)
+	assert.NotContains(t, logs.String(), "Kafka consumer idle")
+
+	clock.Set(t0.Add(time.Minute))
+	assert.True(t, consumer.heartbeat())
+	clock.Set(t0.Add(2 * time.Minute))
+	assert.True(t, consumer.heartbeat(), "heartbeats repeat while the topic stays quiet")
+
+	status := consumer.Status()
+	assert.Equal(t, "search", status.GroupID)
+	assert.Equal(t, int64(2), status.Heartbeats)
+	assert.Equal(t, int64(120), status.IdleSeconds)
+	require.NotNil(t, status.LastMessageAt)
+	assert.True(t, status.LastMessageAt.Equal(t0))
+	require.NotNil(t, status.LastHeartbeatAt)
+	assert.True(t, status.LastHeartbeatAt.Equal(t0.Add(2*time.Minute)))
+	assert.Equal(t, &Position{Topic: "tutor-events", Partition: 2, Offset: 41, Lag: 3}, status.LastPosition)
+	assert.Contains(t, logs.String(), `"msg":"Kafka consumer idle"`)
+	assert.Contains(t, logs.String(), `"offset":41,"lag":3`)
+}
+
+func TestConsumer_HeartbeatBeforeFirstMessage(t *testing.T) {
+	t0 := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	clock := &fakeClock{now: t0}
+	reader := &mockKafkaReader{configReturn: kafka.ReaderConfig{Topic: "tutor-events", GroupID: "search"}}
+	handler := &mockEventHandler{}
+	consumer := NewConsumerWithReader(reader, handler, slog.New(slog.NewTextHandler(os.Stdout, nil)),
+		WithIdleHeartbeat(time.Minute),
+		WithConsumerClock(clock.Now),
+	)
+	assert.False(t, consumer.heartbeat(), "no heartbeat before Start")
+
+	startConsumer(t, consumer, reader, handler, 0)
+
+	clock.Set(t0.Add(30 * time.Second))
+	assert.False(t, consumer.heartbeat(), "idle time counts from the start")
+	clock.Set(t0.Add(time.Minute))
+	assert.True(t, consumer.heartbeat())
+
+	status := consumer.Status()
+	assert.Nil(t, status.LastMessageAt)
+	assert.Nil(t, status.LastPosition)
+	assert.Equal(t, int64(1), status.Heartbeats)
+}
+
+func TestConsumer_HeartbeatLoop(t *testing.T) {
+	reader := &mockKafkaReader{configReturn: kafka.ReaderConfig{Topic: "tutor-events", GroupID: "search"}}
+	handler := &mockEventHandler{}
+	consumer := NewConsumerWithReader(reader, handler, slog.New(slog.NewTextHandler(os.Stdout, nil)),
+		WithIdleHeartbeat(5*time.Millisecond),
+	)
+	startConsumer(t, consumer, reader, handler, 0)
+
+	assert.Eventually(t, func() bool { return consumer.Status().Heartbeats > 0 }, time.Second, time.Millisecond)
+}
@@ -0,0 +1,268 @@
+package kafka
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Targets for Consumer.Seek.
+const (
+	SeekEarliest  = "earliest"
+	SeekLatest    = "latest"
+	SeekTimestamp = "timestamp"
+)
+
+// DefaultAdminTimeout bounds each offset admin request to the brokers.
+const DefaultAdminTimeout = 10 * time.Second
+
+// ErrSeekUnsupported is returned by Consumer.Offsets and Consumer.Seek when
+// the consumer has no access to its group's offsets.
+var ErrSeekUnsupported = errors.New("consumer group offsets are not available")
+
+// SeekTarget is where Consumer.Seek moves the group on every partition.
+type SeekTarget struct {
+	// To is SeekEarliest, SeekLatest or SeekTimestamp.
+	To string
+	// Timestamp is required with SeekTimestamp: each partition moves to its
+	// first message at or after it, or to its end if there is none.
+	Timestamp time.Time
+}
+
+// Validate checks To is known and Timestamp is set when it is needed.
+func (t SeekTarget) Validate() error {
+	switch t.To {
+	case SeekEarliest, SeekLatest:
+		return nil
+	case SeekTimestamp:
+		if t.Timestamp.IsZero() {
+			return errors.New("timestamp is required when seeking to a timestamp")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown seek target %q: must be %s, %s or %s", t.To, SeekEarliest, SeekLatest, SeekTimestamp)
+	}
+}
+
+// PartitionOffset is the consumer group's position on one partition.
+type PartitionOffset struct {
+	Topic     string `json:"topic"`
+	Partition int    `json:"partition"`
+	// Committed is the next offset the group will read, or -1 if it has not
+	// committed on this partition.
+	Committed int64 `json:"committed"`
+	// HighWater is the offset the next message produced will get.
+	HighWater int64 `json:"high_water"`
+	// Lag is how many messages the group has yet to read, or -1 when it has
+	// not committed.
+	Lag int64 `json:"lag"`
+}
+
+// GroupOffsets reads and moves a consumer group's committed offsets.
+type GroupOffsets interface {
+	// Offsets reports every partition of the group's topics.
+	Offsets(ctx context.Context) ([]PartitionOffset, error)
+	// Commit moves the group to target on every partition and reports the
+	// new positions. Brokers refuse it while the group has active members.
+	Commit(ctx context.Context, target SeekTarget) ([]PartitionOffset, error)
+}
+
+// GroupOffsetAdmin implements GroupOffsets with the Kafka admin API.
+type GroupOffsetAdmin struct {
+	client  *kafka.Client
+	groupID string
+	topics  []string
+}
+
+var _ GroupOffsets = (*GroupOffsetAdmin)(nil)
+
+// NewGroupOffsetAdmin manages the offsets of cfg's group on Topic and
+// ExtraTopics.
+func NewGroupOffsetAdmin(cfg Config) *GroupOffsetAdmin {
+	return &GroupOffsetAdmin{
+		client: &kafka.Client{
+			Addr:    kafka.TCP(cfg.Brokers...),
+			Timeout: DefaultAdminTimeout,
+		},
+		groupID: cfg.GroupID,
+		topics:  append([]string{cfg.Topic}, cfg.ExtraTopics...),
+	}
+}
+
+func (a *GroupOffsetAdmin) Offsets(ctx context.Context) ([]PartitionOffset, error) {
+	partitions, err := a.partitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	highWater, err := a.listOffsets(ctx, partitions, kafka.LastOffsetOf)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := a.client.OffsetFetch(ctx, &kafka.OffsetFetchRequest{
+		GroupID: a.groupID,
+		Topics:  partitions,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch committed offsets: %w", err)
+	}
+	if resp.Error != nil {
+		return nil, fmt.Errorf("failed to fetch committed offsets: %w", resp.Error)
+	}
+
+	var offsets []PartitionOffset
+	for topic, fetched := range resp.Topics {
+		for _, p := range fetched {
+			if p.Error != nil {
+				return nil, fmt.Errorf("failed to fetch committed offset of %s/%d: %w", topic, p.Partition, p.Error)
+			}
+			offsets = append(offsets, newPartitionOffset(topic, p.Partition, p.CommittedOffset, highWater[topic][p.Partition].LastOffset))
+		}
+	}
+	sortPartitionOffsets(offsets)
+	return offsets, nil
+}
+
+func (a *GroupOffsetAdmin) Commit(ctx context.Context, target SeekTarget) ([]PartitionOffset, error) {
+	if err := target.Validate(); err != nil {
+		return nil, err
+	}
+	partitions, err := a.partitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// The high-water mark is needed in every case: it is the latest target
+	// and the fallback for timestamps past a partition's last message.
+	highWater, err := a.listOffsets(ctx, partitions, kafka.LastOffsetOf)
+	if err != nil {
+		return nil, err
+	}
+	var resolved map[string]map[int]kafka.PartitionOffsets
+	switch target.To {
+	case SeekEarliest:
+		resolved, err = a.listOffsets(ctx, partitions, kafka.FirstOffsetOf)
+	case SeekTimestamp:
+		resolved, err = a.listOffsets(ctx, partitions, func(p int) kafka.OffsetRequest {
+			return kafka.TimeOffsetOf(p, target.Timestamp)
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	commits := make(map[string][]kafka.OffsetCommit, len(partitions))
+	var offsets []PartitionOffset
+	for topic, ids := range partitions {
+		for _, p := range ids {
+			end := highWater[topic][p].LastOffset
+			offset := end
+			switch target.To {
+			case SeekEarliest:
+				offset = resolved[topic][p].FirstOffset
+			case SeekTimestamp:
+				// The broker answers with a single offset, -1 when no
+				// message is that recent.
+				for o := range resolved[topic][p].Offsets {
+					if o >= 0 {
+						offset = o
+					}
+				}
+			}
+			commits[topic] = append(commits[topic], kafka.OffsetCommit{Partition: p, Offset: offset})
+			offsets = append(offsets, newPartitionOffset(topic, p, offset, end))
+		}
+	}
+
+	// Generation -1 and no member ID commit on behalf of an empty group.
+	resp, err := a.client.OffsetCommit(ctx, &kafka.OffsetCommitRequest{
+		GroupID:      a.groupID,
+		GenerationID: -1,
+		Topics:       commits,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to commit offsets: %w", err)
+	}
+	for topic, committed := range resp.Topics {
+		for _, p := range committed {
+			if p.Error != nil {
+				return nil, fmt.Errorf("failed to commit offset of %s/%d: %w", topic, p.Partition, p.Error)
+			}
+		}
+	}
+	sortPartitionOffsets(offsets)
+	return offsets, nil
+}
+
+// partitions lists the partition IDs of each of the group's topics.
+func (a *GroupOffsetAdmin) partitions(ctx context.Context) (map[string][]int, error) {
+	resp, err := a.client.Metadata(ctx, &kafka.MetadataRequest{Topics: a.topics})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load topic metadata: %w", err)
+	}
+	partitions := make(map[string][]int, len(resp.Topics))
+	for _, topic := range resp.Topics {
+		if topic.Error != nil {
+			return nil, fmt.Errorf("failed to load metadata of topic %s: %w", topic.Name, topic.Error)
+		}
+		for _, p := range topic.Partitions {
+			partitions[topic.Name] = append(partitions[topic.Name], p.ID)
+		}
+	}
+	return partitions, nil
+}
+
+// listOffsets sends one ListOffsets request built by req for every
+// partition.
+func (a *GroupOffsetAdmin) listOffsets(ctx context.Context, partitions map[string][]int, req func(partition int) kafka.OffsetRequest) (map[string]map[int]kafka.PartitionOffsets, error) {
+	topics := make(map[string][]kafka.OffsetRequest, len(partitions))
+	for topic, ids := range partitions {
+		for _, p := range ids {
+			topics[topic] = append(topics[topic], req(p))
+		}
+	}
+	resp, err := a.client.ListOffsets(ctx, &kafka.ListOffsetsRequest{Topics: topics})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list partition offsets: %w", err)
+	}
+
+	result := make(map[string]map[int]kafka.PartitionOffsets, len(resp.Topics))
+	for topic, listed := range resp.Topics {
+		result[topic] = make(map[int]kafka.PartitionOffsets, len(listed))
+		for _, p := range listed {
+			if p.Error != nil {
+				return nil, fmt.Errorf("failed to list offsets of %s/%d: %w", topic, p.Partition, p.Error)
+			}
+			result[topic][p.Partition] = p
+		}
+	}
+	return result, nil
+}
+
+func newPartitionOffset(topic string, partition int, committed, highWater int64) PartitionOffset {
+	lag := int64(-1)
+	if committed >= 0 {
+		lag = max(highWater-committed, 0)
+	}
+	return PartitionOffset{
+		Topic:     topic,
+		Partition: partition,
+		Committed: committed,
+		HighWater: highWater,
+		Lag:       lag,
+	}
+}
+
+func sortPartitionOffsets(offsets []PartitionOffset) {
+	slices.SortFunc(offsets, func(a, b PartitionOffset) int {
+		if c := cmp.Compare(a.Topic, b.Topic); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Partition, b.Partition)
+	})
+}
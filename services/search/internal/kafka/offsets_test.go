@@ -0,0 +1,256 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// closableReader serves messages in order and, unlike mockKafkaReader,
+// unblocks a pending fetch when closed, as kafka.Reader does.
+type closableReader struct {
+	mu        sync.Mutex
+	messages  []kafka.Message
+	committed []int64
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func newClosableReader(ids ...string) *closableReader {
+	r := &closableReader{closed: make(chan struct{})}
+	for i, id := range ids {
+		value, _ := json.Marshal(Event{EventID: id, EventType: "TutorCreated"})
+		r.messages = append(r.messages, kafka.Message{Value: value, Offset: int64(i)})
+	}
+	return r
+}
+
+func (r *closableReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	r.mu.Lock()
+	if len(r.messages) > 0 && !r.isClosed() {
+		msg := r.messages[0]
+		r.messages = r.messages[1:]
+		r.mu.Unlock()
+		return msg, nil
+	}
+	r.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return kafka.Message{}, ctx.Err()
+	case <-r.closed:
+		return kafka.Message{}, io.EOF
+	}
+}
+
+func (r *closableReader) CommitMessages(_ context.Context, msgs ...kafka.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.isClosed() {
+		return errors.New("reader closed")
+	}
+	for _, msg := range msgs {
+		r.committed = append(r.committed, msg.Offset)
+	}
+	return nil
+}
+
+func (r *closableReader) getCommitted() []int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]int64{}, r.committed...)
+}
+
+func (r *closableReader) isClosed() bool {
+	select {
+	case <-r.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (r *closableReader) Close() error {
+	r.closeOnce.Do(func() { close(r.closed) })
+	return nil
+}
+
+func (r *closableReader) Config() kafka.ReaderConfig {
+	return kafka.ReaderConfig{Topic: "tutor-events", GroupID: "search-service"}
+}
+
+// fakeGroupOffsets records commits and checks the reader they replace was
+// closed first.
+type fakeGroupOffsets struct {
+	mu        sync.Mutex
+	before    *closableReader
+	targets   []SeekTarget
+	commitErr error
+	offsets   []PartitionOffset
+}
+
+func (f *fakeGroupOffsets) Offsets(context.Context) ([]PartitionOffset, error) {
+	return f.offsets, nil
+}
+
+func (f *fakeGroupOffsets) Commit(_ context.Context, target SeekTarget) ([]PartitionOffset, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.before != nil && !f.before.isClosed() {
+		return nil, errors.New("group still has an active member")
+	}
+	f.targets = append(f.targets, target)
+	return f.offsets, f.commitErr
+}
+
+func (f *fakeGroupOffsets) getTargets() []SeekTarget {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]SeekTarget{}, f.targets...)
+}
+
+func TestSeekTarget_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  SeekTarget
+		wantErr bool
+	}{
+		{"earliest", SeekTarget{To: SeekEarliest}, false},
+		{"latest", SeekTarget{To: SeekLatest}, false},
+		{"timestamp", SeekTarget{To: SeekTimestamp, Timestamp: time.Now()}, false},
+		{"timestamp missing", SeekTarget{To: SeekTimestamp}, true},
+		{"unknown", SeekTarget{To: "yesterday"}, true},
+		{"empty", SeekTarget{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.target.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewPartitionOffset_Lag(t *testing.T) {
+	assert.Equal(t, int64(3), newPartitionOffset("t", 0, 7, 10).Lag)
+	assert.Equal(t, int64(0), newPartitionOffset("t", 0, 10, 10).Lag)
+	assert.Equal(t, int64(-1), newPartitionOffset("t", 0, -1, 10).Lag, "no commit yet")
+}
+
+func TestConsumer_SeekUnsupported(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	consumer := NewConsumerWithReader(newClosableReader(), &mockEventHandler{}, logger)
+
+	_, err := consumer.Seek(context.Background(), SeekTarget{To: SeekLatest})
+	assert.ErrorIs(t, err, ErrSeekUnsupported)
+	_, err = consumer.Offsets(context.Background())
+	assert.ErrorIs(t, err, ErrSeekUnsupported)
+}
+
+func TestConsumer_SeekRejectsInvalidTarget(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	offsets := &fakeGroupOffsets{}
+	consumer := NewConsumerWithReader(newClosableReader(), &mockEventHandler{}, logger,
+		WithGroupOffsets(offsets),
+		WithReaderFactory(func() MessageReader { return newClosableReader() }),
+	)
+
+	_, err := consumer.Seek(context.Background(), SeekTarget{To: SeekTimestamp})
+	assert.Error(t, err)
+	assert.Empty(t, offsets.getTargets())
+}
+
+func TestConsumer_SeekRestartsWithNewReader(t *testing.T) {
+	first := newClosableReader("event-1", "event-2")
+	second := newClosableReader("event-3")
+	offsets := &fakeGroupOffsets{
+		before:  first,
+		offsets: []PartitionOffset{{Topic: "tutor-events", Partition: 0, Committed: 0, HighWater: 3, Lag: 3}},
+	}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	handler := &blockingEventHandler{started: make(chan string), release: make(chan struct{})}
+	consumer := NewConsumerWithReader(first, handler, logger,
+		WithGroupOffsets(offsets),
+		WithReaderFactory(func() MessageReader { return second }),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- consumer.Start(ctx) }()
+
+	require.Equal(t, "event-1", <-handler.started)
+
+	type seekResult struct {
+		offsets []PartitionOffset
+		err     error
+	}
+	seeked := make(chan seekResult, 1)
+	go func() {
+		result, err := consumer.Seek(context.Background(), SeekTarget{To: SeekEarliest})
+		seeked <- seekResult{result, err}
+	}()
+
+	select {
+	case <-seeked:
+		t.Fatal("Seek returned while a message was still being handled")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	handler.release <- struct{}{}
+	result := <-seeked
+	require.NoError(t, result.err)
+	assert.Equal(t, offsets.offsets, result.offsets)
+	assert.Equal(t, []SeekTarget{{To: SeekEarliest}}, offsets.getTargets())
+
+	// event-2 was never handled: the consumer resumed from the new reader.
+	require.Equal(t, "event-3", <-handler.started)
+	handler.release <- struct{}{}
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.Empty(t, first.getCommitted(), "nothing is committed through the closed reader")
+	assert.Equal(t, []int64{0}, second.getCommitted())
+}
+
+func TestConsumer_SeekCommitFailureStillResumes(t *testing.T) {
+	first := newClosableReader()
+	second := newClosableReader("event-1")
+	offsets := &fakeGroupOffsets{commitErr: errors.New("coordinator unavailable")}
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	handler := &mockEventHandler{}
+	consumer := NewConsumerWithReader(first, handler, logger,
+		WithGroupOffsets(offsets),
+		WithReaderFactory(func() MessageReader { return second }),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- consumer.Start(ctx) }()
+
+	_, err := consumer.Seek(context.Background(), SeekTarget{To: SeekLatest})
+	require.Error(t, err)
+
+	require.Eventually(t, func() bool {
+		return len(handler.getHandledEvents()) == 1
+	}, time.Second, 5*time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
@@ -0,0 +1,206 @@
+// Package lease elects one replica to run scheduled jobs. The elected
+// replica holds a lock document in the search backend and keeps pushing
+// its expiry forward; the others take it over once it lapses.
+package lease
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"search/internal/port"
+)
+
+// JobsLock is the lock guarding scheduled reindexing.
+const JobsLock = "jobs"
+
+// releaseTimeout bounds the delete of a held lock on shutdown.
+const releaseTimeout = 5 * time.Second
+
+// Lease is one replica's claim on a named lock. Expiry is judged by each
+// replica's own clock, so the TTL must comfortably exceed the clock skew
+// between them.
+type Lease struct {
+	store  port.LockStore
+	name   string
+	holder string
+	ttl    time.Duration
+	logger *slog.Logger
+	now    func() time.Time
+
+	mu sync.Mutex
+	// held is the lock as this replica last wrote it, nil when it does not
+	// hold the lease.
+	held *port.Lock
+}
+
+// Status describes who holds a lease, as seen by one replica.
+type Status struct {
+	Name string `json:"name"`
+	// Holder is empty when nobody has taken the lease yet.
+	Holder     string     `json:"holder,omitempty"`
+	AcquiredAt *time.Time `json:"acquired_at,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	// Expired is set when the last holder stopped renewing; the next
+	// replica to try takes the lease over.
+	Expired bool `json:"expired"`
+	// Self names this replica and whether it is the holder.
+	Self     string `json:"self"`
+	HeldSelf bool   `json:"held_by_self"`
+}
+
+// Option configures a Lease.
+type Option func(*Lease)
+
+// WithClock replaces time.Now for expiry stamps and checks.
+func WithClock(now func() time.Time) Option {
+	return func(l *Lease) {
+		l.now = now
+	}
+}
+
+// New returns a Lease on the lock name in store, claimed as holder for ttl
+// at a time.
+func New(store port.LockStore, name, holder string, ttl time.Duration, logger *slog.Logger, opts ...Option) *Lease {
+	l := &Lease{
+		store:  store,
+		name:   name,
+		holder: holder,
+		ttl:    ttl,
+		logger: logger,
+		now:    time.Now,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Acquire takes the lease if nobody holds it or the holder let it expire,
+// and renews it if this replica already holds it. It reports whether this
+// replica holds the lease afterwards. When the store fails, the lease
+// counts as held until the expiry last written.
+func (l *Lease) Acquire(ctx context.Context) (bool, error) {
+	now := l.now()
+	want := port.Lock{
+		Name:       l.name,
+		Holder:     l.holder,
+		AcquiredAt: now,
+		ExpiresAt:  now.Add(l.ttl),
+	}
+
+	cur, ok, err := l.store.GetLock(ctx, l.name)
+	if err != nil {
+		return l.Held(), err
+	}
+
+	var got port.Lock
+	switch {
+	case !ok:
+		got, err = l.store.CreateLock(ctx, want)
+	case cur.Holder == l.holder:
+		want.AcquiredAt = cur.AcquiredAt
+		want.Version = cur.Version
+		got, err = l.store.ReplaceLock(ctx, want)
+	case now.Before(cur.ExpiresAt):
+		l.set(nil)
+		return false, nil
+	default:
+		want.Version = cur.Version
+		got, err = l.store.ReplaceLock(ctx, want)
+	}
+	if errors.Is(err, port.ErrLockConflict) {
+		// Another replica wrote the lock in between.
+		l.set(nil)
+		return false, nil
+	}
+	if err != nil {
+		return l.Held(), err
+	}
+	l.set(&got)
+	return true, nil
+}
+
+// Held reports whether this replica holds the lease and it has not
+// expired since it was last renewed.
+func (l *Lease) Held() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.held != nil && l.now().Before(l.held.ExpiresAt)
+}
+
+// Release deletes the lock if this replica still holds it, so another
+// replica need not wait for it to expire.
+func (l *Lease) Release(ctx context.Context) error {
+	l.mu.Lock()
+	held := l.held
+	l.held = nil
+	l.mu.Unlock()
+
+	if held == nil {
+		return nil
+	}
+	if err := l.store.DeleteLock(ctx, *held); err != nil && !errors.Is(err, port.ErrLockConflict) {
+		return err
+	}
+	return nil
+}
+
+// Status reads the lock from the store.
+func (l *Lease) Status(ctx context.Context) (Status, error) {
+	s := Status{Name: l.name, Self: l.holder}
+	cur, ok, err := l.store.GetLock(ctx, l.name)
+	if err != nil || !ok {
+		return s, err
+	}
+	s.Holder = cur.Holder
+	s.AcquiredAt = &cur.AcquiredAt
+	s.ExpiresAt = &cur.ExpiresAt
+	s.Expired = !l.now().Before(cur.ExpiresAt)
+	s.HeldSelf = cur.Holder == l.holder && !s.Expired
+	return s, nil
+}
+
+// Run tries to take or renew the lease at once and then every third of
+// the TTL until ctx ends, when it releases the lease.
+func (l *Lease) Run(ctx context.Context) {
+	l.heartbeat(ctx)
+
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			l.heartbeat(ctx)
+		case <-ctx.Done():
+			releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), releaseTimeout)
+			defer cancel()
+			if err := l.Release(releaseCtx); err != nil {
+				l.logger.Warn("Failed to release job lease", "lock", l.name, "error", err)
+			}
+			return
+		}
+	}
+}
+
+func (l *Lease) heartbeat(ctx context.Context) {
+	was := l.Held()
+	held, err := l.Acquire(ctx)
+	if err != nil {
+		l.logger.Warn("Failed to renew job lease", "lock", l.name, "error", err)
+	}
+	switch {
+	case held && !was:
+		l.logger.Info("Job lease acquired", "lock", l.name, "holder", l.holder, "ttl", l.ttl)
+	case !held && was:
+		l.logger.Warn("Job lease lost", "lock", l.name, "holder", l.holder)
+	}
+}
+
+func (l *Lease) set(held *port.Lock) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.held = held
+}
@@ -0,0 +1,259 @@
+package lease
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/opensearch"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+// manualClock is a time source tests move by hand. It is shared by every
+// Lease in a test, standing in for replicas with synchronized clocks.
+type manualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *manualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newLease(t *testing.T, store port.LockStore, holder string, clock *manualClock) *Lease {
+	return New(store, JobsLock, holder, 30*time.Second, testutil.NewLogger(t), WithClock(clock.Now))
+}
+
+func TestAcquire_FreeLock(t *testing.T) {
+	store := opensearch.NewMemoryClient()
+	clock := &manualClock{now: time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)}
+	l := newLease(t, store, "pod-a", clock)
+
+	held, err := l.Acquire(context.Background())
+	require.NoError(t, err)
+	assert.True(t, held)
+	assert.True(t, l.Held())
+
+	stored, ok, err := store.GetLock(context.Background(), JobsLock)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "pod-a", stored.Holder)
+	assert.Equal(t, clock.Now(), stored.AcquiredAt)
+	assert.Equal(t, clock.Now().Add(30*time.Second), stored.ExpiresAt)
+}
+
+func TestAcquire_RenewKeepsAcquiredAt(t *testing.T) {
+	store := opensearch.NewMemoryClient()
+	clock := &manualClock{now: time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)}
+	l := newLease(t, store, "pod-a", clock)
+	acquiredAt := clock.Now()
+
+	_, err := l.Acquire(context.Background())
+	require.NoError(t, err)
+	clock.Advance(10 * time.Second)
+	held, err := l.Acquire(context.Background())
+	require.NoError(t, err)
+	assert.True(t, held)
+
+	stored, _, err := store.GetLock(context.Background(), JobsLock)
+	require.NoError(t, err)
+	assert.Equal(t, acquiredAt, stored.AcquiredAt)
+	assert.Equal(t, clock.Now().Add(30*time.Second), stored.ExpiresAt, "renewal must push the expiry forward")
+}
+
+func TestAcquire_HeldByOther(t *testing.T) {
+	store := opensearch.NewMemoryClient()
+	clock := &manualClock{now: time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)}
+	a := newLease(t, store, "pod-a", clock)
+	b := newLease(t, store, "pod-b", clock)
+
+	_, err := a.Acquire(context.Background())
+	require.NoError(t, err)
+	clock.Advance(29 * time.Second)
+
+	held, err := b.Acquire(context.Background())
+	require.NoError(t, err)
+	assert.False(t, held)
+	assert.False(t, b.Held())
+	assert.True(t, a.Held())
+}
+
+func TestAcquire_TakesOverExpiredLock(t *testing.T) {
+	store := opensearch.NewMemoryClient()
+	clock := &manualClock{now: time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)}
+	a := newLease(t, store, "pod-a", clock)
+	b := newLease(t, store, "pod-b", clock)
+
+	_, err := a.Acquire(context.Background())
+	require.NoError(t, err)
+	clock.Advance(30 * time.Second)
+	assert.False(t, a.Held(), "an unrenewed lease lapses at its expiry")
+
+	held, err := b.Acquire(context.Background())
+	require.NoError(t, err)
+	assert.True(t, held)
+
+	// The old holder comes back and finds the lease taken.
+	held, err = a.Acquire(context.Background())
+	require.NoError(t, err)
+	assert.False(t, held)
+
+	s, err := a.Status(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "pod-b", s.Holder)
+	assert.False(t, s.HeldSelf)
+}
+
+func TestAcquire_Contention(t *testing.T) {
+	store := opensearch.NewMemoryClient()
+	clock := &manualClock{now: time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)}
+
+	const replicas = 8
+	var winners atomic.Int32
+	var wg sync.WaitGroup
+	for i := range replicas {
+		l := newLease(t, store, string(rune('a'+i)), clock)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			held, err := l.Acquire(context.Background())
+			assert.NoError(t, err)
+			if held {
+				winners.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), winners.Load(), "exactly one replica must win the lease")
+}
+
+// raceStore lets a rival write the lock between GetLock and the write that
+// follows it.
+type raceStore struct {
+	*opensearch.MemoryClient
+	before func()
+}
+
+func (s *raceStore) ReplaceLock(ctx context.Context, l port.Lock) (port.Lock, error) {
+	s.before()
+	return s.MemoryClient.ReplaceLock(ctx, l)
+}
+
+func TestAcquire_LosesRaceForExpiredLock(t *testing.T) {
+	mem := opensearch.NewMemoryClient()
+	clock := &manualClock{now: time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)}
+	_, err := newLease(t, mem, "pod-a", clock).Acquire(context.Background())
+	require.NoError(t, err)
+	clock.Advance(time.Minute)
+
+	rival := newLease(t, mem, "pod-c", clock)
+	store := &raceStore{MemoryClient: mem, before: func() {
+		held, err := rival.Acquire(context.Background())
+		require.NoError(t, err)
+		require.True(t, held)
+	}}
+	b := newLease(t, store, "pod-b", clock)
+
+	held, err := b.Acquire(context.Background())
+	require.NoError(t, err)
+	assert.False(t, held, "a stale version must not overwrite the rival's lock")
+	assert.True(t, rival.Held())
+}
+
+// failingStore fails every read.
+type failingStore struct {
+	*opensearch.MemoryClient
+	fail atomic.Bool
+}
+
+func (s *failingStore) GetLock(ctx context.Context, name string) (port.Lock, bool, error) {
+	if s.fail.Load() {
+		return port.Lock{}, false, errors.New("cluster unavailable")
+	}
+	return s.MemoryClient.GetLock(ctx, name)
+}
+
+func TestAcquire_StoreErrorKeepsLeaseUntilExpiry(t *testing.T) {
+	store := &failingStore{MemoryClient: opensearch.NewMemoryClient()}
+	clock := &manualClock{now: time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)}
+	l := newLease(t, store, "pod-a", clock)
+	_, err := l.Acquire(context.Background())
+	require.NoError(t, err)
+
+	store.fail.Store(true)
+	clock.Advance(10 * time.Second)
+	held, err := l.Acquire(context.Background())
+	assert.Error(t, err)
+	assert.True(t, held, "the lease is still valid until its expiry")
+
+	clock.Advance(20 * time.Second)
+	held, err = l.Acquire(context.Background())
+	assert.Error(t, err)
+	assert.False(t, held)
+}
+
+func TestRelease(t *testing.T) {
+	store := opensearch.NewMemoryClient()
+	clock := &manualClock{now: time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)}
+	a := newLease(t, store, "pod-a", clock)
+	b := newLease(t, store, "pod-b", clock)
+
+	_, err := a.Acquire(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, a.Release(context.Background()))
+	assert.False(t, a.Held())
+
+	held, err := b.Acquire(context.Background())
+	require.NoError(t, err)
+	assert.True(t, held, "a released lease is free at once")
+
+	require.NoError(t, a.Release(context.Background()), "releasing an unheld lease is a no-op")
+	assert.True(t, b.Held())
+}
+
+func TestStatus_NoHolder(t *testing.T) {
+	clock := &manualClock{now: time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)}
+	l := newLease(t, opensearch.NewMemoryClient(), "pod-a", clock)
+
+	s, err := l.Status(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Status{Name: JobsLock, Self: "pod-a"}, s)
+}
+
+func TestRun_AcquiresAndReleases(t *testing.T) {
+	store := opensearch.NewMemoryClient()
+	l := New(store, JobsLock, "pod-a", 30*time.Millisecond, testutil.NewLogger(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		l.Run(ctx)
+		close(done)
+	}()
+
+	require.Eventually(t, l.Held, time.Second, time.Millisecond)
+	// Several renewals later the lease is still held.
+	time.Sleep(100 * time.Millisecond)
+	assert.True(t, l.Held())
+
+	cancel()
+	<-done
+	_, ok, err := store.GetLock(context.Background(), JobsLock)
+	require.NoError(t, err)
+	assert.False(t, ok, "Run must release the lease when it stops")
+}
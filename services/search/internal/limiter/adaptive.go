@@ -0,0 +1,139 @@
+package limiter
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Adaptive paces writes to the search backend with a token bucket whose
+// rate halves each time the backend rejects a write for being overloaded
+// and climbs back to its maximum a little with every accepted write. One
+// Adaptive is shared by everything that indexes, so a backfill and the
+// Kafka consumer slow down together.
+type Adaptive struct {
+	max    float64
+	min    float64
+	step   float64
+	logger *slog.Logger
+	now    func() time.Time
+
+	mu     sync.Mutex
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+// AdaptiveOption configures an Adaptive.
+type AdaptiveOption func(*Adaptive)
+
+// WithAdaptiveClock replaces time.Now for refilling the bucket.
+func WithAdaptiveClock(now func() time.Time) AdaptiveOption {
+	return func(a *Adaptive) {
+		a.now = now
+	}
+}
+
+// Recovery bounds of an Adaptive: the rate never drops below
+// maxRate/minRateDivisor, nor below one write per second, and each
+// accepted write adds maxRate/recoverySteps back.
+const (
+	minRateDivisor = 100
+	recoverySteps  = 200
+)
+
+// NewAdaptive returns an Adaptive allowing up to maxRate writes per second,
+// in bursts of up to one second's worth.
+func NewAdaptive(maxRate float64, logger *slog.Logger, opts ...AdaptiveOption) *Adaptive {
+	a := &Adaptive{
+		max:    maxRate,
+		min:    min(maxRate, max(maxRate/minRateDivisor, 1)),
+		step:   maxRate / recoverySteps,
+		logger: logger,
+		now:    time.Now,
+		rate:   maxRate,
+		tokens: maxRate,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	a.last = a.now()
+	return a
+}
+
+// Rate returns the current writes per second.
+func (a *Adaptive) Rate() float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.rate
+}
+
+// Wait takes n tokens, one per document written, blocking until the
+// bucket has refilled enough or ctx ends. A batch larger than the bucket
+// goes ahead once the bucket is full and leaves it in debt, delaying the
+// writes after it instead.
+func (a *Adaptive) Wait(ctx context.Context, n int) error {
+	a.mu.Lock()
+	a.refill()
+	wait := time.Duration(0)
+	if need := min(float64(n), a.rate); a.tokens < need {
+		wait = time.Duration((need - a.tokens) / a.rate * float64(time.Second))
+	}
+	a.tokens -= float64(n)
+	a.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// The write is abandoned, so its tokens go back.
+		a.mu.Lock()
+		a.tokens += float64(n)
+		a.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// Throttled halves the rate after the backend rejected a write with 429.
+func (a *Adaptive) Throttled() {
+	a.mu.Lock()
+	a.refill()
+	prev := a.rate
+	a.rate = max(a.rate/2, a.min)
+	a.tokens = min(a.tokens, a.rate)
+	rate := a.rate
+	a.mu.Unlock()
+
+	if rate < prev {
+		a.logger.Warn("Indexing rate lowered after OpenSearch rejected a write", "rate", rate, "max_rate", a.max)
+	}
+}
+
+// Succeeded raises the rate towards its maximum after n documents were
+// accepted.
+func (a *Adaptive) Succeeded(n int) {
+	a.mu.Lock()
+	a.refill()
+	prev := a.rate
+	a.rate = min(a.rate+a.step*float64(n), a.max)
+	rate := a.rate
+	a.mu.Unlock()
+
+	if rate == a.max && prev < a.max {
+		a.logger.Info("Indexing rate recovered", "rate", rate)
+	}
+}
+
+// refill adds the tokens earned since the last call, up to one second's
+// worth. Callers hold a.mu.
+func (a *Adaptive) refill() {
+	now := a.now()
+	a.tokens = min(a.tokens+now.Sub(a.last).Seconds()*a.rate, a.rate)
+	a.last = now
+}
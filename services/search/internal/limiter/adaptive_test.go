@@ -0,0 +1,77 @@
+package limiter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/testutil"
+)
+
+// frozenClock never advances, so the bucket only holds its initial tokens.
+func frozenClock() func() time.Time {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	return func() time.Time { return now }
+}
+
+func TestAdaptive_BurstOf429sThenRecovery(t *testing.T) {
+	a := NewAdaptive(400, testutil.NewLogger(t), WithAdaptiveClock(frozenClock()))
+	require.Equal(t, 400.0, a.Rate())
+
+	for _, want := range []float64{200, 100, 50, 25, 12.5, 6.25, 4, 4} {
+		a.Throttled()
+		assert.Equal(t, want, a.Rate(), "each 429 halves the rate down to a hundredth of the maximum")
+	}
+
+	a.Succeeded(1)
+	assert.Equal(t, 6.0, a.Rate(), "each accepted write adds back a two-hundredth of the maximum")
+	a.Succeeded(100)
+	assert.Equal(t, 206.0, a.Rate())
+	a.Succeeded(1000)
+	assert.Equal(t, 400.0, a.Rate(), "recovery stops at the maximum")
+}
+
+func TestAdaptive_MinimumRate(t *testing.T) {
+	a := NewAdaptive(20, testutil.NewLogger(t), WithAdaptiveClock(frozenClock()))
+	for range 10 {
+		a.Throttled()
+	}
+	assert.Equal(t, 1.0, a.Rate(), "the rate never drops below one write per second")
+}
+
+func TestAdaptive_WaitPaces(t *testing.T) {
+	a := NewAdaptive(50, testutil.NewLogger(t))
+	ctx := context.Background()
+
+	start := time.Now()
+	require.NoError(t, a.Wait(ctx, 50), "a full bucket lets a second's worth through at once")
+	assert.Less(t, time.Since(start), 10*time.Millisecond)
+
+	require.NoError(t, a.Wait(ctx, 2))
+	assert.GreaterOrEqual(t, time.Since(start), 35*time.Millisecond, "an empty bucket refills at the rate")
+}
+
+func TestAdaptive_WaitLargeBatch(t *testing.T) {
+	a := NewAdaptive(1000, testutil.NewLogger(t), WithAdaptiveClock(frozenClock()))
+
+	// A batch above the bucket size goes out once the bucket is full
+	// instead of waiting forever.
+	require.NoError(t, a.Wait(context.Background(), 1500))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, a.Wait(ctx, 1), context.DeadlineExceeded, "the debt delays the next write")
+}
+
+func TestAdaptive_WaitCancelledReturnsTokens(t *testing.T) {
+	a := NewAdaptive(10, testutil.NewLogger(t), WithAdaptiveClock(frozenClock()))
+	require.NoError(t, a.Wait(context.Background(), 10))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, a.Wait(ctx, 5), context.Canceled)
+	assert.Equal(t, 0.0, a.tokens, "an abandoned write gives its tokens back")
+}
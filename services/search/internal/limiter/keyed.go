@@ -0,0 +1,95 @@
+package limiter
+
+import (
+	"sync"
+	"time"
+)
+
+// keyedSweepInterval is how often a Keyed drops the buckets of keys that
+// have gone quiet.
+const keyedSweepInterval = time.Minute
+
+// Keyed gives each key, such as a client ID, its own token bucket. Buckets
+// that have refilled completely are dropped, since a fresh bucket for the
+// same key would behave the same, so memory follows the active keys only.
+type Keyed struct {
+	rate  float64
+	burst float64
+	now   func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// KeyedOption configures a Keyed.
+type KeyedOption func(*Keyed)
+
+// WithKeyedClock replaces time.Now for refilling the buckets.
+func WithKeyedClock(now func() time.Time) KeyedOption {
+	return func(k *Keyed) {
+		k.now = now
+	}
+}
+
+// NewKeyed allows each key rate requests per second, in bursts of up to
+// burst.
+func NewKeyed(rate float64, burst int, opts ...KeyedOption) *Keyed {
+	k := &Keyed{
+		rate:    rate,
+		burst:   float64(max(burst, 1)),
+		now:     time.Now,
+		buckets: make(map[string]*bucket),
+	}
+	for _, opt := range opts {
+		opt(k)
+	}
+	k.lastSweep = k.now()
+	return k
+}
+
+// Allow takes a token from key's bucket and reports whether there was one.
+func (k *Keyed) Allow(key string) bool {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	now := k.now()
+	if now.Sub(k.lastSweep) >= keyedSweepInterval {
+		k.sweep(now)
+	}
+
+	b, ok := k.buckets[key]
+	if !ok {
+		b = &bucket{tokens: k.burst, last: now}
+		k.buckets[key] = b
+	}
+	b.tokens = min(b.tokens+now.Sub(b.last).Seconds()*k.rate, k.burst)
+	b.last = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// sweep drops the buckets that are full again. Callers hold k.mu.
+func (k *Keyed) sweep(now time.Time) {
+	for key, b := range k.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*k.rate >= k.burst {
+			delete(k.buckets, key)
+		}
+	}
+	k.lastSweep = now
+}
+
+// Len returns how many keys have a bucket.
+func (k *Keyed) Len() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return len(k.buckets)
+}
@@ -0,0 +1,43 @@
+package limiter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyed_LimitsEachKeySeparately(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	k := NewKeyed(2, 3, WithKeyedClock(func() time.Time { return now }))
+
+	for range 3 {
+		assert.True(t, k.Allow("a"), "a burst of 3 is allowed")
+	}
+	assert.False(t, k.Allow("a"), "the fourth request in the same instant is refused")
+	assert.True(t, k.Allow("b"), "other keys have their own bucket")
+
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, k.Allow("a"), "half a second earns one token at 2/s")
+	assert.False(t, k.Allow("a"))
+
+	now = now.Add(time.Hour)
+	for range 3 {
+		assert.True(t, k.Allow("a"), "the bucket refills up to the burst only")
+	}
+	assert.False(t, k.Allow("a"))
+}
+
+func TestKeyed_DropsIdleBuckets(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	k := NewKeyed(1, 5, WithKeyedClock(func() time.Time { return now }))
+
+	for _, key := range []string{"a", "b", "c"} {
+		k.Allow(key)
+	}
+	assert.Equal(t, 3, k.Len())
+
+	now = now.Add(keyedSweepInterval)
+	k.Allow("d")
+	assert.Equal(t, 1, k.Len(), "refilled buckets are dropped on the next sweep")
+}
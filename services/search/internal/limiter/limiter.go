@@ -0,0 +1,376 @@
+// Package limiter caps how many calls into the search backend run at once,
+// so a burst of traffic queues briefly in the service instead of piling up
+// on the cluster.
+package limiter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"search/internal/domain"
+	"search/internal/port"
+)
+
+// Client wraps a port.SearchClient with a counting semaphore. Each call
+// except Ping holds one slot for its whole duration; ScanTutors holds it
+// until the scan ends.
+type Client struct {
+	next port.SearchClient
+	sem  chan struct{}
+	wait time.Duration
+}
+
+var _ port.SearchClient = (*Client)(nil)
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithWait bounds how long a call waits for a free slot before failing with
+// port.ErrOverloaded. Zero, the default, waits until the call's context
+// ends.
+func WithWait(d time.Duration) Option {
+	return func(c *Client) {
+		c.wait = d
+	}
+}
+
+// New returns a Client that allows at most limit concurrent calls to next.
+func New(next port.SearchClient, limit int, opts ...Option) *Client {
+	c := &Client{
+		next: next,
+		sem:  make(chan struct{}, max(limit, 1)),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// InFlight reports how many calls currently hold a slot.
+func (c *Client) InFlight() int {
+	return len(c.sem)
+}
+
+// acquire takes a slot, waiting at most c.wait when it is set.
+func (c *Client) acquire(ctx context.Context) error {
+	select {
+	case c.sem <- struct{}{}:
+		return nil
+	default:
+	}
+
+	var timeout <-chan time.Time
+	if c.wait > 0 {
+		timer := time.NewTimer(c.wait)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case c.sem <- struct{}{}:
+		return nil
+	case <-timeout:
+		return fmt.Errorf("%w: %d calls in flight", port.ErrOverloaded, cap(c.sem))
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (c *Client) release() {
+	<-c.sem
+}
+
+// Ping bypasses the limit so health checks answer during a burst.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.next.Ping(ctx)
+}
+
+func (c *Client) EnsureIndex(ctx context.Context) error {
+	if err := c.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.release()
+	return c.next.EnsureIndex(ctx)
+}
+
+func (c *Client) UpsertTutor(ctx context.Context, tutor *domain.Tutor) error {
+	if err := c.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.release()
+	return c.next.UpsertTutor(ctx, tutor)
+}
+
+func (c *Client) BulkUpsertTutors(ctx context.Context, tutors []*domain.Tutor) (*port.BulkResult, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+	return c.next.BulkUpsertTutors(ctx, tutors)
+}
+
+func (c *Client) UpsertSnapshotTutor(ctx context.Context, tutor *domain.Tutor, snapshotID string) (bool, error) {
+	if err := c.acquire(ctx); err != nil {
+		return false, err
+	}
+	defer c.release()
+	return c.next.UpsertSnapshotTutor(ctx, tutor, snapshotID)
+}
+
+func (c *Client) BookSlot(ctx context.Context, tutorID int64, slot time.Time) error {
+	if err := c.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.release()
+	return c.next.BookSlot(ctx, tutorID, slot)
+}
+
+func (c *Client) FreeSlot(ctx context.Context, tutorID int64, slot time.Time) error {
+	if err := c.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.release()
+	return c.next.FreeSlot(ctx, tutorID, slot)
+}
+
+func (c *Client) UpdateBadges(ctx context.Context, tutorID int64, add, remove []string) error {
+	if err := c.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.release()
+	return c.next.UpdateBadges(ctx, tutorID, add, remove)
+}
+
+func (c *Client) CheckQuality(ctx context.Context, rules []string, limit, offset int) ([]port.QualityRuleResult, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+	return c.next.CheckQuality(ctx, rules, limit, offset)
+}
+
+func (c *Client) SetVerified(ctx context.Context, tutorID int64, verified bool, at time.Time) error {
+	if err := c.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.release()
+	return c.next.SetVerified(ctx, tutorID, verified, at)
+}
+
+func (c *Client) SetOffersTrial(ctx context.Context, tutorID int64, offers bool) error {
+	if err := c.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.release()
+	return c.next.SetOffersTrial(ctx, tutorID, offers)
+}
+
+func (c *Client) DeleteTutor(ctx context.Context, id int64) error {
+	if err := c.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.release()
+	return c.next.DeleteTutor(ctx, id)
+}
+
+func (c *Client) BulkDeleteTutors(ctx context.Context, ids []int64) ([]port.BulkDeleteResult, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+	return c.next.BulkDeleteTutors(ctx, ids)
+}
+
+func (c *Client) GetTutor(ctx context.Context, id int64) (*domain.Tutor, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+	return c.next.GetTutor(ctx, id)
+}
+
+func (c *Client) GetTutors(ctx context.Context, ids []int64) (map[int64]domain.Tutor, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+	return c.next.GetTutors(ctx, ids)
+}
+
+func (c *Client) SearchTutors(ctx context.Context, query port.SearchQuery) (*port.SearchResponse, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+	return c.next.SearchTutors(ctx, query)
+}
+
+func (c *Client) GetTutorBySlug(ctx context.Context, slug string) (*domain.Tutor, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+	return c.next.GetTutorBySlug(ctx, slug)
+}
+
+func (c *Client) ChangeSlug(ctx context.Context, tutorID int64, oldSlug, newSlug string) error {
+	if err := c.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.release()
+	return c.next.ChangeSlug(ctx, tutorID, oldSlug, newSlug)
+}
+
+func (c *Client) SearchTutorsWithFacets(ctx context.Context, query port.SearchQuery, facetSize int) (*port.SearchResponse, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+	return c.next.SearchTutorsWithFacets(ctx, query, facetSize)
+}
+
+func (c *Client) SearchTutorsMulti(ctx context.Context, queries []port.SearchQuery) ([]*port.SearchResponse, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+	return c.next.SearchTutorsMulti(ctx, queries)
+}
+
+func (c *Client) ScanTutors(ctx context.Context, query port.SearchQuery, limit int, fn func(domain.Tutor) error) error {
+	if err := c.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.release()
+	return c.next.ScanTutors(ctx, query, limit, fn)
+}
+
+func (c *Client) TopTutorsBySubject(ctx context.Context, subjects []string, perSubject int) (map[string][]domain.Tutor, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+	return c.next.TopTutorsBySubject(ctx, subjects, perSubject)
+}
+
+func (c *Client) FacetCounts(ctx context.Context, size int) (*port.FacetCounts, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+	return c.next.FacetCounts(ctx, size)
+}
+
+func (c *Client) PriceStats(ctx context.Context, subject string, byFormat bool) (*port.PriceStats, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+	return c.next.PriceStats(ctx, subject, byFormat)
+}
+
+func (c *Client) RelatedSubjects(ctx context.Context, subject string, size int) (*port.RelatedSubjects, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+	return c.next.RelatedSubjects(ctx, subject, size)
+}
+
+func (c *Client) QuickSearch(ctx context.Context, prefix string, size int) (*port.QuickSearchResult, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+	return c.next.QuickSearch(ctx, prefix, size)
+}
+
+func (c *Client) RawSearch(ctx context.Context, body []byte) (json.RawMessage, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+	return c.next.RawSearch(ctx, body)
+}
+
+func (c *Client) IndexedTutorIDs(ctx context.Context) ([]int64, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+	return c.next.IndexedTutorIDs(ctx)
+}
+
+// ScanTutorVersions holds its slot for the whole scan, like ScanTutors.
+func (c *Client) ScanTutorVersions(ctx context.Context, fn func(port.TutorVersion) error) error {
+	if err := c.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.release()
+	return c.next.ScanTutorVersions(ctx, fn)
+}
+
+func (c *Client) CountTutors(ctx context.Context) (int64, error) {
+	if err := c.acquire(ctx); err != nil {
+		return 0, err
+	}
+	defer c.release()
+	return c.next.CountTutors(ctx)
+}
+
+func (c *Client) RecreateIndex(ctx context.Context) (*port.RecreateResult, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+	return c.next.RecreateIndex(ctx)
+}
+
+func (c *Client) RecomputePopularity(ctx context.Context) (*port.PopularityResult, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+	return c.next.RecomputePopularity(ctx)
+}
+
+func (c *Client) UpdateIndexSettings(ctx context.Context, replicas int) error {
+	if err := c.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.release()
+	return c.next.UpdateIndexSettings(ctx, replicas)
+}
+
+func (c *Client) CreateExperimentIndex(ctx context.Context, mapping port.ExperimentMapping) (*port.ExperimentIndex, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+	return c.next.CreateExperimentIndex(ctx, mapping)
+}
+
+func (c *Client) DeleteExperimentIndex(ctx context.Context) error {
+	if err := c.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.release()
+	return c.next.DeleteExperimentIndex(ctx)
+}
+
+func (c *Client) IndexAliases(ctx context.Context) (*port.IndexAliases, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+	return c.next.IndexAliases(ctx)
+}
+
+func (c *Client) PointIndexAliases(ctx context.Context, read, write string) (*port.IndexAliases, error) {
+	if err := c.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer c.release()
+	return c.next.PointIndexAliases(ctx, read, write)
+}
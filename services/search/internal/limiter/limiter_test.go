@@ -0,0 +1,160 @@
+package limiter
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/domain"
+	"search/internal/port"
+)
+
+// blockingClient holds every search and upsert until release is closed,
+// recording the highest number of calls it saw at once.
+type blockingClient struct {
+	port.SearchClient
+
+	release  chan struct{}
+	entered  chan struct{}
+	inFlight atomic.Int32
+	peak     atomic.Int32
+	pings    atomic.Int32
+}
+
+func newBlockingClient() *blockingClient {
+	return &blockingClient{
+		release: make(chan struct{}),
+		entered: make(chan struct{}, 100),
+	}
+}
+
+func (b *blockingClient) block() {
+	n := b.inFlight.Add(1)
+	for {
+		peak := b.peak.Load()
+		if n <= peak || b.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	b.entered <- struct{}{}
+	<-b.release
+	b.inFlight.Add(-1)
+}
+
+func (b *blockingClient) Ping(context.Context) error {
+	b.pings.Add(1)
+	return nil
+}
+
+func (b *blockingClient) SearchTutors(context.Context, port.SearchQuery) (*port.SearchResponse, error) {
+	b.block()
+	return &port.SearchResponse{}, nil
+}
+
+func (b *blockingClient) UpsertTutor(context.Context, *domain.Tutor) error {
+	b.block()
+	return nil
+}
+
+// waitEntered waits until n calls are blocked inside the client.
+func (b *blockingClient) waitEntered(t *testing.T, n int) {
+	t.Helper()
+	for range n {
+		select {
+		case <-b.entered:
+		case <-time.After(time.Second):
+			t.Fatal("call did not reach the backend")
+		}
+	}
+}
+
+func TestClient_CapsConcurrentCalls(t *testing.T) {
+	backend := newBlockingClient()
+	c := New(backend, 3)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.SearchTutors(context.Background(), port.SearchQuery{})
+			assert.NoError(t, err)
+		}()
+	}
+
+	backend.waitEntered(t, 3)
+	assert.Equal(t, 3, c.InFlight())
+	close(backend.release)
+	wg.Wait()
+
+	assert.Equal(t, int32(3), backend.peak.Load())
+	assert.Zero(t, c.InFlight(), "every slot is released")
+}
+
+func TestClient_WaitTimesOutWithErrOverloaded(t *testing.T) {
+	backend := newBlockingClient()
+	defer close(backend.release)
+	c := New(backend, 1, WithWait(10*time.Millisecond))
+
+	go c.SearchTutors(context.Background(), port.SearchQuery{})
+	backend.waitEntered(t, 1)
+
+	start := time.Now()
+	_, err := c.SearchTutors(context.Background(), port.SearchQuery{})
+
+	require.ErrorIs(t, err, port.ErrOverloaded)
+	assert.GreaterOrEqual(t, time.Since(start), 10*time.Millisecond)
+	assert.Equal(t, int32(1), backend.inFlight.Load(), "the rejected call never reached the backend")
+}
+
+func TestClient_WaitGetsSlotFreedInTime(t *testing.T) {
+	backend := newBlockingClient()
+	c := New(backend, 1, WithWait(time.Second))
+
+	go c.UpsertTutor(context.Background(), &domain.Tutor{ID: 1})
+	backend.waitEntered(t, 1)
+
+	done := make(chan error, 1)
+	go func() { done <- c.UpsertTutor(context.Background(), &domain.Tutor{ID: 2}) }()
+	close(backend.release)
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("waiting call did not get the freed slot")
+	}
+}
+
+func TestClient_NoWaitBlocksUntilContextEnds(t *testing.T) {
+	backend := newBlockingClient()
+	defer close(backend.release)
+	c := New(backend, 1)
+
+	go c.UpsertTutor(context.Background(), &domain.Tutor{ID: 1})
+	backend.waitEntered(t, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := c.UpsertTutor(ctx, &domain.Tutor{ID: 2})
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.NotErrorIs(t, err, port.ErrOverloaded)
+}
+
+func TestClient_PingBypassesLimit(t *testing.T) {
+	backend := newBlockingClient()
+	defer close(backend.release)
+	c := New(backend, 1, WithWait(time.Millisecond))
+
+	go c.SearchTutors(context.Background(), port.SearchQuery{})
+	backend.waitEntered(t, 1)
+
+	require.NoError(t, c.Ping(context.Background()))
+	assert.Equal(t, int32(1), backend.pings.Load())
+}
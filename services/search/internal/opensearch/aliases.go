@@ -0,0 +1,173 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// WithIndexAliases sends searches to the read alias <index>-read and
+// writes to the write alias <index>-write, which EnsureIndex points at the
+// index when they do not exist yet. PointIndexAliases moves them apart for
+// maintenance. Without it both go to the index itself and index aliases
+// are unsupported.
+func WithIndexAliases() ClientOption {
+	return func(c *Client) error {
+		c.aliases = true
+		return nil
+	}
+}
+
+// readIndex returns what searches and reads on ctx target: the read alias
+// with WithIndexAliases, ctx's index otherwise.
+func (c *Client) readIndex(ctx context.Context) string {
+	if c.aliases {
+		return c.index(ctx) + ReadAliasSuffix
+	}
+	return c.index(ctx)
+}
+
+// writeIndex returns what tutor writes on ctx target: the write alias with
+// WithIndexAliases, ctx's index otherwise.
+func (c *Client) writeIndex(ctx context.Context) string {
+	if c.aliases {
+		return c.index(ctx) + WriteAliasSuffix
+	}
+	return c.index(ctx)
+}
+
+// IndexAliases returns where ctx's read and write aliases point.
+func (c *Client) IndexAliases(ctx context.Context) (*IndexAliases, error) {
+	if !c.aliases {
+		return nil, ErrUnsupported
+	}
+	aliases := &IndexAliases{
+		Read:  AliasTarget{Alias: c.readIndex(ctx)},
+		Write: AliasTarget{Alias: c.writeIndex(ctx)},
+	}
+	for _, target := range []*AliasTarget{&aliases.Read, &aliases.Write} {
+		indices, err := c.aliasIndices(ctx, target.Alias)
+		if err != nil {
+			return nil, err
+		}
+		// Only this client moves the aliases, always to a single index.
+		if len(indices) > 0 {
+			target.Index = indices[0]
+		}
+	}
+	return aliases, nil
+}
+
+// PointIndexAliases moves ctx's read alias to read and its write alias to
+// write in one _aliases request, so searches never see either missing.
+// An empty argument leaves its alias where it is.
+func (c *Client) PointIndexAliases(ctx context.Context, read, write string) (*IndexAliases, error) {
+	if !c.aliases {
+		return nil, ErrUnsupported
+	}
+	current, err := c.IndexAliases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var actions []map[string]any
+	for _, move := range []struct {
+		target *AliasTarget
+		index  string
+	}{{&current.Read, read}, {&current.Write, write}} {
+		if move.index == "" || move.index == move.target.Index {
+			continue
+		}
+		if move.target.Index != "" {
+			actions = append(actions, map[string]any{
+				"remove": map[string]any{"index": move.target.Index, "alias": move.target.Alias},
+			})
+		}
+		actions = append(actions, map[string]any{
+			"add": map[string]any{"index": move.index, "alias": move.target.Alias},
+		})
+	}
+	if len(actions) == 0 {
+		return current, nil
+	}
+
+	if err := c.updateAliases(ctx, actions); err != nil {
+		if isIndexNotFound(err) {
+			return nil, ErrIndexNotFound
+		}
+		return nil, err
+	}
+
+	previous := *current
+	if read != "" {
+		current.Read.Index = read
+	}
+	if write != "" {
+		current.Write.Index = write
+	}
+	c.logger.Warn("Index aliases moved",
+		"index", c.index(ctx),
+		"read", current.Read.Index,
+		"write", current.Write.Index,
+		"previous_read", previous.Read.Index,
+		"previous_write", previous.Write.Index,
+	)
+	return current, nil
+}
+
+// ensureAliases points whichever of ctx's read and write aliases does not
+// exist at ctx's index. Existing ones are left alone, so a restart during
+// maintenance keeps them apart.
+func (c *Client) ensureAliases(ctx context.Context) error {
+	if !c.aliases {
+		return nil
+	}
+	current, err := c.IndexAliases(ctx)
+	if err != nil {
+		return err
+	}
+
+	var actions []map[string]any
+	for _, target := range []AliasTarget{current.Read, current.Write} {
+		if target.Index == "" {
+			actions = append(actions, map[string]any{
+				"add": map[string]any{"index": c.index(ctx), "alias": target.Alias},
+			})
+		}
+	}
+	if len(actions) == 0 {
+		return nil
+	}
+	if err := c.updateAliases(ctx, actions); err != nil {
+		return err
+	}
+	c.logger.Info("Index aliases created", "index", c.index(ctx), "aliases", len(actions))
+	return nil
+}
+
+// updateAliases applies actions atomically with the _aliases API.
+func (c *Client) updateAliases(ctx context.Context, actions []map[string]any) error {
+	body, err := json.Marshal(map[string]any{"actions": actions})
+	if err != nil {
+		return fmt.Errorf("failed to marshal alias actions: %w", err)
+	}
+	if _, err := c.client.Aliases(ctx, opensearchapi.AliasesReq{Body: bytes.NewReader(body)}); err != nil {
+		return fmt.Errorf("failed to update aliases: %w", err)
+	}
+	return nil
+}
+
+// IndexAliases is not supported: the in-memory backend has a single
+// index.
+func (m *MemoryClient) IndexAliases(ctx context.Context) (*IndexAliases, error) {
+	return nil, ErrUnsupported
+}
+
+// PointIndexAliases is not supported: the in-memory backend has a single
+// index.
+func (m *MemoryClient) PointIndexAliases(ctx context.Context, read, write string) (*IndexAliases, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,301 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"search/internal/domain"
+)
+
+const (
+	readAliasPath  = "/tutors-read/_alias/tutors-read"
+	writeAliasPath = "/tutors-write/_alias/tutors-write"
+)
+
+// newAliasClient returns a test client with index aliases enabled.
+func newAliasClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	client := newTestClient(t, handler)
+	client.aliases = true
+	return client
+}
+
+// aliasLookup answers a lookup of alias as OpenSearch does, with index
+// behind it or, when index is empty, as missing.
+func aliasLookup(w http.ResponseWriter, alias, index string) {
+	if index == "" {
+		writeJSON(w, http.StatusNotFound, `{"error":{"type":"index_not_found_exception","reason":"no such index [`+alias+`]"},"status":404}`)
+		return
+	}
+	writeJSON(w, http.StatusOK, `{"`+index+`":{"aliases":{"`+alias+`":{}}}}`)
+}
+
+// aliasActions decodes an _aliases request body.
+func aliasActions(t *testing.T, r *http.Request) []map[string]map[string]string {
+	t.Helper()
+	var body struct {
+		Actions []map[string]map[string]string `json:"actions"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		t.Fatalf("decode _aliases body: %v", err)
+	}
+	return body.Actions
+}
+
+func TestIndexAliases_OperationsUseTheirAlias(t *testing.T) {
+	var mu sync.Mutex
+	var requests []string
+	client := newAliasClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		switch {
+		case r.URL.Path == "/tutors-exp/_alias/tutors-exp":
+			aliasLookup(w, "tutors-exp", "")
+		case r.URL.Path == "/tutors-read/_search":
+			writeJSON(w, http.StatusOK, `{"took":1,"hits":{"total":{"value":0},"hits":[]}}`)
+		case r.URL.Path == "/tutors-read/_doc/7":
+			writeJSON(w, http.StatusOK, `{"_id":"7","found":true,"_source":{"id":7}}`)
+		case r.URL.Path == "/tutors-read/_count":
+			writeJSON(w, http.StatusOK, `{"count":3}`)
+		case r.URL.Path == "/tutors-write/_update/7":
+			if r.URL.Query().Get("require_alias") != "true" {
+				t.Errorf("update query = %q, want require_alias=true", r.URL.RawQuery)
+			}
+			writeJSON(w, http.StatusOK, `{"_id":"7","result":"updated"}`)
+		case r.URL.Path == "/tutors-write/_doc/7":
+			writeJSON(w, http.StatusOK, `{"_id":"7","result":"deleted"}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			writeJSON(w, http.StatusInternalServerError, `{}`)
+		}
+	})
+	client.experiments = make(map[string]experimentState)
+	ctx := context.Background()
+
+	if _, err := client.SearchTutors(ctx, SearchQuery{Text: "math"}); err != nil {
+		t.Errorf("SearchTutors: %v", err)
+	}
+	if _, err := client.GetTutor(ctx, 7); err != nil {
+		t.Errorf("GetTutor: %v", err)
+	}
+	if n, err := client.CountTutors(ctx); err != nil || n != 3 {
+		t.Errorf("CountTutors = %d, %v, want 3", n, err)
+	}
+	if err := client.UpsertTutor(ctx, &domain.Tutor{ID: 7}); err != nil {
+		t.Errorf("UpsertTutor: %v", err)
+	}
+	if err := client.DeleteTutor(ctx, 7); err != nil {
+		t.Errorf("DeleteTutor: %v", err)
+	}
+
+	want := []string{
+		"POST /tutors-read/_search",
+		"GET /tutors-read/_doc/7",
+		"POST /tutors-read/_count",
+		"POST /tutors-write/_update/7",
+		"GET /tutors-exp/_alias/tutors-exp",
+		"DELETE /tutors-write/_doc/7",
+	}
+	if len(requests) != len(want) {
+		t.Fatalf("requests = %v, want %v", requests, want)
+	}
+	for i := range want {
+		if requests[i] != want[i] {
+			t.Errorf("request %d = %s, want %s", i, requests[i], want[i])
+		}
+	}
+}
+
+func TestEnsureIndex_PointsMissingAliases(t *testing.T) {
+	var actions []map[string]map[string]string
+	client := newAliasClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodHead && r.URL.Path == "/tutors":
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == readAliasPath:
+			// A restart mid-maintenance must not move the read alias back.
+			aliasLookup(w, "tutors-read", "tutors-v1")
+		case r.URL.Path == writeAliasPath:
+			aliasLookup(w, "tutors-write", "")
+		case r.Method == http.MethodPost && r.URL.Path == "/_aliases":
+			actions = aliasActions(t, r)
+			writeJSON(w, http.StatusOK, `{"acknowledged":true}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	if err := client.EnsureIndex(context.Background()); err != nil {
+		t.Fatalf("EnsureIndex: %v", err)
+	}
+	if len(actions) != 1 || actions[0]["add"]["alias"] != "tutors-write" || actions[0]["add"]["index"] != "tutors" {
+		t.Errorf("actions = %v, want only tutors-write added on tutors", actions)
+	}
+}
+
+func TestEnsureIndex_AliasesInPlace(t *testing.T) {
+	client := newAliasClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodHead && r.URL.Path == "/tutors":
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == readAliasPath:
+			aliasLookup(w, "tutors-read", "tutors")
+		case r.URL.Path == writeAliasPath:
+			aliasLookup(w, "tutors-write", "tutors-v2")
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	if err := client.EnsureIndex(context.Background()); err != nil {
+		t.Fatalf("EnsureIndex: %v", err)
+	}
+}
+
+func TestIndexAliases(t *testing.T) {
+	client := newAliasClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case readAliasPath:
+			aliasLookup(w, "tutors-read", "tutors")
+		case writeAliasPath:
+			aliasLookup(w, "tutors-write", "")
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	got, err := client.IndexAliases(context.Background())
+	if err != nil {
+		t.Fatalf("IndexAliases: %v", err)
+	}
+	want := IndexAliases{
+		Read:  AliasTarget{Alias: "tutors-read", Index: "tutors"},
+		Write: AliasTarget{Alias: "tutors-write"},
+	}
+	if *got != want {
+		t.Errorf("aliases = %+v, want %+v", *got, want)
+	}
+}
+
+func TestPointIndexAliases(t *testing.T) {
+	tests := []struct {
+		name        string
+		read, write string
+		wantActions []map[string]map[string]string
+		wantRead    string
+		wantWrite   string
+	}{
+		{
+			name:  "write only",
+			write: "tutors-v2",
+			wantActions: []map[string]map[string]string{
+				{"remove": {"index": "tutors", "alias": "tutors-write"}},
+				{"add": {"index": "tutors-v2", "alias": "tutors-write"}},
+			},
+			wantRead:  "tutors",
+			wantWrite: "tutors-v2",
+		},
+		{
+			name: "read only",
+			read: "tutors-v2",
+			wantActions: []map[string]map[string]string{
+				{"remove": {"index": "tutors", "alias": "tutors-read"}},
+				{"add": {"index": "tutors-v2", "alias": "tutors-read"}},
+			},
+			wantRead:  "tutors-v2",
+			wantWrite: "tutors",
+		},
+		{
+			name:  "both",
+			read:  "tutors-v2",
+			write: "tutors-v3",
+			wantActions: []map[string]map[string]string{
+				{"remove": {"index": "tutors", "alias": "tutors-read"}},
+				{"add": {"index": "tutors-v2", "alias": "tutors-read"}},
+				{"remove": {"index": "tutors", "alias": "tutors-write"}},
+				{"add": {"index": "tutors-v3", "alias": "tutors-write"}},
+			},
+			wantRead:  "tutors-v2",
+			wantWrite: "tutors-v3",
+		},
+		{
+			name:      "already there",
+			read:      "tutors",
+			wantRead:  "tutors",
+			wantWrite: "tutors",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var actions []map[string]map[string]string
+			client := newAliasClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == readAliasPath:
+					aliasLookup(w, "tutors-read", "tutors")
+				case r.URL.Path == writeAliasPath:
+					aliasLookup(w, "tutors-write", "tutors")
+				case r.Method == http.MethodPost && r.URL.Path == "/_aliases":
+					actions = aliasActions(t, r)
+					writeJSON(w, http.StatusOK, `{"acknowledged":true}`)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			})
+
+			got, err := client.PointIndexAliases(context.Background(), tt.read, tt.write)
+			if err != nil {
+				t.Fatalf("PointIndexAliases: %v", err)
+			}
+			if got.Read.Index != tt.wantRead || got.Write.Index != tt.wantWrite {
+				t.Errorf("aliases = %+v, want read on %s and write on %s", *got, tt.wantRead, tt.wantWrite)
+			}
+			gotJSON, _ := json.Marshal(actions)
+			wantJSON, _ := json.Marshal(tt.wantActions)
+			if string(gotJSON) != string(wantJSON) {
+				t.Errorf("actions = %s, want %s", gotJSON, wantJSON)
+			}
+		})
+	}
+}
+
+func TestPointIndexAliases_IndexNotFound(t *testing.T) {
+	client := newAliasClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case readAliasPath:
+			aliasLookup(w, "tutors-read", "tutors")
+		case writeAliasPath:
+			aliasLookup(w, "tutors-write", "tutors")
+		case "/_aliases":
+			writeJSON(w, http.StatusNotFound, `{"error":{"type":"index_not_found_exception","reason":"no such index [tutors-v9]"},"status":404}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	if _, err := client.PointIndexAliases(context.Background(), "", "tutors-v9"); !errors.Is(err, ErrIndexNotFound) {
+		t.Errorf("err = %v, want ErrIndexNotFound", err)
+	}
+}
+
+func TestIndexAliases_Unsupported(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+	ctx := context.Background()
+
+	if _, err := client.IndexAliases(ctx); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("IndexAliases err = %v, want ErrUnsupported without WithIndexAliases", err)
+	}
+	if _, err := client.PointIndexAliases(ctx, "tutors-v2", ""); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("PointIndexAliases err = %v, want ErrUnsupported without WithIndexAliases", err)
+	}
+	if _, err := NewMemoryClient().IndexAliases(ctx); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("MemoryClient.IndexAliases err = %v, want ErrUnsupported", err)
+	}
+}
@@ -0,0 +1,129 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v4"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// updateRetries is how often a partial update is retried when a concurrent
+// write to the same tutor wins the version check.
+const updateRetries = 3
+
+// freeSlotScript makes the freed slot the next available one if it is
+// earlier than the current value or the current value has already passed.
+const freeSlotScript = `
+def slot = ZonedDateTime.parse(params.slot);
+def cur = ctx._source.next_available_at;
+if (cur == null || slot.isBefore(ZonedDateTime.parse(cur)) || ZonedDateTime.parse(cur).isBefore(ZonedDateTime.parse(params.now))) {
+  ctx._source.next_available_at = params.slot;
+} else {
+  ctx.op = 'none';
+}`
+
+// bookSlotScript clears next_available_at when that very slot is booked. The
+// next free slot is unknown until another booking is cancelled.
+const bookSlotScript = `
+def cur = ctx._source.next_available_at;
+if (cur != null && ZonedDateTime.parse(cur).isEqual(ZonedDateTime.parse(params.slot))) {
+  ctx._source.remove('next_available_at');
+} else {
+  ctx.op = 'none';
+}`
+
+// BookSlot records that slot was booked.
+func (c *Client) BookSlot(ctx context.Context, tutorID int64, slot time.Time) error {
+	return c.updateAvailability(ctx, tutorID, bookSlotScript, slot)
+}
+
+// FreeSlot records that slot became free.
+func (c *Client) FreeSlot(ctx context.Context, tutorID int64, slot time.Time) error {
+	return c.updateAvailability(ctx, tutorID, freeSlotScript, slot)
+}
+
+func (c *Client) updateAvailability(ctx context.Context, tutorID int64, script string, slot time.Time) error {
+	body, err := json.Marshal(map[string]any{
+		"script": map[string]any{
+			"lang":   "painless",
+			"source": script,
+			"params": map[string]any{
+				"slot": slot.UTC().Format(time.RFC3339),
+				"now":  time.Now().UTC().Format(time.RFC3339),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal availability update: %w", err)
+	}
+
+	retries := updateRetries
+	resp, err := c.client.Update(ctx, opensearchapi.UpdateReq{
+		Index:      c.writeIndex(ctx),
+		DocumentID: strconv.FormatInt(tutorID, 10),
+		Body:       bytes.NewReader(body),
+		Params: opensearchapi.UpdateParams{
+			Refresh:         string(c.refresh),
+			RetryOnConflict: &retries,
+		},
+	})
+	if err != nil {
+		if isDocumentMissing(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to update tutor availability: %w", err)
+	}
+
+	c.logger.Debug("Tutor availability updated", "id", tutorID, "slot", slot, "result", resp.Result)
+	return nil
+}
+
+// isDocumentMissing reports whether err is OpenSearch's 404 for an update
+// of a document that does not exist.
+func isDocumentMissing(err error) bool {
+	var se *opensearch.StructError
+	return errors.As(err, &se) && se.Status == http.StatusNotFound && se.Err.Type == "document_missing_exception"
+}
+
+// BookSlot clears next_available_at if it is slot.
+func (m *MemoryClient) BookSlot(ctx context.Context, tutorID int64, slot time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tutors := m.tutors(ctx)
+	t, ok := tutors[tutorID]
+	if !ok {
+		return ErrNotFound
+	}
+	if t.NextAvailableAt != nil && t.NextAvailableAt.Equal(slot) {
+		t.NextAvailableAt = nil
+		tutors[tutorID] = t
+	}
+	return nil
+}
+
+// FreeSlot moves next_available_at to slot if that is earlier or the current
+// value has passed.
+func (m *MemoryClient) FreeSlot(ctx context.Context, tutorID int64, slot time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tutors := m.tutors(ctx)
+	t, ok := tutors[tutorID]
+	if !ok {
+		return ErrNotFound
+	}
+	if cur := t.NextAvailableAt; cur == nil || slot.Before(*cur) || cur.Before(time.Now()) {
+		at := slot.UTC()
+		t.NextAvailableAt = &at
+		tutors[tutorID] = t
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"search/internal/domain"
+)
+
+func TestUpdateAvailability(t *testing.T) {
+	slot := time.Date(2030, 5, 1, 14, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	tests := []struct {
+		name       string
+		book       bool
+		wantScript string
+	}{
+		{"book", true, "remove('next_available_at')"},
+		{"free", false, "ctx._source.next_available_at = params.slot"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body struct {
+				Script struct {
+					Source string            `json:"source"`
+					Params map[string]string `json:"params"`
+				} `json:"script"`
+			}
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/tutors/_update/7" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				json.NewDecoder(r.Body).Decode(&body)
+				writeJSON(w, http.StatusOK, `{"_index":"tutors","_id":"7","result":"updated"}`)
+			})
+
+			var err error
+			if tt.book {
+				err = client.BookSlot(context.Background(), 7, slot)
+			} else {
+				err = client.FreeSlot(context.Background(), 7, slot)
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !strings.Contains(body.Script.Source, tt.wantScript) {
+				t.Errorf("expected script containing %q, got %s", tt.wantScript, body.Script.Source)
+			}
+			if body.Script.Params["slot"] != "2030-05-01T12:00:00Z" {
+				t.Errorf("expected slot in UTC, got %q", body.Script.Params["slot"])
+			}
+		})
+	}
+}
+
+func TestUpdateAvailability_DocumentMissing(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, `{"error":{"type":"document_missing_exception","reason":"[7]: document missing"},"status":404}`)
+	})
+
+	if err := client.FreeSlot(context.Background(), 7, time.Now()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestUpsertTutor_PreservesOtherFields(t *testing.T) {
+	var body map[string]any
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/tutors/_update/7" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusOK, `{"_index":"tutors","_id":"7","result":"created"}`)
+	})
+
+	if err := client.UpsertTutor(context.Background(), &domain.Tutor{ID: 7, FullName: "Ada"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if body["doc_as_upsert"] != true {
+		t.Errorf("expected doc_as_upsert, got %v", body)
+	}
+	doc, _ := body["doc"].(map[string]any)
+	if _, ok := doc["next_available_at"]; ok {
+		t.Errorf("expected next_available_at to be left out of the partial document, got %v", doc)
+	}
+}
+
+func TestMemoryClient_Availability(t *testing.T) {
+	ctx := context.Background()
+	m := newFixtureMemoryClient(t)
+	soon := time.Now().Add(36 * time.Hour).UTC().Truncate(time.Second)
+	later := soon.Add(5 * 24 * time.Hour)
+
+	next := func() *time.Time {
+		tutor, _ := m.GetTutor(ctx, 1)
+		return tutor.NextAvailableAt
+	}
+
+	m.FreeSlot(ctx, 1, later)
+	if got := next(); got == nil || !got.Equal(later) {
+		t.Fatalf("expected next available %v, got %v", later, got)
+	}
+	m.FreeSlot(ctx, 1, soon.Add(10*24*time.Hour))
+	m.FreeSlot(ctx, 1, soon)
+	if got := next(); got == nil || !got.Equal(soon) {
+		t.Errorf("expected the earlier slot %v, got %v", soon, got)
+	}
+
+	// A profile update does not wipe availability.
+	m.UpsertTutor(ctx, &domain.Tutor{ID: 1, FullName: "Marie Curie"})
+	if next() == nil {
+		t.Error("expected availability to survive an upsert")
+	}
+
+	m.BookSlot(ctx, 1, later)
+	if got := next(); got == nil || !got.Equal(soon) {
+		t.Errorf("expected booking another slot to keep %v, got %v", soon, got)
+	}
+
+	tests := []struct {
+		days int
+		want int
+	}{
+		{0, 4},
+		{2, 1},
+		{1, 0},
+	}
+	for _, tt := range tests {
+		resp, _ := m.SearchTutors(ctx, SearchQuery{AvailableWithinDays: tt.days})
+		if resp.Total != tt.want {
+			t.Errorf("available_within_days=%d: expected %d tutors, got %d", tt.days, tt.want, resp.Total)
+		}
+	}
+
+	m.BookSlot(ctx, 1, soon)
+	if got := next(); got != nil {
+		t.Errorf("expected booking the next slot to clear it, got %v", got)
+	}
+
+	if err := m.BookSlot(ctx, 99, soon); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown tutor, got %v", err)
+	}
+}
+
+func TestBuildSearchQuery_AvailableWithinDays(t *testing.T) {
+	q := buildSearchQuery(SearchQuery{AvailableWithinDays: 7}, nil)
+
+	filter := q["query"].(map[string]any)["bool"].(map[string]any)["filter"].([]map[string]any)
+	if len(filter) != 1 {
+		t.Fatalf("expected one filter, got %v", filter)
+	}
+	rng := filter[0]["range"].(map[string]any)["next_available_at"].(map[string]any)
+	if rng["gte"] != "now" || rng["lte"] != "now+7d" {
+		t.Errorf("expected now..now+7d, got %v", rng)
+	}
+}
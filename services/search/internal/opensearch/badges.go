@@ -0,0 +1,98 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	"search/internal/domain"
+)
+
+// updateBadgesScript mirrors domain.ApplyBadges.
+const updateBadgesScript = `
+def badges = new ArrayList();
+def seen = new HashSet();
+def current = ctx._source.badges == null ? [] : ctx._source.badges;
+for (def list : [current, params.add]) {
+  for (def b : list) {
+    def key = b.toLowerCase();
+    if (!params.remove.contains(key) && seen.add(key)) {
+      badges.add(b);
+    }
+  }
+}
+if (badges.equals(current)) {
+  ctx.op = 'none';
+} else {
+  ctx._source.badges = badges;
+}`
+
+// UpdateBadges adds and removes badges on a tutor with a scripted partial
+// update, so concurrent profile writes keep their other fields.
+func (c *Client) UpdateBadges(ctx context.Context, tutorID int64, add, remove []string) error {
+	lowered := make([]string, len(remove))
+	for i, b := range remove {
+		lowered[i] = strings.ToLower(b)
+	}
+	body, err := json.Marshal(map[string]any{
+		"script": map[string]any{
+			"lang":   "painless",
+			"source": updateBadgesScript,
+			"params": map[string]any{
+				"add":    nonNil(add),
+				"remove": lowered,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal badge update: %w", err)
+	}
+
+	retries := updateRetries
+	resp, err := c.client.Update(ctx, opensearchapi.UpdateReq{
+		Index:      c.writeIndex(ctx),
+		DocumentID: strconv.FormatInt(tutorID, 10),
+		Body:       bytes.NewReader(body),
+		Params: opensearchapi.UpdateParams{
+			Refresh:         string(c.refresh),
+			RetryOnConflict: &retries,
+		},
+	})
+	if err != nil {
+		if isDocumentMissing(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to update tutor badges: %w", err)
+	}
+
+	c.logger.Debug("Tutor badges updated", "id", tutorID, "add", add, "remove", remove, "result", resp.Result)
+	return nil
+}
+
+// nonNil returns s, or an empty slice for nil so it marshals as [].
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+// UpdateBadges applies domain.ApplyBadges to the stored tutor.
+func (m *MemoryClient) UpdateBadges(ctx context.Context, tutorID int64, add, remove []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tutors := m.tutors(ctx)
+	t, ok := tutors[tutorID]
+	if !ok {
+		return ErrNotFound
+	}
+	t.Badges = domain.ApplyBadges(t.Badges, add, remove)
+	tutors[tutorID] = t
+	return nil
+}
@@ -0,0 +1,161 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	"search/internal/domain"
+)
+
+func TestUpdateBadges(t *testing.T) {
+	var body struct {
+		Script struct {
+			Source string              `json:"source"`
+			Params map[string][]string `json:"params"`
+		} `json:"script"`
+	}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/tutors/_update/7" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusOK, `{"_index":"tutors","_id":"7","result":"updated"}`)
+	})
+
+	if err := client.UpdateBadges(context.Background(), 7, nil, []string{"New"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body.Script.Source != updateBadgesScript {
+		t.Errorf("expected the badge script, got %s", body.Script.Source)
+	}
+	if add := body.Script.Params["add"]; add == nil || len(add) != 0 {
+		t.Errorf("expected add to be sent as [], got %v", add)
+	}
+	if remove := body.Script.Params["remove"]; !slices.Equal(remove, []string{"new"}) {
+		t.Errorf("expected remove lowercased, got %v", remove)
+	}
+}
+
+func TestUpdateBadges_DocumentMissing(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, `{"error":{"type":"document_missing_exception","reason":"[7]: document missing"},"status":404}`)
+	})
+
+	if err := client.UpdateBadges(context.Background(), 7, []string{"featured"}, nil); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestUpsertTutor_OmitsEmptyBadges(t *testing.T) {
+	var body map[string]any
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusOK, `{"_index":"tutors","_id":"7","result":"updated"}`)
+	})
+
+	if err := client.UpsertTutor(context.Background(), &domain.Tutor{ID: 7}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if doc, _ := body["doc"].(map[string]any); doc["badges"] != nil {
+		t.Errorf("expected badges to be left out of the partial document, got %v", doc)
+	}
+}
+
+func TestMemoryClient_Badges(t *testing.T) {
+	m := NewMemoryClient()
+	ctx := context.Background()
+	if err := m.UpsertTutor(ctx, &domain.Tutor{ID: 1, FullName: "Ada"}); err != nil {
+		t.Fatalf("failed to upsert: %v", err)
+	}
+
+	if err := m.UpdateBadges(ctx, 2, []string{"featured"}, nil); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown tutor, got %v", err)
+	}
+	if err := m.UpdateBadges(ctx, 1, []string{"featured", "new"}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := m.UpsertTutor(ctx, &domain.Tutor{ID: 1, FullName: "Ada King"}); err != nil {
+		t.Fatalf("failed to upsert: %v", err)
+	}
+
+	resp, _ := m.SearchTutors(ctx, SearchQuery{Badge: "FEATURED"})
+	if resp.Total != 1 || !slices.Equal(resp.Results[0].Badges, []string{"featured", "new"}) {
+		t.Errorf("expected the tutor to keep its badges and match the filter, got %+v", resp.Results)
+	}
+
+	if err := m.UpdateBadges(ctx, 1, nil, []string{"featured"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp, _ := m.SearchTutors(ctx, SearchQuery{Badge: "featured"}); resp.Total != 0 {
+		t.Errorf("expected no match after removing the badge, got %+v", resp.Results)
+	}
+}
+
+func TestBuildSearchQuery_BadgeFilter(t *testing.T) {
+	q := buildSearchQuery(SearchQuery{Badge: "featured"}, nil)
+
+	filter := q["query"].(map[string]any)["bool"].(map[string]any)["filter"].([]map[string]any)
+	if len(filter) != 1 || filter[0]["term"].(map[string]any)["badges"] != "featured" {
+		t.Errorf("expected a badges term filter, got %v", filter)
+	}
+}
+
+func TestBuildSearchQuery_PinnedBadge(t *testing.T) {
+	registry := RelevanceRegistry{}.WithPinnedBadge(domain.BadgeFeatured)
+
+	q := buildSearchQuery(SearchQuery{Text: "math"}, registry)
+	boolQuery := q["query"].(map[string]any)["bool"].(map[string]any)
+	should, ok := boolQuery["should"].([]map[string]any)
+	if !ok || len(should) != 1 {
+		t.Fatalf("expected one should clause, got %v", boolQuery["should"])
+	}
+	pin := should[0]["constant_score"].(map[string]any)
+	if pin["boost"] != pinnedBadgeBoost || pin["filter"].(map[string]any)["term"].(map[string]any)["badges"] != "featured" {
+		t.Errorf("expected a constant_score boost on badges:featured, got %v", pin)
+	}
+	if boolQuery["minimum_should_match"] != 0 {
+		t.Errorf("expected the pin to be optional, got minimum_should_match %v", boolQuery["minimum_should_match"])
+	}
+
+	// Without other clauses the pin still must not filter.
+	matchAll := buildSearchQuery(SearchQuery{}, registry)["query"].(map[string]any)["bool"].(map[string]any)
+	if matchAll["minimum_should_match"] != 0 {
+		t.Errorf("expected an optional pin on a match-all search, got %v", matchAll)
+	}
+
+	for name, q := range map[string]map[string]any{
+		"no pinned badge": buildSearchQuery(SearchQuery{Text: "math"}, nil),
+		"rating sort":     buildSearchQuery(SearchQuery{Text: "math", Sort: SortRating}, registry),
+	} {
+		if _, ok := q["query"].(map[string]any)["bool"].(map[string]any)["should"]; ok {
+			t.Errorf("%s: expected no pin clause", name)
+		}
+	}
+}
+
+func TestRelevanceRegistry_WithPinnedBadge(t *testing.T) {
+	own := DefaultRelevance
+	own.PinnedBadge = "staff-pick"
+	registry := RelevanceRegistry{"control": DefaultRelevance, "own": own}
+
+	pinned := registry.WithPinnedBadge("featured")
+
+	for variant, want := range map[string]string{"control": "featured", "own": "staff-pick", "": "featured", "retired": "featured"} {
+		if got := pinned.For(variant).PinnedBadge; got != want {
+			t.Errorf("variant %q: expected pinned badge %q, got %q", variant, want, got)
+		}
+	}
+	if registry["control"].PinnedBadge != "" {
+		t.Error("expected the original registry to be left alone")
+	}
+
+	invalid := DefaultRelevance
+	invalid.PinnedBadge = "Top Pick"
+	if err := invalid.Validate(); err == nil {
+		t.Error("expected an invalid pinned badge to be rejected")
+	}
+}
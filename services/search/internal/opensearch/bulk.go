@@ -0,0 +1,273 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	"search/internal/domain"
+)
+
+// bulkBatchSize is how many actions are sent per _bulk request.
+const bulkBatchSize = 500
+
+// BulkDeleteTutors deletes the given tutors with the _bulk API, batching
+// bulkBatchSize IDs per request, and refreshes the index once at the end.
+// Results are in the order of ids. A failed batch marks its IDs as errors and
+// the remaining batches still run; only a cancelled context stops early.
+func (c *Client) BulkDeleteTutors(ctx context.Context, ids []int64) ([]BulkDeleteResult, error) {
+	results := make([]BulkDeleteResult, 0, len(ids))
+
+	for start := 0; start < len(ids); start += bulkBatchSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		batch := ids[start:min(start+bulkBatchSize, len(ids))]
+		results = append(results, c.bulkDelete(ctx, batch)...)
+	}
+
+	if len(ids) > 0 && c.refresh != RefreshFalse {
+		if _, err := c.client.Indices.Refresh(ctx, &opensearchapi.IndicesRefreshReq{
+			Indices: []string{c.writeIndex(ctx)},
+		}); err != nil {
+			// The deletes are durable; they only become searchable a little later.
+			c.logger.Warn("Failed to refresh index after bulk delete", "error", err)
+		}
+	}
+
+	return results, nil
+}
+
+// bulkDelete deletes one batch. With an indexing rate set, the batch is
+// paced by it and the items OpenSearch rejects with 429 are sent again in a
+// smaller request, up to maxThrottleRetries times.
+func (c *Client) bulkDelete(ctx context.Context, ids []int64) []BulkDeleteResult {
+	results := make([]BulkDeleteResult, len(ids))
+	for i, id := range ids {
+		results[i] = BulkDeleteResult{ID: id, Status: BulkError}
+	}
+
+	// pending holds the indexes into results still to be sent.
+	pending := make([]int, len(ids))
+	for i := range pending {
+		pending[i] = i
+	}
+	for attempt := 0; len(pending) > 0; attempt++ {
+		if c.indexRate != nil {
+			if err := c.indexRate.Wait(ctx, len(pending)); err != nil {
+				for _, i := range pending {
+					results[i].Error = err.Error()
+				}
+				return results
+			}
+		}
+		accepted, rejected := c.sendBulkDelete(ctx, results, pending)
+		if c.indexRate == nil {
+			break
+		}
+		c.indexRate.Succeeded(accepted)
+		if len(rejected) == 0 {
+			break
+		}
+		c.indexRate.Throttled()
+		if attempt == maxThrottleRetries {
+			break
+		}
+		c.logger.Debug("Retrying bulk deletes rejected by OpenSearch", "ids", len(rejected), "attempt", attempt+1, "rate", c.indexRate.Rate())
+		pending = rejected
+	}
+	return results
+}
+
+// sendBulkDelete deletes the IDs of results at pending in one _bulk request,
+// recording each outcome. It returns how many deletes OpenSearch accepted
+// and the indexes it rejected with 429.
+func (c *Client) sendBulkDelete(ctx context.Context, results []BulkDeleteResult, pending []int) (int, []int) {
+	var body bytes.Buffer
+	for _, i := range pending {
+		fmt.Fprintf(&body, `{"delete":{"_id":%q}}`+"\n", strconv.FormatInt(results[i].ID, 10))
+	}
+
+	resp, err := c.client.Bulk(ctx, opensearchapi.BulkReq{
+		Index: c.writeIndex(ctx),
+		Body:  &body,
+	})
+	if err != nil {
+		c.logger.Error("Bulk delete request failed", "ids", len(pending), "error", err)
+		for _, i := range pending {
+			results[i].Error = err.Error()
+		}
+		if isTooManyRequests(err) {
+			return 0, pending
+		}
+		return 0, nil
+	}
+
+	// Items come back in request order, one per action.
+	accepted := 0
+	var rejected []int
+	for n, i := range pending {
+		if n >= len(resp.Items) {
+			results[i].Error = "missing from bulk response"
+			continue
+		}
+		item := resp.Items[n]["delete"]
+		switch {
+		case item.Error != nil:
+			results[i].Error = item.Error.Type + ": " + item.Error.Reason
+			if item.Status == http.StatusTooManyRequests {
+				rejected = append(rejected, i)
+			}
+		case item.Status == http.StatusNotFound || item.Result == "not_found":
+			results[i].Status = BulkNotFound
+			results[i].Error = ""
+			accepted++
+		default:
+			results[i].Status = BulkDeleted
+			results[i].Error = ""
+			accepted++
+		}
+	}
+	return accepted, rejected
+}
+
+// BulkUpsertTutors writes tutors as UpsertTutor does, as partial updates
+// with doc_as_upsert, batching bulkBatchSize tutors per _bulk request.
+// Items come back in the order of tutors. A tutor OpenSearch refuses with a
+// 400 fails with ErrDocumentRejected; with an indexing rate set, those it
+// rejects with 429 are sent again as for bulkDelete. A request failing as a
+// whole stops the rest and is returned as the error. Written tutors are
+// mirrored to a running experiment index one at a time.
+func (c *Client) BulkUpsertTutors(ctx context.Context, tutors []*domain.Tutor) (*BulkResult, error) {
+	result := &BulkResult{Items: make([]BulkItem, len(tutors))}
+	bodies := make([][]byte, len(tutors))
+	for i, tutor := range tutors {
+		body, err := json.Marshal(map[string]any{
+			"doc":           c.newTutorDocument(tutor, nil),
+			"doc_as_upsert": true,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal tutor %d: %w", tutor.ID, err)
+		}
+		result.Items[i].ID = tutor.ID
+		bodies[i] = body
+	}
+
+	for start := 0; start < len(tutors); start += bulkBatchSize {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		end := min(start+bulkBatchSize, len(tutors))
+		if err := c.bulkUpsert(ctx, result.Items[start:end], bodies[start:end]); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, item := range result.Items {
+		if item.Err != nil {
+			continue
+		}
+		c.mirror(ctx, item.ID, func(index string) error {
+			_, err := c.updateTutor(ctx, index, item.ID, bodies[i])
+			return err
+		})
+	}
+	return result, nil
+}
+
+// bulkUpsert writes one batch, pacing it by the indexing rate when set and
+// retrying the items OpenSearch rejects with 429 like bulkDelete.
+func (c *Client) bulkUpsert(ctx context.Context, items []BulkItem, bodies [][]byte) error {
+	// pending holds the indexes into items still to be sent.
+	pending := make([]int, len(items))
+	for i := range pending {
+		pending[i] = i
+	}
+	for attempt := 0; ; attempt++ {
+		if c.indexRate != nil {
+			if err := c.indexRate.Wait(ctx, len(pending)); err != nil {
+				return err
+			}
+		}
+		accepted, rejected, err := c.sendBulkUpsert(ctx, items, bodies, pending)
+		if c.indexRate == nil {
+			return err
+		}
+		c.indexRate.Succeeded(accepted)
+		if len(rejected) == 0 {
+			return err
+		}
+		c.indexRate.Throttled()
+		if attempt == maxThrottleRetries {
+			return err
+		}
+		c.logger.Debug("Retrying bulk upserts rejected by OpenSearch", "ids", len(rejected), "attempt", attempt+1, "rate", c.indexRate.Rate())
+		pending = rejected
+	}
+}
+
+// sendBulkUpsert writes the tutors of items at pending in one _bulk
+// request, recording each outcome. It returns how many writes OpenSearch
+// accepted and the indexes it rejected with 429, all of them when it
+// rejected the whole request, along with the error of a failed request.
+func (c *Client) sendBulkUpsert(ctx context.Context, items []BulkItem, bodies [][]byte, pending []int) (int, []int, error) {
+	var body bytes.Buffer
+	for _, i := range pending {
+		fmt.Fprintf(&body, `{"update":{"_id":%q,"retry_on_conflict":%d}}`+"\n", strconv.FormatInt(items[i].ID, 10), updateRetries)
+		body.Write(bodies[i])
+		body.WriteByte('\n')
+	}
+
+	index := c.writeIndex(ctx)
+	params := opensearchapi.BulkParams{Refresh: string(c.refresh)}
+	if index != c.index(ctx) {
+		// As for updateTutor, never create an index behind a missing alias.
+		requireAlias := true
+		params.RequireAlias = &requireAlias
+	}
+	resp, err := c.client.Bulk(ctx, opensearchapi.BulkReq{
+		Index:  index,
+		Body:   &body,
+		Params: params,
+	})
+	if err != nil {
+		err = fmt.Errorf("bulk upsert failed: %w", err)
+		for _, i := range pending {
+			items[i].Err = err
+		}
+		if isTooManyRequests(err) {
+			return 0, pending, err
+		}
+		return 0, nil, err
+	}
+
+	// Items come back in request order, one per action.
+	accepted := 0
+	var rejected []int
+	for n, i := range pending {
+		if n >= len(resp.Items) {
+			items[i].Err = errors.New("missing from bulk response")
+			continue
+		}
+		item := resp.Items[n]["update"]
+		switch {
+		case item.Error == nil:
+			items[i].Err = nil
+			accepted++
+		case item.Status == http.StatusBadRequest:
+			items[i].Err = fmt.Errorf("%w: %s: %s", ErrDocumentRejected, item.Error.Type, item.Error.Reason)
+		default:
+			items[i].Err = fmt.Errorf("failed to index tutor: %s: %s", item.Error.Type, item.Error.Reason)
+			if item.Status == http.StatusTooManyRequests {
+				rejected = append(rejected, i)
+			}
+		}
+	}
+	return accepted, rejected, nil
+}
@@ -0,0 +1,247 @@
+package opensearch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"search/internal/domain"
+)
+
+// bulkServer answers _bulk delete requests, reporting the IDs in missing as
+// not found and those in failing as rejected.
+type bulkServer struct {
+	t         *testing.T
+	missing   map[string]bool
+	failing   map[string]bool
+	failBatch int
+	batches   [][]string
+	refreshes int
+}
+
+func (s *bulkServer) handle(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/tutors/_bulk":
+		var ids []string
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			var action struct {
+				Delete struct {
+					ID string `json:"_id"`
+				} `json:"delete"`
+			}
+			if err := json.Unmarshal(scanner.Bytes(), &action); err != nil {
+				s.t.Errorf("invalid bulk line %q: %v", scanner.Text(), err)
+			}
+			ids = append(ids, action.Delete.ID)
+		}
+		s.batches = append(s.batches, ids)
+		if s.failBatch == len(s.batches) {
+			writeJSON(w, http.StatusTooManyRequests, `{"error":{"type":"es_rejected_execution_exception"},"status":429}`)
+			return
+		}
+
+		items := make([]string, len(ids))
+		for i, id := range ids {
+			switch {
+			case s.missing[id]:
+				items[i] = fmt.Sprintf(`{"delete":{"_id":%q,"result":"not_found","status":404}}`, id)
+			case s.failing[id]:
+				items[i] = fmt.Sprintf(`{"delete":{"_id":%q,"status":503,"error":{"type":"unavailable_shards_exception","reason":"primary shard is not active"}}}`, id)
+			default:
+				items[i] = fmt.Sprintf(`{"delete":{"_id":%q,"result":"deleted","status":200}}`, id)
+			}
+		}
+		writeJSON(w, http.StatusOK, `{"took":1,"errors":true,"items":[`+strings.Join(items, ",")+`]}`)
+	case r.Method == http.MethodPost && r.URL.Path == "/tutors/_refresh":
+		s.refreshes++
+		writeJSON(w, http.StatusOK, `{"_shards":{"total":1,"successful":1,"failed":0}}`)
+	default:
+		s.t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		writeJSON(w, http.StatusNotFound, `{}`)
+	}
+}
+
+func TestBulkDeleteTutors_Outcomes(t *testing.T) {
+	server := &bulkServer{t: t, missing: map[string]bool{"2": true}, failing: map[string]bool{"3": true}}
+	client := newTestClient(t, server.handle)
+
+	results, err := client.BulkDeleteTutors(context.Background(), []int64{1, 2, 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []BulkDeleteResult{
+		{ID: 1, Status: BulkDeleted},
+		{ID: 2, Status: BulkNotFound},
+		{ID: 3, Status: BulkError, Error: "unavailable_shards_exception: primary shard is not active"},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("expected %v, got %v", want, results)
+	}
+	for i := range want {
+		if results[i] != want[i] {
+			t.Errorf("result %d: expected %+v, got %+v", i, want[i], results[i])
+		}
+	}
+	if server.refreshes != 1 {
+		t.Errorf("expected one refresh, got %d", server.refreshes)
+	}
+}
+
+func TestBulkDeleteTutors_Batches(t *testing.T) {
+	server := &bulkServer{t: t, failBatch: 2}
+	client := newTestClient(t, server.handle)
+
+	ids := make([]int64, 2*bulkBatchSize+10)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+
+	results, err := client.BulkDeleteTutors(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(server.batches) != 3 {
+		t.Fatalf("expected 3 batches, got %d", len(server.batches))
+	}
+	for i, size := range []int{bulkBatchSize, bulkBatchSize, 10} {
+		if len(server.batches[i]) != size {
+			t.Errorf("batch %d: expected %d actions, got %d", i, size, len(server.batches[i]))
+		}
+	}
+	if server.refreshes != 1 {
+		t.Errorf("expected a single refresh after all batches, got %d", server.refreshes)
+	}
+
+	if len(results) != len(ids) {
+		t.Fatalf("expected %d results, got %d", len(ids), len(results))
+	}
+	for i, result := range results {
+		if result.ID != ids[i] {
+			t.Fatalf("result %d: expected ID %d, got %d", i, ids[i], result.ID)
+		}
+		wantStatus := BulkDeleted
+		if i >= bulkBatchSize && i < 2*bulkBatchSize {
+			wantStatus = BulkError
+		}
+		if result.Status != wantStatus {
+			t.Fatalf("result %d: expected %s, got %s", i, wantStatus, result.Status)
+		}
+	}
+}
+
+func TestBulkDeleteTutors_Empty(t *testing.T) {
+	server := &bulkServer{t: t}
+	client := newTestClient(t, server.handle)
+
+	results, err := client.BulkDeleteTutors(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 0 || len(server.batches) != 0 || server.refreshes != 0 {
+		t.Errorf("expected no requests, got %d batches and %d refreshes", len(server.batches), server.refreshes)
+	}
+}
+
+func TestBulkUpsertTutors_PartialFailure(t *testing.T) {
+	var actions []map[string]map[string]any
+	var docs []map[string]any
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/tutors/_bulk" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		scanner := bufio.NewScanner(r.Body)
+		for line := 0; scanner.Scan(); line++ {
+			if line%2 == 0 {
+				var action map[string]map[string]any
+				json.Unmarshal(scanner.Bytes(), &action)
+				actions = append(actions, action)
+			} else {
+				var doc map[string]any
+				json.Unmarshal(scanner.Bytes(), &doc)
+				docs = append(docs, doc)
+			}
+		}
+		items := make([]string, len(actions))
+		for i, action := range actions {
+			id := action["update"]["_id"]
+			switch id {
+			case "2", "5":
+				items[i] = fmt.Sprintf(`{"update":{"_id":%q,"status":400,"error":{"type":"mapper_parsing_exception","reason":"failed to parse field [rating]"}}}`, id)
+			case "3":
+				items[i] = fmt.Sprintf(`{"update":{"_id":%q,"status":503,"error":{"type":"unavailable_shards_exception","reason":"primary shard is not active"}}}`, id)
+			default:
+				items[i] = fmt.Sprintf(`{"update":{"_id":%q,"result":"created","status":201}}`, id)
+			}
+		}
+		writeJSON(w, http.StatusOK, `{"took":3,"errors":true,"items":[`+strings.Join(items, ",")+`]}`)
+	})
+
+	var tutors []*domain.Tutor
+	for id := int64(1); id <= 6; id++ {
+		tutors = append(tutors, &domain.Tutor{ID: id, FullName: "Tutor"})
+	}
+	result, err := client.BulkUpsertTutors(context.Background(), tutors)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(actions) != 6 || len(docs) != 6 {
+		t.Fatalf("expected 6 actions with a document each, got %d and %d", len(actions), len(docs))
+	}
+	if actions[0]["update"]["_id"] != "1" || actions[0]["update"]["retry_on_conflict"] != float64(updateRetries) {
+		t.Errorf("expected an update action with retries, got %v", actions[0])
+	}
+	if docs[0]["doc_as_upsert"] != true || docs[0]["doc"].(map[string]any)["full_name"] != "Tutor" {
+		t.Errorf("expected the tutor as an upsert document, got %v", docs[0])
+	}
+
+	if len(result.Items) != 6 {
+		t.Fatalf("expected 6 items, got %+v", result.Items)
+	}
+	for i, item := range result.Items {
+		if item.ID != int64(i+1) {
+			t.Errorf("item %d: expected ID %d, got %d", i, i+1, item.ID)
+		}
+		rejected := errors.Is(item.Err, ErrDocumentRejected)
+		switch item.ID {
+		case 2, 5:
+			if !rejected || !strings.Contains(item.Err.Error(), "mapper_parsing_exception") {
+				t.Errorf("tutor %d: expected a rejected document, got %v", item.ID, item.Err)
+			}
+		case 3:
+			if item.Err == nil || rejected {
+				t.Errorf("tutor 3: expected a failure that is not a rejection, got %v", item.Err)
+			}
+		default:
+			if item.Err != nil {
+				t.Errorf("tutor %d: expected success, got %v", item.ID, item.Err)
+			}
+		}
+	}
+	failed := result.Failed()
+	if len(failed) != 3 || failed[0].ID != 2 || failed[1].ID != 3 || failed[2].ID != 5 {
+		t.Errorf("expected tutors 2, 3 and 5 failed, got %+v", failed)
+	}
+}
+
+func TestBulkUpsertTutors_RequestFailure(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusInternalServerError, `{"error":{"type":"exception","reason":"boom"},"status":500}`)
+	})
+
+	result, err := client.BulkUpsertTutors(context.Background(), []*domain.Tutor{{ID: 1}, {ID: 2}})
+	if err == nil || result != nil {
+		t.Errorf("expected the request to fail as a whole, got %+v and %v", result, err)
+	}
+	if errors.Is(err, ErrDocumentRejected) {
+		t.Errorf("expected a retryable error, got %v", err)
+	}
+}
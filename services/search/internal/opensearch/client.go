@@ -2,38 +2,285 @@ package opensearch
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/opensearch-project/opensearch-go/v4"
 	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	"search/internal/domain"
+	"search/internal/limiter"
+	"search/internal/port"
+	"search/internal/tenant"
 )
 
 type Client struct {
-	client *opensearchapi.Client
-	logger *slog.Logger
+	client    *opensearchapi.Client
+	logger    *slog.Logger
+	relevance RelevanceSource
+	settings  IndexSettings
+	// indexName is used when the context carries no tenant.
+	indexName string
+	refresh   RefreshPolicy
+	// pingMode and pingTimeout shape the reachability check in Ping.
+	pingMode    PingMode
+	pingTimeout time.Duration
+	// lockIndexReady is set once the lock control index is known to exist.
+	lockIndexReady atomic.Bool
+	// popularity scores documents as they are written.
+	popularity domain.PopularityWeights
+	// indexRate paces tutor writes; nil leaves them unpaced.
+	indexRate *limiter.Adaptive
+	// experiments caches, per live index, whether an index experiment is
+	// running on it. It is nil unless WithIndexExperiments is given.
+	experimentsMu sync.Mutex
+	experiments   map[string]experimentState
+	// aliases sends searches to the read alias and writes to the write
+	// alias. It is set by WithIndexAliases.
+	aliases bool
+	// conns counts the connections requests went out on.
+	conns *connCounter
+	// config is assembled by the options before the client is built.
+	config opensearch.Config
+}
+
+// RefreshPolicy controls when single-document writes become searchable.
+type RefreshPolicy string
+
+const (
+	// RefreshTrue refreshes the shard right after each write.
+	RefreshTrue RefreshPolicy = "true"
+	// RefreshWaitFor holds each write until the next scheduled refresh.
+	RefreshWaitFor RefreshPolicy = "wait_for"
+	// RefreshFalse leaves writes to the index's refresh interval.
+	RefreshFalse RefreshPolicy = "false"
+)
+
+// PingMode selects the request Ping sends.
+type PingMode string
+
+const (
+	// PingRoot asks the cluster root for its version info.
+	PingRoot PingMode = "root"
+	// PingIndex sends HEAD to the client's index. A missing index still
+	// counts as reachable, so bootstrap can go on to create it.
+	PingIndex PingMode = "index"
+)
+
+// DefaultPingTimeout bounds Ping when WithPing does not set a timeout.
+const DefaultPingTimeout = 2 * time.Second
+
+// ClientOption configures optional Client behaviour. An option rejects an
+// invalid value by returning an error, which NewClient reports.
+type ClientOption func(*Client) error
+
+// WithRelevance weights searches by their experiment variant's config.
+func WithRelevance(r RelevanceRegistry) ClientOption {
+	return WithRelevanceSource(staticRelevance(r))
+}
+
+// WithRelevanceSource weights searches by the registry src holds at the
+// time of each search, so src can swap it without a restart.
+func WithRelevanceSource(src RelevanceSource) ClientOption {
+	return func(c *Client) error {
+		if src == nil {
+			return errors.New("relevance source is nil")
+		}
+		c.relevance = src
+		return nil
+	}
+}
+
+// WithPopularityWeights scores tutor popularity with w instead of
+// domain.DefaultPopularityWeights.
+func WithPopularityWeights(w domain.PopularityWeights) ClientOption {
+	return func(c *Client) error {
+		if err := w.Validate(); err != nil {
+			return fmt.Errorf("popularity weights: %w", err)
+		}
+		c.popularity = w
+		return nil
+	}
+}
+
+// WithIndexSettings creates indices with s instead of
+// port.DefaultIndexSettings.
+func WithIndexSettings(s IndexSettings) ClientOption {
+	return func(c *Client) error {
+		if err := s.Validate(); err != nil {
+			return fmt.Errorf("index settings: %w", err)
+		}
+		c.settings = s
+		return nil
+	}
+}
+
+// WithIndexName targets name instead of IndexName when the context carries
+// no tenant.
+func WithIndexName(name string) ClientOption {
+	return func(c *Client) error {
+		if !tenant.ValidIndex(name) {
+			return fmt.Errorf("index name %q is not a valid index name", name)
+		}
+		c.indexName = name
+		return nil
+	}
 }
 
-func NewClient(url string, logger *slog.Logger) (*Client, error) {
-	client, err := opensearchapi.NewClient(opensearchapi.Config{
-		Client: opensearch.Config{
+// WithBasicAuth authenticates every request as username.
+func WithBasicAuth(username, password string) ClientOption {
+	return func(c *Client) error {
+		if username == "" {
+			return errors.New("basic auth: username is empty")
+		}
+		c.config.Username = username
+		c.config.Password = password
+		return nil
+	}
+}
+
+// WithRetry retries requests that fail with 502, 503 or 504 up to
+// maxRetries times, waiting backoff times the attempt number in between.
+// Zero maxRetries disables retries; without the option the client library
+// retries three times at once.
+func WithRetry(maxRetries int, backoff time.Duration) ClientOption {
+	return func(c *Client) error {
+		if maxRetries < 0 {
+			return fmt.Errorf("retry: max retries must not be negative, got %d", maxRetries)
+		}
+		if backoff < 0 {
+			return fmt.Errorf("retry: backoff must not be negative, got %s", backoff)
+		}
+		c.config.MaxRetries = maxRetries
+		c.config.DisableRetry = maxRetries == 0
+		c.config.RetryBackoff = nil
+		if backoff > 0 {
+			c.config.RetryBackoff = func(attempt int) time.Duration {
+				return time.Duration(attempt) * backoff
+			}
+		}
+		return nil
+	}
+}
+
+// WithRefreshPolicy sets when writes become searchable instead of
+// RefreshTrue. Bulk deletes refresh the index afterwards unless p is
+// RefreshFalse.
+func WithRefreshPolicy(p RefreshPolicy) ClientOption {
+	return func(c *Client) error {
+		switch p {
+		case RefreshTrue, RefreshWaitFor, RefreshFalse:
+		default:
+			return fmt.Errorf("refresh policy must be one of %s|%s|%s, got %q", RefreshTrue, RefreshWaitFor, RefreshFalse, p)
+		}
+		c.refresh = p
+		return nil
+	}
+}
+
+// WithPing makes Ping send mode's request and give up after timeout,
+// instead of PingRoot and DefaultPingTimeout.
+func WithPing(mode PingMode, timeout time.Duration) ClientOption {
+	return func(c *Client) error {
+		switch mode {
+		case PingRoot, PingIndex:
+		default:
+			return fmt.Errorf("ping mode must be one of %s|%s, got %q", PingRoot, PingIndex, mode)
+		}
+		if timeout <= 0 {
+			return fmt.Errorf("ping timeout must be positive, got %s", timeout)
+		}
+		c.pingMode = mode
+		c.pingTimeout = timeout
+		return nil
+	}
+}
+
+// WithTransport sends requests through rt instead of a transport built
+// from DefaultTransportSettings, for TLS settings or instrumentation.
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(c *Client) error {
+		if rt == nil {
+			return errors.New("transport is nil")
+		}
+		c.config.Transport = rt
+		return nil
+	}
+}
+
+// NewClient returns a Client for the cluster at url. Without options it
+// uses IndexName, RefreshTrue, PingRoot with DefaultPingTimeout,
+// port.DefaultIndexSettings, domain.DefaultPopularityWeights and
+// DefaultTransportSettings.
+func NewClient(url string, logger *slog.Logger, opts ...ClientOption) (*Client, error) {
+	c := &Client{
+		logger:      logger,
+		settings:    port.DefaultIndexSettings,
+		indexName:   IndexName,
+		refresh:     RefreshTrue,
+		pingMode:    PingRoot,
+		pingTimeout: DefaultPingTimeout,
+		popularity:  domain.DefaultPopularityWeights,
+		config: opensearch.Config{
 			Addresses: []string{url},
-			Transport: http.DefaultTransport,
+			Transport: newTransport(DefaultTransportSettings),
 		},
-	})
+	}
+	var errs []error
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("invalid opensearch client options: %w", err)
+	}
+	c.conns = &connCounter{next: c.config.Transport, logger: logger}
+	c.config.Transport = c.conns
+
+	client, err := opensearchapi.NewClient(opensearchapi.Config{Client: c.config})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create opensearch client: %w", err)
 	}
+	c.client = client
+	return c, nil
+}
 
-	return &Client{
-		client: client,
-		logger: logger,
-	}, nil
+// index returns the index operations on ctx target: the tenant's index when
+// one was resolved, the client's index name otherwise.
+func (c *Client) index(ctx context.Context) string {
+	if t, ok := tenant.FromContext(ctx); ok {
+		return t.Index
+	}
+	return c.indexName
 }
 
+// Ping checks that the cluster answers a cheap request: the root info
+// endpoint or a HEAD on the index, per the client's PingMode. It gives up
+// after the ping timeout even when ctx allows longer, so a hung cluster
+// cannot stall a health check or a bootstrap attempt.
 func (c *Client) Ping(ctx context.Context) error {
-	_, err := c.client.Cluster.Health(ctx, nil)
+	ctx, cancel := context.WithTimeout(ctx, c.pingTimeout)
+	defer cancel()
+
+	var err error
+	switch c.pingMode {
+	case PingIndex:
+		var resp *opensearch.Response
+		resp, err = c.client.Indices.Exists(ctx, opensearchapi.IndicesExistsReq{
+			Indices: []string{c.readIndex(ctx)},
+		})
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			err = nil
+		}
+	default:
+		_, err = c.client.Info(ctx, nil)
+	}
 	if err != nil {
 		return fmt.Errorf("opensearch ping failed: %w", err)
 	}
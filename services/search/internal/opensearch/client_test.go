@@ -0,0 +1,305 @@
+package opensearch
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"search/internal/domain"
+	"search/internal/tenant"
+	"search/internal/testutil"
+)
+
+// newTestClient returns a Client talking to an httptest server driven by handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, testutil.NewLogger(t))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+// writeJSON writes a canned OpenSearch response.
+func writeJSON(w http.ResponseWriter, status int, body string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	io.WriteString(w, body)
+}
+
+func TestPing(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		writeJSON(w, http.StatusOK, `{"cluster_name":"test","version":{"number":"2.11.0"}}`)
+	})
+
+	if err := client.Ping(context.Background()); err != nil {
+		t.Errorf("expected ping to succeed, got %v", err)
+	}
+}
+
+func TestPing_IndexMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{"index exists", http.StatusOK, false},
+		{"index missing", http.StatusNotFound, false},
+		{"cluster unavailable", http.StatusServiceUnavailable, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodHead || r.URL.Path != "/tutors" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			t.Cleanup(server.Close)
+
+			client, err := NewClient(server.URL, testutil.NewLogger(t),
+				WithPing(PingIndex, time.Second), WithRetry(0, 0))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			err = client.Ping(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Ping err = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// slowTransport holds every request until its context is done or release
+// is closed, standing in for a cluster that accepts connections but hangs.
+type slowTransport struct {
+	release chan struct{}
+}
+
+func (s *slowTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	select {
+	case <-r.Context().Done():
+		return nil, r.Context().Err()
+	case <-s.release:
+		return nil, errors.New("released")
+	}
+}
+
+func TestPing_Timeout(t *testing.T) {
+	for _, mode := range []PingMode{PingRoot, PingIndex} {
+		t.Run(string(mode), func(t *testing.T) {
+			rt := &slowTransport{release: make(chan struct{})}
+			t.Cleanup(func() { close(rt.release) })
+
+			client, err := NewClient("http://localhost:9200", testutil.NewLogger(t),
+				WithTransport(rt), WithRetry(0, 0), WithPing(mode, 50*time.Millisecond))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			start := time.Now()
+			err = client.Ping(context.Background())
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Errorf("Ping err = %v, want context.DeadlineExceeded", err)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("Ping took %s, want it cut off near the 50ms timeout", elapsed)
+			}
+		})
+	}
+}
+
+func TestPing_CallerDeadlineStillApplies(t *testing.T) {
+	rt := &slowTransport{release: make(chan struct{})}
+	t.Cleanup(func() { close(rt.release) })
+
+	client, err := NewClient("http://localhost:9200", testutil.NewLogger(t),
+		WithTransport(rt), WithRetry(0, 0), WithPing(PingRoot, time.Minute))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := client.Ping(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Ping err = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestPing_Error(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusServiceUnavailable, `{"error":"unavailable","status":503}`)
+	})
+
+	if err := client.Ping(context.Background()); err == nil {
+		t.Error("expected ping to fail")
+	}
+}
+
+func TestNewClient_Options(t *testing.T) {
+	var rt countingTransport
+	var gotPath, gotRefresh, gotUser, gotPassword string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotRefresh = r.URL.Query().Get("refresh")
+		gotUser, gotPassword, _ = r.BasicAuth()
+		writeJSON(w, http.StatusOK, `{"_id":"1","result":"created"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, testutil.NewLogger(t),
+		WithIndexName("tutors-v2"),
+		WithBasicAuth("search", "s3cret"),
+		WithRefreshPolicy(RefreshWaitFor),
+		WithTransport(&rt),
+	)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+
+	if err := client.UpsertTutor(context.Background(), &domain.Tutor{ID: 1}); err != nil {
+		t.Fatalf("UpsertTutor: %v", err)
+	}
+	if gotPath != "/tutors-v2/_update/1" {
+		t.Errorf("path = %q, want the configured index", gotPath)
+	}
+	if gotRefresh != "wait_for" {
+		t.Errorf("refresh = %q, want wait_for", gotRefresh)
+	}
+	if gotUser != "search" || gotPassword != "s3cret" {
+		t.Errorf("basic auth = %q:%q, want search:s3cret", gotUser, gotPassword)
+	}
+	if rt.n.Load() != 1 {
+		t.Errorf("transport saw %d requests, want 1", rt.n.Load())
+	}
+
+	ctx := tenant.NewContext(context.Background(), tenant.Tenant{Name: "de", Index: "tutors-de"})
+	if err := client.UpsertTutor(ctx, &domain.Tutor{ID: 1}); err != nil {
+		t.Fatalf("UpsertTutor: %v", err)
+	}
+	if gotPath != "/tutors-de/_update/1" {
+		t.Errorf("path = %q, want the tenant's index", gotPath)
+	}
+}
+
+func TestNewClient_Defaults(t *testing.T) {
+	var gotPath, gotRefresh string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotRefresh = r.URL.Query().Get("refresh")
+		writeJSON(w, http.StatusOK, `{"_id":"1","result":"created"}`)
+	})
+
+	if err := client.UpsertTutor(context.Background(), &domain.Tutor{ID: 1}); err != nil {
+		t.Fatalf("UpsertTutor: %v", err)
+	}
+	if gotPath != "/tutors/_update/1" || gotRefresh != "true" {
+		t.Errorf("got %s refresh=%s, want /tutors/_update/1 refresh=true", gotPath, gotRefresh)
+	}
+}
+
+func TestNewClient_InvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  ClientOption
+		want string
+	}{
+		{"index name", WithIndexName("Tutors"), `index name "Tutors"`},
+		{"empty index name", WithIndexName(""), `index name ""`},
+		{"basic auth", WithBasicAuth("", "pw"), "username is empty"},
+		{"negative retries", WithRetry(-1, 0), "max retries must not be negative"},
+		{"negative backoff", WithRetry(1, -time.Second), "backoff must not be negative"},
+		{"refresh policy", WithRefreshPolicy("always"), `got "always"`},
+		{"transport", WithTransport(nil), "transport is nil"},
+		{"ping mode", WithPing("health", time.Second), `got "health"`},
+		{"ping timeout", WithPing(PingRoot, 0), "ping timeout must be positive"},
+		{"index settings", WithIndexSettings(IndexSettings{Shards: 0}), "index settings"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient("http://localhost:9200", slog.Default(), tt.opt)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewClient_ReportsEveryInvalidOption(t *testing.T) {
+	_, err := NewClient("http://localhost:9200", slog.Default(),
+		WithIndexName("Tutors"),
+		WithRefreshPolicy("always"),
+	)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{"index name", "refresh policy"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("err = %v, want it to mention %q", err, want)
+		}
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	tests := []struct {
+		name       string
+		maxRetries int
+		wantErr    bool
+		wantCalls  int32
+	}{
+		{"retries until success", 2, false, 3},
+		{"disabled", 0, true, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) <= 2 {
+					writeJSON(w, http.StatusServiceUnavailable, `{"error":"unavailable","status":503}`)
+					return
+				}
+				writeJSON(w, http.StatusOK, `{"status":"green"}`)
+			}))
+			t.Cleanup(server.Close)
+
+			client, err := NewClient(server.URL, testutil.NewLogger(t),
+				WithRetry(tt.maxRetries, time.Millisecond))
+			if err != nil {
+				t.Fatalf("failed to create client: %v", err)
+			}
+
+			err = client.Ping(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Ping err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if calls.Load() != tt.wantCalls {
+				t.Errorf("server saw %d requests, want %d", calls.Load(), tt.wantCalls)
+			}
+		})
+	}
+}
+
+// countingTransport counts requests passed to http.DefaultTransport.
+type countingTransport struct {
+	n atomic.Int32
+}
+
+func (c *countingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	c.n.Add(1)
+	return http.DefaultTransport.RoundTrip(r)
+}
@@ -0,0 +1,253 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v4"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// experimentRecheck is how long a replica trusts what it last saw of an
+// index experiment. Another replica starting or ending one is picked up
+// within it.
+const experimentRecheck = 30 * time.Second
+
+type experimentState struct {
+	running bool
+	checked time.Time
+}
+
+// WithIndexExperiments lets CreateExperimentIndex start index experiments
+// and makes tutor writes look up, every experimentRecheck, whether one is
+// running to mirror them into. Without it index experiments are
+// unsupported.
+func WithIndexExperiments() ClientOption {
+	return func(c *Client) error {
+		c.experiments = make(map[string]experimentState)
+		return nil
+	}
+}
+
+// CreateExperimentIndex creates an index analyzed per mapping behind the
+// alias <index>-exp, so tutor writes reach it from then on, and starts a
+// reindex task copying the live index's documents over. Documents written
+// while the task runs are kept over the copied ones.
+func (c *Client) CreateExperimentIndex(ctx context.Context, mapping ExperimentMapping) (*ExperimentIndex, error) {
+	if c.experiments == nil {
+		return nil, ErrUnsupported
+	}
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+	live := c.index(ctx)
+	exp := &ExperimentIndex{
+		Alias: live + ExperimentIndexSuffix,
+		Index: live + ExperimentIndexSuffix + "-" + time.Now().UTC().Format("20060102150405"),
+	}
+
+	running, err := c.aliasIndices(ctx, exp.Alias)
+	if err != nil {
+		return nil, err
+	}
+	if len(running) > 0 {
+		return nil, ErrExperimentRunning
+	}
+
+	body, err := json.Marshal(experimentIndexBody(c.settings, mapping, exp.Alias))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal experiment index: %w", err)
+	}
+	if _, err := c.client.Indices.Create(ctx, opensearchapi.IndicesCreateReq{
+		Index: exp.Index,
+		Body:  bytes.NewReader(body),
+	}); err != nil {
+		if isIndexExists(err) {
+			return nil, ErrExperimentRunning
+		}
+		return nil, fmt.Errorf("failed to create experiment index: %w", err)
+	}
+	c.setExperiment(live, true)
+
+	if exp.ReindexTask, err = c.startReindex(ctx, live, exp.Index); err != nil {
+		if err := c.deleteExperiment(ctx, live, []string{exp.Index}); err != nil {
+			c.logger.Error("Failed to delete experiment index after reindex failed", "index", exp.Index, "error", err)
+		}
+		return nil, err
+	}
+
+	c.logger.Warn("Index experiment started",
+		"index", live,
+		"experiment_index", exp.Index,
+		"reindex_task", exp.ReindexTask,
+	)
+	return exp, nil
+}
+
+// DeleteExperimentIndex stops mirroring writes to ctx's experiment index
+// and deletes it. Other replicas stop within experimentRecheck; their
+// writes in between fail harmlessly, as writes to the alias require it to
+// exist.
+func (c *Client) DeleteExperimentIndex(ctx context.Context) error {
+	if c.experiments == nil {
+		return ErrUnsupported
+	}
+	live := c.index(ctx)
+	indices, err := c.aliasIndices(ctx, live+ExperimentIndexSuffix)
+	if err != nil {
+		return err
+	}
+	if len(indices) == 0 {
+		c.setExperiment(live, false)
+		return ErrNoExperiment
+	}
+	if err := c.deleteExperiment(ctx, live, indices); err != nil {
+		return err
+	}
+	c.logger.Warn("Index experiment ended", "index", live, "experiment_index", indices)
+	return nil
+}
+
+func (c *Client) deleteExperiment(ctx context.Context, live string, indices []string) error {
+	c.setExperiment(live, false)
+	if _, err := c.client.Indices.Delete(ctx, opensearchapi.IndicesDeleteReq{Indices: indices}); err != nil {
+		return fmt.Errorf("failed to delete experiment index: %w", err)
+	}
+	return nil
+}
+
+// startReindex copies every document of from into to in the background
+// and returns the task doing it. Documents already in to are skipped
+// rather than overwritten.
+func (c *Client) startReindex(ctx context.Context, from, to string) (string, error) {
+	body, err := json.Marshal(map[string]any{
+		"conflicts": "proceed",
+		"source":    map[string]any{"index": from},
+		"dest":      map[string]any{"index": to, "op_type": "create"},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal reindex request: %w", err)
+	}
+
+	wait := false
+	resp, err := c.client.Reindex(ctx, opensearchapi.ReindexReq{
+		Body:   bytes.NewReader(body),
+		Params: opensearchapi.ReindexParams{WaitForCompletion: &wait},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to start reindex into experiment index: %w", err)
+	}
+	return resp.Task, nil
+}
+
+// experimentIndexBody returns the live index body with mapping's analysis
+// changes and the experiment alias.
+func experimentIndexBody(s IndexSettings, mapping ExperimentMapping, alias string) map[string]any {
+	body := indexBody(s)
+	settings := body["settings"].(map[string]any)
+	live := settings["analysis"].(map[string]any)
+
+	analysis := maps.Clone(live)
+	analyzers := maps.Clone(live["analyzer"].(map[string]any))
+	for name, a := range mapping.Analyzers {
+		analyzers[name] = map[string]any{
+			"type":      "custom",
+			"tokenizer": a.Tokenizer,
+			"filter":    slices.Clone(a.Filter),
+		}
+	}
+	filters := maps.Clone(live["filter"].(map[string]any))
+	for name, language := range mapping.Stemmers {
+		filters[name] = map[string]any{"type": "stemmer", "language": language}
+	}
+	analysis["analyzer"] = analyzers
+	analysis["filter"] = filters
+	settings["analysis"] = analysis
+
+	body["aliases"] = map[string]any{alias: map[string]any{}}
+	return body
+}
+
+// experimentIndex returns ctx's experiment alias and whether an index
+// experiment is running on ctx's index, as last seen within
+// experimentRecheck. When the lookup fails the previous answer stands.
+func (c *Client) experimentIndex(ctx context.Context) (string, bool) {
+	if c.experiments == nil {
+		return "", false
+	}
+	live := c.index(ctx)
+	alias := live + ExperimentIndexSuffix
+
+	c.experimentsMu.Lock()
+	state, ok := c.experiments[live]
+	c.experimentsMu.Unlock()
+	if ok && time.Since(state.checked) < experimentRecheck {
+		return alias, state.running
+	}
+
+	running := state.running
+	indices, err := c.aliasIndices(ctx, alias)
+	if err != nil {
+		c.logger.Warn("Failed to look up index experiment", "alias", alias, "error", err)
+	} else {
+		running = len(indices) > 0
+	}
+	c.setExperiment(live, running)
+	return alias, running
+}
+
+func (c *Client) setExperiment(live string, running bool) {
+	c.experimentsMu.Lock()
+	defer c.experimentsMu.Unlock()
+	c.experiments[live] = experimentState{running: running, checked: time.Now()}
+}
+
+// aliasIndices returns the indices behind alias, none when it does not
+// exist.
+func (c *Client) aliasIndices(ctx context.Context, alias string) ([]string, error) {
+	resp, err := c.client.Indices.Alias.Get(ctx, opensearchapi.AliasGetReq{
+		Indices: []string{alias},
+		Alias:   []string{alias},
+	})
+	if err != nil {
+		var se *opensearch.StringError
+		if isIndexNotFound(err) || errors.As(err, &se) && se.Status == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get alias %s: %w", alias, err)
+	}
+	return slices.Sorted(maps.Keys(resp.Indices)), nil
+}
+
+// mirror repeats a tutor write against ctx's experiment index while an
+// index experiment is running. A failure is logged, not returned: the live
+// index has taken the write, and the experiment must not fail it.
+func (c *Client) mirror(ctx context.Context, id int64, write func(index string) error) {
+	alias, running := c.experimentIndex(ctx)
+	if !running {
+		return
+	}
+	err := c.throttled(ctx, func() error { return write(alias) })
+	if err != nil && !isDocumentNotFound(err) {
+		c.logger.Warn("Failed to write tutor to experiment index", "index", alias, "id", id, "error", err)
+	}
+}
+
+// CreateExperimentIndex is not supported: the in-memory backend has a
+// single index.
+func (m *MemoryClient) CreateExperimentIndex(ctx context.Context, mapping ExperimentMapping) (*ExperimentIndex, error) {
+	return nil, ErrUnsupported
+}
+
+// DeleteExperimentIndex is not supported: the in-memory backend has a
+// single index.
+func (m *MemoryClient) DeleteExperimentIndex(ctx context.Context) error {
+	return ErrUnsupported
+}
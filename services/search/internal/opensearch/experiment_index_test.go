@@ -0,0 +1,387 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"search/internal/domain"
+	"search/internal/port"
+)
+
+const experimentAliasPath = "/tutors-exp/_alias/tutors-exp"
+
+// newExperimentClient returns a test client with index experiments
+// enabled.
+func newExperimentClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	client := newTestClient(t, handler)
+	client.experiments = make(map[string]experimentState)
+	return client
+}
+
+// experimentAliasMissing answers an alias lookup as OpenSearch does when no
+// experiment runs.
+func experimentAliasMissing(w http.ResponseWriter) {
+	writeJSON(w, http.StatusNotFound, `{"error":{"type":"index_not_found_exception","reason":"no such index [tutors-exp]"},"status":404}`)
+}
+
+func experimentAliasFound(w http.ResponseWriter) {
+	writeJSON(w, http.StatusOK, `{"tutors-exp-20261017120000":{"aliases":{"tutors-exp":{}}}}`)
+}
+
+func TestCreateExperimentIndex(t *testing.T) {
+	var created map[string]any
+	var reindex map[string]any
+	var reindexQuery string
+	client := newExperimentClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == experimentAliasPath:
+			experimentAliasMissing(w)
+		case r.Method == http.MethodPut && strings.HasPrefix(r.URL.Path, "/tutors-exp-"):
+			json.NewDecoder(r.Body).Decode(&created)
+			writeJSON(w, http.StatusOK, `{"acknowledged":true}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/_reindex":
+			reindexQuery = r.URL.RawQuery
+			json.NewDecoder(r.Body).Decode(&reindex)
+			writeJSON(w, http.StatusOK, `{"task":"node-1:42"}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	exp, err := client.CreateExperimentIndex(context.Background(), ExperimentMapping{
+		Analyzers: map[string]port.Analyzer{
+			"english_analyzer": {Tokenizer: "standard", Filter: []string{"lowercase", "asciifolding", "english_stemmer"}},
+		},
+		Stemmers: map[string]string{"english_stemmer": "light_english"},
+	})
+	if err != nil {
+		t.Fatalf("CreateExperimentIndex: %v", err)
+	}
+	if exp.Alias != "tutors-exp" || !strings.HasPrefix(exp.Index, "tutors-exp-") || exp.ReindexTask != "node-1:42" {
+		t.Errorf("experiment = %+v, want alias tutors-exp, a tutors-exp- index and task node-1:42", exp)
+	}
+
+	if _, ok := created["aliases"].(map[string]any)["tutors-exp"]; !ok {
+		t.Errorf("aliases = %v, want tutors-exp", created["aliases"])
+	}
+	analysis := created["settings"].(map[string]any)["analysis"].(map[string]any)
+	english := analysis["analyzer"].(map[string]any)["english_analyzer"].(map[string]any)
+	if filters, _ := json.Marshal(english["filter"]); string(filters) != `["lowercase","asciifolding","english_stemmer"]` {
+		t.Errorf("english_analyzer filters = %s, want the requested chain", filters)
+	}
+	if _, ok := analysis["analyzer"].(map[string]any)["russian_analyzer"]; !ok {
+		t.Error("russian_analyzer missing, want analyzers left out of the request copied")
+	}
+	stemmer := analysis["filter"].(map[string]any)["english_stemmer"].(map[string]any)
+	if stemmer["language"] != "light_english" {
+		t.Errorf("english_stemmer = %v, want light_english", stemmer)
+	}
+	if created["mappings"].(map[string]any)["dynamic"] != "strict" {
+		t.Errorf("mappings = %v, want the live index mappings", created["mappings"])
+	}
+
+	// The shared mapping must not pick up the experiment's changes.
+	live := indexMapping["settings"].(map[string]any)["analysis"].(map[string]any)
+	if live["filter"].(map[string]any)["english_stemmer"].(map[string]any)["language"] != "english" {
+		t.Error("CreateExperimentIndex modified indexMapping")
+	}
+
+	if reindexQuery != "wait_for_completion=false" {
+		t.Errorf("reindex query = %q, want wait_for_completion=false", reindexQuery)
+	}
+	dest := reindex["dest"].(map[string]any)
+	if reindex["source"].(map[string]any)["index"] != "tutors" || dest["index"] != exp.Index || dest["op_type"] != "create" ||
+		reindex["conflicts"] != "proceed" {
+		t.Errorf("reindex = %v, want tutors copied into %s without overwriting", reindex, exp.Index)
+	}
+}
+
+func TestCreateExperimentIndex_AlreadyRunning(t *testing.T) {
+	client := newExperimentClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != experimentAliasPath {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		experimentAliasFound(w)
+	})
+
+	if _, err := client.CreateExperimentIndex(context.Background(), ExperimentMapping{}); !errors.Is(err, ErrExperimentRunning) {
+		t.Errorf("err = %v, want ErrExperimentRunning", err)
+	}
+}
+
+func TestCreateExperimentIndex_InvalidMapping(t *testing.T) {
+	client := newExperimentClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	mapping := ExperimentMapping{Stemmers: map[string]string{"english_stemmer": "dutch"}}
+	if _, err := client.CreateExperimentIndex(context.Background(), mapping); err == nil {
+		t.Error("expected an error for a stemmer language outside the whitelist")
+	}
+}
+
+func TestCreateExperimentIndex_ReindexFailureDeletesIndex(t *testing.T) {
+	var deleted string
+	client := newExperimentClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == experimentAliasPath:
+			experimentAliasMissing(w)
+		case r.Method == http.MethodPut:
+			writeJSON(w, http.StatusOK, `{"acknowledged":true}`)
+		case r.URL.Path == "/_reindex":
+			writeJSON(w, http.StatusInternalServerError, `{"error":{"type":"exception","reason":"boom"},"status":500}`)
+		case r.Method == http.MethodDelete:
+			deleted = r.URL.Path
+			writeJSON(w, http.StatusOK, `{"acknowledged":true}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	if _, err := client.CreateExperimentIndex(context.Background(), ExperimentMapping{}); err == nil {
+		t.Fatal("expected the reindex failure to be returned")
+	}
+	if !strings.HasPrefix(deleted, "/tutors-exp-") {
+		t.Errorf("deleted %q, want the new experiment index", deleted)
+	}
+	if _, running := client.experimentIndex(context.Background()); running {
+		t.Error("experiment still marked running after its index was deleted")
+	}
+}
+
+func TestExperimentIndex_Unsupported(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+	ctx := context.Background()
+
+	if _, err := client.CreateExperimentIndex(ctx, ExperimentMapping{}); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("CreateExperimentIndex err = %v, want ErrUnsupported without WithIndexExperiments", err)
+	}
+	if err := client.DeleteExperimentIndex(ctx); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("DeleteExperimentIndex err = %v, want ErrUnsupported without WithIndexExperiments", err)
+	}
+	m := NewMemoryClient()
+	if _, err := m.CreateExperimentIndex(ctx, ExperimentMapping{}); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("MemoryClient.CreateExperimentIndex err = %v, want ErrUnsupported", err)
+	}
+	if err := m.DeleteExperimentIndex(ctx); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("MemoryClient.DeleteExperimentIndex err = %v, want ErrUnsupported", err)
+	}
+}
+
+func TestDeleteExperimentIndex(t *testing.T) {
+	var deleted string
+	client := newExperimentClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == experimentAliasPath:
+			experimentAliasFound(w)
+		case r.Method == http.MethodDelete:
+			deleted = r.URL.Path
+			writeJSON(w, http.StatusOK, `{"acknowledged":true}`)
+		case r.URL.Path == "/tutors/_update/1":
+			writeJSON(w, http.StatusOK, `{"result":"updated"}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	ctx := context.Background()
+
+	if err := client.DeleteExperimentIndex(ctx); err != nil {
+		t.Fatalf("DeleteExperimentIndex: %v", err)
+	}
+	if deleted != "/tutors-exp-20261017120000" {
+		t.Errorf("deleted %q, want the index behind the alias", deleted)
+	}
+	// Writes stop reaching the experiment at once, without a lookup.
+	if err := client.UpsertTutor(ctx, &domain.Tutor{ID: 1}); err != nil {
+		t.Fatalf("UpsertTutor: %v", err)
+	}
+}
+
+func TestDeleteExperimentIndex_NoExperiment(t *testing.T) {
+	client := newExperimentClient(t, func(w http.ResponseWriter, r *http.Request) {
+		experimentAliasMissing(w)
+	})
+
+	if err := client.DeleteExperimentIndex(context.Background()); !errors.Is(err, ErrNoExperiment) {
+		t.Errorf("err = %v, want ErrNoExperiment", err)
+	}
+}
+
+// dualWriteServer records the writes it receives and fails those to the
+// experiment alias with expStatus, if set.
+type dualWriteServer struct {
+	mu        sync.Mutex
+	lookups   int
+	running   bool
+	expStatus int
+	writes    []string
+}
+
+func (s *dualWriteServer) handle(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.URL.Path == experimentAliasPath {
+		s.lookups++
+		if s.running {
+			experimentAliasFound(w)
+		} else {
+			experimentAliasMissing(w)
+		}
+		return
+	}
+	write := r.Method + " " + r.URL.Path
+	if r.URL.Query().Get("require_alias") == "true" {
+		write += " require_alias"
+	}
+	s.writes = append(s.writes, write)
+
+	switch {
+	case strings.HasPrefix(r.URL.Path, "/tutors-exp/") && s.expStatus != 0:
+		writeJSON(w, s.expStatus, `{"error":{"type":"exception","reason":"experiment index unavailable"},"status":`+strconv.Itoa(s.expStatus)+`}`)
+	case r.Method == http.MethodDelete:
+		writeJSON(w, http.StatusOK, `{"result":"deleted"}`)
+	default:
+		writeJSON(w, http.StatusOK, `{"result":"updated"}`)
+	}
+}
+
+func TestDualWrite(t *testing.T) {
+	writes := []struct {
+		name  string
+		write func(*Client) error
+		live  string
+		exp   string
+	}{
+		{
+			name:  "upsert",
+			write: func(c *Client) error { return c.UpsertTutor(context.Background(), &domain.Tutor{ID: 1}) },
+			live:  "POST /tutors/_update/1",
+			exp:   "POST /tutors-exp/_update/1 require_alias",
+		},
+		{
+			name: "snapshot upsert",
+			write: func(c *Client) error {
+				_, err := c.UpsertSnapshotTutor(context.Background(), &domain.Tutor{ID: 1}, "snap-1")
+				return err
+			},
+			live: "POST /tutors/_update/1",
+			exp:  "POST /tutors-exp/_update/1 require_alias",
+		},
+		{
+			name:  "delete",
+			write: func(c *Client) error { return c.DeleteTutor(context.Background(), 1) },
+			live:  "DELETE /tutors/_doc/1",
+			exp:   "DELETE /tutors-exp/_doc/1",
+		},
+	}
+	for _, w := range writes {
+		t.Run(w.name+" mirrored", func(t *testing.T) {
+			server := &dualWriteServer{running: true}
+			client := newExperimentClient(t, server.handle)
+
+			if err := w.write(client); err != nil {
+				t.Fatalf("write: %v", err)
+			}
+			if len(server.writes) != 2 || server.writes[0] != w.live || server.writes[1] != w.exp {
+				t.Errorf("writes = %q, want %q then %q", server.writes, w.live, w.exp)
+			}
+		})
+		t.Run(w.name+" experiment failure", func(t *testing.T) {
+			server := &dualWriteServer{running: true, expStatus: http.StatusInternalServerError}
+			client := newExperimentClient(t, server.handle)
+
+			if err := w.write(client); err != nil {
+				t.Errorf("write: %v, want the experiment's failure kept from the live write", err)
+			}
+			if len(server.writes) != 2 || server.writes[0] != w.live {
+				t.Errorf("writes = %q, want the live write to go through first", server.writes)
+			}
+		})
+		t.Run(w.name+" no experiment", func(t *testing.T) {
+			server := &dualWriteServer{}
+			client := newExperimentClient(t, server.handle)
+
+			for range 3 {
+				if err := w.write(client); err != nil {
+					t.Fatalf("write: %v", err)
+				}
+			}
+			if len(server.writes) != 3 {
+				t.Errorf("writes = %q, want only the live index written", server.writes)
+			}
+			if server.lookups != 1 {
+				t.Errorf("alias looked up %d times, want once within experimentRecheck", server.lookups)
+			}
+		})
+	}
+}
+
+func TestDualWrite_LiveFailureSkipsExperiment(t *testing.T) {
+	var writes []string
+	client := newExperimentClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == experimentAliasPath {
+			experimentAliasFound(w)
+			return
+		}
+		writes = append(writes, r.URL.Path)
+		writeJSON(w, http.StatusInternalServerError, `{"error":{"type":"exception","reason":"boom"},"status":500}`)
+	})
+
+	if err := client.UpsertTutor(context.Background(), &domain.Tutor{ID: 1}); err == nil {
+		t.Fatal("expected the live write's failure to be returned")
+	}
+	for _, path := range writes {
+		if strings.HasPrefix(path, "/tutors-exp/") {
+			t.Errorf("wrote %s, want a failed live write kept from the experiment", path)
+		}
+	}
+}
+
+func TestSearchTutors_IndexVariant(t *testing.T) {
+	tests := []struct {
+		name        string
+		running     bool
+		wantPath    string
+		wantVariant string
+	}{
+		{"experiment running", true, "/tutors-exp/_search", IndexVariant},
+		{"no experiment", false, "/tutors/_search", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var path string
+			client := newExperimentClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == experimentAliasPath {
+					if tt.running {
+						experimentAliasFound(w)
+					} else {
+						experimentAliasMissing(w)
+					}
+					return
+				}
+				path = r.URL.Path
+				writeJSON(w, http.StatusOK, `{"took":1,"_shards":{"total":1,"successful":1,"failed":0},"hits":{"total":{"value":0,"relation":"eq"},"hits":[]}}`)
+			})
+
+			result, err := client.SearchTutors(context.Background(), SearchQuery{Text: "algebra", Variant: IndexVariant})
+			if err != nil {
+				t.Fatalf("SearchTutors: %v", err)
+			}
+			if path != tt.wantPath {
+				t.Errorf("searched %s, want %s", path, tt.wantPath)
+			}
+			if result.Variant != tt.wantVariant {
+				t.Errorf("variant = %q, want %q", result.Variant, tt.wantVariant)
+			}
+		})
+	}
+}
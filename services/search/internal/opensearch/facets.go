@@ -0,0 +1,181 @@
+package opensearch
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	"search/internal/domain"
+	"search/internal/port"
+)
+
+// facetBuckets is a terms aggregation result.
+type facetBuckets struct {
+	Buckets []struct {
+		Key      string `json:"key"`
+		DocCount int    `json:"doc_count"`
+	} `json:"buckets"`
+}
+
+func (b facetBuckets) counts() map[string]int {
+	counts := make(map[string]int, len(b.Buckets))
+	for _, bucket := range b.Buckets {
+		counts[bucket.Key] = bucket.DocCount
+	}
+	return counts
+}
+
+// FacetCounts counts tutors per subject key and teaching level with terms
+// aggregations, and those offering a trial lesson with a filter one, in a
+// single search.
+func (c *Client) FacetCounts(ctx context.Context, size int) (*FacetCounts, error) {
+	body, err := json.Marshal(buildFacetCountsQuery(size))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal facet counts query: %w", err)
+	}
+
+	resp, err := c.client.Search(ctx, &opensearchapi.SearchReq{
+		Indices: []string{c.readIndex(ctx)},
+		Body:    bytes.NewReader(body),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count facets: %w", err)
+	}
+
+	return decodeFacetCounts(resp.Aggregations)
+}
+
+// SearchTutorsWithFacets runs query, as SearchTutors does, and the
+// FacetCounts aggregations as one _msearch request, saving the page that
+// shows both a round-trip. Both run on the index query does, which holds
+// the same tutors under an index experiment. It logs how much longer the
+// combined request took than the search inside it.
+func (c *Client) SearchTutorsWithFacets(ctx context.Context, query SearchQuery, facetSize int) (*SearchResponse, error) {
+	index, query := c.searchIndex(ctx, query)
+	start := time.Now()
+	items, err := c.multiSearchIndex(ctx, index, buildSearchQuery(query, c.currentRelevance()), buildFacetCountsQuery(facetSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tutors with facets: %w", err)
+	}
+	roundTrip := time.Since(start)
+	// The searches run side by side, so the slower one is the time spent.
+	port.TimingFrom(ctx).AddTook(time.Duration(max(items[0].Took, items[1].Took)) * time.Millisecond)
+
+	var resp opensearchapi.SearchResp
+	if err := json.Unmarshal(items[0].raw, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode search response: %w", err)
+	}
+	result, err := c.searchResult(index, query, &resp, items[0].raw)
+	if err != nil {
+		return nil, err
+	}
+	if result.Facets, err = decodeFacetCounts(items[1].Aggregations); err != nil {
+		return nil, err
+	}
+
+	c.logger.Info("Searched tutors with facets",
+		"index", index,
+		"round_trip_ms", roundTrip.Milliseconds(),
+		"search_took_ms", items[0].Took,
+		"facets_took_ms", items[1].Took,
+		"delta_ms", roundTrip.Milliseconds()-int64(items[0].Took),
+	)
+	return result, nil
+}
+
+func decodeFacetCounts(raw json.RawMessage) (*FacetCounts, error) {
+	var aggs struct {
+		BySubject facetBuckets `json:"by_subject"`
+		ByLevel   facetBuckets `json:"by_level"`
+		Trial     struct {
+			DocCount int `json:"doc_count"`
+		} `json:"trial"`
+	}
+	if err := json.Unmarshal(raw, &aggs); err != nil {
+		return nil, fmt.Errorf("failed to decode facet counts aggregations: %w", err)
+	}
+	return &FacetCounts{
+		Subjects: aggs.BySubject.counts(),
+		Levels:   aggs.ByLevel.counts(),
+		Trial:    aggs.Trial.DocCount,
+	}, nil
+}
+
+// buildFacetCountsQuery counts the size most taught subjects; terms
+// aggregations order buckets by count and then key, as MemoryClient does.
+func buildFacetCountsQuery(size int) map[string]any {
+	return map[string]any{
+		"size": 0,
+		"aggs": map[string]any{
+			"by_subject": map[string]any{
+				"terms": map[string]any{
+					"field": "subjects",
+					"size":  size,
+				},
+			},
+			"by_level": map[string]any{
+				"terms": map[string]any{
+					"field": "levels",
+					"size":  len(domain.Levels),
+				},
+			},
+			"trial": map[string]any{
+				"filter": map[string]any{
+					"term": map[string]any{"offers_trial": true},
+				},
+			},
+		},
+	}
+}
+
+func (m *MemoryClient) FacetCounts(ctx context.Context, size int) (*FacetCounts, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	counts := &FacetCounts{
+		Subjects: make(map[string]int),
+		Levels:   make(map[string]int),
+	}
+	for _, t := range m.indices[IndexFor(ctx)] {
+		for _, s := range t.Subjects {
+			counts.Subjects[s]++
+		}
+		for _, l := range t.Levels {
+			counts.Levels[l]++
+		}
+		if t.OffersTrial {
+			counts.Trial++
+		}
+	}
+	if len(counts.Subjects) > size {
+		keys := slices.Collect(maps.Keys(counts.Subjects))
+		slices.SortFunc(keys, func(a, b string) int {
+			if c := cmp.Compare(counts.Subjects[b], counts.Subjects[a]); c != 0 {
+				return c
+			}
+			return cmp.Compare(a, b)
+		})
+		for _, key := range keys[size:] {
+			delete(counts.Subjects, key)
+		}
+	}
+	return counts, nil
+}
+
+func (m *MemoryClient) SearchTutorsWithFacets(ctx context.Context, query SearchQuery, facetSize int) (*SearchResponse, error) {
+	result, err := m.SearchTutors(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if result.Facets, err = m.FacetCounts(ctx, facetSize); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
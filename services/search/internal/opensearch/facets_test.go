@@ -0,0 +1,219 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"maps"
+	"net/http"
+	"strings"
+	"testing"
+
+	"search/internal/domain"
+	"search/internal/port"
+)
+
+func TestFacetCounts(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/tutors/_search" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			Aggs map[string]json.RawMessage `json:"aggs"`
+		}
+		raw, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(raw, &body); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		if _, ok := body.Aggs["by_level"]; !ok {
+			t.Errorf("expected a by_level aggregation, got %s", raw)
+		}
+		if !strings.Contains(string(body.Aggs["by_subject"]), `"size":25`) {
+			t.Errorf("expected the subject buckets capped at the facet size, got %s", raw)
+		}
+		if !strings.Contains(string(body.Aggs["trial"]), `"offers_trial":true`) {
+			t.Errorf("expected a trial filter aggregation, got %s", raw)
+		}
+		writeJSON(w, http.StatusOK, `{
+			"took": 1, "timed_out": false,
+			"_shards": {"total": 1, "successful": 1, "skipped": 0, "failed": 0},
+			"hits": {"total": {"value": 3, "relation": "eq"}, "hits": []},
+			"aggregations": {
+				"by_subject": {"buckets": [
+					{"key": "math", "doc_count": 2},
+					{"key": "physics", "doc_count": 1}
+				]},
+				"by_level": {"buckets": [
+					{"key": "school", "doc_count": 3}
+				]},
+				"trial": {"doc_count": 2}
+			}
+		}`)
+	})
+
+	counts, err := client.FacetCounts(context.Background(), 25)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := map[string]int{"math": 2, "physics": 1}; !maps.Equal(counts.Subjects, want) {
+		t.Errorf("expected subjects %v, got %v", want, counts.Subjects)
+	}
+	if want := map[string]int{"school": 3}; !maps.Equal(counts.Levels, want) {
+		t.Errorf("expected levels %v, got %v", want, counts.Levels)
+	}
+	if counts.Trial != 2 {
+		t.Errorf("expected 2 tutors offering a trial, got %d", counts.Trial)
+	}
+}
+
+func TestMemoryClient_FacetCounts(t *testing.T) {
+	client := NewMemoryClient()
+	ctx := context.Background()
+	for _, tutor := range []domain.Tutor{
+		{ID: 1, Subjects: []string{"physics", "math"}, Levels: []string{domain.LevelSchool, domain.LevelAdult}},
+		{ID: 2, Subjects: []string{"math"}, Levels: []string{domain.LevelSchool}, OffersTrial: true},
+		{ID: 3},
+	} {
+		client.UpsertTutor(ctx, &tutor)
+	}
+
+	counts, err := client.FacetCounts(ctx, port.MaxFacetSize)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := map[string]int{"math": 2, "physics": 1}; !maps.Equal(counts.Subjects, want) {
+		t.Errorf("expected subjects %v, got %v", want, counts.Subjects)
+	}
+	if want := map[string]int{"school": 2, "adult": 1}; !maps.Equal(counts.Levels, want) {
+		t.Errorf("expected levels %v, got %v", want, counts.Levels)
+	}
+	if counts.Trial != 1 {
+		t.Errorf("expected 1 tutor offering a trial, got %d", counts.Trial)
+	}
+}
+
+func TestMemoryClient_FacetCounts_Size(t *testing.T) {
+	client := NewMemoryClient()
+	ctx := context.Background()
+	for _, tutor := range []domain.Tutor{
+		{ID: 1, Subjects: []string{"physics", "math", "chess"}, Levels: []string{domain.LevelSchool}},
+		{ID: 2, Subjects: []string{"math", "art"}, Levels: []string{domain.LevelAdult}},
+	} {
+		client.UpsertTutor(ctx, &tutor)
+	}
+
+	counts, err := client.FacetCounts(ctx, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// art, chess and physics tie on one tutor; art wins on its key.
+	if want := map[string]int{"math": 2, "art": 1}; !maps.Equal(counts.Subjects, want) {
+		t.Errorf("expected the 2 most taught subjects %v, got %v", want, counts.Subjects)
+	}
+	if want := map[string]int{"school": 1, "adult": 1}; !maps.Equal(counts.Levels, want) {
+		t.Errorf("expected every level regardless of size, got %v", counts.Levels)
+	}
+}
+
+func TestSearchTutorsWithFacets(t *testing.T) {
+	var lines []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/tutors/_msearch" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		raw, _ := io.ReadAll(r.Body)
+		lines = strings.Split(strings.TrimSuffix(string(raw), "\n"), "\n")
+		writeJSON(w, http.StatusOK, `{"took": 5, "responses": [
+			{
+				"took": 3, "status": 200,
+				"_shards": {"total": 2, "successful": 1, "failed": 1},
+				"hits": {"total": {"value": 7, "relation": "eq"}, "hits": [
+					{"_source": {"id": 3, "location": "London"}, "inner_hits": {"alternates": {"hits": {"hits": [
+						{"_source": {"id": 3, "location": "London"}},
+						{"_source": {"id": 2, "location": "London"}}
+					]}}}}
+				]}
+			},
+			{
+				"took": 2, "status": 200,
+				"_shards": {"total": 2, "successful": 2, "failed": 0},
+				"hits": {"total": {"value": 9, "relation": "eq"}, "hits": []},
+				"aggregations": {
+					"by_subject": {"buckets": [{"key": "math", "doc_count": 6}]},
+					"by_level": {"buckets": [{"key": "adult", "doc_count": 4}]}
+				}
+			}
+		]}`)
+	})
+
+	result, err := client.SearchTutorsWithFacets(context.Background(), SearchQuery{Text: "math", DiversifyBy: DiversifyLocation, Limit: 5}, 30)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(lines) != 4 {
+		t.Fatalf("expected two searches in the request, got %q", lines)
+	}
+	var search, facets map[string]any
+	json.Unmarshal([]byte(lines[1]), &search)
+	json.Unmarshal([]byte(lines[3]), &facets)
+	if search["collapse"] == nil || search["size"] != 5.0 {
+		t.Errorf("expected the first search to be the tutor search, got %s", lines[1])
+	}
+	if facets["aggs"] == nil || facets["size"] != 0.0 {
+		t.Errorf("expected the second search to count facets, got %s", lines[3])
+	}
+	if !strings.Contains(lines[3], `"size":30`) {
+		t.Errorf("expected the subject buckets capped at the facet size, got %s", lines[3])
+	}
+
+	if len(result.Results) != 1 || result.Total != 7 || result.TookMs != 3 {
+		t.Errorf("expected the search part's results, got %+v", result)
+	}
+	if !result.Partial || result.ShardsFailed != 1 {
+		t.Errorf("expected the search part's shard failure, got %+v", result)
+	}
+	if alts := result.Alternates[3]; len(alts) != 1 || alts[0].ID != 2 {
+		t.Errorf("expected tutor 2 under 3, got %+v", result.Alternates)
+	}
+	if result.Facets == nil || !maps.Equal(result.Facets.Subjects, map[string]int{"math": 6}) ||
+		!maps.Equal(result.Facets.Levels, map[string]int{"adult": 4}) {
+		t.Errorf("unexpected facets %+v", result.Facets)
+	}
+}
+
+func TestSearchTutorsWithFacets_FailedPart(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{"took": 1, "responses": [
+			{"took": 1, "status": 200, "hits": {"total": {"value": 0}, "hits": []}},
+			{"status": 429, "error": {"type": "circuit_breaking_exception", "reason": "too many buckets"}}
+		]}`)
+	})
+
+	_, err := client.SearchTutorsWithFacets(context.Background(), SearchQuery{}, port.MaxFacetSize)
+	if err == nil || !strings.Contains(err.Error(), "circuit_breaking_exception") {
+		t.Errorf("expected the facets failure, got %v", err)
+	}
+}
+
+func TestMemoryClient_SearchTutorsWithFacets(t *testing.T) {
+	client := NewMemoryClient()
+	ctx := context.Background()
+	for _, tutor := range []domain.Tutor{
+		{ID: 1, FullName: "Ada", Subjects: []string{"math"}},
+		{ID: 2, FullName: "Grace", Subjects: []string{"physics"}},
+	} {
+		client.UpsertTutor(ctx, &tutor)
+	}
+
+	result, err := client.SearchTutorsWithFacets(ctx, SearchQuery{Subjects: []string{"math"}}, port.MaxFacetSize)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Results) != 1 || result.Results[0].ID != 1 {
+		t.Errorf("expected the filtered results, got %+v", result.Results)
+	}
+	if want := map[string]int{"math": 1, "physics": 1}; result.Facets == nil || !maps.Equal(result.Facets.Subjects, want) {
+		t.Errorf("expected facets over the whole index %v, got %+v", want, result.Facets)
+	}
+}
@@ -0,0 +1,115 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"slices"
+	"testing"
+
+	"search/internal/domain"
+)
+
+func TestUpsertTutor_AvailabilityUTCMinutes(t *testing.T) {
+	var body struct {
+		Doc map[string]any `json:"doc"`
+	}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusOK, `{"_index":"tutors","_id":"7","result":"updated"}`)
+	})
+
+	tutor := &domain.Tutor{ID: 7, Timezone: "Asia/Kolkata", WorkingHours: []domain.WorkingHours{{Start: "04:00", End: "07:00"}}}
+	if err := client.UpsertTutor(context.Background(), tutor); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 04:00-07:00 at +05:30 is 22:30-01:30 UTC.
+	want := []any{
+		map[string]any{"gte": float64(0), "lt": float64(90)},
+		map[string]any{"gte": float64(1350), "lt": float64(1440)},
+	}
+	if !reflect.DeepEqual(body.Doc["availability_utc_minutes"], want) {
+		t.Errorf("expected %v, got %v", want, body.Doc["availability_utc_minutes"])
+	}
+	if body.Doc["timezone"] != "Asia/Kolkata" {
+		t.Errorf("expected timezone to be indexed, got %v", body.Doc["timezone"])
+	}
+}
+
+func TestUpsertTutor_NoWorkingHoursClearsAvailability(t *testing.T) {
+	var body struct {
+		Doc map[string]any `json:"doc"`
+	}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusOK, `{"_index":"tutors","_id":"7","result":"updated"}`)
+	})
+
+	if err := client.UpsertTutor(context.Background(), &domain.Tutor{ID: 7}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ranges, ok := body.Doc["availability_utc_minutes"].([]any)
+	if !ok || len(ranges) != 0 {
+		t.Errorf("expected an empty list to clear stale ranges, got %v", body.Doc["availability_utc_minutes"])
+	}
+}
+
+func TestBuildSearchQuery_AvailableBetween(t *testing.T) {
+	q := buildSearchQuery(SearchQuery{AvailableBetween: []domain.MinuteRange{{Start: 0, End: 60}, {Start: 1320, End: 1440}}}, nil)
+
+	filter := q["query"].(map[string]any)["bool"].(map[string]any)["filter"].([]map[string]any)
+	if len(filter) != 1 {
+		t.Fatalf("expected one filter, got %v", filter)
+	}
+	overlap := filter[0]["bool"].(map[string]any)
+	if overlap["minimum_should_match"] != 1 {
+		t.Errorf("expected any range to match, got %v", overlap)
+	}
+	should := overlap["should"].([]map[string]any)
+	want := map[string]any{"gte": 1320, "lt": 1440, "relation": "intersects"}
+	if got := should[1]["range"].(map[string]any)["availability_utc_minutes"]; len(should) != 2 || !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, should)
+	}
+}
+
+func TestMemoryClient_AvailableBetween(t *testing.T) {
+	m := NewMemoryClient()
+	for _, tutor := range []domain.Tutor{
+		{ID: 1, Timezone: "UTC", WorkingHours: []domain.WorkingHours{{Start: "09:00", End: "12:00"}}},
+		{ID: 2, Timezone: "Asia/Tokyo", WorkingHours: []domain.WorkingHours{{Start: "18:00", End: "22:00"}}},
+		{ID: 3, Timezone: "UTC", WorkingHours: []domain.WorkingHours{{Start: "22:00", End: "02:00"}}},
+		{ID: 4},
+	} {
+		if err := m.UpsertTutor(context.Background(), &tutor); err != nil {
+			t.Fatalf("failed to upsert tutor: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		ranges  []domain.MinuteRange
+		wantIDs []int64
+	}{
+		{"morning utc", []domain.MinuteRange{{Start: 600, End: 660}}, []int64{1, 2}},
+		{"ends where hours start", []domain.MinuteRange{{Start: 480, End: 540}}, []int64{}},
+		{"after utc midnight", []domain.MinuteRange{{Start: 0, End: 30}}, []int64{3}},
+		{"no filter", nil, []int64{1, 2, 3, 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := m.SearchTutors(context.Background(), SearchQuery{AvailableBetween: tt.ranges})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			ids := []int64{}
+			for _, tutor := range resp.Results {
+				ids = append(ids, tutor.ID)
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("expected %v, got %v", tt.wantIDs, ids)
+			}
+		})
+	}
+}
@@ -7,10 +7,38 @@ import (
 	"fmt"
 
 	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	"search/internal/domain"
+	"search/internal/tenant"
 )
 
-const IndexName = "tutors"
+// EnsureIndices creates each tenant's index if it does not exist yet.
+func EnsureIndices(ctx context.Context, c SearchClient, tenants []tenant.Tenant) error {
+	for _, t := range tenants {
+		if err := c.EnsureIndex(tenant.NewContext(ctx, t)); err != nil {
+			return fmt.Errorf("tenant %s: %w", t.Name, err)
+		}
+	}
+	return nil
+}
+
+// dateFormat accepts the RFC 3339 UTC timestamps domain.Tutor encodes, and
+// epoch milliseconds for range queries built from numbers.
+const dateFormat = "strict_date_optional_time||epoch_millis"
+
+// languageText maps a free-text field analyzed as English, with en and ru
+// subfields stemmed per language for queries detected as one of them.
+var languageText = map[string]any{
+	"type":     "text",
+	"analyzer": "english_analyzer",
+	"fields": map[string]any{
+		domain.LanguageEnglish: map[string]any{"type": "text", "analyzer": "english_analyzer"},
+		domain.LanguageRussian: map[string]any{"type": "text", "analyzer": "russian_analyzer"},
+	},
+}
 
+// indexMapping is the index body for port.DefaultIndexSettings; see
+// indexBody.
 var indexMapping = map[string]any{
 	"settings": map[string]any{
 		"number_of_shards":   1,
@@ -22,36 +50,119 @@ var indexMapping = map[string]any{
 					"tokenizer": "standard",
 					"filter":    []string{"lowercase", "english_stemmer"},
 				},
+				"russian_analyzer": map[string]any{
+					"type":      "custom",
+					"tokenizer": "standard",
+					"filter":    []string{"lowercase", "russian_stemmer"},
+				},
 			},
 			"filter": map[string]any{
 				"english_stemmer": map[string]any{
 					"type":     "stemmer",
 					"language": "english",
 				},
+				"russian_stemmer": map[string]any{
+					"type":     "stemmer",
+					"language": "russian",
+				},
+			},
+			"normalizer": map[string]any{
+				"lowercase_normalizer": map[string]any{
+					"type":   "custom",
+					"filter": []string{"lowercase"},
+				},
 			},
 		},
 	},
 	"mappings": map[string]any{
+		// Documents are built from tutorDocument, so every field they carry
+		// is mapped below. strict turns a field added to the struct but not
+		// here into a rejected write instead of a dynamically typed mapping
+		// that conflicts with the explicit one once the index is recreated.
+		"dynamic": "strict",
 		"properties": map[string]any{
-			"id":            map[string]any{"type": "integer"},
-			"slug":          map[string]any{"type": "keyword"},
-			"full_name":     map[string]any{"type": "text", "analyzer": "english_analyzer"},
-			"avatar_url":    map[string]any{"type": "keyword", "index": false},
-			"headline":      map[string]any{"type": "text", "analyzer": "english_analyzer"},
-			"bio":           map[string]any{"type": "text", "analyzer": "english_analyzer"},
-			"subjects":      map[string]any{"type": "keyword"},
-			"hourly_rate":   map[string]any{"type": "float"},
-			"rating":        map[string]any{"type": "float"},
-			"reviews_count": map[string]any{"type": "integer"},
-			"is_verified":   map[string]any{"type": "boolean"},
-			"location":      map[string]any{"type": "keyword"},
-			"formats":       map[string]any{"type": "keyword"},
-			"created_at":    map[string]any{"type": "date"},
-			"updated_at":    map[string]any{"type": "date"},
+			// long, as tutor IDs reach domain.DefaultMaxTutorID.
+			"id":             map[string]any{"type": "long"},
+			"slug":           map[string]any{"type": "keyword"},
+			"previous_slugs": map[string]any{"type": "keyword"},
+			"full_name":      map[string]any{"type": "text", "analyzer": "english_analyzer"},
+			"avatar_url":     map[string]any{"type": "keyword", "index": false},
+			"headline":       languageText,
+			"bio":            languageText,
+			"bio_snippet":    map[string]any{"type": "text", "index": false},
+			// subjects is filtered on as keywords; the text subfield lets a
+			// search for "chess coach" match a tutor of chess.
+			"subjects": map[string]any{
+				"type": "keyword",
+				"fields": map[string]any{
+					"text": map[string]any{"type": "text", "analyzer": "english_analyzer"},
+				},
+			},
+			"subjects_display":  map[string]any{"type": "keyword", "index": false},
+			"hourly_rate":       map[string]any{"type": "float"},
+			"online_rate":       map[string]any{"type": "float"},
+			"offline_rate":      map[string]any{"type": "float"},
+			"rating":            map[string]any{"type": "float"},
+			"reviews_count":     map[string]any{"type": "integer"},
+			"is_verified":       map[string]any{"type": "boolean"},
+			"offers_trial":      map[string]any{"type": "boolean"},
+			"location":          map[string]any{"type": "keyword"},
+			"formats":           map[string]any{"type": "keyword"},
+			"levels":            map[string]any{"type": "keyword"},
+			"created_at":        map[string]any{"type": "date", "format": dateFormat},
+			"updated_at":        map[string]any{"type": "date", "format": dateFormat},
+			"indexed_at":        map[string]any{"type": "date", "format": dateFormat},
+			"next_available_at": map[string]any{"type": "date", "format": dateFormat},
+			"verified_at":       map[string]any{"type": "date", "format": dateFormat},
+			"snapshot_id":       map[string]any{"type": "keyword"},
+			"lang":              map[string]any{"type": "keyword"},
+			"popularity":        map[string]any{"type": "float"},
+			"timezone":          map[string]any{"type": "keyword"},
+			"working_hours": map[string]any{
+				"properties": map[string]any{
+					"start": map[string]any{"type": "keyword", "index": false},
+					"end":   map[string]any{"type": "keyword", "index": false},
+				},
+			},
+			"availability_utc_minutes": map[string]any{"type": "integer_range"},
+			"education": map[string]any{
+				"properties": map[string]any{
+					"institution": map[string]any{"type": "text"},
+					"degree":      map[string]any{"type": "text"},
+					"year":        map[string]any{"type": "integer"},
+				},
+			},
+			"badges": map[string]any{"type": "keyword", "normalizer": "lowercase_normalizer"},
+			// certifications is searched as text; the keyword subfield
+			// backs the case-insensitive certification filter.
+			"certifications": map[string]any{
+				"type": "text",
+				"fields": map[string]any{
+					"keyword": map[string]any{"type": "keyword", "normalizer": "lowercase_normalizer"},
+				},
+			},
 		},
 	},
 }
 
+// indexBody returns indexMapping with the shard and replica counts from s.
+func indexBody(s IndexSettings) map[string]any {
+	settings := make(map[string]any, len(indexMapping["settings"].(map[string]any)))
+	for k, v := range indexMapping["settings"].(map[string]any) {
+		settings[k] = v
+	}
+	settings["number_of_shards"] = s.Shards
+	settings["number_of_replicas"] = s.Replicas
+
+	return map[string]any{
+		"settings": settings,
+		"mappings": indexMapping["mappings"],
+	}
+}
+
+// EnsureIndex creates the index for ctx's tenant if it does not exist yet,
+// and with WithIndexAliases points the read and write aliases that do not
+// exist at it.
 func (c *Client) EnsureIndex(ctx context.Context) error {
 	exists, err := c.indexExists(ctx)
 	if err != nil {
@@ -59,16 +170,16 @@ func (c *Client) EnsureIndex(ctx context.Context) error {
 	}
 
 	if exists {
-		c.logger.Info("Index already exists", "index", IndexName)
-		return nil
+		c.logger.Info("Index already exists", "index", c.index(ctx))
+	} else if err := c.createIndex(ctx); err != nil {
+		return err
 	}
-
-	return c.createIndex(ctx)
+	return c.ensureAliases(ctx)
 }
 
 func (c *Client) indexExists(ctx context.Context) (bool, error) {
 	_, err := c.client.Indices.Exists(ctx, opensearchapi.IndicesExistsReq{
-		Indices: []string{IndexName},
+		Indices: []string{c.index(ctx)},
 	})
 	if err != nil {
 		// Exists returns error when index doesn't exist
@@ -78,19 +189,100 @@ func (c *Client) indexExists(ctx context.Context) (bool, error) {
 }
 
 func (c *Client) createIndex(ctx context.Context) error {
-	body, err := json.Marshal(indexMapping)
+	body, err := json.Marshal(indexBody(c.settings))
 	if err != nil {
 		return fmt.Errorf("failed to marshal index mapping: %w", err)
 	}
 
 	_, err = c.client.Indices.Create(ctx, opensearchapi.IndicesCreateReq{
-		Index: IndexName,
+		Index: c.index(ctx),
 		Body:  bytes.NewReader(body),
 	})
 	if err != nil {
 		return fmt.Errorf("failed to create index: %w", err)
 	}
 
-	c.logger.Info("Index created successfully", "index", IndexName)
+	c.logger.Info("Index created successfully",
+		"index", c.index(ctx),
+		"shards", c.settings.Shards,
+		"replicas", c.settings.Replicas,
+	)
 	return nil
 }
+
+// UpdateIndexSettings sets number_of_replicas on ctx's index. The shard
+// count is fixed at creation and needs a RecreateIndex to change.
+func (c *Client) UpdateIndexSettings(ctx context.Context, replicas int) error {
+	body, err := json.Marshal(map[string]any{
+		"index": map[string]any{"number_of_replicas": replicas},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal index settings: %w", err)
+	}
+
+	if _, err := c.client.Indices.Settings.Put(ctx, opensearchapi.SettingsPutReq{
+		Indices: []string{c.index(ctx)},
+		Body:    bytes.NewReader(body),
+	}); err != nil {
+		return fmt.Errorf("failed to update index settings: %w", err)
+	}
+
+	c.logger.Info("Index settings updated", "index", c.index(ctx), "replicas", replicas)
+	return nil
+}
+
+// RecreateIndex deletes the index for ctx's tenant, if present, and creates it again
+// from indexMapping. Every indexed document is lost.
+func (c *Client) RecreateIndex(ctx context.Context) (*RecreateResult, error) {
+	exists, err := c.indexExists(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &RecreateResult{}
+	if exists {
+		if result.OldCount, err = c.countDocuments(ctx, c.index(ctx)); err != nil {
+			return nil, err
+		}
+		if _, err := c.client.Indices.Delete(ctx, opensearchapi.IndicesDeleteReq{
+			Indices: []string{c.index(ctx)},
+		}); err != nil {
+			return nil, fmt.Errorf("failed to delete index: %w", err)
+		}
+		c.logger.Warn("Index deleted", "index", c.index(ctx), "documents", result.OldCount)
+	}
+
+	if err := c.createIndex(ctx); err != nil {
+		return nil, err
+	}
+	// Deleting the index dropped the aliases pointing at it.
+	if err := c.ensureAliases(ctx); err != nil {
+		return nil, err
+	}
+
+	if result.NewCount, err = c.countDocuments(ctx, c.index(ctx)); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CountTutors counts the documents searches on ctx see with the _count
+// API.
+func (c *Client) CountTutors(ctx context.Context) (int64, error) {
+	return c.countDocuments(ctx, c.readIndex(ctx))
+}
+
+func (c *Client) countDocuments(ctx context.Context, index string) (int64, error) {
+	resp, err := c.client.Indices.Count(ctx, &opensearchapi.IndicesCountReq{
+		Indices: []string{index},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count documents: %w", err)
+	}
+	return int64(resp.Count), nil
+}
+
+// UpdateIndexSettings is not supported: the in-memory backend has no replicas.
+func (m *MemoryClient) UpdateIndexSettings(ctx context.Context, replicas int) error {
+	return ErrUnsupported
+}
@@ -1,6 +1,20 @@
 package opensearch
 
-import "testing"
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"search/internal/domain"
+	"search/internal/port"
+	"search/internal/tenant"
+)
 
 func TestIndexMapping_Structure(t *testing.T) {
 	if _, ok := indexMapping["settings"]; !ok {
@@ -52,17 +66,25 @@ func TestIndexMapping_Properties(t *testing.T) {
 		field        string
 		expectedType string
 	}{
-		{"id", "integer"},
+		{"id", "long"},
 		{"full_name", "text"},
 		{"headline", "text"},
 		{"bio", "text"},
+		{"bio_snippet", "text"},
 		{"subjects", "keyword"},
+		{"subjects_display", "keyword"},
 		{"hourly_rate", "float"},
+		{"online_rate", "float"},
+		{"offline_rate", "float"},
 		{"rating", "float"},
+		{"popularity", "float"},
+		{"timezone", "keyword"},
+		{"availability_utc_minutes", "integer_range"},
 		{"reviews_count", "integer"},
 		{"is_verified", "boolean"},
 		{"location", "keyword"},
 		{"formats", "keyword"},
+		{"previous_slugs", "keyword"},
 		{"created_at", "date"},
 		{"updated_at", "date"},
 	}
@@ -83,8 +105,361 @@ func TestIndexMapping_Properties(t *testing.T) {
 	}
 }
 
+// TestIndexMapping_IDHoldsMaxTutorID keeps the id mapping wide enough for
+// every ID the API accepts; an integer field rejects 2^31 and up.
+func TestIndexMapping_IDHoldsMaxTutorID(t *testing.T) {
+	maxValue := map[string]int64{"integer": math.MaxInt32, "long": math.MaxInt64}
+
+	properties := indexMapping["mappings"].(map[string]any)["properties"].(map[string]any)
+	idType := properties["id"].(map[string]any)["type"].(string)
+	limit, ok := maxValue[idType]
+	if !ok {
+		t.Fatalf("unexpected id type %q", idType)
+	}
+	for _, id := range []int64{1 << 31, domain.DefaultMaxTutorID} {
+		if id > limit {
+			t.Errorf("id mapped as %s cannot hold tutor ID %d", idType, id)
+		}
+	}
+}
+
+func TestIndexMapping_BioSnippetNotIndexed(t *testing.T) {
+	properties := indexMapping["mappings"].(map[string]any)["properties"].(map[string]any)
+	if snippet := properties["bio_snippet"].(map[string]any); snippet["index"] != false {
+		t.Errorf("expected bio_snippet to be display-only, got %v", snippet)
+	}
+}
+
+func TestIndexMapping_LanguageSubfields(t *testing.T) {
+	properties := indexMapping["mappings"].(map[string]any)["properties"].(map[string]any)
+
+	for _, field := range []string{"headline", "bio"} {
+		subfields := properties[field].(map[string]any)["fields"].(map[string]any)
+		for lang, analyzer := range map[string]string{"en": "english_analyzer", "ru": "russian_analyzer"} {
+			if got := subfields[lang].(map[string]any)["analyzer"]; got != analyzer {
+				t.Errorf("expected %s.%s analyzed by %s, got %v", field, lang, analyzer, got)
+			}
+		}
+	}
+	if got := properties["lang"].(map[string]any)["type"]; got != "keyword" {
+		t.Errorf("expected lang to be a keyword, got %v", got)
+	}
+	analyzers := indexMapping["settings"].(map[string]any)["analysis"].(map[string]any)["analyzer"].(map[string]any)
+	if _, ok := analyzers["russian_analyzer"]; !ok {
+		t.Error("expected a russian_analyzer")
+	}
+}
+
+func TestIndexMapping_SubjectsText(t *testing.T) {
+	properties := indexMapping["mappings"].(map[string]any)["properties"].(map[string]any)
+	subjects := properties["subjects"].(map[string]any)
+
+	if subjects["type"] != "keyword" {
+		t.Errorf("expected subjects to stay a keyword for filters, got %v", subjects["type"])
+	}
+	text := subjects["fields"].(map[string]any)["text"].(map[string]any)
+	if text["type"] != "text" || text["analyzer"] != "english_analyzer" {
+		t.Errorf("expected subjects.text analyzed by english_analyzer, got %v", text)
+	}
+}
+
+func TestIndexMapping_DateFormats(t *testing.T) {
+	properties := indexMapping["mappings"].(map[string]any)["properties"].(map[string]any)
+
+	for _, field := range []string{"created_at", "updated_at", "indexed_at", "next_available_at"} {
+		if got := properties[field].(map[string]any)["format"]; got != dateFormat {
+			t.Errorf("expected %s to have format %s, got %v", field, dateFormat, got)
+		}
+	}
+}
+
 func TestIndexName(t *testing.T) {
 	if IndexName != "tutors" {
 		t.Errorf("expected index name 'tutors', got %s", IndexName)
 	}
 }
+
+func TestIndexFor(t *testing.T) {
+	if got := IndexFor(context.Background()); got != IndexName {
+		t.Errorf("expected %s without a tenant, got %s", IndexName, got)
+	}
+	ctx := tenant.NewContext(context.Background(), tenant.Tenant{Name: "de", Index: "tutors-de"})
+	if got := IndexFor(ctx); got != "tutors-de" {
+		t.Errorf("expected tutors-de, got %s", got)
+	}
+}
+
+func TestEnsureIndices_CreatesMissingTenantIndices(t *testing.T) {
+	var created []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodHead && r.URL.Path == "/tutors-us":
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodHead:
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == http.MethodPut:
+			created = append(created, r.URL.Path)
+			writeJSON(w, http.StatusOK, `{"acknowledged":true}`)
+		}
+	})
+
+	tenants := []tenant.Tenant{{Name: "us", Index: "tutors-us"}, {Name: "de", Index: "tutors-de"}, {Name: "fr", Index: "tutors-fr"}}
+	if err := EnsureIndices(context.Background(), client, tenants); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"/tutors-de", "/tutors-fr"}; !slices.Equal(created, want) {
+		t.Errorf("expected created %v, got %v", want, created)
+	}
+}
+
+func TestEnsureIndices_ReportsFailingTenant(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		writeJSON(w, http.StatusBadRequest, `{"error":{"type":"invalid_index_name_exception","reason":"bad"},"status":400}`)
+	})
+
+	err := EnsureIndices(context.Background(), client, []tenant.Tenant{{Name: "de", Index: "tutors-de"}})
+	if err == nil || !strings.Contains(err.Error(), "tenant de") {
+		t.Errorf("expected error naming tenant de, got %v", err)
+	}
+}
+
+func TestRecreateIndex(t *testing.T) {
+	tests := []struct {
+		name         string
+		exists       bool
+		wantRequests []string
+		wantOld      int64
+	}{
+		{
+			name:   "existing index is deleted and recreated",
+			exists: true,
+			wantRequests: []string{
+				"HEAD /tutors",
+				"POST /tutors/_count",
+				"DELETE /tutors",
+				"PUT /tutors",
+				"POST /tutors/_count",
+			},
+			wantOld: 5,
+		},
+		{
+			name:   "missing index is just created",
+			exists: false,
+			wantRequests: []string{
+				"HEAD /tutors",
+				"PUT /tutors",
+				"POST /tutors/_count",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			deleted := false
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				switch {
+				case r.Method == http.MethodHead && !tt.exists:
+					w.WriteHeader(http.StatusNotFound)
+				case r.Method == http.MethodHead:
+					w.WriteHeader(http.StatusOK)
+				case r.Method == http.MethodDelete:
+					deleted = true
+					writeJSON(w, http.StatusOK, `{"acknowledged":true}`)
+				case r.Method == http.MethodPut:
+					writeJSON(w, http.StatusOK, `{"acknowledged":true,"index":"tutors"}`)
+				case strings.HasSuffix(r.URL.Path, "/_count") && tt.exists && !deleted:
+					writeJSON(w, http.StatusOK, `{"count":5}`)
+				default:
+					writeJSON(w, http.StatusOK, `{"count":0}`)
+				}
+			})
+
+			result, err := client.RecreateIndex(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !slices.Equal(requests, tt.wantRequests) {
+				t.Errorf("expected requests %v, got %v", tt.wantRequests, requests)
+			}
+			if result.OldCount != tt.wantOld || result.NewCount != 0 {
+				t.Errorf("expected counts %d/0, got %d/%d", tt.wantOld, result.OldCount, result.NewCount)
+			}
+		})
+	}
+}
+
+func TestRecreateIndex_DeleteFailureStopsBeforeCreate(t *testing.T) {
+	created := false
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodHead:
+			w.WriteHeader(http.StatusOK)
+		case http.MethodDelete:
+			writeJSON(w, http.StatusInternalServerError, `{"error":{"type":"exception","reason":"boom"},"status":500}`)
+		case http.MethodPut:
+			created = true
+			writeJSON(w, http.StatusOK, `{"acknowledged":true}`)
+		default:
+			writeJSON(w, http.StatusOK, `{"count":1}`)
+		}
+	})
+
+	if _, err := client.RecreateIndex(context.Background()); err == nil {
+		t.Error("expected error when delete fails")
+	}
+	if created {
+		t.Error("expected index not to be created after a failed delete")
+	}
+}
+
+func TestIndexBody_AppliesSettings(t *testing.T) {
+	body := indexBody(IndexSettings{Shards: 3, Replicas: 2})
+
+	settings := body["settings"].(map[string]any)
+	if settings["number_of_shards"] != 3 || settings["number_of_replicas"] != 2 {
+		t.Errorf("expected 3 shards and 2 replicas, got %v and %v", settings["number_of_shards"], settings["number_of_replicas"])
+	}
+	if _, ok := settings["analysis"]; !ok {
+		t.Error("expected the analysis settings to be kept")
+	}
+	if _, ok := body["mappings"]; !ok {
+		t.Error("expected the mappings to be kept")
+	}
+
+	defaults := indexMapping["settings"].(map[string]any)
+	if defaults["number_of_shards"] != 1 || defaults["number_of_replicas"] != 0 {
+		t.Errorf("expected indexMapping to be left unchanged, got %v", defaults)
+	}
+}
+
+func TestCreateIndex_SendsConfiguredSettings(t *testing.T) {
+	var body struct {
+		Settings struct {
+			Shards   int `json:"number_of_shards"`
+			Replicas int `json:"number_of_replicas"`
+		} `json:"settings"`
+	}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusOK, `{"acknowledged":true,"index":"tutors"}`)
+	})
+	WithIndexSettings(IndexSettings{Shards: 2, Replicas: 1})(client)
+
+	if err := client.EnsureIndex(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body.Settings.Shards != 2 || body.Settings.Replicas != 1 {
+		t.Errorf("expected 2 shards and 1 replica, got %+v", body.Settings)
+	}
+}
+
+func TestUpdateIndexSettings(t *testing.T) {
+	var body map[string]map[string]int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/tutors-de/_settings" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusOK, `{"acknowledged":true}`)
+	})
+
+	ctx := tenant.NewContext(context.Background(), tenant.Tenant{Name: "de", Index: "tutors-de"})
+	if err := client.UpdateIndexSettings(ctx, 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body["index"]["number_of_replicas"] != 2 {
+		t.Errorf("expected number_of_replicas 2, got %v", body)
+	}
+}
+
+func TestUpdateIndexSettings_Error(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, `{"error":{"type":"index_not_found_exception","reason":"no such index"},"status":404}`)
+	})
+
+	if err := client.UpdateIndexSettings(context.Background(), 1); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestMemoryClient_UpdateIndexSettingsUnsupported(t *testing.T) {
+	if err := NewMemoryClient().UpdateIndexSettings(context.Background(), 1); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported, got %v", err)
+	}
+}
+
+func TestIndexMapping_DynamicStrict(t *testing.T) {
+	if got := indexMapping["mappings"].(map[string]any)["dynamic"]; got != "strict" {
+		t.Errorf("expected dynamic mapping to be strict, got %v", got)
+	}
+	if got := indexBody(port.DefaultIndexSettings)["mappings"].(map[string]any)["dynamic"]; got != "strict" {
+		t.Errorf("expected the created index to keep the strict policy, got %v", got)
+	}
+}
+
+// TestIndexMapping_CoversDocument guards the strict policy: a field written
+// by newTutorDocument but missing from the mapping would reject every
+// write.
+func TestIndexMapping_CoversDocument(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	snapshotID := "snap-1"
+	tutor := &domain.Tutor{
+		ID:              1,
+		FullName:        "Ada Lovelace",
+		Bio:             "Mathematician.",
+		Subjects:        []string{"math"},
+		SubjectsDisplay: []string{"Math"},
+		Formats:         []string{"online"},
+		Levels:          []string{"school"},
+		Education:       []domain.Education{{Institution: "Cambridge", Degree: "BA", Year: 1835}},
+		Certifications:  []string{"CELTA"},
+		Badges:          []string{"featured"},
+		Timezone:        "Europe/London",
+		WorkingHours:    []domain.WorkingHours{{Start: "09:00", End: "17:00"}},
+		IndexedAt:       &now,
+		NextAvailableAt: &now,
+		VerifiedAt:      &now,
+		Popularity:      1,
+	}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+	raw, err := json.Marshal(client.newTutorDocument(tutor, &snapshotID))
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var doc map[string]any
+	json.Unmarshal(raw, &doc)
+
+	var check func(path string, doc map[string]any, properties map[string]any)
+	check = func(path string, doc map[string]any, properties map[string]any) {
+		for name, value := range doc {
+			field, ok := properties[name].(map[string]any)
+			if !ok {
+				t.Errorf("document field %s%s is not mapped", path, name)
+				continue
+			}
+			nested, _ := field["properties"].(map[string]any)
+			if nested == nil {
+				continue
+			}
+			items, _ := value.([]any)
+			for _, item := range items {
+				if obj, ok := item.(map[string]any); ok {
+					check(path+name+".", obj, nested)
+				}
+			}
+		}
+	}
+	check("", doc, indexMapping["mappings"].(map[string]any)["properties"].(map[string]any))
+}
@@ -3,13 +3,75 @@ package opensearch
 import (
 	"context"
 
-	"search/internal/domain"
+	"search/internal/port"
 )
 
-type SearchClient interface {
-	Ping(ctx context.Context) error
-	EnsureIndex(ctx context.Context) error
-	UpsertTutor(ctx context.Context, tutor *domain.Tutor) error
-	DeleteTutor(ctx context.Context, id int64) error
-	SearchTutors(ctx context.Context, query SearchQuery) (*SearchResponse, error)
+// The search contract lives in internal/port. These aliases keep the
+// package's own code and tests terse.
+type (
+	SearchClient      = port.SearchClient
+	SearchQuery       = port.SearchQuery
+	SearchResponse    = port.SearchResponse
+	BulkDeleteResult  = port.BulkDeleteResult
+	BulkResult        = port.BulkResult
+	BulkItem          = port.BulkItem
+	RecreateResult    = port.RecreateResult
+	PopularityResult  = port.PopularityResult
+	IndexSettings     = port.IndexSettings
+	FacetCounts       = port.FacetCounts
+	PriceStats        = port.PriceStats
+	RelatedSubjects   = port.RelatedSubjects
+	SubjectCount      = port.SubjectCount
+	QuickSearchResult = port.QuickSearchResult
+	QualityRuleResult = port.QualityRuleResult
+	ExperimentMapping = port.ExperimentMapping
+	ExperimentIndex   = port.ExperimentIndex
+	IndexAliases      = port.IndexAliases
+	AliasTarget       = port.AliasTarget
+	TutorVersion      = port.TutorVersion
+	Lock              = port.Lock
+)
+
+var (
+	ErrNotFound     = port.ErrNotFound
+	ErrInvalidQuery = port.ErrInvalidQuery
+	ErrUnsupported  = port.ErrUnsupported
+
+	ErrDocumentRejected = port.ErrDocumentRejected
+	ErrLockConflict     = port.ErrLockConflict
+
+	ErrExperimentRunning = port.ErrExperimentRunning
+	ErrNoExperiment      = port.ErrNoExperiment
+	ErrIndexNotFound     = port.ErrIndexNotFound
+)
+
+const (
+	IndexName    = port.IndexName
+	BulkDeleted  = port.BulkDeleted
+	BulkNotFound = port.BulkNotFound
+	BulkError    = port.BulkError
+
+	SortRelevance  = port.SortRelevance
+	SortRating     = port.SortRating
+	SortPopularity = port.SortPopularity
+
+	DiversifyLocation   = port.DiversifyLocation
+	DiversifyAlternates = port.DiversifyAlternates
+
+	IndexVariant          = port.IndexVariant
+	ExperimentIndexSuffix = port.ExperimentIndexSuffix
+	ReadAliasSuffix       = port.ReadAliasSuffix
+	WriteAliasSuffix      = port.WriteAliasSuffix
+)
+
+var (
+	_ port.SearchClient = (*Client)(nil)
+	_ port.SearchClient = (*MemoryClient)(nil)
+	_ port.LockStore    = (*Client)(nil)
+	_ port.LockStore    = (*MemoryClient)(nil)
+)
+
+// IndexFor returns the index that operations on ctx target.
+func IndexFor(ctx context.Context) string {
+	return port.IndexFor(ctx)
 }
@@ -0,0 +1,224 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/opensearch-project/opensearch-go/v4"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	"search/internal/port"
+)
+
+// LockIndexName is the control index holding one document per Lock, keyed
+// by the lock name. It is shared by all tenants.
+const LockIndexName = "search-locks"
+
+// lockIndexBody creates the control index: a single shard, as it only ever
+// holds a handful of documents, with the tutor index's replica count.
+func lockIndexBody(replicas int) map[string]any {
+	return map[string]any{
+		"settings": map[string]any{
+			"number_of_shards":   1,
+			"number_of_replicas": replicas,
+		},
+		"mappings": map[string]any{
+			"dynamic": "strict",
+			"properties": map[string]any{
+				"name":        map[string]any{"type": "keyword"},
+				"holder":      map[string]any{"type": "keyword"},
+				"acquired_at": map[string]any{"type": "date", "format": dateFormat},
+				"expires_at":  map[string]any{"type": "date", "format": dateFormat},
+			},
+		},
+	}
+}
+
+// CreateLock stores l with op_type=create, so only one of several
+// concurrent callers succeeds. The control index is created on first use.
+func (c *Client) CreateLock(ctx context.Context, l Lock) (Lock, error) {
+	if err := c.ensureLockIndex(ctx); err != nil {
+		return Lock{}, err
+	}
+	return c.writeLock(ctx, l, opensearchapi.IndexParams{OpType: "create"})
+}
+
+// GetLock reads the named lock. A missing control index means no lock has
+// been taken yet.
+func (c *Client) GetLock(ctx context.Context, name string) (Lock, bool, error) {
+	resp, err := c.client.Document.Get(ctx, opensearchapi.DocumentGetReq{
+		Index:      LockIndexName,
+		DocumentID: name,
+	})
+	if err != nil {
+		if isDocumentNotFound(err) || isIndexNotFound(err) {
+			return Lock{}, false, nil
+		}
+		return Lock{}, false, fmt.Errorf("failed to get lock: %w", err)
+	}
+	if !resp.Found {
+		return Lock{}, false, nil
+	}
+
+	var l Lock
+	if err := json.Unmarshal(resp.Source, &l); err != nil {
+		return Lock{}, false, fmt.Errorf("failed to unmarshal lock: %w", err)
+	}
+	l.Version = port.LockVersion{SeqNo: resp.SeqNo, PrimaryTerm: resp.PrimaryTerm}
+	return l, true, nil
+}
+
+// ReplaceLock overwrites the lock with if_seq_no and if_primary_term set to
+// l.Version.
+func (c *Client) ReplaceLock(ctx context.Context, l Lock) (Lock, error) {
+	return c.writeLock(ctx, l, opensearchapi.IndexParams{
+		IfSeqNo:       &l.Version.SeqNo,
+		IfPrimaryTerm: &l.Version.PrimaryTerm,
+	})
+}
+
+// DeleteLock deletes the lock with if_seq_no and if_primary_term set to
+// l.Version.
+func (c *Client) DeleteLock(ctx context.Context, l Lock) error {
+	_, err := c.client.Document.Delete(ctx, opensearchapi.DocumentDeleteReq{
+		Index:      LockIndexName,
+		DocumentID: l.Name,
+		Params: opensearchapi.DocumentDeleteParams{
+			IfSeqNo:       &l.Version.SeqNo,
+			IfPrimaryTerm: &l.Version.PrimaryTerm,
+		},
+	})
+	if err != nil {
+		if isVersionConflict(err) || isDocumentNotFound(err) {
+			return ErrLockConflict
+		}
+		return fmt.Errorf("failed to delete lock: %w", err)
+	}
+	return nil
+}
+
+func (c *Client) writeLock(ctx context.Context, l Lock, params opensearchapi.IndexParams) (Lock, error) {
+	body, err := json.Marshal(l)
+	if err != nil {
+		return Lock{}, fmt.Errorf("failed to marshal lock: %w", err)
+	}
+
+	resp, err := c.client.Index(ctx, opensearchapi.IndexReq{
+		Index:      LockIndexName,
+		DocumentID: l.Name,
+		Body:       bytes.NewReader(body),
+		Params:     params,
+	})
+	if err != nil {
+		if isVersionConflict(err) || isDocumentNotFound(err) {
+			return Lock{}, ErrLockConflict
+		}
+		return Lock{}, fmt.Errorf("failed to write lock: %w", err)
+	}
+	l.Version = port.LockVersion{SeqNo: resp.SeqNo, PrimaryTerm: resp.PrimaryTerm}
+	return l, nil
+}
+
+// ensureLockIndex creates the control index unless an earlier call did. An
+// index created concurrently by another replica counts as success.
+func (c *Client) ensureLockIndex(ctx context.Context) error {
+	if c.lockIndexReady.Load() {
+		return nil
+	}
+
+	body, err := json.Marshal(lockIndexBody(c.settings.Replicas))
+	if err != nil {
+		return fmt.Errorf("failed to marshal lock index: %w", err)
+	}
+	_, err = c.client.Indices.Create(ctx, opensearchapi.IndicesCreateReq{
+		Index: LockIndexName,
+		Body:  bytes.NewReader(body),
+	})
+	if err != nil && !isIndexExists(err) {
+		return fmt.Errorf("failed to create lock index: %w", err)
+	}
+	if err == nil {
+		c.logger.Info("Lock index created", "index", LockIndexName)
+	}
+	c.lockIndexReady.Store(true)
+	return nil
+}
+
+// isVersionConflict reports whether err is OpenSearch's 409 for a create of
+// an existing document or a write with a stale if_seq_no.
+func isVersionConflict(err error) bool {
+	var se *opensearch.StructError
+	return errors.As(err, &se) && se.Status == http.StatusConflict
+}
+
+// isIndexNotFound reports whether err is OpenSearch's 404 for a request
+// against an index that does not exist.
+func isIndexNotFound(err error) bool {
+	var se *opensearch.StructError
+	return errors.As(err, &se) && se.Status == http.StatusNotFound && se.Err.Type == "index_not_found_exception"
+}
+
+// isIndexExists reports whether err is OpenSearch's refusal to create an
+// index that already exists.
+func isIndexExists(err error) bool {
+	var se *opensearch.StructError
+	return errors.As(err, &se) && se.Err.Type == "resource_already_exists_exception"
+}
+
+// CreateLock stores l unless a lock with its name exists.
+func (m *MemoryClient) CreateLock(ctx context.Context, l Lock) (Lock, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.locks[l.Name]; ok {
+		return Lock{}, ErrLockConflict
+	}
+	return m.storeLock(l), nil
+}
+
+// GetLock returns the named lock.
+func (m *MemoryClient) GetLock(ctx context.Context, name string) (Lock, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	l, ok := m.locks[name]
+	return l, ok, nil
+}
+
+// ReplaceLock overwrites the lock if it is still at l.Version.
+func (m *MemoryClient) ReplaceLock(ctx context.Context, l Lock) (Lock, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if cur, ok := m.locks[l.Name]; !ok || cur.Version != l.Version {
+		return Lock{}, ErrLockConflict
+	}
+	return m.storeLock(l), nil
+}
+
+// DeleteLock removes the lock if it is still at l.Version.
+func (m *MemoryClient) DeleteLock(ctx context.Context, l Lock) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if cur, ok := m.locks[l.Name]; !ok || cur.Version != l.Version {
+		return ErrLockConflict
+	}
+	delete(m.locks, l.Name)
+	return nil
+}
+
+// storeLock saves l under the next sequence number, the way OpenSearch
+// numbers writes to a shard. Callers hold m.mu for writing.
+func (m *MemoryClient) storeLock(l Lock) Lock {
+	m.lockSeq++
+	l.Version = port.LockVersion{SeqNo: m.lockSeq, PrimaryTerm: 1}
+	l.AcquiredAt = l.AcquiredAt.UTC()
+	l.ExpiresAt = l.ExpiresAt.UTC()
+	m.locks[l.Name] = l
+	return l
+}
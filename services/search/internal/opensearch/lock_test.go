@@ -0,0 +1,207 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"search/internal/port"
+)
+
+func TestCreateLock(t *testing.T) {
+	var indexCreates atomic.Int32
+	var gotOpType string
+	var gotBody Lock
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/"+LockIndexName:
+			indexCreates.Add(1)
+			var body map[string]any
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["mappings"].(map[string]any)["dynamic"] != "strict" {
+				t.Errorf("lock index mapping = %v, want dynamic strict", body["mappings"])
+			}
+			writeJSON(w, http.StatusOK, `{"acknowledged":true}`)
+		case r.Method == http.MethodPut && r.URL.Path == "/"+LockIndexName+"/_doc/jobs":
+			gotOpType = r.URL.Query().Get("op_type")
+			json.NewDecoder(r.Body).Decode(&gotBody)
+			writeJSON(w, http.StatusCreated, `{"_id":"jobs","result":"created","_seq_no":4,"_primary_term":2}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	at := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	want := Lock{Name: "jobs", Holder: "pod-a", AcquiredAt: at, ExpiresAt: at.Add(30 * time.Second)}
+	for range 2 {
+		got, err := client.CreateLock(context.Background(), want)
+		if err != nil {
+			t.Fatalf("CreateLock: %v", err)
+		}
+		if got.Version != (port.LockVersion{SeqNo: 4, PrimaryTerm: 2}) {
+			t.Errorf("version = %+v, want seq_no 4 and primary_term 2", got.Version)
+		}
+	}
+	if gotOpType != "create" {
+		t.Errorf("op_type = %q, want create", gotOpType)
+	}
+	if gotBody.Holder != "pod-a" || !gotBody.ExpiresAt.Equal(want.ExpiresAt) {
+		t.Errorf("stored lock = %+v, want %+v", gotBody, want)
+	}
+	if n := indexCreates.Load(); n != 1 {
+		t.Errorf("lock index created %d times, want once", n)
+	}
+}
+
+func TestCreateLock_IndexAlreadyExists(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/"+LockIndexName {
+			writeJSON(w, http.StatusBadRequest, `{"error":{"type":"resource_already_exists_exception","reason":"index [search-locks] already exists"},"status":400}`)
+			return
+		}
+		writeJSON(w, http.StatusCreated, `{"_id":"jobs","result":"created","_seq_no":0,"_primary_term":1}`)
+	})
+
+	if _, err := client.CreateLock(context.Background(), Lock{Name: "jobs", Holder: "pod-a"}); err != nil {
+		t.Errorf("CreateLock: %v, want an existing lock index to be accepted", err)
+	}
+}
+
+func TestCreateLock_Conflict(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/"+LockIndexName {
+			writeJSON(w, http.StatusOK, `{"acknowledged":true}`)
+			return
+		}
+		writeJSON(w, http.StatusConflict, `{"error":{"type":"version_conflict_engine_exception","reason":"[jobs]: version conflict, document already exists"},"status":409}`)
+	})
+
+	_, err := client.CreateLock(context.Background(), Lock{Name: "jobs", Holder: "pod-b"})
+	if !errors.Is(err, ErrLockConflict) {
+		t.Errorf("err = %v, want ErrLockConflict", err)
+	}
+}
+
+func TestGetLock(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		wantOK bool
+	}{
+		{
+			name:   "found",
+			status: http.StatusOK,
+			body:   `{"_id":"jobs","found":true,"_seq_no":7,"_primary_term":1,"_source":{"name":"jobs","holder":"pod-a","acquired_at":"2026-10-17T12:00:00Z","expires_at":"2026-10-17T12:00:30Z"}}`,
+			wantOK: true,
+		},
+		{
+			name:   "missing document",
+			status: http.StatusNotFound,
+			body:   `{"_id":"jobs","found":false}`,
+		},
+		{
+			name:   "missing index",
+			status: http.StatusNotFound,
+			body:   `{"error":{"type":"index_not_found_exception","reason":"no such index [search-locks]"},"status":404}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/"+LockIndexName+"/_doc/jobs" {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				writeJSON(w, tt.status, tt.body)
+			})
+
+			got, ok, err := client.GetLock(context.Background(), "jobs")
+			if err != nil {
+				t.Fatalf("GetLock: %v", err)
+			}
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && (got.Holder != "pod-a" || got.Version.SeqNo != 7) {
+				t.Errorf("lock = %+v, want pod-a at seq_no 7", got)
+			}
+		})
+	}
+}
+
+func TestReplaceLock_Conditional(t *testing.T) {
+	var query string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		if r.URL.Query().Get("if_seq_no") != "7" {
+			writeJSON(w, http.StatusConflict, `{"error":{"type":"version_conflict_engine_exception","reason":"required seqNo [7]"},"status":409}`)
+			return
+		}
+		writeJSON(w, http.StatusOK, `{"_id":"jobs","result":"updated","_seq_no":8,"_primary_term":1}`)
+	})
+
+	l := Lock{Name: "jobs", Holder: "pod-a", Version: port.LockVersion{SeqNo: 7, PrimaryTerm: 1}}
+	got, err := client.ReplaceLock(context.Background(), l)
+	if err != nil {
+		t.Fatalf("ReplaceLock: %v", err)
+	}
+	if got.Version.SeqNo != 8 {
+		t.Errorf("seq_no = %d, want 8", got.Version.SeqNo)
+	}
+	if query != "if_primary_term=1&if_seq_no=7" {
+		t.Errorf("query = %q, want if_primary_term and if_seq_no", query)
+	}
+
+	l.Version.SeqNo = 6
+	if _, err := client.ReplaceLock(context.Background(), l); !errors.Is(err, ErrLockConflict) {
+		t.Errorf("stale ReplaceLock err = %v, want ErrLockConflict", err)
+	}
+}
+
+func TestDeleteLock_Conflict(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		writeJSON(w, http.StatusConflict, `{"error":{"type":"version_conflict_engine_exception","reason":"required seqNo [7]"},"status":409}`)
+	})
+
+	err := client.DeleteLock(context.Background(), Lock{Name: "jobs", Version: port.LockVersion{SeqNo: 7, PrimaryTerm: 1}})
+	if !errors.Is(err, ErrLockConflict) {
+		t.Errorf("err = %v, want ErrLockConflict", err)
+	}
+}
+
+func TestMemoryClient_Locks(t *testing.T) {
+	m := NewMemoryClient()
+	ctx := context.Background()
+
+	created, err := m.CreateLock(ctx, Lock{Name: "jobs", Holder: "pod-a"})
+	if err != nil {
+		t.Fatalf("CreateLock: %v", err)
+	}
+	if _, err := m.CreateLock(ctx, Lock{Name: "jobs", Holder: "pod-b"}); !errors.Is(err, ErrLockConflict) {
+		t.Errorf("second CreateLock err = %v, want ErrLockConflict", err)
+	}
+
+	replaced, err := m.ReplaceLock(ctx, created)
+	if err != nil {
+		t.Fatalf("ReplaceLock: %v", err)
+	}
+	if _, err := m.ReplaceLock(ctx, created); !errors.Is(err, ErrLockConflict) {
+		t.Errorf("stale ReplaceLock err = %v, want ErrLockConflict", err)
+	}
+	if err := m.DeleteLock(ctx, created); !errors.Is(err, ErrLockConflict) {
+		t.Errorf("stale DeleteLock err = %v, want ErrLockConflict", err)
+	}
+	if err := m.DeleteLock(ctx, replaced); err != nil {
+		t.Fatalf("DeleteLock: %v", err)
+	}
+	if _, ok, _ := m.GetLock(ctx, "jobs"); ok {
+		t.Error("lock still present after DeleteLock")
+	}
+}
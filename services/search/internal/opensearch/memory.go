@@ -0,0 +1,476 @@
+package opensearch
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"search/internal/domain"
+)
+
+// MemoryClient is a SearchClient that keeps tutors in a map per index, so
+// tenants stay isolated as they do in OpenSearch. It approximates
+// the OpenSearch query closely enough for local development, demos and
+// tests: text matching is a case-insensitive substring match rather than
+// analyzed full-text search, but every filter and the paging rules are the
+// same.
+type MemoryClient struct {
+	mu      sync.RWMutex
+	indices map[string]map[int64]domain.Tutor
+	// snapshotted marks documents last written by UpsertSnapshotTutor.
+	snapshotted map[snapshotKey]bool
+	popularity  domain.PopularityWeights
+	// locks holds the LockStore documents; lockSeq numbers their writes.
+	locks   map[string]Lock
+	lockSeq int
+}
+
+type snapshotKey struct {
+	index string
+	id    int64
+}
+
+// MemoryOption configures a MemoryClient.
+type MemoryOption func(*MemoryClient)
+
+// WithMemoryPopularityWeights scores tutor popularity with w instead of
+// domain.DefaultPopularityWeights. Unlike WithPopularityWeights it does
+// not validate w.
+func WithMemoryPopularityWeights(w domain.PopularityWeights) MemoryOption {
+	return func(m *MemoryClient) {
+		m.popularity = w
+	}
+}
+
+// NewMemoryClient returns an empty MemoryClient.
+func NewMemoryClient(opts ...MemoryOption) *MemoryClient {
+	m := &MemoryClient{
+		indices:     make(map[string]map[int64]domain.Tutor),
+		snapshotted: make(map[snapshotKey]bool),
+		popularity:  domain.DefaultPopularityWeights,
+		locks:       make(map[string]Lock),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// tutors returns the map for ctx's index, creating it when it does not
+// exist yet. Callers hold m.mu for writing.
+func (m *MemoryClient) tutors(ctx context.Context) map[int64]domain.Tutor {
+	name := IndexFor(ctx)
+	tutors, ok := m.indices[name]
+	if !ok {
+		tutors = make(map[int64]domain.Tutor)
+		m.indices[name] = tutors
+	}
+	return tutors
+}
+
+func (m *MemoryClient) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (m *MemoryClient) EnsureIndex(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tutors(ctx)
+	return nil
+}
+
+func (m *MemoryClient) UpsertTutor(ctx context.Context, tutor *domain.Tutor) error {
+	t := *tutor
+	t.Subjects = slices.Clone(t.Subjects)
+	t.SubjectsDisplay = slices.Clone(t.SubjectsDisplay)
+	t.Formats = slices.Clone(t.Formats)
+	t.Levels = slices.Clone(t.Levels)
+	t.Education = slices.Clone(t.Education)
+	t.Certifications = slices.Clone(t.Certifications)
+	t.Badges = slices.Clone(t.Badges)
+	t.PreviousSlugs = slices.Clone(t.PreviousSlugs)
+	t.WorkingHours = slices.Clone(t.WorkingHours)
+	t.SetBioSnippet()
+	t.SetPopularity(m.popularity, time.Now())
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tutors := m.tutors(ctx)
+	// Like the partial update OpenSearch does, keep availability and
+	// verified_at that events maintain and badges and slug history the
+	// update does not send.
+	if prev, ok := tutors[t.ID]; ok {
+		if t.NextAvailableAt == nil {
+			t.NextAvailableAt = prev.NextAvailableAt
+		}
+		if t.VerifiedAt == nil {
+			t.VerifiedAt = prev.VerifiedAt
+		}
+		if len(t.Badges) == 0 {
+			t.Badges = prev.Badges
+		}
+		if len(t.PreviousSlugs) == 0 {
+			t.PreviousSlugs = prev.PreviousSlugs
+		}
+	}
+	tutors[t.ID] = t
+	delete(m.snapshotted, snapshotKey{index: IndexFor(ctx), id: t.ID})
+	return nil
+}
+
+func (m *MemoryClient) DeleteTutor(ctx context.Context, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tutors := m.tutors(ctx)
+	if _, ok := tutors[id]; !ok {
+		return ErrNotFound
+	}
+	delete(tutors, id)
+	delete(m.snapshotted, snapshotKey{index: IndexFor(ctx), id: id})
+	return nil
+}
+
+func (m *MemoryClient) BulkDeleteTutors(ctx context.Context, ids []int64) ([]BulkDeleteResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tutors := m.tutors(ctx)
+	results := make([]BulkDeleteResult, len(ids))
+	for i, id := range ids {
+		results[i] = BulkDeleteResult{ID: id, Status: BulkDeleted}
+		if _, ok := tutors[id]; !ok {
+			results[i].Status = BulkNotFound
+		}
+		delete(tutors, id)
+		delete(m.snapshotted, snapshotKey{index: IndexFor(ctx), id: id})
+	}
+	return results, nil
+}
+
+// BulkUpsertTutors upserts each tutor in turn; the in-memory backend
+// refuses none of them.
+func (m *MemoryClient) BulkUpsertTutors(ctx context.Context, tutors []*domain.Tutor) (*BulkResult, error) {
+	result := &BulkResult{Items: make([]BulkItem, len(tutors))}
+	for i, tutor := range tutors {
+		result.Items[i] = BulkItem{ID: tutor.ID, Err: m.UpsertTutor(ctx, tutor)}
+	}
+	return result, nil
+}
+
+func (m *MemoryClient) GetTutor(ctx context.Context, id int64) (*domain.Tutor, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	t, ok := m.indices[IndexFor(ctx)][id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	t.Subjects = slices.Clone(t.Subjects)
+	t.SubjectsDisplay = slices.Clone(t.SubjectsDisplay)
+	t.Formats = slices.Clone(t.Formats)
+	t.Levels = slices.Clone(t.Levels)
+	t.Education = slices.Clone(t.Education)
+	t.Certifications = slices.Clone(t.Certifications)
+	t.Badges = slices.Clone(t.Badges)
+	t.PreviousSlugs = slices.Clone(t.PreviousSlugs)
+	t.WorkingHours = slices.Clone(t.WorkingHours)
+	return &t, nil
+}
+
+func (m *MemoryClient) GetTutors(ctx context.Context, ids []int64) (map[int64]domain.Tutor, error) {
+	tutors := make(map[int64]domain.Tutor, len(ids))
+	for _, id := range ids {
+		if t, err := m.GetTutor(ctx, id); err == nil {
+			tutors[id] = *t
+		}
+	}
+	return tutors, nil
+}
+
+// SearchTutors filters and ranks tutors the way buildSearchQuery does. With
+// text, results are ordered by a field-weighted match score (headline counts
+// double); otherwise, and to break ties, by ID. Experiment variants do not
+// change the ranking, and IndexVariant is served without a variant as no
+// index experiment can run. DiversifyBy keeps the best tutor per location,
+// the way collapse does, and pages over those.
+func (m *MemoryClient) SearchTutors(ctx context.Context, query SearchQuery) (*SearchResponse, error) {
+	if query.Variant == IndexVariant {
+		query.Variant = ""
+	}
+	hits := m.rank(ctx, query)
+	if !query.IncludeBio {
+		for i := range hits {
+			hits[i].Bio = ""
+		}
+	}
+	total := len(hits)
+
+	var alternates map[int64][]domain.Tutor
+	if query.DiversifyBy == DiversifyLocation {
+		hits, alternates = collapseByLocation(hits)
+	}
+
+	limit, offset := query.Page()
+	results := make([]domain.Tutor, 0, limit)
+	for i := offset; i < len(hits) && len(results) < limit; i++ {
+		results = append(results, hits[i])
+	}
+
+	resp := &SearchResponse{Results: results, Total: total, Variant: query.Variant}
+	if alternates != nil {
+		resp.Alternates = make(map[int64][]domain.Tutor)
+		for _, t := range results {
+			if alts := alternates[t.ID]; len(alts) > 0 {
+				resp.Alternates[t.ID] = alts
+			}
+		}
+	}
+	return resp, nil
+}
+
+// SearchTutorsMulti runs each query in turn.
+func (m *MemoryClient) SearchTutorsMulti(ctx context.Context, queries []SearchQuery) ([]*SearchResponse, error) {
+	results := make([]*SearchResponse, len(queries))
+	for i, query := range queries {
+		result, err := m.SearchTutors(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = result
+	}
+	return results, nil
+}
+
+// collapseByLocation keeps the first of hits per location, tutors without
+// one sharing a group, and returns up to DiversifyAlternates of the rest
+// of each group keyed by the kept tutor's ID.
+func collapseByLocation(hits []domain.Tutor) ([]domain.Tutor, map[int64][]domain.Tutor) {
+	top := make(map[string]int64)
+	alternates := make(map[int64][]domain.Tutor)
+	var kept []domain.Tutor
+	for _, t := range hits {
+		id, ok := top[t.Location]
+		if !ok {
+			top[t.Location] = t.ID
+			kept = append(kept, t)
+			continue
+		}
+		if len(alternates[id]) < DiversifyAlternates {
+			alternates[id] = append(alternates[id], t)
+		}
+	}
+	return kept, alternates
+}
+
+// ScanTutors calls fn for up to limit tutors in SearchTutors order, ignoring
+// query.Limit and query.Offset.
+func (m *MemoryClient) ScanTutors(ctx context.Context, query SearchQuery, limit int, fn func(domain.Tutor) error) error {
+	if limit <= 0 {
+		return nil
+	}
+	hits := m.rank(ctx, query)
+	for _, t := range hits[:min(limit, len(hits))] {
+		if err := fn(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// rank returns every tutor matching query, best match first.
+func (m *MemoryClient) rank(ctx context.Context, query SearchQuery) []domain.Tutor {
+	type scored struct {
+		tutor domain.Tutor
+		score int
+	}
+
+	m.mu.RLock()
+	var hits []scored
+	for _, t := range m.indices[IndexFor(ctx)] {
+		if !matchesFilters(t, query) {
+			continue
+		}
+		score := 0
+		if query.Text != "" {
+			if score = textScore(t, query.Text); score == 0 {
+				continue
+			}
+		}
+		hits = append(hits, scored{tutor: t, score: score})
+	}
+	m.mu.RUnlock()
+
+	slices.SortFunc(hits, func(a, b scored) int {
+		switch query.Sort {
+		case SortRating:
+			return compareByRating(a.tutor, b.tutor)
+		case SortPopularity:
+			return compareByPopularity(a.tutor, b.tutor)
+		}
+		if c := cmp.Compare(b.score, a.score); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.tutor.ID, b.tutor.ID)
+	})
+
+	tutors := make([]domain.Tutor, len(hits))
+	for i, h := range hits {
+		tutors[i] = h.tutor
+	}
+	return tutors
+}
+
+func (m *MemoryClient) IndexedTutorIDs(ctx context.Context) ([]int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	tutors := m.indices[IndexFor(ctx)]
+	ids := make([]int64, 0, len(tutors))
+	for id := range tutors {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+// ScanTutorVersions calls fn for every tutor of ctx's index in ID order.
+// It works on a copy, so fn may write to the client.
+func (m *MemoryClient) ScanTutorVersions(ctx context.Context, fn func(TutorVersion) error) error {
+	m.mu.RLock()
+	tutors := m.indices[IndexFor(ctx)]
+	versions := make([]TutorVersion, 0, len(tutors))
+	for id, t := range tutors {
+		versions = append(versions, TutorVersion{ID: id, UpdatedAt: t.UpdatedAt})
+	}
+	m.mu.RUnlock()
+
+	slices.SortFunc(versions, func(a, b TutorVersion) int { return cmp.Compare(a.ID, b.ID) })
+	for _, v := range versions {
+		if err := fn(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MemoryClient) CountTutors(ctx context.Context) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return int64(len(m.indices[IndexFor(ctx)])), nil
+}
+
+func (m *MemoryClient) RecreateIndex(ctx context.Context) (*RecreateResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	name := IndexFor(ctx)
+	result := &RecreateResult{OldCount: int64(len(m.indices[name]))}
+	m.indices[name] = make(map[int64]domain.Tutor)
+	for key := range m.snapshotted {
+		if key.index == name {
+			delete(m.snapshotted, key)
+		}
+	}
+	return result, nil
+}
+
+func matchesFilters(t domain.Tutor, query SearchQuery) bool {
+	if len(query.Subjects) > 0 && !slices.ContainsFunc(query.Subjects, func(s string) bool {
+		return slices.Contains(t.Subjects, s)
+	}) {
+		return false
+	}
+	rate := t.RateFor(query.Format)
+	if query.MinPrice != nil && rate < *query.MinPrice {
+		return false
+	}
+	if query.MaxPrice != nil && rate > *query.MaxPrice {
+		return false
+	}
+	if query.BelowPrice != nil && rate >= *query.BelowPrice {
+		return false
+	}
+	if query.MinRating != nil && t.Rating < *query.MinRating {
+		return false
+	}
+	if query.Format != "" && !slices.Contains(t.Formats, query.Format) {
+		return false
+	}
+	if len(query.Levels) > 0 && !slices.ContainsFunc(query.Levels, func(l string) bool {
+		return slices.Contains(t.Levels, l)
+	}) {
+		return false
+	}
+	if query.Location != "" && t.Location != query.Location {
+		return false
+	}
+	if query.Certification != "" && !slices.ContainsFunc(t.Certifications, func(c string) bool {
+		return strings.EqualFold(c, query.Certification)
+	}) {
+		return false
+	}
+	if query.Badge != "" && !slices.ContainsFunc(t.Badges, func(b string) bool {
+		return strings.EqualFold(b, query.Badge)
+	}) {
+		return false
+	}
+	if query.Trial && !t.OffersTrial {
+		return false
+	}
+	if query.AvailableWithinDays > 0 {
+		now := time.Now()
+		if t.NextAvailableAt == nil || t.NextAvailableAt.Before(now) ||
+			t.NextAvailableAt.After(now.AddDate(0, 0, query.AvailableWithinDays)) {
+			return false
+		}
+	}
+	if len(query.AvailableBetween) > 0 && !overlapsAny(t.AvailabilityUTC(time.Now()), query.AvailableBetween) {
+		return false
+	}
+	return !slices.Contains(query.ExcludeIDs, t.ID)
+}
+
+// overlapsAny reports whether any range of a overlaps any range of b.
+func overlapsAny(a, b []domain.MinuteRange) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x.Overlaps(y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// textScore mirrors the field boosts in buildSearchQuery, doubled so the
+// half-weight credential fields score whole numbers.
+func textScore(t domain.Tutor, text string) int {
+	text = strings.ToLower(text)
+	contains := func(s string) bool {
+		return strings.Contains(strings.ToLower(s), text)
+	}
+	score := 0
+	if contains(t.FullName) {
+		score += 2
+	}
+	if contains(t.Headline) {
+		score += 4
+	}
+	if contains(t.Bio) {
+		score += 2
+	}
+	if slices.ContainsFunc(t.Subjects, contains) {
+		score += 3
+	}
+	if slices.ContainsFunc(t.Education, func(e domain.Education) bool { return contains(e.Institution) }) {
+		score++
+	}
+	if slices.ContainsFunc(t.Certifications, contains) {
+		score++
+	}
+	return score
+}
@@ -0,0 +1,330 @@
+package opensearch
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"search/internal/domain"
+	"search/internal/port"
+	"search/internal/tenant"
+)
+
+func ptr(v float64) *float64 { return &v }
+
+func newFixtureMemoryClient(t *testing.T) *MemoryClient {
+	t.Helper()
+
+	m := NewMemoryClient()
+	fixtures := []domain.Tutor{
+		{ID: 1, FullName: "Marie Curie", Headline: "Physics and chemistry", Bio: "Nobel laureate", Subjects: []string{"physics", "chemistry"}, HourlyRate: 60, Rating: 5, Location: "Paris", Formats: []string{"offline"}, Levels: []string{"university"}},
+		{ID: 2, FullName: "Alan Turing", Headline: "Math and computing", Bio: "Enjoys physics puzzles", Subjects: []string{"math"}, HourlyRate: 35, Rating: 4.6, Location: "London", Formats: []string{"online"}, Levels: []string{"school", "adult"}},
+		{ID: 3, FullName: "Ada Lovelace", Headline: "Mathematics tutor", Bio: "First programmer", Subjects: []string{"math", "programming"}, HourlyRate: 45, Rating: 4.9, Location: "London", Formats: []string{"online", "offline"}, OffersTrial: true},
+		{ID: 4, FullName: "Richard Feynman", Headline: "Physics made fun", Bio: "Bongo player", Subjects: []string{"physics"}, HourlyRate: 80, Rating: 4.2, Location: "Pasadena", Formats: []string{"online"},
+			Education: []domain.Education{{Institution: "Princeton University", Degree: "PhD", Year: 1942}}, Certifications: []string{"CELTA"}},
+	}
+	for i := range fixtures {
+		if err := m.UpsertTutor(context.Background(), &fixtures[i]); err != nil {
+			t.Fatalf("failed to upsert fixture: %v", err)
+		}
+	}
+	return m
+}
+
+func TestMemoryClient_SearchTutors(t *testing.T) {
+	m := newFixtureMemoryClient(t)
+
+	tests := []struct {
+		name      string
+		query     SearchQuery
+		wantIDs   []int64
+		wantTotal int
+	}{
+		{"match all orders by id", SearchQuery{}, []int64{1, 2, 3, 4}, 4},
+		{"text is case insensitive", SearchQuery{Text: "MARIE"}, []int64{1}, 1},
+		{"text ranks headline above bio", SearchQuery{Text: "physics"}, []int64{1, 4, 2}, 3},
+		{"text prefix", SearchQuery{Text: "math"}, []int64{2, 3}, 2},
+		{"text without match", SearchQuery{Text: "biology"}, []int64{}, 0},
+		{"subjects match any", SearchQuery{Subjects: []string{"chemistry", "programming"}}, []int64{1, 3}, 2},
+		{"min price", SearchQuery{MinPrice: ptr(60)}, []int64{1, 4}, 2},
+		{"max price", SearchQuery{MaxPrice: ptr(45)}, []int64{2, 3}, 2},
+		{"price range", SearchQuery{MinPrice: ptr(40), MaxPrice: ptr(70)}, []int64{1, 3}, 2},
+		{"below price is exclusive", SearchQuery{BelowPrice: ptr(45)}, []int64{2}, 1},
+		{"min rating", SearchQuery{MinRating: ptr(4.8)}, []int64{1, 3}, 2},
+		{"format", SearchQuery{Format: "offline"}, []int64{1, 3}, 2},
+		{"location", SearchQuery{Location: "London"}, []int64{2, 3}, 2},
+		{"levels match any", SearchQuery{Levels: []string{"university", "adult"}}, []int64{1, 2}, 2},
+		{"level nobody teaches", SearchQuery{Levels: []string{"school"}, Location: "Paris"}, []int64{}, 0},
+		{"text matches institution", SearchQuery{Text: "princeton"}, []int64{4}, 1},
+		{"text matches certification", SearchQuery{Text: "celta"}, []int64{4}, 1},
+		{"text matches subject", SearchQuery{Text: "programming"}, []int64{3}, 1},
+		{"certification is case insensitive", SearchQuery{Certification: "celta"}, []int64{4}, 1},
+		{"unknown certification", SearchQuery{Certification: "DELTA"}, []int64{}, 0},
+		{"trial", SearchQuery{Trial: true}, []int64{3}, 1},
+		{"exclude ids", SearchQuery{ExcludeIDs: []int64{1, 3}}, []int64{2, 4}, 2},
+		{"sort by rating", SearchQuery{Sort: SortRating}, []int64{1, 3, 2, 4}, 4},
+		{"sort by rating ignores text score", SearchQuery{Text: "physics", Sort: SortRating}, []int64{1, 2, 4}, 3},
+		{"combined filters", SearchQuery{Text: "math", Format: "online", MinRating: ptr(4.7)}, []int64{3}, 1},
+		{"limit", SearchQuery{Limit: 2}, []int64{1, 2}, 4},
+		{"offset", SearchQuery{Limit: 2, Offset: 3}, []int64{4}, 4},
+		{"offset past end", SearchQuery{Offset: 10}, []int64{}, 4},
+		{"negative offset", SearchQuery{Offset: -5, Limit: 1}, []int64{1}, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := m.SearchTutors(context.Background(), tt.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			ids := make([]int64, len(resp.Results))
+			for i, tutor := range resp.Results {
+				ids[i] = tutor.ID
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("expected ids %v, got %v", tt.wantIDs, ids)
+			}
+			if resp.Total != tt.wantTotal {
+				t.Errorf("expected total %d, got %d", tt.wantTotal, resp.Total)
+			}
+		})
+	}
+}
+
+func TestMemoryClient_LimitIsCapped(t *testing.T) {
+	m := NewMemoryClient()
+	for id := range int64(port.MaxLimit + 10) {
+		m.UpsertTutor(context.Background(), &domain.Tutor{ID: id + 1})
+	}
+
+	resp, _ := m.SearchTutors(context.Background(), SearchQuery{Limit: 1000})
+	if len(resp.Results) != port.MaxLimit {
+		t.Errorf("expected %d results, got %d", port.MaxLimit, len(resp.Results))
+	}
+
+	resp, _ = m.SearchTutors(context.Background(), SearchQuery{})
+	if len(resp.Results) != port.DefaultLimit {
+		t.Errorf("expected default %d results, got %d", port.DefaultLimit, len(resp.Results))
+	}
+}
+
+func TestMemoryClient_Diversify(t *testing.T) {
+	m := newFixtureMemoryClient(t)
+
+	result, err := m.SearchTutors(context.Background(), SearchQuery{DiversifyBy: DiversifyLocation, Sort: SortRating})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var ids []int64
+	for _, tutor := range result.Results {
+		ids = append(ids, tutor.ID)
+	}
+	if !slices.Equal(ids, []int64{1, 3, 4}) || result.Total != 4 {
+		t.Errorf("expected one tutor per location [1 3 4] of 4, got %v of %d", ids, result.Total)
+	}
+	if alts := result.Alternates[3]; len(alts) != 1 || alts[0].ID != 2 || alts[0].Bio != "" {
+		t.Errorf("expected tutor 2 without bio under 3, got %+v", alts)
+	}
+	if len(result.Alternates) != 1 {
+		t.Errorf("expected alternates only under 3, got %v", result.Alternates)
+	}
+}
+
+func TestMemoryClient_ScanTutors(t *testing.T) {
+	m := newFixtureMemoryClient(t)
+	all, _ := m.SearchTutors(context.Background(), SearchQuery{Limit: 100})
+
+	var ids []int64
+	err := m.ScanTutors(context.Background(), SearchQuery{Limit: 1, Offset: 1}, len(all.Results)-1, func(tutor domain.Tutor) error {
+		ids = append(ids, tutor.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(ids) != len(all.Results)-1 {
+		t.Fatalf("expected %d tutors, got %d", len(all.Results)-1, len(ids))
+	}
+	for i, id := range ids {
+		if id != all.Results[i].ID {
+			t.Errorf("expected search order ignoring limit and offset, got %v", ids)
+			break
+		}
+	}
+}
+
+func TestMemoryClient_BulkDeleteTutors(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryClient()
+	m.UpsertTutor(ctx, &domain.Tutor{ID: 1})
+	m.UpsertTutor(ctx, &domain.Tutor{ID: 3})
+
+	results, err := m.BulkDeleteTutors(ctx, []int64{3, 2, 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []BulkDeleteResult{{ID: 3, Status: BulkDeleted}, {ID: 2, Status: BulkNotFound}, {ID: 1, Status: BulkDeleted}}
+	if !slices.Equal(results, want) {
+		t.Errorf("expected %v, got %v", want, results)
+	}
+	if ids, _ := m.IndexedTutorIDs(ctx); len(ids) != 0 {
+		t.Errorf("expected empty index, got %v", ids)
+	}
+}
+
+func TestMemoryClient_UpsertDeleteRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryClient()
+
+	tutor := &domain.Tutor{ID: 7, FullName: "Emmy Noether", Subjects: []string{"math"}}
+	m.UpsertTutor(ctx, tutor)
+	tutor.Subjects[0] = "mutated"
+
+	resp, _ := m.SearchTutors(ctx, SearchQuery{Subjects: []string{"math"}})
+	if resp.Total != 1 {
+		t.Errorf("expected stored tutor to be isolated from caller mutation, got total %d", resp.Total)
+	}
+
+	m.UpsertTutor(ctx, &domain.Tutor{ID: 7, FullName: "Amalie Emmy Noether"})
+	resp, _ = m.SearchTutors(ctx, SearchQuery{Text: "amalie"})
+	if resp.Total != 1 {
+		t.Errorf("expected upsert to replace the tutor, got total %d", resp.Total)
+	}
+
+	got, err := m.GetTutor(ctx, 7)
+	if err != nil || got.FullName != "Amalie Emmy Noether" {
+		t.Errorf("expected GetTutor to return the replaced tutor, got %v, %v", got, err)
+	}
+
+	if err := m.DeleteTutor(ctx, 7); err != nil {
+		t.Errorf("unexpected delete error: %v", err)
+	}
+	if _, err := m.GetTutor(ctx, 7); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound from GetTutor after delete, got %v", err)
+	}
+	if err := m.DeleteTutor(ctx, 7); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound on second delete, got %v", err)
+	}
+}
+
+func TestMemoryClient_BioSnippet(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryClient()
+	bio := "Patient tutor. " + strings.Repeat("Lessons for every level. ", 10)
+	if err := m.UpsertTutor(ctx, &domain.Tutor{ID: 1, FullName: "Ada", Bio: bio}); err != nil {
+		t.Fatal(err)
+	}
+
+	result, _ := m.SearchTutors(ctx, SearchQuery{})
+	got := result.Results[0]
+	if got.Bio != "" || got.BioSnippet != domain.Snippet(bio, domain.BioSnippetLength) {
+		t.Errorf("expected only the snippet in results, got bio %q and snippet %q", got.Bio, got.BioSnippet)
+	}
+
+	result, _ = m.SearchTutors(ctx, SearchQuery{IncludeBio: true})
+	if result.Results[0].Bio != bio {
+		t.Errorf("expected the full bio with IncludeBio, got %q", result.Results[0].Bio)
+	}
+}
+
+func TestMemoryClient_GetTutors(t *testing.T) {
+	ctx := context.Background()
+	m := newFixtureMemoryClient(t)
+
+	tutors, err := m.GetTutors(ctx, []int64{2, 4, 99})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tutors) != 2 || tutors[2].ID != 2 || tutors[4].ID != 4 {
+		t.Errorf("expected tutors 2 and 4 only, got %+v", tutors)
+	}
+}
+
+func TestMemoryClient_IndexedTutorIDsAndRecreate(t *testing.T) {
+	ctx := context.Background()
+	m := newFixtureMemoryClient(t)
+
+	ids, _ := m.IndexedTutorIDs(ctx)
+	if !slices.Equal(ids, []int64{1, 2, 3, 4}) {
+		t.Errorf("expected ids [1 2 3 4], got %v", ids)
+	}
+	if count, _ := m.CountTutors(ctx); count != 4 {
+		t.Errorf("expected a count of 4, got %d", count)
+	}
+
+	result, _ := m.RecreateIndex(ctx)
+	if result.OldCount != 4 || result.NewCount != 0 {
+		t.Errorf("expected counts 4/0, got %d/%d", result.OldCount, result.NewCount)
+	}
+	if ids, _ := m.IndexedTutorIDs(ctx); len(ids) != 0 {
+		t.Errorf("expected empty index after recreate, got %v", ids)
+	}
+	if count, _ := m.CountTutors(ctx); count != 0 {
+		t.Errorf("expected a count of 0 after recreate, got %d", count)
+	}
+}
+
+func TestMemoryClient_TenantsAreIsolated(t *testing.T) {
+	m := newFixtureMemoryClient(t)
+	de := tenant.NewContext(context.Background(), tenant.Tenant{Name: "de", Index: "tutors-de"})
+
+	if err := m.UpsertTutor(de, &domain.Tutor{ID: 9, FullName: "Berlin Tutor"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if ids, _ := m.IndexedTutorIDs(de); !slices.Equal(ids, []int64{9}) {
+		t.Errorf("expected only tutor 9 in tutors-de, got %v", ids)
+	}
+	if _, err := m.GetTutor(context.Background(), 9); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected tutor 9 to be absent from the default index, got %v", err)
+	}
+	if err := m.DeleteTutor(de, 1); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected tutor 1 to be absent from tutors-de, got %v", err)
+	}
+}
+
+func TestMemoryClient_PriceByFormat(t *testing.T) {
+	m := NewMemoryClient()
+	for _, tutor := range []domain.Tutor{
+		// Cheap online, expensive in person.
+		{ID: 1, HourlyRate: 30, OnlineRate: 30, OfflineRate: 80, Formats: []string{"online", "offline"}},
+		// One rate for everything.
+		{ID: 2, HourlyRate: 50, Formats: []string{"online", "offline"}},
+	} {
+		if err := m.UpsertTutor(context.Background(), &tutor); err != nil {
+			t.Fatalf("failed to upsert: %v", err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		query   SearchQuery
+		wantIDs []int64
+	}{
+		{"no format filters the hourly rate", SearchQuery{MaxPrice: ptr(60)}, []int64{1, 2}},
+		{"offline filters the offline rate", SearchQuery{MaxPrice: ptr(60), Format: "offline"}, []int64{2}},
+		{"online filters the online rate", SearchQuery{MinPrice: ptr(40), Format: "online"}, []int64{2}},
+		{"hourly rate stands in for a missing format rate", SearchQuery{MinPrice: ptr(45), MaxPrice: ptr(90), Format: "offline"}, []int64{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := m.SearchTutors(context.Background(), tt.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var ids []int64
+			for _, r := range resp.Results {
+				ids = append(ids, r.ID)
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("expected %v, got %v", tt.wantIDs, ids)
+			}
+		})
+	}
+}
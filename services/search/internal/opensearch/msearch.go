@@ -0,0 +1,104 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// multiSearchItem is one search's response within an _msearch response.
+// Only the parts this package reads are decoded.
+type multiSearchItem struct {
+	Took   int `json:"took"`
+	Status int `json:"status"`
+	Error  *struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	} `json:"error"`
+	Hits struct {
+		Total struct {
+			Value int64 `json:"value"`
+		} `json:"total"`
+		Hits []struct {
+			ID     string          `json:"_id"`
+			Source json.RawMessage `json:"_source"`
+		} `json:"hits"`
+	} `json:"hits"`
+	Aggregations json.RawMessage `json:"aggregations"`
+	// raw is the whole response, for callers that need more of it.
+	raw json.RawMessage
+}
+
+// multiSearch runs bodies against ctx's index in one _msearch request and
+// returns their responses in the same order. A search that failed on its
+// own fails the whole call, since callers need every part.
+func (c *Client) multiSearch(ctx context.Context, bodies ...map[string]any) ([]multiSearchItem, error) {
+	return c.multiSearchIndex(ctx, c.readIndex(ctx), bodies...)
+}
+
+// multiSearchIndex is multiSearch against index.
+func (c *Client) multiSearchIndex(ctx context.Context, index string, bodies ...map[string]any) ([]multiSearchItem, error) {
+	body, err := buildMultiSearchBody(bodies)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.client.MSearch(ctx, opensearchapi.MSearchReq{
+		Indices: []string{index},
+		Body:    bytes.NewReader(body),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to run multi search: %w", err)
+	}
+
+	// MSearchResp drops aggregations and per-search errors, so the raw body
+	// is decoded again.
+	raw, err := io.ReadAll(resp.Inspect().Response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read multi search response: %w", err)
+	}
+	return splitMultiSearchResponse(raw, len(bodies))
+}
+
+// buildMultiSearchBody encodes bodies as _msearch NDJSON: an empty header
+// line, so the request's index applies, then the body, for each search.
+func buildMultiSearchBody(bodies []map[string]any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, body := range bodies {
+		buf.WriteString("{}\n")
+		if err := enc.Encode(body); err != nil {
+			return nil, fmt.Errorf("failed to marshal multi search body: %w", err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// splitMultiSearchResponse decodes an _msearch response into its want
+// per-search responses.
+func splitMultiSearchResponse(raw []byte, want int) ([]multiSearchItem, error) {
+	var resp struct {
+		Responses []json.RawMessage `json:"responses"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode multi search response: %w", err)
+	}
+	if len(resp.Responses) != want {
+		return nil, fmt.Errorf("multi search returned %d responses, expected %d", len(resp.Responses), want)
+	}
+	items := make([]multiSearchItem, len(resp.Responses))
+	for i, r := range resp.Responses {
+		if err := json.Unmarshal(r, &items[i]); err != nil {
+			return nil, fmt.Errorf("failed to decode multi search response %d: %w", i, err)
+		}
+		if items[i].Error != nil {
+			return nil, fmt.Errorf("multi search %d failed with status %d: %s: %s", i, items[i].Status, items[i].Error.Type, items[i].Error.Reason)
+		}
+		items[i].raw = r
+	}
+	return items, nil
+}
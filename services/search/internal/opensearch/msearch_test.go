@@ -0,0 +1,152 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"search/internal/port"
+)
+
+func TestBuildMultiSearchBody(t *testing.T) {
+	body, err := buildMultiSearchBody([]map[string]any{
+		{"size": 5, "query": map[string]any{"match_all": map[string]any{}}},
+		{"size": 0},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasSuffix(string(body), "\n") {
+		t.Error("expected the body to end with a newline")
+	}
+	lines := strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected a header and a body line per search, got %q", lines)
+	}
+	for _, i := range []int{0, 2} {
+		if lines[i] != "{}" {
+			t.Errorf("expected an empty header on line %d, got %s", i, lines[i])
+		}
+	}
+	var first, second map[string]any
+	if err := json.Unmarshal([]byte(lines[1]), &first); err != nil || first["size"] != 5.0 {
+		t.Errorf("unexpected first body %s", lines[1])
+	}
+	if err := json.Unmarshal([]byte(lines[3]), &second); err != nil || second["size"] != 0.0 {
+		t.Errorf("unexpected second body %s", lines[3])
+	}
+}
+
+func TestSplitMultiSearchResponse(t *testing.T) {
+	raw := []byte(`{"took": 3, "responses": [
+		{"status": 200, "hits": {"hits": [{"_source": {"id": 1}}, {"_source": {"id": 2}}]}},
+		{"status": 200, "hits": {"hits": []}, "aggregations": {"by_subject": {"buckets": []}}}
+	]}`)
+
+	items, err := splitMultiSearchResponse(raw, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items[0].Hits.Hits) != 2 || string(items[0].Hits.Hits[1].Source) != `{"id": 2}` {
+		t.Errorf("unexpected first response %+v", items[0])
+	}
+	if len(items[1].Hits.Hits) != 0 || !strings.Contains(string(items[1].Aggregations), "by_subject") {
+		t.Errorf("unexpected second response %+v", items[1])
+	}
+	if !strings.Contains(string(items[0].raw), `"id": 2`) {
+		t.Errorf("expected the first response to keep its raw form, got %s", items[0].raw)
+	}
+}
+
+func TestSplitMultiSearchResponse_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		wantErr string
+	}{
+		{"malformed", `{"responses": [`, "failed to decode"},
+		{"missing response", `{"responses": [{"status": 200}]}`, "returned 1 responses, expected 2"},
+		{
+			"failed search",
+			`{"responses": [{"status": 200}, {"status": 400, "error": {"type": "search_phase_execution_exception", "reason": "bad regexp"}}]}`,
+			"multi search 1 failed with status 400: search_phase_execution_exception: bad regexp",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := splitMultiSearchResponse([]byte(tt.raw), 2)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestSearchTutorsMulti(t *testing.T) {
+	var lines []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/tutors/_msearch" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		raw, _ := io.ReadAll(r.Body)
+		lines = strings.Split(strings.TrimSuffix(string(raw), "\n"), "\n")
+		writeJSON(w, http.StatusOK, `{"took": 6, "responses": [
+			{
+				"took": 4, "status": 200,
+				"_shards": {"total": 1, "successful": 1, "failed": 0},
+				"hits": {"total": {"value": 2, "relation": "eq"}, "hits": [
+					{"_source": {"id": 3}}, {"_source": {"id": 1}}
+				]}
+			},
+			{
+				"took": 2, "status": 200,
+				"_shards": {"total": 1, "successful": 1, "failed": 0},
+				"hits": {"total": {"value": 1, "relation": "eq"}, "hits": [{"_source": {"id": 3}}]}
+			}
+		]}`)
+	})
+
+	ctx, timing := port.NewTimingContext(context.Background())
+	results, err := client.SearchTutorsMulti(ctx, []SearchQuery{
+		{Text: "algebra", Subjects: []string{"math"}, Limit: 5},
+		{Text: "algebra", Subjects: []string{"physics"}, Limit: 5},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(lines) != 4 {
+		t.Fatalf("expected two searches in the request, got %q", lines)
+	}
+	for i, subject := range []string{"math", "physics"} {
+		if !strings.Contains(lines[2*i+1], `"terms":{"subjects":["`+subject+`"]}`) || !strings.Contains(lines[2*i+1], `"size":5`) {
+			t.Errorf("expected search %d to filter on %s, got %s", i, subject, lines[2*i+1])
+		}
+	}
+
+	if len(results) != 2 || results[0].Total != 2 || len(results[0].Results) != 2 || results[1].Total != 1 || results[1].Results[0].ID != 3 {
+		t.Errorf("expected each search's results in order, got %+v", results)
+	}
+	if timing.Took() != 4*time.Millisecond {
+		t.Errorf("expected the slowest search's took, got %v", timing.Took())
+	}
+}
+
+func TestSearchTutorsMulti_FailedPart(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{"took": 1, "responses": [
+			{"took": 1, "status": 200, "hits": {"total": {"value": 0, "relation": "eq"}, "hits": []}},
+			{"status": 429, "error": {"type": "es_rejected_execution_exception", "reason": "queue full"}}
+		]}`)
+	})
+
+	if _, err := client.SearchTutorsMulti(context.Background(), []SearchQuery{{}, {}}); err == nil || !strings.Contains(err.Error(), "queue full") {
+		t.Errorf("expected the failed search's reason, got %v", err)
+	}
+}
@@ -0,0 +1,114 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	"search/internal/domain"
+)
+
+// maxPopularityErrors caps the failures a PopularityResult lists.
+const maxPopularityErrors = 10
+
+// popularitySource lists the fields the popularity score reads.
+var popularitySource = []string{"id", "rating", "reviews_count", "is_verified", "updated_at"}
+
+// RecomputePopularity scrolls through ctx's index reading only the fields
+// the score depends on, and writes each tutor's new popularity with a
+// _bulk partial update per scroll page. Tutors deleted meanwhile are
+// skipped; other item failures are counted and the run goes on.
+func (c *Client) RecomputePopularity(ctx context.Context) (*PopularityResult, error) {
+	result := &PopularityResult{}
+	now := time.Now()
+	body := map[string]any{
+		"_source": popularitySource,
+		"sort":    []string{"_doc"},
+		"query":   map[string]any{"match_all": map[string]any{}},
+	}
+
+	err := c.scroll(ctx, body, func(hits []opensearchapi.SearchHit) error {
+		var payload bytes.Buffer
+		ids := make([]string, 0, len(hits))
+		for _, hit := range hits {
+			var tutor domain.Tutor
+			if err := json.Unmarshal(hit.Source, &tutor); err != nil {
+				addPopularityError(result, hit.ID, err.Error())
+				continue
+			}
+			fmt.Fprintf(&payload, `{"update":{"_id":%q,"retry_on_conflict":%d}}`+"\n", hit.ID, updateRetries)
+			fmt.Fprintf(&payload, `{"doc":{"popularity":%g}}`+"\n", c.popularity.Popularity(&tutor, now))
+			ids = append(ids, hit.ID)
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		resp, err := c.client.Bulk(ctx, opensearchapi.BulkReq{
+			Index: c.writeIndex(ctx),
+			Body:  &payload,
+		})
+		if err != nil {
+			return fmt.Errorf("bulk update: %w", err)
+		}
+		// Items come back in request order, one per action.
+		for i, id := range ids {
+			if i >= len(resp.Items) {
+				addPopularityError(result, id, "missing from bulk response")
+				continue
+			}
+			item := resp.Items[i]["update"]
+			switch {
+			case item.Status == http.StatusNotFound:
+			case item.Error != nil:
+				addPopularityError(result, id, item.Error.Type+": "+item.Error.Reason)
+			default:
+				result.Updated++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to recompute popularity: %w", err)
+	}
+
+	if result.Updated > 0 && c.refresh != RefreshFalse {
+		if _, err := c.client.Indices.Refresh(ctx, &opensearchapi.IndicesRefreshReq{
+			Indices: []string{c.writeIndex(ctx)},
+		}); err != nil {
+			c.logger.Warn("Failed to refresh index after popularity recompute", "error", err)
+		}
+	}
+
+	c.logger.Info("Popularity recomputed", "index", c.index(ctx), "updated", result.Updated, "failed", result.Failed)
+	return result, nil
+}
+
+// addPopularityError counts a tutor that could not be rescored, keeping
+// the first maxPopularityErrors reasons.
+func addPopularityError(result *PopularityResult, id, reason string) {
+	result.Failed++
+	if len(result.Errors) < maxPopularityErrors {
+		result.Errors = append(result.Errors, fmt.Sprintf("tutor %s: %s", id, reason))
+	}
+}
+
+// RecomputePopularity rescores every tutor in ctx's index with the
+// client's weights.
+func (m *MemoryClient) RecomputePopularity(ctx context.Context) (*PopularityResult, error) {
+	now := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tutors := m.tutors(ctx)
+	for id, t := range tutors {
+		t.SetPopularity(m.popularity, now)
+		tutors[id] = t
+	}
+	return &PopularityResult{Updated: len(tutors)}, nil
+}
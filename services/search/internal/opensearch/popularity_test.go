@@ -0,0 +1,292 @@
+package opensearch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"search/internal/domain"
+	"search/internal/testutil"
+)
+
+// testWeights scores a tutor by rating plus 1 if verified, ignoring
+// reviews and recency, so expected popularities are easy to read.
+var testWeights = domain.PopularityWeights{Rating: 1, Verified: 1, RecencyHalfLife: time.Hour}
+
+func newPopularityTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, testutil.NewLogger(t), WithPopularityWeights(testWeights))
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return client
+}
+
+func TestUpsertTutor_ScoresPopularity(t *testing.T) {
+	var body struct {
+		Doc map[string]any `json:"doc"`
+	}
+	client := newPopularityTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusOK, `{"_index":"tutors","_id":"7","result":"updated"}`)
+	})
+
+	tutor := &domain.Tutor{ID: 7, Rating: 4.5, ReviewsCount: 12, IsVerified: true}
+	if err := client.UpsertTutor(context.Background(), tutor); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body.Doc["popularity"] != 5.5 {
+		t.Errorf("expected popularity 5.5, got %v", body.Doc["popularity"])
+	}
+	if tutor.Popularity != 0 {
+		t.Errorf("expected the caller's tutor to be left alone, got popularity %g", tutor.Popularity)
+	}
+}
+
+func TestWithPopularityWeights_Invalid(t *testing.T) {
+	weights := testWeights
+	weights.Rating = -1
+
+	if _, err := NewClient("http://localhost:9200", slog.Default(), WithPopularityWeights(weights)); err == nil {
+		t.Error("expected error for negative weights")
+	}
+}
+
+func TestBuildSearchQuery_PopularitySort(t *testing.T) {
+	q := buildSearchQuery(SearchQuery{Text: "math", Sort: SortPopularity}, nil)
+
+	want := []map[string]any{
+		{"popularity": map[string]any{"order": "desc"}},
+		{"rating": map[string]any{"order": "desc"}},
+		{"reviews_count": map[string]any{"order": "desc"}},
+		{"id": map[string]any{"order": "asc"}},
+	}
+	if !reflect.DeepEqual(q["sort"], want) {
+		t.Errorf("expected sort %v, got %v", want, q["sort"])
+	}
+	must := q["query"].(map[string]any)["bool"].(map[string]any)["must"].([]map[string]any)
+	if len(must) != 1 {
+		t.Errorf("expected no popularity boost when sorting by popularity, got %v", must)
+	}
+}
+
+func TestBuildSearchQuery_PopularityBoost(t *testing.T) {
+	tuned := DefaultRelevance
+	tuned.PopularityBoost = 2.5
+	off := DefaultRelevance
+	off.PopularityBoost = 0
+	registry := RelevanceRegistry{"": tuned, "off": off}
+
+	q := buildSearchQuery(SearchQuery{Text: "math"}, registry)
+	must := q["query"].(map[string]any)["bool"].(map[string]any)["must"].([]map[string]any)
+	if len(must) != 2 {
+		t.Fatalf("expected text match and popularity boost, got %v", must)
+	}
+	fn := must[1]["function_score"].(map[string]any)["functions"].([]map[string]any)[0]
+	factor := fn["field_value_factor"].(map[string]any)
+	if factor["field"] != "popularity" || factor["modifier"] != "log1p" || fn["weight"] != 2.5 {
+		t.Errorf("unexpected popularity function %v", fn)
+	}
+
+	for _, query := range []SearchQuery{
+		{Text: "math", Variant: "off"},
+		{Text: "math", Sort: SortRating},
+	} {
+		q := buildSearchQuery(query, registry)
+		must := q["query"].(map[string]any)["bool"].(map[string]any)["must"].([]map[string]any)
+		if len(must) != 1 {
+			t.Errorf("%+v: expected no popularity boost, got %v", query, must)
+		}
+	}
+	if _, ok := buildSearchQuery(SearchQuery{}, registry)["query"].(map[string]any)["match_all"]; !ok {
+		t.Error("expected no popularity boost without text")
+	}
+}
+
+func TestRelevanceConfig_NegativePopularityBoost(t *testing.T) {
+	rc := DefaultRelevance
+	rc.PopularityBoost = -1
+	if err := rc.Validate(); err == nil {
+		t.Error("expected error for a negative popularity boost")
+	}
+}
+
+func TestMemoryClient_SortByPopularity(t *testing.T) {
+	m := NewMemoryClient(WithMemoryPopularityWeights(testWeights))
+	for _, tutor := range []domain.Tutor{
+		{ID: 1, Rating: 4.8},
+		{ID: 2, Rating: 4.2, IsVerified: true},
+		{ID: 3, Rating: 4.5, IsVerified: true},
+		{ID: 4, Rating: 4.8, ReviewsCount: 30},
+	} {
+		if err := m.UpsertTutor(context.Background(), &tutor); err != nil {
+			t.Fatalf("failed to upsert tutor: %v", err)
+		}
+	}
+
+	resp, err := m.SearchTutors(context.Background(), SearchQuery{Sort: SortPopularity})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var ids []int64
+	for _, tutor := range resp.Results {
+		ids = append(ids, tutor.ID)
+	}
+	// 3 and 2 are verified; 1 and 4 tie and more reviews break the tie.
+	if want := []int64{3, 2, 4, 1}; !slices.Equal(ids, want) {
+		t.Errorf("expected %v, got %v", want, ids)
+	}
+	if resp.Results[0].Popularity != 5.5 {
+		t.Errorf("expected popularity 5.5, got %g", resp.Results[0].Popularity)
+	}
+}
+
+func TestMemoryClient_RecomputePopularity(t *testing.T) {
+	m := NewMemoryClient()
+	if err := m.UpsertTutor(context.Background(), &domain.Tutor{ID: 1, Rating: 4, IsVerified: true}); err != nil {
+		t.Fatalf("failed to upsert tutor: %v", err)
+	}
+	m.popularity = testWeights
+
+	result, err := m.RecomputePopularity(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Updated != 1 || result.Failed != 0 {
+		t.Errorf("unexpected result %+v", result)
+	}
+	tutor, _ := m.GetTutor(context.Background(), 1)
+	if tutor.Popularity != 5 {
+		t.Errorf("expected popularity 5, got %g", tutor.Popularity)
+	}
+}
+
+// popularityServer serves the tutors in pages through the scroll API and
+// records the partial updates _bulk receives, failing those in failing
+// and reporting those in missing as deleted.
+type popularityServer struct {
+	t          *testing.T
+	pages      [][]string
+	next       int
+	searchBody map[string]any
+	failing    map[string]bool
+	missing    map[string]bool
+	updates    map[string]float64
+	refreshes  int
+}
+
+func (s *popularityServer) handle(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/tutors/_search":
+		json.NewDecoder(r.Body).Decode(&s.searchBody)
+		writeJSON(w, http.StatusOK, s.page())
+	case r.Method == http.MethodPost && r.URL.Path == "/_search/scroll":
+		writeJSON(w, http.StatusOK, s.page())
+	case r.Method == http.MethodDelete && r.URL.Path == "/_search/scroll/scroll-1":
+		writeJSON(w, http.StatusOK, `{"succeeded":true,"num_freed":1}`)
+	case r.Method == http.MethodPost && r.URL.Path == "/tutors/_bulk":
+		var items []string
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			var action struct {
+				Update struct {
+					ID string `json:"_id"`
+				} `json:"update"`
+			}
+			json.Unmarshal(scanner.Bytes(), &action)
+			if !scanner.Scan() {
+				s.t.Fatalf("update of %s has no document", action.Update.ID)
+			}
+			var doc struct {
+				Doc map[string]float64 `json:"doc"`
+			}
+			if err := json.Unmarshal(scanner.Bytes(), &doc); err != nil {
+				s.t.Errorf("invalid bulk line %q: %v", scanner.Text(), err)
+			}
+			id := action.Update.ID
+			switch {
+			case s.failing[id]:
+				items = append(items, `{"update":{"_id":"`+id+`","status":429,"error":{"type":"es_rejected_execution_exception","reason":"queue full"}}}`)
+			case s.missing[id]:
+				items = append(items, `{"update":{"_id":"`+id+`","status":404,"error":{"type":"document_missing_exception","reason":"document missing"}}}`)
+			default:
+				s.updates[id] = doc.Doc["popularity"]
+				items = append(items, `{"update":{"_id":"`+id+`","result":"updated","status":200}}`)
+			}
+		}
+		writeJSON(w, http.StatusOK, `{"took":1,"errors":true,"items":[`+strings.Join(items, ",")+`]}`)
+	case r.Method == http.MethodPost && r.URL.Path == "/tutors/_refresh":
+		s.refreshes++
+		writeJSON(w, http.StatusOK, `{"_shards":{"total":1,"successful":1,"failed":0}}`)
+	default:
+		s.t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		writeJSON(w, http.StatusNotFound, `{}`)
+	}
+}
+
+// page returns the next page of hits; tutor N is rated N/2 and verified
+// when N is even.
+func (s *popularityServer) page() string {
+	hits := []map[string]any{}
+	if s.next < len(s.pages) {
+		for _, id := range s.pages[s.next] {
+			n := float64(id[0] - '0')
+			hits = append(hits, map[string]any{
+				"_index":  "tutors",
+				"_id":     id,
+				"_source": map[string]any{"rating": n / 2, "is_verified": int(n)%2 == 0},
+			})
+		}
+		s.next++
+	}
+	body, _ := json.Marshal(map[string]any{
+		"_scroll_id": "scroll-1",
+		"hits":       map[string]any{"total": map[string]any{"value": 0}, "hits": hits},
+	})
+	return string(body)
+}
+
+func TestRecomputePopularity(t *testing.T) {
+	server := &popularityServer{
+		t:       t,
+		pages:   [][]string{{"1", "2", "3"}, {"4", "5"}},
+		failing: map[string]bool{"3": true},
+		missing: map[string]bool{"5": true},
+		updates: map[string]float64{},
+	}
+	client := newPopularityTestClient(t, server.handle)
+
+	result, err := client.RecomputePopularity(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Updated != 3 || result.Failed != 1 {
+		t.Errorf("expected 3 updated and 1 failed, got %+v", result)
+	}
+	if want := []string{"tutor 3: es_rejected_execution_exception: queue full"}; !slices.Equal(result.Errors, want) {
+		t.Errorf("expected errors %q, got %q", want, result.Errors)
+	}
+	if want := map[string]float64{"1": 0.5, "2": 2, "4": 3}; !reflect.DeepEqual(server.updates, want) {
+		t.Errorf("expected updates %v, got %v", want, server.updates)
+	}
+	source, _ := server.searchBody["_source"].([]any)
+	if len(source) != len(popularitySource) {
+		t.Errorf("expected only the scored fields to be fetched, got %v", server.searchBody["_source"])
+	}
+	if server.refreshes != 1 {
+		t.Errorf("expected one refresh, got %d", server.refreshes)
+	}
+}
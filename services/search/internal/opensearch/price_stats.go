@@ -0,0 +1,204 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// maxFormatBuckets bounds the lesson formats PriceStats segments by;
+// tutors pick from a handful, so only free-form formats reach it.
+const maxFormatBuckets = 20
+
+// pricePercents are the percentiles PriceStats reports.
+var pricePercents = []float64{25, 50, 75, 90}
+
+// priceAggs is the result of priceStatsAggs.
+type priceAggs struct {
+	Stats struct {
+		Count        int64   `json:"count"`
+		Min          float64 `json:"min"`
+		Max          float64 `json:"max"`
+		Avg          float64 `json:"avg"`
+		StdDeviation float64 `json:"std_deviation"`
+	} `json:"rate_stats"`
+	Percentiles struct {
+		Values []struct {
+			Key   float64 `json:"key"`
+			Value float64 `json:"value"`
+		} `json:"values"`
+	} `json:"rate_percentiles"`
+}
+
+func (a priceAggs) stats() *PriceStats {
+	stats := &PriceStats{
+		Count:        a.Stats.Count,
+		Min:          a.Stats.Min,
+		Max:          a.Stats.Max,
+		Avg:          a.Stats.Avg,
+		StdDeviation: a.Stats.StdDeviation,
+	}
+	for _, v := range a.Percentiles.Values {
+		if p := percentile(stats, v.Key); p != nil {
+			*p = v.Value
+		}
+	}
+	return stats
+}
+
+// percentile returns the field of stats holding percent, nil for a
+// percent it has none for.
+func percentile(stats *PriceStats, percent float64) *float64 {
+	switch percent {
+	case 25:
+		return &stats.P25
+	case 50:
+		return &stats.P50
+	case 75:
+		return &stats.P75
+	case 90:
+		return &stats.P90
+	}
+	return nil
+}
+
+// PriceStats runs extended_stats and percentiles aggregations on the
+// hourly rates of the subject's tutors, repeated under a terms
+// aggregation on formats when byFormat is set.
+func (c *Client) PriceStats(ctx context.Context, subject string, byFormat bool) (*PriceStats, error) {
+	body, err := json.Marshal(buildPriceStatsQuery(subject, byFormat))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal price stats query: %w", err)
+	}
+
+	resp, err := c.client.Search(ctx, &opensearchapi.SearchReq{
+		Indices: []string{c.readIndex(ctx)},
+		Body:    bytes.NewReader(body),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate price stats: %w", err)
+	}
+
+	var aggs struct {
+		priceAggs
+		ByFormat struct {
+			Buckets []struct {
+				Key string `json:"key"`
+				priceAggs
+			} `json:"buckets"`
+		} `json:"by_format"`
+	}
+	if err := json.Unmarshal(resp.Aggregations, &aggs); err != nil {
+		return nil, fmt.Errorf("failed to decode price stats aggregations: %w", err)
+	}
+
+	stats := aggs.stats()
+	if byFormat {
+		stats.ByFormat = make(map[string]*PriceStats, len(aggs.ByFormat.Buckets))
+		for _, bucket := range aggs.ByFormat.Buckets {
+			stats.ByFormat[bucket.Key] = bucket.stats()
+		}
+	}
+	return stats, nil
+}
+
+func buildPriceStatsQuery(subject string, byFormat bool) map[string]any {
+	aggs := priceStatsAggs()
+	if byFormat {
+		aggs["by_format"] = map[string]any{
+			"terms": map[string]any{
+				"field": "formats",
+				"size":  maxFormatBuckets,
+			},
+			"aggs": priceStatsAggs(),
+		}
+	}
+	return map[string]any{
+		"size": 0,
+		"query": map[string]any{
+			"bool": map[string]any{
+				"filter": []map[string]any{
+					{"term": map[string]any{"subjects": subject}},
+					{"range": map[string]any{"hourly_rate": map[string]any{"gt": 0}}},
+				},
+			},
+		},
+		"aggs": aggs,
+	}
+}
+
+func priceStatsAggs() map[string]any {
+	return map[string]any{
+		"rate_stats": map[string]any{
+			"extended_stats": map[string]any{"field": "hourly_rate"},
+		},
+		"rate_percentiles": map[string]any{
+			"percentiles": map[string]any{
+				"field":    "hourly_rate",
+				"percents": pricePercents,
+				"keyed":    false,
+			},
+		},
+	}
+}
+
+// PriceStats computes the stats exactly, interpolating percentiles
+// linearly between the nearest rates.
+func (m *MemoryClient) PriceStats(ctx context.Context, subject string, byFormat bool) (*PriceStats, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var rates []float64
+	formatRates := make(map[string][]float64)
+	for _, t := range m.indices[IndexFor(ctx)] {
+		if t.HourlyRate <= 0 || !slices.Contains(t.Subjects, subject) {
+			continue
+		}
+		rates = append(rates, t.HourlyRate)
+		for _, f := range t.Formats {
+			formatRates[f] = append(formatRates[f], t.HourlyRate)
+		}
+	}
+
+	stats := priceStatsOf(rates)
+	if byFormat {
+		stats.ByFormat = make(map[string]*PriceStats, len(formatRates))
+		for f, rates := range formatRates {
+			stats.ByFormat[f] = priceStatsOf(rates)
+		}
+	}
+	return stats, nil
+}
+
+func priceStatsOf(rates []float64) *PriceStats {
+	stats := &PriceStats{Count: int64(len(rates))}
+	if len(rates) == 0 {
+		return stats
+	}
+	slices.Sort(rates)
+	stats.Min, stats.Max = rates[0], rates[len(rates)-1]
+
+	var sum float64
+	for _, r := range rates {
+		sum += r
+	}
+	stats.Avg = sum / float64(len(rates))
+	var squares float64
+	for _, r := range rates {
+		squares += (r - stats.Avg) * (r - stats.Avg)
+	}
+	stats.StdDeviation = math.Sqrt(squares / float64(len(rates)))
+
+	for _, p := range pricePercents {
+		rank := p / 100 * float64(len(rates)-1)
+		lo := int(rank)
+		hi := min(lo+1, len(rates)-1)
+		*percentile(stats, p) = rates[lo] + (rates[hi]-rates[lo])*(rank-float64(lo))
+	}
+	return stats
+}
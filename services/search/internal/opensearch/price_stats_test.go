@@ -0,0 +1,158 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"search/internal/domain"
+)
+
+func TestBuildPriceStatsQuery(t *testing.T) {
+	raw, _ := json.Marshal(buildPriceStatsQuery("math", true))
+	var body struct {
+		Size  int `json:"size"`
+		Query struct {
+			Bool struct {
+				Filter []map[string]map[string]any `json:"filter"`
+			} `json:"bool"`
+		} `json:"query"`
+		Aggs map[string]struct {
+			ExtendedStats map[string]any `json:"extended_stats"`
+			Percentiles   struct {
+				Field    string    `json:"field"`
+				Percents []float64 `json:"percents"`
+				Keyed    bool      `json:"keyed"`
+			} `json:"percentiles"`
+			Terms map[string]any             `json:"terms"`
+			Aggs  map[string]json.RawMessage `json:"aggs"`
+		} `json:"aggs"`
+	}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		t.Fatalf("failed to decode query: %v", err)
+	}
+
+	if body.Size != 0 {
+		t.Errorf("expected no hits, got size %d", body.Size)
+	}
+	if len(body.Query.Bool.Filter) != 2 || body.Query.Bool.Filter[0]["term"]["subjects"] != "math" ||
+		body.Query.Bool.Filter[1]["range"]["hourly_rate"] == nil {
+		t.Errorf("expected subject and rate filters, got %s", raw)
+	}
+	if field := body.Aggs["rate_stats"].ExtendedStats["field"]; field != "hourly_rate" {
+		t.Errorf("expected extended_stats on hourly_rate, got %v", field)
+	}
+	percentiles := body.Aggs["rate_percentiles"].Percentiles
+	if percentiles.Field != "hourly_rate" || !slices.Equal(percentiles.Percents, []float64{25, 50, 75, 90}) || percentiles.Keyed {
+		t.Errorf("unexpected percentiles aggregation %+v", percentiles)
+	}
+	byFormat := body.Aggs["by_format"]
+	if byFormat.Terms["field"] != "formats" {
+		t.Errorf("expected a terms aggregation on formats, got %v", byFormat.Terms)
+	}
+	if byFormat.Aggs["rate_stats"] == nil || byFormat.Aggs["rate_percentiles"] == nil {
+		t.Errorf("expected the stats repeated per format, got %v", byFormat.Aggs)
+	}
+
+	raw, _ = json.Marshal(buildPriceStatsQuery("math", false))
+	body.Aggs = nil
+	json.Unmarshal(raw, &body)
+	if _, ok := body.Aggs["by_format"]; ok {
+		t.Errorf("expected no format segmentation, got %s", raw)
+	}
+}
+
+func TestPriceStats(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/tutors/_search" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		writeJSON(w, http.StatusOK, `{
+			"took": 2, "timed_out": false,
+			"_shards": {"total": 1, "successful": 1, "skipped": 0, "failed": 0},
+			"hits": {"total": {"value": 4, "relation": "eq"}, "hits": []},
+			"aggregations": {
+				"rate_stats": {"count": 4, "min": 20, "max": 80, "avg": 45, "sum": 180, "std_deviation": 22.9},
+				"rate_percentiles": {"values": [{"key": 25.0, "value": 27.5}, {"key": 50.0, "value": 40}, {"key": 75.0, "value": 57.5}, {"key": 90.0, "value": 71}]},
+				"by_format": {"buckets": [
+					{"key": "online", "doc_count": 3,
+						"rate_stats": {"count": 3, "min": 20, "max": 60, "avg": 40, "std_deviation": 16.3},
+						"rate_percentiles": {"values": [{"key": 25.0, "value": 30}, {"key": 50.0, "value": 40}, {"key": 75.0, "value": 50}, {"key": 90.0, "value": 56}]}}
+				]}
+			}
+		}`)
+	})
+
+	stats, err := client.PriceStats(context.Background(), "math", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Count != 4 || stats.Min != 20 || stats.Max != 80 || stats.Avg != 45 || stats.StdDeviation != 22.9 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if stats.P25 != 27.5 || stats.P50 != 40 || stats.P75 != 57.5 || stats.P90 != 71 {
+		t.Errorf("unexpected percentiles %+v", stats)
+	}
+	online := stats.ByFormat["online"]
+	if len(stats.ByFormat) != 1 || online == nil || online.Count != 3 || online.P90 != 56 {
+		t.Errorf("unexpected format stats %+v", stats.ByFormat)
+	}
+}
+
+func TestPriceStats_NoTutors(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{
+			"took": 1, "timed_out": false,
+			"_shards": {"total": 1, "successful": 1, "skipped": 0, "failed": 0},
+			"hits": {"total": {"value": 0, "relation": "eq"}, "hits": []},
+			"aggregations": {
+				"rate_stats": {"count": 0, "min": null, "max": null, "avg": null, "sum": 0, "std_deviation": null},
+				"rate_percentiles": {"values": [{"key": 25.0, "value": null}, {"key": 50.0, "value": null}, {"key": 75.0, "value": null}, {"key": 90.0, "value": null}]}
+			}
+		}`)
+	})
+
+	stats, err := client.PriceStats(context.Background(), "astrology", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Count != 0 || stats.P50 != 0 || stats.ByFormat != nil {
+		t.Errorf("expected empty stats, got %+v", stats)
+	}
+}
+
+func TestMemoryClient_PriceStats(t *testing.T) {
+	client := NewMemoryClient()
+	ctx := context.Background()
+	for _, tutor := range []domain.Tutor{
+		{ID: 1, Subjects: []string{"math"}, HourlyRate: 20, Formats: []string{"online"}},
+		{ID: 2, Subjects: []string{"math", "physics"}, HourlyRate: 30, Formats: []string{"online", "offline"}},
+		{ID: 3, Subjects: []string{"math"}, HourlyRate: 50, Formats: []string{"offline"}},
+		{ID: 4, Subjects: []string{"math"}, HourlyRate: 80},
+		{ID: 5, Subjects: []string{"math"}},
+		{ID: 6, Subjects: []string{"physics"}, HourlyRate: 100},
+	} {
+		client.UpsertTutor(ctx, &tutor)
+	}
+
+	stats, err := client.PriceStats(ctx, "math", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stats.Count != 4 || stats.Min != 20 || stats.Max != 80 || stats.Avg != 45 {
+		t.Errorf("expected the four math tutors with a rate, got %+v", stats)
+	}
+	if stats.P25 != 27.5 || stats.P50 != 40 || stats.P75 != 57.5 || stats.P90 != 71 {
+		t.Errorf("unexpected percentiles %+v", stats)
+	}
+	if online, offline := stats.ByFormat["online"], stats.ByFormat["offline"]; online == nil || offline == nil ||
+		online.Count != 2 || online.Avg != 25 || offline.Count != 2 || offline.Avg != 40 {
+		t.Errorf("unexpected format stats %+v", stats.ByFormat)
+	}
+
+	if stats, _ := client.PriceStats(ctx, "astrology", false); stats.Count != 0 {
+		t.Errorf("expected no tutors, got %+v", stats)
+	}
+}
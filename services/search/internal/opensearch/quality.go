@@ -0,0 +1,146 @@
+package opensearch
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"search/internal/domain"
+	"search/internal/port"
+)
+
+// qualityQueries builds the query behind each port.QualityRules entry.
+var qualityQueries = map[string]func() map[string]any{
+	// An empty or punctuation-only headline indexes no terms, which a
+	// regexp over any term tells apart; exists would count "" as a value.
+	port.QualityEmptyHeadline: func() map[string]any {
+		return map[string]any{"bool": map[string]any{
+			"must_not": []map[string]any{{"regexp": map[string]any{"headline": ".+"}}},
+		}}
+	},
+	port.QualityRatingOutOfRange: func() map[string]any {
+		return map[string]any{"bool": map[string]any{
+			"should": []map[string]any{
+				{"range": map[string]any{"rating": map[string]any{"gt": 5}}},
+				{"range": map[string]any{"rating": map[string]any{"lt": 0}}},
+			},
+			"minimum_should_match": 1,
+		}}
+	},
+	port.QualityVerifiedZeroRate: func() map[string]any {
+		return map[string]any{"bool": map[string]any{
+			"filter": []map[string]any{
+				{"term": map[string]any{"is_verified": true}},
+				{"range": map[string]any{"hourly_rate": map[string]any{"lte": 0}}},
+			},
+		}}
+	},
+	port.QualityMissingSubjects: func() map[string]any {
+		return map[string]any{"bool": map[string]any{
+			"must_not": []map[string]any{{"exists": map[string]any{"field": "subjects"}}},
+		}}
+	},
+}
+
+// buildQualityQuery returns the search for the page of tutors breaking
+// rule, lowest ID first, with an exact total.
+func buildQualityQuery(rule string, limit, offset int) (map[string]any, error) {
+	query, ok := qualityQueries[rule]
+	if !ok {
+		return nil, fmt.Errorf("unknown quality rule %q", rule)
+	}
+	return map[string]any{
+		"query":            query(),
+		"size":             limit,
+		"from":             offset,
+		"_source":          false,
+		"track_total_hits": true,
+		"sort":             []map[string]any{{"id": "asc"}},
+	}, nil
+}
+
+// CheckQuality runs one search per rule in a single _msearch request.
+func (c *Client) CheckQuality(ctx context.Context, rules []string, limit, offset int) ([]QualityRuleResult, error) {
+	bodies := make([]map[string]any, len(rules))
+	for i, rule := range rules {
+		body, err := buildQualityQuery(rule, limit, offset)
+		if err != nil {
+			return nil, err
+		}
+		bodies[i] = body
+	}
+	if len(bodies) == 0 {
+		return []QualityRuleResult{}, nil
+	}
+
+	items, err := c.multiSearch(ctx, bodies...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check data quality: %w", err)
+	}
+
+	results := make([]QualityRuleResult, len(rules))
+	for i, item := range items {
+		ids := make([]int64, 0, len(item.Hits.Hits))
+		for _, hit := range item.Hits.Hits {
+			id, err := strconv.ParseInt(hit.ID, 10, 64)
+			if err != nil {
+				c.logger.Warn("Skipping document with non-numeric ID", "id", hit.ID)
+				continue
+			}
+			ids = append(ids, id)
+		}
+		results[i] = QualityRuleResult{Rule: rules[i], Count: item.Hits.Total.Value, IDs: ids}
+	}
+	return results, nil
+}
+
+// memoryQualityChecks mirror qualityQueries.
+var memoryQualityChecks = map[string]func(domain.Tutor) bool{
+	port.QualityEmptyHeadline: func(t domain.Tutor) bool {
+		return !strings.ContainsFunc(t.Headline, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) })
+	},
+	port.QualityRatingOutOfRange: func(t domain.Tutor) bool {
+		return t.Rating > 5 || t.Rating < 0
+	},
+	port.QualityVerifiedZeroRate: func(t domain.Tutor) bool {
+		return t.IsVerified && t.HourlyRate <= 0
+	},
+	port.QualityMissingSubjects: func(t domain.Tutor) bool {
+		return len(t.Subjects) == 0
+	},
+}
+
+// CheckQuality applies each rule's check to every stored tutor.
+func (m *MemoryClient) CheckQuality(ctx context.Context, rules []string, limit, offset int) ([]QualityRuleResult, error) {
+	for _, rule := range rules {
+		if _, ok := memoryQualityChecks[rule]; !ok {
+			return nil, fmt.Errorf("unknown quality rule %q", rule)
+		}
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	tutors := m.indices[IndexFor(ctx)]
+	ids := slices.Sorted(maps.Keys(tutors))
+
+	results := make([]QualityRuleResult, len(rules))
+	for i, rule := range rules {
+		check := memoryQualityChecks[rule]
+		result := QualityRuleResult{Rule: rule, IDs: []int64{}}
+		for _, id := range ids {
+			if !check(tutors[id]) {
+				continue
+			}
+			if result.Count >= int64(offset) && len(result.IDs) < limit {
+				result.IDs = append(result.IDs, id)
+			}
+			result.Count++
+		}
+		results[i] = result
+	}
+	return results, nil
+}
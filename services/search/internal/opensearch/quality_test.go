@@ -0,0 +1,132 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"search/internal/domain"
+	"search/internal/port"
+)
+
+func TestQualityQueries_CoverEveryRule(t *testing.T) {
+	for _, rule := range port.QualityRules {
+		if _, ok := qualityQueries[rule]; !ok {
+			t.Errorf("rule %s has no query", rule)
+		}
+		if _, ok := memoryQualityChecks[rule]; !ok {
+			t.Errorf("rule %s has no memory check", rule)
+		}
+	}
+	if len(qualityQueries) != len(port.QualityRules) || len(memoryQualityChecks) != len(port.QualityRules) {
+		t.Errorf("expected exactly the %d rules of port.QualityRules", len(port.QualityRules))
+	}
+}
+
+func TestBuildQualityQuery(t *testing.T) {
+	tests := []struct {
+		rule string
+		want string
+	}{
+		{port.QualityEmptyHeadline, `{"bool":{"must_not":[{"regexp":{"headline":".+"}}]}}`},
+		{port.QualityRatingOutOfRange, `{"bool":{"minimum_should_match":1,"should":[{"range":{"rating":{"gt":5}}},{"range":{"rating":{"lt":0}}}]}}`},
+		{port.QualityVerifiedZeroRate, `{"bool":{"filter":[{"term":{"is_verified":true}},{"range":{"hourly_rate":{"lte":0}}}]}}`},
+		{port.QualityMissingSubjects, `{"bool":{"must_not":[{"exists":{"field":"subjects"}}]}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			body, err := buildQualityQuery(tt.rule, 25, 50)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			query, _ := json.Marshal(body["query"])
+			if string(query) != tt.want {
+				t.Errorf("expected query %s, got %s", tt.want, query)
+			}
+			if body["size"] != 25 || body["from"] != 50 || body["_source"] != false || body["track_total_hits"] != true {
+				t.Errorf("unexpected paging %v", body)
+			}
+		})
+	}
+
+	if _, err := buildQualityQuery("bogus", 10, 0); err == nil {
+		t.Error("expected an error for an unknown rule")
+	}
+}
+
+func TestCheckQuality(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/tutors/_msearch" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		raw, _ := io.ReadAll(r.Body)
+		if lines := strings.Count(string(raw), "\n"); lines != 4 {
+			t.Errorf("expected two searches, got %s", raw)
+		}
+		writeJSON(w, http.StatusOK, `{"took": 2, "responses": [
+			{"status": 200, "hits": {"total": {"value": 12, "relation": "eq"}, "hits": [{"_id": "3"}, {"_id": "8"}]}},
+			{"status": 200, "hits": {"total": {"value": 0, "relation": "eq"}, "hits": []}}
+		]}`)
+	})
+
+	results, err := client.CheckQuality(context.Background(), []string{port.QualityEmptyHeadline, port.QualityMissingSubjects}, 2, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected two results, got %+v", results)
+	}
+	if r := results[0]; r.Rule != port.QualityEmptyHeadline || r.Count != 12 || !slices.Equal(r.IDs, []int64{3, 8}) {
+		t.Errorf("unexpected first result %+v", r)
+	}
+	if r := results[1]; r.Rule != port.QualityMissingSubjects || r.Count != 0 || r.IDs == nil || len(r.IDs) != 0 {
+		t.Errorf("expected an empty second result, got %+v", r)
+	}
+}
+
+func TestMemoryClient_CheckQuality(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryClient()
+	for _, tutor := range []domain.Tutor{
+		{ID: 1, Headline: "Math", Subjects: []string{"math"}, Rating: 4.5, HourlyRate: 30, IsVerified: true},
+		{ID: 2, Headline: " - ", Subjects: []string{"math"}},
+		{ID: 3, Headline: "Physics", Subjects: []string{"physics"}, Rating: 7},
+		{ID: 4, Headline: "Chemistry", IsVerified: true},
+		{ID: 5, Headline: "", Subjects: []string{"math"}, IsVerified: true},
+	} {
+		m.UpsertTutor(ctx, &tutor)
+	}
+
+	results, err := m.CheckQuality(ctx, port.QualityRules, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := map[string][]int64{
+		port.QualityEmptyHeadline:    {2, 5},
+		port.QualityRatingOutOfRange: {3},
+		port.QualityVerifiedZeroRate: {4, 5},
+		port.QualityMissingSubjects:  {4},
+	}
+	for _, r := range results {
+		if !slices.Equal(r.IDs, want[r.Rule]) || r.Count != int64(len(want[r.Rule])) {
+			t.Errorf("%s: expected %v, got %d %v", r.Rule, want[r.Rule], r.Count, r.IDs)
+		}
+	}
+
+	page, err := m.CheckQuality(ctx, []string{port.QualityVerifiedZeroRate}, 1, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if page[0].Count != 2 || !slices.Equal(page[0].IDs, []int64{5}) {
+		t.Errorf("expected the second of two hits, got %+v", page[0])
+	}
+
+	if _, err := m.CheckQuality(ctx, []string{"bogus"}, 10, 0); err == nil {
+		t.Error("expected an error for an unknown rule")
+	}
+}
@@ -0,0 +1,116 @@
+package opensearch
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"search/internal/domain"
+)
+
+// QuickSearch runs the tutor search and the subject suggestions for
+// search-as-you-type in a single _msearch request.
+func (c *Client) QuickSearch(ctx context.Context, prefix string, size int) (*QuickSearchResult, error) {
+	tutorsQuery, subjectsQuery := buildQuickSearchQueries(prefix, size, c.currentRelevance())
+	items, err := c.multiSearch(ctx, tutorsQuery, subjectsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run quick search: %w", err)
+	}
+
+	tutors := make([]domain.Tutor, 0, len(items[0].Hits.Hits))
+	for _, hit := range items[0].Hits.Hits {
+		var tutor domain.Tutor
+		if err := json.Unmarshal(hit.Source, &tutor); err != nil {
+			c.logger.Warn("Failed to unmarshal tutor", "error", err)
+			continue
+		}
+		tutors = append(tutors, tutor)
+	}
+
+	var aggs struct {
+		BySubject facetBuckets `json:"by_subject"`
+	}
+	if err := json.Unmarshal(items[1].Aggregations, &aggs); err != nil {
+		return nil, fmt.Errorf("failed to decode quick search aggregations: %w", err)
+	}
+
+	return &QuickSearchResult{Tutors: tutors, Subjects: aggs.BySubject.counts()}, nil
+}
+
+// buildQuickSearchQueries returns the two searches behind QuickSearch: up to
+// size tutors whose text starts a phrase with prefix, weighted like
+// SearchTutors, and a hitless search bucketing the subject keys that start
+// with prefix.
+func buildQuickSearchQueries(prefix string, size int, relevance RelevanceRegistry) (tutors, subjects map[string]any) {
+	tutors = map[string]any{
+		"size": size,
+		"query": map[string]any{
+			"multi_match": map[string]any{
+				"query":  prefix,
+				"fields": relevance.For("").fields(),
+				"type":   "phrase_prefix",
+			},
+		},
+	}
+	subjects = map[string]any{
+		"size": 0,
+		"aggs": map[string]any{
+			"by_subject": map[string]any{
+				"terms": map[string]any{
+					"field":   "subjects",
+					"include": escapeRegexp(strings.ToLower(prefix)) + ".*",
+					"size":    size,
+				},
+			},
+		},
+	}
+	return tutors, subjects
+}
+
+// escapeRegexp quotes the Lucene regular expression operators in s, so it
+// matches literally in a terms include.
+func escapeRegexp(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune(`.?+*|{}[]()"\#@&<>~`, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// QuickSearch matches text the way SearchTutors does and, like a terms
+// aggregation, keeps the size most taught subject keys starting with prefix.
+func (m *MemoryClient) QuickSearch(ctx context.Context, prefix string, size int) (*QuickSearchResult, error) {
+	hits := m.rank(ctx, SearchQuery{Text: prefix})
+
+	lower := strings.ToLower(prefix)
+	counts := make(map[string]int)
+	m.mu.RLock()
+	for _, t := range m.indices[IndexFor(ctx)] {
+		for _, s := range t.Subjects {
+			if strings.HasPrefix(s, lower) {
+				counts[s]++
+			}
+		}
+	}
+	m.mu.RUnlock()
+
+	keys := slices.SortedFunc(maps.Keys(counts), func(a, b string) int {
+		if c := cmp.Compare(counts[b], counts[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+	subjects := make(map[string]int, min(size, len(keys)))
+	for _, key := range keys[:min(size, len(keys))] {
+		subjects[key] = counts[key]
+	}
+
+	return &QuickSearchResult{Tutors: hits[:min(size, len(hits))], Subjects: subjects}, nil
+}
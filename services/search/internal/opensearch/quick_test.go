@@ -0,0 +1,97 @@
+package opensearch
+
+import (
+	"context"
+	"io"
+	"maps"
+	"net/http"
+	"strings"
+	"testing"
+
+	"search/internal/domain"
+)
+
+func TestBuildQuickSearchQueries(t *testing.T) {
+	tutors, subjects := buildQuickSearchQueries("Ma", 5, nil)
+
+	if tutors["size"] != 5 {
+		t.Errorf("expected tutor size 5, got %v", tutors["size"])
+	}
+	match := tutors["query"].(map[string]any)["multi_match"].(map[string]any)
+	if match["query"] != "Ma" || match["type"] != "phrase_prefix" {
+		t.Errorf("unexpected tutor query %v", match)
+	}
+
+	if subjects["size"] != 0 {
+		t.Errorf("expected a hitless subject search, got size %v", subjects["size"])
+	}
+	terms := subjects["aggs"].(map[string]any)["by_subject"].(map[string]any)["terms"].(map[string]any)
+	if terms["include"] != "ma.*" || terms["size"] != 5 {
+		t.Errorf("unexpected subject aggregation %v", terms)
+	}
+}
+
+func TestEscapeRegexp(t *testing.T) {
+	if got, want := escapeRegexp(`c++ (adv.)`), `c\+\+ \(adv\.\)`; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestQuickSearch(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/tutors/_msearch" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		raw, _ := io.ReadAll(r.Body)
+		if lines := strings.Count(string(raw), "\n"); lines != 4 {
+			t.Errorf("expected two searches, got %s", raw)
+		}
+		writeJSON(w, http.StatusOK, `{"took": 2, "responses": [
+			{"status": 200, "hits": {"total": {"value": 1, "relation": "eq"}, "hits": [
+				{"_source": {"id": 7, "full_name": "Marie Curie"}}
+			]}},
+			{"status": 200, "hits": {"total": {"value": 3, "relation": "eq"}, "hits": []}, "aggregations": {
+				"by_subject": {"buckets": [{"key": "math", "doc_count": 3}]}
+			}}
+		]}`)
+	})
+
+	result, err := client.QuickSearch(context.Background(), "ma", 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Tutors) != 1 || result.Tutors[0].ID != 7 {
+		t.Errorf("unexpected tutors %+v", result.Tutors)
+	}
+	if want := map[string]int{"math": 3}; !maps.Equal(result.Subjects, want) {
+		t.Errorf("expected subjects %v, got %v", want, result.Subjects)
+	}
+}
+
+func TestMemoryClient_QuickSearch(t *testing.T) {
+	client := NewMemoryClient()
+	ctx := context.Background()
+	for _, tutor := range []domain.Tutor{
+		{ID: 1, FullName: "Marie Curie", Subjects: []string{"physics", "math"}},
+		{ID: 2, FullName: "Ada Lovelace", Headline: "Maths for engineers", Subjects: []string{"math", "marketing"}},
+		{ID: 3, FullName: "Alan Turing", Subjects: []string{"computer-science"}},
+	} {
+		client.UpsertTutor(ctx, &tutor)
+	}
+
+	result, err := client.QuickSearch(ctx, "Ma", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Tutors) != 1 || result.Tutors[0].ID != 2 {
+		t.Errorf("expected the best match only, got %+v", result.Tutors)
+	}
+	if want := map[string]int{"math": 2}; !maps.Equal(result.Subjects, want) {
+		t.Errorf("expected the most taught subject only, got %v", result.Subjects)
+	}
+
+	result, _ = client.QuickSearch(ctx, "MA", 5)
+	if want := map[string]int{"math": 2, "marketing": 1}; !maps.Equal(result.Subjects, want) {
+		t.Errorf("expected subjects %v, got %v", want, result.Subjects)
+	}
+}
@@ -0,0 +1,42 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/opensearch-project/opensearch-go/v4"
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// RawSearch runs body as a _search request against the tutors index and
+// returns the response body unmodified. Callers are responsible for vetting
+// body; it is sent as is.
+func (c *Client) RawSearch(ctx context.Context, body []byte) (json.RawMessage, error) {
+	resp, err := c.client.Search(ctx, &opensearchapi.SearchReq{
+		Indices: []string{c.readIndex(ctx)},
+		Body:    bytes.NewReader(body),
+	})
+	if err != nil {
+		var se *opensearch.StructError
+		if errors.As(err, &se) && se.Status == http.StatusBadRequest {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidQuery, se.Err.Reason)
+		}
+		return nil, fmt.Errorf("failed to run raw search: %w", err)
+	}
+
+	raw, err := io.ReadAll(resp.Inspect().Response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read raw search response: %w", err)
+	}
+	return raw, nil
+}
+
+// RawSearch is not supported: the in-memory backend has no query DSL.
+func (m *MemoryClient) RawSearch(ctx context.Context, body []byte) (json.RawMessage, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,78 @@
+package opensearch
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestRawSearch(t *testing.T) {
+	const response = `{"took":3,"hits":{"total":{"value":2},"hits":[]},"aggregations":{"by_subject":{"buckets":[{"key":"math","doc_count":2}]}}}`
+	var gotBody string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/tutors/_search" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		writeJSON(w, http.StatusOK, response)
+	})
+
+	raw, err := client.RawSearch(context.Background(), []byte(`{"size":0,"aggs":{"by_subject":{"terms":{"field":"subjects"}}}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if string(raw) != response {
+		t.Errorf("expected response passed through unchanged, got %s", raw)
+	}
+	if gotBody != `{"size":0,"aggs":{"by_subject":{"terms":{"field":"subjects"}}}}` {
+		t.Errorf("expected body sent unchanged, got %s", gotBody)
+	}
+}
+
+func TestRawSearch_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		body        string
+		wantInvalid bool
+	}{
+		{
+			name:        "bad query",
+			status:      http.StatusBadRequest,
+			body:        `{"error":{"type":"parsing_exception","reason":"unknown query [nope]"},"status":400}`,
+			wantInvalid: true,
+		},
+		{
+			name:   "server error",
+			status: http.StatusInternalServerError,
+			body:   `{"error":{"type":"exception","reason":"boom"},"status":500}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(w, tt.status, tt.body)
+			})
+
+			_, err := client.RawSearch(context.Background(), []byte(`{"query":{"nope":{}}}`))
+
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if errors.Is(err, ErrInvalidQuery) != tt.wantInvalid {
+				t.Errorf("expected ErrInvalidQuery %v, got %v", tt.wantInvalid, err)
+			}
+		})
+	}
+}
+
+func TestMemoryClient_RawSearchUnsupported(t *testing.T) {
+	if _, err := NewMemoryClient().RawSearch(context.Background(), []byte(`{}`)); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("expected ErrUnsupported, got %v", err)
+	}
+}
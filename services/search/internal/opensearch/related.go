@@ -0,0 +1,108 @@
+package opensearch
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// RelatedSubjects runs a terms aggregation on subjects over the tutors
+// teaching subject, excluding subject itself from the buckets.
+func (c *Client) RelatedSubjects(ctx context.Context, subject string, size int) (*RelatedSubjects, error) {
+	body, err := json.Marshal(buildRelatedSubjectsQuery(subject, size))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal related subjects query: %w", err)
+	}
+
+	resp, err := c.client.Search(ctx, &opensearchapi.SearchReq{
+		Indices: []string{c.readIndex(ctx)},
+		Body:    bytes.NewReader(body),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate related subjects: %w", err)
+	}
+
+	var aggs struct {
+		Related struct {
+			Buckets []struct {
+				Key      string `json:"key"`
+				DocCount int    `json:"doc_count"`
+			} `json:"buckets"`
+		} `json:"related"`
+	}
+	if err := json.Unmarshal(resp.Aggregations, &aggs); err != nil {
+		return nil, fmt.Errorf("failed to decode related subjects aggregation: %w", err)
+	}
+
+	related := &RelatedSubjects{
+		Total:    resp.Hits.Total.Value,
+		Subjects: make([]SubjectCount, len(aggs.Related.Buckets)),
+	}
+	for i, bucket := range aggs.Related.Buckets {
+		related.Subjects[i] = SubjectCount{Key: bucket.Key, Count: bucket.DocCount}
+	}
+	return related, nil
+}
+
+func buildRelatedSubjectsQuery(subject string, size int) map[string]any {
+	return map[string]any{
+		"size":             0,
+		"track_total_hits": true,
+		"query": map[string]any{
+			"bool": map[string]any{
+				"filter": []map[string]any{
+					{"term": map[string]any{"subjects": subject}},
+				},
+			},
+		},
+		"aggs": map[string]any{
+			"related": map[string]any{
+				"terms": map[string]any{
+					"field":   "subjects",
+					"size":    size,
+					"exclude": []string{subject},
+					"order":   []map[string]any{{"_count": "desc"}, {"_key": "asc"}},
+				},
+			},
+		},
+	}
+}
+
+// RelatedSubjects counts the co-taught subjects exactly, ordered like the
+// terms aggregation.
+func (m *MemoryClient) RelatedSubjects(ctx context.Context, subject string, size int) (*RelatedSubjects, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	related := &RelatedSubjects{}
+	counts := make(map[string]int)
+	for _, t := range m.indices[IndexFor(ctx)] {
+		if !slices.Contains(t.Subjects, subject) {
+			continue
+		}
+		related.Total++
+		for _, s := range t.Subjects {
+			if s != subject {
+				counts[s]++
+			}
+		}
+	}
+
+	keys := slices.SortedFunc(maps.Keys(counts), func(a, b string) int {
+		if c := cmp.Compare(counts[b], counts[a]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+	related.Subjects = make([]SubjectCount, 0, min(size, len(keys)))
+	for _, key := range keys[:min(size, len(keys))] {
+		related.Subjects = append(related.Subjects, SubjectCount{Key: key, Count: counts[key]})
+	}
+	return related, nil
+}
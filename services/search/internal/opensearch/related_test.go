@@ -0,0 +1,125 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"search/internal/domain"
+)
+
+func TestBuildRelatedSubjectsQuery(t *testing.T) {
+	raw, _ := json.Marshal(buildRelatedSubjectsQuery("algebra", 10))
+	var body struct {
+		Size           int  `json:"size"`
+		TrackTotalHits bool `json:"track_total_hits"`
+		Query          struct {
+			Bool struct {
+				Filter []map[string]map[string]any `json:"filter"`
+			} `json:"bool"`
+		} `json:"query"`
+		Aggs struct {
+			Related struct {
+				Terms struct {
+					Field   string           `json:"field"`
+					Size    int              `json:"size"`
+					Exclude []string         `json:"exclude"`
+					Order   []map[string]any `json:"order"`
+				} `json:"terms"`
+			} `json:"related"`
+		} `json:"aggs"`
+	}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		t.Fatalf("failed to decode query: %v", err)
+	}
+
+	if body.Size != 0 || !body.TrackTotalHits {
+		t.Errorf("expected no hits and an exact total, got %s", raw)
+	}
+	if len(body.Query.Bool.Filter) != 1 || body.Query.Bool.Filter[0]["term"]["subjects"] != "algebra" {
+		t.Errorf("expected a subject filter, got %s", raw)
+	}
+	terms := body.Aggs.Related.Terms
+	if terms.Field != "subjects" || terms.Size != 10 || !slices.Equal(terms.Exclude, []string{"algebra"}) {
+		t.Errorf("expected the top 10 subjects without algebra, got %+v", terms)
+	}
+	if len(terms.Order) != 2 || terms.Order[0]["_count"] != "desc" || terms.Order[1]["_key"] != "asc" {
+		t.Errorf("expected most shared first, ties by key, got %v", terms.Order)
+	}
+}
+
+func TestRelatedSubjects(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/tutors/_search" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		writeJSON(w, http.StatusOK, `{
+			"took": 2, "timed_out": false,
+			"_shards": {"total": 1, "successful": 1, "skipped": 0, "failed": 0},
+			"hits": {"total": {"value": 12, "relation": "eq"}, "hits": []},
+			"aggregations": {
+				"related": {"doc_count_error_upper_bound": 0, "sum_other_doc_count": 1, "buckets": [
+					{"key": "geometry", "doc_count": 7},
+					{"key": "calculus", "doc_count": 4}
+				]}
+			}
+		}`)
+	})
+
+	related, err := client.RelatedSubjects(context.Background(), "algebra", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []SubjectCount{{Key: "geometry", Count: 7}, {Key: "calculus", Count: 4}}
+	if related.Total != 12 || !slices.Equal(related.Subjects, want) {
+		t.Errorf("unexpected related subjects %+v", related)
+	}
+}
+
+func TestRelatedSubjects_NoTutors(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{
+			"took": 1, "timed_out": false,
+			"_shards": {"total": 1, "successful": 1, "skipped": 0, "failed": 0},
+			"hits": {"total": {"value": 0, "relation": "eq"}, "hits": []},
+			"aggregations": {"related": {"doc_count_error_upper_bound": 0, "sum_other_doc_count": 0, "buckets": []}}
+		}`)
+	})
+
+	related, err := client.RelatedSubjects(context.Background(), "astrology", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if related.Total != 0 || len(related.Subjects) != 0 {
+		t.Errorf("expected nothing related, got %+v", related)
+	}
+}
+
+func TestMemoryClient_RelatedSubjects(t *testing.T) {
+	client := NewMemoryClient()
+	ctx := context.Background()
+	for _, tutor := range []domain.Tutor{
+		{ID: 1, Subjects: []string{"algebra", "geometry"}},
+		{ID: 2, Subjects: []string{"algebra", "geometry", "calculus"}},
+		{ID: 3, Subjects: []string{"algebra", "physics"}},
+		{ID: 4, Subjects: []string{"algebra"}},
+		{ID: 5, Subjects: []string{"geometry", "art"}},
+	} {
+		client.UpsertTutor(ctx, &tutor)
+	}
+
+	related, err := client.RelatedSubjects(ctx, "algebra", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []SubjectCount{{Key: "geometry", Count: 2}, {Key: "calculus", Count: 1}}
+	if related.Total != 4 || !slices.Equal(related.Subjects, want) {
+		t.Errorf("expected geometry then calculus among 4 tutors, got %+v", related)
+	}
+
+	if related, _ := client.RelatedSubjects(ctx, "astrology", 10); related.Total != 0 || len(related.Subjects) != 0 {
+		t.Errorf("expected nothing related, got %+v", related)
+	}
+}
@@ -0,0 +1,145 @@
+package opensearch
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"search/internal/domain"
+)
+
+// RelevanceConfig weights the text match of a search.
+type RelevanceConfig struct {
+	FullNameBoost float64 `json:"full_name_boost"`
+	HeadlineBoost float64 `json:"headline_boost"`
+	BioBoost      float64 `json:"bio_boost"`
+	// Fuzziness is the typo tolerance of the fuzzy match: "AUTO", or a
+	// maximum edit distance of "0", "1" or "2".
+	Fuzziness string `json:"fuzziness"`
+	// PinnedBadge, if set, ranks tutors carrying that badge first in
+	// relevance-ordered searches.
+	PinnedBadge string `json:"pinned_badge,omitempty"`
+	// PopularityBoost weights log(1+popularity) in the score of
+	// relevance-ordered text searches; 0 leaves popularity out.
+	PopularityBoost float64 `json:"popularity_boost"`
+}
+
+// DefaultRelevance is used for searches outside any experiment variant.
+var DefaultRelevance = RelevanceConfig{
+	FullNameBoost:   1,
+	HeadlineBoost:   2,
+	BioBoost:        1,
+	Fuzziness:       "AUTO",
+	PopularityBoost: 1,
+}
+
+// Validate reports the first setting OpenSearch would reject.
+func (r RelevanceConfig) Validate() error {
+	if r.FullNameBoost <= 0 || r.HeadlineBoost <= 0 || r.BioBoost <= 0 {
+		return errors.New("boosts must be positive")
+	}
+	if r.PopularityBoost < 0 {
+		return fmt.Errorf("popularity_boost must not be negative, got %g", r.PopularityBoost)
+	}
+	switch r.Fuzziness {
+	case "AUTO", "0", "1", "2":
+	default:
+		return fmt.Errorf("fuzziness must be AUTO, 0, 1 or 2, got %q", r.Fuzziness)
+	}
+	if r.PinnedBadge != "" {
+		if err := domain.ValidateBadge(r.PinnedBadge); err != nil {
+			return fmt.Errorf("pinned_badge: %w", err)
+		}
+	}
+	return nil
+}
+
+// credentialBoost weights education institutions and certifications below
+// the profile text in every variant.
+const credentialBoost = 0.5
+
+// subjectTextBoost weights subjects matched as text a little above the
+// default field weight in every variant.
+const subjectTextBoost = 1.5
+
+// fields returns the multi_match fields with their boosts.
+func (r RelevanceConfig) fields() []string {
+	return []string{
+		boosted("full_name", r.FullNameBoost),
+		boosted("headline", r.HeadlineBoost),
+		boosted("bio", r.BioBoost),
+		boosted("subjects.text", subjectTextBoost),
+		boosted("education.institution", credentialBoost),
+		boosted("certifications", credentialBoost),
+	}
+}
+
+// languageFields returns the headline and bio subfields stemmed for lang,
+// weighted like the fields themselves.
+func (r RelevanceConfig) languageFields(lang string) []string {
+	return []string{
+		boosted("headline."+lang, r.HeadlineBoost),
+		boosted("bio."+lang, r.BioBoost),
+	}
+}
+
+func boosted(field string, boost float64) string {
+	if boost == 1 {
+		return field
+	}
+	return field + "^" + strconv.FormatFloat(boost, 'g', -1, 64)
+}
+
+// RelevanceRegistry maps experiment variants to their RelevanceConfig.
+type RelevanceRegistry map[string]RelevanceConfig
+
+// WithPinnedBadge returns a copy of r that pins badge in every variant that
+// pins none of its own, including searches outside any experiment.
+func (r RelevanceRegistry) WithPinnedBadge(badge string) RelevanceRegistry {
+	pinned := make(RelevanceRegistry, len(r)+1)
+	for variant, cfg := range r {
+		if cfg.PinnedBadge == "" {
+			cfg.PinnedBadge = badge
+		}
+		pinned[variant] = cfg
+	}
+	if _, ok := pinned[""]; !ok {
+		cfg := DefaultRelevance
+		cfg.PinnedBadge = badge
+		pinned[""] = cfg
+	}
+	return pinned
+}
+
+// RelevanceSource supplies the registry a search is weighted by. The
+// client asks once per search, so every part of a query uses the same
+// registry even when the source swaps it concurrently.
+type RelevanceSource interface {
+	Relevance() RelevanceRegistry
+}
+
+// staticRelevance is a registry that never changes.
+type staticRelevance RelevanceRegistry
+
+func (r staticRelevance) Relevance() RelevanceRegistry { return RelevanceRegistry(r) }
+
+// currentRelevance returns the registry for the next search; nil, which
+// weights every variant with DefaultRelevance, when none is configured.
+func (c *Client) currentRelevance() RelevanceRegistry {
+	if c.relevance == nil {
+		return nil
+	}
+	return c.relevance.Relevance()
+}
+
+// For returns variant's config. A variant without an override gets the
+// config registered for the empty variant, or DefaultRelevance.
+func (r RelevanceRegistry) For(variant string) RelevanceConfig {
+	if cfg, ok := r[variant]; ok {
+		return cfg
+	}
+	if cfg, ok := r[""]; ok {
+		return cfg
+	}
+	return DefaultRelevance
+}
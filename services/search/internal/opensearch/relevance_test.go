@@ -0,0 +1,95 @@
+package opensearch
+
+import (
+	"slices"
+	"testing"
+)
+
+// textMatches returns the fuzzy and phrase_prefix multi_match clauses.
+func textMatches(t *testing.T, q map[string]any) (fuzzy, prefix map[string]any) {
+	t.Helper()
+	must := q["query"].(map[string]any)["bool"].(map[string]any)["must"].([]map[string]any)
+	should := must[0]["bool"].(map[string]any)["should"].([]map[string]any)
+	return should[0]["multi_match"].(map[string]any), should[1]["multi_match"].(map[string]any)
+}
+
+func TestBuildSearchQuery_DefaultRelevance(t *testing.T) {
+	fuzzy, prefix := textMatches(t, buildSearchQuery(SearchQuery{Text: "math"}, nil))
+
+	want := []string{"full_name", "headline^2", "bio", "subjects.text^1.5", "education.institution^0.5", "certifications^0.5"}
+	if !slices.Equal(fuzzy["fields"].([]string), want) || !slices.Equal(prefix["fields"].([]string), want) {
+		t.Errorf("expected fields %v, got %v and %v", want, fuzzy["fields"], prefix["fields"])
+	}
+	if fuzzy["fuzziness"] != "AUTO" {
+		t.Errorf("expected AUTO fuzziness, got %v", fuzzy["fuzziness"])
+	}
+}
+
+func TestBuildSearchQuery_VariantRelevance(t *testing.T) {
+	boosted := DefaultRelevance
+	boosted.HeadlineBoost = 4
+	boosted.BioBoost = 0.5
+	boosted.Fuzziness = "1"
+	registry := RelevanceRegistry{"control": DefaultRelevance, "boosted": boosted}
+
+	tests := []struct {
+		variant       string
+		wantFields    []string
+		wantFuzziness string
+	}{
+		{"boosted", []string{"full_name", "headline^4", "bio^0.5", "subjects.text^1.5", "education.institution^0.5", "certifications^0.5"}, "1"},
+		{"control", []string{"full_name", "headline^2", "bio", "subjects.text^1.5", "education.institution^0.5", "certifications^0.5"}, "AUTO"},
+		{"", []string{"full_name", "headline^2", "bio", "subjects.text^1.5", "education.institution^0.5", "certifications^0.5"}, "AUTO"},
+		{"retired", []string{"full_name", "headline^2", "bio", "subjects.text^1.5", "education.institution^0.5", "certifications^0.5"}, "AUTO"},
+	}
+	for _, tt := range tests {
+		fuzzy, prefix := textMatches(t, buildSearchQuery(SearchQuery{Text: "math", Variant: tt.variant}, registry))
+		if !slices.Equal(fuzzy["fields"].([]string), tt.wantFields) || !slices.Equal(prefix["fields"].([]string), tt.wantFields) {
+			t.Errorf("variant %q: expected fields %v, got %v and %v", tt.variant, tt.wantFields, fuzzy["fields"], prefix["fields"])
+		}
+		if fuzzy["fuzziness"] != tt.wantFuzziness {
+			t.Errorf("variant %q: expected fuzziness %s, got %v", tt.variant, tt.wantFuzziness, fuzzy["fuzziness"])
+		}
+	}
+}
+
+func TestBuildSearchQuery_LanguageMatch(t *testing.T) {
+	boosted := DefaultRelevance
+	boosted.BioBoost = 3
+	registry := RelevanceRegistry{"": boosted}
+
+	tests := []struct {
+		text       string
+		wantFields []string
+		wantLang   string
+	}{
+		{"репетитор математики", []string{"headline.ru^2", "bio.ru^3"}, "ru"},
+		{"math tutor", []string{"headline.en^2", "bio.en^3"}, "en"},
+		{"english для детей", nil, ""},
+		{"🎓", nil, ""},
+	}
+	for _, tt := range tests {
+		q := buildSearchQuery(SearchQuery{Text: tt.text}, registry)
+		must := q["query"].(map[string]any)["bool"].(map[string]any)["must"].([]map[string]any)
+		should := must[0]["bool"].(map[string]any)["should"].([]map[string]any)
+
+		if tt.wantLang == "" {
+			if len(should) != 2 {
+				t.Errorf("%q: expected no language clause, got %v", tt.text, should)
+			}
+			continue
+		}
+		if len(should) != 3 {
+			t.Fatalf("%q: expected a language clause, got %v", tt.text, should)
+		}
+		clause := should[2]["bool"].(map[string]any)
+		match := clause["must"].(map[string]any)["multi_match"].(map[string]any)
+		if !slices.Equal(match["fields"].([]string), tt.wantFields) {
+			t.Errorf("%q: expected fields %v, got %v", tt.text, tt.wantFields, match["fields"])
+		}
+		term := clause["should"].(map[string]any)["term"].(map[string]any)["lang"].(map[string]any)
+		if term["value"] != tt.wantLang || term["boost"] != languageMatchBoost {
+			t.Errorf("%q: expected a boost on lang %s, got %v", tt.text, tt.wantLang, term)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"strconv"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	"search/internal/domain"
+)
+
+const (
+	scrollPageSize  = 1000
+	scrollKeepAlive = time.Minute
+)
+
+// scroll runs body as a scrolling search over the tutors index and calls fn
+// with each page of hits until the results are exhausted or fn fails. The
+// scroll context is always released before returning.
+func (c *Client) scroll(ctx context.Context, body map[string]any, fn func([]opensearchapi.SearchHit) error) error {
+	q := maps.Clone(body)
+	if _, ok := q["size"]; !ok {
+		q["size"] = scrollPageSize
+	}
+
+	payload, err := json.Marshal(q)
+	if err != nil {
+		return fmt.Errorf("failed to marshal scroll query: %w", err)
+	}
+
+	resp, err := c.client.Search(ctx, &opensearchapi.SearchReq{
+		Indices: []string{c.readIndex(ctx)},
+		Body:    bytes.NewReader(payload),
+		Params:  opensearchapi.SearchParams{Scroll: scrollKeepAlive},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start scroll: %w", err)
+	}
+
+	scrollID := resp.ScrollID
+	defer func() {
+		if scrollID == nil {
+			return
+		}
+		if _, err := c.client.Scroll.Delete(context.WithoutCancel(ctx), opensearchapi.ScrollDeleteReq{
+			ScrollIDs: []string{*scrollID},
+		}); err != nil {
+			c.logger.Warn("Failed to clear scroll", "error", err)
+		}
+	}()
+
+	hits := resp.Hits.Hits
+	for len(hits) > 0 {
+		if err := fn(hits); err != nil {
+			return err
+		}
+		if scrollID == nil {
+			return nil
+		}
+
+		next, err := c.client.Scroll.Get(ctx, opensearchapi.ScrollGetReq{
+			ScrollID: *scrollID,
+			Params:   opensearchapi.ScrollGetParams{Scroll: scrollKeepAlive},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to continue scroll: %w", err)
+		}
+		if next.ScrollID != nil {
+			scrollID = next.ScrollID
+		}
+		hits = next.Hits.Hits
+	}
+
+	return nil
+}
+
+// IndexedTutorIDs returns the IDs of every tutor document in the index. Only
+// document IDs are fetched, not sources.
+func (c *Client) IndexedTutorIDs(ctx context.Context) ([]int64, error) {
+	ids := []int64{}
+	body := map[string]any{
+		"_source": false,
+		"sort":    []string{"_doc"},
+		"query":   map[string]any{"match_all": map[string]any{}},
+	}
+
+	err := c.scroll(ctx, body, func(hits []opensearchapi.SearchHit) error {
+		for _, hit := range hits {
+			id, err := strconv.ParseInt(hit.ID, 10, 64)
+			if err != nil {
+				c.logger.Warn("Skipping document with non-numeric ID", "doc_id", hit.ID)
+				continue
+			}
+			ids = append(ids, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list indexed tutor IDs: %w", err)
+	}
+
+	return ids, nil
+}
+
+// ScanTutorVersions scrolls through ctx's index sorted by ID, reading only
+// each tutor's ID and updated_at.
+func (c *Client) ScanTutorVersions(ctx context.Context, fn func(TutorVersion) error) error {
+	body := map[string]any{
+		"_source": []string{"id", "updated_at"},
+		"sort":    []map[string]any{{"id": map[string]any{"order": "asc"}}},
+		"query":   map[string]any{"match_all": map[string]any{}},
+	}
+
+	err := c.scroll(ctx, body, func(hits []opensearchapi.SearchHit) error {
+		for _, hit := range hits {
+			var doc struct {
+				ID        int64     `json:"id"`
+				UpdatedAt time.Time `json:"updated_at"`
+			}
+			if err := json.Unmarshal(hit.Source, &doc); err != nil {
+				return fmt.Errorf("failed to decode tutor %s: %w", hit.ID, err)
+			}
+			if err := fn(TutorVersion{ID: doc.ID, UpdatedAt: doc.UpdatedAt}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan tutor versions: %w", err)
+	}
+	return nil
+}
+
+// errScanLimit stops a scroll once ScanTutors has delivered enough tutors.
+var errScanLimit = errors.New("scan limit reached")
+
+// ScanTutors calls fn for up to limit tutors matching query's text and
+// filters, in relevance order. query.Limit and query.Offset are ignored; the
+// results are read with a scroll, so limit may exceed the search page cap.
+// query.DiversifyBy is ignored too, as scrolls cannot be collapsed.
+func (c *Client) ScanTutors(ctx context.Context, query SearchQuery, limit int, fn func(domain.Tutor) error) error {
+	if limit <= 0 {
+		return nil
+	}
+
+	body := buildSearchQuery(query, c.currentRelevance())
+	delete(body, "from")
+	delete(body, "collapse")
+	body["size"] = min(limit, scrollPageSize)
+
+	seen := 0
+	err := c.scroll(ctx, body, func(hits []opensearchapi.SearchHit) error {
+		for _, hit := range hits {
+			var tutor domain.Tutor
+			if err := json.Unmarshal(hit.Source, &tutor); err != nil {
+				c.logger.Warn("Failed to unmarshal tutor", "error", err)
+				continue
+			}
+			if err := fn(tutor); err != nil {
+				return err
+			}
+			if seen++; seen >= limit {
+				return errScanLimit
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errScanLimit) {
+		return fmt.Errorf("failed to scan tutors: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"search/internal/domain"
+)
+
+// scrollServer serves the given pages of document IDs through the scroll API
+// and records whether the scroll context was cleared.
+type scrollServer struct {
+	t           *testing.T
+	pages       [][]string
+	next        int
+	searchBody  map[string]any
+	scrollParam string
+	cleared     bool
+}
+
+func (s *scrollServer) handle(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/tutors/_search":
+		s.scrollParam = r.URL.Query().Get("scroll")
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &s.searchBody)
+		writeJSON(w, http.StatusOK, s.page())
+	case r.Method == http.MethodPost && r.URL.Path == "/_search/scroll":
+		writeJSON(w, http.StatusOK, s.page())
+	case r.Method == http.MethodDelete && r.URL.Path == "/_search/scroll/scroll-1":
+		s.cleared = true
+		writeJSON(w, http.StatusOK, `{"succeeded":true,"num_freed":1}`)
+	default:
+		s.t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		writeJSON(w, http.StatusNotFound, `{}`)
+	}
+}
+
+func (s *scrollServer) page() string {
+	hits := []map[string]any{}
+	if s.next < len(s.pages) {
+		for _, id := range s.pages[s.next] {
+			hits = append(hits, map[string]any{
+				"_index":  "tutors",
+				"_id":     id,
+				"_source": map[string]any{"full_name": "Tutor " + id},
+			})
+		}
+		s.next++
+	}
+	body, _ := json.Marshal(map[string]any{
+		"_scroll_id": "scroll-1",
+		"hits":       map[string]any{"total": map[string]any{"value": 0}, "hits": hits},
+	})
+	return string(body)
+}
+
+func TestIndexedTutorIDs(t *testing.T) {
+	server := &scrollServer{t: t, pages: [][]string{{"1", "2", "3"}, {"5", "not-a-number"}, {"8"}}}
+	client := newTestClient(t, server.handle)
+
+	ids, err := client.IndexedTutorIDs(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	want := []int64{1, 2, 3, 5, 8}
+	if len(ids) != len(want) {
+		t.Fatalf("expected %v, got %v", want, ids)
+	}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, ids)
+			break
+		}
+	}
+
+	if server.scrollParam != "60000ms" {
+		t.Errorf("expected scroll keep-alive, got %q", server.scrollParam)
+	}
+	if server.searchBody["_source"] != false {
+		t.Errorf("expected _source=false, got %v", server.searchBody["_source"])
+	}
+	if !server.cleared {
+		t.Error("expected scroll context to be cleared")
+	}
+}
+
+func TestIndexedTutorIDs_EmptyIndex(t *testing.T) {
+	server := &scrollServer{t: t}
+	client := newTestClient(t, server.handle)
+
+	ids, err := client.IndexedTutorIDs(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ids) != 0 {
+		t.Errorf("expected no IDs, got %v", ids)
+	}
+}
+
+func TestIndexedTutorIDs_SearchError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusBadRequest, `{"error":{"type":"search_phase_execution_exception"},"status":400}`)
+	})
+
+	if _, err := client.IndexedTutorIDs(context.Background()); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestScanTutors_StopsAtLimit(t *testing.T) {
+	server := &scrollServer{t: t, pages: [][]string{{"1", "2"}, {"3", "4"}, {"5"}}}
+	client := newTestClient(t, server.handle)
+
+	var names []string
+	err := client.ScanTutors(context.Background(), SearchQuery{Location: "Paris", Limit: 5, Offset: 40}, 3, func(tutor domain.Tutor) error {
+		names = append(names, tutor.FullName)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if want := []string{"Tutor 1", "Tutor 2", "Tutor 3"}; !slices.Equal(names, want) {
+		t.Errorf("expected %v, got %v", want, names)
+	}
+	if server.next != 2 {
+		t.Errorf("expected scrolling to stop after 2 pages, fetched %d", server.next)
+	}
+	if _, ok := server.searchBody["from"]; ok {
+		t.Errorf("expected no from in a scroll query, got %v", server.searchBody["from"])
+	}
+	if server.searchBody["size"] != float64(3) {
+		t.Errorf("expected page size capped at the limit, got %v", server.searchBody["size"])
+	}
+	if !strings.Contains(fmt.Sprint(server.searchBody["query"]), "Paris") {
+		t.Errorf("expected filters in the scroll query, got %v", server.searchBody["query"])
+	}
+	if !server.cleared {
+		t.Error("expected scroll context to be cleared")
+	}
+}
+
+func TestScanTutors_CallbackError(t *testing.T) {
+	server := &scrollServer{t: t, pages: [][]string{{"1", "2"}}}
+	client := newTestClient(t, server.handle)
+	errStop := errors.New("client went away")
+
+	err := client.ScanTutors(context.Background(), SearchQuery{}, 10, func(domain.Tutor) error {
+		return errStop
+	})
+
+	if !errors.Is(err, errStop) {
+		t.Errorf("expected callback error, got %v", err)
+	}
+	if !server.cleared {
+		t.Error("expected scroll context to be cleared")
+	}
+}
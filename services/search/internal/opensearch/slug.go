@@ -0,0 +1,161 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	"search/internal/domain"
+)
+
+// changeSlugScript mirrors domain.PreviousSlugsAfter and sets the new slug.
+const changeSlugScript = `
+def current = ctx._source.previous_slugs == null ? [] : ctx._source.previous_slugs;
+def history = new ArrayList();
+for (def s : current) {
+  if (s != params.new_slug) {
+    history.add(s);
+  }
+}
+for (def s : [params.old_slug, ctx._source.slug]) {
+  if (s != null && s != '' && s != params.new_slug && !history.contains(s)) {
+    history.add(s);
+  }
+}
+if (history.size() > params.max) {
+  history = new ArrayList(history.subList(history.size() - params.max, history.size()));
+}
+if (ctx._source.slug == params.new_slug && history.equals(current)) {
+  ctx.op = 'none';
+} else {
+  ctx._source.slug = params.new_slug;
+  ctx._source.previous_slugs = history;
+}`
+
+// ChangeSlug moves a tutor to newSlug with a scripted partial update, so
+// concurrent profile writes keep their other fields.
+func (c *Client) ChangeSlug(ctx context.Context, tutorID int64, oldSlug, newSlug string) error {
+	body, err := json.Marshal(map[string]any{
+		"script": map[string]any{
+			"lang":   "painless",
+			"source": changeSlugScript,
+			"params": map[string]any{
+				"old_slug": oldSlug,
+				"new_slug": newSlug,
+				"max":      domain.MaxPreviousSlugs,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal slug change: %w", err)
+	}
+
+	retries := updateRetries
+	resp, err := c.client.Update(ctx, opensearchapi.UpdateReq{
+		Index:      c.writeIndex(ctx),
+		DocumentID: strconv.FormatInt(tutorID, 10),
+		Body:       bytes.NewReader(body),
+		Params: opensearchapi.UpdateParams{
+			Refresh:         string(c.refresh),
+			RetryOnConflict: &retries,
+		},
+	})
+	if err != nil {
+		if isDocumentMissing(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to change tutor slug: %w", documentError(err))
+	}
+
+	c.logger.Debug("Tutor slug changed", "id", tutorID, "old_slug", oldSlug, "new_slug", newSlug, "result", resp.Result)
+	return nil
+}
+
+// GetTutorBySlug finds the tutor whose slug is slug or, failing that, the
+// most recently updated one that had it before.
+func (c *Client) GetTutorBySlug(ctx context.Context, slug string) (*domain.Tutor, error) {
+	body, err := json.Marshal(buildSlugQuery(slug))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal slug query: %w", err)
+	}
+
+	resp, err := c.client.Search(ctx, &opensearchapi.SearchReq{
+		Indices: []string{c.readIndex(ctx)},
+		Body:    bytes.NewReader(body),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tutor by slug: %w", err)
+	}
+	if len(resp.Hits.Hits) == 0 {
+		return nil, ErrNotFound
+	}
+
+	var tutor domain.Tutor
+	if err := json.Unmarshal(resp.Hits.Hits[0].Source, &tutor); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tutor: %w", err)
+	}
+	return &tutor, nil
+}
+
+// buildSlugQuery matches slug against current and previous slugs, scoring
+// a current one higher, since a slug given up may have been taken since.
+func buildSlugQuery(slug string) map[string]any {
+	return map[string]any{
+		"size": 1,
+		"query": map[string]any{
+			"bool": map[string]any{
+				"should": []map[string]any{
+					{"constant_score": map[string]any{"filter": map[string]any{"term": map[string]any{"slug": slug}}, "boost": 2}},
+					{"constant_score": map[string]any{"filter": map[string]any{"term": map[string]any{"previous_slugs": slug}}, "boost": 1}},
+				},
+				"minimum_should_match": 1,
+			},
+		},
+		"sort": []map[string]any{
+			{"_score": map[string]any{"order": "desc"}},
+			{"updated_at": map[string]any{"order": "desc"}},
+		},
+	}
+}
+
+// ChangeSlug applies domain.PreviousSlugsAfter to the stored tutor.
+func (m *MemoryClient) ChangeSlug(ctx context.Context, tutorID int64, oldSlug, newSlug string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tutors := m.tutors(ctx)
+	t, ok := tutors[tutorID]
+	if !ok {
+		return ErrNotFound
+	}
+	t.PreviousSlugs = domain.PreviousSlugsAfter(t.PreviousSlugs, t.Slug, oldSlug, newSlug)
+	t.Slug = newSlug
+	tutors[tutorID] = t
+	return nil
+}
+
+// GetTutorBySlug returns a copy of the tutor, like GetTutor.
+func (m *MemoryClient) GetTutorBySlug(ctx context.Context, slug string) (*domain.Tutor, error) {
+	m.mu.RLock()
+	var found *domain.Tutor
+	for _, t := range m.indices[IndexFor(ctx)] {
+		if t.Slug == slug {
+			found = &t
+			break
+		}
+		if slices.Contains(t.PreviousSlugs, slug) && (found == nil || t.UpdatedAt.After(found.UpdatedAt)) {
+			found = &t
+		}
+	}
+	m.mu.RUnlock()
+
+	if found == nil {
+		return nil, ErrNotFound
+	}
+	return m.GetTutor(ctx, found.ID)
+}
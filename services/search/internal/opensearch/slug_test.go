@@ -0,0 +1,143 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"search/internal/domain"
+)
+
+func TestChangeSlug(t *testing.T) {
+	var body struct {
+		Script struct {
+			Source string         `json:"source"`
+			Params map[string]any `json:"params"`
+		} `json:"script"`
+	}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/tutors/_update/7" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusOK, `{"_index":"tutors","_id":"7","result":"updated"}`)
+	})
+
+	if err := client.ChangeSlug(context.Background(), 7, "marie-curie", "marie-sklodowska-curie"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(body.Script.Source, "ctx._source.previous_slugs = history") {
+		t.Errorf("expected script setting previous_slugs, got %s", body.Script.Source)
+	}
+	if body.Script.Params["old_slug"] != "marie-curie" || body.Script.Params["new_slug"] != "marie-sklodowska-curie" {
+		t.Errorf("unexpected slug params %v", body.Script.Params)
+	}
+	if body.Script.Params["max"] != float64(domain.MaxPreviousSlugs) {
+		t.Errorf("expected max %d, got %v", domain.MaxPreviousSlugs, body.Script.Params["max"])
+	}
+}
+
+func TestChangeSlug_DocumentMissing(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, `{"error":{"type":"document_missing_exception","reason":"[7]: document missing"},"status":404}`)
+	})
+
+	if err := client.ChangeSlug(context.Background(), 7, "a", "b"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestChangeSlug_StrictMappingRejected(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusBadRequest, `{"error":{"type":"strict_dynamic_mapping_exception","reason":"mapping set to strict, dynamic introduction of [previous_slugs] within [_doc] is not allowed"},"status":400}`)
+	})
+
+	if err := client.ChangeSlug(context.Background(), 7, "a", "b"); !errors.Is(err, ErrDocumentRejected) {
+		t.Errorf("expected ErrDocumentRejected, got %v", err)
+	}
+}
+
+func TestGetTutorBySlug(t *testing.T) {
+	var body map[string]any
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tutors/_search" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusOK, `{"took":1,"hits":{"total":{"value":1,"relation":"eq"},"hits":[
+			{"_index":"tutors","_id":"7","_score":1,"_source":{"id":7,"slug":"marie-sklodowska-curie","previous_slugs":["marie-curie"]}}
+		]}}`)
+	})
+
+	tutor, err := client.GetTutorBySlug(context.Background(), "marie-curie")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tutor.Slug != "marie-sklodowska-curie" || !slices.Equal(tutor.PreviousSlugs, []string{"marie-curie"}) {
+		t.Errorf("unexpected tutor %+v", tutor)
+	}
+
+	should := body["query"].(map[string]any)["bool"].(map[string]any)["should"].([]any)
+	if len(should) != 2 {
+		t.Fatalf("expected slug and previous_slugs clauses, got %v", should)
+	}
+	for i, field := range []string{"slug", "previous_slugs"} {
+		term := should[i].(map[string]any)["constant_score"].(map[string]any)["filter"].(map[string]any)["term"].(map[string]any)
+		if term[field] != "marie-curie" {
+			t.Errorf("expected %s term, got %v", field, term)
+		}
+	}
+}
+
+func TestGetTutorBySlug_NotFound(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{"took":1,"hits":{"total":{"value":0,"relation":"eq"},"hits":[]}}`)
+	})
+
+	if _, err := client.GetTutorBySlug(context.Background(), "nobody"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestMemoryClient_ChangeSlug(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryClient()
+	now := time.Now()
+	m.UpsertTutor(ctx, &domain.Tutor{ID: 1, Slug: "marie-curie", UpdatedAt: now})
+	m.UpsertTutor(ctx, &domain.Tutor{ID: 2, Slug: "pierre-curie", UpdatedAt: now})
+
+	if err := m.ChangeSlug(ctx, 1, "marie-curie", "marie-sklodowska-curie"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// A later upsert without slug history keeps it.
+	m.UpsertTutor(ctx, &domain.Tutor{ID: 1, Slug: "marie-sklodowska-curie", UpdatedAt: now})
+
+	for _, slug := range []string{"marie-sklodowska-curie", "marie-curie"} {
+		tutor, err := m.GetTutorBySlug(ctx, slug)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", slug, err)
+		}
+		if tutor.ID != 1 || tutor.Slug != "marie-sklodowska-curie" {
+			t.Errorf("%s: unexpected tutor %+v", slug, tutor)
+		}
+	}
+
+	// A tutor that takes a slug over wins against the history.
+	m.UpsertTutor(ctx, &domain.Tutor{ID: 3, Slug: "marie-curie", UpdatedAt: now.Add(-time.Hour)})
+	if tutor, _ := m.GetTutorBySlug(ctx, "marie-curie"); tutor == nil || tutor.ID != 3 {
+		t.Errorf("expected the current slug to win, got %+v", tutor)
+	}
+
+	if _, err := m.GetTutorBySlug(ctx, "nobody"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if err := m.ChangeSlug(ctx, 99, "a", "b"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
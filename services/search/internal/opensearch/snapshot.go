@@ -0,0 +1,129 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	"search/internal/domain"
+)
+
+// tutorDocument is a tutor as stored in the index. SnapshotID is set while
+// the document was last written from a bootstrap snapshot; live writes
+// marshal it as null to clear it. BioSnippet replaces the tutor's own, so
+// it always matches the bio written with it, and Popularity is scored by
+// the client's weights at write time. Lang is the language detected in the
+// headline and bio, and AvailabilityUTC the working hours as UTC minute
+// ranges, using the time zone's offset at write time.
+type tutorDocument struct {
+	*domain.Tutor
+	BioSnippet      string        `json:"bio_snippet"`
+	Popularity      float64       `json:"popularity"`
+	Lang            string        `json:"lang"`
+	AvailabilityUTC []minuteRange `json:"availability_utc_minutes"`
+	SnapshotID      *string       `json:"snapshot_id"`
+}
+
+// minuteRange is a domain.MinuteRange as an integer_range value.
+type minuteRange struct {
+	Gte int `json:"gte"`
+	Lt  int `json:"lt"`
+}
+
+func (c *Client) newTutorDocument(tutor *domain.Tutor, snapshotID *string) tutorDocument {
+	now := time.Now()
+	availability := []minuteRange{}
+	for _, r := range tutor.AvailabilityUTC(now) {
+		availability = append(availability, minuteRange{Gte: r.Start, Lt: r.End})
+	}
+	return tutorDocument{
+		Tutor:           tutor,
+		BioSnippet:      domain.Snippet(tutor.Bio, domain.BioSnippetLength),
+		Popularity:      c.popularity.Popularity(tutor, now),
+		Lang:            domain.DetectLanguage(tutor.Headline + " " + tutor.Bio),
+		AvailabilityUTC: availability,
+		SnapshotID:      snapshotID,
+	}
+}
+
+// snapshotScript overwrites a document only if a snapshot wrote it last.
+// Snapshot records rank below every live event, so once a live write has
+// cleared snapshot_id the document is left alone.
+const snapshotScript = `
+if (ctx._source.snapshot_id == null) {
+  ctx.op = 'none';
+} else {
+  ctx._source.putAll(params.doc);
+}`
+
+// UpsertSnapshotTutor writes tutor from snapshot snapshotID, creating the
+// document if needed. It returns false when a live write already owns the
+// document.
+func (c *Client) UpsertSnapshotTutor(ctx context.Context, tutor *domain.Tutor, snapshotID string) (bool, error) {
+	doc := c.newTutorDocument(tutor, &snapshotID)
+	body, err := json.Marshal(map[string]any{
+		"script": map[string]any{
+			"lang":   "painless",
+			"source": snapshotScript,
+			"params": map[string]any{"doc": doc},
+		},
+		"upsert": doc,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal snapshot tutor: %w", err)
+	}
+
+	var resp *opensearchapi.UpdateResp
+	err = c.throttled(ctx, func() error {
+		var err error
+		resp, err = c.updateTutor(ctx, c.writeIndex(ctx), tutor.ID, body)
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to index snapshot tutor: %w", documentError(err))
+	}
+	c.mirror(ctx, tutor.ID, func(index string) error {
+		_, err := c.updateTutor(ctx, index, tutor.ID, body)
+		return err
+	})
+
+	c.logger.Debug("Snapshot tutor indexed", "id", tutor.ID, "snapshot_id", snapshotID, "result", resp.Result)
+	return resp.Result != "noop", nil
+}
+
+// UpsertSnapshotTutor writes tutor unless a live UpsertTutor reached it first.
+func (m *MemoryClient) UpsertSnapshotTutor(ctx context.Context, tutor *domain.Tutor, snapshotID string) (bool, error) {
+	t := *tutor
+	t.Subjects = slices.Clone(t.Subjects)
+	t.SubjectsDisplay = slices.Clone(t.SubjectsDisplay)
+	t.Formats = slices.Clone(t.Formats)
+	t.Levels = slices.Clone(t.Levels)
+	t.Education = slices.Clone(t.Education)
+	t.Certifications = slices.Clone(t.Certifications)
+	t.Badges = slices.Clone(t.Badges)
+	t.WorkingHours = slices.Clone(t.WorkingHours)
+	t.SetBioSnippet()
+	t.SetPopularity(m.popularity, time.Now())
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	tutors := m.tutors(ctx)
+	key := snapshotKey{index: IndexFor(ctx), id: t.ID}
+	prev, exists := tutors[t.ID]
+	if exists && !m.snapshotted[key] {
+		return false, nil
+	}
+	if exists && t.NextAvailableAt == nil {
+		t.NextAvailableAt = prev.NextAvailableAt
+	}
+	if exists && len(t.Badges) == 0 {
+		t.Badges = prev.Badges
+	}
+	tutors[t.ID] = t
+	m.snapshotted[key] = true
+	return true, nil
+}
@@ -0,0 +1,203 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"search/internal/domain"
+)
+
+func TestUpsertTutor_ClearsSnapshotID(t *testing.T) {
+	var body struct {
+		Doc map[string]any `json:"doc"`
+	}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusOK, `{"_index":"tutors","_id":"7","result":"updated"}`)
+	})
+
+	if err := client.UpsertTutor(context.Background(), &domain.Tutor{ID: 7, FullName: "Jane"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	v, ok := body.Doc["snapshot_id"]
+	if !ok || v != nil {
+		t.Errorf("expected snapshot_id: null in the live doc, got %v (present %v)", v, ok)
+	}
+	if body.Doc["full_name"] != "Jane" {
+		t.Errorf("expected tutor fields in the doc, got %v", body.Doc)
+	}
+}
+
+func TestUpsertTutor_GeneratesBioSnippet(t *testing.T) {
+	var body struct {
+		Doc map[string]any `json:"doc"`
+	}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusOK, `{"_index":"tutors","_id":"7","result":"updated"}`)
+	})
+
+	bio := "Patient maths tutor. " + strings.Repeat("Exam preparation for all levels. ", 10)
+	tutor := &domain.Tutor{ID: 7, Bio: bio, BioSnippet: "stale"}
+	if err := client.UpsertTutor(context.Background(), tutor); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := domain.Snippet(bio, domain.BioSnippetLength)
+	if body.Doc["bio_snippet"] != want || body.Doc["bio"] != bio {
+		t.Errorf("expected bio and bio_snippet %q, got %v and %v", want, body.Doc["bio"], body.Doc["bio_snippet"])
+	}
+	if tutor.BioSnippet != "stale" {
+		t.Errorf("expected the caller's tutor to be left alone, got %q", tutor.BioSnippet)
+	}
+}
+
+func TestUpsertTutor_DetectsLanguage(t *testing.T) {
+	var body struct {
+		Doc map[string]any `json:"doc"`
+	}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusOK, `{"_index":"tutors","_id":"7","result":"updated"}`)
+	})
+
+	tutor := &domain.Tutor{ID: 7, Headline: "Репетитор по физике", Bio: "Готовлю к олимпиадам и ЕГЭ."}
+	if err := client.UpsertTutor(context.Background(), tutor); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body.Doc["lang"] != domain.LanguageRussian {
+		t.Errorf("expected lang %q, got %v", domain.LanguageRussian, body.Doc["lang"])
+	}
+}
+
+func TestUpsertTutor_RejectedDocument(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		wantRejected bool
+	}{
+		{
+			name:         "mapping error",
+			status:       http.StatusBadRequest,
+			body:         `{"error":{"type":"mapper_parsing_exception","reason":"failed to parse field [hourly_rate] of type [float]"},"status":400}`,
+			wantRejected: true,
+		},
+		{
+			name:   "server error",
+			status: http.StatusInternalServerError,
+			body:   `{"error":{"type":"exception","reason":"boom"},"status":500}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(w, tt.status, tt.body)
+			})
+
+			err := client.UpsertTutor(context.Background(), &domain.Tutor{ID: 7})
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if got := errors.Is(err, ErrDocumentRejected); got != tt.wantRejected {
+				t.Errorf("expected rejected %v, got %v: %v", tt.wantRejected, got, err)
+			}
+			if tt.wantRejected && !strings.Contains(err.Error(), "mapper_parsing_exception") {
+				t.Errorf("expected the reason in the error, got %v", err)
+			}
+			_, err = client.UpsertSnapshotTutor(context.Background(), &domain.Tutor{ID: 7}, "snap-1")
+			if got := errors.Is(err, ErrDocumentRejected); got != tt.wantRejected {
+				t.Errorf("snapshot: expected rejected %v, got %v: %v", tt.wantRejected, got, err)
+			}
+		})
+	}
+}
+
+func TestUpsertSnapshotTutor(t *testing.T) {
+	tests := []struct {
+		result      string
+		wantApplied bool
+	}{
+		{"created", true},
+		{"updated", true},
+		{"noop", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.result, func(t *testing.T) {
+			var body struct {
+				Script struct {
+					Source string `json:"source"`
+					Params struct {
+						Doc map[string]any `json:"doc"`
+					} `json:"params"`
+				} `json:"script"`
+				Upsert map[string]any `json:"upsert"`
+			}
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/tutors/_update/7" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				json.NewDecoder(r.Body).Decode(&body)
+				writeJSON(w, http.StatusOK, `{"_index":"tutors","_id":"7","result":"`+tt.result+`"}`)
+			})
+
+			applied, err := client.UpsertSnapshotTutor(context.Background(), &domain.Tutor{ID: 7, FullName: "Jane"}, "snap-1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if applied != tt.wantApplied {
+				t.Errorf("expected applied %v, got %v", tt.wantApplied, applied)
+			}
+
+			if !strings.Contains(body.Script.Source, "ctx._source.snapshot_id == null") {
+				t.Errorf("expected the script to skip live documents, got %s", body.Script.Source)
+			}
+			if body.Script.Params.Doc["snapshot_id"] != "snap-1" || body.Upsert["snapshot_id"] != "snap-1" {
+				t.Errorf("expected snapshot_id in doc and upsert, got %v and %v", body.Script.Params.Doc, body.Upsert)
+			}
+		})
+	}
+}
+
+func TestMemoryClient_UpsertSnapshotTutor(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryClient()
+
+	apply := func(headline string) bool {
+		t.Helper()
+		applied, err := m.UpsertSnapshotTutor(ctx, &domain.Tutor{ID: 1, Headline: headline}, "snap-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return applied
+	}
+	headline := func() string {
+		t.Helper()
+		tutor, err := m.GetTutor(ctx, 1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return tutor.Headline
+	}
+
+	if !apply("first") || !apply("second") || headline() != "second" {
+		t.Fatalf("expected snapshot records to overwrite each other, got %q", headline())
+	}
+
+	m.UpsertTutor(ctx, &domain.Tutor{ID: 1, Headline: "live"})
+	if apply("third") || headline() != "live" {
+		t.Errorf("expected the live write to win, got %q", headline())
+	}
+
+	m.DeleteTutor(ctx, 1)
+	if !apply("after delete") || headline() != "after delete" {
+		t.Errorf("expected a snapshot record to index a missing tutor, got %q", headline())
+	}
+}
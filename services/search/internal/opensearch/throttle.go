@@ -0,0 +1,61 @@
+package opensearch
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/opensearch-project/opensearch-go/v4"
+
+	"search/internal/limiter"
+)
+
+// maxThrottleRetries bounds how often a write OpenSearch rejects with 429
+// is retried before the rejection is returned.
+const maxThrottleRetries = 5
+
+// WithIndexingRate paces tutor writes with rate and retries those OpenSearch
+// rejects with 429, halving the rate each time. Without it writes go out
+// as fast as they come and a 429 fails them.
+func WithIndexingRate(rate *limiter.Adaptive) ClientOption {
+	return func(c *Client) error {
+		c.indexRate = rate
+		return nil
+	}
+}
+
+// throttled runs write, one document's worth, under c.indexRate, retrying
+// it while OpenSearch rejects it with 429.
+func (c *Client) throttled(ctx context.Context, write func() error) error {
+	if c.indexRate == nil {
+		return write()
+	}
+	for attempt := 0; ; attempt++ {
+		if err := c.indexRate.Wait(ctx, 1); err != nil {
+			return err
+		}
+		err := write()
+		if !isTooManyRequests(err) {
+			if err == nil {
+				c.indexRate.Succeeded(1)
+			}
+			return err
+		}
+		c.indexRate.Throttled()
+		if attempt == maxThrottleRetries {
+			return err
+		}
+		c.logger.Debug("Retrying write rejected by OpenSearch", "attempt", attempt+1, "rate", c.indexRate.Rate())
+	}
+}
+
+// isTooManyRequests reports whether err is OpenSearch's 429, sent when a
+// thread pool queue is full (es_rejected_execution_exception).
+func isTooManyRequests(err error) bool {
+	var se *opensearch.StructError
+	if errors.As(err, &se) {
+		return se.Status == http.StatusTooManyRequests
+	}
+	var str *opensearch.StringError
+	return errors.As(err, &str) && str.Status == http.StatusTooManyRequests
+}
@@ -0,0 +1,122 @@
+package opensearch
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"search/internal/domain"
+	"search/internal/limiter"
+	"search/internal/testutil"
+)
+
+const rejectedExecution = `{"error":{"type":"es_rejected_execution_exception","reason":"rejected execution of coordinating operation"},"status":429}`
+
+func newIndexRate(t *testing.T) *limiter.Adaptive {
+	return limiter.NewAdaptive(1000, testutil.NewLogger(t))
+}
+
+func TestUpsertTutor_RetriesAfter429(t *testing.T) {
+	var requests atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= 3 {
+			writeJSON(w, http.StatusTooManyRequests, rejectedExecution)
+			return
+		}
+		writeJSON(w, http.StatusOK, `{"_id":"1","result":"updated"}`)
+	})
+	client.indexRate = newIndexRate(t)
+
+	if err := client.UpsertTutor(context.Background(), &domain.Tutor{ID: 1}); err != nil {
+		t.Fatalf("UpsertTutor: %v", err)
+	}
+	if n := requests.Load(); n != 4 {
+		t.Errorf("expected 3 rejections and a success, got %d requests", n)
+	}
+	if rate := client.indexRate.Rate(); rate != 125+5 {
+		t.Errorf("expected the rate halved three times and nudged back up, got %g", rate)
+	}
+}
+
+func TestUpsertTutor_GivesUpAfterRepeated429s(t *testing.T) {
+	var requests atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		writeJSON(w, http.StatusTooManyRequests, rejectedExecution)
+	})
+	client.indexRate = newIndexRate(t)
+
+	err := client.UpsertTutor(context.Background(), &domain.Tutor{ID: 1})
+	if !isTooManyRequests(err) {
+		t.Errorf("expected the 429 once retries run out, got %v", err)
+	}
+	if n := requests.Load(); n != maxThrottleRetries+1 {
+		t.Errorf("expected %d attempts, got %d", maxThrottleRetries+1, n)
+	}
+}
+
+func TestUpsertTutor_429WithoutIndexingRate(t *testing.T) {
+	var requests atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		writeJSON(w, http.StatusTooManyRequests, rejectedExecution)
+	})
+
+	if err := client.UpsertTutor(context.Background(), &domain.Tutor{ID: 1}); err == nil {
+		t.Fatal("expected the 429 to fail the write")
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("expected no retries, got %d requests", n)
+	}
+}
+
+func TestBulkDeleteTutors_RetriesRejectedItems(t *testing.T) {
+	var bodies []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/_refresh") {
+			writeJSON(w, http.StatusOK, `{}`)
+			return
+		}
+		var ids []string
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			ids = append(ids, scanner.Text())
+		}
+		bodies = append(bodies, strings.Join(ids, ","))
+
+		if len(bodies) == 1 {
+			writeJSON(w, http.StatusOK, `{"errors":true,"items":[
+				{"delete":{"_id":"1","status":200,"result":"deleted"}},
+				{"delete":{"_id":"2","status":429,"error":{"type":"es_rejected_execution_exception","reason":"queue full"}}},
+				{"delete":{"_id":"3","status":404,"result":"not_found"}},
+				{"delete":{"_id":"4","status":429,"error":{"type":"es_rejected_execution_exception","reason":"queue full"}}}
+			]}`)
+			return
+		}
+		writeJSON(w, http.StatusOK, `{"errors":false,"items":[
+			{"delete":{"_id":"2","status":200,"result":"deleted"}},
+			{"delete":{"_id":"4","status":200,"result":"deleted"}}
+		]}`)
+	})
+	client.indexRate = newIndexRate(t)
+
+	results, err := client.BulkDeleteTutors(context.Background(), []int64{1, 2, 3, 4})
+	if err != nil {
+		t.Fatalf("BulkDeleteTutors: %v", err)
+	}
+	want := []string{BulkDeleted, BulkDeleted, BulkNotFound, BulkDeleted}
+	for i, r := range results {
+		if r.Status != want[i] || r.Error != "" {
+			t.Errorf("result %d = %+v, want %s", i, r, want[i])
+		}
+	}
+	if len(bodies) != 2 || bodies[1] != `{"delete":{"_id":"2"}},{"delete":{"_id":"4"}}` {
+		t.Errorf("expected only the rejected items resent, got %q", bodies)
+	}
+	if rate := client.indexRate.Rate(); rate >= 1000 {
+		t.Errorf("expected the rate lowered after the rejections, got %g", rate)
+	}
+}
@@ -0,0 +1,159 @@
+package opensearch
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+
+	"search/internal/domain"
+)
+
+// ratingSort orders tutors best rated first, more reviews breaking ties,
+// then ID for a stable order. It backs TopTutorsBySubject and SortRating.
+var ratingSort = []map[string]any{
+	{"rating": map[string]any{"order": "desc"}},
+	{"reviews_count": map[string]any{"order": "desc"}},
+	{"id": map[string]any{"order": "asc"}},
+}
+
+// popularitySort orders tutors most popular first, then like ratingSort.
+var popularitySort = append([]map[string]any{
+	{"popularity": map[string]any{"order": "desc"}},
+}, ratingSort...)
+
+// TopTutorsBySubject fetches every subject's best rated tutors in one
+// request: a terms aggregation on subjects with a top_hits sub-aggregation.
+func (c *Client) TopTutorsBySubject(ctx context.Context, subjects []string, perSubject int) (map[string][]domain.Tutor, error) {
+	body, err := json.Marshal(buildTopTutorsQuery(subjects, perSubject))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal top tutors query: %w", err)
+	}
+
+	resp, err := c.client.Search(ctx, &opensearchapi.SearchReq{
+		Indices: []string{c.readIndex(ctx)},
+		Body:    bytes.NewReader(body),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search top tutors: %w", err)
+	}
+
+	var aggs struct {
+		BySubject struct {
+			Buckets []struct {
+				Key string `json:"key"`
+				Top struct {
+					Hits struct {
+						Hits []struct {
+							Source json.RawMessage `json:"_source"`
+						} `json:"hits"`
+					} `json:"hits"`
+				} `json:"top"`
+			} `json:"buckets"`
+		} `json:"by_subject"`
+	}
+	if err := json.Unmarshal(resp.Aggregations, &aggs); err != nil {
+		return nil, fmt.Errorf("failed to decode top tutors aggregation: %w", err)
+	}
+
+	result := emptyTopTutors(subjects)
+	for _, bucket := range aggs.BySubject.Buckets {
+		if _, ok := result[bucket.Key]; !ok {
+			continue
+		}
+		for _, hit := range bucket.Top.Hits.Hits {
+			var tutor domain.Tutor
+			if err := json.Unmarshal(hit.Source, &tutor); err != nil {
+				c.logger.Warn("Failed to unmarshal tutor", "error", err)
+				continue
+			}
+			result[bucket.Key] = append(result[bucket.Key], tutor)
+		}
+	}
+	return result, nil
+}
+
+// buildTopTutorsQuery returns a hitless search whose by_subject buckets,
+// one per requested subject, each hold its perSubject best rated tutors.
+func buildTopTutorsQuery(subjects []string, perSubject int) map[string]any {
+	return map[string]any{
+		"size": 0,
+		"query": map[string]any{
+			"bool": map[string]any{
+				"filter": []map[string]any{
+					{"terms": map[string]any{"subjects": subjects}},
+				},
+			},
+		},
+		"aggs": map[string]any{
+			"by_subject": map[string]any{
+				"terms": map[string]any{
+					"field": "subjects",
+					// A tutor's other subjects would otherwise get buckets too.
+					"include": subjects,
+					"size":    len(subjects),
+				},
+				"aggs": map[string]any{
+					"top": map[string]any{
+						"top_hits": map[string]any{
+							"size": perSubject,
+							"sort": ratingSort,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// emptyTopTutors returns a result with an empty list for every subject, so
+// subjects nobody teaches are still present.
+func emptyTopTutors(subjects []string) map[string][]domain.Tutor {
+	result := make(map[string][]domain.Tutor, len(subjects))
+	for _, s := range subjects {
+		result[s] = []domain.Tutor{}
+	}
+	return result
+}
+
+func (m *MemoryClient) TopTutorsBySubject(ctx context.Context, subjects []string, perSubject int) (map[string][]domain.Tutor, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := emptyTopTutors(subjects)
+	for _, t := range m.indices[IndexFor(ctx)] {
+		for _, s := range t.Subjects {
+			if _, ok := result[s]; ok {
+				result[s] = append(result[s], t)
+			}
+		}
+	}
+	for s, tutors := range result {
+		slices.SortFunc(tutors, compareByRating)
+		result[s] = tutors[:min(perSubject, len(tutors))]
+	}
+	return result, nil
+}
+
+// compareByRating mirrors ratingSort.
+func compareByRating(a, b domain.Tutor) int {
+	if c := cmp.Compare(b.Rating, a.Rating); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(b.ReviewsCount, a.ReviewsCount); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.ID, b.ID)
+}
+
+// compareByPopularity mirrors popularitySort.
+func compareByPopularity(a, b domain.Tutor) int {
+	if c := cmp.Compare(b.Popularity, a.Popularity); c != 0 {
+		return c
+	}
+	return compareByRating(a, b)
+}
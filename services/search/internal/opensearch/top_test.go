@@ -0,0 +1,149 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"search/internal/domain"
+)
+
+func TestBuildTopTutorsQuery(t *testing.T) {
+	subjects := []string{"math", "physics"}
+	raw, err := json.Marshal(buildTopTutorsQuery(subjects, 4))
+	if err != nil {
+		t.Fatalf("failed to marshal query: %v", err)
+	}
+
+	var q struct {
+		Size  int `json:"size"`
+		Query struct {
+			Bool struct {
+				Filter []struct {
+					Terms map[string][]string `json:"terms"`
+				} `json:"filter"`
+			} `json:"bool"`
+		} `json:"query"`
+		Aggs struct {
+			BySubject struct {
+				Terms struct {
+					Field   string   `json:"field"`
+					Include []string `json:"include"`
+					Size    int      `json:"size"`
+				} `json:"terms"`
+				Aggs struct {
+					Top struct {
+						TopHits struct {
+							Size int                            `json:"size"`
+							Sort []map[string]map[string]string `json:"sort"`
+						} `json:"top_hits"`
+					} `json:"top"`
+				} `json:"aggs"`
+			} `json:"by_subject"`
+		} `json:"aggs"`
+	}
+	if err := json.Unmarshal(raw, &q); err != nil {
+		t.Fatalf("failed to decode query: %v", err)
+	}
+
+	if q.Size != 0 {
+		t.Errorf("expected no top-level hits, got size %d", q.Size)
+	}
+	if len(q.Query.Bool.Filter) != 1 || !slices.Equal(q.Query.Bool.Filter[0].Terms["subjects"], subjects) {
+		t.Errorf("expected a subjects terms filter, got %s", raw)
+	}
+	terms := q.Aggs.BySubject.Terms
+	if terms.Field != "subjects" || !slices.Equal(terms.Include, subjects) || terms.Size != 2 {
+		t.Errorf("expected one bucket per requested subject, got %+v", terms)
+	}
+	hits := q.Aggs.BySubject.Aggs.Top.TopHits
+	if hits.Size != 4 {
+		t.Errorf("expected 4 hits per subject, got %d", hits.Size)
+	}
+	if len(hits.Sort) == 0 || hits.Sort[0]["rating"]["order"] != "desc" {
+		t.Errorf("expected hits sorted by rating desc first, got %v", hits.Sort)
+	}
+}
+
+func TestTopTutorsBySubject(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/tutors/_search" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		writeJSON(w, http.StatusOK, `{
+			"took": 1, "timed_out": false,
+			"_shards": {"total": 1, "successful": 1, "skipped": 0, "failed": 0},
+			"hits": {"total": {"value": 3, "relation": "eq"}, "hits": []},
+			"aggregations": {"by_subject": {"buckets": [
+				{"key": "math", "doc_count": 2, "top": {"hits": {"hits": [
+					{"_source": {"id": 3, "full_name": "Ada Lovelace", "rating": 4.9}},
+					{"_source": {"id": 2, "full_name": "Alan Turing", "rating": 4.6}}
+				]}}},
+				{"key": "physics", "doc_count": 1, "top": {"hits": {"hits": [
+					{"_source": {"id": 1, "full_name": "Marie Curie", "rating": 5}}
+				]}}}
+			]}}
+		}`)
+	})
+
+	result, err := client.TopTutorsBySubject(context.Background(), []string{"math", "physics", "english"}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string][]int64{"math": {3, 2}, "physics": {1}, "english": {}}
+	if len(result) != len(want) {
+		t.Errorf("expected subjects %v, got %v", want, result)
+	}
+	for subject, ids := range want {
+		tutors, ok := result[subject]
+		if !ok {
+			t.Errorf("expected %s in the result", subject)
+			continue
+		}
+		if got := tutorIDs(tutors); !slices.Equal(got, ids) {
+			t.Errorf("%s: expected tutors %v, got %v", subject, ids, got)
+		}
+	}
+}
+
+func TestMemoryClient_TopTutorsBySubject(t *testing.T) {
+	client := NewMemoryClient()
+	ctx := context.Background()
+	for _, tutor := range []domain.Tutor{
+		{ID: 1, Subjects: []string{"physics", "chemistry"}, Rating: 5, ReviewsCount: 10},
+		{ID: 2, Subjects: []string{"math"}, Rating: 4.6, ReviewsCount: 8},
+		{ID: 3, Subjects: []string{"math", "programming"}, Rating: 4.9, ReviewsCount: 20},
+		{ID: 4, Subjects: []string{"math"}, Rating: 4.9, ReviewsCount: 30},
+	} {
+		client.UpsertTutor(ctx, &tutor)
+	}
+
+	result, err := client.TopTutorsBySubject(ctx, []string{"math", "physics", "english"}, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := map[string][]int64{"math": {4, 3}, "physics": {1}, "english": {}}
+	if len(result) != len(want) {
+		t.Errorf("expected subjects %v, got %v", want, result)
+	}
+	for subject, ids := range want {
+		if got := tutorIDs(result[subject]); !slices.Equal(got, ids) {
+			t.Errorf("%s: expected tutors %v, got %v", subject, ids, got)
+		}
+	}
+	if result["english"] == nil {
+		t.Error("expected an empty list, not nil, for a subject nobody teaches")
+	}
+}
+
+func tutorIDs(tutors []domain.Tutor) []int64 {
+	ids := make([]int64, len(tutors))
+	for i, tutor := range tutors {
+		ids[i] = tutor.ID
+	}
+	return ids
+}
@@ -0,0 +1,121 @@
+package opensearch
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptrace"
+	"sync/atomic"
+	"time"
+
+	"search/internal/port"
+)
+
+// TransportSettings tunes the pool of connections to the cluster.
+type TransportSettings struct {
+	// MaxIdleConns caps the idle connections kept across all nodes, zero
+	// for no cap; MaxIdleConnsPerHost caps them per node.
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout closes a connection idle for longer, zero never.
+	IdleConnTimeout time.Duration
+	// TLSHandshakeTimeout bounds the TLS handshake of a new connection,
+	// zero leaving it unbounded.
+	TLSHandshakeTimeout time.Duration
+	// ResponseHeaderTimeout bounds the wait for a response's headers once
+	// a request is sent, zero leaving it to the request's context.
+	ResponseHeaderTimeout time.Duration
+}
+
+// DefaultTransportSettings keeps enough idle connections per node for
+// concurrent searches and writes to reuse them, where
+// http.DefaultTransport keeps two and dials anew for the rest.
+var DefaultTransportSettings = TransportSettings{
+	MaxIdleConns:          100,
+	MaxIdleConnsPerHost:   100,
+	IdleConnTimeout:       90 * time.Second,
+	TLSHandshakeTimeout:   10 * time.Second,
+	ResponseHeaderTimeout: 30 * time.Second,
+}
+
+// Validate checks no count or timeout is negative.
+func (s TransportSettings) Validate() error {
+	var errs []error
+	if s.MaxIdleConns < 0 {
+		errs = append(errs, fmt.Errorf("max idle connections must not be negative, got %d", s.MaxIdleConns))
+	}
+	if s.MaxIdleConnsPerHost < 0 {
+		errs = append(errs, fmt.Errorf("max idle connections per host must not be negative, got %d", s.MaxIdleConnsPerHost))
+	}
+	for _, timeout := range []struct {
+		name string
+		d    time.Duration
+	}{
+		{"idle connection timeout", s.IdleConnTimeout},
+		{"TLS handshake timeout", s.TLSHandshakeTimeout},
+		{"response header timeout", s.ResponseHeaderTimeout},
+	} {
+		if timeout.d < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative, got %s", timeout.name, timeout.d))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithTransportSettings sends requests through a transport pooling
+// connections per s instead of DefaultTransportSettings.
+func WithTransportSettings(s TransportSettings) ClientOption {
+	return func(c *Client) error {
+		if err := s.Validate(); err != nil {
+			return fmt.Errorf("transport settings: %w", err)
+		}
+		c.config.Transport = newTransport(s)
+		return nil
+	}
+}
+
+// newTransport returns http.DefaultTransport's proxy, dialer and HTTP/2
+// setup with the pool sized and timed per s.
+func newTransport(s TransportSettings) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConns = s.MaxIdleConns
+	t.MaxIdleConnsPerHost = s.MaxIdleConnsPerHost
+	t.IdleConnTimeout = s.IdleConnTimeout
+	t.TLSHandshakeTimeout = s.TLSHandshakeTimeout
+	t.ResponseHeaderTimeout = s.ResponseHeaderTimeout
+	return t
+}
+
+// connCounter counts, through an httptrace hook on every request, whether
+// next sent it on a new connection or a pooled one. Only transports built
+// on http.Transport report either. It also notes every attempt in the
+// request's port.Timing: the client library retries by sending the same
+// *http.Request again.
+type connCounter struct {
+	next   http.RoundTripper
+	logger *slog.Logger
+	dialed atomic.Uint64
+	reused atomic.Uint64
+}
+
+func (cc *connCounter) RoundTrip(req *http.Request) (*http.Response, error) {
+	port.TimingFrom(req.Context()).Attempt(req)
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				cc.reused.Add(1)
+				return
+			}
+			cc.dialed.Add(1)
+			cc.logger.Debug("Opened OpenSearch connection", "addr", info.Conn.RemoteAddr().String())
+		},
+	}
+	return cc.next.RoundTrip(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+}
+
+// ConnectionStats counts the connections requests to the cluster were sent
+// on since the client was created.
+func (c *Client) ConnectionStats() port.ConnectionStats {
+	return port.ConnectionStats{New: c.conns.dialed.Load(), Reused: c.conns.reused.Load()}
+}
@@ -0,0 +1,207 @@
+package opensearch
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+// transportOf returns the http.Transport under client's connection
+// counter.
+func transportOf(t *testing.T, client *Client) *http.Transport {
+	t.Helper()
+	tr, ok := client.conns.next.(*http.Transport)
+	if !ok {
+		t.Fatalf("transport is %T, want *http.Transport", client.conns.next)
+	}
+	return tr
+}
+
+func TestNewClient_DefaultTransportSettings(t *testing.T) {
+	client, err := NewClient("http://localhost:9200", testutil.NewLogger(t))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	tr := transportOf(t, client)
+	if tr.MaxIdleConns != 100 || tr.MaxIdleConnsPerHost != 100 || tr.IdleConnTimeout != 90*time.Second ||
+		tr.TLSHandshakeTimeout != 10*time.Second || tr.ResponseHeaderTimeout != 30*time.Second {
+		t.Errorf("transport = %+v, want DefaultTransportSettings", tr)
+	}
+	if tr == http.DefaultTransport {
+		t.Error("the client must not share http.DefaultTransport")
+	}
+}
+
+func TestWithTransportSettings(t *testing.T) {
+	s := TransportSettings{
+		MaxIdleConns:          20,
+		MaxIdleConnsPerHost:   10,
+		IdleConnTimeout:       time.Minute,
+		TLSHandshakeTimeout:   3 * time.Second,
+		ResponseHeaderTimeout: 5 * time.Second,
+	}
+	client, err := NewClient("http://localhost:9200", testutil.NewLogger(t), WithTransportSettings(s))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	tr := transportOf(t, client)
+	got := TransportSettings{
+		MaxIdleConns:          tr.MaxIdleConns,
+		MaxIdleConnsPerHost:   tr.MaxIdleConnsPerHost,
+		IdleConnTimeout:       tr.IdleConnTimeout,
+		TLSHandshakeTimeout:   tr.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: tr.ResponseHeaderTimeout,
+	}
+	if got != s {
+		t.Errorf("transport settings = %+v, want %+v", got, s)
+	}
+	if tr.Proxy == nil || tr.DialContext == nil {
+		t.Error("expected the proxy and dialer of http.DefaultTransport to be kept")
+	}
+}
+
+func TestWithTransportSettings_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		s    TransportSettings
+		want string
+	}{
+		{"max idle", TransportSettings{MaxIdleConns: -1}, "max idle connections must not be negative"},
+		{"max idle per host", TransportSettings{MaxIdleConnsPerHost: -1}, "per host must not be negative"},
+		{"idle timeout", TransportSettings{IdleConnTimeout: -time.Second}, "idle connection timeout must not be negative"},
+		{"TLS handshake", TransportSettings{TLSHandshakeTimeout: -time.Second}, "TLS handshake timeout must not be negative"},
+		{"response header", TransportSettings{ResponseHeaderTimeout: -time.Second}, "response header timeout must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewClient("http://localhost:9200", testutil.NewLogger(t), WithTransportSettings(tt.s))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("err = %v, want one containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConnectionStats_SequentialRequestsReuseConnection(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, `{"cluster_name":"test","version":{"number":"2.11.0"}}`)
+	})
+
+	for i := range 5 {
+		if err := client.Ping(context.Background()); err != nil {
+			t.Fatalf("ping %d: %v", i, err)
+		}
+	}
+
+	if got := client.ConnectionStats(); got.New != 1 || got.Reused != 4 {
+		t.Errorf("stats = %+v, want 1 new and 4 reused", got)
+	}
+}
+
+// burst sends n pings at once, held by the server until all n have
+// arrived so each needs a connection of its own.
+func burst(t *testing.T, client *Client, arrived *sync.WaitGroup, n int) {
+	t.Helper()
+	arrived.Add(n)
+	var wg sync.WaitGroup
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := client.Ping(context.Background()); err != nil {
+				t.Errorf("ping: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestConnectionStats_PoolKeepsConcurrentConnections(t *testing.T) {
+	const concurrent = 8
+	tests := []struct {
+		name       string
+		perHost    int
+		wantReused uint64
+	}{
+		{"default settings", DefaultTransportSettings.MaxIdleConnsPerHost, concurrent},
+		// http.DefaultTransport's pool size: all but two are dialed again.
+		{"two idle per host", 2, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var arrived sync.WaitGroup
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				arrived.Done()
+				arrived.Wait()
+				writeJSON(w, http.StatusOK, `{"cluster_name":"test","version":{"number":"2.11.0"}}`)
+			}))
+			t.Cleanup(server.Close)
+
+			s := DefaultTransportSettings
+			s.MaxIdleConnsPerHost = tt.perHost
+			client, err := NewClient(server.URL, testutil.NewLogger(t), WithTransportSettings(s), WithRetry(0, 0))
+			if err != nil {
+				t.Fatalf("NewClient: %v", err)
+			}
+
+			burst(t, client, &arrived, concurrent)
+			first := client.ConnectionStats()
+			if first.New != concurrent || first.Reused != 0 {
+				t.Fatalf("first burst stats = %+v, want %d new", first, concurrent)
+			}
+
+			burst(t, client, &arrived, concurrent)
+			got := client.ConnectionStats()
+			if reused := got.Reused - first.Reused; reused != tt.wantReused {
+				t.Errorf("second burst reused %d connections, want %d (stats %+v)", reused, tt.wantReused, got)
+			}
+		})
+	}
+}
+
+func TestTiming_CountsRetriesAndTook(t *testing.T) {
+	var searches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tutors/_search" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if searches.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		writeJSON(w, http.StatusOK, `{"took":7,"hits":{"total":{"value":0},"hits":[]},"_shards":{"total":1,"failed":0}}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(server.URL, testutil.NewLogger(t), WithRetry(2, 0))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	ctx, timing := port.NewTimingContext(context.Background())
+	if _, err := client.SearchTutors(ctx, SearchQuery{}); err != nil {
+		t.Fatalf("SearchTutors: %v", err)
+	}
+	if timing.Retries() != 1 || timing.Took() != 7*time.Millisecond {
+		t.Errorf("timing = %d retries, %s took; want 1 and 7ms", timing.Retries(), timing.Took())
+	}
+
+	// A second search is a new request, not a retry.
+	if _, err := client.SearchTutors(ctx, SearchQuery{}); err != nil {
+		t.Fatalf("SearchTutors: %v", err)
+	}
+	if timing.Retries() != 1 || timing.Took() != 14*time.Millisecond {
+		t.Errorf("timing = %d retries, %s took; want 1 and 14ms", timing.Retries(), timing.Took())
+	}
+}
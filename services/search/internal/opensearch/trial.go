@@ -0,0 +1,58 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// SetOffersTrial sets a tutor's offers_trial with a partial update, so
+// concurrent profile writes keep their other fields. OpenSearch skips the
+// write when the tutor already has the value.
+func (c *Client) SetOffersTrial(ctx context.Context, tutorID int64, offers bool) error {
+	body, err := json.Marshal(map[string]any{
+		"doc": map[string]any{"offers_trial": offers},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal trial update: %w", err)
+	}
+
+	retries := updateRetries
+	resp, err := c.client.Update(ctx, opensearchapi.UpdateReq{
+		Index:      c.writeIndex(ctx),
+		DocumentID: strconv.FormatInt(tutorID, 10),
+		Body:       bytes.NewReader(body),
+		Params: opensearchapi.UpdateParams{
+			Refresh:         string(c.refresh),
+			RetryOnConflict: &retries,
+		},
+	})
+	if err != nil {
+		if isDocumentMissing(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to update tutor trial setting: %w", err)
+	}
+
+	c.logger.Debug("Tutor trial setting updated", "id", tutorID, "offers_trial", offers, "result", resp.Result)
+	return nil
+}
+
+// SetOffersTrial updates the stored tutor's offers_trial.
+func (m *MemoryClient) SetOffersTrial(ctx context.Context, tutorID int64, offers bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tutors := m.tutors(ctx)
+	t, ok := tutors[tutorID]
+	if !ok {
+		return ErrNotFound
+	}
+	t.OffersTrial = offers
+	tutors[tutorID] = t
+	return nil
+}
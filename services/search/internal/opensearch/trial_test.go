@@ -0,0 +1,59 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"search/internal/domain"
+)
+
+func TestSetOffersTrial(t *testing.T) {
+	var body struct {
+		Doc map[string]any `json:"doc"`
+	}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/tutors/_update/7" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusOK, `{"_index":"tutors","_id":"7","result":"updated"}`)
+	})
+
+	if err := client.SetOffersTrial(context.Background(), 7, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(body.Doc) != 1 || body.Doc["offers_trial"] != true {
+		t.Errorf("expected a partial update of offers_trial only, got %v", body.Doc)
+	}
+}
+
+func TestSetOffersTrial_DocumentMissing(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, `{"error":{"type":"document_missing_exception","reason":"[7]: document missing"},"status":404}`)
+	})
+
+	if err := client.SetOffersTrial(context.Background(), 7, true); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestMemoryClient_SetOffersTrial(t *testing.T) {
+	m := NewMemoryClient()
+	ctx := context.Background()
+	m.UpsertTutor(ctx, &domain.Tutor{ID: 1, FullName: "Ada Lovelace"})
+
+	if err := m.SetOffersTrial(ctx, 1, true); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, _ := m.GetTutor(ctx, 1)
+	if !got.OffersTrial || got.FullName != "Ada Lovelace" {
+		t.Errorf("expected the trial set and other fields kept, got %+v", got)
+	}
+
+	if err := m.SetOffersTrial(ctx, 99, true); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
@@ -4,90 +4,260 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"slices"
 	"strconv"
+	"time"
 
+	"github.com/opensearch-project/opensearch-go/v4"
 	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
 
 	"search/internal/domain"
+	"search/internal/port"
 )
 
-type SearchQuery struct {
-	Text      string
-	Subjects  []string
-	MinPrice  *float64
-	MaxPrice  *float64
-	MinRating *float64
-	Format    string
-	Location  string
-	Limit     int
-	Offset    int
-}
-
-type SearchResponse struct {
-	Results []domain.Tutor `json:"results"`
-	Total   int            `json:"total"`
-}
-
+// UpsertTutor writes tutor as a partial update, creating the document if
+// needed. Fields owned by other aggregates, like next_available_at, are left
+// alone when tutor does not set them. It clears snapshot_id, so later
+// snapshot records for the tutor are ignored.
 func (c *Client) UpsertTutor(ctx context.Context, tutor *domain.Tutor) error {
-	body, err := json.Marshal(tutor)
+	body, err := json.Marshal(map[string]any{
+		"doc":           c.newTutorDocument(tutor, nil),
+		"doc_as_upsert": true,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to marshal tutor: %w", err)
 	}
 
-	_, err = c.client.Index(ctx, opensearchapi.IndexReq{
-		Index:      IndexName,
-		DocumentID: strconv.FormatInt(tutor.ID, 10),
-		Body:       bytes.NewReader(body),
-		Params: opensearchapi.IndexParams{
-			Refresh: "true",
-		},
-	})
-	if err != nil {
-		return fmt.Errorf("failed to index tutor: %w", err)
+	update := func(index string) error {
+		_, err := c.updateTutor(ctx, index, tutor.ID, body)
+		return err
 	}
+	if err := c.throttled(ctx, func() error { return update(c.writeIndex(ctx)) }); err != nil {
+		return fmt.Errorf("failed to index tutor: %w", documentError(err))
+	}
+	c.mirror(ctx, tutor.ID, update)
 
 	c.logger.Debug("Tutor indexed", "id", tutor.ID)
 	return nil
 }
 
-func (c *Client) DeleteTutor(ctx context.Context, id int64) error {
-	resp, err := c.client.Document.Delete(ctx, opensearchapi.DocumentDeleteReq{
-		Index:      IndexName,
+// updateTutor sends the update body for tutor id to index. A write to an
+// alias, the write alias or an experiment's, requires the alias to exist,
+// so one racing its removal cannot create an index with a dynamic mapping.
+func (c *Client) updateTutor(ctx context.Context, index string, id int64, body []byte) (*opensearchapi.UpdateResp, error) {
+	retries := updateRetries
+	params := opensearchapi.UpdateParams{
+		Refresh:         string(c.refresh),
+		RetryOnConflict: &retries,
+	}
+	if index != c.index(ctx) {
+		requireAlias := true
+		params.RequireAlias = &requireAlias
+	}
+	return c.client.Update(ctx, opensearchapi.UpdateReq{
+		Index:      index,
 		DocumentID: strconv.FormatInt(id, 10),
-		Params: opensearchapi.DocumentDeleteParams{
-			Refresh: "true",
-		},
+		Body:       bytes.NewReader(body),
+		Params:     params,
 	})
+}
+
+// DeleteTutor removes a tutor document. It returns ErrNotFound when the
+// document is not in the index.
+func (c *Client) DeleteTutor(ctx context.Context, id int64) error {
+	remove := func(index string) (*opensearchapi.DocumentDeleteResp, error) {
+		return c.client.Document.Delete(ctx, opensearchapi.DocumentDeleteReq{
+			Index:      index,
+			DocumentID: strconv.FormatInt(id, 10),
+			Params: opensearchapi.DocumentDeleteParams{
+				Refresh: string(c.refresh),
+			},
+		})
+	}
+	var resp *opensearchapi.DocumentDeleteResp
+	err := c.throttled(ctx, func() error {
+		var err error
+		resp, err = remove(c.writeIndex(ctx))
+		return err
+	})
+	if err == nil || isDocumentNotFound(err) {
+		// The experiment index may still hold a tutor the live one lost.
+		c.mirror(ctx, id, func(index string) error {
+			_, err := remove(index)
+			return err
+		})
+	}
 	if err != nil {
+		if isDocumentNotFound(err) {
+			c.logger.Debug("Tutor not found in index", "id", id)
+			return ErrNotFound
+		}
 		return fmt.Errorf("failed to delete tutor from index: %w", err)
 	}
 
 	if resp.Result == "not_found" {
-		c.logger.Debug("Tutor not found in index (already deleted)", "id", id)
-		return nil
+		c.logger.Debug("Tutor not found in index", "id", id)
+		return ErrNotFound
 	}
 
 	c.logger.Debug("Tutor deleted", "id", id, "result", resp.Result)
 	return nil
 }
 
-func (c *Client) SearchTutors(ctx context.Context, query SearchQuery) (*SearchResponse, error) {
-	q := buildSearchQuery(query)
+// GetTutor fetches a single tutor by ID, returning ErrNotFound if it is not
+// indexed.
+func (c *Client) GetTutor(ctx context.Context, id int64) (*domain.Tutor, error) {
+	resp, err := c.client.Document.Get(ctx, opensearchapi.DocumentGetReq{
+		Index:      c.readIndex(ctx),
+		DocumentID: strconv.FormatInt(id, 10),
+	})
+	if err != nil {
+		if isDocumentNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get tutor from index: %w", err)
+	}
+	if !resp.Found {
+		return nil, ErrNotFound
+	}
+
+	var tutor domain.Tutor
+	if err := json.Unmarshal(resp.Source, &tutor); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tutor: %w", err)
+	}
+	return &tutor, nil
+}
+
+// GetTutors fetches tutors with the _mget API, bulkBatchSize IDs per
+// request.
+func (c *Client) GetTutors(ctx context.Context, ids []int64) (map[int64]domain.Tutor, error) {
+	tutors := make(map[int64]domain.Tutor, len(ids))
+	for start := 0; start < len(ids); start += bulkBatchSize {
+		batch := ids[start:min(start+bulkBatchSize, len(ids))]
+		if err := c.getTutors(ctx, batch, tutors); err != nil {
+			return nil, err
+		}
+	}
+	return tutors, nil
+}
+
+func (c *Client) getTutors(ctx context.Context, ids []int64, into map[int64]domain.Tutor) error {
+	docIDs := make([]string, len(ids))
+	for i, id := range ids {
+		docIDs[i] = strconv.FormatInt(id, 10)
+	}
+	body, err := json.Marshal(map[string]any{"ids": docIDs})
+	if err != nil {
+		return fmt.Errorf("failed to marshal tutor IDs: %w", err)
+	}
+
+	resp, err := c.client.MGet(ctx, opensearchapi.MGetReq{
+		Index: c.readIndex(ctx),
+		Body:  bytes.NewReader(body),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get tutors from index: %w", err)
+	}
+
+	for _, doc := range resp.Docs {
+		if !doc.Found {
+			continue
+		}
+		var tutor domain.Tutor
+		if err := json.Unmarshal(doc.Source, &tutor); err != nil {
+			return fmt.Errorf("failed to unmarshal tutor %s: %w", doc.ID, err)
+		}
+		into[tutor.ID] = tutor
+	}
+	return nil
+}
 
-	body, err := json.Marshal(q)
+// SearchTutors runs query against ctx's index, or against its experiment
+// index for IndexVariant while an index experiment is running. Without
+// one, IndexVariant searches are served as if they had no variant.
+func (c *Client) SearchTutors(ctx context.Context, query SearchQuery) (*SearchResponse, error) {
+	index, query := c.searchIndex(ctx, query)
+	body, err := json.Marshal(buildSearchQuery(query, c.currentRelevance()))
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal search query: %w", err)
 	}
 
 	resp, err := c.client.Search(ctx, &opensearchapi.SearchReq{
-		Indices: []string{IndexName},
+		Indices: []string{index},
 		Body:    bytes.NewReader(body),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to search tutors: %w", err)
 	}
+	port.TimingFrom(ctx).AddTook(time.Duration(resp.Took) * time.Millisecond)
+
+	var raw []byte
+	if query.DiversifyBy != "" {
+		// SearchResp drops inner hits, so the raw body is decoded again.
+		if raw, err = io.ReadAll(resp.Inspect().Response.Body); err != nil {
+			return nil, fmt.Errorf("failed to read search response: %w", err)
+		}
+	}
+	return c.searchResult(index, query, resp, raw)
+}
+
+// SearchTutorsMulti runs queries as one _msearch request on the index the
+// first one runs on and converts each response as SearchTutors does.
+func (c *Client) SearchTutorsMulti(ctx context.Context, queries []SearchQuery) ([]*SearchResponse, error) {
+	if len(queries) == 0 {
+		return nil, nil
+	}
+	index, first := c.searchIndex(ctx, queries[0])
+	queries = slices.Clone(queries)
+	relevance := c.currentRelevance()
+	bodies := make([]map[string]any, len(queries))
+	for i := range queries {
+		queries[i].Variant = first.Variant
+		bodies[i] = buildSearchQuery(queries[i], relevance)
+	}
 
+	items, err := c.multiSearchIndex(ctx, index, bodies...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search tutors: %w", err)
+	}
+
+	results := make([]*SearchResponse, len(items))
+	took := 0
+	for i, item := range items {
+		var resp opensearchapi.SearchResp
+		if err := json.Unmarshal(item.raw, &resp); err != nil {
+			return nil, fmt.Errorf("failed to decode search response %d: %w", i, err)
+		}
+		if results[i], err = c.searchResult(index, queries[i], &resp, item.raw); err != nil {
+			return nil, err
+		}
+		took = max(took, item.Took)
+	}
+	// The searches run side by side, so the slowest one is the time spent.
+	port.TimingFrom(ctx).AddTook(time.Duration(took) * time.Millisecond)
+	return results, nil
+}
+
+// searchIndex returns the index query runs on: the experiment index for
+// the index variant while an experiment is running, otherwise ctx's index,
+// with the variant cleared.
+func (c *Client) searchIndex(ctx context.Context, query SearchQuery) (string, SearchQuery) {
+	if query.Variant == IndexVariant {
+		if alias, running := c.experimentIndex(ctx); running {
+			return alias, query
+		}
+		query.Variant = ""
+	}
+	return c.readIndex(ctx), query
+}
+
+// searchResult converts the response to query on index. raw is the
+// undecoded response, only read for a diversified search.
+func (c *Client) searchResult(index string, query SearchQuery, resp *opensearchapi.SearchResp, raw []byte) (*SearchResponse, error) {
 	tutors := make([]domain.Tutor, 0, len(resp.Hits.Hits))
 	for _, hit := range resp.Hits.Hits {
 		var tutor domain.Tutor
@@ -98,41 +268,98 @@ func (c *Client) SearchTutors(ctx context.Context, query SearchQuery) (*SearchRe
 		tutors = append(tutors, tutor)
 	}
 
-	return &SearchResponse{
-		Results: tutors,
-		Total:   resp.Hits.Total.Value,
-	}, nil
+	result := &SearchResponse{
+		Results:      tutors,
+		Total:        resp.Hits.Total.Value,
+		Variant:      query.Variant,
+		TookMs:       resp.Took,
+		ShardsTotal:  resp.Shards.Total,
+		ShardsFailed: resp.Shards.Failed,
+		Partial:      resp.Shards.Failed > 0,
+	}
+	if query.DiversifyBy != "" {
+		var err error
+		if result.Alternates, err = collapsedAlternates(raw); err != nil {
+			return nil, err
+		}
+	}
+	if result.Partial {
+		c.logger.Warn("Search returned partial results",
+			"index", index,
+			"shards_total", resp.Shards.Total,
+			"shards_failed", resp.Shards.Failed,
+			"failures", shardFailureReasons(resp.Shards.Failures),
+		)
+	}
+	return result, nil
+}
+
+// shardFailureReasons describes each failure as "index[shard] type: reason".
+func shardFailureReasons(failures []opensearchapi.ResponseShardsFailure) []string {
+	reasons := make([]string, len(failures))
+	for i, f := range failures {
+		reasons[i] = fmt.Sprintf("%v[%d] %s: %s", f.Index, f.Shard, f.Reason.Type, f.Reason.Reason)
+	}
+	return reasons
+}
+
+// documentError wraps OpenSearch's 400 for a write in ErrDocumentRejected,
+// keeping its reason; other errors are returned as they are.
+func documentError(err error) error {
+	var se *opensearch.StructError
+	if errors.As(err, &se) && se.Status == http.StatusBadRequest {
+		return fmt.Errorf("%w: %s: %s", ErrDocumentRejected, se.Err.Type, se.Err.Reason)
+	}
+	return err
 }
 
-func buildSearchQuery(query SearchQuery) map[string]any {
+// isDocumentNotFound reports whether err is OpenSearch's 404 for a missing
+// document. A missing index is reported differently (as a structured
+// index_not_found_exception) and is not treated as not-found.
+func isDocumentNotFound(err error) bool {
+	var se *opensearch.StringError
+	return errors.As(err, &se) && se.Status == http.StatusNotFound
+}
+
+// buildSearchQuery translates query into an OpenSearch request body, weighting
+// the text match by the relevance config of query's variant.
+func buildSearchQuery(query SearchQuery, relevance RelevanceRegistry) map[string]any {
 	must := []map[string]any{}
 	filter := []map[string]any{}
 
 	if query.Text != "" {
+		rc := relevance.For(query.Variant)
 		// Use bool query with should to support both:
 		// - phrase_prefix: partial word matching ("mar" -> "Marie")
 		// - fuzziness: typo tolerance ("marei" -> "Marie")
+		textShould := []map[string]any{
+			{
+				"multi_match": map[string]any{
+					"query":     query.Text,
+					"fields":    rc.fields(),
+					"fuzziness": rc.Fuzziness,
+				},
+			},
+			{
+				"multi_match": map[string]any{
+					"query":  query.Text,
+					"fields": rc.fields(),
+					"type":   "phrase_prefix",
+				},
+			},
+		}
+		if match := languageMatch(query.Text, rc); match != nil {
+			textShould = append(textShould, match)
+		}
 		must = append(must, map[string]any{
 			"bool": map[string]any{
-				"should": []map[string]any{
-					{
-						"multi_match": map[string]any{
-							"query":     query.Text,
-							"fields":    []string{"full_name", "headline^2", "bio"},
-							"fuzziness": "AUTO",
-						},
-					},
-					{
-						"multi_match": map[string]any{
-							"query":  query.Text,
-							"fields": []string{"full_name", "headline^2", "bio"},
-							"type":   "phrase_prefix",
-						},
-					},
-				},
+				"should":               textShould,
 				"minimum_should_match": 1,
 			},
 		})
+		if rc.PopularityBoost > 0 && query.Sort == SortRelevance {
+			must = append(must, popularityBoost(rc.PopularityBoost))
+		}
 	}
 
 	if len(query.Subjects) > 0 {
@@ -143,19 +370,8 @@ func buildSearchQuery(query SearchQuery) map[string]any {
 		})
 	}
 
-	if query.MinPrice != nil || query.MaxPrice != nil {
-		rangeQuery := map[string]any{}
-		if query.MinPrice != nil {
-			rangeQuery["gte"] = *query.MinPrice
-		}
-		if query.MaxPrice != nil {
-			rangeQuery["lte"] = *query.MaxPrice
-		}
-		filter = append(filter, map[string]any{
-			"range": map[string]any{
-				"hourly_rate": rangeQuery,
-			},
-		})
+	if query.MinPrice != nil || query.MaxPrice != nil || query.BelowPrice != nil {
+		filter = append(filter, priceFilter(query))
 	}
 
 	if query.MinRating != nil {
@@ -176,6 +392,14 @@ func buildSearchQuery(query SearchQuery) map[string]any {
 		})
 	}
 
+	if len(query.Levels) > 0 {
+		filter = append(filter, map[string]any{
+			"terms": map[string]any{
+				"levels": query.Levels,
+			},
+		})
+	}
+
 	if query.Location != "" {
 		filter = append(filter, map[string]any{
 			"term": map[string]any{
@@ -184,19 +408,89 @@ func buildSearchQuery(query SearchQuery) map[string]any {
 		})
 	}
 
-	const maxLimit = 100
-	limit := query.Limit
-	if limit <= 0 {
-		limit = 20
-	} else if limit > maxLimit {
-		limit = maxLimit
+	if query.Certification != "" {
+		filter = append(filter, map[string]any{
+			"term": map[string]any{
+				"certifications.keyword": query.Certification,
+			},
+		})
 	}
 
-	offset := query.Offset
-	if offset < 0 {
-		offset = 0
+	if query.Badge != "" {
+		filter = append(filter, map[string]any{
+			"term": map[string]any{
+				"badges": query.Badge,
+			},
+		})
+	}
+
+	// Documents indexed before offers_trial existed lack it, and a term
+	// query does not match a missing field.
+	if query.Trial {
+		filter = append(filter, map[string]any{
+			"term": map[string]any{
+				"offers_trial": true,
+			},
+		})
 	}
 
+	// Pinning only reorders: the clause is optional, so it adds its boost
+	// to tutors carrying the badge without dropping the others.
+	var should []map[string]any
+	if rc := relevance.For(query.Variant); rc.PinnedBadge != "" && query.Sort == SortRelevance {
+		should = append(should, map[string]any{
+			"constant_score": map[string]any{
+				"filter": map[string]any{"term": map[string]any{"badges": rc.PinnedBadge}},
+				"boost":  pinnedBadgeBoost,
+			},
+		})
+	}
+
+	if query.AvailableWithinDays > 0 {
+		filter = append(filter, map[string]any{
+			"range": map[string]any{
+				"next_available_at": map[string]any{
+					"gte": "now",
+					"lte": fmt.Sprintf("now+%dd", query.AvailableWithinDays),
+				},
+			},
+		})
+	}
+
+	if len(query.AvailableBetween) > 0 {
+		// A window wrapping around UTC midnight is two ranges; either
+		// overlapping will do.
+		overlaps := make([]map[string]any, len(query.AvailableBetween))
+		for i, r := range query.AvailableBetween {
+			overlaps[i] = map[string]any{
+				"range": map[string]any{
+					"availability_utc_minutes": map[string]any{
+						"gte":      r.Start,
+						"lt":       r.End,
+						"relation": "intersects",
+					},
+				},
+			}
+		}
+		filter = append(filter, map[string]any{
+			"bool": map[string]any{
+				"should":               overlaps,
+				"minimum_should_match": 1,
+			},
+		})
+	}
+
+	var mustNot []map[string]any
+	if len(query.ExcludeIDs) > 0 {
+		mustNot = append(mustNot, map[string]any{
+			"terms": map[string]any{
+				"id": query.ExcludeIDs,
+			},
+		})
+	}
+
+	limit, offset := query.Page()
+
 	boolQuery := map[string]any{}
 	if len(must) > 0 {
 		boolQuery["must"] = must
@@ -204,6 +498,13 @@ func buildSearchQuery(query SearchQuery) map[string]any {
 	if len(filter) > 0 {
 		boolQuery["filter"] = filter
 	}
+	if len(mustNot) > 0 {
+		boolQuery["must_not"] = mustNot
+	}
+	if len(should) > 0 {
+		boolQuery["should"] = should
+		boolQuery["minimum_should_match"] = 0
+	}
 
 	q := map[string]any{
 		"size": limit,
@@ -220,5 +521,186 @@ func buildSearchQuery(query SearchQuery) map[string]any {
 		}
 	}
 
+	switch query.Sort {
+	case SortRating:
+		q["sort"] = ratingSort
+	case SortPopularity:
+		q["sort"] = popularitySort
+	}
+	// Result lists show bio_snippet; the full bio is only sent on request.
+	if !query.IncludeBio {
+		q["_source"] = map[string]any{"excludes": []string{"bio"}}
+	}
+
+	if query.DiversifyBy != "" {
+		q["collapse"] = collapseClause(query.DiversifyBy, q)
+	}
+
 	return q
 }
+
+// alternatesInnerHits names the inner hits a collapsed search returns
+// under each result.
+const alternatesInnerHits = "alternates"
+
+// collapseClause keeps the best hit per value of field and returns the
+// runners-up as inner hits, ordered and trimmed like the outer hits of q.
+// The inner hits include the result itself, so one more is requested.
+func collapseClause(field string, q map[string]any) map[string]any {
+	innerHits := map[string]any{
+		"name": alternatesInnerHits,
+		"size": DiversifyAlternates + 1,
+	}
+	if sort, ok := q["sort"]; ok {
+		innerHits["sort"] = sort
+	}
+	if source, ok := q["_source"]; ok {
+		innerHits["_source"] = source
+	}
+	return map[string]any{
+		"field":      field,
+		"inner_hits": innerHits,
+	}
+}
+
+// collapsedAlternates decodes the inner hits of a collapsed search
+// response, keyed by the ID of the result they were collapsed under.
+func collapsedAlternates(raw []byte) (map[int64][]domain.Tutor, error) {
+	type hits struct {
+		Hits []struct {
+			Source json.RawMessage `json:"_source"`
+		} `json:"hits"`
+	}
+	var resp struct {
+		Hits struct {
+			Hits []struct {
+				Source    json.RawMessage `json:"_source"`
+				InnerHits map[string]struct {
+					Hits hits `json:"hits"`
+				} `json:"inner_hits"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		return nil, fmt.Errorf("failed to decode collapsed hits: %w", err)
+	}
+
+	alternates := make(map[int64][]domain.Tutor)
+	for _, hit := range resp.Hits.Hits {
+		var top struct {
+			ID int64 `json:"id"`
+		}
+		if err := json.Unmarshal(hit.Source, &top); err != nil {
+			continue
+		}
+		for _, inner := range hit.InnerHits[alternatesInnerHits].Hits.Hits {
+			var tutor domain.Tutor
+			if err := json.Unmarshal(inner.Source, &tutor); err != nil || tutor.ID == top.ID {
+				continue
+			}
+			if len(alternates[top.ID]) < DiversifyAlternates {
+				alternates[top.ID] = append(alternates[top.ID], tutor)
+			}
+		}
+	}
+	return alternates, nil
+}
+
+// languageMatch returns the clause that favours tutors written in the
+// language of text: a match on the headline and bio subfields stemmed for
+// it, scoring more when the tutor's detected language is the same. It
+// returns nil when text is not clearly English or Russian.
+func languageMatch(text string, rc RelevanceConfig) map[string]any {
+	lang := domain.DetectLanguage(text)
+	if lang != domain.LanguageEnglish && lang != domain.LanguageRussian {
+		return nil
+	}
+	return map[string]any{
+		"bool": map[string]any{
+			"must": map[string]any{
+				"multi_match": map[string]any{
+					"query":  text,
+					"fields": rc.languageFields(lang),
+				},
+			},
+			"should": map[string]any{
+				"term": map[string]any{
+					"lang": map[string]any{"value": lang, "boost": languageMatchBoost},
+				},
+			},
+		},
+	}
+}
+
+// formatRateFields maps the lesson formats with their own rate to the
+// field holding it.
+var formatRateFields = map[string]string{
+	domain.FormatOnline:  "online_rate",
+	domain.FormatOffline: "offline_rate",
+}
+
+// priceFilter bounds query's price. With a format that has its own rate,
+// a tutor matches on that rate, or on hourly_rate when they have none for
+// it (zero, or a document indexed before the field existed), like
+// domain.Tutor.RateFor.
+func priceFilter(query SearchQuery) map[string]any {
+	bounds := map[string]any{}
+	if query.MinPrice != nil {
+		bounds["gte"] = *query.MinPrice
+	}
+	if query.MaxPrice != nil {
+		bounds["lte"] = *query.MaxPrice
+	}
+	if query.BelowPrice != nil {
+		bounds["lt"] = *query.BelowPrice
+	}
+	hourly := map[string]any{"range": map[string]any{"hourly_rate": bounds}}
+
+	field, ok := formatRateFields[query.Format]
+	if !ok {
+		return hourly
+	}
+	return map[string]any{
+		"bool": map[string]any{
+			"should": []map[string]any{
+				{"range": map[string]any{field: bounds}},
+				{
+					"bool": map[string]any{
+						"must_not": []map[string]any{
+							{"range": map[string]any{field: map[string]any{"gt": 0}}},
+						},
+						"filter": []map[string]any{hourly},
+					},
+				},
+			},
+			"minimum_should_match": 1,
+		},
+	}
+}
+
+// popularityBoost returns the clause that adds log(1+popularity) times
+// boost to a text match's score, so of similar matches the more popular
+// tutor ranks first. Documents indexed before popularity existed score 0.
+func popularityBoost(boost float64) map[string]any {
+	return map[string]any{
+		"function_score": map[string]any{
+			"query": map[string]any{"match_all": map[string]any{}},
+			"functions": []map[string]any{{
+				"field_value_factor": map[string]any{
+					"field":    "popularity",
+					"modifier": "log1p",
+					"missing":  0,
+				},
+				"weight": boost,
+			}},
+			"boost_mode": "replace",
+		},
+	}
+}
+
+// languageMatchBoost weights a tutor written in the query's language.
+const languageMatchBoost = 2
+
+// pinnedBadgeBoost lifts tutors carrying the pinned badge above any text
+// match score.
+const pinnedBadgeBoost = 1000
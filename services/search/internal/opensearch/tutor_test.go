@@ -1,12 +1,20 @@
 package opensearch
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"slices"
 	"testing"
+
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
 )
 
 func TestBuildSearchQuery_EmptyQuery(t *testing.T) {
 	query := SearchQuery{}
-	result := buildSearchQuery(query)
+	result := buildSearchQuery(query, nil)
 
 	if _, ok := result["query"]; !ok {
 		t.Error("missing query field")
@@ -28,23 +36,27 @@ func TestBuildSearchQuery_TextSearch(t *testing.T) {
 	query := SearchQuery{
 		Text: "математика",
 	}
-	result := buildSearchQuery(query)
+	result := buildSearchQuery(query, nil)
 
 	q := result["query"].(map[string]any)
 	boolQuery := q["bool"].(map[string]any)
 	must := boolQuery["must"].([]map[string]any)
 
-	if len(must) != 1 {
-		t.Errorf("expected 1 must clause, got %d", len(must))
+	// The text match, then the default popularity boost
+	if len(must) != 2 {
+		t.Errorf("expected 2 must clauses, got %d", len(must))
+	}
+	if _, ok := must[1]["function_score"]; !ok {
+		t.Errorf("expected a popularity function_score, got %v", must[1])
 	}
 
 	// The text search now uses a nested bool with should clauses
-	// for both fuzzy and phrase_prefix matching
+	// for fuzzy and phrase_prefix matching, plus the Russian subfields
 	innerBool := must[0]["bool"].(map[string]any)
 	should := innerBool["should"].([]map[string]any)
 
-	if len(should) != 2 {
-		t.Errorf("expected 2 should clauses, got %d", len(should))
+	if len(should) != 3 {
+		t.Errorf("expected 3 should clauses, got %d", len(should))
 	}
 
 	// First should clause: fuzzy multi_match
@@ -70,7 +82,7 @@ func TestBuildSearchQuery_Subjects(t *testing.T) {
 	query := SearchQuery{
 		Subjects: []string{"math", "physics"},
 	}
-	result := buildSearchQuery(query)
+	result := buildSearchQuery(query, nil)
 
 	q := result["query"].(map[string]any)
 	boolQuery := q["bool"].(map[string]any)
@@ -108,7 +120,7 @@ func TestBuildSearchQuery_PriceRange(t *testing.T) {
 				MinPrice: tt.minPrice,
 				MaxPrice: tt.maxPrice,
 			}
-			result := buildSearchQuery(query)
+			result := buildSearchQuery(query, nil)
 
 			q := result["query"].(map[string]any)
 			boolQuery := q["bool"].(map[string]any)
@@ -140,7 +152,7 @@ func TestBuildSearchQuery_MinRating(t *testing.T) {
 	query := SearchQuery{
 		MinRating: &minRating,
 	}
-	result := buildSearchQuery(query)
+	result := buildSearchQuery(query, nil)
 
 	q := result["query"].(map[string]any)
 	boolQuery := q["bool"].(map[string]any)
@@ -162,7 +174,7 @@ func TestBuildSearchQuery_Format(t *testing.T) {
 	query := SearchQuery{
 		Format: "online",
 	}
-	result := buildSearchQuery(query)
+	result := buildSearchQuery(query, nil)
 
 	q := result["query"].(map[string]any)
 	boolQuery := q["bool"].(map[string]any)
@@ -182,7 +194,7 @@ func TestBuildSearchQuery_Location(t *testing.T) {
 	query := SearchQuery{
 		Location: "Moscow",
 	}
-	result := buildSearchQuery(query)
+	result := buildSearchQuery(query, nil)
 
 	q := result["query"].(map[string]any)
 	boolQuery := q["bool"].(map[string]any)
@@ -217,7 +229,7 @@ func TestBuildSearchQuery_Pagination(t *testing.T) {
 				Limit:  tt.limit,
 				Offset: tt.offset,
 			}
-			result := buildSearchQuery(query)
+			result := buildSearchQuery(query, nil)
 
 			if result["size"] != tt.expectedSize {
 				t.Errorf("expected size %d, got %v", tt.expectedSize, result["size"])
@@ -228,3 +240,435 @@ func TestBuildSearchQuery_Pagination(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteTutor(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		wantErr      bool
+		wantNotFound bool
+	}{
+		{
+			name:   "deleted",
+			status: http.StatusOK,
+			body:   `{"_index":"tutors","_id":"7","result":"deleted"}`,
+		},
+		{
+			name:         "document missing",
+			status:       http.StatusNotFound,
+			body:         `{"_index":"tutors","_id":"7","result":"not_found"}`,
+			wantErr:      true,
+			wantNotFound: true,
+		},
+		{
+			name:    "index missing",
+			status:  http.StatusNotFound,
+			body:    `{"error":{"type":"index_not_found_exception","reason":"no such index [tutors]"},"status":404}`,
+			wantErr: true,
+		},
+		{
+			name:    "server error",
+			status:  http.StatusInternalServerError,
+			body:    `{"error":{"type":"exception","reason":"boom"},"status":500}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodDelete || r.URL.Path != "/tutors/_doc/7" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				writeJSON(w, tt.status, tt.body)
+			})
+
+			err := client.DeleteTutor(context.Background(), 7)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if errors.Is(err, ErrNotFound) != tt.wantNotFound {
+				t.Errorf("expected ErrNotFound %v, got %v", tt.wantNotFound, err)
+			}
+		})
+	}
+}
+
+func TestGetTutor(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		wantName     string
+		wantErr      bool
+		wantNotFound bool
+	}{
+		{
+			name:     "found",
+			status:   http.StatusOK,
+			body:     `{"_index":"tutors","_id":"7","found":true,"_source":{"id":7,"full_name":"Emmy Noether"}}`,
+			wantName: "Emmy Noether",
+		},
+		{
+			name:         "document missing",
+			status:       http.StatusNotFound,
+			body:         `{"_index":"tutors","_id":"7","found":false}`,
+			wantErr:      true,
+			wantNotFound: true,
+		},
+		{
+			name:    "index missing",
+			status:  http.StatusNotFound,
+			body:    `{"error":{"type":"index_not_found_exception","reason":"no such index [tutors]"},"status":404}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet || r.URL.Path != "/tutors/_doc/7" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				writeJSON(w, tt.status, tt.body)
+			})
+
+			tutor, err := client.GetTutor(context.Background(), 7)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if errors.Is(err, ErrNotFound) != tt.wantNotFound {
+				t.Errorf("expected ErrNotFound %v, got %v", tt.wantNotFound, err)
+			}
+			if err == nil && tutor.FullName != tt.wantName {
+				t.Errorf("expected %q, got %q", tt.wantName, tutor.FullName)
+			}
+		})
+	}
+}
+
+func TestGetTutors(t *testing.T) {
+	var requests int
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != http.MethodPost || r.URL.Path != "/tutors/_mget" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			IDs []string `json:"ids"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if requests == 1 && (len(body.IDs) != bulkBatchSize || body.IDs[0] != "1") {
+			t.Errorf("expected a full first batch starting at 1, got %d IDs", len(body.IDs))
+		}
+		if requests == 2 {
+			if !slices.Equal(body.IDs, []string{"501"}) {
+				t.Errorf("expected the remainder in a second batch, got %v", body.IDs)
+			}
+			writeJSON(w, http.StatusOK, `{"docs": [{"_index": "tutors", "_id": "501", "found": true, "_source": {"id": 501, "full_name": "Emmy Noether"}}]}`)
+			return
+		}
+		writeJSON(w, http.StatusOK, `{"docs": [
+			{"_index": "tutors", "_id": "1", "found": true, "_source": {"id": 1, "full_name": "Ada Lovelace"}},
+			{"_index": "tutors", "_id": "2", "found": false}
+		]}`)
+	})
+
+	ids := make([]int64, bulkBatchSize+1)
+	for i := range ids {
+		ids[i] = int64(i + 1)
+	}
+	tutors, err := client.GetTutors(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+	if len(tutors) != 2 || tutors[1].FullName != "Ada Lovelace" || tutors[501].FullName != "Emmy Noether" {
+		t.Errorf("expected the found tutors only, got %+v", tutors)
+	}
+}
+
+func TestGetTutors_Error(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, `{"error":{"type":"index_not_found_exception","reason":"no such index [tutors]"},"status":404}`)
+	})
+
+	if _, err := client.GetTutors(context.Background(), []int64{1}); err == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestBuildSearchQuery_ExcludeIDs(t *testing.T) {
+	q := buildSearchQuery(SearchQuery{ExcludeIDs: []int64{4, 9}}, nil)
+
+	boolQuery, ok := q["query"].(map[string]any)["bool"].(map[string]any)
+	if !ok {
+		t.Fatalf("expected a bool query, got %v", q["query"])
+	}
+	mustNot, ok := boolQuery["must_not"].([]map[string]any)
+	if !ok || len(mustNot) != 1 {
+		t.Fatalf("expected one must_not clause, got %v", boolQuery["must_not"])
+	}
+	ids := mustNot[0]["terms"].(map[string]any)["id"].([]int64)
+	if len(ids) != 2 || ids[0] != 4 || ids[1] != 9 {
+		t.Errorf("expected excluded ids [4 9], got %v", ids)
+	}
+
+	if _, ok := buildSearchQuery(SearchQuery{}, nil)["query"].(map[string]any)["match_all"]; !ok {
+		t.Error("expected match_all without exclusions")
+	}
+}
+
+func TestBuildSearchQuery_BelowPriceAndSort(t *testing.T) {
+	below := 50.0
+	q := buildSearchQuery(SearchQuery{BelowPrice: &below, Sort: SortRating}, nil)
+
+	filter := q["query"].(map[string]any)["bool"].(map[string]any)["filter"].([]map[string]any)
+	rate := filter[0]["range"].(map[string]any)["hourly_rate"].(map[string]any)
+	if rate["lt"] != 50.0 || len(rate) != 1 {
+		t.Errorf("expected hourly_rate lt 50, got %v", rate)
+	}
+	if sort, ok := q["sort"].([]map[string]any); !ok || len(sort) != 3 || sort[0]["rating"] == nil {
+		t.Errorf("expected rating sort, got %v", q["sort"])
+	}
+
+	if _, ok := buildSearchQuery(SearchQuery{}, nil)["sort"]; ok {
+		t.Error("expected relevance order without a sort clause")
+	}
+}
+
+func TestBuildSearchQuery_PriceByFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{"no format uses hourly rate", "", `{"range":{"hourly_rate":{"gte":20,"lte":60}}}`},
+		{"format without its own rate uses hourly rate", "group", `{"range":{"hourly_rate":{"gte":20,"lte":60}}}`},
+		{"offline uses offline rate, hourly rate without one", "offline", `{"bool":{"minimum_should_match":1,"should":[
+			{"range":{"offline_rate":{"gte":20,"lte":60}}},
+			{"bool":{"filter":[{"range":{"hourly_rate":{"gte":20,"lte":60}}}],"must_not":[{"range":{"offline_rate":{"gt":0}}}]}}
+		]}}`},
+		{"online uses online rate, hourly rate without one", "online", `{"bool":{"minimum_should_match":1,"should":[
+			{"range":{"online_rate":{"gte":20,"lte":60}}},
+			{"bool":{"filter":[{"range":{"hourly_rate":{"gte":20,"lte":60}}}],"must_not":[{"range":{"online_rate":{"gt":0}}}]}}
+		]}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lo, hi := 20.0, 60.0
+			q := buildSearchQuery(SearchQuery{MinPrice: &lo, MaxPrice: &hi, Format: tt.format}, nil)
+
+			filter := q["query"].(map[string]any)["bool"].(map[string]any)["filter"].([]map[string]any)
+			got, _ := json.Marshal(filter[0])
+			var gotValue, wantValue any
+			json.Unmarshal(got, &gotValue)
+			if err := json.Unmarshal([]byte(tt.want), &wantValue); err != nil {
+				t.Fatalf("invalid expectation: %v", err)
+			}
+			if !reflect.DeepEqual(gotValue, wantValue) {
+				t.Errorf("expected price filter %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestBuildSearchQuery_BioSource(t *testing.T) {
+	source, ok := buildSearchQuery(SearchQuery{}, nil)["_source"].(map[string]any)
+	if !ok || !slices.Equal(source["excludes"].([]string), []string{"bio"}) {
+		t.Errorf("expected the bio excluded by default, got %v", source)
+	}
+
+	if _, ok := buildSearchQuery(SearchQuery{IncludeBio: true}, nil)["_source"]; ok {
+		t.Error("expected the whole source with IncludeBio")
+	}
+}
+
+func TestBuildSearchQuery_Certification(t *testing.T) {
+	q := buildSearchQuery(SearchQuery{Certification: "CELTA"}, nil)
+
+	filter := q["query"].(map[string]any)["bool"].(map[string]any)["filter"].([]map[string]any)
+	if len(filter) != 1 {
+		t.Fatalf("expected one filter clause, got %v", filter)
+	}
+	if got := filter[0]["term"].(map[string]any)["certifications.keyword"]; got != "CELTA" {
+		t.Errorf("expected a certifications.keyword term for CELTA, got %v", filter[0])
+	}
+}
+
+func TestBuildSearchQuery_Trial(t *testing.T) {
+	q := buildSearchQuery(SearchQuery{Trial: true}, nil)
+
+	filter := q["query"].(map[string]any)["bool"].(map[string]any)["filter"].([]map[string]any)
+	if len(filter) != 1 {
+		t.Fatalf("expected one filter clause, got %v", filter)
+	}
+	if got := filter[0]["term"].(map[string]any)["offers_trial"]; got != true {
+		t.Errorf("expected an offers_trial term for true, got %v", filter[0])
+	}
+}
+
+func TestBuildSearchQuery_SubjectsFilterWithText(t *testing.T) {
+	q := buildSearchQuery(SearchQuery{Text: "chess coach", Subjects: []string{"chess"}}, nil)
+
+	filter := q["query"].(map[string]any)["bool"].(map[string]any)["filter"].([]map[string]any)
+	if len(filter) != 1 {
+		t.Fatalf("expected one filter clause, got %v", filter)
+	}
+	if got := filter[0]["terms"].(map[string]any)["subjects"]; !slices.Equal(got.([]string), []string{"chess"}) {
+		t.Errorf("expected a terms filter on the subjects keyword, got %v", filter[0])
+	}
+	fuzzy, prefix := textMatches(t, q)
+	for _, match := range []map[string]any{fuzzy, prefix} {
+		if !slices.Contains(match["fields"].([]string), "subjects.text^1.5") {
+			t.Errorf("expected subjects.text among the text fields, got %v", match["fields"])
+		}
+	}
+}
+
+func TestBuildSearchQuery_Levels(t *testing.T) {
+	q := buildSearchQuery(SearchQuery{Levels: []string{"school", "adult"}}, nil)
+
+	filter := q["query"].(map[string]any)["bool"].(map[string]any)["filter"].([]map[string]any)
+	if len(filter) != 1 {
+		t.Fatalf("expected one filter clause, got %v", filter)
+	}
+	got, _ := filter[0]["terms"].(map[string]any)["levels"].([]string)
+	if !slices.Equal(got, []string{"school", "adult"}) {
+		t.Errorf("expected a levels terms filter for school and adult, got %v", filter[0])
+	}
+
+}
+
+func TestBuildSearchQuery_Diversify(t *testing.T) {
+	q := buildSearchQuery(SearchQuery{DiversifyBy: DiversifyLocation, Sort: SortRating}, nil)
+
+	collapse, ok := q["collapse"].(map[string]any)
+	if !ok || collapse["field"] != "location" {
+		t.Fatalf("expected a collapse on location, got %v", q["collapse"])
+	}
+	inner := collapse["inner_hits"].(map[string]any)
+	if inner["name"] != alternatesInnerHits || inner["size"] != DiversifyAlternates+1 {
+		t.Errorf("expected %d inner hits named %s, got %v", DiversifyAlternates+1, alternatesInnerHits, inner)
+	}
+	if _, ok := inner["sort"].([]map[string]any); !ok {
+		t.Errorf("expected the inner hits to share the rating sort, got %v", inner["sort"])
+	}
+	if _, ok := inner["_source"]; !ok {
+		t.Error("expected the inner hits to leave out the bio too")
+	}
+
+	if _, ok := buildSearchQuery(SearchQuery{}, nil)["collapse"]; ok {
+		t.Error("expected no collapse without diversify_by")
+	}
+}
+
+func TestSearchTutors_Diversified(t *testing.T) {
+	var body map[string]any
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusOK, `{
+			"took": 3,
+			"_shards": {"total": 1, "successful": 1, "failed": 0},
+			"hits": {"total": {"value": 4, "relation": "eq"}, "hits": [
+				{"_source": {"id": 3, "location": "London"}, "inner_hits": {"alternates": {"hits": {"hits": [
+					{"_source": {"id": 3, "location": "London"}},
+					{"_source": {"id": 2, "location": "London"}},
+					{"_source": {"id": 5, "location": "London"}}
+				]}}}},
+				{"_source": {"id": 1, "location": "Paris"}, "inner_hits": {"alternates": {"hits": {"hits": [
+					{"_source": {"id": 1, "location": "Paris"}}
+				]}}}}
+			]}
+		}`)
+	})
+
+	result, err := client.SearchTutors(context.Background(), SearchQuery{DiversifyBy: DiversifyLocation})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if body["collapse"] == nil {
+		t.Errorf("expected the request to collapse, got %v", body)
+	}
+	if len(result.Results) != 2 || result.Total != 4 {
+		t.Errorf("expected 2 results of 4 matches, got %+v", result)
+	}
+	alts := result.Alternates[3]
+	if len(alts) != 2 || alts[0].ID != 2 || alts[1].ID != 5 {
+		t.Errorf("expected tutors 2 and 5 under 3, got %+v", alts)
+	}
+	if _, ok := result.Alternates[1]; ok {
+		t.Errorf("expected no alternates under 1, got %+v", result.Alternates[1])
+	}
+}
+
+func TestSearchTutors_ShardInfo(t *testing.T) {
+	tests := []struct {
+		name        string
+		shards      string
+		wantFailed  int
+		wantPartial bool
+	}{
+		{
+			name:   "complete",
+			shards: `{"total": 3, "successful": 3, "skipped": 0, "failed": 0}`,
+		},
+		{
+			name: "one shard failed",
+			shards: `{"total": 3, "successful": 2, "skipped": 0, "failed": 1, "failures": [
+				{"shard": 1, "index": "tutors", "node": "n1", "reason": {"type": "node_not_connected_exception", "reason": "node n1 disconnected"}}
+			]}`,
+			wantFailed:  1,
+			wantPartial: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(w, http.StatusOK, `{
+					"took": 12, "timed_out": false,
+					"_shards": `+tt.shards+`,
+					"hits": {"total": {"value": 1, "relation": "eq"}, "hits": [
+						{"_source": {"id": 1, "full_name": "Ada Lovelace"}}
+					]}
+				}`)
+			})
+
+			result, err := client.SearchTutors(context.Background(), SearchQuery{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(result.Results) != 1 || result.Total != 1 {
+				t.Errorf("expected the answering shards' hits, got %+v", result)
+			}
+			if result.TookMs != 12 || result.ShardsTotal != 3 {
+				t.Errorf("expected took 12 and 3 shards, got %d and %d", result.TookMs, result.ShardsTotal)
+			}
+			if result.ShardsFailed != tt.wantFailed || result.Partial != tt.wantPartial {
+				t.Errorf("expected shards_failed %d partial %v, got %d %v",
+					tt.wantFailed, tt.wantPartial, result.ShardsFailed, result.Partial)
+			}
+		})
+	}
+}
+
+func TestShardFailureReasons(t *testing.T) {
+	var failure opensearchapi.ResponseShardsFailure
+	failure.Shard = 2
+	failure.Index = "tutors-de"
+	failure.Reason.Type = "query_shard_exception"
+	failure.Reason.Reason = "failed to create query"
+
+	got := shardFailureReasons([]opensearchapi.ResponseShardsFailure{failure})
+	want := "tutors-de[2] query_shard_exception: failed to create query"
+	if len(got) != 1 || got[0] != want {
+		t.Errorf("expected [%q], got %q", want, got)
+	}
+}
@@ -0,0 +1,97 @@
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/opensearch-project/opensearch-go/v4/opensearchapi"
+)
+
+// setVerifiedScript flips is_verified, stamps or clears verified_at and
+// moves popularity by the verified weight, so sort=popularity reflects the
+// change without a recompute. A tutor already in the requested state is
+// left alone, keeping its original verified_at.
+const setVerifiedScript = `
+def was = ctx._source.is_verified == true;
+if (was == params.verified) {
+  ctx.op = 'none';
+} else {
+  ctx._source.is_verified = params.verified;
+  if (params.verified) {
+    ctx._source.verified_at = params.at;
+  } else {
+    ctx._source.remove('verified_at');
+  }
+  if (ctx._source.popularity != null) {
+    def delta = params.verified ? params.weight : -params.weight;
+    ctx._source.popularity = Math.max(0.0, Math.round((ctx._source.popularity + delta) * 10000) / 10000.0);
+  }
+}`
+
+// SetVerified updates a tutor's verification with a scripted partial
+// update, so concurrent profile writes keep their other fields.
+func (c *Client) SetVerified(ctx context.Context, tutorID int64, verified bool, at time.Time) error {
+	body, err := json.Marshal(map[string]any{
+		"script": map[string]any{
+			"lang":   "painless",
+			"source": setVerifiedScript,
+			"params": map[string]any{
+				"verified": verified,
+				"at":       at.UTC().Format(time.RFC3339Nano),
+				"weight":   c.popularity.Verified,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal verification update: %w", err)
+	}
+
+	retries := updateRetries
+	resp, err := c.client.Update(ctx, opensearchapi.UpdateReq{
+		Index:      c.writeIndex(ctx),
+		DocumentID: strconv.FormatInt(tutorID, 10),
+		Body:       bytes.NewReader(body),
+		Params: opensearchapi.UpdateParams{
+			Refresh:         string(c.refresh),
+			RetryOnConflict: &retries,
+		},
+	})
+	if err != nil {
+		if isDocumentMissing(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to update tutor verification: %w", err)
+	}
+
+	c.logger.Debug("Tutor verification updated", "id", tutorID, "verified", verified, "result", resp.Result)
+	return nil
+}
+
+// SetVerified updates the stored tutor's verification and rescores its
+// popularity. A tutor already in the requested state is left alone.
+func (m *MemoryClient) SetVerified(ctx context.Context, tutorID int64, verified bool, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tutors := m.tutors(ctx)
+	t, ok := tutors[tutorID]
+	if !ok {
+		return ErrNotFound
+	}
+	if t.IsVerified == verified {
+		return nil
+	}
+	t.IsVerified = verified
+	t.VerifiedAt = nil
+	if verified {
+		at = at.UTC()
+		t.VerifiedAt = &at
+	}
+	t.SetPopularity(m.popularity, time.Now())
+	tutors[tutorID] = t
+	return nil
+}
@@ -0,0 +1,106 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"search/internal/domain"
+)
+
+func TestSetVerified(t *testing.T) {
+	at := time.Date(2026, 3, 1, 9, 30, 0, 0, time.FixedZone("EET", 2*60*60))
+
+	tests := []struct {
+		name     string
+		verified bool
+	}{
+		{"verify", true},
+		{"unverify", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body struct {
+				Script struct {
+					Source string         `json:"source"`
+					Params map[string]any `json:"params"`
+				} `json:"script"`
+			}
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/tutors/_update/7" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				json.NewDecoder(r.Body).Decode(&body)
+				writeJSON(w, http.StatusOK, `{"_index":"tutors","_id":"7","result":"updated"}`)
+			})
+
+			if err := client.SetVerified(context.Background(), 7, tt.verified, at); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !strings.Contains(body.Script.Source, "ctx._source.is_verified = params.verified") {
+				t.Errorf("expected script setting is_verified, got %s", body.Script.Source)
+			}
+			if body.Script.Params["verified"] != tt.verified {
+				t.Errorf("expected verified %t, got %v", tt.verified, body.Script.Params["verified"])
+			}
+			if body.Script.Params["at"] != "2026-03-01T07:30:00Z" {
+				t.Errorf("expected at in UTC, got %v", body.Script.Params["at"])
+			}
+			if body.Script.Params["weight"] != domain.DefaultPopularityWeights.Verified {
+				t.Errorf("expected the verified popularity weight, got %v", body.Script.Params["weight"])
+			}
+		})
+	}
+}
+
+func TestSetVerified_DocumentMissing(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, `{"error":{"type":"document_missing_exception","reason":"[7]: document missing"},"status":404}`)
+	})
+
+	if err := client.SetVerified(context.Background(), 7, true, time.Now()); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestMemoryClient_SetVerified(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemoryClient()
+	m.UpsertTutor(ctx, &domain.Tutor{ID: 1, FullName: "Marie Curie", Rating: 4.5, ReviewsCount: 10})
+	at := time.Date(2026, 3, 1, 7, 30, 0, 0, time.UTC)
+
+	before, _ := m.GetTutor(ctx, 1)
+	if err := m.SetVerified(ctx, 1, true, at); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tutor, _ := m.GetTutor(ctx, 1)
+	if !tutor.IsVerified || tutor.VerifiedAt == nil || !tutor.VerifiedAt.Equal(at) {
+		t.Errorf("expected verified at %v, got %t at %v", at, tutor.IsVerified, tutor.VerifiedAt)
+	}
+	if tutor.Popularity <= before.Popularity {
+		t.Errorf("expected popularity to rise above %v, got %v", before.Popularity, tutor.Popularity)
+	}
+
+	// Verifying again keeps the original date.
+	m.SetVerified(ctx, 1, true, at.Add(time.Hour))
+	tutor, _ = m.GetTutor(ctx, 1)
+	if !tutor.VerifiedAt.Equal(at) {
+		t.Errorf("expected verified_at to stay %v, got %v", at, tutor.VerifiedAt)
+	}
+
+	m.SetVerified(ctx, 1, false, at)
+	tutor, _ = m.GetTutor(ctx, 1)
+	if tutor.IsVerified || tutor.VerifiedAt != nil {
+		t.Errorf("expected unverified without verified_at, got %t at %v", tutor.IsVerified, tutor.VerifiedAt)
+	}
+
+	if err := m.SetVerified(ctx, 99, true, at); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
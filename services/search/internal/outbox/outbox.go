@@ -0,0 +1,336 @@
+// Package outbox keeps index writes that failed on the HTTP path and
+// replays them until they succeed, optionally across restarts.
+package outbox
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"search/internal/domain"
+	"search/internal/port"
+	"search/internal/tenant"
+)
+
+// Operations an Entry replays.
+const (
+	OpUpsert = "upsert"
+	OpDelete = "delete"
+)
+
+// Retry pacing used by Run.
+const (
+	DefaultRetryInterval = 5 * time.Second
+	MaxRetryBackoff      = 5 * time.Minute
+)
+
+// DefaultMaxEntries caps the backlog unless configured otherwise.
+const DefaultMaxEntries = 1000
+
+// ErrFull is returned by Record when the journal holds MaxEntries writes.
+var ErrFull = errors.New("write journal is full")
+
+// Entry is one pending write. Only the latest write per tutor and tenant
+// is kept, so replaying entries in Seq order preserves the final state.
+type Entry struct {
+	Seq     int64          `json:"seq"`
+	Op      string         `json:"op"`
+	TutorID int64          `json:"tutor_id"`
+	Tenant  *tenant.Tenant `json:"tenant,omitempty"`
+	// Tutor is the document to write; nil for deletes.
+	Tutor      *domain.Tutor `json:"tutor,omitempty"`
+	RecordedAt time.Time     `json:"recorded_at"`
+	Attempts   int           `json:"attempts"`
+	LastError  string        `json:"last_error,omitempty"`
+}
+
+// key identifies the tutor document an entry writes.
+func (e Entry) key() string {
+	if e.Tenant == nil {
+		return fmt.Sprintf("/%d", e.TutorID)
+	}
+	return fmt.Sprintf("%s/%d", e.Tenant.Index, e.TutorID)
+}
+
+// Writer applies replayed writes; port.SearchClient implements it.
+type Writer interface {
+	UpsertTutor(ctx context.Context, tutor *domain.Tutor) error
+	DeleteTutor(ctx context.Context, id int64) error
+}
+
+// Journal holds pending writes in memory and, with a file, on disk. A nil
+// Journal records nothing.
+type Journal struct {
+	path       string
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.Mutex
+	entries []Entry
+	nextSeq int64
+}
+
+// Option configures a Journal.
+type Option func(*Journal)
+
+// WithFile keeps the journal in the file at path, one JSON entry per
+// line, so pending writes survive restarts. New loads it and every change
+// rewrites it atomically.
+func WithFile(path string) Option {
+	return func(j *Journal) {
+		j.path = path
+	}
+}
+
+// WithMaxEntries caps the backlog at n writes instead of
+// DefaultMaxEntries.
+func WithMaxEntries(n int) Option {
+	return func(j *Journal) {
+		j.maxEntries = n
+	}
+}
+
+// WithClock replaces time.Now for entry timestamps.
+func WithClock(now func() time.Time) Option {
+	return func(j *Journal) {
+		j.now = now
+	}
+}
+
+// New returns a Journal, starting from its file when one is configured and
+// exists.
+func New(opts ...Option) (*Journal, error) {
+	j := &Journal{maxEntries: DefaultMaxEntries, now: time.Now, nextSeq: 1}
+	for _, opt := range opts {
+		opt(j)
+	}
+	if j.path == "" {
+		return j, nil
+	}
+
+	data, err := os.ReadFile(j.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return j, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read write journal: %w", err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("parse write journal %s line %d: %w", j.path, line, err)
+		}
+		j.entries = append(j.entries, e)
+		j.nextSeq = max(j.nextSeq, e.Seq+1)
+	}
+	slices.SortFunc(j.entries, func(a, b Entry) int { return cmp.Compare(a.Seq, b.Seq) })
+	return j, nil
+}
+
+// Record queues a write of tutor, or a delete of tutorID when op is
+// OpDelete, to ctx's tenant. It replaces any pending write of the same
+// tutor. ErrFull is returned when the backlog is at its cap.
+func (j *Journal) Record(ctx context.Context, op string, tutorID int64, tutor *domain.Tutor) error {
+	if j == nil {
+		return errors.New("write journal is not enabled")
+	}
+	e := Entry{Op: op, TutorID: tutorID, RecordedAt: j.now().UTC()}
+	if t, ok := tenant.FromContext(ctx); ok {
+		e.Tenant = &t
+	}
+	if op == OpUpsert {
+		copied := *tutor
+		e.Tutor = &copied
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	entries := slices.DeleteFunc(slices.Clone(j.entries), func(p Entry) bool { return p.key() == e.key() })
+	if len(entries) >= j.maxEntries {
+		return ErrFull
+	}
+	e.Seq = j.nextSeq
+	entries = append(entries, e)
+	if err := j.save(entries); err != nil {
+		return err
+	}
+	j.entries = entries
+	j.nextSeq++
+	return nil
+}
+
+// Forget drops the pending write of tutorID to ctx's tenant, if any. Call
+// it after a later write of the tutor succeeded, so the replay cannot undo
+// it.
+func (j *Journal) Forget(ctx context.Context, tutorID int64) error {
+	if j == nil {
+		return nil
+	}
+	probe := Entry{TutorID: tutorID}
+	if t, ok := tenant.FromContext(ctx); ok {
+		probe.Tenant = &t
+	}
+	return j.remove(func(e Entry) bool { return e.key() == probe.key() })
+}
+
+// Entries returns the pending writes, oldest first.
+func (j *Journal) Entries() []Entry {
+	if j == nil {
+		return nil
+	}
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return slices.Clone(j.entries)
+}
+
+// MaxEntries returns the backlog cap.
+func (j *Journal) MaxEntries() int {
+	if j == nil {
+		return 0
+	}
+	return j.maxEntries
+}
+
+// Replay applies the pending writes oldest first and drops each that
+// succeeds; a delete of a tutor already gone counts as success. It stops
+// at the first failure, which it records on the entry and returns.
+func (j *Journal) Replay(ctx context.Context, w Writer) (replayed int, err error) {
+	for _, e := range j.Entries() {
+		if err := j.apply(ctx, w, e); err != nil {
+			j.update(e.Seq, func(p *Entry) {
+				p.Attempts++
+				p.LastError = err.Error()
+			})
+			return replayed, fmt.Errorf("replay %s of tutor %d: %w", e.Op, e.TutorID, err)
+		}
+		// A newer write of the tutor recorded meanwhile has its own Seq
+		// and stays queued.
+		if err := j.remove(func(p Entry) bool { return p.Seq == e.Seq }); err != nil {
+			return replayed, err
+		}
+		replayed++
+	}
+	return replayed, nil
+}
+
+// apply writes e, restamping an upserted tutor's indexed_at.
+func (j *Journal) apply(ctx context.Context, w Writer, e Entry) error {
+	if e.Tenant != nil {
+		ctx = tenant.NewContext(ctx, *e.Tenant)
+	}
+	switch e.Op {
+	case OpUpsert:
+		tutor := *e.Tutor
+		tutor.MarkIndexed(j.now())
+		return w.UpsertTutor(ctx, &tutor)
+	case OpDelete:
+		if err := w.DeleteTutor(ctx, e.TutorID); err != nil && !errors.Is(err, port.ErrNotFound) {
+			return err
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown operation %q", e.Op)
+}
+
+// Run replays the backlog every DefaultRetryInterval until ctx ends. After
+// a failed replay the wait doubles, up to MaxRetryBackoff.
+func (j *Journal) Run(ctx context.Context, w Writer, logger *slog.Logger) {
+	delay := DefaultRetryInterval
+	for {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return
+		}
+
+		replayed, err := j.Replay(ctx, w)
+		if replayed > 0 {
+			logger.Info("Replayed pending tutor writes", "replayed", replayed, "pending", len(j.Entries()))
+		}
+		if err != nil {
+			delay = min(delay*2, MaxRetryBackoff)
+			logger.Warn("Failed to replay pending tutor writes", "error", err, "retry_in", delay)
+			continue
+		}
+		delay = DefaultRetryInterval
+	}
+}
+
+func (j *Journal) remove(drop func(Entry) bool) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	entries := slices.DeleteFunc(slices.Clone(j.entries), drop)
+	if len(entries) == len(j.entries) {
+		return nil
+	}
+	if err := j.save(entries); err != nil {
+		return err
+	}
+	j.entries = entries
+	return nil
+}
+
+func (j *Journal) update(seq int64, fn func(*Entry)) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	for i := range j.entries {
+		if j.entries[i].Seq == seq {
+			fn(&j.entries[i])
+		}
+	}
+	// Attempt counts are informational; losing one on a failed save is
+	// harmless.
+	_ = j.save(j.entries)
+}
+
+// save writes entries to the journal file, replacing it atomically.
+// Without a file it does nothing.
+func (j *Journal) save(entries []Entry) error {
+	if j.path == "" {
+		return nil
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			return fmt.Errorf("write journal: %w", err)
+		}
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(j.path), filepath.Base(j.path)+".*")
+	if err != nil {
+		return fmt.Errorf("write journal: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write journal: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write journal: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write journal: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), j.path); err != nil {
+		return fmt.Errorf("write journal: %w", err)
+	}
+	return nil
+}
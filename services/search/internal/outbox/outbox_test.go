@@ -0,0 +1,179 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/domain"
+	"search/internal/port"
+	"search/internal/tenant"
+)
+
+// fakeWriter records the writes it applies and fails while err is set.
+type fakeWriter struct {
+	mu     sync.Mutex
+	err    error
+	writes []string
+}
+
+func (f *fakeWriter) UpsertTutor(ctx context.Context, tutor *domain.Tutor) error {
+	return f.write(ctx, fmt.Sprintf("upsert %d %s", tutor.ID, tutor.FullName))
+}
+
+func (f *fakeWriter) DeleteTutor(ctx context.Context, id int64) error {
+	if id == 404 {
+		return port.ErrNotFound
+	}
+	return f.write(ctx, fmt.Sprintf("delete %d", id))
+}
+
+func (f *fakeWriter) write(ctx context.Context, op string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	if t, ok := tenant.FromContext(ctx); ok {
+		op += " @" + t.Index
+	}
+	f.writes = append(f.writes, op)
+	return nil
+}
+
+func TestJournal_PersistsAcrossRestart(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+
+	journal, err := New(WithFile(path))
+	require.NoError(t, err)
+	require.NoError(t, journal.Record(ctx, OpUpsert, 1, &domain.Tutor{ID: 1, FullName: "Ada"}))
+	de := tenant.NewContext(ctx, tenant.Tenant{Name: "de", Index: "tutors-de"})
+	require.NoError(t, journal.Record(de, OpDelete, 2, nil))
+
+	// A new process loads the backlog and keeps numbering after it.
+	restarted, err := New(WithFile(path))
+	require.NoError(t, err)
+	entries := restarted.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, OpUpsert, entries[0].Op)
+	assert.Equal(t, "Ada", entries[0].Tutor.FullName)
+	assert.Equal(t, OpDelete, entries[1].Op)
+	assert.Equal(t, "tutors-de", entries[1].Tenant.Index)
+
+	require.NoError(t, restarted.Record(ctx, OpUpsert, 3, &domain.Tutor{ID: 3}))
+	assert.Greater(t, restarted.Entries()[2].Seq, entries[1].Seq)
+
+	writer := &fakeWriter{}
+	replayed, err := restarted.Replay(ctx, writer)
+	require.NoError(t, err)
+	assert.Equal(t, 3, replayed)
+	assert.Equal(t, []string{"upsert 1 Ada", "delete 2 @tutors-de", "upsert 3 "}, writer.writes)
+
+	emptied, err := New(WithFile(path))
+	require.NoError(t, err)
+	assert.Empty(t, emptied.Entries(), "replayed writes are removed from the file")
+}
+
+func TestJournal_ReplayOrder(t *testing.T) {
+	ctx := context.Background()
+	journal, err := New()
+	require.NoError(t, err)
+
+	require.NoError(t, journal.Record(ctx, OpUpsert, 1, &domain.Tutor{ID: 1, FullName: "first"}))
+	require.NoError(t, journal.Record(ctx, OpUpsert, 2, &domain.Tutor{ID: 2, FullName: "Bob"}))
+	// A later write of tutor 1 replaces its pending one and moves to the back.
+	require.NoError(t, journal.Record(ctx, OpUpsert, 1, &domain.Tutor{ID: 1, FullName: "second"}))
+	require.NoError(t, journal.Record(ctx, OpDelete, 3, nil))
+	require.Len(t, journal.Entries(), 3)
+
+	writer := &fakeWriter{}
+	_, err = journal.Replay(ctx, writer)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"upsert 2 Bob", "upsert 1 second", "delete 3"}, writer.writes)
+}
+
+func TestJournal_ReplayStopsAtFailure(t *testing.T) {
+	ctx := context.Background()
+	journal, err := New()
+	require.NoError(t, err)
+	require.NoError(t, journal.Record(ctx, OpUpsert, 1, &domain.Tutor{ID: 1}))
+	require.NoError(t, journal.Record(ctx, OpUpsert, 2, &domain.Tutor{ID: 2}))
+
+	writer := &fakeWriter{err: errors.New("cluster unavailable")}
+	replayed, err := journal.Replay(ctx, writer)
+	require.Error(t, err)
+	assert.Zero(t, replayed)
+	entries := journal.Entries()
+	require.Len(t, entries, 2)
+	assert.Equal(t, 1, entries[0].Attempts)
+	assert.Equal(t, "cluster unavailable", entries[0].LastError)
+	assert.Zero(t, entries[1].Attempts, "writes after the failure are not tried")
+
+	writer.err = nil
+	replayed, err = journal.Replay(ctx, writer)
+	require.NoError(t, err)
+	assert.Equal(t, 2, replayed)
+	assert.Empty(t, journal.Entries())
+}
+
+func TestJournal_DeleteOfMissingTutorSucceeds(t *testing.T) {
+	ctx := context.Background()
+	journal, err := New()
+	require.NoError(t, err)
+	require.NoError(t, journal.Record(ctx, OpDelete, 404, nil))
+
+	_, err = journal.Replay(ctx, &fakeWriter{})
+	require.NoError(t, err)
+	assert.Empty(t, journal.Entries())
+}
+
+func TestJournal_MaxEntries(t *testing.T) {
+	ctx := context.Background()
+	journal, err := New(WithMaxEntries(2))
+	require.NoError(t, err)
+
+	require.NoError(t, journal.Record(ctx, OpDelete, 1, nil))
+	require.NoError(t, journal.Record(ctx, OpDelete, 2, nil))
+	assert.ErrorIs(t, journal.Record(ctx, OpDelete, 3, nil), ErrFull)
+	assert.NoError(t, journal.Record(ctx, OpUpsert, 2, &domain.Tutor{ID: 2}), "replacing a pending write does not grow the backlog")
+	assert.Equal(t, 2, journal.MaxEntries())
+}
+
+func TestJournal_Forget(t *testing.T) {
+	ctx := context.Background()
+	de := tenant.NewContext(ctx, tenant.Tenant{Name: "de", Index: "tutors-de"})
+	journal, err := New()
+	require.NoError(t, err)
+	require.NoError(t, journal.Record(ctx, OpUpsert, 1, &domain.Tutor{ID: 1}))
+	require.NoError(t, journal.Record(de, OpUpsert, 1, &domain.Tutor{ID: 1}))
+
+	require.NoError(t, journal.Forget(de, 1))
+
+	entries := journal.Entries()
+	require.Len(t, entries, 1, "only the tenant's own pending write is dropped")
+	assert.Nil(t, entries[0].Tenant)
+}
+
+func TestNew_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(`{"seq": 1, "op": "delete", "tutor_id": 1}`+"\n{oops\n"), 0o600))
+
+	_, err := New(WithFile(path))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+}
+
+func TestJournal_Nil(t *testing.T) {
+	var journal *Journal
+	assert.Error(t, journal.Record(context.Background(), OpDelete, 1, nil))
+	assert.NoError(t, journal.Forget(context.Background(), 1))
+	assert.Empty(t, journal.Entries())
+}
@@ -0,0 +1,43 @@
+package port
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Suffixes naming the aliases searches and writes target when the backend
+// splits them, after the live index, as in tutors-read and tutors-write.
+// Pointing them at different indices lets a long reindex fill a new index
+// while searches keep hitting the old one.
+const (
+	ReadAliasSuffix  = "-read"
+	WriteAliasSuffix = "-write"
+)
+
+// AliasTarget is an index alias and the index behind it. Index is empty
+// when the alias does not exist.
+type AliasTarget struct {
+	Alias string `json:"alias"`
+	Index string `json:"index"`
+}
+
+// IndexAliases is where ctx's read and write aliases point.
+type IndexAliases struct {
+	Read  AliasTarget `json:"read"`
+	Write AliasTarget `json:"write"`
+}
+
+// ValidateAliasTarget checks index names a single concrete index an alias
+// can point at.
+func ValidateAliasTarget(index string) error {
+	if index == "" {
+		return fmt.Errorf("index must not be empty")
+	}
+	if strings.ContainsAny(index, "*,? \"\\/<>|#") {
+		return fmt.Errorf("index %q must name a single index", index)
+	}
+	if strings.HasSuffix(index, ReadAliasSuffix) || strings.HasSuffix(index, WriteAliasSuffix) {
+		return fmt.Errorf("index %q must not be a read or write alias", index)
+	}
+	return nil
+}
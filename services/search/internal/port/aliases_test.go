@@ -0,0 +1,38 @@
+package port
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateAliasTarget(t *testing.T) {
+	tests := []struct {
+		index   string
+		wantErr string
+	}{
+		{index: "tutors"},
+		{index: "tutors-v2"},
+		{index: "tutors-20261017"},
+		{index: "", wantErr: "must not be empty"},
+		{index: "tutors-*", wantErr: "single index"},
+		{index: "tutors,tutors-v2", wantErr: "single index"},
+		{index: "tutors v2", wantErr: "single index"},
+		{index: "tutors-read", wantErr: "read or write alias"},
+		{index: "tutors-write", wantErr: "read or write alias"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.index, func(t *testing.T) {
+			err := ValidateAliasTarget(tt.index)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
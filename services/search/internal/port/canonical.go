@@ -0,0 +1,115 @@
+package port
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Canonical returns a stable text form of q: equal searches written
+// differently give the same string. Text, location and certification are
+// lowercased with runs of whitespace collapsed, list filters are sorted and
+// deduplicated, the page is the one Page applies, and unset filters are
+// left out. The result is URL-encoded parameters sorted by name.
+func (q SearchQuery) Canonical() string {
+	v := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			v.Set(key, value)
+		}
+	}
+	setFloat := func(key string, f *float64) {
+		if f != nil {
+			v.Set(key, strconv.FormatFloat(*f, 'g', -1, 64))
+		}
+	}
+	setList := func(key string, values []string) {
+		if len(values) > 0 {
+			v[key] = sortedSet(values)
+		}
+	}
+
+	set("q", normalizeText(q.Text))
+	setList("subjects", lowerAll(q.Subjects))
+	setFloat("min_price", q.MinPrice)
+	setFloat("max_price", q.MaxPrice)
+	setFloat("below_price", q.BelowPrice)
+	setFloat("min_rating", q.MinRating)
+	set("format", strings.ToLower(q.Format))
+	set("location", normalizeText(q.Location))
+	setList("level", lowerAll(q.Levels))
+	set("certification", normalizeText(q.Certification))
+	set("badge", strings.ToLower(q.Badge))
+	if q.Trial {
+		v.Set("trial", "true")
+	}
+	set("variant", q.Variant)
+	if q.AvailableWithinDays > 0 {
+		v.Set("available_within_days", strconv.Itoa(q.AvailableWithinDays))
+	}
+	if len(q.AvailableBetween) > 0 {
+		ranges := make([]string, len(q.AvailableBetween))
+		for i, r := range q.AvailableBetween {
+			ranges[i] = strconv.Itoa(r.Start) + "-" + strconv.Itoa(r.End)
+		}
+		setList("available_between", ranges)
+	}
+	if len(q.ExcludeIDs) > 0 {
+		for _, id := range sortedSet(q.ExcludeIDs) {
+			v.Add("exclude_ids", strconv.FormatInt(id, 10))
+		}
+	}
+	if q.IncludeBio {
+		v.Set("fields", "bio")
+	}
+	set("sort", q.Sort)
+	set("diversify_by", q.DiversifyBy)
+	limit, offset := q.Page()
+	v.Set("limit", strconv.Itoa(limit))
+	v.Set("offset", strconv.Itoa(offset))
+	return v.Encode()
+}
+
+// Normalized returns q as a backend applies it: the page clamped by Page,
+// and subjects, levels and excluded IDs sorted without duplicates. Other
+// fields are copied unchanged, and q is not modified.
+func (q SearchQuery) Normalized() SearchQuery {
+	n := q
+	n.Subjects = sortedSet(q.Subjects)
+	n.Levels = sortedSet(q.Levels)
+	n.ExcludeIDs = sortedSet(q.ExcludeIDs)
+	n.Limit, n.Offset = q.Page()
+	return n
+}
+
+// Hash returns the first 16 hex digits of the SHA-256 of Canonical, short
+// enough to quote in a support ticket and stable across processes.
+func (q SearchQuery) Hash() string {
+	sum := sha256.Sum256([]byte(q.Canonical()))
+	return hex.EncodeToString(sum[:8])
+}
+
+// sortedSet returns a sorted copy of values without duplicates, nil when
+// values is empty.
+func sortedSet[T cmp.Ordered](values []T) []T {
+	if len(values) == 0 {
+		return nil
+	}
+	return slices.Compact(slices.Sorted(slices.Values(values)))
+}
+
+func normalizeText(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+func lowerAll(values []string) []string {
+	out := make([]string, len(values))
+	for i, s := range values {
+		out[i] = strings.ToLower(s)
+	}
+	return out
+}
@@ -0,0 +1,189 @@
+package port
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"search/internal/domain"
+)
+
+func ptr(f float64) *float64 { return &f }
+
+func TestSearchQuery_Canonical(t *testing.T) {
+	q := SearchQuery{
+		Text:       "  Algebra   TUTOR ",
+		Subjects:   []string{"physics", "math", "math"},
+		MinPrice:   ptr(10),
+		MaxPrice:   ptr(49.5),
+		Levels:     []string{"University", "school"},
+		ExcludeIDs: []int64{12, 3},
+		Sort:       SortRating,
+	}
+	want := "exclude_ids=3&exclude_ids=12&level=school&level=university&limit=20&max_price=49.5&min_price=10" +
+		"&offset=0&q=algebra+tutor&sort=rating&subjects=math&subjects=physics"
+	if got := q.Canonical(); got != want {
+		t.Errorf("Canonical() = %q, want %q", got, want)
+	}
+}
+
+func TestSearchQuery_Normalized(t *testing.T) {
+	tests := []struct {
+		name  string
+		query SearchQuery
+		want  SearchQuery
+	}{
+		{
+			name:  "defaults filled in",
+			query: SearchQuery{Text: "algebra"},
+			want:  SearchQuery{Text: "algebra", Limit: DefaultLimit},
+		},
+		{
+			name:  "limit clamped and negative offset zeroed",
+			query: SearchQuery{Limit: 500, Offset: -5},
+			want:  SearchQuery{Limit: MaxLimit},
+		},
+		{
+			name: "lists sorted and deduplicated",
+			query: SearchQuery{
+				Subjects:   []string{"physics", "math", "physics"},
+				Levels:     []string{"school", "adult", "school"},
+				ExcludeIDs: []int64{9, 3, 9},
+				Limit:      10,
+				Offset:     30,
+			},
+			want: SearchQuery{
+				Subjects:   []string{"math", "physics"},
+				Levels:     []string{"adult", "school"},
+				ExcludeIDs: []int64{3, 9},
+				Limit:      10,
+				Offset:     30,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.query.Normalized()
+			if got.Canonical() != tt.want.Canonical() || got.Limit != tt.want.Limit || got.Offset != tt.want.Offset {
+				t.Errorf("Normalized() = %+v, want %+v", got, tt.want)
+			}
+			if !slices.Equal(got.Subjects, tt.want.Subjects) || !slices.Equal(got.Levels, tt.want.Levels) ||
+				!slices.Equal(got.ExcludeIDs, tt.want.ExcludeIDs) {
+				t.Errorf("Normalized() lists = %v %v %v, want %v %v %v",
+					got.Subjects, got.Levels, got.ExcludeIDs, tt.want.Subjects, tt.want.Levels, tt.want.ExcludeIDs)
+			}
+			if got.Hash() != tt.query.Hash() {
+				t.Error("Normalized() must describe the same search as the original query")
+			}
+		})
+	}
+}
+
+func TestSearchQuery_Normalized_DoesNotModifyQuery(t *testing.T) {
+	q := SearchQuery{Subjects: []string{"physics", "math"}, ExcludeIDs: []int64{9, 3}, Limit: 500}
+	q.Normalized()
+	if !slices.Equal(q.Subjects, []string{"physics", "math"}) || !slices.Equal(q.ExcludeIDs, []int64{9, 3}) || q.Limit != 500 {
+		t.Errorf("Normalized() modified its receiver: %+v", q)
+	}
+}
+
+func TestSearchQuery_Hash_EqualQueries(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b SearchQuery
+	}{
+		{"subject order", SearchQuery{Subjects: []string{"math", "physics"}}, SearchQuery{Subjects: []string{"physics", "math"}}},
+		{"duplicate subjects", SearchQuery{Subjects: []string{"math"}}, SearchQuery{Subjects: []string{"math", "math"}}},
+		{"text case and spacing", SearchQuery{Text: "Algebra Tutor"}, SearchQuery{Text: " algebra   tutor "}},
+		{"default limit", SearchQuery{}, SearchQuery{Limit: DefaultLimit}},
+		{"limit above the maximum", SearchQuery{Limit: 500}, SearchQuery{Limit: MaxLimit}},
+		{"negative offset", SearchQuery{Offset: -5}, SearchQuery{}},
+		{"empty lists", SearchQuery{Subjects: []string{}, ExcludeIDs: []int64{}}, SearchQuery{}},
+		{"exclude order", SearchQuery{ExcludeIDs: []int64{3, 1, 2}}, SearchQuery{ExcludeIDs: []int64{1, 2, 3}}},
+		{
+			"window order",
+			SearchQuery{AvailableBetween: []domain.MinuteRange{{Start: 0, End: 60}, {Start: 1380, End: 1440}}},
+			SearchQuery{AvailableBetween: []domain.MinuteRange{{Start: 1380, End: 1440}, {Start: 0, End: 60}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.a.Hash() != tt.b.Hash() {
+				t.Errorf("hashes differ:\n%s\n%s", tt.a.Canonical(), tt.b.Canonical())
+			}
+		})
+	}
+}
+
+func TestSearchQuery_Hash_Permutations(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	base := SearchQuery{
+		Text:       "calculus",
+		Subjects:   []string{"math", "physics", "chemistry", "biology", "english"},
+		Levels:     []string{"school", "university", "adult"},
+		ExcludeIDs: []int64{5, 8, 13, 21, 34},
+	}
+	want := base.Hash()
+	for i := 0; i < 100; i++ {
+		q := base
+		q.Subjects = shuffled(rng, base.Subjects)
+		q.Levels = shuffled(rng, base.Levels)
+		q.ExcludeIDs = shuffled(rng, base.ExcludeIDs)
+		if got := q.Hash(); got != want {
+			t.Fatalf("permutation %v %v %v hashes to %s, want %s", q.Subjects, q.Levels, q.ExcludeIDs, got, want)
+		}
+	}
+}
+
+func TestSearchQuery_Hash_DifferentQueries(t *testing.T) {
+	queries := []SearchQuery{
+		{},
+		{Text: "math"},
+		{Text: "maths"},
+		{Subjects: []string{"math"}},
+		{Location: "math"},
+		{Certification: "math"},
+		{Subjects: []string{"math", "physics"}},
+		{Subjects: []string{"math,physics"}},
+		{MinPrice: ptr(10)},
+		{MaxPrice: ptr(10)},
+		{BelowPrice: ptr(10)},
+		{MinRating: ptr(4)},
+		{Format: "online"},
+		{Levels: []string{"school"}},
+		{Badge: "featured"},
+		{Variant: "b"},
+		{AvailableWithinDays: 7},
+		{AvailableBetween: []domain.MinuteRange{{Start: 0, End: 60}}},
+		{ExcludeIDs: []int64{1}},
+		{IncludeBio: true},
+		{Sort: SortRating},
+		{Sort: SortPopularity},
+		{DiversifyBy: DiversifyLocation},
+		{Limit: 10},
+		{Offset: 20},
+		{Limit: 10, Offset: 20},
+	}
+	seen := make(map[string]int)
+	for i, q := range queries {
+		h := q.Hash()
+		if j, ok := seen[h]; ok {
+			t.Errorf("queries %d (%s) and %d (%s) hash to %s", j, queries[j].Canonical(), i, q.Canonical(), h)
+		}
+		seen[h] = i
+	}
+}
+
+func TestSearchQuery_Hash_DoesNotModifyQuery(t *testing.T) {
+	q := SearchQuery{Subjects: []string{"physics", "math"}, ExcludeIDs: []int64{2, 1}}
+	q.Hash()
+	if q.Subjects[0] != "physics" || q.ExcludeIDs[0] != 2 {
+		t.Errorf("Hash reordered the query's slices: %v %v", q.Subjects, q.ExcludeIDs)
+	}
+}
+
+func shuffled[T any](rng *rand.Rand, s []T) []T {
+	out := append([]T(nil), s...)
+	rng.Shuffle(len(out), func(i, j int) { out[i], out[j] = out[j], out[i] })
+	return out
+}
@@ -0,0 +1,119 @@
+package port
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"strconv"
+	"time"
+)
+
+// ChecksumAlgorithm names how a Checksum is computed, for whoever computes
+// the same from another copy of the tutors. Every tutor contributes the
+// line "<id>:<updated_at>\n", updated_at in Unix microseconds (0 when
+// unset), in ascending ID order, and the digest is the hex SHA-256 of all
+// the lines.
+const ChecksumAlgorithm = "sha256:id:updated_at_us"
+
+// MaxChecksumBuckets bounds how many buckets a Checksum splits tutors into.
+const MaxChecksumBuckets = 1024
+
+// TutorVersion is what a Checksum covers of one tutor.
+type TutorVersion struct {
+	ID        int64
+	UpdatedAt time.Time
+}
+
+// Digest is the checksum of a set of tutors.
+type Digest struct {
+	Count  int64  `json:"count"`
+	Digest string `json:"digest"`
+}
+
+// BucketDigest is the Digest of the tutors whose ID modulo the bucket
+// count is Bucket.
+type BucketDigest struct {
+	Bucket int `json:"bucket"`
+	Digest
+}
+
+// Checksum digests every tutor of an index and, when asked for, each
+// bucket of them separately.
+type Checksum struct {
+	Digest
+	Buckets []BucketDigest `json:"buckets,omitempty"`
+}
+
+// Checksummer computes a Checksum from tutors added in ascending ID order.
+type Checksummer struct {
+	all     digester
+	buckets []digester
+	last    int64
+	line    []byte
+}
+
+type digester struct {
+	h     hash.Hash
+	count int64
+}
+
+func newDigester() digester {
+	return digester{h: sha256.New()}
+}
+
+func (d *digester) add(line []byte) {
+	d.h.Write(line)
+	d.count++
+}
+
+func (d *digester) sum() Digest {
+	return Digest{Count: d.count, Digest: hex.EncodeToString(d.h.Sum(nil))}
+}
+
+// NewChecksummer returns a Checksummer that also digests tutors in buckets
+// by ID modulo buckets. Zero buckets digests them only as a whole.
+func NewChecksummer(buckets int) *Checksummer {
+	c := &Checksummer{all: newDigester(), buckets: make([]digester, buckets)}
+	for i := range c.buckets {
+		c.buckets[i] = newDigester()
+	}
+	return c
+}
+
+// Add digests v. It fails when v does not come after the previous tutor,
+// as the digest would then not match one computed in order.
+func (c *Checksummer) Add(v TutorVersion) error {
+	if c.all.count > 0 && v.ID <= c.last {
+		return fmt.Errorf("tutor %d out of order after %d", v.ID, c.last)
+	}
+	c.last = v.ID
+
+	var micros int64
+	if !v.UpdatedAt.IsZero() {
+		micros = v.UpdatedAt.UnixMicro()
+	}
+	c.line = strconv.AppendInt(c.line[:0], v.ID, 10)
+	c.line = append(c.line, ':')
+	c.line = strconv.AppendInt(c.line, micros, 10)
+	c.line = append(c.line, '\n')
+
+	c.all.add(c.line)
+	if n := int64(len(c.buckets)); n > 0 {
+		bucket := v.ID % n
+		if bucket < 0 {
+			bucket += n
+		}
+		c.buckets[bucket].add(c.line)
+	}
+	return nil
+}
+
+// Sum returns the checksum of the tutors added so far.
+func (c *Checksummer) Sum() Checksum {
+	sum := Checksum{Digest: c.all.sum()}
+	for i := range c.buckets {
+		sum.Buckets = append(sum.Buckets, BucketDigest{Bucket: i, Digest: c.buckets[i].sum()})
+	}
+	return sum
+}
@@ -0,0 +1,35 @@
+package port
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChecksummer(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	sum := NewChecksummer(0)
+	for _, v := range []TutorVersion{{ID: 1, UpdatedAt: at}, {ID: 5}} {
+		if err := sum.Add(v); err != nil {
+			t.Fatalf("Add(%d): %v", v.ID, err)
+		}
+	}
+
+	// sha256("1:1772366400000000\n5:0\n")
+	want := "1264975fc3e1d9b27af4ba448acc2edcf2c7203a4c0f9b7c182a0319846e2b21"
+	if got := sum.Sum(); got.Count != 2 || got.Digest.Digest != want || got.Buckets != nil {
+		t.Errorf("expected 2 tutors digesting to %s without buckets, got %+v", want, got)
+	}
+}
+
+func TestChecksummer_RejectsOutOfOrder(t *testing.T) {
+	sum := NewChecksummer(4)
+	if err := sum.Add(TutorVersion{ID: 3}); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	for _, id := range []int64{3, 2} {
+		if err := sum.Add(TutorVersion{ID: id}); err == nil || !strings.Contains(err.Error(), "out of order") {
+			t.Errorf("Add(%d): expected an out of order error, got %v", id, err)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package port
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// IndexVariant is the experiment variant that searches the index
+// experiment's index instead of the live one. While no index experiment
+// is running it is served by the live index with the default relevance.
+const IndexVariant = "index"
+
+// ExperimentIndexSuffix names the alias of an index experiment after the
+// live index it shadows, as in tutors-exp.
+const ExperimentIndexSuffix = "-exp"
+
+// Analysis an index experiment may change. Field types, the other
+// analysis components and the shard count are fixed, so every document the
+// live index accepts fits the experiment index too.
+var (
+	// ExperimentAnalyzers are the analyzers the text fields are mapped
+	// with.
+	ExperimentAnalyzers = []string{"english_analyzer", "russian_analyzer"}
+	// ExperimentTokenizers are the built-in tokenizers an analyzer may
+	// use.
+	ExperimentTokenizers = []string{"standard", "classic", "letter", "whitespace"}
+	// ExperimentFilters are the token filters an analyzer may chain: the
+	// index's own stemmers and stateless built-ins.
+	ExperimentFilters = []string{
+		"lowercase", "asciifolding", "stop", "kstem", "porter_stem", "unique",
+		"english_stemmer", "russian_stemmer",
+	}
+	// ExperimentStemmers lists the languages each of the index's stemmer
+	// filters may be switched to.
+	ExperimentStemmers = map[string][]string{
+		"english_stemmer": {"english", "light_english", "minimal_english", "porter2", "possessive_english"},
+		"russian_stemmer": {"russian", "light_russian"},
+	}
+)
+
+// maxAnalyzerFilters bounds the filter chain of an experiment analyzer.
+const maxAnalyzerFilters = 8
+
+// ExperimentMapping is how an index experiment's analysis differs from
+// the live index's. Analyzers and stemmers it leaves out are copied
+// unchanged.
+type ExperimentMapping struct {
+	// Analyzers replaces analyzers from ExperimentAnalyzers, by name.
+	Analyzers map[string]Analyzer `json:"analyzers,omitempty"`
+	// Stemmers sets the language of stemmer filters from
+	// ExperimentStemmers, by name.
+	Stemmers map[string]string `json:"stemmers,omitempty"`
+}
+
+// Analyzer is a custom analyzer: a tokenizer followed by token filters
+// applied in order.
+type Analyzer struct {
+	Tokenizer string   `json:"tokenizer"`
+	Filter    []string `json:"filter"`
+}
+
+// Validate reports the first change outside the whitelist above.
+func (m ExperimentMapping) Validate() error {
+	for _, name := range slices.Sorted(maps.Keys(m.Analyzers)) {
+		a := m.Analyzers[name]
+		if !slices.Contains(ExperimentAnalyzers, name) {
+			return fmt.Errorf("analyzer %q cannot be changed, want one of %s", name, strings.Join(ExperimentAnalyzers, ", "))
+		}
+		if !slices.Contains(ExperimentTokenizers, a.Tokenizer) {
+			return fmt.Errorf("analyzer %q: tokenizer must be one of %s, got %q", name, strings.Join(ExperimentTokenizers, ", "), a.Tokenizer)
+		}
+		if len(a.Filter) > maxAnalyzerFilters {
+			return fmt.Errorf("analyzer %q: at most %d filters, got %d", name, maxAnalyzerFilters, len(a.Filter))
+		}
+		for _, f := range a.Filter {
+			if !slices.Contains(ExperimentFilters, f) {
+				return fmt.Errorf("analyzer %q: filter must be one of %s, got %q", name, strings.Join(ExperimentFilters, ", "), f)
+			}
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(m.Stemmers)) {
+		languages, ok := ExperimentStemmers[name]
+		if !ok {
+			return fmt.Errorf("stemmer %q cannot be changed, want one of %s", name, strings.Join(slices.Sorted(maps.Keys(ExperimentStemmers)), ", "))
+		}
+		if !slices.Contains(languages, m.Stemmers[name]) {
+			return fmt.Errorf("stemmer %q: language must be one of %s, got %q", name, strings.Join(languages, ", "), m.Stemmers[name])
+		}
+	}
+	return nil
+}
+
+// ExperimentIndex describes a running index experiment.
+type ExperimentIndex struct {
+	// Alias is what writes and IndexVariant searches target; Index is the
+	// index behind it.
+	Alias string `json:"alias"`
+	Index string `json:"index"`
+	// ReindexTask is the backend task copying the live index's documents
+	// over, for following its progress.
+	ReindexTask string `json:"reindex_task,omitempty"`
+}
@@ -0,0 +1,79 @@
+package port
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExperimentMapping_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mapping ExperimentMapping
+		wantErr string
+	}{
+		{name: "empty copies the live analysis", mapping: ExperimentMapping{}},
+		{
+			name: "whitelisted changes",
+			mapping: ExperimentMapping{
+				Analyzers: map[string]Analyzer{
+					"english_analyzer": {Tokenizer: "classic", Filter: []string{"lowercase", "asciifolding", "stop", "english_stemmer"}},
+					"russian_analyzer": {Tokenizer: "standard", Filter: []string{"lowercase", "russian_stemmer"}},
+				},
+				Stemmers: map[string]string{"english_stemmer": "light_english", "russian_stemmer": "light_russian"},
+			},
+		},
+		{
+			name:    "unknown analyzer",
+			mapping: ExperimentMapping{Analyzers: map[string]Analyzer{"keyword_analyzer": {Tokenizer: "standard"}}},
+			wantErr: `analyzer "keyword_analyzer" cannot be changed`,
+		},
+		{
+			name:    "tokenizer outside the whitelist",
+			mapping: ExperimentMapping{Analyzers: map[string]Analyzer{"english_analyzer": {Tokenizer: "ngram"}}},
+			wantErr: "tokenizer must be one of",
+		},
+		{
+			name:    "missing tokenizer",
+			mapping: ExperimentMapping{Analyzers: map[string]Analyzer{"english_analyzer": {Filter: []string{"lowercase"}}}},
+			wantErr: "tokenizer must be one of",
+		},
+		{
+			name: "filter outside the whitelist",
+			mapping: ExperimentMapping{Analyzers: map[string]Analyzer{
+				"english_analyzer": {Tokenizer: "standard", Filter: []string{"lowercase", "synonym"}},
+			}},
+			wantErr: `got "synonym"`,
+		},
+		{
+			name: "too many filters",
+			mapping: ExperimentMapping{Analyzers: map[string]Analyzer{
+				"english_analyzer": {Tokenizer: "standard", Filter: strings.Fields(strings.Repeat("lowercase ", maxAnalyzerFilters+1))},
+			}},
+			wantErr: "at most 8 filters",
+		},
+		{
+			name:    "unknown stemmer",
+			mapping: ExperimentMapping{Stemmers: map[string]string{"german_stemmer": "german"}},
+			wantErr: `stemmer "german_stemmer" cannot be changed`,
+		},
+		{
+			name:    "language of another stemmer",
+			mapping: ExperimentMapping{Stemmers: map[string]string{"english_stemmer": "russian"}},
+			wantErr: "language must be one of",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.mapping.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package port
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"search/internal/domain"
+)
+
+// fullQuery sets every serialized SearchQuery field to a non-zero value.
+func fullQuery() SearchQuery {
+	return SearchQuery{
+		Text:                "algebra",
+		Subjects:            []string{"math", "physics"},
+		MinPrice:            ptr(0),
+		MaxPrice:            ptr(60.5),
+		BelowPrice:          ptr(70),
+		MinRating:           ptr(4.5),
+		Format:              "online",
+		Location:            "Berlin",
+		Levels:              []string{"school"},
+		Certification:       "CELTA",
+		Badge:               "featured",
+		Trial:               true,
+		AvailableWithinDays: 7,
+		AvailableBetween:    []domain.MinuteRange{{Start: 1080, End: 1260}},
+		ExcludeIDs:          []int64{3, 9},
+		IncludeBio:          true,
+		Sort:                SortRating,
+		DiversifyBy:         DiversifyLocation,
+		Limit:               10,
+		Offset:              20,
+	}
+}
+
+func TestSearchQuery_JSONTags(t *testing.T) {
+	snakeCase := regexp.MustCompile(`^[a-z]+(_[a-z]+)*$`)
+	typ := reflect.TypeFor[SearchQuery]()
+	for i := range typ.NumField() {
+		f := typ.Field(i)
+		tag, ok := f.Tag.Lookup("json")
+		if !ok {
+			t.Errorf("field %s has no json tag", f.Name)
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+		if name != "-" && !snakeCase.MatchString(name) {
+			t.Errorf("field %s is serialized as %q, want snake_case", f.Name, name)
+		}
+	}
+}
+
+func TestSearchQuery_JSONRoundTrip(t *testing.T) {
+	q := fullQuery()
+
+	// A field added to SearchQuery must be added to fullQuery too.
+	v := reflect.ValueOf(q)
+	for i := range v.NumField() {
+		f := v.Type().Field(i)
+		if f.Tag.Get("json") != "-" && v.Field(i).IsZero() {
+			t.Errorf("fullQuery leaves %s unset", f.Name)
+		}
+	}
+
+	data, err := json.Marshal(q)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var got SearchQuery
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(got, q) {
+		t.Errorf("round trip changed the query:\n got %+v\nwant %+v", got, q)
+	}
+}
+
+func TestSearchQuery_JSONWireFormat(t *testing.T) {
+	data, err := json.Marshal(fullQuery())
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	want := `{"q":"algebra","subjects":["math","physics"],"min_price":0,"max_price":60.5,"below_price":70,` +
+		`"min_rating":4.5,"format":"online","location":"Berlin","level":["school"],"certification":"CELTA",` +
+		`"badge":"featured","trial":true,"available_within_days":7,"available_between":[{"start":1080,"end":1260}],` +
+		`"exclude_ids":[3,9],"include_bio":true,"sort":"rating","diversify_by":"location","limit":10,"offset":20}`
+	if string(data) != want {
+		t.Errorf("wire format changed:\n got %s\nwant %s", data, want)
+	}
+}
+
+func TestSearchQuery_JSONUnsetFields(t *testing.T) {
+	data, err := json.Marshal(SearchQuery{Variant: "b"})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if string(data) != `{"limit":0,"offset":0}` {
+		t.Errorf("expected only the page of an empty query, got %s", data)
+	}
+
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"absent", `{}`},
+		{"null", `{"min_price":null,"max_price":null,"below_price":null,"min_rating":null,"subjects":null}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var q SearchQuery
+			if err := json.Unmarshal([]byte(tt.body), &q); err != nil {
+				t.Fatalf("Unmarshal: %v", err)
+			}
+			if !reflect.DeepEqual(q, SearchQuery{}) {
+				t.Errorf("expected an unset query, got %+v", q)
+			}
+		})
+	}
+}
+
+func TestSearchQuery_JSONZeroPointers(t *testing.T) {
+	var q SearchQuery
+	if err := json.Unmarshal([]byte(`{"min_price":0,"min_rating":0}`), &q); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if q.MinPrice == nil || *q.MinPrice != 0 || q.MinRating == nil || *q.MinRating != 0 {
+		t.Errorf("expected zero bounds to stay set, got %v %v", q.MinPrice, q.MinRating)
+	}
+	if q.MaxPrice != nil || q.BelowPrice != nil {
+		t.Errorf("expected absent bounds to stay unset, got %v %v", q.MaxPrice, q.BelowPrice)
+	}
+}
+
+func TestSearchQuery_JSONOmitsVariant(t *testing.T) {
+	var q SearchQuery
+	if err := json.Unmarshal([]byte(`{"variant":"b","Variant":"b"}`), &q); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if q.Variant != "" {
+		t.Errorf("expected the experiment variant never to be read from JSON, got %q", q.Variant)
+	}
+}
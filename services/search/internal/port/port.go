@@ -0,0 +1,504 @@
+// Package port defines the search backend contract the rest of the service
+// depends on. internal/opensearch provides the implementations.
+package port
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"search/internal/domain"
+	"search/internal/tenant"
+)
+
+// ErrNotFound is returned when a tutor document does not exist in the index.
+var ErrNotFound = errors.New("tutor not found in index")
+
+// ErrInvalidQuery is returned when the backend rejects a raw query as
+// malformed.
+var ErrInvalidQuery = errors.New("invalid query")
+
+// ErrUnsupported is returned by backends that cannot perform an operation.
+var ErrUnsupported = errors.New("not supported by this search backend")
+
+// ErrDocumentRejected is returned when the backend refuses a tutor document
+// itself, for example because a field does not fit the index mapping.
+// Sending the same document again fails the same way.
+var ErrDocumentRejected = errors.New("tutor document rejected by search backend")
+
+// ErrOverloaded is returned when a call could not get a concurrency slot in
+// time and was not sent to the backend.
+var ErrOverloaded = errors.New("search backend concurrency limit reached")
+
+// ErrLockConflict is returned when a lock write loses to another one: the
+// lock already exists on create, or changed since it was read.
+var ErrLockConflict = errors.New("lock changed concurrently")
+
+// ErrExperimentRunning is returned by CreateExperimentIndex when an index
+// experiment is already running on the index.
+var ErrExperimentRunning = errors.New("index experiment already running")
+
+// ErrNoExperiment is returned by DeleteExperimentIndex when no index
+// experiment is running on the index.
+var ErrNoExperiment = errors.New("no index experiment running")
+
+// ErrIndexNotFound is returned by PointIndexAliases when the index to point
+// an alias at does not exist.
+var ErrIndexNotFound = errors.New("index not found")
+
+// IndexName is the index used when the context carries no tenant.
+const IndexName = "tutors"
+
+// IndexFor returns the index that operations on ctx target: the tenant's
+// index when one was resolved, IndexName otherwise.
+func IndexFor(ctx context.Context) string {
+	if t, ok := tenant.FromContext(ctx); ok {
+		return t.Index
+	}
+	return IndexName
+}
+
+type SearchClient interface {
+	Ping(ctx context.Context) error
+	EnsureIndex(ctx context.Context) error
+	UpsertTutor(ctx context.Context, tutor *domain.Tutor) error
+	// BulkUpsertTutors writes tutors as UpsertTutor would, in one bulk
+	// request. The error is for a request that failed as a whole; a tutor
+	// the backend refused only fails its own item of the result.
+	BulkUpsertTutors(ctx context.Context, tutors []*domain.Tutor) (*BulkResult, error)
+	// UpsertSnapshotTutor writes a bootstrap snapshot record unless a live
+	// write has reached the tutor's document first. It reports whether the
+	// record was applied.
+	UpsertSnapshotTutor(ctx context.Context, tutor *domain.Tutor, snapshotID string) (bool, error)
+	// BookSlot and FreeSlot keep next_available_at in step with bookings.
+	// Both return ErrNotFound when the tutor is not indexed.
+	BookSlot(ctx context.Context, tutorID int64, slot time.Time) error
+	FreeSlot(ctx context.Context, tutorID int64, slot time.Time) error
+	// UpdateBadges adds and removes badges on an indexed tutor without
+	// touching its other fields. It returns ErrNotFound when the tutor is
+	// not indexed.
+	UpdateBadges(ctx context.Context, tutorID int64, add, remove []string) error
+	// SetVerified sets an indexed tutor's is_verified without touching its
+	// other fields, stamping verified_at with at when verifying and
+	// clearing it otherwise. It returns ErrNotFound when the tutor is not
+	// indexed.
+	SetVerified(ctx context.Context, tutorID int64, verified bool, at time.Time) error
+	// SetOffersTrial sets an indexed tutor's offers_trial without touching
+	// its other fields. It returns ErrNotFound when the tutor is not
+	// indexed.
+	SetOffersTrial(ctx context.Context, tutorID int64, offers bool) error
+	DeleteTutor(ctx context.Context, id int64) error
+	BulkDeleteTutors(ctx context.Context, ids []int64) ([]BulkDeleteResult, error)
+	GetTutor(ctx context.Context, id int64) (*domain.Tutor, error)
+	// GetTutorBySlug returns the tutor whose slug is slug, or else the most
+	// recently updated one that had it before. It returns ErrNotFound when
+	// no tutor ever had it.
+	GetTutorBySlug(ctx context.Context, slug string) (*domain.Tutor, error)
+	// ChangeSlug sets an indexed tutor's slug to newSlug and records
+	// oldSlug, and the slug it replaces, in its previous_slugs without
+	// touching its other fields. It returns ErrNotFound when the tutor is
+	// not indexed.
+	ChangeSlug(ctx context.Context, tutorID int64, oldSlug, newSlug string) error
+	// GetTutors fetches the indexed tutors among ids, keyed by ID. IDs not
+	// in the index are absent from the result.
+	GetTutors(ctx context.Context, ids []int64) (map[int64]domain.Tutor, error)
+	SearchTutors(ctx context.Context, query SearchQuery) (*SearchResponse, error)
+	// SearchTutorsWithFacets is SearchTutors that also fills in the
+	// response's Facets, counted over the whole index like FacetCounts
+	// with facetSize, in the same round-trip where the backend can.
+	SearchTutorsWithFacets(ctx context.Context, query SearchQuery, facetSize int) (*SearchResponse, error)
+	// SearchTutorsMulti runs each of queries as SearchTutors does, in one
+	// round-trip where the backend can, and returns their responses in
+	// the same order. Every query runs on the index the first one does.
+	SearchTutorsMulti(ctx context.Context, queries []SearchQuery) ([]*SearchResponse, error)
+	ScanTutors(ctx context.Context, query SearchQuery, limit int, fn func(domain.Tutor) error) error
+	// TopTutorsBySubject returns up to perSubject tutors teaching each of
+	// subjects, best rated first. Every subject is a key of the result,
+	// with an empty list when nobody teaches it.
+	TopTutorsBySubject(ctx context.Context, subjects []string, perSubject int) (map[string][]domain.Tutor, error)
+	// FacetCounts returns how many tutors carry each subject key and
+	// teaching level, keeping the size most taught subjects (ties by key).
+	FacetCounts(ctx context.Context, size int) (*FacetCounts, error)
+	// PriceStats summarizes the hourly rates of tutors teaching the subject
+	// key, and with byFormat also per lesson format. Count is zero when
+	// nobody teaches it.
+	PriceStats(ctx context.Context, subject string, byFormat bool) (*PriceStats, error)
+	// RelatedSubjects counts the other subject keys taught by tutors
+	// teaching subject, keeping the size most shared.
+	RelatedSubjects(ctx context.Context, subject string, size int) (*RelatedSubjects, error)
+	// QuickSearch backs search-as-you-type: up to size tutors matching
+	// prefix and the counts of up to size subject keys starting with it.
+	QuickSearch(ctx context.Context, prefix string, size int) (*QuickSearchResult, error)
+	// CheckQuality counts the tutors in ctx's index breaking each of rules,
+	// which must be from QualityRules, and returns the IDs of up to limit
+	// of them from offset, lowest first.
+	CheckQuality(ctx context.Context, rules []string, limit, offset int) ([]QualityRuleResult, error)
+	// RawSearch returns ErrInvalidQuery for a malformed body and
+	// ErrUnsupported on backends without a query DSL.
+	RawSearch(ctx context.Context, body []byte) (json.RawMessage, error)
+	IndexedTutorIDs(ctx context.Context) ([]int64, error)
+	// ScanTutorVersions calls fn with the ID and updated_at of every tutor
+	// in ctx's index, in ascending ID order, stopping at the first error.
+	ScanTutorVersions(ctx context.Context, fn func(TutorVersion) error) error
+	// CountTutors returns how many tutors ctx's index holds.
+	CountTutors(ctx context.Context) (int64, error)
+	RecreateIndex(ctx context.Context) (*RecreateResult, error)
+	// RecomputePopularity rescores every tutor in ctx's index with the
+	// backend's current popularity weights, without rewriting other
+	// fields.
+	RecomputePopularity(ctx context.Context) (*PopularityResult, error)
+	// UpdateIndexSettings changes the replica count of ctx's live index.
+	// Backends without replicas return ErrUnsupported.
+	UpdateIndexSettings(ctx context.Context, replicas int) error
+	// CreateExperimentIndex starts an index experiment on ctx's index: a
+	// second index analyzed per mapping, filled from the live one and
+	// written alongside it, that IndexVariant searches. It returns
+	// ErrExperimentRunning when one is already running. Backends with a
+	// single index return ErrUnsupported.
+	CreateExperimentIndex(ctx context.Context, mapping ExperimentMapping) (*ExperimentIndex, error)
+	// DeleteExperimentIndex stops the writes to ctx's experiment index and
+	// deletes it. It returns ErrNoExperiment when none is running.
+	DeleteExperimentIndex(ctx context.Context) error
+	// IndexAliases returns where ctx's read and write aliases point.
+	// Backends that do not split reads from writes return ErrUnsupported.
+	IndexAliases(ctx context.Context) (*IndexAliases, error)
+	// PointIndexAliases moves ctx's read alias to read and its write alias
+	// to write, together, leaving an alias whose argument is empty where it
+	// is. It returns where they point afterwards, and ErrIndexNotFound when
+	// a target index does not exist.
+	PointIndexAliases(ctx context.Context, read, write string) (*IndexAliases, error)
+}
+
+// SearchQuery is a tutor search. Its JSON form, echoed as
+// SearchResponse.AppliedFilters, names fields after the /tutors/search
+// parameters and leaves out unset filters; it is a stable wire format, so
+// fields are only ever added. The pointer bounds tell a zero bound from an
+// unset one, and null reads as unset. Variant is never serialized.
+type SearchQuery struct {
+	Text     string   `json:"q,omitempty"`
+	Subjects []string `json:"subjects,omitempty"`
+	MinPrice *float64 `json:"min_price,omitempty"`
+	MaxPrice *float64 `json:"max_price,omitempty"`
+	// BelowPrice, if set, keeps only tutors whose hourly rate is strictly
+	// below it, unlike the inclusive MinPrice and MaxPrice.
+	BelowPrice *float64 `json:"below_price,omitempty"`
+	MinRating  *float64 `json:"min_rating,omitempty"`
+	Format     string   `json:"format,omitempty"`
+	Location   string   `json:"location,omitempty"`
+	// Levels keeps only tutors teaching at any of them, from domain.Levels.
+	Levels []string `json:"level,omitempty"`
+	// Certification keeps only tutors holding it, compared
+	// case-insensitively.
+	Certification string `json:"certification,omitempty"`
+	// Badge keeps only tutors carrying it, such as domain.BadgeFeatured.
+	Badge string `json:"badge,omitempty"`
+	// Trial keeps only tutors offering a free trial lesson.
+	Trial bool `json:"trial,omitempty"`
+	// Variant is the experiment variant serving the search. It selects the
+	// backend's relevance settings; empty uses the defaults. Responses
+	// carry it as SearchResponse.Variant.
+	Variant string `json:"-"`
+	// AvailableWithinDays, if positive, keeps only tutors with a free slot
+	// between now and that many days ahead.
+	AvailableWithinDays int `json:"available_within_days,omitempty"`
+	// AvailableBetween, if set, keeps only tutors whose working hours
+	// overlap any of these ranges of minutes of the UTC day.
+	AvailableBetween []domain.MinuteRange `json:"available_between,omitempty"`
+	// ExcludeIDs lists tutors that must not appear in the results.
+	ExcludeIDs []int64 `json:"exclude_ids,omitempty"`
+	// IncludeBio returns each tutor's full Bio; by default results carry
+	// only BioSnippet.
+	IncludeBio bool `json:"include_bio,omitempty"`
+	// Sort is SortRelevance, the default, SortRating or SortPopularity.
+	Sort string `json:"sort,omitempty"`
+	// DiversifyBy, if set, collapses the results on that field, one of
+	// DiversifyFields: each page shows one tutor per value, with up to
+	// DiversifyAlternates others sharing it in SearchResponse.Alternates.
+	DiversifyBy string `json:"diversify_by,omitempty"`
+	Limit       int    `json:"limit"`
+	Offset      int    `json:"offset"`
+}
+
+// Page size bounds applied to every search.
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
+// Page returns the limit and offset a backend applies: a missing limit is
+// DefaultLimit, a larger one MaxLimit, and a negative offset zero.
+func (q SearchQuery) Page() (limit, offset int) {
+	limit = q.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	} else if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	return limit, max(q.Offset, 0)
+}
+
+// Result orders for SearchQuery.Sort.
+const (
+	SortRelevance = ""
+	// SortRating puts the best rated tutors first, then those with more
+	// reviews, then lower IDs.
+	SortRating = "rating"
+	// SortPopularity puts the most popular tutors first, breaking ties
+	// like SortRating. See domain.PopularityWeights.
+	SortPopularity = "popularity"
+)
+
+// DiversifyLocation collapses search results on the tutor's location.
+const DiversifyLocation = "location"
+
+// DiversifyFields lists the values SearchQuery.DiversifyBy accepts. Each
+// is a single-valued keyword field; subjects, which holds several values
+// per tutor, cannot be collapsed on.
+var DiversifyFields = []string{DiversifyLocation}
+
+// DiversifyAlternates is how many collapsed tutors a diversified search
+// returns under each result.
+const DiversifyAlternates = 2
+
+type SearchResponse struct {
+	Results []domain.Tutor `json:"results"`
+	Total   int            `json:"total"`
+	// Variant echoes SearchQuery.Variant.
+	Variant string `json:"variant,omitempty"`
+	// TookMs is the backend's own search time; zero when it reports none.
+	TookMs int `json:"took_ms,omitempty"`
+	// ShardsTotal and ShardsFailed count the shards the search ran on and
+	// those that failed. Partial is set when any failed, in which case
+	// Results and Total only cover the shards that answered.
+	ShardsTotal  int  `json:"shards_total,omitempty"`
+	ShardsFailed int  `json:"shards_failed,omitempty"`
+	Partial      bool `json:"partial,omitempty"`
+	// AppliedFilters is the query as the backend applied it, see
+	// SearchQuery.Normalized. Backends leave it nil; the HTTP API fills
+	// it in.
+	AppliedFilters *SearchQuery `json:"applied_filters,omitempty"`
+	// TruncatedFields names the optional fields dropped from the results
+	// to keep the response within the HTTP API's size budget, in the
+	// order they were dropped. Backends leave it nil.
+	TruncatedFields []string `json:"truncated_fields,omitempty"`
+	// Alternates holds, for a search with DiversifyBy, the tutors
+	// collapsed under each result, keyed by the result's ID. Results
+	// without any are left out.
+	Alternates map[int64][]domain.Tutor `json:"-"`
+	// Facets holds, for SearchTutorsWithFacets, the tutors per subject
+	// and level across the whole index, whatever the query's filters.
+	Facets *FacetCounts `json:"-"`
+}
+
+// Outcomes of a bulk delete, per ID.
+const (
+	BulkDeleted  = "deleted"
+	BulkNotFound = "not_found"
+	BulkError    = "error"
+)
+
+// BulkDeleteResult is the outcome of deleting one tutor in a bulk request.
+type BulkDeleteResult struct {
+	ID     int64  `json:"id"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BulkResult is the outcome of a bulk upsert, one item per tutor in
+// request order.
+type BulkResult struct {
+	Items []BulkItem
+}
+
+// BulkItem is the outcome of writing one tutor in a bulk request.
+type BulkItem struct {
+	ID int64
+	// Err is nil when the tutor was written. It wraps ErrDocumentRejected
+	// when the backend refused the document itself.
+	Err error
+}
+
+// Failed returns the items that were not written, in request order.
+func (r *BulkResult) Failed() []BulkItem {
+	var failed []BulkItem
+	for _, item := range r.Items {
+		if item.Err != nil {
+			failed = append(failed, item)
+		}
+	}
+	return failed
+}
+
+// Bounds on the shard and replica counts of an index.
+const (
+	MaxShards   = 1024
+	MaxReplicas = 16
+)
+
+// IndexSettings are the shard and replica counts an index is created with.
+// Only Replicas can change afterwards, through UpdateIndexSettings.
+type IndexSettings struct {
+	Shards   int
+	Replicas int
+}
+
+// DefaultIndexSettings suit a single-node development cluster.
+var DefaultIndexSettings = IndexSettings{Shards: 1, Replicas: 0}
+
+// Validate checks both counts are within bounds.
+func (s IndexSettings) Validate() error {
+	if s.Shards < 1 || s.Shards > MaxShards {
+		return fmt.Errorf("shards must be between 1 and %d, got %d", MaxShards, s.Shards)
+	}
+	return ValidateReplicas(s.Replicas)
+}
+
+// ValidateReplicas checks a replica count is within bounds.
+func ValidateReplicas(n int) error {
+	if n < 0 || n > MaxReplicas {
+		return fmt.Errorf("replicas must be between 0 and %d, got %d", MaxReplicas, n)
+	}
+	return nil
+}
+
+// MaxFacetSize bounds the subjects a facet count may be asked for; the
+// catalog holds a few dozen, so only a flood of unknown subjects reaches it.
+const MaxFacetSize = 1000
+
+// FacetCounts holds how many tutors carry each value of the faceted
+// fields. Values no tutor carries are absent.
+type FacetCounts struct {
+	Subjects map[string]int
+	Levels   map[string]int
+	// Trial is how many tutors offer a free trial lesson.
+	Trial int
+}
+
+// PriceStats summarizes a set of hourly rates. Tutors without a rate are
+// left out. The other fields are zero when Count is.
+type PriceStats struct {
+	Count        int64
+	Min          float64
+	Max          float64
+	Avg          float64
+	StdDeviation float64
+	// P25 to P90 are the rates a quarter, half, three quarters and nine
+	// tenths of the tutors charge at most. OpenSearch estimates them.
+	P25, P50, P75, P90 float64
+	// ByFormat holds the stats of each lesson format, when asked for. A
+	// tutor offering several formats counts in each.
+	ByFormat map[string]*PriceStats
+}
+
+// RelatedSubjects is what the tutors teaching one subject also teach.
+type RelatedSubjects struct {
+	// Total is how many tutors teach the subject; zero when nobody does.
+	Total int
+	// Subjects are the other subject keys, most shared first, ties by
+	// key.
+	Subjects []SubjectCount
+}
+
+// SubjectCount is a subject key and how many tutors teach it.
+type SubjectCount struct {
+	Key   string
+	Count int
+}
+
+// QuickSearchResult is the outcome of a QuickSearch.
+type QuickSearchResult struct {
+	Tutors []domain.Tutor
+	// Subjects counts the tutors teaching each matching subject key.
+	Subjects map[string]int
+}
+
+// Data quality rules for CheckQuality, each naming suspicious tutor data
+// support should look at.
+const (
+	// QualityEmptyHeadline flags tutors whose headline has no words.
+	QualityEmptyHeadline = "empty_headline"
+	// QualityRatingOutOfRange flags ratings above 5 or below 0.
+	QualityRatingOutOfRange = "rating_out_of_range"
+	// QualityVerifiedZeroRate flags verified tutors without an hourly
+	// rate.
+	QualityVerifiedZeroRate = "verified_zero_rate"
+	// QualityMissingSubjects flags tutors teaching no subject.
+	QualityMissingSubjects = "missing_subjects"
+)
+
+// QualityRules lists every data quality rule in report order.
+var QualityRules = []string{
+	QualityEmptyHeadline,
+	QualityRatingOutOfRange,
+	QualityVerifiedZeroRate,
+	QualityMissingSubjects,
+}
+
+// QualityRuleResult is what CheckQuality found for one rule.
+type QualityRuleResult struct {
+	Rule string `json:"rule"`
+	// Count is how many tutors break the rule; IDs holds the requested
+	// page of them.
+	Count int64   `json:"count"`
+	IDs   []int64 `json:"ids"`
+}
+
+// RecreateResult reports document counts around a RecreateIndex call.
+type RecreateResult struct {
+	OldCount int64 `json:"old_count"`
+	NewCount int64 `json:"new_count"`
+}
+
+// PopularityResult reports a RecomputePopularity run: how many tutors were
+// rescored and how many could not be. Errors holds a sample of the
+// failures.
+type PopularityResult struct {
+	Updated int      `json:"updated"`
+	Failed  int      `json:"failed"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// Lock is a named, expiring claim stored in the search backend, so that
+// replicas can agree on which of them runs a job.
+type Lock struct {
+	Name       string    `json:"name"`
+	Holder     string    `json:"holder"`
+	AcquiredAt time.Time `json:"acquired_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	// Version identifies the stored revision; ReplaceLock and DeleteLock
+	// only succeed while it is current.
+	Version LockVersion `json:"-"`
+}
+
+// LockVersion is the revision of a stored Lock.
+type LockVersion struct {
+	SeqNo       int
+	PrimaryTerm int
+}
+
+// LockStore keeps Locks with optimistic concurrency control. Locks are
+// shared by all tenants.
+type LockStore interface {
+	// CreateLock stores l and returns it with its version, or returns
+	// ErrLockConflict if a lock with that name exists.
+	CreateLock(ctx context.Context, l Lock) (Lock, error)
+	// GetLock returns the named lock and whether it exists.
+	GetLock(ctx context.Context, name string) (Lock, bool, error)
+	// ReplaceLock overwrites the lock if it is still at l.Version and
+	// returns it with its new version. It returns ErrLockConflict if the
+	// lock changed or was deleted.
+	ReplaceLock(ctx context.Context, l Lock) (Lock, error)
+	// DeleteLock removes the lock if it is still at l.Version, and returns
+	// ErrLockConflict otherwise.
+	DeleteLock(ctx context.Context, l Lock) error
+}
+
+// ConnectionStats counts the connections requests to the search backend
+// were sent on: New ones dialed for them and Reused idle ones from the
+// pool. A high share of new connections means the pool keeps too few idle.
+type ConnectionStats struct {
+	New    uint64 `json:"new"`
+	Reused uint64 `json:"reused"`
+}
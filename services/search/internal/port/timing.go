@@ -0,0 +1,86 @@
+package port
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Timing collects how long the search backend spent on a request and how
+// often it had to retry, for the Server-Timing header. Backends write into
+// the Timing their context carries; every method is a no-op on nil, so
+// they need not check for one. It is safe for concurrent use.
+type Timing struct {
+	mu      sync.Mutex
+	took    time.Duration
+	sent    map[any]struct{}
+	retries int
+}
+
+type timingKey struct{}
+
+// NewTimingContext returns a copy of ctx carrying a fresh Timing.
+func NewTimingContext(ctx context.Context) (context.Context, *Timing) {
+	t := &Timing{}
+	return context.WithValue(ctx, timingKey{}, t), t
+}
+
+// WithoutTiming returns a copy of ctx that carries no Timing, for work
+// done on a request's behalf that its response should not account for.
+func WithoutTiming(ctx context.Context) context.Context {
+	return context.WithValue(ctx, timingKey{}, (*Timing)(nil))
+}
+
+// TimingFrom returns the Timing ctx carries, or nil.
+func TimingFrom(ctx context.Context) *Timing {
+	t, _ := ctx.Value(timingKey{}).(*Timing)
+	return t
+}
+
+// AddTook adds time the backend reports spending on a search.
+func (t *Timing) AddTook(d time.Duration) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.took += d
+}
+
+// Attempt notes one attempt at sending the backend request identified by
+// req. Attempts after the first at the same request count as retries.
+func (t *Timing) Attempt(req any) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.sent[req]; ok {
+		t.retries++
+		return
+	}
+	if t.sent == nil {
+		t.sent = make(map[any]struct{})
+	}
+	t.sent[req] = struct{}{}
+}
+
+// Took returns the backend time added so far.
+func (t *Timing) Took() time.Duration {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.took
+}
+
+// Retries returns how many backend requests were sent again.
+func (t *Timing) Retries() int {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.retries
+}
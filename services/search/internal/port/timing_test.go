@@ -0,0 +1,44 @@
+package port
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestTiming(t *testing.T) {
+	ctx, timing := NewTimingContext(context.Background())
+	if TimingFrom(ctx) != timing {
+		t.Fatal("expected the context to carry the Timing")
+	}
+
+	TimingFrom(ctx).AddTook(5 * time.Millisecond)
+	TimingFrom(ctx).AddTook(3 * time.Millisecond)
+	for _, req := range []string{"a", "b", "a", "a"} {
+		TimingFrom(ctx).Attempt(req)
+	}
+
+	if timing.Took() != 8*time.Millisecond {
+		t.Errorf("expected 8ms took, got %s", timing.Took())
+	}
+	if timing.Retries() != 2 {
+		t.Errorf("expected 2 retries, got %d", timing.Retries())
+	}
+}
+
+func TestTiming_Absent(t *testing.T) {
+	ctx, timing := NewTimingContext(context.Background())
+	detached := WithoutTiming(ctx)
+
+	// Every method is safe on the nil Timing of a context without one.
+	TimingFrom(detached).AddTook(time.Second)
+	TimingFrom(detached).Attempt("a")
+	TimingFrom(context.Background()).Attempt("a")
+
+	if TimingFrom(detached) != nil || TimingFrom(detached).Took() != 0 || TimingFrom(detached).Retries() != 0 {
+		t.Error("expected no Timing without one in the context")
+	}
+	if timing.Took() != 0 {
+		t.Errorf("expected the detached context not to reach the Timing, got %s", timing.Took())
+	}
+}
@@ -0,0 +1,193 @@
+// Package reindex resyncs the whole index from Django, on demand and on a
+// schedule.
+package reindex
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"search/internal/domain"
+	"search/internal/port"
+)
+
+// ErrRunning is returned when a reindex is requested while one is running.
+var ErrRunning = errors.New("reindex already running")
+
+// What started a run.
+const (
+	TriggerManual   = "manual"
+	TriggerSchedule = "schedule"
+)
+
+// Run outcomes.
+const (
+	StatusSucceeded = "succeeded"
+	// StatusPartial means every page was read but some tutors failed to index.
+	StatusPartial = "partial"
+	StatusFailed  = "failed"
+)
+
+// Source lists every tutor page by page; *django.Client implements it.
+type Source interface {
+	ListTutors(ctx context.Context, fn func([]domain.Tutor) error) error
+}
+
+// Run describes one reindex run.
+type Run struct {
+	Trigger    string    `json:"trigger"`
+	Status     string    `json:"status"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Synced     int       `json:"synced"`
+	Failed     int       `json:"failed"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Job copies every tutor from a Source into the index. At most one run is in
+// progress at a time.
+type Job struct {
+	source Source
+	os     port.SearchClient
+	logger *slog.Logger
+	now    func() time.Time
+	// subjects canonicalizes the subjects of every copied tutor.
+	subjects *domain.SubjectCatalog
+	// scrubber, if set, removes contact details from every copied tutor.
+	scrubber domain.Scrubber
+
+	mu      sync.Mutex
+	running bool
+	last    *Run
+}
+
+// Option configures a Job.
+type Option func(*Job)
+
+// WithClock replaces time.Now for run timestamps.
+func WithClock(now func() time.Time) Option {
+	return func(j *Job) {
+		j.now = now
+	}
+}
+
+// WithSubjectCatalog maps copied subjects to c's keys instead of the
+// embedded defaults.
+func WithSubjectCatalog(c *domain.SubjectCatalog) Option {
+	return func(j *Job) {
+		j.subjects = c
+	}
+}
+
+// WithScrubber runs the bio and headline of every copied tutor through s.
+func WithScrubber(s domain.Scrubber) Option {
+	return func(j *Job) {
+		j.scrubber = s
+	}
+}
+
+// NewJob creates a reindex job reading from source and writing to os.
+func NewJob(source Source, os port.SearchClient, logger *slog.Logger, opts ...Option) *Job {
+	j := &Job{
+		source:   source,
+		os:       os,
+		logger:   logger,
+		now:      time.Now,
+		subjects: domain.DefaultSubjectCatalog(),
+	}
+	for _, opt := range opts {
+		opt(j)
+	}
+	return j
+}
+
+// Run reindexes synchronously and returns the finished run, or ErrRunning if
+// another run is in progress.
+func (j *Job) Run(ctx context.Context, trigger string) (Run, error) {
+	if !j.acquire() {
+		return Run{}, ErrRunning
+	}
+	return j.run(ctx, trigger), nil
+}
+
+// Start reindexes in the background. It returns ErrRunning if another run is
+// in progress.
+func (j *Job) Start(ctx context.Context, trigger string) error {
+	if !j.acquire() {
+		return ErrRunning
+	}
+	go j.run(ctx, trigger)
+	return nil
+}
+
+// Status returns the last finished run, nil if there has been none, and
+// whether a run is in progress now.
+func (j *Job) Status() (last *Run, running bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.last != nil {
+		r := *j.last
+		last = &r
+	}
+	return last, j.running
+}
+
+func (j *Job) acquire() bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.running {
+		return false
+	}
+	j.running = true
+	return true
+}
+
+func (j *Job) run(ctx context.Context, trigger string) Run {
+	run := Run{Trigger: trigger, StartedAt: j.now().UTC()}
+	j.logger.Info("Reindex started", "trigger", trigger)
+
+	err := j.source.ListTutors(ctx, func(tutors []domain.Tutor) error {
+		for i := range tutors {
+			if zeroed := tutors[i].NormalizeTimes(j.now()); len(zeroed) > 0 {
+				j.logger.Warn("Zeroed implausible tutor timestamps", "id", tutors[i].ID, "fields", zeroed)
+			}
+			tutors[i].Scrub(j.scrubber)
+			tutors[i].CanonicalizeSubjects(j.subjects)
+			tutors[i].NormalizeRates()
+			tutors[i].MarkIndexed(j.now())
+			if err := j.os.UpsertTutor(ctx, &tutors[i]); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				j.logger.Error("Failed to reindex tutor", "id", tutors[i].ID, "error", err)
+				run.Failed++
+				continue
+			}
+			run.Synced++
+		}
+		return nil
+	})
+
+	run.FinishedAt = j.now().UTC()
+	switch {
+	case err != nil:
+		run.Status = StatusFailed
+		run.Error = err.Error()
+		j.logger.Error("Reindex failed", "trigger", trigger, "synced", run.Synced, "failed", run.Failed, "error", err)
+	case run.Failed > 0:
+		run.Status = StatusPartial
+		j.logger.Warn("Reindex finished with failures", "trigger", trigger, "synced", run.Synced, "failed", run.Failed)
+	default:
+		run.Status = StatusSucceeded
+		j.logger.Info("Reindex finished", "trigger", trigger, "synced", run.Synced,
+			"duration", run.FinishedAt.Sub(run.StartedAt).String())
+	}
+
+	j.mu.Lock()
+	j.last = &run
+	j.running = false
+	j.mu.Unlock()
+	return run
+}
@@ -0,0 +1,305 @@
+package reindex
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/domain"
+	"search/internal/opensearch"
+	"search/internal/schedule"
+	"search/internal/testutil"
+)
+
+// fakeSource serves pages of tutors. When block is set, each listing waits
+// for it to be closed first.
+type fakeSource struct {
+	pages   [][]domain.Tutor
+	err     error
+	block   chan struct{}
+	started chan struct{}
+	calls   atomic.Int32
+}
+
+func (s *fakeSource) ListTutors(ctx context.Context, fn func([]domain.Tutor) error) error {
+	s.calls.Add(1)
+	if s.started != nil {
+		s.started <- struct{}{}
+	}
+	if s.block != nil {
+		<-s.block
+	}
+	for _, page := range s.pages {
+		if err := fn(page); err != nil {
+			return err
+		}
+	}
+	return s.err
+}
+
+// failingClient fails to upsert the tutors in fail.
+type failingClient struct {
+	*opensearch.MemoryClient
+	fail map[int64]bool
+}
+
+func (c *failingClient) UpsertTutor(ctx context.Context, tutor *domain.Tutor) error {
+	if c.fail[tutor.ID] {
+		return errors.New("index unavailable")
+	}
+	return c.MemoryClient.UpsertTutor(ctx, tutor)
+}
+
+// fakeClock only moves when Advance is called. Each After call is announced
+// on waiting so tests know the scheduler is idle.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	timers  []fakeTimer
+	waiting chan time.Duration
+}
+
+type fakeTimer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now, waiting: make(chan time.Duration, 10)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	ch := make(chan time.Time, 1)
+	c.timers = append(c.timers, fakeTimer{at: c.now.Add(d), ch: ch})
+	c.mu.Unlock()
+	c.waiting <- d
+	return ch
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+	for _, t := range c.timers {
+		if t.at.After(c.now) {
+			pending = append(pending, t)
+			continue
+		}
+		t.ch <- c.now
+	}
+	c.timers = pending
+}
+
+func (c *fakeClock) awaitTimer(t *testing.T) time.Duration {
+	t.Helper()
+	select {
+	case d := <-c.waiting:
+		return d
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not wait for its next run")
+		return 0
+	}
+}
+
+func TestJob_Run(t *testing.T) {
+	source := &fakeSource{pages: [][]domain.Tutor{{{ID: 1}, {ID: 2}}, {{ID: 3}}}}
+	client := &failingClient{MemoryClient: opensearch.NewMemoryClient(), fail: map[int64]bool{2: true}}
+	start := time.Date(2026, 3, 10, 3, 0, 0, 0, time.UTC)
+	job := NewJob(source, client, testutil.NewLogger(t), WithClock(func() time.Time { return start }))
+
+	run, err := job.Run(context.Background(), TriggerManual)
+	require.NoError(t, err)
+
+	assert.Equal(t, Run{
+		Trigger:    TriggerManual,
+		Status:     StatusPartial,
+		StartedAt:  start,
+		FinishedAt: start,
+		Synced:     2,
+		Failed:     1,
+	}, run)
+
+	ids, _ := client.IndexedTutorIDs(context.Background())
+	assert.Equal(t, []int64{1, 3}, ids)
+
+	last, running := job.Status()
+	assert.False(t, running)
+	require.NotNil(t, last)
+	assert.Equal(t, run, *last)
+}
+
+func TestJob_RunStatuses(t *testing.T) {
+	ok := NewJob(&fakeSource{pages: [][]domain.Tutor{{{ID: 1}}}}, opensearch.NewMemoryClient(), testutil.NewLogger(t))
+	run, err := ok.Run(context.Background(), TriggerSchedule)
+	require.NoError(t, err)
+	assert.Equal(t, StatusSucceeded, run.Status)
+
+	broken := NewJob(&fakeSource{err: errors.New("django returned 503")}, opensearch.NewMemoryClient(), testutil.NewLogger(t))
+	run, err = broken.Run(context.Background(), TriggerSchedule)
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailed, run.Status)
+	assert.Equal(t, "django returned 503", run.Error)
+}
+
+func TestJob_CanonicalizesSubjects(t *testing.T) {
+	source := &fakeSource{pages: [][]domain.Tutor{{{ID: 1, Subjects: []string{"Maths", "Calculus"}}}}}
+	client := opensearch.NewMemoryClient()
+	job := NewJob(source, client, testutil.NewLogger(t))
+
+	_, err := job.Run(context.Background(), TriggerManual)
+	require.NoError(t, err)
+
+	tutor, err := client.GetTutor(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"math", "calculus"}, tutor.Subjects)
+	assert.Equal(t, []string{"Mathematics", "Calculus"}, tutor.SubjectsDisplay)
+}
+
+func TestJob_StatusBeforeFirstRun(t *testing.T) {
+	job := NewJob(&fakeSource{}, opensearch.NewMemoryClient(), testutil.NewLogger(t))
+
+	last, running := job.Status()
+	assert.Nil(t, last)
+	assert.False(t, running)
+}
+
+func TestJob_RejectsOverlappingRuns(t *testing.T) {
+	source := &fakeSource{block: make(chan struct{}), started: make(chan struct{}, 1)}
+	job := NewJob(source, opensearch.NewMemoryClient(), testutil.NewLogger(t))
+
+	require.NoError(t, job.Start(context.Background(), TriggerManual))
+	<-source.started
+
+	_, running := job.Status()
+	assert.True(t, running)
+	assert.ErrorIs(t, job.Start(context.Background(), TriggerManual), ErrRunning)
+	_, err := job.Run(context.Background(), TriggerSchedule)
+	assert.ErrorIs(t, err, ErrRunning)
+
+	close(source.block)
+	require.Eventually(t, func() bool {
+		_, running := job.Status()
+		return !running
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, int32(1), source.calls.Load())
+
+	_, err = job.Run(context.Background(), TriggerSchedule)
+	assert.NoError(t, err, "a new run may start once the previous one finished")
+}
+
+func TestScheduler_RunsOnSchedule(t *testing.T) {
+	sched, err := schedule.Parse("30 3 * * *")
+	require.NoError(t, err)
+	clock := newFakeClock(time.Date(2026, 3, 10, 2, 0, 0, 0, time.UTC))
+	source := &fakeSource{started: make(chan struct{}, 10)}
+	job := NewJob(source, opensearch.NewMemoryClient(), testutil.NewLogger(t))
+	scheduler := NewScheduler(sched, job, testutil.NewLogger(t), WithSchedulerClock(clock))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		scheduler.Run(ctx)
+		close(done)
+	}()
+
+	assert.Equal(t, 90*time.Minute, clock.awaitTimer(t))
+	clock.Advance(89 * time.Minute)
+	assert.Zero(t, source.calls.Load(), "must not run before the scheduled time")
+
+	clock.Advance(time.Minute)
+	<-source.started
+	assert.Equal(t, 24*time.Hour, clock.awaitTimer(t))
+
+	last, _ := job.Status()
+	require.NotNil(t, last)
+	assert.Equal(t, TriggerSchedule, last.Trigger)
+
+	clock.Advance(24 * time.Hour)
+	<-source.started
+	clock.awaitTimer(t)
+	assert.Equal(t, int32(2), source.calls.Load())
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop on cancel")
+	}
+}
+
+func TestScheduler_SkipsWhileRunning(t *testing.T) {
+	sched, err := schedule.Parse("@every 1h")
+	require.NoError(t, err)
+	clock := newFakeClock(time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC))
+	source := &fakeSource{block: make(chan struct{}), started: make(chan struct{}, 10)}
+	job := NewJob(source, opensearch.NewMemoryClient(), testutil.NewLogger(t))
+	scheduler := NewScheduler(sched, job, testutil.NewLogger(t), WithSchedulerClock(clock))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go scheduler.Run(ctx)
+
+	// A manual run is still going when the scheduled time arrives.
+	require.NoError(t, job.Start(context.Background(), TriggerManual))
+	<-source.started
+
+	clock.awaitTimer(t)
+	clock.Advance(time.Hour)
+	clock.awaitTimer(t)
+
+	assert.Equal(t, int32(1), source.calls.Load(), "scheduled run must be skipped")
+
+	close(source.block)
+	require.Eventually(t, func() bool {
+		_, running := job.Status()
+		return !running
+	}, time.Second, time.Millisecond)
+	last, _ := job.Status()
+	assert.Equal(t, TriggerManual, last.Trigger)
+}
+
+// fakeLeader holds the lease while held is set.
+type fakeLeader struct {
+	held atomic.Bool
+}
+
+func (l *fakeLeader) Held() bool { return l.held.Load() }
+
+func TestScheduler_SkipsWithoutLease(t *testing.T) {
+	sched, err := schedule.Parse("@every 1h")
+	require.NoError(t, err)
+	clock := newFakeClock(time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC))
+	source := &fakeSource{started: make(chan struct{}, 10)}
+	job := NewJob(source, opensearch.NewMemoryClient(), testutil.NewLogger(t))
+	leader := &fakeLeader{}
+	scheduler := NewScheduler(sched, job, testutil.NewLogger(t), WithSchedulerClock(clock), WithLeader(leader))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go scheduler.Run(ctx)
+
+	clock.awaitTimer(t)
+	clock.Advance(time.Hour)
+	clock.awaitTimer(t)
+	assert.Zero(t, source.calls.Load(), "a replica without the lease must not reindex")
+
+	leader.held.Store(true)
+	clock.Advance(time.Hour)
+	<-source.started
+	clock.awaitTimer(t)
+	assert.Equal(t, int32(1), source.calls.Load())
+}
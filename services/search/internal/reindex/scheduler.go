@@ -0,0 +1,98 @@
+package reindex
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"search/internal/schedule"
+)
+
+// Clock is the time source a Scheduler waits on.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// Leader reports whether this replica may run scheduled jobs;
+// *lease.Lease implements it.
+type Leader interface {
+	Held() bool
+}
+
+// Scheduler runs a Job at the times given by a schedule.
+type Scheduler struct {
+	schedule schedule.Schedule
+	job      *Job
+	clock    Clock
+	logger   *slog.Logger
+	// leader, if set, must be held for a scheduled run to start.
+	leader Leader
+}
+
+// SchedulerOption configures a Scheduler.
+type SchedulerOption func(*Scheduler)
+
+// WithSchedulerClock replaces the wall clock, for tests.
+func WithSchedulerClock(c Clock) SchedulerOption {
+	return func(s *Scheduler) {
+		s.clock = c
+	}
+}
+
+// WithLeader skips scheduled runs while l is not held, so that only one
+// of several replicas reindexes.
+func WithLeader(l Leader) SchedulerOption {
+	return func(s *Scheduler) {
+		s.leader = l
+	}
+}
+
+// NewScheduler creates a scheduler that runs job on sched.
+func NewScheduler(sched schedule.Schedule, job *Job, logger *slog.Logger, opts ...SchedulerOption) *Scheduler {
+	s := &Scheduler{
+		schedule: sched,
+		job:      job,
+		clock:    realClock{},
+		logger:   logger,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Run blocks until ctx is cancelled, reindexing at each scheduled time. A
+// scheduled run is skipped when another run, e.g. a manual one, is still in
+// progress, or when another replica holds the leader's lease.
+func (s *Scheduler) Run(ctx context.Context) {
+	for {
+		now := s.clock.Now()
+		next := s.schedule.Next(now)
+		if next.IsZero() {
+			s.logger.Error("Reindex schedule never fires; scheduler stopped")
+			return
+		}
+		s.logger.Info("Next scheduled reindex", "at", next.UTC().Format(time.RFC3339))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.clock.After(next.Sub(now)):
+		}
+
+		if s.leader != nil && !s.leader.Held() {
+			s.logger.Info("Skipping scheduled reindex; another replica holds the job lease")
+			continue
+		}
+		if _, err := s.job.Run(ctx, TriggerSchedule); errors.Is(err, ErrRunning) {
+			s.logger.Info("Skipping scheduled reindex; a run is already in progress")
+		}
+	}
+}
@@ -0,0 +1,241 @@
+// Package relevance holds the relevance settings searches are weighted by
+// and reloads them from a file without a restart.
+package relevance
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"search/internal/opensearch"
+)
+
+// ErrNoFile is returned by Reload when the source has no file to read.
+var ErrNoFile = errors.New("no relevance config file configured")
+
+// Parse reads a relevance config from JSON: an object overriding fields of
+// opensearch.DefaultRelevance, e.g. {"headline_boost": 3}. Unknown fields
+// are rejected so a misspelt boost cannot silently fall back to the
+// default.
+func Parse(data []byte) (opensearch.RelevanceConfig, error) {
+	rc := opensearch.DefaultRelevance
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&rc); err != nil {
+		return opensearch.RelevanceConfig{}, fmt.Errorf("invalid relevance JSON: %w", err)
+	}
+	if err := rc.Validate(); err != nil {
+		return opensearch.RelevanceConfig{}, err
+	}
+	return rc, nil
+}
+
+// Load reads a relevance config from the JSON file at path.
+func Load(path string) (opensearch.RelevanceConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return opensearch.RelevanceConfig{}, err
+	}
+	return Parse(data)
+}
+
+// Status describes the relevance settings in effect.
+type Status struct {
+	// File is the watched config file; empty when the defaults are used.
+	File string `json:"file,omitempty"`
+	// LoadedAt is when the settings in effect were loaded.
+	LoadedAt time.Time `json:"loaded_at"`
+	// Default weights searches outside any experiment variant.
+	Default opensearch.RelevanceConfig `json:"default"`
+	// Variants are the experiment variants' own settings, which the file
+	// does not change.
+	Variants map[string]opensearch.RelevanceConfig `json:"variants,omitempty"`
+	// LastError is the most recent rejected reload, cleared by the next
+	// successful one.
+	LastError   string     `json:"last_error,omitempty"`
+	LastErrorAt *time.Time `json:"last_error_at,omitempty"`
+}
+
+// Source supplies the relevance registry searches are weighted by. The
+// file, if any, replaces the settings for searches outside an experiment
+// variant; experiment variants and the pinned badge are fixed at startup.
+// A reload swaps the whole registry at once, and a file that fails to
+// parse or validate leaves the previous settings in place.
+type Source struct {
+	path        string
+	variants    opensearch.RelevanceRegistry
+	pinnedBadge string
+	logger      *slog.Logger
+	now         func() time.Time
+
+	registry atomic.Pointer[opensearch.RelevanceRegistry]
+
+	// mu serializes reloads and guards the fields below.
+	mu        sync.Mutex
+	seen      fileStamp
+	loadedAt  time.Time
+	lastErr   string
+	lastErrAt time.Time
+}
+
+// fileStamp identifies a version of the file without reading it.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+	err     string
+}
+
+// Option configures a Source.
+type Option func(*Source)
+
+// WithClock replaces time.Now for load timestamps.
+func WithClock(now func() time.Time) Option {
+	return func(s *Source) {
+		s.now = now
+	}
+}
+
+// New creates a source from the experiment variants, the pinned badge and
+// the config file at path; both may be empty. Unlike a reload, a file that
+// cannot be loaded fails New, so the service does not start on settings
+// nobody asked for.
+func New(path string, variants opensearch.RelevanceRegistry, pinnedBadge string, logger *slog.Logger, opts ...Option) (*Source, error) {
+	s := &Source{
+		path:        path,
+		variants:    variants,
+		pinnedBadge: pinnedBadge,
+		logger:      logger,
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	rc := opensearch.DefaultRelevance
+	if path != "" {
+		s.seen = stat(path)
+		var err error
+		if rc, err = Load(path); err != nil {
+			return nil, err
+		}
+	}
+	s.store(rc)
+	return s, nil
+}
+
+// Relevance returns the registry in effect, for
+// opensearch.WithRelevanceSource.
+func (s *Source) Relevance() opensearch.RelevanceRegistry {
+	return *s.registry.Load()
+}
+
+// Reload reads the file and swaps its settings in. An invalid file is
+// logged and returned as an error, and the previous settings stay.
+func (s *Source) Reload() error {
+	if s.path == "" {
+		return ErrNoFile
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.reload(stat(s.path))
+}
+
+func (s *Source) reload(stamp fileStamp) error {
+	s.seen = stamp
+	rc, err := Load(s.path)
+	if err != nil {
+		s.lastErr, s.lastErrAt = err.Error(), s.now().UTC()
+		s.logger.Error("Rejected relevance config; keeping the previous one", "file", s.path, "error", err)
+		return err
+	}
+	s.store(rc)
+	s.lastErr, s.lastErrAt = "", time.Time{}
+	s.logger.Info("Reloaded relevance config", "file", s.path, "relevance", rc)
+	return nil
+}
+
+// store builds the registry for rc and swaps it in. Callers other than New
+// hold mu.
+func (s *Source) store(rc opensearch.RelevanceConfig) {
+	registry := make(opensearch.RelevanceRegistry, len(s.variants)+1)
+	maps.Copy(registry, s.variants)
+	registry[""] = rc
+	if s.pinnedBadge != "" {
+		registry = registry.WithPinnedBadge(s.pinnedBadge)
+	}
+	s.registry.Store(&registry)
+	s.loadedAt = s.now().UTC()
+}
+
+// Status returns the settings in effect and the last rejected reload.
+func (s *Source) Status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	registry := s.Relevance()
+	st := Status{
+		File:      s.path,
+		LoadedAt:  s.loadedAt,
+		Default:   registry.For(""),
+		LastError: s.lastErr,
+	}
+	for variant, rc := range registry {
+		if variant == "" {
+			continue
+		}
+		if st.Variants == nil {
+			st.Variants = make(map[string]opensearch.RelevanceConfig, len(registry)-1)
+		}
+		st.Variants[variant] = rc
+	}
+	if s.lastErr != "" {
+		at := s.lastErrAt
+		st.LastErrorAt = &at
+	}
+	return st
+}
+
+// Watch checks the file every interval until ctx ends and reloads it when
+// its modification time or size changed. A rejected version is not retried
+// until the file changes again. It returns at once without a file.
+func (s *Source) Watch(ctx context.Context, interval time.Duration) {
+	if s.path == "" {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.check()
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *Source) check() {
+	stamp := stat(s.path)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if stamp == s.seen {
+		return
+	}
+	_ = s.reload(stamp)
+}
+
+func stat(path string) fileStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fileStamp{err: err.Error()}
+	}
+	return fileStamp{modTime: info.ModTime(), size: info.Size()}
+}
@@ -0,0 +1,217 @@
+package relevance
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/opensearch"
+	"search/internal/testutil"
+)
+
+var testLogger = testutil.DiscardLogger()
+
+func writeFile(t *testing.T, path, data string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o600))
+}
+
+func TestParse_OverridesDefaults(t *testing.T) {
+	rc, err := Parse([]byte(`{"headline_boost": 3, "fuzziness": "1"}`))
+	require.NoError(t, err)
+
+	want := opensearch.DefaultRelevance
+	want.HeadlineBoost = 3
+	want.Fuzziness = "1"
+	assert.Equal(t, want, rc)
+}
+
+func TestParse_RejectsInvalid(t *testing.T) {
+	tests := map[string]string{
+		"malformed":     `{"headline_boost": `,
+		"unknown field": `{"headline_bost": 3}`,
+		"zero boost":    `{"bio_boost": 0}`,
+		"fuzziness":     `{"fuzziness": "3"}`,
+		"pinned badge":  `{"pinned_badge": "Not A Badge"}`,
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNew_WithoutFileUsesDefaults(t *testing.T) {
+	variants := opensearch.RelevanceRegistry{"boosted": {FullNameBoost: 1, HeadlineBoost: 4, BioBoost: 1, Fuzziness: "AUTO"}}
+	s, err := New("", variants, "", testLogger)
+	require.NoError(t, err)
+
+	r := s.Relevance()
+	assert.Equal(t, opensearch.DefaultRelevance, r.For(""))
+	assert.Equal(t, variants["boosted"], r.For("boosted"))
+	assert.ErrorIs(t, s.Reload(), ErrNoFile)
+
+	st := s.Status()
+	assert.Empty(t, st.File)
+	assert.Equal(t, opensearch.DefaultRelevance, st.Default)
+	assert.Equal(t, map[string]opensearch.RelevanceConfig{"boosted": variants["boosted"]}, st.Variants)
+}
+
+func TestNew_RejectsInvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "relevance.json")
+	writeFile(t, path, `{"bio_boost": -1}`)
+
+	_, err := New(path, nil, "", testLogger)
+	assert.Error(t, err)
+
+	_, err = New(filepath.Join(t.TempDir(), "missing.json"), nil, "", testLogger)
+	assert.Error(t, err)
+}
+
+func TestReload_SwapsDefaultAndKeepsVariants(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "relevance.json")
+	writeFile(t, path, `{"headline_boost": 3}`)
+	variants := opensearch.RelevanceRegistry{"control": opensearch.DefaultRelevance}
+	s, err := New(path, variants, "verified", testLogger)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3.0, s.Relevance().For("").HeadlineBoost)
+	assert.Equal(t, "verified", s.Relevance().For("").PinnedBadge)
+
+	writeFile(t, path, `{"headline_boost": 5, "pinned_badge": "top_rated"}`)
+	require.NoError(t, s.Reload())
+
+	r := s.Relevance()
+	assert.Equal(t, 5.0, r.For("").HeadlineBoost)
+	assert.Equal(t, "top_rated", r.For("").PinnedBadge, "the file's own pinned badge wins")
+	assert.Equal(t, 2.0, r.For("control").HeadlineBoost)
+	assert.Equal(t, "verified", r.For("control").PinnedBadge)
+}
+
+func TestReload_RejectsInvalidFileAndKeepsPrevious(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "relevance.json")
+	writeFile(t, path, `{"headline_boost": 3}`)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s, err := New(path, nil, "", testLogger, WithClock(func() time.Time { return now }))
+	require.NoError(t, err)
+
+	writeFile(t, path, `{"headline_boost": "high"}`)
+	now = now.Add(time.Minute)
+	assert.Error(t, s.Reload())
+
+	assert.Equal(t, 3.0, s.Relevance().For("").HeadlineBoost)
+	st := s.Status()
+	assert.Equal(t, 3.0, st.Default.HeadlineBoost)
+	assert.Equal(t, time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), st.LoadedAt)
+	assert.Contains(t, st.LastError, "invalid relevance JSON")
+	require.NotNil(t, st.LastErrorAt)
+	assert.Equal(t, now, *st.LastErrorAt)
+
+	writeFile(t, path, `{"headline_boost": 4}`)
+	require.NoError(t, s.Reload())
+	st = s.Status()
+	assert.Equal(t, 4.0, st.Default.HeadlineBoost)
+	assert.Empty(t, st.LastError)
+	assert.Nil(t, st.LastErrorAt)
+}
+
+func TestWatch_ReloadsChangedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "relevance.json")
+	writeFile(t, path, `{"headline_boost": 3}`)
+	s, err := New(path, nil, "", testLogger)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Watch(ctx, 5*time.Millisecond)
+
+	writeFile(t, path, `{"headline_boost": 4, "bio_boost": 2}`)
+	assert.Eventually(t, func() bool {
+		return s.Relevance().For("").BioBoost == 2
+	}, time.Second, 5*time.Millisecond)
+
+	// A rejected version leaves the last good one in place.
+	writeFile(t, path, `{"headline_boost": 0}`)
+	assert.Eventually(t, func() bool {
+		return s.Status().LastError != ""
+	}, time.Second, 5*time.Millisecond)
+	assert.Equal(t, 4.0, s.Relevance().For("").HeadlineBoost)
+}
+
+// TestReload_SwapIsAtomicUnderConcurrentSearches flips the file between two
+// configs while searches run and checks that every query was built from
+// one of them, never a mix.
+func TestReload_SwapIsAtomicUnderConcurrentSearches(t *testing.T) {
+	const (
+		configA = `{"headline_boost": 3, "bio_boost": 3, "fuzziness": "1"}`
+		configB = `{"headline_boost": 5, "bio_boost": 5, "fuzziness": "2"}`
+	)
+	path := filepath.Join(t.TempDir(), "relevance.json")
+	writeFile(t, path, configA)
+	s, err := New(path, nil, "", testLogger)
+	require.NoError(t, err)
+
+	var mixed, seenA, seenB atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body := string(data)
+		isA := strings.Contains(body, `"headline^3"`) && strings.Contains(body, `"bio^3"`) && strings.Contains(body, `"fuzziness":"1"`)
+		isB := strings.Contains(body, `"headline^5"`) && strings.Contains(body, `"bio^5"`) && strings.Contains(body, `"fuzziness":"2"`)
+		switch {
+		case isA && !isB:
+			seenA.Add(1)
+		case isB && !isA:
+			seenB.Add(1)
+		default:
+			mixed.Add(1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"hits":{"total":{"value":0},"hits":[]}}`)
+	}))
+	defer server.Close()
+
+	client, err := opensearch.NewClient(server.URL, testLogger, opensearch.WithRelevanceSource(s))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var searches sync.WaitGroup
+	for range 8 {
+		searches.Add(1)
+		go func() {
+			defer searches.Done()
+			for ctx.Err() == nil {
+				if _, err := client.SearchTutors(context.Background(), opensearch.SearchQuery{Text: "algebra"}); err != nil {
+					t.Errorf("search failed: %v", err)
+					return
+				}
+			}
+		}()
+	}
+
+	for i := range 50 {
+		config := configA
+		if i%2 == 0 {
+			config = configB
+		}
+		writeFile(t, path, config)
+		require.NoError(t, s.Reload())
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	searches.Wait()
+
+	assert.Zero(t, mixed.Load(), "queries mixed settings from both configs")
+	assert.Positive(t, seenA.Load()+seenB.Load())
+}
@@ -0,0 +1,88 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func at(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestParse_Next(t *testing.T) {
+	tests := []struct {
+		spec string
+		from string
+		want string
+	}{
+		{"6h", "2026-03-10 14:07", "2026-03-10 20:07"},
+		{"@every 90m", "2026-03-10 14:07", "2026-03-10 15:37"},
+		{"@hourly", "2026-03-10 14:07", "2026-03-10 15:00"},
+		{"@daily", "2026-03-10 14:07", "2026-03-11 00:00"},
+		{"@weekly", "2026-03-10 14:07", "2026-03-15 00:00"},
+		{"@monthly", "2026-03-10 14:07", "2026-04-01 00:00"},
+		{"30 3 * * *", "2026-03-10 03:29", "2026-03-10 03:30"},
+		{"30 3 * * *", "2026-03-10 03:30", "2026-03-11 03:30"},
+		{"*/15 * * * *", "2026-03-10 14:07", "2026-03-10 14:15"},
+		{"0 9-17/4 * * *", "2026-03-10 14:07", "2026-03-10 17:00"},
+		{"0 0 * * 1-5", "2026-03-13 12:00", "2026-03-16 00:00"},
+		{"0 0 * * 7", "2026-03-10 12:00", "2026-03-15 00:00"},
+		{"0 0 31 * *", "2026-04-01 00:00", "2026-05-31 00:00"},
+		{"0 12 1,15 2 *", "2026-02-01 12:00", "2026-02-15 12:00"},
+		{"0 0 29 2 *", "2026-03-01 00:00", "2028-02-29 00:00"},
+		// With both day fields restricted, either may match.
+		{"0 0 13 * 5", "2026-03-10 00:00", "2026-03-13 00:00"},
+		{"0 0 20 * 5", "2026-03-10 00:00", "2026-03-13 00:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec+" from "+tt.from, func(t *testing.T) {
+			s, err := Parse(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, at(tt.want), s.Next(at(tt.from)))
+		})
+	}
+}
+
+func TestParse_NeverMatches(t *testing.T) {
+	s, err := Parse("0 0 30 2 *")
+	require.NoError(t, err)
+
+	assert.True(t, s.Next(at("2026-01-01 00:00")).IsZero())
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantErr string
+	}{
+		{"", "empty schedule"},
+		{"10s", "interval must be at least 1m"},
+		{"@every soon", `invalid interval "soon"`},
+		{"@yearly", "5 fields"},
+		{"0 0 * *", "5 fields"},
+		{"60 * * * *", `minute: "60" is not between 0 and 59`},
+		{"0 24 * * *", `hour: "24" is not between 0 and 23`},
+		{"0 0 0 * *", `day of month: "0" is not between 1 and 31`},
+		{"0 0 * 13 *", `month: "13" is not between 1 and 12`},
+		{"0 0 * * 8", `day of week: "8" is not between 0 and 7`},
+		{"*/0 * * * *", `minute: invalid step "0"`},
+		{"0 5-2 * * *", `hour: range "5-2" is reversed`},
+		{"0 x * * *", `hour: "x" is not between 0 and 23`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			_, err := Parse(tt.spec)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
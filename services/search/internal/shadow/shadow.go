@@ -0,0 +1,278 @@
+// Package shadow runs a candidate search strategy alongside the current
+// one on a sample of live searches and logs how their results compare,
+// while only ever serving the current strategy's results.
+package shadow
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"search/internal/port"
+)
+
+// Defaults for the options.
+const (
+	// DefaultMaxInFlight bounds the candidate searches running at once;
+	// samples beyond it are skipped rather than queued.
+	DefaultMaxInFlight = 8
+	// DefaultTimeout bounds one candidate search.
+	DefaultTimeout = 5 * time.Second
+)
+
+// compareDepth is how many top results the comparison looks at.
+const compareDepth = 10
+
+// Client serves every call from the primary client. For a sample of
+// relevance-ordered text searches outside any experiment it also runs the
+// search on the candidate in the background and logs a Comparison. The
+// candidate never delays or changes a response: it runs on its own
+// goroutine, detached from the request's cancellation, and a candidate
+// failure is only logged.
+type Client struct {
+	port.SearchClient
+	candidate port.SearchClient
+	percent   float64
+	logger    *slog.Logger
+	timeout   time.Duration
+	sample    func() float64
+	now       func() time.Time
+
+	slots   chan struct{}
+	wg      sync.WaitGroup
+	skipped atomic.Int64
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithMaxInFlight caps the candidate searches running at once.
+func WithMaxInFlight(n int) Option {
+	return func(c *Client) {
+		if n > 0 {
+			c.slots = make(chan struct{}, n)
+		}
+	}
+}
+
+// WithTimeout bounds each candidate search by d instead of DefaultTimeout.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		if d > 0 {
+			c.timeout = d
+		}
+	}
+}
+
+// WithSampler replaces the random source deciding which searches are
+// shadowed. sample returns a number in [0, 100).
+func WithSampler(sample func() float64) Option {
+	return func(c *Client) {
+		c.sample = sample
+	}
+}
+
+// WithClock replaces time.Now for measuring latencies.
+func WithClock(now func() time.Time) Option {
+	return func(c *Client) {
+		c.now = now
+	}
+}
+
+// New shadows percent (0 to 100) of eligible searches on primary with
+// candidate.
+func New(primary, candidate port.SearchClient, percent float64, logger *slog.Logger, opts ...Option) *Client {
+	c := &Client{
+		SearchClient: primary,
+		candidate:    candidate,
+		percent:      percent,
+		logger:       logger,
+		timeout:      DefaultTimeout,
+		sample:       func() float64 { return rand.Float64() * 100 },
+		now:          time.Now,
+		slots:        make(chan struct{}, DefaultMaxInFlight),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Comparison is the outcome of one shadowed search.
+type Comparison struct {
+	QueryHash string
+	// Overlap is the share of the primary's top results the candidate
+	// also returned in its top results; 1 when both found nothing.
+	Overlap float64
+	// RankCorrelation is Spearman's rho over the top results both
+	// returned, nil when fewer than two are shared.
+	RankCorrelation  *float64
+	PrimaryLatency   time.Duration
+	CandidateLatency time.Duration
+	PrimaryTotal     int
+	CandidateTotal   int
+}
+
+// primaryOutcome is what the request goroutine hands the shadow goroutine
+// once the primary search is done. The IDs are copied so the handler can
+// keep modifying its results.
+type primaryOutcome struct {
+	ids     []int64
+	total   int
+	latency time.Duration
+	err     error
+}
+
+// SearchTutors serves query from the primary and may shadow it.
+func (c *Client) SearchTutors(ctx context.Context, query port.SearchQuery) (*port.SearchResponse, error) {
+	return c.search(ctx, query, c.SearchClient.SearchTutors)
+}
+
+// SearchTutorsWithFacets serves query from the primary and may shadow it
+// with a plain SearchTutors on the candidate; facets do not depend on the
+// ranking.
+func (c *Client) SearchTutorsWithFacets(ctx context.Context, query port.SearchQuery, facetSize int) (*port.SearchResponse, error) {
+	return c.search(ctx, query, func(ctx context.Context, query port.SearchQuery) (*port.SearchResponse, error) {
+		return c.SearchClient.SearchTutorsWithFacets(ctx, query, facetSize)
+	})
+}
+
+func (c *Client) search(ctx context.Context, query port.SearchQuery, primary func(context.Context, port.SearchQuery) (*port.SearchResponse, error)) (*port.SearchResponse, error) {
+	if !c.eligible(query) || c.sample() >= c.percent {
+		return primary(ctx, query)
+	}
+	select {
+	case c.slots <- struct{}{}:
+	default:
+		if n := c.skipped.Add(1); n == 1 || n%100 == 0 {
+			c.logger.Warn("Shadow searches at capacity; skipping samples", "skipped", n)
+		}
+		return primary(ctx, query)
+	}
+
+	// Buffered so the request goroutine never waits on the shadow one.
+	done := make(chan primaryOutcome, 1)
+	// The candidate's time is not the response's.
+	shadowCtx, cancel := context.WithTimeout(port.WithoutTiming(context.WithoutCancel(ctx)), c.timeout)
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		defer func() { <-c.slots }()
+		defer cancel()
+		c.shadow(shadowCtx, query, done)
+	}()
+
+	start := c.now()
+	resp, err := primary(ctx, query)
+	outcome := primaryOutcome{latency: c.now().Sub(start), err: err}
+	if err == nil {
+		outcome.ids = topIDs(resp)
+		outcome.total = resp.Total
+	}
+	done <- outcome
+	return resp, err
+}
+
+// eligible reports whether query is ranked by relevance, so a different
+// relevance strategy could change its results.
+func (c *Client) eligible(query port.SearchQuery) bool {
+	return c.percent > 0 && query.Text != "" && query.Sort == port.SortRelevance && query.Variant == ""
+}
+
+func (c *Client) shadow(ctx context.Context, query port.SearchQuery, done <-chan primaryOutcome) {
+	start := c.now()
+	resp, err := c.candidate.SearchTutors(ctx, query)
+	latency := c.now().Sub(start)
+
+	primary := <-done
+	if primary.err != nil {
+		return
+	}
+	if err != nil {
+		c.logger.Warn("Shadow search failed", "query_hash", query.Hash(), "candidate_ms", latency.Milliseconds(), "error", err)
+		return
+	}
+
+	cmp := compare(primary.ids, topIDs(resp))
+	cmp.QueryHash = query.Hash()
+	cmp.PrimaryLatency, cmp.CandidateLatency = primary.latency, latency
+	cmp.PrimaryTotal, cmp.CandidateTotal = primary.total, resp.Total
+
+	attrs := []any{
+		"query_hash", cmp.QueryHash,
+		"overlap_at_10", cmp.Overlap,
+		"primary_ms", cmp.PrimaryLatency.Milliseconds(),
+		"candidate_ms", cmp.CandidateLatency.Milliseconds(),
+		"primary_total", cmp.PrimaryTotal,
+		"candidate_total", cmp.CandidateTotal,
+	}
+	if cmp.RankCorrelation != nil {
+		attrs = append(attrs, "rank_correlation", *cmp.RankCorrelation)
+	}
+	c.logger.Info("Shadow search compared", attrs...)
+}
+
+// Wait blocks until the shadow searches in flight have finished, for a
+// clean shutdown.
+func (c *Client) Wait() {
+	c.wg.Wait()
+}
+
+func topIDs(resp *port.SearchResponse) []int64 {
+	n := min(compareDepth, len(resp.Results))
+	ids := make([]int64, n)
+	for i := range n {
+		ids[i] = resp.Results[i].ID
+	}
+	return ids
+}
+
+// compare measures how far two top-result lists agree.
+func compare(primary, candidate []int64) Comparison {
+	if len(primary) == 0 && len(candidate) == 0 {
+		return Comparison{Overlap: 1}
+	}
+	candidateRank := make(map[int64]int, len(candidate))
+	for i, id := range candidate {
+		candidateRank[id] = i
+	}
+
+	// Ranks of the shared results within each list, in primary order.
+	var shared []int
+	for _, id := range primary {
+		if r, ok := candidateRank[id]; ok {
+			shared = append(shared, r)
+		}
+	}
+	cmp := Comparison{Overlap: float64(len(shared)) / float64(max(len(primary), len(candidate)))}
+	if n := len(shared); n >= 2 {
+		rho := spearman(shared)
+		cmp.RankCorrelation = &rho
+	}
+	return cmp
+}
+
+// spearman returns Spearman's rho between the order of candidateRanks and
+// the order of its values: 1 when the candidate ranks the shared results
+// in the same order, -1 when in reverse.
+func spearman(candidateRanks []int) float64 {
+	n := len(candidateRanks)
+	// Re-rank the candidate positions among the shared results only.
+	order := make([]int, n)
+	for i, r := range candidateRanks {
+		for _, other := range candidateRanks {
+			if other < r {
+				order[i]++
+			}
+		}
+	}
+	var d2 float64
+	for i, r := range order {
+		d := float64(i - r)
+		d2 += d * d
+	}
+	return 1 - 6*d2/float64(n*(n*n-1))
+}
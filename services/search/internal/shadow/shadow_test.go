@@ -0,0 +1,241 @@
+package shadow
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/domain"
+	"search/internal/port"
+	"search/internal/testutil"
+)
+
+// stubClient answers every search with ids, after release is closed when
+// it is set.
+type stubClient struct {
+	port.SearchClient
+
+	ids      []int64
+	err      error
+	release  chan struct{}
+	calls    atomic.Int32
+	canceled atomic.Bool
+}
+
+func (s *stubClient) SearchTutors(ctx context.Context, _ port.SearchQuery) (*port.SearchResponse, error) {
+	s.calls.Add(1)
+	if s.release != nil {
+		<-s.release
+	}
+	s.canceled.Store(ctx.Err() != nil)
+	if s.err != nil {
+		return nil, s.err
+	}
+	resp := &port.SearchResponse{Total: len(s.ids) * 10}
+	for _, id := range s.ids {
+		resp.Results = append(resp.Results, domain.Tutor{ID: id})
+	}
+	return resp, nil
+}
+
+func (s *stubClient) SearchTutorsWithFacets(ctx context.Context, query port.SearchQuery, _ int) (*port.SearchResponse, error) {
+	return s.SearchTutors(ctx, query)
+}
+
+func always() float64 { return 0 }
+
+func never() float64 { return 99.9 }
+
+func newTestClient(primary, candidate port.SearchClient, opts ...Option) (*Client, *testutil.CapturingHandler) {
+	logger, logs := testutil.NewCapturingLogger()
+	return New(primary, candidate, 10, logger, append([]Option{WithSampler(always)}, opts...)...), logs
+}
+
+func TestClient_ServesPrimaryAndLogsComparison(t *testing.T) {
+	primary := &stubClient{ids: []int64{1, 2, 3, 4}}
+	candidate := &stubClient{ids: []int64{2, 1, 3, 9}}
+	c, logs := newTestClient(primary, candidate)
+
+	query := port.SearchQuery{Text: "algebra"}
+	resp, err := c.SearchTutors(context.Background(), query)
+	require.NoError(t, err)
+	c.Wait()
+
+	require.Len(t, resp.Results, 4)
+	assert.Equal(t, int64(4), resp.Results[3].ID, "the response must come from the primary")
+	assert.Equal(t, int32(1), candidate.calls.Load())
+
+	records := logs.Find("Shadow search compared")
+	require.Len(t, records, 1)
+	attrs := records[0].Attrs
+	assert.Equal(t, query.Hash(), attrs["query_hash"])
+	assert.InDelta(t, 0.75, attrs["overlap_at_10"], 1e-9)
+	assert.InDelta(t, 0.5, attrs["rank_correlation"], 1e-9)
+	assert.Contains(t, attrs, "primary_ms")
+	assert.Contains(t, attrs, "candidate_ms")
+	assert.Equal(t, int64(40), attrs["primary_total"])
+}
+
+func TestClient_WithFacetsServesPrimary(t *testing.T) {
+	primary := &stubClient{ids: []int64{1}}
+	candidate := &stubClient{ids: []int64{1}}
+	c, logs := newTestClient(primary, candidate)
+
+	resp, err := c.SearchTutorsWithFacets(context.Background(), port.SearchQuery{Text: "algebra"}, port.MaxFacetSize)
+	require.NoError(t, err)
+	c.Wait()
+
+	assert.Equal(t, int64(1), resp.Results[0].ID)
+	assert.Len(t, logs.Find("Shadow search compared"), 1)
+}
+
+func TestClient_SlowCandidateDoesNotDelayResponse(t *testing.T) {
+	primary := &stubClient{ids: []int64{1, 2}}
+	candidate := &stubClient{ids: []int64{1, 2}, release: make(chan struct{})}
+	c, logs := newTestClient(primary, candidate)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		resp, err := c.SearchTutors(ctx, port.SearchQuery{Text: "algebra"})
+		assert.NoError(t, err)
+		assert.Len(t, resp.Results, 2)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the response waited for the candidate")
+	}
+
+	// The request is over; its context ends but the candidate carries on.
+	cancel()
+	close(candidate.release)
+	c.Wait()
+
+	assert.False(t, candidate.canceled.Load(), "the candidate must not inherit the request's cancellation")
+	assert.Len(t, logs.Find("Shadow search compared"), 1)
+}
+
+func TestClient_CandidateTimeout(t *testing.T) {
+	primary := &stubClient{ids: []int64{1}}
+	candidate := &stubClient{ids: []int64{1}, release: make(chan struct{})}
+	c, _ := newTestClient(primary, candidate, WithTimeout(10*time.Millisecond))
+
+	_, err := c.SearchTutors(context.Background(), port.SearchQuery{Text: "algebra"})
+	require.NoError(t, err)
+	time.Sleep(30 * time.Millisecond)
+	close(candidate.release)
+	c.Wait()
+
+	assert.True(t, candidate.canceled.Load(), "the candidate must be bounded by its own timeout")
+}
+
+func TestClient_CandidateFailureIsOnlyLogged(t *testing.T) {
+	primary := &stubClient{ids: []int64{1}}
+	candidate := &stubClient{err: errors.New("boom")}
+	c, logs := newTestClient(primary, candidate)
+
+	resp, err := c.SearchTutors(context.Background(), port.SearchQuery{Text: "algebra"})
+	require.NoError(t, err)
+	c.Wait()
+
+	assert.Equal(t, int64(1), resp.Results[0].ID)
+	assert.Len(t, logs.Find("Shadow search failed"), 1)
+	assert.Empty(t, logs.Find("Shadow search compared"))
+}
+
+func TestClient_PrimaryFailureSkipsComparison(t *testing.T) {
+	primary := &stubClient{err: port.ErrOverloaded}
+	candidate := &stubClient{ids: []int64{1}}
+	c, logs := newTestClient(primary, candidate)
+
+	_, err := c.SearchTutors(context.Background(), port.SearchQuery{Text: "algebra"})
+	require.ErrorIs(t, err, port.ErrOverloaded)
+	c.Wait()
+
+	assert.Empty(t, logs.Find("Shadow search compared"))
+}
+
+func TestClient_OnlyShadowsSampledRelevanceSearches(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  port.SearchQuery
+		sample func() float64
+	}{
+		{"not sampled", port.SearchQuery{Text: "algebra"}, never},
+		{"no text", port.SearchQuery{Subjects: []string{"math"}}, always},
+		{"sorted by rating", port.SearchQuery{Text: "algebra", Sort: port.SortRating}, always},
+		{"in an experiment", port.SearchQuery{Text: "algebra", Variant: "b"}, always},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primary := &stubClient{ids: []int64{1}}
+			candidate := &stubClient{ids: []int64{1}}
+			c, _ := newTestClient(primary, candidate, WithSampler(tt.sample))
+
+			_, err := c.SearchTutors(context.Background(), tt.query)
+			require.NoError(t, err)
+			c.Wait()
+
+			assert.Equal(t, int32(1), primary.calls.Load())
+			assert.Zero(t, candidate.calls.Load())
+		})
+	}
+}
+
+func TestClient_SkipsSamplesAtCapacity(t *testing.T) {
+	primary := &stubClient{ids: []int64{1}}
+	candidate := &stubClient{ids: []int64{1}, release: make(chan struct{})}
+	c, logs := newTestClient(primary, candidate, WithMaxInFlight(1))
+
+	for range 3 {
+		_, err := c.SearchTutors(context.Background(), port.SearchQuery{Text: "algebra"})
+		require.NoError(t, err)
+	}
+	close(candidate.release)
+	c.Wait()
+
+	assert.Equal(t, int32(3), primary.calls.Load())
+	assert.Equal(t, int32(1), candidate.calls.Load())
+	assert.Len(t, logs.Find("Shadow searches at capacity; skipping samples"), 1)
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name      string
+		primary   []int64
+		candidate []int64
+		overlap   float64
+		rho       *float64
+	}{
+		{"both empty", nil, nil, 1, nil},
+		{"identical", []int64{1, 2, 3}, []int64{1, 2, 3}, 1, ptr(1)},
+		{"reversed", []int64{1, 2, 3}, []int64{3, 2, 1}, 1, ptr(-1)},
+		{"disjoint", []int64{1, 2}, []int64{3, 4}, 0, nil},
+		{"one shared", []int64{1, 2}, []int64{2, 3}, 0.5, nil},
+		{"candidate found nothing", []int64{1, 2}, nil, 0, nil},
+		{"shorter primary", []int64{1, 2}, []int64{2, 5, 1, 6}, 0.5, ptr(-1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := compare(tt.primary, tt.candidate)
+			assert.InDelta(t, tt.overlap, got.Overlap, 1e-9)
+			if tt.rho == nil {
+				assert.Nil(t, got.RankCorrelation)
+			} else {
+				require.NotNil(t, got.RankCorrelation)
+				assert.InDelta(t, *tt.rho, *got.RankCorrelation, 1e-9)
+			}
+		})
+	}
+}
+
+func ptr(f float64) *float64 { return &f }
@@ -0,0 +1,156 @@
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"slices"
+	"sync"
+	"time"
+
+	"search/internal/port"
+)
+
+var _ Store = (*Memory)(nil)
+
+// Memory is an in-process Store. Its contents are lost on restart.
+type Memory struct {
+	mu       sync.Mutex
+	searches map[string][]SavedSearch
+	hidden   map[string]map[int64]struct{}
+	now      func() time.Time
+}
+
+// NewMemory returns an empty in-memory Store.
+func NewMemory() *Memory {
+	return &Memory{
+		searches: make(map[string][]SavedSearch),
+		hidden:   make(map[string]map[int64]struct{}),
+		now:      time.Now,
+	}
+}
+
+func (m *Memory) CreateSavedSearch(_ context.Context, userID string, s SavedSearch) (*SavedSearch, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.searches[userID]) >= MaxSavedSearches {
+		return nil, ErrLimitReached
+	}
+
+	s.ID = newID()
+	s.CreatedAt = m.now().UTC()
+	s.Query = cloneQuery(s.Query)
+	m.searches[userID] = append(m.searches[userID], s)
+
+	s.Query = cloneQuery(s.Query)
+	return &s, nil
+}
+
+func (m *Memory) ListSavedSearches(_ context.Context, userID string) ([]SavedSearch, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]SavedSearch, len(m.searches[userID]))
+	for i, s := range m.searches[userID] {
+		s.Query = cloneQuery(s.Query)
+		out[i] = s
+	}
+	return out, nil
+}
+
+func (m *Memory) GetSavedSearch(_ context.Context, userID, id string) (*SavedSearch, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, s := range m.searches[userID] {
+		if s.ID == id {
+			s.Query = cloneQuery(s.Query)
+			return &s, nil
+		}
+	}
+	return nil, ErrNotFound
+}
+
+func (m *Memory) DeleteSavedSearch(_ context.Context, userID, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	searches := m.searches[userID]
+	i := slices.IndexFunc(searches, func(s SavedSearch) bool { return s.ID == id })
+	if i < 0 {
+		return ErrNotFound
+	}
+	m.searches[userID] = slices.Delete(searches, i, i+1)
+	return nil
+}
+
+func (m *Memory) HideTutor(_ context.Context, userID string, tutorID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	hidden := m.hidden[userID]
+	if _, ok := hidden[tutorID]; ok {
+		return nil
+	}
+	if len(hidden) >= MaxHiddenTutors {
+		return ErrLimitReached
+	}
+	if hidden == nil {
+		hidden = make(map[int64]struct{})
+		m.hidden[userID] = hidden
+	}
+	hidden[tutorID] = struct{}{}
+	return nil
+}
+
+func (m *Memory) UnhideTutor(_ context.Context, userID string, tutorID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.hidden[userID][tutorID]; !ok {
+		return ErrNotFound
+	}
+	delete(m.hidden[userID], tutorID)
+	return nil
+}
+
+func (m *Memory) HiddenTutorIDs(_ context.Context, userID string) ([]int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ids := make([]int64, 0, len(m.hidden[userID]))
+	for id := range m.hidden[userID] {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+// cloneQuery copies the slices and pointers in q so stored values cannot be
+// mutated through a returned copy.
+func cloneQuery(q port.SearchQuery) port.SearchQuery {
+	q.Subjects = slices.Clone(q.Subjects)
+	q.Levels = slices.Clone(q.Levels)
+	q.AvailableBetween = slices.Clone(q.AvailableBetween)
+	q.ExcludeIDs = slices.Clone(q.ExcludeIDs)
+	q.MinPrice = clonePtr(q.MinPrice)
+	q.MaxPrice = clonePtr(q.MaxPrice)
+	q.BelowPrice = clonePtr(q.BelowPrice)
+	q.MinRating = clonePtr(q.MinRating)
+	return q
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+func newID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,155 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/domain"
+	"search/internal/port"
+)
+
+func TestMemory_SavedSearchLifecycle(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory()
+	maxPrice := 40.0
+
+	created, err := m.CreateSavedSearch(ctx, "1", SavedSearch{
+		Name:  "online physics",
+		Query: port.SearchQuery{Subjects: []string{"physics"}, Format: "online", MaxPrice: &maxPrice},
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.ID)
+	assert.False(t, created.CreatedAt.IsZero())
+
+	got, err := m.GetSavedSearch(ctx, "1", created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "online physics", got.Name)
+	assert.Equal(t, 40.0, *got.Query.MaxPrice)
+
+	list, err := m.ListSavedSearches(ctx, "1")
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	require.NoError(t, m.DeleteSavedSearch(ctx, "1", created.ID))
+	_, err = m.GetSavedSearch(ctx, "1", created.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, m.DeleteSavedSearch(ctx, "1", created.ID), ErrNotFound)
+}
+
+func TestMemory_SavedSearchesAreScopedToUser(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory()
+
+	created, err := m.CreateSavedSearch(ctx, "1", SavedSearch{Name: "mine"})
+	require.NoError(t, err)
+
+	_, err = m.GetSavedSearch(ctx, "2", created.ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.ErrorIs(t, m.DeleteSavedSearch(ctx, "2", created.ID), ErrNotFound)
+
+	list, err := m.ListSavedSearches(ctx, "2")
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func TestMemory_SavedSearchLimit(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory()
+
+	for i := range MaxSavedSearches {
+		_, err := m.CreateSavedSearch(ctx, "1", SavedSearch{Name: fmt.Sprintf("search %d", i)})
+		require.NoError(t, err)
+	}
+
+	_, err := m.CreateSavedSearch(ctx, "1", SavedSearch{Name: "one too many"})
+	assert.ErrorIs(t, err, ErrLimitReached)
+
+	_, err = m.CreateSavedSearch(ctx, "2", SavedSearch{Name: "other user"})
+	assert.NoError(t, err, "the limit is per user")
+}
+
+func TestMemory_ReturnedSearchesAreCopies(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory()
+
+	created, err := m.CreateSavedSearch(ctx, "1", SavedSearch{Query: port.SearchQuery{Subjects: []string{"math"}}})
+	require.NoError(t, err)
+
+	created.Query.Subjects[0] = "changed"
+	got, err := m.GetSavedSearch(ctx, "1", created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"math"}, got.Query.Subjects)
+}
+
+func TestCloneQuery_SharesNoMemory(t *testing.T) {
+	price, rating := 30.0, 4.5
+	q := port.SearchQuery{
+		Subjects:         []string{"math"},
+		MinPrice:         &price,
+		MaxPrice:         &price,
+		BelowPrice:       &price,
+		MinRating:        &rating,
+		Levels:           []string{"school"},
+		AvailableBetween: []domain.MinuteRange{{Start: 60, End: 120}},
+		ExcludeIDs:       []int64{7},
+	}
+
+	clone := cloneQuery(q)
+	assert.Equal(t, q, clone)
+
+	orig, copied := reflect.ValueOf(q), reflect.ValueOf(clone)
+	for i := range orig.NumField() {
+		name := orig.Type().Field(i).Name
+		switch f := orig.Field(i); f.Kind() {
+		case reflect.Pointer:
+			require.False(t, f.IsNil(), "populate %s", name)
+			assert.NotEqual(t, f.Pointer(), copied.Field(i).Pointer(), "%s is shared", name)
+		case reflect.Slice:
+			require.Positive(t, f.Len(), "populate %s", name)
+			assert.NotEqual(t, f.Pointer(), copied.Field(i).Pointer(), "%s is shared", name)
+		}
+	}
+}
+
+func TestMemory_HiddenTutors(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory()
+
+	require.NoError(t, m.HideTutor(ctx, "1", 30))
+	require.NoError(t, m.HideTutor(ctx, "1", 10))
+	require.NoError(t, m.HideTutor(ctx, "1", 30), "hiding twice is a no-op")
+
+	ids, err := m.HiddenTutorIDs(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{10, 30}, ids)
+
+	other, err := m.HiddenTutorIDs(ctx, "2")
+	require.NoError(t, err)
+	assert.Empty(t, other)
+
+	require.NoError(t, m.UnhideTutor(ctx, "1", 10))
+	assert.ErrorIs(t, m.UnhideTutor(ctx, "1", 10), ErrNotFound)
+	assert.ErrorIs(t, m.UnhideTutor(ctx, "2", 30), ErrNotFound)
+
+	ids, err = m.HiddenTutorIDs(ctx, "1")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{30}, ids)
+}
+
+func TestMemory_HiddenTutorLimit(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemory()
+
+	for id := range int64(MaxHiddenTutors) {
+		require.NoError(t, m.HideTutor(ctx, "1", id+1))
+	}
+
+	assert.ErrorIs(t, m.HideTutor(ctx, "1", MaxHiddenTutors+1), ErrLimitReached)
+	assert.NoError(t, m.HideTutor(ctx, "1", 1), "re-hiding at the limit is still a no-op")
+	assert.NoError(t, m.HideTutor(ctx, "2", 1), "the limit is per user")
+}
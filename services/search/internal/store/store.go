@@ -0,0 +1,55 @@
+// Package store persists per-user search preferences such as saved searches
+// and hidden tutors.
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"search/internal/port"
+)
+
+// Per-user limits.
+const (
+	// MaxSavedSearches is the number of saved searches a single user may keep.
+	MaxSavedSearches = 20
+	// MaxHiddenTutors is the number of tutors a single user may hide.
+	MaxHiddenTutors = 500
+)
+
+var (
+	// ErrNotFound is returned when the requested item does not exist for the user.
+	ErrNotFound = errors.New("not found")
+	// ErrLimitReached is returned when a user already holds the maximum number of items.
+	ErrLimitReached = errors.New("limit reached")
+)
+
+// SavedSearch is a named search a user can re-run later.
+type SavedSearch struct {
+	ID        string
+	Name      string
+	Query     port.SearchQuery
+	CreatedAt time.Time
+}
+
+// Store holds per-user data. Implementations must be safe for concurrent use
+// and must scope every operation to userID.
+type Store interface {
+	// CreateSavedSearch stores s under a newly assigned ID and returns the
+	// stored copy, or ErrLimitReached if the user has MaxSavedSearches already.
+	CreateSavedSearch(ctx context.Context, userID string, s SavedSearch) (*SavedSearch, error)
+	// ListSavedSearches returns the user's saved searches, oldest first.
+	ListSavedSearches(ctx context.Context, userID string) ([]SavedSearch, error)
+	GetSavedSearch(ctx context.Context, userID, id string) (*SavedSearch, error)
+	DeleteSavedSearch(ctx context.Context, userID, id string) error
+
+	// HideTutor excludes tutorID from the user's search results. Hiding an
+	// already hidden tutor is a no-op; a new one beyond MaxHiddenTutors
+	// returns ErrLimitReached.
+	HideTutor(ctx context.Context, userID string, tutorID int64) error
+	// UnhideTutor returns ErrNotFound if tutorID was not hidden.
+	UnhideTutor(ctx context.Context, userID string, tutorID int64) error
+	// HiddenTutorIDs returns the user's hidden tutors in ascending order.
+	HiddenTutorIDs(ctx context.Context, userID string) ([]int64, error)
+}
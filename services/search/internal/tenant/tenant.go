@@ -0,0 +1,135 @@
+// Package tenant maps marketplace regions to their OpenSearch indices and
+// carries the resolved tenant through request contexts.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultName is the only tenant when none are configured.
+const DefaultName = "default"
+
+// Tenant is one marketplace and the index holding its tutors.
+type Tenant struct {
+	Name  string `json:"name"`
+	Index string `json:"index"`
+}
+
+var (
+	namePattern  = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+	indexPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]*$`)
+)
+
+// ValidIndex reports whether index is usable as an index name: lowercase
+// letters, digits, '-', '_' or '.', not starting with a separator.
+func ValidIndex(index string) bool {
+	return indexPattern.MatchString(index)
+}
+
+// Parse reads a comma-separated list of name=index pairs, such as
+// "us=tutors-us,de=tutors-de".
+func Parse(spec string) ([]Tenant, error) {
+	var tenants []Tenant
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, index, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("%q is not name=index", part)
+		}
+		tenants = append(tenants, Tenant{Name: strings.TrimSpace(name), Index: strings.TrimSpace(index)})
+	}
+	if len(tenants) == 0 {
+		return nil, errors.New("no tenants listed")
+	}
+	return tenants, nil
+}
+
+// Registry resolves tenant names. It is immutable after construction.
+type Registry struct {
+	def    Tenant
+	all    []Tenant
+	byName map[string]Tenant
+}
+
+// NewRegistry returns a Registry over tenants. defaultName picks the tenant
+// used when a request or event names none; empty means the first one.
+func NewRegistry(tenants []Tenant, defaultName string) (*Registry, error) {
+	if len(tenants) == 0 {
+		return nil, errors.New("no tenants configured")
+	}
+
+	r := &Registry{byName: make(map[string]Tenant, len(tenants))}
+	indices := make(map[string]string, len(tenants))
+	for _, t := range tenants {
+		if !namePattern.MatchString(t.Name) {
+			return nil, fmt.Errorf("tenant name %q must be lowercase letters, digits, '-' or '_'", t.Name)
+		}
+		if !ValidIndex(t.Index) {
+			return nil, fmt.Errorf("index %q for tenant %q is not a valid index name", t.Index, t.Name)
+		}
+		if _, dup := r.byName[t.Name]; dup {
+			return nil, fmt.Errorf("tenant %q listed twice", t.Name)
+		}
+		if other, dup := indices[t.Index]; dup {
+			return nil, fmt.Errorf("tenants %q and %q share index %q", other, t.Name, t.Index)
+		}
+		r.byName[t.Name] = t
+		indices[t.Index] = t.Name
+		r.all = append(r.all, t)
+	}
+
+	if defaultName == "" {
+		defaultName = tenants[0].Name
+	}
+	def, ok := r.byName[defaultName]
+	if !ok {
+		return nil, fmt.Errorf("default tenant %q is not configured", defaultName)
+	}
+	r.def = def
+	return r, nil
+}
+
+// Single returns a Registry with one tenant, DefaultName, stored in index.
+func Single(index string) *Registry {
+	t := Tenant{Name: DefaultName, Index: index}
+	return &Registry{def: t, all: []Tenant{t}, byName: map[string]Tenant{t.Name: t}}
+}
+
+// Lookup resolves name, treating an empty name as the default tenant.
+func (r *Registry) Lookup(name string) (Tenant, bool) {
+	if name == "" {
+		return r.def, true
+	}
+	t, ok := r.byName[name]
+	return t, ok
+}
+
+// Default returns the tenant used when none is named.
+func (r *Registry) Default() Tenant {
+	return r.def
+}
+
+// All returns every tenant in configuration order.
+func (r *Registry) All() []Tenant {
+	return append([]Tenant(nil), r.all...)
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx routed to t.
+func NewContext(ctx context.Context, t Tenant) context.Context {
+	return context.WithValue(ctx, contextKey{}, t)
+}
+
+// FromContext returns the tenant stored by NewContext, if any.
+func FromContext(ctx context.Context) (Tenant, bool) {
+	t, ok := ctx.Value(contextKey{}).(Tenant)
+	return t, ok
+}
@@ -0,0 +1,86 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	got, err := Parse(" us = tutors-us , de=tutors-de,")
+	require.NoError(t, err)
+	assert.Equal(t, []Tenant{{"us", "tutors-us"}, {"de", "tutors-de"}}, got)
+
+	for _, spec := range []string{"", " , ", "us", "us:tutors-us"} {
+		_, err := Parse(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestNewRegistry(t *testing.T) {
+	r, err := NewRegistry([]Tenant{{"us", "tutors-us"}, {"de", "tutors-de"}}, "de")
+	require.NoError(t, err)
+
+	assert.Equal(t, Tenant{"de", "tutors-de"}, r.Default())
+	assert.Equal(t, []Tenant{{"us", "tutors-us"}, {"de", "tutors-de"}}, r.All())
+
+	got, ok := r.Lookup("us")
+	assert.True(t, ok)
+	assert.Equal(t, "tutors-us", got.Index)
+
+	got, ok = r.Lookup("")
+	assert.True(t, ok)
+	assert.Equal(t, "de", got.Name)
+
+	_, ok = r.Lookup("fr")
+	assert.False(t, ok)
+}
+
+func TestNewRegistry_DefaultsToFirst(t *testing.T) {
+	r, err := NewRegistry([]Tenant{{"us", "tutors-us"}, {"de", "tutors-de"}}, "")
+	require.NoError(t, err)
+	assert.Equal(t, "us", r.Default().Name)
+}
+
+func TestNewRegistry_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		tenants []Tenant
+		def     string
+	}{
+		{"empty", nil, ""},
+		{"bad name", []Tenant{{"US", "tutors-us"}}, ""},
+		{"bad index", []Tenant{{"us", "Tutors US"}}, ""},
+		{"duplicate name", []Tenant{{"us", "a"}, {"us", "b"}}, ""},
+		{"shared index", []Tenant{{"us", "tutors"}, {"de", "tutors"}}, ""},
+		{"unknown default", []Tenant{{"us", "tutors-us"}}, "de"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRegistry(tt.tenants, tt.def)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestSingle(t *testing.T) {
+	r := Single("tutors")
+	got, ok := r.Lookup("")
+	assert.True(t, ok)
+	assert.Equal(t, Tenant{DefaultName, "tutors"}, got)
+	_, ok = r.Lookup("us")
+	assert.False(t, ok)
+}
+
+func TestContext(t *testing.T) {
+	_, ok := FromContext(context.Background())
+	assert.False(t, ok)
+
+	ctx := NewContext(context.Background(), Tenant{"de", "tutors-de"})
+	got, ok := FromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "tutors-de", got.Index)
+}
@@ -0,0 +1,72 @@
+package testutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"search/internal/domain"
+	"search/internal/kafka"
+)
+
+// ProfileTime is the CreatedAt and UpdatedAt of the tutors NewTutor
+// builds: fixed, and late enough for domain.MinProfileTime.
+var ProfileTime = time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+
+// NewTutor returns a complete, valid tutor with the given ID, so tests set
+// only the fields they are about:
+//
+//	tutor := testutil.NewTutor(7)
+//	tutor.HourlyRate = 80
+func NewTutor(id int64) domain.Tutor {
+	return domain.Tutor{
+		ID:           id,
+		Slug:         fmt.Sprintf("tutor-%d", id),
+		FullName:     fmt.Sprintf("Tutor %d", id),
+		Headline:     "Math Tutor",
+		Bio:          "Experienced math teacher",
+		Subjects:     []string{"math"},
+		HourlyRate:   50,
+		Rating:       4.5,
+		ReviewsCount: 10,
+		IsVerified:   true,
+		Location:     "New York",
+		Formats:      []string{"online"},
+		CreatedAt:    ProfileTime,
+		UpdatedAt:    ProfileTime,
+	}
+}
+
+// NewEvent returns an outbox event of eventType about the tutor
+// aggregateID, carrying payload encoded as JSON. Its EventID is derived
+// from the type and aggregate, and CreatedAt is now.
+func NewEvent(t testing.TB, eventType string, aggregateID int64, payload any) kafka.Event {
+	t.Helper()
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("failed to encode %s payload: %v", eventType, err)
+	}
+	id := strconv.FormatInt(aggregateID, 10)
+	return kafka.Event{
+		EventID:       eventType + "-" + id,
+		EventType:     eventType,
+		AggregateType: "Tutor",
+		AggregateID:   id,
+		Payload:       data,
+		CreatedAt:     time.Now().UTC().Format(time.RFC3339Nano),
+	}
+}
+
+// TutorEvent returns a TutorCreated or TutorUpdated event carrying tutor.
+func TutorEvent(t testing.TB, eventType string, tutor domain.Tutor) kafka.Event {
+	t.Helper()
+	return NewEvent(t, eventType, tutor.ID, tutor)
+}
+
+// DeleteEvent returns a TutorDeleted event for the tutor id.
+func DeleteEvent(t testing.TB, id int64) kafka.Event {
+	t.Helper()
+	return NewEvent(t, "TutorDeleted", id, map[string]int64{"id": id})
+}
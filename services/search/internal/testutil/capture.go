@@ -0,0 +1,147 @@
+package testutil
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Record is a captured log record with its attributes flattened: an
+// attribute in a group is keyed "group.key", and values are resolved with
+// slog.Value.Any, so integers read as int64 and durations as
+// time.Duration.
+type Record struct {
+	Time    time.Time
+	Level   slog.Level
+	Message string
+	Attrs   map[string]any
+}
+
+// CapturingHandler is a slog.Handler keeping every record for assertions.
+// Loggers derived with With or WithGroup share its records. It is safe for
+// concurrent use.
+type CapturingHandler struct {
+	store  *recordStore
+	level  slog.Leveler
+	attrs  []slog.Attr
+	groups []string
+}
+
+type recordStore struct {
+	mu      sync.Mutex
+	records []Record
+}
+
+// NewCapturingHandler captures records at level or above; nil captures
+// every level.
+func NewCapturingHandler(level slog.Leveler) *CapturingHandler {
+	if level == nil {
+		level = slog.LevelDebug
+	}
+	return &CapturingHandler{store: &recordStore{}, level: level}
+}
+
+// NewCapturingLogger returns a logger capturing every record into the
+// returned handler.
+func NewCapturingLogger() (*slog.Logger, *CapturingHandler) {
+	h := NewCapturingHandler(nil)
+	return slog.New(h), h
+}
+
+// Enabled reports whether level is captured.
+func (h *CapturingHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle captures r.
+func (h *CapturingHandler) Handle(_ context.Context, r slog.Record) error {
+	rec := Record{Time: r.Time, Level: r.Level, Message: r.Message, Attrs: make(map[string]any)}
+	for _, a := range h.attrs {
+		flatten(rec.Attrs, "", a)
+	}
+	prefix := strings.Join(h.groups, ".")
+	if prefix != "" {
+		prefix += "."
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		flatten(rec.Attrs, prefix, a)
+		return true
+	})
+
+	h.store.mu.Lock()
+	defer h.store.mu.Unlock()
+	h.store.records = append(h.store.records, rec)
+	return nil
+}
+
+// WithAttrs returns a handler adding attrs to every record, sharing h's
+// records.
+func (h *CapturingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	c.attrs = slices.Clip(h.attrs)
+	for _, a := range attrs {
+		// Keep the group these attributes were added under.
+		if len(h.groups) > 0 {
+			a.Key = strings.Join(h.groups, ".") + "." + a.Key
+		}
+		c.attrs = append(c.attrs, a)
+	}
+	return &c
+}
+
+// WithGroup returns a handler nesting later attributes under name,
+// sharing h's records.
+func (h *CapturingHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	c := *h
+	c.groups = append(slices.Clip(h.groups), name)
+	return &c
+}
+
+// Records returns the records captured so far, oldest first.
+func (h *CapturingHandler) Records() []Record {
+	h.store.mu.Lock()
+	defer h.store.mu.Unlock()
+	return slices.Clone(h.store.records)
+}
+
+// Find returns the captured records with message msg, oldest first.
+func (h *CapturingHandler) Find(msg string) []Record {
+	var found []Record
+	for _, r := range h.Records() {
+		if r.Message == msg {
+			found = append(found, r)
+		}
+	}
+	return found
+}
+
+// Reset drops the records captured so far.
+func (h *CapturingHandler) Reset() {
+	h.store.mu.Lock()
+	defer h.store.mu.Unlock()
+	h.store.records = nil
+}
+
+func flatten(dst map[string]any, prefix string, a slog.Attr) {
+	v := a.Value.Resolve()
+	if v.Kind() == slog.KindGroup {
+		// An inline group (empty key) adds its attributes at this level.
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range v.Group() {
+			flatten(dst, prefix, ga)
+		}
+		return
+	}
+	if a.Key == "" {
+		return
+	}
+	dst[prefix+a.Key] = v.Any()
+}
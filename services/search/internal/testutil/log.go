@@ -0,0 +1,55 @@
+// Package testutil holds helpers shared by the service's tests: loggers
+// that report through the test, a handler capturing log records for
+// assertions, and builders for tutors and Kafka events.
+//
+// It imports domain and kafka, so the tests of those two packages cannot
+// use it.
+package testutil
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+)
+
+// NewLogger returns a logger writing every record, debug included, to
+// t.Log, so it shows up only for failed tests or with -v. Records logged
+// after the test has finished, by goroutines it left behind, are dropped
+// instead of panicking.
+func NewLogger(t testing.TB) *slog.Logger {
+	t.Helper()
+	w := &testWriter{t: t}
+	t.Cleanup(w.close)
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+// DiscardLogger returns a logger dropping every record, for loggers
+// created outside a test such as package-level fixtures.
+func DiscardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+// testWriter adapts t.Log to an io.Writer. slog handlers write one record
+// per call.
+type testWriter struct {
+	mu   sync.Mutex
+	t    testing.TB
+	done bool
+}
+
+func (w *testWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.done {
+		w.t.Log(string(bytes.TrimRight(p, "\n")))
+	}
+	return len(p), nil
+}
+
+func (w *testWriter) close() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.done = true
+}
@@ -0,0 +1,139 @@
+package testutil
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"search/internal/domain"
+)
+
+func TestCapturingHandler_RecordsMessageLevelAndAttrs(t *testing.T) {
+	logger, logs := NewCapturingLogger()
+
+	logger.Info("Tutor indexed", "tutor_id", 7, "took", 2*time.Millisecond, "ok", true)
+	logger.Warn("Slow search", "query", "algebra")
+
+	records := logs.Records()
+	require.Len(t, records, 2)
+	assert.Equal(t, slog.LevelInfo, records[0].Level)
+	assert.Equal(t, "Tutor indexed", records[0].Message)
+	assert.Equal(t, map[string]any{"tutor_id": int64(7), "took": 2 * time.Millisecond, "ok": true}, records[0].Attrs)
+	assert.False(t, records[0].Time.IsZero())
+	assert.Equal(t, slog.LevelWarn, records[1].Level)
+}
+
+func TestCapturingHandler_FlattensGroupsAndSharesRecords(t *testing.T) {
+	logger, logs := NewCapturingLogger()
+
+	logger.With("component", "consumer").
+		WithGroup("kafka").With("topic", "tutors").
+		Info("Consumer started", slog.Group("offsets", "first", 1), "partition", 0)
+
+	records := logs.Find("Consumer started")
+	require.Len(t, records, 1)
+	assert.Equal(t, map[string]any{
+		"component":           "consumer",
+		"kafka.topic":         "tutors",
+		"kafka.offsets.first": int64(1),
+		"kafka.partition":     int64(0),
+	}, records[0].Attrs)
+}
+
+func TestCapturingHandler_Level(t *testing.T) {
+	logs := NewCapturingHandler(slog.LevelWarn)
+	logger := slog.New(logs)
+
+	logger.Debug("debug")
+	logger.Info("info")
+	logger.Error("error")
+
+	records := logs.Records()
+	require.Len(t, records, 1)
+	assert.Equal(t, "error", records[0].Message)
+}
+
+func TestCapturingHandler_ResolvesLogValuers(t *testing.T) {
+	logger, logs := NewCapturingLogger()
+
+	logger.Info("Config loaded", "search", redacted{})
+
+	assert.Equal(t, "***", logs.Records()[0].Attrs["search"])
+}
+
+type redacted struct{}
+
+func (redacted) LogValue() slog.Value { return slog.StringValue("***") }
+
+func TestCapturingHandler_FindAndReset(t *testing.T) {
+	logger, logs := NewCapturingLogger()
+
+	logger.Info("a")
+	logger.Info("b")
+	logger.Info("a")
+	assert.Len(t, logs.Find("a"), 2)
+	assert.Empty(t, logs.Find("c"))
+
+	logs.Reset()
+	assert.Empty(t, logs.Records())
+}
+
+func TestCapturingHandler_Concurrent(t *testing.T) {
+	logger, logs := NewCapturingLogger()
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			logger.With("worker", i).InfoContext(context.Background(), "done")
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, logs.Find("done"), 50)
+}
+
+func TestNewLogger_DropsRecordsAfterTheTest(t *testing.T) {
+	var logger *slog.Logger
+	t.Run("inner", func(t *testing.T) {
+		logger = NewLogger(t)
+		logger.Info("during the test")
+	})
+	// Would panic with a bare t.Log.
+	logger.Info("after the test")
+}
+
+func TestNewTutor_IsValid(t *testing.T) {
+	tutor := NewTutor(42)
+
+	assert.Equal(t, int64(42), tutor.ID)
+	assert.NotEmpty(t, tutor.Slug)
+	assert.Empty(t, tutor.NormalizeTimes(time.Now()), "the profile times must survive normalization")
+}
+
+func TestNewEvent(t *testing.T) {
+	tutor := NewTutor(9)
+	event := TutorEvent(t, "TutorUpdated", tutor)
+
+	assert.Equal(t, "TutorUpdated", event.EventType)
+	assert.Equal(t, "Tutor", event.AggregateType)
+	assert.Equal(t, "9", event.AggregateID)
+	assert.NotEmpty(t, event.EventID)
+	_, err := time.Parse(time.RFC3339Nano, event.CreatedAt)
+	require.NoError(t, err)
+
+	var decoded domain.Tutor
+	require.NoError(t, json.Unmarshal(event.Payload, &decoded))
+	assert.Equal(t, tutor.FullName, decoded.FullName)
+
+	deleted := DeleteEvent(t, 9)
+	assert.Equal(t, "TutorDeleted", deleted.EventType)
+	assert.JSONEq(t, `{"id": 9}`, string(deleted.Payload))
+}
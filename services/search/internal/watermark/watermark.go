@@ -0,0 +1,163 @@
+// Package watermark tracks how far the index has caught up with Django:
+// the newest created_at among the events the consumer has processed.
+package watermark
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// DefaultSaveInterval is how often Run writes a changed watermark to the
+// state file.
+const DefaultSaveInterval = 5 * time.Second
+
+// Tracker holds the watermark. It only moves forward, so a redelivered or
+// out-of-order event never makes the index look older than it is. A nil
+// Tracker ignores every call.
+type Tracker struct {
+	path string
+
+	mu    sync.Mutex
+	at    time.Time
+	dirty bool
+}
+
+// Option configures a Tracker.
+type Option func(*Tracker)
+
+// WithStateFile keeps the watermark in the JSON file at path, so it
+// survives restarts. New loads it and Run and Save write it.
+func WithStateFile(path string) Option {
+	return func(t *Tracker) {
+		t.path = path
+	}
+}
+
+type state struct {
+	Watermark time.Time `json:"watermark"`
+}
+
+// New returns a Tracker, starting from the state file when one is
+// configured and exists.
+func New(opts ...Option) (*Tracker, error) {
+	t := &Tracker{}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if t.path == "" {
+		return t, nil
+	}
+
+	data, err := os.ReadFile(t.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return t, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read watermark state: %w", err)
+	}
+	var s state
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parse watermark state %s: %w", t.path, err)
+	}
+	t.at = s.Watermark.UTC()
+	return t, nil
+}
+
+// Advance moves the watermark to at if at is newer, reporting whether it
+// moved.
+func (t *Tracker) Advance(at time.Time) bool {
+	if t == nil {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !at.After(t.at) {
+		return false
+	}
+	t.at = at.UTC()
+	t.dirty = true
+	return true
+}
+
+// Watermark returns the watermark, and false if no event has been
+// processed yet.
+func (t *Tracker) Watermark() (time.Time, bool) {
+	if t == nil {
+		return time.Time{}, false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.at, !t.at.IsZero()
+}
+
+// Save writes the watermark to the state file if it moved since the last
+// save. The file is replaced atomically. Without a state file it does
+// nothing.
+func (t *Tracker) Save() error {
+	if t == nil || t.path == "" {
+		return nil
+	}
+	t.mu.Lock()
+	at, dirty := t.at, t.dirty
+	t.dirty = false
+	t.mu.Unlock()
+	if !dirty {
+		return nil
+	}
+
+	if err := t.write(at); err != nil {
+		t.mu.Lock()
+		t.dirty = true
+		t.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (t *Tracker) write(at time.Time) error {
+	data, err := json.Marshal(state{Watermark: at})
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(t.path), filepath.Base(t.path)+".*")
+	if err != nil {
+		return fmt.Errorf("write watermark state: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write watermark state: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write watermark state: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), t.path); err != nil {
+		return fmt.Errorf("write watermark state: %w", err)
+	}
+	return nil
+}
+
+// Run saves the watermark every interval until ctx ends. Call Save once
+// more after the consumer has stopped to keep the final position.
+func (t *Tracker) Run(ctx context.Context, interval time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := t.Save(); err != nil {
+				logger.Warn("Failed to save watermark", "error", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
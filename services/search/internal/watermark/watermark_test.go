@@ -0,0 +1,79 @@
+package watermark
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracker_OnlyMovesForward(t *testing.T) {
+	tracker, err := New()
+	require.NoError(t, err)
+
+	_, ok := tracker.Watermark()
+	assert.False(t, ok, "no watermark before the first event")
+
+	t1 := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	assert.True(t, tracker.Advance(t1))
+	assert.False(t, tracker.Advance(t1.Add(-time.Minute)), "an older event must not move it back")
+	assert.False(t, tracker.Advance(t1), "an equal event does not move it")
+
+	at, ok := tracker.Watermark()
+	require.True(t, ok)
+	assert.Equal(t, t1, at)
+
+	t2 := t1.Add(time.Second)
+	assert.True(t, tracker.Advance(t2.In(time.FixedZone("CEST", 2*3600))))
+	at, _ = tracker.Watermark()
+	assert.Equal(t, t2, at)
+	assert.Equal(t, time.UTC, at.Location())
+}
+
+func TestTracker_Nil(t *testing.T) {
+	var tracker *Tracker
+	assert.False(t, tracker.Advance(time.Now()))
+	_, ok := tracker.Watermark()
+	assert.False(t, ok)
+	assert.NoError(t, tracker.Save())
+}
+
+func TestTracker_StateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watermark.json")
+
+	tracker, err := New(WithStateFile(path))
+	require.NoError(t, err, "a missing state file starts empty")
+	_, ok := tracker.Watermark()
+	assert.False(t, ok)
+
+	require.NoError(t, tracker.Save())
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "nothing is written before the watermark moves")
+
+	at := time.Date(2026, 5, 1, 12, 0, 0, 123000000, time.UTC)
+	tracker.Advance(at)
+	require.NoError(t, tracker.Save())
+
+	restarted, err := New(WithStateFile(path))
+	require.NoError(t, err)
+	got, ok := restarted.Watermark()
+	require.True(t, ok)
+	assert.Equal(t, at, got)
+
+	assert.False(t, restarted.Advance(at.Add(-time.Hour)), "a restart keeps the floor")
+
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary files are cleaned up")
+}
+
+func TestNew_CorruptStateFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "watermark.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+	_, err := New(WithStateFile(path))
+	assert.ErrorContains(t, err, "parse watermark state")
+}